@@ -9,8 +9,11 @@ import (
 	"time"
 
 	adapter "github.com/eyo-chen/expense-tracker-go/internal/adapter"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/service/mailer"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/service/s3"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler"
+	"github.com/eyo-chen/expense-tracker-go/internal/middleware"
 	"github.com/eyo-chen/expense-tracker-go/internal/router"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
@@ -63,10 +66,11 @@ func main() {
 	s3Client, presignClient := s3.NewS3Clients(os.Getenv("AWS_REGION"), os.Getenv("AWS_KEY"), os.Getenv("AWS_SECRET"))
 
 	// Setup adapter, usecase, and handler
-	adapter := adapter.New(mysqlDB, redisClient, s3Client, presignClient, os.Getenv("AWS_BUCKET"), os.Getenv("STOCK_SERVICE_URL"))
-	usecase := usecase.New(adapter.User, adapter.MainCateg, adapter.SubCateg, adapter.Icon, adapter.Transaction, adapter.MonthlyTrans, adapter.RedisService, adapter.UserIcon, adapter.S3Service, adapter.StockService, adapter.HistoricalPortfolioService)
-	handler := handler.New(usecase.User, usecase.MainCateg, usecase.SubCateg, usecase.Transaction, usecase.Icon, usecase.UserIcon, usecase.InitData, usecase.Stock, usecase.HistoricalPortfolio)
-	if err := initServe(handler); err != nil {
+	adapter := adapter.New(mysqlDB, redisClient, s3Client, presignClient, os.Getenv("AWS_BUCKET"), os.Getenv("STOCK_SERVICE_URL"), newMailClient(), os.Getenv("MAIL_FROM"))
+	usecase := usecase.New(adapter.User, adapter.MainCateg, adapter.SubCateg, adapter.Icon, adapter.Transaction, adapter.MonthlyTrans, adapter.RedisService, adapter.UserIcon, adapter.S3Service, adapter.StockService, adapter.HistoricalPortfolioService, adapter.RecurringTrans, adapter.Budget, adapter.ExchangeRate, adapter.Account, adapter.Tag, adapter.Trash, adapter.TransRevision, adapter.Attachment, adapter.Rule, adapter.View, adapter.Ledger, adapter.Contact, adapter.Share, adapter.Settlement, adapter.MailService, adapter.Session, adapter.APIKey)
+	handler := handler.New(usecase.User, usecase.MainCateg, usecase.SubCateg, usecase.Transaction, usecase.Icon, usecase.UserIcon, usecase.InitData, usecase.Stock, usecase.HistoricalPortfolio, usecase.RecurringTrans, usecase.Budget, usecase.ImportTrans, usecase.ExchangeRate, usecase.Account, usecase.Tag, usecase.Trash, usecase.Rule, usecase.View, usecase.Ledger, usecase.Contact, usecase.Share, usecase.Settlement, usecase.APIKey)
+	mw := middleware.New(usecase.Ledger, usecase.User, usecase.APIKey)
+	if err := initServe(handler, mw); err != nil {
 		logger.Fatal("Unable to start server", "error", err)
 	}
 }
@@ -104,7 +108,7 @@ func newMysqlDB() (*sql.DB, error) {
 	return db, nil
 }
 
-func initServe(handler *handler.Handler) error {
+func initServe(handler *handler.Handler, mw *middleware.Middleware) error {
 	port := fmt.Sprintf(":%s", os.Getenv("PORT"))
 	if port == "" {
 		port = fmt.Sprintf(":%d", 8000)
@@ -112,7 +116,7 @@ func initServe(handler *handler.Handler) error {
 
 	srv := &http.Server{
 		Addr:         port,
-		Handler:      router.New(handler),
+		Handler:      router.New(handler, mw),
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
@@ -185,3 +189,14 @@ func newRedisClient() (*redis.Client, error) {
 
 	return client, nil
 }
+
+// newMailClient returns the SMTP client when the SMTP host is set,
+// otherwise the mails are written to the file for local development
+func newMailClient() interfaces.MailClient {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return mailer.NewFileClient(os.Getenv("MAIL_FILE_PATH"))
+	}
+
+	return mailer.NewSMTPClient(host, os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"))
+}
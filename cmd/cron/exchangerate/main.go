@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	adapter "github.com/eyo-chen/expense-tracker-go/internal/adapter"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/exchangerate"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// importEvent is the payload of invoking the job, the rates are shared by all users,
+// so they're only imported by the operator, with this job or the import route guarded by the admin key
+type importEvent struct {
+	Rates []exchangeRate `json:"rates"`
+}
+
+type exchangeRate struct {
+	From string  `json:"from"`
+	To   string  `json:"to"`
+	Date string  `json:"date"`
+	Rate float64 `json:"rate"`
+}
+
+func main() {
+	lambda.Start(handleRequest)
+}
+
+func handleRequest(ctx context.Context, event importEvent) error {
+	logger.Register()
+
+	rates := cvtToDomainExchangeRates(event.Rates)
+	v := validator.New()
+	if !v.ImportExchangeRates(rates) {
+		logger.Error("Invalid exchange rates", "error", v.Error)
+		return errors.New("invalid exchange rates")
+	}
+
+	logger.Info("Connecting to database...")
+	mysqlDB, err := newMysqlDB()
+	if err != nil {
+		logger.Error("Unable to connect to mysql database", "error", err)
+		return err
+	}
+	defer func() {
+		if err := mysqlDB.Close(); err != nil {
+			logger.Error("Unable to close mysql database", "error", err)
+		}
+	}()
+
+	// Setup adapter and usecase
+	adapter := adapter.New(mysqlDB, nil, nil, nil, "", "", nil, "")
+	exchangeRateUC := exchangerate.New(adapter.ExchangeRate)
+
+	if err := exchangeRateUC.Import(ctx, rates); err != nil {
+		logger.Error("Failed to import exchange rates", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully imported exchange rates", "count", len(rates))
+	return nil
+}
+
+// cvtToDomainExchangeRates converts the event to domain exchange rates.
+// The date is left zero when it's not in YYYY-MM-DD format, so that the validator reports it.
+func cvtToDomainExchangeRates(rates []exchangeRate) []domain.ExchangeRate {
+	result := make([]domain.ExchangeRate, 0, len(rates))
+
+	for _, r := range rates {
+		date, _ := time.Parse(time.DateOnly, r.Date)
+		result = append(result, domain.ExchangeRate{
+			From: strings.ToUpper(r.From),
+			To:   strings.ToUpper(r.To),
+			Date: date,
+			Rate: r.Rate,
+		})
+	}
+
+	return result
+}
+
+func newMysqlDB() (*sql.DB, error) {
+	config := map[string]string{
+		"host":     os.Getenv("DB_HOST"),
+		"port":     os.Getenv("DB_PORT"),
+		"name":     os.Getenv("DB_NAME"),
+		"user":     os.Getenv("DB_USER"),
+		"password": os.Getenv("DB_PASSWORD"),
+	}
+
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", config["user"], config["password"], config["host"], config["port"], config["name"])
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
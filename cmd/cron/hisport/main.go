@@ -19,8 +19,8 @@ func main() {
 	initEnv()
 
 	// Setup adapter, usecase, and handler
-	adapter := adapter.New(nil, nil, nil, nil, "", os.Getenv("STOCK_SERVICE_URL"))
-	usecase := usecase.New(adapter.User, adapter.MainCateg, adapter.SubCateg, adapter.Icon, adapter.Transaction, adapter.MonthlyTrans, adapter.RedisService, adapter.UserIcon, adapter.S3Service, adapter.StockService, adapter.HistoricalPortfolioService)
+	adapter := adapter.New(nil, nil, nil, nil, "", os.Getenv("STOCK_SERVICE_URL"), nil, "")
+	usecase := usecase.New(adapter.User, adapter.MainCateg, adapter.SubCateg, adapter.Icon, adapter.Transaction, adapter.MonthlyTrans, adapter.RedisService, adapter.UserIcon, adapter.S3Service, adapter.StockService, adapter.HistoricalPortfolioService, adapter.RecurringTrans, adapter.Budget, adapter.ExchangeRate, adapter.Account, adapter.Tag, adapter.Trash, adapter.TransRevision, adapter.Attachment, adapter.Rule, adapter.View, adapter.Ledger, adapter.Contact, adapter.Share, adapter.Settlement, adapter.MailService, adapter.Session, adapter.APIKey)
 
 	userID := 11100
 
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	adapter "github.com/eyo-chen/expense-tracker-go/internal/adapter"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/service/s3"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/trash"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	lambda.Start(handleRequest)
+}
+
+func handleRequest(ctx context.Context) error {
+	logger.Register()
+
+	logger.Info("Connecting to database...")
+	mysqlDB, err := newMysqlDB()
+	if err != nil {
+		logger.Error("Unable to connect to mysql database", "error", err)
+		return err
+	}
+	defer func() {
+		if err := mysqlDB.Close(); err != nil {
+			logger.Error("Unable to close mysql database", "error", err)
+		}
+	}()
+
+	// S3 is used to delete the files of the purged attachments
+	s3Client, presignClient := s3.NewS3Clients(os.Getenv("AWS_REGION"), os.Getenv("AWS_KEY"), os.Getenv("AWS_SECRET"))
+
+	// Setup adapter and usecase
+	adapter := adapter.New(mysqlDB, nil, s3Client, presignClient, os.Getenv("AWS_BUCKET"), "", nil, "")
+	trashUC := trash.New(adapter.Trash, adapter.Attachment, adapter.S3Service)
+
+	// Permanently delete the items which have been in trash longer than the retention period
+	now := time.Now()
+	if err := trashUC.Purge(ctx, now); err != nil {
+		logger.Error("Failed to purge trash", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully purged trash", "before", now.Add(-domain.TrashRetention).Format(time.DateOnly))
+	return nil
+}
+
+func newMysqlDB() (*sql.DB, error) {
+	config := map[string]string{
+		"host":     os.Getenv("DB_HOST"),
+		"port":     os.Getenv("DB_PORT"),
+		"name":     os.Getenv("DB_NAME"),
+		"user":     os.Getenv("DB_USER"),
+		"password": os.Getenv("DB_PASSWORD"),
+	}
+
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", config["user"], config["password"], config["host"], config["port"], config["name"])
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	adapter "github.com/eyo-chen/expense-tracker-go/internal/adapter"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/recurringtrans"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/transaction"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	lambda.Start(handleRequest)
+}
+
+func handleRequest(ctx context.Context) error {
+	logger.Register()
+
+	logger.Info("Connecting to database...")
+	mysqlDB, err := newMysqlDB()
+	if err != nil {
+		logger.Error("Unable to connect to mysql database", "error", err)
+		return err
+	}
+	defer func() {
+		if err := mysqlDB.Close(); err != nil {
+			logger.Error("Unable to close mysql database", "error", err)
+		}
+	}()
+
+	// Setup adapter and usecase
+	adapter := adapter.New(mysqlDB, nil, nil, nil, "", "", nil, "")
+	transactionUC := transaction.New(adapter.Transaction, adapter.MainCateg, adapter.SubCateg, adapter.MonthlyTrans, adapter.RedisService, adapter.S3Service, adapter.Account, adapter.Tag, adapter.TransRevision, adapter.Attachment, adapter.Rule, adapter.View)
+	recurringTransUC := recurringtrans.New(adapter.RecurringTrans, adapter.MainCateg, adapter.SubCateg, transactionUC)
+
+	// Materialize all recurring transactions due today, including the ones missed by previous runs
+	today := time.Now()
+	if err := recurringTransUC.Materialize(ctx, today); err != nil {
+		logger.Error("Failed to materialize recurring transactions", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully materialized recurring transactions", "date", today.Format(time.DateOnly))
+	return nil
+}
+
+func newMysqlDB() (*sql.DB, error) {
+	config := map[string]string{
+		"host":     os.Getenv("DB_HOST"),
+		"port":     os.Getenv("DB_PORT"),
+		"name":     os.Getenv("DB_NAME"),
+		"user":     os.Getenv("DB_USER"),
+		"password": os.Getenv("DB_PASSWORD"),
+	}
+
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", config["user"], config["password"], config["host"], config["port"], config["name"])
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -4,14 +4,31 @@ import (
 	"database/sql"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/account"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/apikey"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/attachment"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/budget"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/contact"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/exchangerate"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/icon"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/ledger"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/maincateg"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/monthlytrans"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/recurringtrans"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/rule"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/session"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/settlement"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/share"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/subcateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/tag"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/transaction"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/transrevision"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/trash"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/user"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/usericon"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/view"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/service/hisport"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/service/mailer"
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/service/mq"
 	redisservice "github.com/eyo-chen/expense-tracker-go/internal/adapter/service/redis"
 	s3service "github.com/eyo-chen/expense-tracker-go/internal/adapter/service/s3"
@@ -29,7 +46,24 @@ type Adapter struct {
 	UserIcon                   *usericon.Repo
 	S3Service                  *s3service.Service
 	MonthlyTrans               *monthlytrans.Repo
+	RecurringTrans             *recurringtrans.Repo
+	Budget                     *budget.Repo
+	ExchangeRate               *exchangerate.Repo
+	Account                    *account.Repo
+	Tag                        *tag.Repo
+	Trash                      *trash.Repo
+	TransRevision              *transrevision.Repo
+	Attachment                 *attachment.Repo
+	Rule                       *rule.Repo
+	View                       *view.Repo
+	Ledger                     *ledger.Repo
+	Contact                    *contact.Repo
+	Share                      *share.Repo
+	Settlement                 *settlement.Repo
+	Session                    *session.Repo
+	APIKey                     *apikey.Repo
 	MQService                  *mq.Service
+	MailService                *mailer.Service
 	StockService               *stock.Service
 	HistoricalPortfolioService *hisport.Service
 }
@@ -40,6 +74,8 @@ func New(mysqlDB *sql.DB,
 	presignClient interfaces.S3PresignClient,
 	bucket string,
 	gRPCAddr string,
+	mailClient interfaces.MailClient,
+	mailFrom string,
 ) *Adapter {
 	return &Adapter{
 		User:                       user.New(mysqlDB),
@@ -51,6 +87,23 @@ func New(mysqlDB *sql.DB,
 		UserIcon:                   usericon.New(mysqlDB),
 		S3Service:                  s3service.New(bucket, s3Client, presignClient),
 		MonthlyTrans:               monthlytrans.New(mysqlDB),
+		RecurringTrans:             recurringtrans.New(mysqlDB),
+		Budget:                     budget.New(mysqlDB),
+		ExchangeRate:               exchangerate.New(mysqlDB),
+		Account:                    account.New(mysqlDB),
+		Tag:                        tag.New(mysqlDB),
+		Trash:                      trash.New(mysqlDB),
+		TransRevision:              transrevision.New(mysqlDB),
+		Attachment:                 attachment.New(mysqlDB),
+		Rule:                       rule.New(mysqlDB),
+		View:                       view.New(mysqlDB),
+		Ledger:                     ledger.New(mysqlDB),
+		Contact:                    contact.New(mysqlDB),
+		Share:                      share.New(mysqlDB),
+		Settlement:                 settlement.New(mysqlDB),
+		Session:                    session.New(mysqlDB),
+		APIKey:                     apikey.New(mysqlDB),
+		MailService:                mailer.New(mailFrom, mailClient),
 		StockService:               stock.NewService(gRPCAddr),
 		HistoricalPortfolioService: hisport.NewService(gRPCAddr),
 	}
@@ -37,3 +37,9 @@ type MQClient interface {
 	// Close closes the message queue client.
 	Close() error
 }
+
+// MailClient is the interface that wraps the basic methods for mail client.
+type MailClient interface {
+	// SendMail sends the raw message from the sender to the recipients.
+	SendMail(ctx context.Context, from string, to []string, msg []byte) error
+}
@@ -0,0 +1,124 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errorutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	uniqueNameUser = "accounts.unique_name_user"
+	packageName    = "adapter/repository/account"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+type Account struct {
+	ID             int64
+	UserID         int64 `gofacto:"foreignKey,struct:User"`
+	Name           string
+	Type           string
+	InitialBalance float64
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Create(ctx context.Context, account domain.Account, userID int64) error {
+	qStmt := "INSERT INTO accounts (user_id, name, type, initial_balance) VALUES (?, ?, ?, ?)"
+
+	a := cvtToModelAccount(account, userID)
+	if _, err := r.DB.ExecContext(ctx, qStmt, a.UserID, a.Name, a.Type, a.InitialBalance); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueNameUser
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.Account, error) {
+	qStmt := `SELECT id, name, type, initial_balance
+						FROM accounts
+						WHERE user_id = ?
+						ORDER BY id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var accounts []domain.Account
+	for rows.Next() {
+		var a Account
+		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.InitialBalance); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		accounts = append(accounts, cvtToDomainAccount(a))
+	}
+
+	return accounts, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Account, error) {
+	qStmt := `SELECT id, name, type, initial_balance
+						FROM accounts
+						WHERE id = ? AND user_id = ?`
+
+	var a Account
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).Scan(&a.ID, &a.Name, &a.Type, &a.InitialBalance); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Account{}, domain.ErrAccountNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.Account{}, err
+	}
+
+	return cvtToDomainAccount(a), nil
+}
+
+func (r *Repo) Update(ctx context.Context, account domain.Account) error {
+	qStmt := "UPDATE accounts SET name = ?, type = ?, initial_balance = ? WHERE id = ?"
+
+	a := cvtToModelAccount(account, 0)
+	if _, err := r.DB.ExecContext(ctx, qStmt, a.Name, a.Type, a.InitialBalance, a.ID); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueNameUser
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM accounts WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
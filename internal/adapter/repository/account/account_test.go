@@ -0,0 +1,225 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type AccountSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestAccountSuite(t *testing.T) {
+	suite.Run(t, new(AccountSuite))
+}
+
+func (s *AccountSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *AccountSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *AccountSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *AccountSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	_, err = tx.Exec("DELETE FROM accounts")
+	s.Require().NoError(err)
+
+	_, err = tx.Exec("DELETE FROM users")
+	s.Require().NoError(err)
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *AccountSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *AccountSuite, desc string){
+		"when no duplicate name, insert account":  create_NoDuplicateName_InsertAccount,
+		"when duplicate name, return error":       create_DuplicateName_ReturnError,
+		"when same name of other user, insert it": create_SameNameOfOtherUser_InsertAccount,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoDuplicateName_InsertAccount(s *AccountSuite, desc string) {
+	user, _, err := s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	account := domain.Account{Name: "wallet", Type: domain.AccountTypeCash, InitialBalance: 100}
+	err = s.repo.Create(mockCTX, account, user.ID)
+	s.Require().NoError(err, desc)
+
+	var a Account
+	err = s.db.QueryRow("SELECT name, type, initial_balance FROM accounts WHERE user_id = ? AND name = ?", user.ID, "wallet").
+		Scan(&a.Name, &a.Type, &a.InitialBalance)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.AccountTypeCash.ToModelValue(), a.Type, desc)
+	s.Require().Equal(float64(100), a.InitialBalance, desc)
+}
+
+func create_DuplicateName_ReturnError(s *AccountSuite, desc string) {
+	user, accounts, err := s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	account := domain.Account{Name: accounts[0].Name, Type: domain.AccountTypeBank}
+	err = s.repo.Create(mockCTX, account, user.ID)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUser, desc)
+}
+
+func create_SameNameOfOtherUser_InsertAccount(s *AccountSuite, desc string) {
+	_, accounts, err := s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	user2, _, err := s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	account := domain.Account{Name: accounts[0].Name, Type: domain.AccountTypeBank}
+	err = s.repo.Create(mockCTX, account, user2.ID)
+	s.Require().NoError(err, desc)
+}
+
+func (s *AccountSuite) TestGetAll() {
+	user, accounts, err := s.f.InsertAccountsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err)
+
+	// account of another user
+	_, _, err = s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err)
+
+	expResult := []domain.Account{
+		cvtToDomainAccount(accounts[0]),
+		cvtToDomainAccount(accounts[1]),
+	}
+
+	result, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(expResult, result)
+
+	s.TearDownTest()
+}
+
+func (s *AccountSuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *AccountSuite, desc string){
+		"when data exists, return data":         getByIDAndUserID_DataExists_ReturnData,
+		"when user not match, return not found": getByIDAndUserID_UserNotMatch_ReturnNotFound,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_DataExists_ReturnData(s *AccountSuite, desc string) {
+	user, accounts, err := s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndUserID(mockCTX, accounts[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(cvtToDomainAccount(accounts[0]), result, desc)
+}
+
+func getByIDAndUserID_UserNotMatch_ReturnNotFound(s *AccountSuite, desc string) {
+	user, accounts, err := s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndUserID(mockCTX, accounts[0].ID, user.ID+1)
+	s.Require().ErrorIs(err, domain.ErrAccountNotFound, desc)
+	s.Require().Empty(result, desc)
+}
+
+func (s *AccountSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *AccountSuite, desc string){
+		"when no duplicate name, update account": update_NoDuplicateName_UpdateAccount,
+		"when duplicate name, return error":      update_DuplicateName_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoDuplicateName_UpdateAccount(s *AccountSuite, desc string) {
+	user, accounts, err := s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	account := domain.Account{ID: accounts[0].ID, Name: "credit card", Type: domain.AccountTypeCreditCard, InitialBalance: -50}
+	err = s.repo.Update(mockCTX, account)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndUserID(mockCTX, accounts[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(account, result, desc)
+}
+
+func update_DuplicateName_ReturnError(s *AccountSuite, desc string) {
+	_, accounts, err := s.f.InsertAccountsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	account := domain.Account{ID: accounts[0].ID, Name: accounts[1].Name, Type: domain.AccountTypeCash}
+	err = s.repo.Update(mockCTX, account)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUser, desc)
+}
+
+func (s *AccountSuite) TestDelete() {
+	user, accounts, err := s.f.InsertAccountsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err)
+
+	err = s.repo.Delete(mockCTX, accounts[0].ID)
+	s.Require().NoError(err)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, accounts[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrAccountNotFound)
+
+	s.TearDownTest()
+}
@@ -0,0 +1,22 @@
+package account
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelAccount(a domain.Account, userID int64) Account {
+	return Account{
+		ID:             a.ID,
+		UserID:         userID,
+		Name:           a.Name,
+		Type:           a.Type.ToModelValue(),
+		InitialBalance: a.InitialBalance,
+	}
+}
+
+func cvtToDomainAccount(a Account) domain.Account {
+	return domain.Account{
+		ID:             a.ID,
+		Name:           a.Name,
+		Type:           domain.CvtToAccountType(a.Type),
+		InitialBalance: a.InitialBalance,
+	}
+}
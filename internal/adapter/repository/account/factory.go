@@ -0,0 +1,43 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/user"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/gofacto"
+	"github.com/eyo-chen/gofacto/db/mysqlf"
+)
+
+type factory struct {
+	account *gofacto.Factory[Account]
+}
+
+func newFactory(db *sql.DB) *factory {
+	return &factory{
+		account: gofacto.New(Account{}).
+			WithDB(mysqlf.NewConfig(db)).
+			WithStorageName("accounts"),
+	}
+}
+
+// InsertAccountsWithOneUser inserts cash accounts with one user
+func (f *factory) InsertAccountsWithOneUser(ctx context.Context, i int) (user.User, []Account, error) {
+	ows := make([]Account, i)
+	for k := range ows {
+		ows[k] = Account{Type: domain.AccountTypeCash.ToModelValue()}
+	}
+
+	u := user.User{}
+	accounts, err := f.account.BuildList(ctx, i).Overwrites(ows...).WithOne(&u).Insert()
+	if err != nil {
+		return user.User{}, nil, err
+	}
+
+	return u, accounts, nil
+}
+
+func (f *factory) Reset() {
+	f.account.Reset()
+}
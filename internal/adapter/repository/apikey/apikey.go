@@ -0,0 +1,156 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/repository/apikey"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+// APIKey is the model of API key, Scopes is the JSON array of the granted scopes
+type APIKey struct {
+	ID         int64
+	UserID     int64 `gofacto:"foreignKey,struct:User"`
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []byte
+	ExpiresAt  *time.Time `gofacto:"omit"`
+	LastUsedAt *time.Time `gofacto:"omit"`
+	CreatedAt  time.Time
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Create(ctx context.Context, key domain.APIKey, keyHash string) (int64, error) {
+	qStmt := "INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at) VALUES (?, ?, ?, ?, ?, ?)"
+
+	k, err := cvtToModelAPIKey(key, keyHash)
+	if err != nil {
+		logger.Error("cvtToModelAPIKey failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	res, err := r.DB.ExecContext(ctx, qStmt, k.UserID, k.Name, k.Prefix, k.KeyHash, k.Scopes, k.ExpiresAt)
+	if err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	qStmt := `SELECT id, user_id, name, prefix, scopes, expires_at, last_used_at, created_at
+						FROM api_keys
+						WHERE user_id = ?
+						ORDER BY id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		var k APIKey
+		if err := rows.Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.Scopes, &k.ExpiresAt, &k.LastUsedAt, &k.CreatedAt); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		key, err := cvtToDomainAPIKey(k)
+		if err != nil {
+			logger.Error("cvtToDomainAPIKey failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.APIKey, error) {
+	qStmt := `SELECT id, user_id, name, prefix, scopes, expires_at, last_used_at, created_at
+						FROM api_keys
+						WHERE id = ? AND user_id = ?`
+
+	return r.getOne(ctx, qStmt, id, userID)
+}
+
+func (r *Repo) GetByKeyHash(ctx context.Context, keyHash string) (domain.APIKey, error) {
+	qStmt := `SELECT id, user_id, name, prefix, scopes, expires_at, last_used_at, created_at
+						FROM api_keys
+						WHERE key_hash = ?`
+
+	return r.getOne(ctx, qStmt, keyHash)
+}
+
+func (r *Repo) UpdateLastUsedAt(ctx context.Context, id int64) error {
+	qStmt := "UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM api_keys WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) getOne(ctx context.Context, qStmt string, args ...interface{}) (domain.APIKey, error) {
+	var k APIKey
+	if err := r.DB.QueryRowContext(ctx, qStmt, args...).
+		Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.Scopes, &k.ExpiresAt, &k.LastUsedAt, &k.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.APIKey{}, domain.ErrAPIKeyNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.APIKey{}, err
+	}
+
+	key, err := cvtToDomainAPIKey(k)
+	if err != nil {
+		logger.Error("cvtToDomainAPIKey failed", "package", packageName, "err", err)
+		return domain.APIKey{}, err
+	}
+
+	return key, nil
+}
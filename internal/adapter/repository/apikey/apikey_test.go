@@ -0,0 +1,197 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type APIKeySuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestAPIKeySuite(t *testing.T) {
+	suite.Run(t, new(APIKeySuite))
+}
+
+func (s *APIKeySuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *APIKeySuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *APIKeySuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *APIKeySuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"api_keys", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *APIKeySuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *APIKeySuite, desc string){
+		"when no expiry, create successfully":   create_NoExpiry_CreateSuccessfully,
+		"when with expiry, create successfully": create_WithExpiry_CreateSuccessfully,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoExpiry_CreateSuccessfully(s *APIKeySuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	key := domain.APIKey{
+		UserID: users[0].ID,
+		Name:   "spreadsheet",
+		Prefix: "etk_12345678",
+		Scopes: []string{domain.ScopeTransactionsRead, domain.ScopeChartsRead},
+	}
+
+	// action
+	id, err := s.repo.Create(mockCTX, key, "hash")
+	s.Require().NoError(err, desc)
+
+	// assertion
+	res, err := s.repo.GetByKeyHash(mockCTX, "hash")
+	s.Require().NoError(err, desc)
+	s.Require().Equal(id, res.ID, desc)
+	s.Require().Equal(key.UserID, res.UserID, desc)
+	s.Require().Equal(key.Name, res.Name, desc)
+	s.Require().Equal(key.Prefix, res.Prefix, desc)
+	s.Require().Equal(key.Scopes, res.Scopes, desc)
+	s.Require().Nil(res.ExpiresAt, desc)
+	s.Require().Nil(res.LastUsedAt, desc)
+	s.Require().False(res.CreatedAt.IsZero(), desc)
+}
+
+func create_WithExpiry_CreateSuccessfully(s *APIKeySuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := domain.APIKey{
+		UserID:    users[0].ID,
+		Name:      "script",
+		Prefix:    "etk_12345678",
+		Scopes:    []string{domain.ScopeTransactionsWrite},
+		ExpiresAt: &expiresAt,
+	}
+
+	// action
+	id, err := s.repo.Create(mockCTX, key, "hash")
+	s.Require().NoError(err, desc)
+
+	// assertion
+	res, err := s.repo.GetByIDAndUserID(mockCTX, id, users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().NotNil(res.ExpiresAt, desc)
+	s.Require().True(expiresAt.Equal(*res.ExpiresAt), desc)
+}
+
+func (s *APIKeySuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *APIKeySuite, desc string){
+		"when key of other user, return error": getByIDAndUserID_OtherUser_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_OtherUser_ReturnError(s *APIKeySuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 2)
+	s.Require().NoError(err, desc)
+	id, err := s.repo.Create(mockCTX, domain.APIKey{UserID: users[0].ID, Name: "script", Scopes: []string{domain.ScopeChartsRead}}, "hash")
+	s.Require().NoError(err, desc)
+
+	// action
+	key, err := s.repo.GetByIDAndUserID(mockCTX, id, users[1].ID)
+
+	// assertion
+	s.Require().ErrorIs(err, domain.ErrAPIKeyNotFound, desc)
+	s.Require().Empty(key, desc)
+}
+
+func (s *APIKeySuite) TestUpdateLastUsedAt() {
+	for scenario, fn := range map[string]func(s *APIKeySuite, desc string){
+		"when no error, set last used": updateLastUsedAt_NoError_SetLastUsed,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func updateLastUsedAt_NoError_SetLastUsed(s *APIKeySuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	id, err := s.repo.Create(mockCTX, domain.APIKey{UserID: users[0].ID, Name: "script", Scopes: []string{domain.ScopeChartsRead}}, "hash")
+	s.Require().NoError(err, desc)
+
+	// action
+	err = s.repo.UpdateLastUsedAt(mockCTX, id)
+	s.Require().NoError(err, desc)
+
+	// assertion
+	keys, err := s.repo.GetAll(mockCTX, users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(keys, 1, desc)
+	s.Require().NotNil(keys[0].LastUsedAt, desc)
+}
@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"encoding/json"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+func cvtToModelAPIKey(k domain.APIKey, keyHash string) (APIKey, error) {
+	scopes, err := json.Marshal(k.Scopes)
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	return APIKey{
+		ID:        k.ID,
+		UserID:    k.UserID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		ExpiresAt: k.ExpiresAt,
+	}, nil
+}
+
+func cvtToDomainAPIKey(k APIKey) (domain.APIKey, error) {
+	var scopes []string
+	if err := json.Unmarshal(k.Scopes, &scopes); err != nil {
+		return domain.APIKey{}, err
+	}
+
+	return domain.APIKey{
+		ID:         k.ID,
+		UserID:     k.UserID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/user"
+	"github.com/eyo-chen/gofacto"
+	"github.com/eyo-chen/gofacto/db/mysqlf"
+)
+
+type factory struct {
+	user *gofacto.Factory[user.User]
+}
+
+func newFactory(db *sql.DB) *factory {
+	return &factory{
+		user: gofacto.New(user.User{}).WithDB(mysqlf.NewConfig(db)),
+	}
+}
+
+// InsertUsers inserts the users, the API keys are inserted by the repo
+func (f *factory) InsertUsers(ctx context.Context, i int) ([]user.User, error) {
+	return f.user.BuildList(ctx, i).Insert()
+}
+
+func (f *factory) Reset() {
+	f.user.Reset()
+}
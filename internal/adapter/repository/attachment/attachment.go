@@ -0,0 +1,118 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errorutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	uniqueObjectKey = "transaction_attachments.unique_object_key"
+	packageName     = "adapter/repository/attachment"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+// Attachment is the model of transaction attachment
+type Attachment struct {
+	ID            int64
+	TransactionID int64
+	UserID        int64
+	FileName      string
+	ObjectKey     string
+	CreatedAt     time.Time
+}
+
+func (r *Repo) Create(ctx context.Context, a domain.TransAttachment) error {
+	qStmt := `INSERT INTO transaction_attachments (transaction_id, user_id, file_name, object_key)
+						VALUES (?, ?, ?, ?)`
+
+	m := cvtToModelAttachment(a)
+	if _, err := r.DB.ExecContext(ctx, qStmt, m.TransactionID, m.UserID, m.FileName, m.ObjectKey); err != nil {
+		// the object key contains the transaction id and file name
+		if errorutil.ParseError(err, uniqueObjectKey) {
+			return domain.ErrUniqueAttachmentFileName
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetByIDAndTransID(ctx context.Context, id, transID int64) (domain.TransAttachment, error) {
+	qStmt := `SELECT id, transaction_id, user_id, file_name, object_key, created_at
+						FROM transaction_attachments
+						WHERE id = ? AND transaction_id = ?`
+
+	var m Attachment
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, transID).
+		Scan(&m.ID, &m.TransactionID, &m.UserID, &m.FileName, &m.ObjectKey, &m.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TransAttachment{}, domain.ErrAttachmentNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.TransAttachment{}, err
+	}
+
+	return cvtToDomainAttachment(m), nil
+}
+
+func (r *Repo) GetByPurgeableTrans(ctx context.Context, before time.Time) ([]domain.TransAttachment, error) {
+	// a transaction in trash with its category has the same deleted_at as the category,
+	// so these are all the attachments removed by purging the trash
+	qStmt := `SELECT a.id, a.transaction_id, a.user_id, a.file_name, a.object_key, a.created_at
+						FROM transaction_attachments AS a
+						INNER JOIN transactions AS t
+						ON a.transaction_id = t.id
+						WHERE t.deleted_at < ?
+						ORDER BY a.id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, before)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var attachments []domain.TransAttachment
+	for rows.Next() {
+		var m Attachment
+		if err := rows.Scan(&m.ID, &m.TransactionID, &m.UserID, &m.FileName, &m.ObjectKey, &m.CreatedAt); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		attachments = append(attachments, cvtToDomainAttachment(m))
+	}
+
+	return attachments, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM transaction_attachments WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,265 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/transaction"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type AttachmentSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *transaction.TransactionFactory
+}
+
+func TestAttachmentSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentSuite))
+}
+
+func (s *AttachmentSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = transaction.NewTransactionFactory(s.db)
+}
+
+func (s *AttachmentSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *AttachmentSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = transaction.NewTransactionFactory(s.db)
+}
+
+func (s *AttachmentSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"transaction_attachments", "transactions", "sub_categories", "main_categories", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *AttachmentSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *AttachmentSuite, desc string){
+		"when no error, create successfully":      create_NoError_CreateSuccessfully,
+		"when file name is used, return error":    create_FileNameUsed_ReturnError,
+		"when same file name on other transation": create_SameFileNameOnOtherTrans_CreateSuccessfully,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *AttachmentSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	a := domain.TransAttachment{
+		TransactionID: trans[0].ID,
+		UserID:        user.ID,
+		FileName:      "receipt.png",
+		ObjectKey:     genObjectKey(s, user.ID, trans[0].ID, "receipt.png"),
+	}
+	err = s.repo.Create(mockCTX, a)
+	s.Require().NoError(err, desc)
+
+	var result Attachment
+	stmt := "SELECT id, transaction_id, user_id, file_name, object_key, created_at FROM transaction_attachments WHERE transaction_id = ?"
+	err = s.db.QueryRow(stmt, trans[0].ID).Scan(&result.ID, &result.TransactionID, &result.UserID, &result.FileName, &result.ObjectKey, &result.CreatedAt)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(a.FileName, result.FileName, desc)
+	s.Require().Equal(a.ObjectKey, result.ObjectKey, desc)
+	s.Require().Equal(user.ID, result.UserID, desc)
+}
+
+func create_FileNameUsed_ReturnError(s *AttachmentSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	a := domain.TransAttachment{
+		TransactionID: trans[0].ID,
+		UserID:        user.ID,
+		FileName:      "receipt.png",
+		ObjectKey:     genObjectKey(s, user.ID, trans[0].ID, "receipt.png"),
+	}
+	err = s.repo.Create(mockCTX, a)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, a)
+	s.Require().ErrorIs(err, domain.ErrUniqueAttachmentFileName, desc)
+}
+
+func create_SameFileNameOnOtherTrans_CreateSuccessfully(s *AttachmentSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	for _, t := range trans {
+		err = s.repo.Create(mockCTX, domain.TransAttachment{
+			TransactionID: t.ID,
+			UserID:        user.ID,
+			FileName:      "receipt.png",
+			ObjectKey:     genObjectKey(s, user.ID, t.ID, "receipt.png"),
+		})
+		s.Require().NoError(err, desc)
+	}
+}
+
+func (s *AttachmentSuite) TestGetByIDAndTransID() {
+	for scenario, fn := range map[string]func(s *AttachmentSuite, desc string){
+		"when attachment of the transaction, return it":      getByIDAndTransID_AttachmentOfTrans_ReturnIt,
+		"when attachment of other transaction, return error": getByIDAndTransID_AttachmentOfOtherTrans_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndTransID_AttachmentOfTrans_ReturnIt(s *AttachmentSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	a := domain.TransAttachment{
+		TransactionID: trans[0].ID,
+		UserID:        user.ID,
+		FileName:      "receipt.pdf",
+		ObjectKey:     genObjectKey(s, user.ID, trans[0].ID, "receipt.pdf"),
+	}
+	s.Require().NoError(s.repo.Create(mockCTX, a), desc)
+
+	var id int64
+	err = s.db.QueryRow("SELECT id FROM transaction_attachments WHERE transaction_id = ?", trans[0].ID).Scan(&id)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndTransID(mockCTX, id, trans[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().False(result.CreatedAt.IsZero(), desc)
+	result.CreatedAt = time.Time{}
+	a.ID = id
+	s.Require().Equal(a, result, desc)
+}
+
+func getByIDAndTransID_AttachmentOfOtherTrans_ReturnError(s *AttachmentSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	a := domain.TransAttachment{
+		TransactionID: trans[0].ID,
+		UserID:        user.ID,
+		FileName:      "receipt.pdf",
+		ObjectKey:     genObjectKey(s, user.ID, trans[0].ID, "receipt.pdf"),
+	}
+	s.Require().NoError(s.repo.Create(mockCTX, a), desc)
+
+	var id int64
+	err = s.db.QueryRow("SELECT id FROM transaction_attachments WHERE transaction_id = ?", trans[0].ID).Scan(&id)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndTransID(mockCTX, id, trans[1].ID)
+	s.Require().ErrorIs(err, domain.ErrAttachmentNotFound, desc)
+	s.Require().Empty(result, desc)
+}
+
+func (s *AttachmentSuite) TestGetByPurgeableTrans() {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, "test get by purgeable trans")
+
+	objectKeys := make([]string, len(trans))
+	for i, t := range trans {
+		objectKeys[i] = genObjectKey(s, user.ID, t.ID, "receipt.png")
+		err := s.repo.Create(mockCTX, domain.TransAttachment{
+			TransactionID: t.ID,
+			UserID:        user.ID,
+			FileName:      "receipt.png",
+			ObjectKey:     objectKeys[i],
+		})
+		s.Require().NoError(err, "test get by purgeable trans")
+	}
+
+	// the first transaction is in trash long enough, the second one is just moved to trash
+	before := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	_, err = s.db.Exec("UPDATE transactions SET deleted_at = ? WHERE id = ?", before.Add(-time.Hour), trans[0].ID)
+	s.Require().NoError(err, "test get by purgeable trans")
+	_, err = s.db.Exec("UPDATE transactions SET deleted_at = ? WHERE id = ?", before.Add(time.Hour), trans[1].ID)
+	s.Require().NoError(err, "test get by purgeable trans")
+
+	result, err := s.repo.GetByPurgeableTrans(mockCTX, before)
+	s.Require().NoError(err, "test get by purgeable trans")
+	s.Require().Len(result, 1, "test get by purgeable trans")
+	s.Require().Equal(trans[0].ID, result[0].TransactionID, "test get by purgeable trans")
+	s.Require().Equal(objectKeys[0], result[0].ObjectKey, "test get by purgeable trans")
+}
+
+func (s *AttachmentSuite) TestDelete() {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, "test delete")
+
+	err = s.repo.Create(mockCTX, domain.TransAttachment{
+		TransactionID: trans[0].ID,
+		UserID:        user.ID,
+		FileName:      "receipt.png",
+		ObjectKey:     genObjectKey(s, user.ID, trans[0].ID, "receipt.png"),
+	})
+	s.Require().NoError(err, "test delete")
+
+	var id int64
+	err = s.db.QueryRow("SELECT id FROM transaction_attachments WHERE transaction_id = ?", trans[0].ID).Scan(&id)
+	s.Require().NoError(err, "test delete")
+
+	err = s.repo.Delete(mockCTX, id)
+	s.Require().NoError(err, "test delete")
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transaction_attachments WHERE id = ?", id).Scan(&count)
+	s.Require().NoError(err, "test delete")
+	s.Require().Zero(count, "test delete")
+}
+
+// genObjectKey generates the object key of the attachment
+func genObjectKey(s *AttachmentSuite, userID, transID int64, fileName string) string {
+	objectKey, err := domain.GenAttachmentObjectKey(userID, transID, fileName)
+	s.Require().NoError(err)
+
+	return objectKey
+}
@@ -0,0 +1,24 @@
+package attachment
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelAttachment(a domain.TransAttachment) Attachment {
+	return Attachment{
+		ID:            a.ID,
+		TransactionID: a.TransactionID,
+		UserID:        a.UserID,
+		FileName:      a.FileName,
+		ObjectKey:     a.ObjectKey,
+	}
+}
+
+func cvtToDomainAttachment(m Attachment) domain.TransAttachment {
+	return domain.TransAttachment{
+		ID:            m.ID,
+		TransactionID: m.TransactionID,
+		UserID:        m.UserID,
+		FileName:      m.FileName,
+		ObjectKey:     m.ObjectKey,
+		CreatedAt:     m.CreatedAt,
+	}
+}
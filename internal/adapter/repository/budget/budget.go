@@ -0,0 +1,111 @@
+package budget
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/repository/budget"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+type Budget struct {
+	ID          int64
+	UserID      int64 `gofacto:"foreignKey,struct:User"`
+	MainCategID int64 `gofacto:"foreignKey,struct:MainCateg,table:main_categories" mysqlf:"main_category_id"`
+	Amount      float64
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Upsert(ctx context.Context, budget domain.Budget, userID int64) error {
+	qStmt := `INSERT INTO budgets (user_id, main_category_id, amount)
+						VALUES (?, ?, ?)
+						ON DUPLICATE KEY UPDATE amount = VALUES(amount)`
+
+	b := cvtToModelBudget(budget, userID)
+	if _, err := r.DB.ExecContext(ctx, qStmt, b.UserID, b.MainCategID, b.Amount); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.Budget, error) {
+	qStmt := `SELECT b.id, b.amount, mc.id, mc.name, mc.type
+						FROM budgets AS b
+						INNER JOIN main_categories AS mc
+						ON b.main_category_id = mc.id
+						AND mc.deleted_at IS NULL
+						WHERE b.user_id = ?
+						ORDER BY b.id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var budgets []domain.Budget
+	for rows.Next() {
+		var b Budget
+		var name, categType string
+		if err := rows.Scan(&b.ID, &b.Amount, &b.MainCategID, &name, &categType); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		budgets = append(budgets, cvtToDomainBudget(b, name, categType))
+	}
+
+	return budgets, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Budget, error) {
+	qStmt := `SELECT b.id, b.amount, mc.id, mc.name, mc.type
+						FROM budgets AS b
+						INNER JOIN main_categories AS mc
+						ON b.main_category_id = mc.id
+						AND mc.deleted_at IS NULL
+						WHERE b.id = ? AND b.user_id = ?`
+
+	var b Budget
+	var name, categType string
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).Scan(&b.ID, &b.Amount, &b.MainCategID, &name, &categType); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Budget{}, domain.ErrBudgetNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.Budget{}, err
+	}
+
+	return cvtToDomainBudget(b, name, categType), nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM budgets WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package budget
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type BudgetSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestBudgetSuite(t *testing.T) {
+	suite.Run(t, new(BudgetSuite))
+}
+
+func (s *BudgetSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *BudgetSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *BudgetSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *BudgetSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	_, err = tx.Exec("DELETE FROM budgets")
+	s.Require().NoError(err)
+
+	_, err = tx.Exec("DELETE FROM main_categories")
+	s.Require().NoError(err)
+
+	_, err = tx.Exec("DELETE FROM users")
+	s.Require().NoError(err)
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *BudgetSuite) TestUpsert() {
+	for scenario, fn := range map[string]func(s *BudgetSuite, desc string){
+		"when budget not exists, insert budget":     upsert_NotExists_InsertBudget,
+		"when budget already exists, update amount": upsert_AlreadyExists_UpdateAmount,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func upsert_NotExists_InsertBudget(s *BudgetSuite, desc string) {
+	user, categs, err := s.f.InsertMainCategsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	budget := domain.Budget{MainCateg: domain.MainCateg{ID: categs[0].ID}, Amount: 500}
+	err = s.repo.Upsert(mockCTX, budget, user.ID)
+	s.Require().NoError(err, desc)
+
+	var amount float64
+	err = s.db.QueryRow("SELECT amount FROM budgets WHERE user_id = ? AND main_category_id = ?", user.ID, categs[0].ID).Scan(&amount)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(float64(500), amount, desc)
+}
+
+func upsert_AlreadyExists_UpdateAmount(s *BudgetSuite, desc string) {
+	user, categs, err := s.f.InsertMainCategsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	_, err = s.f.InsertBudgets(mockCTX, user, categs)
+	s.Require().NoError(err, desc)
+
+	budget := domain.Budget{MainCateg: domain.MainCateg{ID: categs[0].ID}, Amount: 800}
+	err = s.repo.Upsert(mockCTX, budget, user.ID)
+	s.Require().NoError(err, desc)
+
+	var count int
+	var amount float64
+	err = s.db.QueryRow("SELECT COUNT(*), MAX(amount) FROM budgets WHERE user_id = ?", user.ID).Scan(&count, &amount)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(1, count, desc)
+	s.Require().Equal(float64(800), amount, desc)
+}
+
+func (s *BudgetSuite) TestGetAll() {
+	user, categs, err := s.f.InsertMainCategsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err)
+
+	budgets, err := s.f.InsertBudgets(mockCTX, user, categs)
+	s.Require().NoError(err)
+
+	// budget of another user
+	user2, categs2, err := s.f.InsertMainCategsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err)
+	_, err = s.f.InsertBudgets(mockCTX, user2, categs2)
+	s.Require().NoError(err)
+
+	expResult := []domain.Budget{
+		cvtToDomainBudget(budgets[0], categs[0].Name, categs[0].Type),
+		cvtToDomainBudget(budgets[1], categs[1].Name, categs[1].Type),
+	}
+
+	result, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(expResult, result)
+
+	s.TearDownTest()
+}
+
+func (s *BudgetSuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *BudgetSuite, desc string){
+		"when data exists, return data":         getByIDAndUserID_DataExists_ReturnData,
+		"when user not match, return not found": getByIDAndUserID_UserNotMatch_ReturnNotFound,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_DataExists_ReturnData(s *BudgetSuite, desc string) {
+	user, categs, err := s.f.InsertMainCategsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	budgets, err := s.f.InsertBudgets(mockCTX, user, categs)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndUserID(mockCTX, budgets[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(cvtToDomainBudget(budgets[0], categs[0].Name, categs[0].Type), result, desc)
+}
+
+func getByIDAndUserID_UserNotMatch_ReturnNotFound(s *BudgetSuite, desc string) {
+	user, categs, err := s.f.InsertMainCategsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	budgets, err := s.f.InsertBudgets(mockCTX, user, categs)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndUserID(mockCTX, budgets[0].ID, user.ID+1)
+	s.Require().ErrorIs(err, domain.ErrBudgetNotFound, desc)
+	s.Require().Empty(result, desc)
+}
+
+func (s *BudgetSuite) TestDelete() {
+	user, categs, err := s.f.InsertMainCategsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err)
+
+	budgets, err := s.f.InsertBudgets(mockCTX, user, categs)
+	s.Require().NoError(err)
+
+	err = s.repo.Delete(mockCTX, budgets[0].ID)
+	s.Require().NoError(err)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, budgets[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrBudgetNotFound)
+
+	s.TearDownTest()
+}
@@ -0,0 +1,24 @@
+package budget
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelBudget(b domain.Budget, userID int64) Budget {
+	return Budget{
+		ID:          b.ID,
+		UserID:      userID,
+		MainCategID: b.MainCateg.ID,
+		Amount:      b.Amount,
+	}
+}
+
+func cvtToDomainBudget(b Budget, name, categType string) domain.Budget {
+	return domain.Budget{
+		ID: b.ID,
+		MainCateg: domain.MainCateg{
+			ID:   b.MainCategID,
+			Name: name,
+			Type: domain.CvtToTransactionType(categType),
+		},
+		Amount: b.Amount,
+	}
+}
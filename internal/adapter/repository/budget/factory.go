@@ -0,0 +1,62 @@
+package budget
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/maincateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/user"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/gofacto"
+	"github.com/eyo-chen/gofacto/db/mysqlf"
+)
+
+type factory struct {
+	budget    *gofacto.Factory[Budget]
+	maincateg *gofacto.Factory[maincateg.MainCateg]
+}
+
+func newFactory(db *sql.DB) *factory {
+	return &factory{
+		budget: gofacto.New(Budget{}).
+			WithDB(mysqlf.NewConfig(db)).
+			WithStorageName("budgets"),
+		maincateg: gofacto.New(maincateg.MainCateg{}).
+			WithDB(mysqlf.NewConfig(db)).
+			WithStorageName("main_categories"),
+	}
+}
+
+// InsertMainCategsWithOneUser inserts expense main categories with one user
+func (f *factory) InsertMainCategsWithOneUser(ctx context.Context, i int) (user.User, []maincateg.MainCateg, error) {
+	ows := make([]maincateg.MainCateg, i)
+	for k := range ows {
+		ows[k] = maincateg.MainCateg{
+			Type:     domain.TransactionTypeExpense.ToModelValue(),
+			IconType: domain.IconTypeDefault.ToModelValue(),
+		}
+	}
+
+	u := user.User{}
+	categs, err := f.maincateg.BuildList(ctx, i).Overwrites(ows...).WithOne(&u).Insert()
+	if err != nil {
+		return user.User{}, nil, err
+	}
+
+	return u, categs, nil
+}
+
+// InsertBudgets inserts budgets with given user and main categories, one budget for each main category
+func (f *factory) InsertBudgets(ctx context.Context, u user.User, categs []maincateg.MainCateg) ([]Budget, error) {
+	ows := make([]Budget, len(categs))
+	for k, c := range categs {
+		ows[k] = Budget{UserID: u.ID, MainCategID: c.ID}
+	}
+
+	return f.budget.BuildList(ctx, len(categs)).Overwrites(ows...).Insert()
+}
+
+func (f *factory) Reset() {
+	f.budget.Reset()
+	f.maincateg.Reset()
+}
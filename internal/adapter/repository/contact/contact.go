@@ -0,0 +1,182 @@
+package contact
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errorutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	uniqueNameUser = "contacts.unique_name_user"
+	packageName    = "adapter/repository/contact"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+type Contact struct {
+	ID     int64
+	UserID int64 `gofacto:"foreignKey,struct:User"`
+	Name   string
+	Email  string
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Create(ctx context.Context, contact domain.Contact, userID int64) error {
+	qStmt := "INSERT INTO contacts (user_id, name, email) VALUES (?, ?, NULLIF(?, ''))"
+
+	c := cvtToModelContact(contact, userID)
+	if _, err := r.DB.ExecContext(ctx, qStmt, c.UserID, c.Name, c.Email); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueContactNameUser
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.Contact, error) {
+	qStmt := `SELECT id, name, COALESCE(email, '')
+						FROM contacts
+						WHERE user_id = ?
+						ORDER BY id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var contacts []domain.Contact
+	for rows.Next() {
+		var c Contact
+		if err := rows.Scan(&c.ID, &c.Name, &c.Email); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		contacts = append(contacts, cvtToDomainContact(c))
+	}
+
+	return contacts, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Contact, error) {
+	qStmt := `SELECT id, name, COALESCE(email, '')
+						FROM contacts
+						WHERE id = ? AND user_id = ?`
+
+	var c Contact
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).Scan(&c.ID, &c.Name, &c.Email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Contact{}, domain.ErrContactNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.Contact{}, err
+	}
+
+	return cvtToDomainContact(c), nil
+}
+
+func (r *Repo) GetByIDs(ctx context.Context, ids []int64, userID int64) ([]domain.Contact, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT id, name, COALESCE(email, '')
+									FROM contacts
+									WHERE user_id = ?
+									AND id IN (?`)
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, userID, ids[0])
+	for _, id := range ids[1:] {
+		sb.WriteString(", ?")
+		args = append(args, id)
+	}
+	sb.WriteString(") ORDER BY id")
+
+	rows, err := r.DB.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var contacts []domain.Contact
+	for rows.Next() {
+		var c Contact
+		if err := rows.Scan(&c.ID, &c.Name, &c.Email); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		contacts = append(contacts, cvtToDomainContact(c))
+	}
+
+	return contacts, nil
+}
+
+func (r *Repo) Update(ctx context.Context, contact domain.Contact) error {
+	qStmt := "UPDATE contacts SET name = ?, email = NULLIF(?, '') WHERE id = ?"
+
+	c := cvtToModelContact(contact, 0)
+	if _, err := r.DB.ExecContext(ctx, qStmt, c.Name, c.Email, c.ID); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueContactNameUser
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// IsInUse returns true if the contact takes part in any shared transaction or settlement
+func (r *Repo) IsInUse(ctx context.Context, id int64) (bool, error) {
+	qStmt := `SELECT EXISTS (SELECT 1 FROM shared_transactions WHERE paid_by = ?)
+						OR EXISTS (SELECT 1 FROM transaction_shares WHERE contact_id = ?)
+						OR EXISTS (SELECT 1 FROM settlements WHERE from_contact_id = ? OR to_contact_id = ?)`
+
+	var inUse bool
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, id, id, id).Scan(&inUse); err != nil {
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return false, err
+	}
+
+	return inUse, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM contacts WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package contact
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type ContactSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestContactSuite(t *testing.T) {
+	suite.Run(t, new(ContactSuite))
+}
+
+func (s *ContactSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *ContactSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *ContactSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *ContactSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"settlements", "contacts", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *ContactSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *ContactSuite, desc string){
+		"when no error, create successfully": create_NoError_CreateSuccessfully,
+		"when name is used, return error":    create_NameUsed_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *ContactSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Contact{Name: "Alice", Email: "alice@a.com"}, users[0].ID)
+	s.Require().NoError(err, desc)
+	err = s.repo.Create(mockCTX, domain.Contact{Name: "Bob"}, users[0].ID)
+	s.Require().NoError(err, desc)
+
+	contacts, err := s.repo.GetAll(mockCTX, users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(contacts, 2, desc)
+	s.Require().Equal("Alice", contacts[0].Name, desc)
+	s.Require().Equal("alice@a.com", contacts[0].Email, desc)
+	s.Require().Equal("Bob", contacts[1].Name, desc)
+	s.Require().Empty(contacts[1].Email, desc)
+}
+
+func create_NameUsed_ReturnError(s *ContactSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Contact{Name: "Alice"}, users[0].ID)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Contact{Name: "Alice"}, users[0].ID)
+	s.Require().ErrorIs(err, domain.ErrUniqueContactNameUser, desc)
+}
+
+func (s *ContactSuite) TestGetByIDs() {
+	for scenario, fn := range map[string]func(s *ContactSuite, desc string){
+		"when contact of other user, skip it": getByIDs_OtherUser_SkipIt,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDs_OtherUser_SkipIt(s *ContactSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Contact{Name: "Alice"}, users[0].ID)
+	s.Require().NoError(err, desc)
+	err = s.repo.Create(mockCTX, domain.Contact{Name: "Bob"}, users[1].ID)
+	s.Require().NoError(err, desc)
+
+	var aliceID, bobID int64
+	s.Require().NoError(s.db.QueryRow("SELECT id FROM contacts WHERE name = 'Alice'").Scan(&aliceID), desc)
+	s.Require().NoError(s.db.QueryRow("SELECT id FROM contacts WHERE name = 'Bob'").Scan(&bobID), desc)
+
+	contacts, err := s.repo.GetByIDs(mockCTX, []int64{aliceID, bobID}, users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.Contact{{ID: aliceID, Name: "Alice"}}, contacts, desc)
+}
+
+func (s *ContactSuite) TestIsInUse() {
+	for scenario, fn := range map[string]func(s *ContactSuite, desc string){
+		"when no reference, return false":      isInUse_NoReference_ReturnFalse,
+		"when used in settlement, return true": isInUse_UsedInSettlement_ReturnTrue,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func isInUse_NoReference_ReturnFalse(s *ContactSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Contact{Name: "Alice"}, users[0].ID)
+	s.Require().NoError(err, desc)
+
+	var id int64
+	s.Require().NoError(s.db.QueryRow("SELECT id FROM contacts WHERE name = 'Alice'").Scan(&id), desc)
+
+	inUse, err := s.repo.IsInUse(mockCTX, id)
+	s.Require().NoError(err, desc)
+	s.Require().False(inUse, desc)
+}
+
+func isInUse_UsedInSettlement_ReturnTrue(s *ContactSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Contact{Name: "Alice"}, users[0].ID)
+	s.Require().NoError(err, desc)
+
+	var id int64
+	s.Require().NoError(s.db.QueryRow("SELECT id FROM contacts WHERE name = 'Alice'").Scan(&id), desc)
+
+	_, err = s.db.Exec("INSERT INTO settlements (user_id, from_contact_id, amount, currency, date) VALUES (?, ?, 10, 'USD', '2024-03-01')", users[0].ID, id)
+	s.Require().NoError(err, desc)
+
+	inUse, err := s.repo.IsInUse(mockCTX, id)
+	s.Require().NoError(err, desc)
+	s.Require().True(inUse, desc)
+}
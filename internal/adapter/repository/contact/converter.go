@@ -0,0 +1,20 @@
+package contact
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelContact(c domain.Contact, userID int64) Contact {
+	return Contact{
+		ID:     c.ID,
+		UserID: userID,
+		Name:   c.Name,
+		Email:  c.Email,
+	}
+}
+
+func cvtToDomainContact(c Contact) domain.Contact {
+	return domain.Contact{
+		ID:    c.ID,
+		Name:  c.Name,
+		Email: c.Email,
+	}
+}
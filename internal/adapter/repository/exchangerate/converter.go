@@ -0,0 +1,21 @@
+package exchangerate
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelExchangeRate(r domain.ExchangeRate) ExchangeRate {
+	return ExchangeRate{
+		FromCurrency: r.From,
+		ToCurrency:   r.To,
+		Date:         r.Date,
+		Rate:         r.Rate,
+	}
+}
+
+func cvtToDomainExchangeRate(m ExchangeRate) domain.ExchangeRate {
+	return domain.ExchangeRate{
+		From: m.FromCurrency,
+		To:   m.ToCurrency,
+		Date: m.Date,
+		Rate: m.Rate,
+	}
+}
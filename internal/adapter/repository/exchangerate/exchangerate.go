@@ -0,0 +1,111 @@
+package exchangerate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/repository/exchangerate"
+
+	// upsertBatchSize is the number of rows written by a single INSERT statement
+	upsertBatchSize = 500
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+type ExchangeRate struct {
+	ID           int64
+	FromCurrency string
+	ToCurrency   string
+	Date         time.Time
+	Rate         float64
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Upsert(ctx context.Context, rates []domain.ExchangeRate) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	for start := 0; start < len(rates); start += upsertBatchSize {
+		end := min(start+upsertBatchSize, len(rates))
+		batch := rates[start:end]
+
+		var sb strings.Builder
+		sb.WriteString("INSERT INTO exchange_rates (from_currency, to_currency, date, rate) VALUES ")
+		args := make([]interface{}, 0, len(batch)*4)
+		for i, rate := range batch {
+			sb.WriteString("(?, ?, ?, ?)")
+			if i < len(batch)-1 {
+				sb.WriteString(", ")
+			}
+
+			m := cvtToModelExchangeRate(rate)
+			args = append(args, m.FromCurrency, m.ToCurrency, m.Date, m.Rate)
+		}
+		sb.WriteString(" ON DUPLICATE KEY UPDATE rate = VALUES(rate)")
+
+		if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, from, to string) ([]domain.ExchangeRate, error) {
+	qStmt := `SELECT id, from_currency, to_currency, date, rate
+						FROM exchange_rates
+						WHERE from_currency = ?
+						AND to_currency = ?
+						ORDER BY date DESC`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, from, to)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var rates []domain.ExchangeRate
+	for rows.Next() {
+		var m ExchangeRate
+		if err := rows.Scan(&m.ID, &m.FromCurrency, &m.ToCurrency, &m.Date, &m.Rate); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		rates = append(rates, cvtToDomainExchangeRate(m))
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,146 @@
+package exchangerate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX     = context.Background()
+	mockLoc, _  = time.LoadLocation("")
+	mockTimeNow = time.Unix(1629446406, 0).Truncate(24 * time.Hour).In(mockLoc)
+)
+
+type ExchangeRateSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+}
+
+func TestExchangeRateSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateSuite))
+}
+
+func (s *ExchangeRateSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+}
+
+func (s *ExchangeRateSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *ExchangeRateSuite) SetupTest() {
+	s.repo = New(s.db)
+}
+
+func (s *ExchangeRateSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	_, err = tx.Exec("DELETE FROM exchange_rates")
+	s.Require().NoError(err)
+
+	s.Require().NoError(tx.Commit())
+}
+
+func (s *ExchangeRateSuite) TestUpsert() {
+	for scenario, fn := range map[string]func(s *ExchangeRateSuite, desc string){
+		"when rates not exist, insert them":   upsert_RatesNotExist_InsertThem,
+		"when rate exists, update its rate":   upsert_RateExists_UpdateRate,
+		"when one rate fails, insert nothing": upsert_OneRateFail_InsertNothing,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func upsert_RatesNotExist_InsertThem(s *ExchangeRateSuite, desc string) {
+	rates := []domain.ExchangeRate{
+		{From: "EUR", To: "USD", Date: mockTimeNow, Rate: 1.1},
+		{From: "EUR", To: "USD", Date: mockTimeNow.AddDate(0, 0, -1), Rate: 1.2},
+	}
+
+	err := s.repo.Upsert(mockCTX, rates)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetAll(mockCTX, "EUR", "USD")
+	s.Require().NoError(err, desc)
+	s.Require().Equal(rates, result, desc)
+}
+
+func upsert_RateExists_UpdateRate(s *ExchangeRateSuite, desc string) {
+	err := s.repo.Upsert(mockCTX, []domain.ExchangeRate{{From: "EUR", To: "USD", Date: mockTimeNow, Rate: 1.1}})
+	s.Require().NoError(err, desc)
+
+	rates := []domain.ExchangeRate{{From: "EUR", To: "USD", Date: mockTimeNow, Rate: 1.3}}
+	err = s.repo.Upsert(mockCTX, rates)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetAll(mockCTX, "EUR", "USD")
+	s.Require().NoError(err, desc)
+	s.Require().Equal(rates, result, desc)
+}
+
+func upsert_OneRateFail_InsertNothing(s *ExchangeRateSuite, desc string) {
+	rates := []domain.ExchangeRate{
+		{From: "EUR", To: "USD", Date: mockTimeNow, Rate: 1.1},
+		{From: "EURO", To: "USD", Date: mockTimeNow, Rate: 1.1}, // too long for CHAR(3)
+	}
+
+	err := s.repo.Upsert(mockCTX, rates)
+	s.Require().Error(err, desc)
+
+	result, err := s.repo.GetAll(mockCTX, "EUR", "USD")
+	s.Require().NoError(err, desc)
+	s.Require().Empty(result, desc)
+}
+
+func (s *ExchangeRateSuite) TestGetAll() {
+	rates := []domain.ExchangeRate{
+		{From: "EUR", To: "USD", Date: mockTimeNow.AddDate(0, 0, -1), Rate: 1.2},
+		{From: "EUR", To: "USD", Date: mockTimeNow, Rate: 1.1},
+		{From: "USD", To: "EUR", Date: mockTimeNow, Rate: 0.9},
+		{From: "EUR", To: "GBP", Date: mockTimeNow, Rate: 0.8},
+	}
+	err := s.repo.Upsert(mockCTX, rates)
+	s.Require().NoError(err)
+
+	// only the given pair, the latest first
+	expResult := []domain.ExchangeRate{rates[1], rates[0]}
+
+	result, err := s.repo.GetAll(mockCTX, "EUR", "USD")
+	s.Require().NoError(err)
+	s.Require().Equal(expResult, result)
+
+	s.TearDownTest()
+}
@@ -0,0 +1,313 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errorutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	uniqueLedgerMember      = "ledger_members.PRIMARY"
+	uniqueLedgerInviteEmail = "ledger_invites.unique_ledger_email"
+	packageName             = "adapter/repository/ledger"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+// Create inserts a shared ledger along with the user owning its data, and the creator becomes the owner.
+// The user of the ledger has no email, and starts with the base currency of the creator.
+func (r *Repo) Create(ctx context.Context, name string, userID int64) (int64, error) {
+	userStmt := `INSERT INTO users (name, password_hash, base_currency)
+							 SELECT ?, '', base_currency FROM users WHERE id = ?`
+	ledgerStmt := `INSERT INTO ledgers (name, user_id) VALUES (?, ?)`
+	memberStmt := `INSERT INTO ledger_members (ledger_id, user_id, role) VALUES (?, ?, ?)`
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return 0, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, userStmt, name, userID)
+	if err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	ledgerUserID, err := res.LastInsertId()
+	if err != nil {
+		logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	res, err = tx.ExecContext(ctx, ledgerStmt, name, ledgerUserID)
+	if err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, memberStmt, id, userID, domain.LedgerRoleTypeOwner.ToModelValue()); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repo) GetByUserID(ctx context.Context, userID int64) ([]domain.Ledger, error) {
+	qStmt := `SELECT l.id, l.name, l.user_id, l.is_personal, lm.role
+						FROM ledgers AS l
+						INNER JOIN ledger_members AS lm ON lm.ledger_id = l.id
+						WHERE lm.user_id = ?
+						ORDER BY l.is_personal DESC, l.id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var ledgers []domain.Ledger
+	for rows.Next() {
+		var l domain.Ledger
+		var role string
+		if err := rows.Scan(&l.ID, &l.Name, &l.UserID, &l.IsPersonal, &role); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+		l.Role = domain.CvtToLedgerRoleType(role)
+
+		ledgers = append(ledgers, l)
+	}
+
+	return ledgers, nil
+}
+
+// GetByIDAndUserID returns the ledger with the role of the user, ErrLedgerNotFound is returned if the user is not a member
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Ledger, error) {
+	qStmt := `SELECT l.id, l.name, l.user_id, l.is_personal, lm.role
+						FROM ledgers AS l
+						INNER JOIN ledger_members AS lm ON lm.ledger_id = l.id
+						WHERE l.id = ? AND lm.user_id = ?`
+
+	var l domain.Ledger
+	var role string
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).Scan(&l.ID, &l.Name, &l.UserID, &l.IsPersonal, &role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Ledger{}, domain.ErrLedgerNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.Ledger{}, err
+	}
+	l.Role = domain.CvtToLedgerRoleType(role)
+
+	return l, nil
+}
+
+func (r *Repo) GetMembers(ctx context.Context, id int64) ([]domain.LedgerMember, error) {
+	qStmt := `SELECT u.id, u.name, COALESCE(u.email, ''), lm.role
+						FROM ledger_members AS lm
+						INNER JOIN users AS u ON u.id = lm.user_id
+						WHERE lm.ledger_id = ?
+						ORDER BY lm.role, u.id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, id)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var members []domain.LedgerMember
+	for rows.Next() {
+		var m domain.LedgerMember
+		var role string
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Email, &role); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+		m.Role = domain.CvtToLedgerRoleType(role)
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+func (r *Repo) UpdateMemberRole(ctx context.Context, id, userID int64, role domain.LedgerRoleType) error {
+	qStmt := `UPDATE ledger_members SET role = ? WHERE ledger_id = ? AND user_id = ?`
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, role.ToModelValue(), id, userID); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteMember(ctx context.Context, id, userID int64) error {
+	qStmt := `DELETE FROM ledger_members WHERE ledger_id = ? AND user_id = ?`
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id, userID); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) CreateInvite(ctx context.Context, invite domain.LedgerInvite) error {
+	qStmt := `INSERT INTO ledger_invites (ledger_id, email, role, invited_by) VALUES (?, ?, ?, ?)`
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, invite.LedgerID, invite.Email, invite.Role.ToModelValue(), invite.InvitedBy); err != nil {
+		if errorutil.ParseError(err, uniqueLedgerInviteEmail) {
+			return domain.ErrUniqueLedgerInviteEmail
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetInvitesByEmail(ctx context.Context, email string) ([]domain.LedgerInvite, error) {
+	qStmt := `SELECT li.id, li.ledger_id, l.name, li.email, li.role, li.invited_by
+						FROM ledger_invites AS li
+						INNER JOIN ledgers AS l ON l.id = li.ledger_id
+						WHERE li.email = ?
+						ORDER BY li.id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, email)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var invites []domain.LedgerInvite
+	for rows.Next() {
+		var i domain.LedgerInvite
+		var role string
+		if err := rows.Scan(&i.ID, &i.LedgerID, &i.LedgerName, &i.Email, &role, &i.InvitedBy); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+		i.Role = domain.CvtToLedgerRoleType(role)
+
+		invites = append(invites, i)
+	}
+
+	return invites, nil
+}
+
+func (r *Repo) GetInviteByID(ctx context.Context, id int64) (domain.LedgerInvite, error) {
+	qStmt := `SELECT li.id, li.ledger_id, l.name, li.email, li.role, li.invited_by
+						FROM ledger_invites AS li
+						INNER JOIN ledgers AS l ON l.id = li.ledger_id
+						WHERE li.id = ?`
+
+	var i domain.LedgerInvite
+	var role string
+	if err := r.DB.QueryRowContext(ctx, qStmt, id).Scan(&i.ID, &i.LedgerID, &i.LedgerName, &i.Email, &role, &i.InvitedBy); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.LedgerInvite{}, domain.ErrLedgerInviteNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.LedgerInvite{}, err
+	}
+	i.Role = domain.CvtToLedgerRoleType(role)
+
+	return i, nil
+}
+
+// AcceptInvite adds the user to the ledger with the role of the invite, and deletes the invite
+func (r *Repo) AcceptInvite(ctx context.Context, id, userID int64) error {
+	memberStmt := `INSERT INTO ledger_members (ledger_id, user_id, role)
+								 SELECT ledger_id, ?, role FROM ledger_invites WHERE id = ?`
+	inviteStmt := `DELETE FROM ledger_invites WHERE id = ?`
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, memberStmt, userID, id); err != nil {
+		if errorutil.ParseError(err, uniqueLedgerMember) {
+			return domain.ErrLedgerMemberExists
+		}
+
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, inviteStmt, id); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteInvite(ctx context.Context, id int64) error {
+	qStmt := `DELETE FROM ledger_invites WHERE id = ?`
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type LedgerSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestLedgerSuite(t *testing.T) {
+	suite.Run(t, new(LedgerSuite))
+}
+
+func (s *LedgerSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *LedgerSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *LedgerSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *LedgerSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"ledger_invites", "ledger_members", "ledgers", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *LedgerSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when no error, insert ledger with owner": create_NoError_InsertLedgerWithOwner,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_InsertLedgerWithOwner(s *LedgerSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	id, err := s.repo.Create(mockCTX, "household", users[0].ID)
+	s.Require().NoError(err, desc)
+
+	ledger, err := s.repo.GetByIDAndUserID(mockCTX, id, users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal("household", ledger.Name, desc)
+	s.Require().False(ledger.IsPersonal, desc)
+	s.Require().Equal(domain.LedgerRoleTypeOwner, ledger.Role, desc)
+
+	// the data of the ledger is owned by a new user without email
+	var email sql.NullString
+	err = s.db.QueryRow("SELECT email FROM users WHERE id = ?", ledger.UserID).Scan(&email)
+	s.Require().NoError(err, desc)
+	s.Require().False(email.Valid, desc)
+	s.Require().NotEqual(users[0].ID, ledger.UserID, desc)
+}
+
+func (s *LedgerSuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when user is not a member, return error": getByIDAndUserID_NotMember_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_NotMember_ReturnError(s *LedgerSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	id, err := s.repo.Create(mockCTX, "household", users[0].ID)
+	s.Require().NoError(err, desc)
+
+	ledger, err := s.repo.GetByIDAndUserID(mockCTX, id, users[1].ID)
+	s.Require().ErrorIs(err, domain.ErrLedgerNotFound, desc)
+	s.Require().Empty(ledger, desc)
+}
+
+func (s *LedgerSuite) TestAcceptInvite() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when no error, add member and delete invite": acceptInvite_NoError_AddMemberAndDeleteInvite,
+		"when already a member, return error":         acceptInvite_AlreadyMember_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func acceptInvite_NoError_AddMemberAndDeleteInvite(s *LedgerSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	id, err := s.repo.Create(mockCTX, "household", users[0].ID)
+	s.Require().NoError(err, desc)
+
+	invite := domain.LedgerInvite{LedgerID: id, Email: users[1].Email, Role: domain.LedgerRoleTypeViewer, InvitedBy: users[0].ID}
+	s.Require().NoError(s.repo.CreateInvite(mockCTX, invite), desc)
+
+	invites, err := s.repo.GetInvitesByEmail(mockCTX, users[1].Email)
+	s.Require().NoError(err, desc)
+	s.Require().Len(invites, 1, desc)
+	s.Require().Equal("household", invites[0].LedgerName, desc)
+
+	err = s.repo.AcceptInvite(mockCTX, invites[0].ID, users[1].ID)
+	s.Require().NoError(err, desc)
+
+	ledger, err := s.repo.GetByIDAndUserID(mockCTX, id, users[1].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.LedgerRoleTypeViewer, ledger.Role, desc)
+
+	_, err = s.repo.GetInviteByID(mockCTX, invites[0].ID)
+	s.Require().ErrorIs(err, domain.ErrLedgerInviteNotFound, desc)
+}
+
+func acceptInvite_AlreadyMember_ReturnError(s *LedgerSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	id, err := s.repo.Create(mockCTX, "household", users[0].ID)
+	s.Require().NoError(err, desc)
+
+	invite := domain.LedgerInvite{LedgerID: id, Email: users[0].Email, Role: domain.LedgerRoleTypeEditor, InvitedBy: users[0].ID}
+	s.Require().NoError(s.repo.CreateInvite(mockCTX, invite), desc)
+
+	invites, err := s.repo.GetInvitesByEmail(mockCTX, users[0].Email)
+	s.Require().NoError(err, desc)
+	s.Require().Len(invites, 1, desc)
+
+	err = s.repo.AcceptInvite(mockCTX, invites[0].ID, users[0].ID)
+	s.Require().ErrorIs(err, domain.ErrLedgerMemberExists, desc)
+}
+
+func (s *LedgerSuite) TestCreateInvite() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when email is invited twice, return error": createInvite_DuplicateEmail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func createInvite_DuplicateEmail_ReturnError(s *LedgerSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	id, err := s.repo.Create(mockCTX, "household", users[0].ID)
+	s.Require().NoError(err, desc)
+
+	invite := domain.LedgerInvite{LedgerID: id, Email: "a@a.com", Role: domain.LedgerRoleTypeEditor, InvitedBy: users[0].ID}
+	s.Require().NoError(s.repo.CreateInvite(mockCTX, invite), desc)
+
+	err = s.repo.CreateInvite(mockCTX, invite)
+	s.Require().ErrorIs(err, domain.ErrUniqueLedgerInviteEmail, desc)
+}
@@ -6,11 +6,12 @@ import (
 
 func cvtToDomainMainCateg(c MainCateg) domain.MainCateg {
 	return domain.MainCateg{
-		ID:       c.ID,
-		Name:     c.Name,
-		Type:     domain.CvtToTransactionType(c.Type),
-		IconType: domain.CvtToIconType(c.IconType),
-		IconData: c.IconData,
+		ID:         c.ID,
+		Name:       c.Name,
+		Type:       domain.CvtToTransactionType(c.Type),
+		IconType:   domain.CvtToIconType(c.IconType),
+		IconData:   c.IconData,
+		IsArchived: c.IsArchived,
 	}
 }
 
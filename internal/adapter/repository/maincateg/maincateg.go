@@ -12,8 +12,9 @@ import (
 )
 
 const (
-	uniqueNameUserType = "main_categories.unique_name_user_type"
-	packageName        = "adapter/repository/maincateg"
+	uniqueNameUserType         = "main_categories.unique_name_user_type"
+	uniqueNameUserMainCategory = "sub_categories.unique_name_user_maincategory"
+	packageName                = "adapter/repository/maincateg"
 )
 
 type Repo struct {
@@ -25,19 +26,24 @@ func New(db *sql.DB) *Repo {
 }
 
 type MainCateg struct {
-	ID       int64
-	Name     string
-	Type     string
-	UserID   int64 `gofacto:"foreignKey,struct:User"`
-	IconType string
-	IconData string
+	ID         int64
+	Name       string
+	Type       string
+	UserID     int64 `gofacto:"foreignKey,struct:User"`
+	IconType   string
+	IconData   string
+	IsArchived bool `gofacto:"omit"`
 }
 
 func (r *Repo) Create(ctx context.Context, categ domain.MainCateg, userID int64) error {
-	stmt := `INSERT INTO main_categories (name, type, user_id, icon_type, icon_data) VALUES (?, ?, ?, ?, ?)`
+	// the new main category is placed after the existing ones
+	stmt := `INSERT INTO main_categories (name, type, user_id, icon_type, icon_data, position)
+					 SELECT ?, ?, ?, ?, ?, COALESCE(MAX(position), 0) + 1
+					 FROM main_categories
+					 WHERE user_id = ?`
 
 	c := cvtToMainCateg(categ, userID)
-	if _, err := r.DB.ExecContext(ctx, stmt, c.Name, c.Type, c.UserID, c.IconType, c.IconData); err != nil {
+	if _, err := r.DB.ExecContext(ctx, stmt, c.Name, c.Type, c.UserID, c.IconType, c.IconData, c.UserID); err != nil {
 		if errorutil.ParseError(err, uniqueNameUserType) {
 			return domain.ErrUniqueNameUserType
 		}
@@ -49,17 +55,24 @@ func (r *Repo) Create(ctx context.Context, categ domain.MainCateg, userID int64)
 	return nil
 }
 
-func (r *Repo) GetAll(ctx context.Context, userID int64, transType domain.TransactionType) ([]domain.MainCateg, error) {
+func (r *Repo) GetAll(ctx context.Context, userID int64, transType domain.TransactionType, includeArchived bool) ([]domain.MainCateg, error) {
 	var sb strings.Builder
-	sb.WriteString(`SELECT id, name, type, icon_type, icon_data
+	sb.WriteString(`SELECT id, name, type, icon_type, icon_data, is_archived
 					 				FROM main_categories
 					 				WHERE user_id = ?
+									AND deleted_at IS NULL
 									`)
 
 	if transType.IsValid() {
 		sb.WriteString(` AND type = ` + transType.ToModelValue())
 	}
 
+	if !includeArchived {
+		sb.WriteString(` AND is_archived = FALSE`)
+	}
+
+	sb.WriteString(` ORDER BY position, id`)
+
 	rows, err := r.DB.QueryContext(ctx, sb.String(), userID)
 	if err != nil {
 		logger.Error("r.DB.Query failed", "package", packageName, "err", err)
@@ -74,7 +87,7 @@ func (r *Repo) GetAll(ctx context.Context, userID int64, transType domain.Transa
 	var categs []domain.MainCateg
 	for rows.Next() {
 		var categ MainCateg
-		if err := rows.Scan(&categ.ID, &categ.Name, &categ.Type, &categ.IconType, &categ.IconData); err != nil {
+		if err := rows.Scan(&categ.ID, &categ.Name, &categ.Type, &categ.IconType, &categ.IconData, &categ.IsArchived); err != nil {
 			logger.Error("rows.Scan failed", "package", packageName, "err", err)
 			return nil, err
 		}
@@ -106,11 +119,143 @@ func (r *Repo) Update(ctx context.Context, categ domain.MainCateg) error {
 	return nil
 }
 
+// Delete moves the main category to trash, along with its sub categories and transactions.
+// They share the same deleted_at, so that restoring the main category only brings back what's deleted with it.
+// The name is only unique among the live categories, so it can be used again right away.
 func (r *Repo) Delete(id int64) error {
-	stmt := `DELETE FROM main_categories WHERE id = ?`
+	tx, err := r.DB.Begin()
+	if err != nil {
+		logger.Error("r.DB.Begin failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
 
-	if _, err := r.DB.Exec(stmt, id); err != nil {
-		logger.Error("r.DB.Exec failed", "package", packageName, "err", err)
+	if err := trashMainCateg(context.Background(), tx, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// trashMainCateg moves the main category to trash in the database transaction, along with its sub categories and transactions
+func trashMainCateg(ctx context.Context, tx *sql.Tx, id int64) error {
+	categStmt := `UPDATE main_categories SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`
+	subCategStmt := `UPDATE sub_categories
+									 SET deleted_at = (SELECT deleted_at FROM main_categories WHERE id = ?)
+									 WHERE main_category_id = ? AND deleted_at IS NULL`
+	transStmt := `UPDATE transactions
+								SET deleted_at = (SELECT deleted_at FROM main_categories WHERE id = ?)
+								WHERE (main_category_id = ? OR id IN (SELECT transaction_id FROM transaction_splits WHERE main_category_id = ?))
+								AND deleted_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, categStmt, id); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, subCategStmt, id, id); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	// split transaction is moved to trash as a whole when any of its lines is in the category
+	if _, err := tx.ExecContext(ctx, transStmt, id, id, id); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// Import applies a category template import in one database transaction, so a failure leaves the categories as they were.
+// The replaced categories are archived first, then the new categories are placed after the existing ones.
+func (r *Repo) Import(ctx context.Context, input domain.ImportCategInput, userID int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	// the replaced categories still have transactions, so they're archived instead of moved to trash
+	for _, id := range input.ArchiveMainCategIDs {
+		if _, err := tx.ExecContext(ctx, `UPDATE main_categories SET is_archived = TRUE WHERE id = ?`, id); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	for _, id := range input.ArchiveSubCategIDs {
+		if _, err := tx.ExecContext(ctx, `UPDATE sub_categories SET is_archived = TRUE WHERE id = ?`, id); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	// placeholder id -> id of the created main category
+	ids := make(map[int64]int64, len(input.MainCategs))
+	mainStmt := `INSERT INTO main_categories (name, type, user_id, icon_type, icon_data, position)
+							 SELECT ?, ?, ?, ?, ?, COALESCE(MAX(position), 0) + 1
+							 FROM main_categories
+							 WHERE user_id = ?`
+	for _, categ := range input.MainCategs {
+		c := cvtToMainCateg(categ, userID)
+		res, err := tx.ExecContext(ctx, mainStmt, c.Name, c.Type, c.UserID, c.IconType, c.IconData, c.UserID)
+		if err != nil {
+			if errorutil.ParseError(err, uniqueNameUserType) {
+				return domain.ErrUniqueNameUserType
+			}
+
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+
+		if ids[categ.ID], err = res.LastInsertId(); err != nil {
+			logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	subStmt := `INSERT INTO sub_categories (name, user_id, main_category_id, position)
+							SELECT ?, ?, ?, COALESCE(MAX(position), 0) + 1
+							FROM sub_categories
+							WHERE main_category_id = ?`
+	for _, c := range input.SubCategs {
+		mainCategID := c.MainCategID
+		if mainCategID < 0 {
+			mainCategID = ids[mainCategID]
+		}
+
+		if _, err := tx.ExecContext(ctx, subStmt, c.Name, userID, mainCategID, mainCategID); err != nil {
+			if errorutil.ParseError(err, uniqueNameUserMainCategory) {
+				return domain.ErrUniqueNameUserMainCateg
+			}
+
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET is_set_init_category = true WHERE id = ?`, userID); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
 		return err
 	}
 
@@ -118,10 +263,10 @@ func (r *Repo) Delete(id int64) error {
 }
 
 func (r *Repo) GetByID(id, userID int64) (*domain.MainCateg, error) {
-	stmt := `SELECT id, name, type, icon_type, icon_data FROM main_categories WHERE id = ? AND user_id = ?`
+	stmt := `SELECT id, name, type, icon_type, icon_data, is_archived FROM main_categories WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
 
 	var categ MainCateg
-	if err := r.DB.QueryRow(stmt, id, userID).Scan(&categ.ID, &categ.Name, &categ.Type, &categ.IconType, &categ.IconData); err != nil {
+	if err := r.DB.QueryRow(stmt, id, userID).Scan(&categ.ID, &categ.Name, &categ.Type, &categ.IconType, &categ.IconData, &categ.IsArchived); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrMainCategNotFound
 		}
@@ -158,3 +303,104 @@ func (r *Repo) BatchCreate(ctx context.Context, categs []domain.MainCateg, userI
 
 	return nil
 }
+
+// Merge moves everything of the main category into the target main category, and deletes the main category.
+// Its sub categories are moved as they are, so a sub category with the same name in the target fails the merge.
+// The budget of the target is kept when both main categories have one.
+func (r *Repo) Merge(ctx context.Context, id, targetID int64) error {
+	subCategStmt := `UPDATE sub_categories SET main_category_id = ? WHERE main_category_id = ?`
+	budgetStmt := `UPDATE IGNORE budgets SET main_category_id = ? WHERE main_category_id = ?`
+	refStmts := []string{
+		`UPDATE transactions SET main_category_id = ? WHERE main_category_id = ?`,
+		`UPDATE transaction_splits SET main_category_id = ? WHERE main_category_id = ?`,
+		`UPDATE recurring_transactions SET main_category_id = ? WHERE main_category_id = ?`,
+		`UPDATE rules SET main_category_id = ? WHERE main_category_id = ?`,
+	}
+	categStmt := `DELETE FROM main_categories WHERE id = ?`
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, subCategStmt, targetID, id); err != nil {
+		if errorutil.ParseError(err, uniqueNameUserMainCategory) {
+			return domain.ErrUniqueNameUserMainCateg
+		}
+
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	for _, stmt := range refStmts {
+		if _, err := tx.ExecContext(ctx, stmt, targetID, id); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	// the budget left behind is the one ignored because the target already has one, and it's deleted along with the main category
+	if _, err := tx.ExecContext(ctx, budgetStmt, targetID, id); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, categStmt, id); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) SetArchived(ctx context.Context, id int64, archived bool) error {
+	stmt := `UPDATE main_categories SET is_archived = ? WHERE id = ?`
+
+	if _, err := r.DB.ExecContext(ctx, stmt, archived, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// Reorder sets the position of the main categories by the order of ids
+func (r *Repo) Reorder(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// the position follows the order of ids
+	var sb strings.Builder
+	sb.WriteString(`UPDATE main_categories SET position = CASE id`)
+	args := make([]interface{}, 0, len(ids)*3)
+	for i, id := range ids {
+		sb.WriteString(` WHEN ? THEN ?`)
+		args = append(args, id, i+1)
+	}
+	sb.WriteString(` END WHERE id IN (?`)
+	args = append(args, ids[0])
+	for _, id := range ids[1:] {
+		sb.WriteString(", ?")
+		args = append(args, id)
+	}
+	sb.WriteString(")")
+
+	if _, err := r.DB.ExecContext(ctx, sb.String(), args...); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -147,6 +147,8 @@ func (s *MainCategSuite) TestGetAll() {
 		"when specify expense type, return only expense type data": getAll_ExpenseType_ReturnOnlyExpenseTypeData,
 		"when specify unspecified type, return all data":           getAll_UnSpecifiedType_ReturnAllData,
 		"when multiple users, return correct data":                 getAll_MultipleUsers_ReturnCorrectData,
+		"when archived, hide unless included":                      getAll_Archived_HideUnlessIncluded,
+		"when reordered, return in order of position":              getAll_Reordered_ReturnInOrderOfPosition,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -170,7 +172,7 @@ func getAll_IncomeType_ReturnOnlyIncomeTypeData(s *MainCategSuite, desc string)
 		},
 	}
 
-	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeIncome)
+	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeIncome, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, categs, desc)
 }
@@ -189,7 +191,7 @@ func getAll_ExpenseType_ReturnOnlyExpenseTypeData(s *MainCategSuite, desc string
 		},
 	}
 
-	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeExpense)
+	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeExpense, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, categs, desc)
 }
@@ -215,7 +217,7 @@ func getAll_UnSpecifiedType_ReturnAllData(s *MainCategSuite, desc string) {
 		},
 	}
 
-	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeUnSpecified)
+	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeUnSpecified, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, categs, desc)
 }
@@ -241,7 +243,7 @@ func getAll_MultipleUsers_ReturnCorrectData(s *MainCategSuite, desc string) {
 		},
 	}
 
-	categs, err := s.mainCategRepo.GetAll(mockCTX, users[1].ID, domain.TransactionTypeUnSpecified)
+	categs, err := s.mainCategRepo.GetAll(mockCTX, users[1].ID, domain.TransactionTypeUnSpecified, false)
 	s.Require().NoError(err)
 	s.Require().Equal(expResult, categs)
 }
@@ -341,7 +343,9 @@ func update_DuplicateName_ReturnError(s *MainCategSuite, desc string) {
 
 func (s *MainCategSuite) TestDelete() {
 	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
-		"when no error, delete successfully": delete_NoError_DeleteSuccessfully,
+		"when no error, delete successfully":                   delete_NoError_DeleteSuccessfully,
+		"when has sub categories, move them to trash together": delete_HasSubCategs_MoveThemToTrashTogether,
+		"when name used again, create successfully":            delete_NameUsedAgain_CreateSuccessfully,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -361,11 +365,65 @@ func delete_NoError_DeleteSuccessfully(s *MainCategSuite, desc string) {
 	checkStmt := `SELECT id
 							 FROM main_categories
 							 WHERE id = ?
+							 AND deleted_at IS NULL
 							 `
 	err = s.db.QueryRow(checkStmt, categ.ID).Scan(&categ.ID)
 	s.Require().EqualError(err, sql.ErrNoRows.Error(), desc)
 }
 
+func delete_HasSubCategs_MoveThemToTrashTogether(s *MainCategSuite, desc string) {
+	categ, user, err := s.f.InsertMainCategWithAss(mockCTX, MainCateg{})
+	s.Require().NoError(err, desc)
+
+	_, err = s.db.Exec("INSERT INTO sub_categories (name, user_id, main_category_id) VALUES (?, ?, ?)", "sub", user.ID, categ.ID)
+	s.Require().NoError(err, desc)
+
+	err = s.mainCategRepo.Delete(categ.ID)
+	s.Require().NoError(err, desc)
+
+	// the sub category shares the deleted time with the main category, so they can be restored together
+	var count int
+	checkStmt := `SELECT COUNT(*)
+							 FROM sub_categories AS sc
+							 INNER JOIN main_categories AS mc
+							 ON sc.main_category_id = mc.id
+							 WHERE mc.id = ?
+							 AND sc.deleted_at IS NOT NULL
+							 AND sc.deleted_at = mc.deleted_at
+							 `
+	err = s.db.QueryRow(checkStmt, categ.ID).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(1, count, desc)
+}
+
+func delete_NameUsedAgain_CreateSuccessfully(s *MainCategSuite, desc string) {
+	categ, user, err := s.f.InsertMainCategWithAss(mockCTX, MainCateg{})
+	s.Require().NoError(err, desc)
+
+	_, err = s.db.Exec("INSERT INTO sub_categories (name, user_id, main_category_id) VALUES (?, ?, ?)", "sub", user.ID, categ.ID)
+	s.Require().NoError(err, desc)
+
+	err = s.mainCategRepo.Delete(categ.ID)
+	s.Require().NoError(err, desc)
+
+	// the name in trash doesn't block a new category, nor a second one moved to trash
+	for i := 0; i < 2; i++ {
+		newCateg := domain.MainCateg{Name: categ.Name, Type: domain.CvtToTransactionType(categ.Type), IconType: domain.IconTypeDefault, IconData: "url"}
+		err = s.mainCategRepo.Create(mockCTX, newCateg, user.ID)
+		s.Require().NoError(err, desc)
+
+		var id int64
+		err = s.db.QueryRow("SELECT id FROM main_categories WHERE user_id = ? AND name = ? AND deleted_at IS NULL", user.ID, categ.Name).Scan(&id)
+		s.Require().NoError(err, desc)
+
+		_, err = s.db.Exec("INSERT INTO sub_categories (name, user_id, main_category_id) VALUES (?, ?, ?)", "sub", user.ID, id)
+		s.Require().NoError(err, desc)
+
+		err = s.mainCategRepo.Delete(id)
+		s.Require().NoError(err, desc)
+	}
+}
+
 func (s *MainCategSuite) TestGetByID() {
 	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
 		"when has data, return successfully":     getByID_NoError_ReturnSuccessfully,
@@ -580,3 +638,214 @@ func createBatch_AlreadyExistData_ReturnError(s *MainCategSuite, desc string) {
 	err = s.db.QueryRow(stms, user.ID).Scan(&count)
 	s.Require().NoError(err, desc)
 }
+
+func (s *MainCategSuite) TestMerge() {
+	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
+		"when no name conflict, move everything into target": merge_NoNameConflict_MoveEverythingIntoTarget,
+		"when sub category name conflict, return error":      merge_SubCategNameConflict_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func merge_NoNameConflict_MoveEverythingIntoTarget(s *MainCategSuite, desc string) {
+	source, target, userID := s.prepareMergeCategs(desc)
+
+	_, err := s.db.Exec("INSERT INTO sub_categories (name, user_id, main_category_id) VALUES ('lunch', ?, ?)", userID, source)
+	s.Require().NoError(err, desc)
+	var subID int64
+	s.Require().NoError(s.db.QueryRow("SELECT id FROM sub_categories WHERE main_category_id = ?", source).Scan(&subID), desc)
+
+	_, err = s.db.Exec("INSERT INTO transactions (user_id, type, main_category_id, sub_category_id, price, date) VALUES (?, '2', ?, ?, 10, '2024-03-01')", userID, source, subID)
+	s.Require().NoError(err, desc)
+	_, err = s.db.Exec("INSERT INTO budgets (user_id, main_category_id, amount) VALUES (?, ?, 100), (?, ?, 200)", userID, source, userID, target)
+	s.Require().NoError(err, desc)
+
+	err = s.mainCategRepo.Merge(mockCTX, source, target)
+	s.Require().NoError(err, desc)
+
+	// the source is deleted
+	_, err = s.mainCategRepo.GetByID(source, userID)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotFound, desc)
+
+	var subMainCategID, transMainCategID int64
+	s.Require().NoError(s.db.QueryRow("SELECT main_category_id FROM sub_categories WHERE id = ?", subID).Scan(&subMainCategID), desc)
+	s.Require().NoError(s.db.QueryRow("SELECT main_category_id FROM transactions WHERE sub_category_id = ?", subID).Scan(&transMainCategID), desc)
+	s.Require().Equal(target, subMainCategID, desc)
+	s.Require().Equal(target, transMainCategID, desc)
+
+	// the budget of the target is kept
+	var amount float64
+	s.Require().NoError(s.db.QueryRow("SELECT amount FROM budgets WHERE user_id = ?", userID).Scan(&amount), desc)
+	s.Require().Equal(200.0, amount, desc)
+}
+
+func merge_SubCategNameConflict_ReturnError(s *MainCategSuite, desc string) {
+	source, target, userID := s.prepareMergeCategs(desc)
+
+	_, err := s.db.Exec("INSERT INTO sub_categories (name, user_id, main_category_id) VALUES ('lunch', ?, ?), ('lunch', ?, ?)", userID, source, userID, target)
+	s.Require().NoError(err, desc)
+
+	err = s.mainCategRepo.Merge(mockCTX, source, target)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUserMainCateg, desc)
+
+	// nothing is changed
+	_, err = s.mainCategRepo.GetByID(source, userID)
+	s.Require().NoError(err, desc)
+}
+
+// prepareMergeCategs inserts two expense main categories of a user, and returns their ids and the user id
+func (s *MainCategSuite) prepareMergeCategs(desc string) (int64, int64, int64) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	for _, name := range []string{"food", "meal"} {
+		categ := domain.MainCateg{Name: name, Type: domain.TransactionTypeExpense, IconType: domain.IconTypeDefault, IconData: name}
+		s.Require().NoError(s.mainCategRepo.Create(mockCTX, categ, users[0].ID), desc)
+	}
+
+	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeUnSpecified, false)
+	s.Require().NoError(err, desc)
+	s.Require().Len(categs, 2, desc)
+
+	return categs[0].ID, categs[1].ID, users[0].ID
+}
+
+func getAll_Archived_HideUnlessIncluded(s *MainCategSuite, desc string) {
+	mainCategList, users, err := s.f.InsertMainCategListWithAss(mockCTX, 2, 1, 2, "expense", "expense")
+	s.Require().NoError(err, desc)
+
+	err = s.mainCategRepo.SetArchived(mockCTX, mainCategList[0].ID, true)
+	s.Require().NoError(err, desc)
+
+	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeUnSpecified, false)
+	s.Require().NoError(err, desc)
+	s.Require().Len(categs, 1, desc)
+	s.Require().Equal(mainCategList[1].ID, categs[0].ID, desc)
+
+	categs, err = s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeUnSpecified, true)
+	s.Require().NoError(err, desc)
+	s.Require().Len(categs, 2, desc)
+	s.Require().True(categs[0].IsArchived, desc)
+
+	// archived main category can still be found by id
+	categ, err := s.mainCategRepo.GetByID(mainCategList[0].ID, users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().True(categ.IsArchived, desc)
+}
+
+func getAll_Reordered_ReturnInOrderOfPosition(s *MainCategSuite, desc string) {
+	mainCategList, users, err := s.f.InsertMainCategListWithAss(mockCTX, 3, 1, 3, "expense", "expense", "expense")
+	s.Require().NoError(err, desc)
+
+	err = s.mainCategRepo.Reorder(mockCTX, []int64{mainCategList[2].ID, mainCategList[0].ID, mainCategList[1].ID})
+	s.Require().NoError(err, desc)
+
+	categs, err := s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeUnSpecified, false)
+	s.Require().NoError(err, desc)
+
+	ids := make([]int64, 0, len(categs))
+	for _, c := range categs {
+		ids = append(ids, c.ID)
+	}
+	s.Require().Equal([]int64{mainCategList[2].ID, mainCategList[0].ID, mainCategList[1].ID}, ids, desc)
+
+	// the new main category is placed at the end
+	newCateg := domain.MainCateg{Name: "new", Type: domain.TransactionTypeExpense, IconType: domain.IconTypeDefault, IconData: "url"}
+	s.Require().NoError(s.mainCategRepo.Create(mockCTX, newCateg, users[0].ID), desc)
+
+	categs, err = s.mainCategRepo.GetAll(mockCTX, users[0].ID, domain.TransactionTypeUnSpecified, false)
+	s.Require().NoError(err, desc)
+	s.Require().Equal("new", categs[len(categs)-1].Name, desc)
+}
+
+func (s *MainCategSuite) TestImport() {
+	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
+		"when no error, apply all changes":      import_NoError_ApplyAll,
+		"when one insert fails, change nothing": import_OneInsertFail_ChangeNothing,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func import_NoError_ApplyAll(s *MainCategSuite, desc string) {
+	source, target, userID := s.prepareMergeCategs(desc)
+
+	_, err := s.db.Exec("INSERT INTO sub_categories (name, user_id, main_category_id) VALUES ('lunch', ?, ?), ('dinner', ?, ?)", userID, target, userID, target)
+	s.Require().NoError(err, desc)
+	var dinnerID int64
+	s.Require().NoError(s.db.QueryRow("SELECT id FROM sub_categories WHERE name = 'dinner'").Scan(&dinnerID), desc)
+	_, err = s.db.Exec("INSERT INTO transactions (user_id, type, main_category_id, sub_category_id, price, date) VALUES (?, '2', ?, ?, 10, '2024-03-01')", userID, target, dinnerID)
+	s.Require().NoError(err, desc)
+
+	input := domain.ImportCategInput{
+		ArchiveMainCategIDs: []int64{source},
+		ArchiveSubCategIDs:  []int64{dinnerID},
+		MainCategs:          []domain.MainCateg{{ID: -1, Name: "pets", Type: domain.TransactionTypeExpense, IconType: domain.IconTypeDefault, IconData: "url"}},
+		SubCategs: []domain.SubCateg{
+			{Name: "vet", MainCategID: -1},
+			{Name: "snacks", MainCategID: target},
+		},
+	}
+	err = s.mainCategRepo.Import(mockCTX, input, userID)
+	s.Require().NoError(err, desc)
+
+	categs, err := s.mainCategRepo.GetAll(mockCTX, userID, domain.TransactionTypeUnSpecified, false)
+	s.Require().NoError(err, desc)
+	names := make([]string, 0, len(categs))
+	for _, c := range categs {
+		names = append(names, c.Name)
+	}
+	s.Require().Equal([]string{"meal", "pets"}, names, desc)
+
+	// the replaced categories are archived, not moved to trash
+	categ, err := s.mainCategRepo.GetByID(source, userID)
+	s.Require().NoError(err, desc)
+	s.Require().True(categ.IsArchived, desc)
+
+	var subNames []string
+	rows, err := s.db.Query("SELECT name FROM sub_categories WHERE user_id = ? AND deleted_at IS NULL AND is_archived = FALSE ORDER BY id", userID)
+	s.Require().NoError(err, desc)
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		s.Require().NoError(rows.Scan(&name), desc)
+		subNames = append(subNames, name)
+	}
+	s.Require().Equal([]string{"lunch", "vet", "snacks"}, subNames, desc)
+
+	// the transactions of the archived categories are kept
+	var transCount int
+	s.Require().NoError(s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE sub_category_id = ? AND deleted_at IS NULL", dinnerID).Scan(&transCount), desc)
+	s.Require().Equal(1, transCount, desc)
+
+	var isSetInitCategory bool
+	s.Require().NoError(s.db.QueryRow("SELECT is_set_init_category FROM users WHERE id = ?", userID).Scan(&isSetInitCategory), desc)
+	s.Require().True(isSetInitCategory, desc)
+}
+
+func import_OneInsertFail_ChangeNothing(s *MainCategSuite, desc string) {
+	source, target, userID := s.prepareMergeCategs(desc)
+
+	// the new main category uses the name of an existing one regardless of case
+	input := domain.ImportCategInput{
+		ArchiveMainCategIDs: []int64{source},
+		MainCategs:          []domain.MainCateg{{ID: -1, Name: "MEAL", Type: domain.TransactionTypeExpense, IconType: domain.IconTypeDefault, IconData: "url"}},
+	}
+	err := s.mainCategRepo.Import(mockCTX, input, userID)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUserType, desc)
+
+	// the main category isn't archived
+	_, err = s.mainCategRepo.GetByID(source, userID)
+	s.Require().NoError(err, desc)
+	_, err = s.mainCategRepo.GetByID(target, userID)
+	s.Require().NoError(err, desc)
+}
@@ -27,6 +27,7 @@ type MonthlyTrans struct {
 	MonthDate    time.Time
 	TotalExpense float64
 	TotalIncome  float64
+	Unconverted  int64 `gofacto:"omit"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -37,16 +38,16 @@ func New(db *sql.DB) *Repo {
 
 func (r *Repo) Create(ctx context.Context, date time.Time, trans []domain.MonthlyAggregatedData) error {
 	var sb strings.Builder
-	sb.WriteString("INSERT INTO monthly_transactions (user_id, month_date, total_expense, total_income) VALUES ")
+	sb.WriteString("INSERT INTO monthly_transactions (user_id, month_date, total_expense, total_income, unconverted) VALUES ")
 
-	args := make([]interface{}, 0, len(trans)*4)
+	args := make([]interface{}, 0, len(trans)*5)
 	for i, t := range trans {
-		sb.WriteString("(?, ?, ?, ?)")
+		sb.WriteString("(?, ?, ?, ?, ?)")
 		if i < len(trans)-1 {
 			sb.WriteString(", ")
 		}
 
-		args = append(args, t.UserID, date, t.TotalExpense, t.TotalIncome)
+		args = append(args, t.UserID, date, t.TotalExpense, t.TotalIncome, t.Unconverted)
 	}
 
 	stmt := sb.String()
@@ -63,11 +64,13 @@ func (r *Repo) Create(ctx context.Context, date time.Time, trans []domain.Monthl
 }
 
 func (r *Repo) GetByUserIDAndMonthDate(ctx context.Context, userID int64, monthDate time.Time) (domain.AccInfo, error) {
-	query := `SELECT * FROM monthly_transactions WHERE user_id = ? AND month_date = ?`
+	query := `SELECT id, user_id, month_date, total_expense, total_income, unconverted, created_at, updated_at
+						FROM monthly_transactions
+						WHERE user_id = ? AND month_date = ?`
 
 	var mt MonthlyTrans
 	row := r.DB.QueryRowContext(ctx, query, userID, monthDate)
-	if err := row.Scan(&mt.ID, &mt.UserID, &mt.MonthDate, &mt.TotalExpense, &mt.TotalIncome, &mt.CreatedAt, &mt.UpdatedAt); err != nil {
+	if err := row.Scan(&mt.ID, &mt.UserID, &mt.MonthDate, &mt.TotalExpense, &mt.TotalIncome, &mt.Unconverted, &mt.CreatedAt, &mt.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.AccInfo{}, domain.ErrDataNotFound
 		}
@@ -80,5 +83,6 @@ func (r *Repo) GetByUserIDAndMonthDate(ctx context.Context, userID int64, monthD
 		TotalExpense: mt.TotalExpense,
 		TotalIncome:  mt.TotalIncome,
 		TotalBalance: mt.TotalIncome - mt.TotalExpense,
+		Unconverted:  mt.Unconverted,
 	}, nil
 }
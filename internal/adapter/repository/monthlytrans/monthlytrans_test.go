@@ -107,6 +107,7 @@ func create_OneData_InsertSuccessfully(s *MonthlyTransSuite, desc string) {
 			UserID:       users[0].ID,
 			TotalExpense: 100,
 			TotalIncome:  200,
+			Unconverted:  1,
 		},
 	}
 
@@ -187,7 +188,7 @@ func create_SameUserAndDate_ReturnError(s *MonthlyTransSuite, desc string) {
 
 func getMonthlyTrans(s *MonthlyTransSuite, date time.Time) []domain.MonthlyAggregatedData {
 	stmt := `
-		SELECT user_id, total_expense, total_income
+		SELECT user_id, total_expense, total_income, unconverted
 		FROM monthly_transactions
 		WHERE month_date = ?
 	`
@@ -202,7 +203,7 @@ func getMonthlyTrans(s *MonthlyTransSuite, date time.Time) []domain.MonthlyAggre
 	createdTrans := []domain.MonthlyAggregatedData{}
 	for rows.Next() {
 		var trans domain.MonthlyAggregatedData
-		err := rows.Scan(&trans.UserID, &trans.TotalExpense, &trans.TotalIncome)
+		err := rows.Scan(&trans.UserID, &trans.TotalExpense, &trans.TotalIncome, &trans.Unconverted)
 		s.Require().NoError(err)
 
 		createdTrans = append(createdTrans, trans)
@@ -0,0 +1,43 @@
+package recurringtrans
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelRecurringTrans(rt domain.RecurringTrans) RecurringTrans {
+	return RecurringTrans{
+		ID:            rt.ID,
+		UserID:        rt.Template.UserID,
+		Type:          rt.Template.Type.ToModelValue(),
+		MainCategID:   rt.Template.MainCategID,
+		SubCategID:    rt.Template.SubCategID,
+		Price:         rt.Template.Price,
+		Currency:      rt.Template.Currency,
+		Note:          rt.Template.Note,
+		Frequency:     rt.Schedule.Freq.ToModelValue(),
+		IntervalCount: rt.Schedule.Interval,
+		StartDate:     rt.Schedule.StartDate,
+		EndDate:       rt.Schedule.EndDate,
+		NextDate:      rt.NextDate,
+	}
+}
+
+func cvtToDomainRecurringTrans(m RecurringTrans) domain.RecurringTrans {
+	return domain.RecurringTrans{
+		ID: m.ID,
+		Template: domain.CreateTransactionInput{
+			UserID:      m.UserID,
+			Type:        domain.CvtToTransactionType(m.Type),
+			MainCategID: m.MainCategID,
+			SubCategID:  m.SubCategID,
+			Price:       m.Price,
+			Currency:    m.Currency,
+			Note:        m.Note,
+		},
+		Schedule: domain.RecurringSchedule{
+			Freq:      domain.CvtToRecurFreqType(m.Frequency),
+			Interval:  m.IntervalCount,
+			StartDate: m.StartDate,
+			EndDate:   m.EndDate,
+		},
+		NextDate: m.NextDate,
+	}
+}
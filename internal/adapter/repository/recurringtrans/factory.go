@@ -0,0 +1,82 @@
+package recurringtrans
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/maincateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/subcateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/user"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/gofacto"
+	"github.com/eyo-chen/gofacto/db/mysqlf"
+)
+
+var (
+	mockLocF, _  = time.LoadLocation("")
+	mockTimeNowF = time.Unix(1629446406, 0).Truncate(24 * time.Hour).In(mockLocF)
+)
+
+type factory struct {
+	recurringTrans *gofacto.Factory[RecurringTrans]
+	subcateg       *gofacto.Factory[subcateg.SubCateg]
+}
+
+func blueprint(i int) RecurringTrans {
+	return RecurringTrans{
+		Type:          domain.TransactionTypeExpense.ToModelValue(),
+		Price:         float64(i*10.0 + 1.0),
+		Currency:      domain.DefaultCurrency,
+		Note:          "test" + fmt.Sprint(i),
+		Frequency:     domain.RecurFreqTypeMonthly.ToModelValue(),
+		IntervalCount: 1,
+		StartDate:     mockTimeNowF,
+		NextDate:      mockTimeNowF,
+	}
+}
+
+func newFactory(db *sql.DB) *factory {
+	return &factory{
+		recurringTrans: gofacto.New(RecurringTrans{}).
+			WithDB(mysqlf.NewConfig(db)).
+			WithStorageName("recurring_transactions").
+			WithBlueprint(blueprint),
+		subcateg: gofacto.New(subcateg.SubCateg{}).
+			WithDB(mysqlf.NewConfig(db)).
+			WithStorageName("sub_categories"),
+	}
+}
+
+// PrepareUserMainAndSubCateg inserts a user with a main category and a sub category
+func (f *factory) PrepareUserMainAndSubCateg(ctx context.Context) (user.User, maincateg.MainCateg, subcateg.SubCateg, error) {
+	u := user.User{}
+	m := maincateg.MainCateg{Type: domain.TransactionTypeExpense.ToModelValue(), IconType: domain.IconTypeDefault.ToModelValue()}
+
+	s, err := f.subcateg.Build(ctx).WithOne(&u, &m).Insert()
+	if err != nil {
+		return user.User{}, maincateg.MainCateg{}, subcateg.SubCateg{}, err
+	}
+
+	return u, m, s, nil
+}
+
+// InsertRecurringTransWithGivenCateg inserts recurring transactions with given user, main category and sub category
+func (f *factory) InsertRecurringTransWithGivenCateg(ctx context.Context, i int, u user.User, m maincateg.MainCateg, s subcateg.SubCateg, ow ...RecurringTrans) ([]RecurringTrans, error) {
+	owCateg := make([]RecurringTrans, i)
+	for k := 0; k < i; k++ {
+		owCateg[k] = RecurringTrans{
+			UserID:      u.ID,
+			MainCategID: m.ID,
+			SubCategID:  s.ID,
+		}
+	}
+
+	return f.recurringTrans.BuildList(ctx, i).Overwrites(owCateg...).Overwrites(ow...).Insert()
+}
+
+func (f *factory) Reset() {
+	f.recurringTrans.Reset()
+	f.subcateg.Reset()
+}
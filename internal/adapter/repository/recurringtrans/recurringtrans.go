@@ -0,0 +1,170 @@
+package recurringtrans
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/repository/recurringtrans"
+
+	// currencyOrBaseValue falls back to the user's base currency when the currency is empty
+	currencyOrBaseValue = "COALESCE(NULLIF(?, ''), (SELECT base_currency FROM users WHERE id = ?))"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+type RecurringTrans struct {
+	ID            int64
+	UserID        int64 `gofacto:"foreignKey,struct:User"`
+	Type          string
+	MainCategID   int64 `gofacto:"foreignKey,struct:MainCateg,table:main_categories" mysqlf:"main_category_id"`
+	SubCategID    int64 `gofacto:"foreignKey,struct:SubCateg,table:sub_categories" mysqlf:"sub_category_id"`
+	Price         float64
+	Currency      string
+	Note          string
+	Frequency     string
+	IntervalCount int
+	StartDate     time.Time
+	EndDate       *time.Time `gofacto:"omit"`
+	NextDate      time.Time
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Create(ctx context.Context, rt domain.RecurringTrans) error {
+	m := cvtToModelRecurringTrans(rt)
+	qStmt := `INSERT INTO recurring_transactions (user_id, type, main_category_id, sub_category_id, price, currency, note, frequency, interval_count, start_date, end_date, next_date)
+						VALUES (?, ?, ?, ?, ?, ` + currencyOrBaseValue + `, ?, ?, ?, ?, ?, ?)`
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, m.UserID, m.Type, m.MainCategID, m.SubCategID, m.Price, m.Currency, m.UserID, m.Note, m.Frequency, m.IntervalCount, m.StartDate, m.EndDate, m.NextDate); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.RecurringTrans, error) {
+	qStmt := `SELECT id, user_id, type, main_category_id, sub_category_id, price, currency, note, frequency, interval_count, start_date, end_date, next_date
+						FROM recurring_transactions
+						WHERE user_id = ?
+						ORDER BY next_date, id`
+
+	return r.query(ctx, qStmt, userID)
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.RecurringTrans, error) {
+	qStmt := `SELECT id, user_id, type, main_category_id, sub_category_id, price, currency, note, frequency, interval_count, start_date, end_date, next_date
+						FROM recurring_transactions
+						WHERE id = ? AND user_id = ?`
+
+	var m RecurringTrans
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).
+		Scan(&m.ID, &m.UserID, &m.Type, &m.MainCategID, &m.SubCategID, &m.Price, &m.Currency, &m.Note, &m.Frequency, &m.IntervalCount, &m.StartDate, &m.EndDate, &m.NextDate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.RecurringTrans{}, domain.ErrRecurringTransNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.RecurringTrans{}, err
+	}
+
+	return cvtToDomainRecurringTrans(m), nil
+}
+
+func (r *Repo) Update(ctx context.Context, rt domain.RecurringTrans) error {
+	m := cvtToModelRecurringTrans(rt)
+	qStmt := `UPDATE recurring_transactions
+						SET type = ?, main_category_id = ?, sub_category_id = ?, price = ?, currency = ` + currencyOrBaseValue + `, note = ?, frequency = ?, interval_count = ?, start_date = ?, end_date = ?, next_date = ?
+						WHERE id = ?`
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, m.Type, m.MainCategID, m.SubCategID, m.Price, m.Currency, m.UserID, m.Note, m.Frequency, m.IntervalCount, m.StartDate, m.EndDate, m.NextDate, m.ID); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM recurring_transactions WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetDue(ctx context.Context, date time.Time) ([]domain.RecurringTrans, error) {
+	qStmt := `SELECT id, user_id, type, main_category_id, sub_category_id, price, currency, note, frequency, interval_count, start_date, end_date, next_date
+						FROM recurring_transactions
+						WHERE next_date <= ?
+						AND (end_date IS NULL OR next_date <= end_date)
+						AND NOT EXISTS (SELECT 1 FROM main_categories AS mc WHERE mc.id = main_category_id AND mc.deleted_at IS NOT NULL)
+						AND NOT EXISTS (SELECT 1 FROM sub_categories AS sc WHERE sc.id = sub_category_id AND sc.deleted_at IS NOT NULL)
+						ORDER BY id`
+
+	return r.query(ctx, qStmt, date)
+}
+
+func (r *Repo) UpdateNextDate(ctx context.Context, id int64, from, to time.Time) error {
+	// only move the next date when it's still the one we read
+	// it makes sure the same occurrence is never materialized twice by concurrent runs
+	qStmt := "UPDATE recurring_transactions SET next_date = ? WHERE id = ? AND next_date = ?"
+
+	res, err := r.DB.ExecContext(ctx, qStmt, to, id, from)
+	if err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("res.RowsAffected failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrRecurringTransNotDue
+	}
+
+	return nil
+}
+
+func (r *Repo) query(ctx context.Context, qStmt string, args ...interface{}) ([]domain.RecurringTrans, error) {
+	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var list []domain.RecurringTrans
+	for rows.Next() {
+		var m RecurringTrans
+		if err := rows.Scan(&m.ID, &m.UserID, &m.Type, &m.MainCategID, &m.SubCategID, &m.Price, &m.Currency, &m.Note, &m.Frequency, &m.IntervalCount, &m.StartDate, &m.EndDate, &m.NextDate); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		list = append(list, cvtToDomainRecurringTrans(m))
+	}
+
+	return list, nil
+}
@@ -0,0 +1,278 @@
+package recurringtrans
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX     = context.Background()
+	mockLoc, _  = time.LoadLocation("")
+	mockTimeNow = time.Unix(1629446406, 0).Truncate(24 * time.Hour).In(mockLoc)
+)
+
+type RecurringTransSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestRecurringTransSuite(t *testing.T) {
+	suite.Run(t, new(RecurringTransSuite))
+}
+
+func (s *RecurringTransSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *RecurringTransSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *RecurringTransSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *RecurringTransSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	_, err = tx.Exec("DELETE FROM recurring_transactions")
+	s.Require().NoError(err)
+
+	_, err = tx.Exec("DELETE FROM sub_categories")
+	s.Require().NoError(err)
+
+	_, err = tx.Exec("DELETE FROM main_categories")
+	s.Require().NoError(err)
+
+	_, err = tx.Exec("DELETE FROM users")
+	s.Require().NoError(err)
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *RecurringTransSuite) TestCreate() {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err)
+
+	endDate := mockTimeNow.AddDate(1, 0, 0)
+	rt := domain.RecurringTrans{
+		Template: domain.CreateTransactionInput{
+			UserID:      user.ID,
+			Type:        domain.TransactionTypeExpense,
+			MainCategID: main.ID,
+			SubCategID:  sub.ID,
+			Price:       100,
+			Note:        "rent",
+		},
+		Schedule: domain.RecurringSchedule{
+			Freq:      domain.RecurFreqTypeMonthly,
+			Interval:  1,
+			StartDate: mockTimeNow,
+			EndDate:   &endDate,
+		},
+		NextDate: mockTimeNow,
+	}
+
+	err = s.repo.Create(mockCTX, rt)
+	s.Require().NoError(err)
+
+	list, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+	s.Require().Len(list, 1)
+
+	rt.ID = list[0].ID
+	// the currency falls back to the user's base currency
+	rt.Template.Currency = domain.DefaultCurrency
+	s.Require().Equal(rt, list[0])
+
+	s.TearDownTest()
+}
+
+func (s *RecurringTransSuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when data exists, return data":          getByIDAndUserID_DataExists_ReturnData,
+		"when user not match, return not found":  getByIDAndUserID_UserNotMatch_ReturnNotFound,
+		"when data not exists, return not found": getByIDAndUserID_DataNotExists_ReturnNotFound,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_DataExists_ReturnData(s *RecurringTransSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	list, err := s.f.InsertRecurringTransWithGivenCateg(mockCTX, 1, user, main, sub)
+	s.Require().NoError(err, desc)
+
+	rt, err := s.repo.GetByIDAndUserID(mockCTX, list[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(cvtToDomainRecurringTrans(list[0]), rt, desc)
+}
+
+func getByIDAndUserID_UserNotMatch_ReturnNotFound(s *RecurringTransSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	list, err := s.f.InsertRecurringTransWithGivenCateg(mockCTX, 1, user, main, sub)
+	s.Require().NoError(err, desc)
+
+	rt, err := s.repo.GetByIDAndUserID(mockCTX, list[0].ID, user.ID+1)
+	s.Require().ErrorIs(err, domain.ErrRecurringTransNotFound, desc)
+	s.Require().Empty(rt, desc)
+}
+
+func getByIDAndUserID_DataNotExists_ReturnNotFound(s *RecurringTransSuite, desc string) {
+	rt, err := s.repo.GetByIDAndUserID(mockCTX, 999, 1)
+	s.Require().ErrorIs(err, domain.ErrRecurringTransNotFound, desc)
+	s.Require().Empty(rt, desc)
+}
+
+func (s *RecurringTransSuite) TestGetDue() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when next date is before or on the date, return data": getDue_NextDateBeforeDate_ReturnData,
+		"when schedule is ended, exclude data":                 getDue_ScheduleEnded_ExcludeData,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getDue_NextDateBeforeDate_ReturnData(s *RecurringTransSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	ow1 := RecurringTrans{NextDate: mockTimeNow.AddDate(0, 0, -1)}
+	ow2 := RecurringTrans{NextDate: mockTimeNow}
+	ow3 := RecurringTrans{NextDate: mockTimeNow.AddDate(0, 0, 1)}
+	list, err := s.f.InsertRecurringTransWithGivenCateg(mockCTX, 3, user, main, sub, ow1, ow2, ow3)
+	s.Require().NoError(err, desc)
+
+	expResult := []domain.RecurringTrans{
+		cvtToDomainRecurringTrans(list[0]),
+		cvtToDomainRecurringTrans(list[1]),
+	}
+
+	result, err := s.repo.GetDue(mockCTX, mockTimeNow)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getDue_ScheduleEnded_ExcludeData(s *RecurringTransSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	endDate := mockTimeNow.AddDate(0, 0, -2)
+	ow1 := RecurringTrans{NextDate: mockTimeNow.AddDate(0, 0, -1), EndDate: &endDate}
+	ow2 := RecurringTrans{NextDate: mockTimeNow}
+	list, err := s.f.InsertRecurringTransWithGivenCateg(mockCTX, 2, user, main, sub, ow1, ow2)
+	s.Require().NoError(err, desc)
+
+	expResult := []domain.RecurringTrans{
+		cvtToDomainRecurringTrans(list[1]),
+	}
+
+	result, err := s.repo.GetDue(mockCTX, mockTimeNow)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func (s *RecurringTransSuite) TestUpdateNextDate() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when next date matches, update successfully": updateNextDate_NextDateMatches_UpdateSuccessfully,
+		"when next date not matches, return error":    updateNextDate_NextDateNotMatches_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func updateNextDate_NextDateMatches_UpdateSuccessfully(s *RecurringTransSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	list, err := s.f.InsertRecurringTransWithGivenCateg(mockCTX, 1, user, main, sub)
+	s.Require().NoError(err, desc)
+
+	nextDate := mockTimeNow.AddDate(0, 1, 0)
+	err = s.repo.UpdateNextDate(mockCTX, list[0].ID, mockTimeNow, nextDate)
+	s.Require().NoError(err, desc)
+
+	rt, err := s.repo.GetByIDAndUserID(mockCTX, list[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(nextDate, rt.NextDate, desc)
+}
+
+func updateNextDate_NextDateNotMatches_ReturnError(s *RecurringTransSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	list, err := s.f.InsertRecurringTransWithGivenCateg(mockCTX, 1, user, main, sub)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.UpdateNextDate(mockCTX, list[0].ID, mockTimeNow.AddDate(0, 0, -1), mockTimeNow.AddDate(0, 1, 0))
+	s.Require().ErrorIs(err, domain.ErrRecurringTransNotDue, desc)
+
+	rt, err := s.repo.GetByIDAndUserID(mockCTX, list[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(mockTimeNow, rt.NextDate, desc)
+}
+
+func (s *RecurringTransSuite) TestDelete() {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err)
+
+	list, err := s.f.InsertRecurringTransWithGivenCateg(mockCTX, 1, user, main, sub)
+	s.Require().NoError(err)
+
+	err = s.repo.Delete(mockCTX, list[0].ID)
+	s.Require().NoError(err)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, list[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrRecurringTransNotFound)
+
+	s.TearDownTest()
+}
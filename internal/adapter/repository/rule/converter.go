@@ -0,0 +1,43 @@
+package rule
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelRule(r domain.Rule, userID int64) Rule {
+	return Rule{
+		ID:           r.ID,
+		UserID:       userID,
+		Name:         r.Name,
+		Priority:     r.Priority,
+		Type:         r.Type.ToModelValue(),
+		NoteContains: r.NoteContains,
+		NoteRegex:    r.NoteRegex,
+		MinPrice:     r.MinPrice,
+		MaxPrice:     r.MaxPrice,
+		MainCategID:  r.MainCateg.ID,
+		SubCategID:   r.SubCateg.ID,
+	}
+}
+
+func cvtToDomainRule(m Rule) domain.Rule {
+	transType := domain.CvtToTransactionType(m.Type)
+	return domain.Rule{
+		ID:           m.ID,
+		Name:         m.Name,
+		Priority:     m.Priority,
+		Type:         transType,
+		NoteContains: m.NoteContains,
+		NoteRegex:    m.NoteRegex,
+		MinPrice:     m.MinPrice,
+		MaxPrice:     m.MaxPrice,
+		MainCateg: domain.MainCateg{
+			ID:   m.MainCategID,
+			Name: m.MainCategName,
+			Type: transType,
+		},
+		SubCateg: domain.SubCateg{
+			ID:          m.SubCategID,
+			Name:        m.SubCategName,
+			MainCategID: m.MainCategID,
+		},
+	}
+}
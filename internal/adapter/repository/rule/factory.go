@@ -0,0 +1,58 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/maincateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/subcateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/tag"
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/user"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/gofacto"
+	"github.com/eyo-chen/gofacto/db/mysqlf"
+)
+
+type factory struct {
+	subcateg *gofacto.Factory[subcateg.SubCateg]
+	tag      *gofacto.Factory[tag.Tag]
+}
+
+func newFactory(db *sql.DB) *factory {
+	return &factory{
+		subcateg: gofacto.New(subcateg.SubCateg{}).
+			WithDB(mysqlf.NewConfig(db)).
+			WithStorageName("sub_categories"),
+		tag: gofacto.New(tag.Tag{}).
+			WithDB(mysqlf.NewConfig(db)).
+			WithStorageName("tags"),
+	}
+}
+
+// PrepareUserMainAndSubCateg inserts a user with an expense main category and a sub category
+func (f *factory) PrepareUserMainAndSubCateg(ctx context.Context) (user.User, maincateg.MainCateg, subcateg.SubCateg, error) {
+	u := user.User{}
+	m := maincateg.MainCateg{Type: domain.TransactionTypeExpense.ToModelValue(), IconType: domain.IconTypeDefault.ToModelValue()}
+
+	s, err := f.subcateg.Build(ctx).WithOne(&u, &m).Insert()
+	if err != nil {
+		return user.User{}, maincateg.MainCateg{}, subcateg.SubCateg{}, err
+	}
+
+	return u, m, s, nil
+}
+
+// InsertTagsWithGivenUser inserts tags of the given user
+func (f *factory) InsertTagsWithGivenUser(ctx context.Context, i int, u user.User) ([]tag.Tag, error) {
+	ow := make([]tag.Tag, i)
+	for k := range ow {
+		ow[k] = tag.Tag{UserID: u.ID}
+	}
+
+	return f.tag.BuildList(ctx, i).Overwrites(ow...).Insert()
+}
+
+func (f *factory) Reset() {
+	f.subcateg.Reset()
+	f.tag.Reset()
+}
@@ -0,0 +1,280 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errorutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	uniqueNameUser = "rules.unique_name_user"
+	packageName    = "adapter/repository/rule"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+// Rule is the model of auto-categorizing rule
+type Rule struct {
+	ID            int64
+	UserID        int64
+	Name          string
+	Priority      int
+	Type          string
+	NoteContains  string
+	NoteRegex     string
+	MinPrice      *float64
+	MaxPrice      *float64
+	MainCategID   int64
+	MainCategName string
+	SubCategID    int64
+	SubCategName  string
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Create(ctx context.Context, rule domain.Rule, userID int64) error {
+	qStmt := `INSERT INTO rules (user_id, name, priority, type, note_contains, note_regex, min_price, max_price, main_category_id, sub_category_id)
+						VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	m := cvtToModelRule(rule, userID)
+	res, err := tx.ExecContext(ctx, qStmt, m.UserID, m.Name, m.Priority, m.Type, m.NoteContains, m.NoteRegex, m.MinPrice, m.MaxPrice, m.MainCategID, m.SubCategID)
+	if err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueRuleNameUser
+		}
+
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := insertTags(ctx, tx, id, rule.TagIDs()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.Rule, error) {
+	// the rule is left out while its category is in trash, and it's back when the category is restored
+	qStmt := `SELECT r.id, r.name, r.priority, r.type, r.note_contains, r.note_regex, r.min_price, r.max_price,
+						mc.id, mc.name, sc.id, sc.name
+						FROM rules AS r
+						INNER JOIN main_categories AS mc
+						ON r.main_category_id = mc.id
+						INNER JOIN sub_categories AS sc
+						ON r.sub_category_id = sc.id
+						WHERE r.user_id = ?
+						AND mc.deleted_at IS NULL
+						AND sc.deleted_at IS NULL
+						ORDER BY r.priority, r.id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var rules []domain.Rule
+	for rows.Next() {
+		var m Rule
+		if err := rows.Scan(&m.ID, &m.Name, &m.Priority, &m.Type, &m.NoteContains, &m.NoteRegex, &m.MinPrice, &m.MaxPrice,
+			&m.MainCategID, &m.MainCategName, &m.SubCategID, &m.SubCategName); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		rules = append(rules, cvtToDomainRule(m))
+	}
+
+	if err := r.attachTags(ctx, rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Rule, error) {
+	qStmt := `SELECT id, name, priority, type, note_contains, note_regex, min_price, max_price, main_category_id, sub_category_id
+						FROM rules
+						WHERE id = ? AND user_id = ?`
+
+	var m Rule
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).
+		Scan(&m.ID, &m.Name, &m.Priority, &m.Type, &m.NoteContains, &m.NoteRegex, &m.MinPrice, &m.MaxPrice, &m.MainCategID, &m.SubCategID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Rule{}, domain.ErrRuleNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.Rule{}, err
+	}
+
+	return cvtToDomainRule(m), nil
+}
+
+func (r *Repo) Update(ctx context.Context, rule domain.Rule) error {
+	qStmt := `UPDATE rules
+						SET name = ?, priority = ?, type = ?, note_contains = ?, note_regex = ?, min_price = ?, max_price = ?, main_category_id = ?, sub_category_id = ?
+						WHERE id = ?`
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	m := cvtToModelRule(rule, 0)
+	if _, err := tx.ExecContext(ctx, qStmt, m.Name, m.Priority, m.Type, m.NoteContains, m.NoteRegex, m.MinPrice, m.MaxPrice, m.MainCategID, m.SubCategID, m.ID); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueRuleNameUser
+		}
+
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	// the tags are replaced as a whole
+	if _, err := tx.ExecContext(ctx, "DELETE FROM rule_tags WHERE rule_id = ?", m.ID); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := insertTags(ctx, tx, m.ID, rule.TagIDs()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM rules WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// insertTags links the tags to the rule
+func insertTags(ctx context.Context, tx *sql.Tx, ruleID int64, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO rule_tags (rule_id, tag_id) VALUES ")
+	args := make([]interface{}, 0, len(tagIDs)*2)
+	for i, id := range tagIDs {
+		sb.WriteString("(?, ?)")
+		if i < len(tagIDs)-1 {
+			sb.WriteString(", ")
+		}
+
+		args = append(args, ruleID, id)
+	}
+
+	if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// attachTags loads the tags of the rules, and sets them on the rules
+func (r *Repo) attachTags(ctx context.Context, rules []domain.Rule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT rt.rule_id, tg.id, tg.name
+									FROM rule_tags AS rt
+									INNER JOIN tags AS tg
+									ON rt.tag_id = tg.id
+									WHERE rt.rule_id IN (?`)
+	args := make([]interface{}, 0, len(rules))
+	args = append(args, rules[0].ID)
+	for _, rl := range rules[1:] {
+		sb.WriteString(", ?")
+		args = append(args, rl.ID)
+	}
+	sb.WriteString(") ORDER BY tg.id")
+
+	rows, err := r.DB.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	idToTags := map[int64][]domain.Tag{}
+	for rows.Next() {
+		var ruleID int64
+		var t domain.Tag
+		if err := rows.Scan(&ruleID, &t.ID, &t.Name); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return err
+		}
+
+		idToTags[ruleID] = append(idToTags[ruleID], t)
+	}
+
+	for i, rl := range rules {
+		rules[i].Tags = idToTags[rl.ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type RuleSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestRuleSuite(t *testing.T) {
+	suite.Run(t, new(RuleSuite))
+}
+
+func (s *RuleSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *RuleSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *RuleSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *RuleSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"rule_tags", "rules", "tags", "sub_categories", "main_categories", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *RuleSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when no duplicate name, insert rule with tags": create_NoDuplicateName_InsertRuleWithTags,
+		"when duplicate name, return error":             create_DuplicateName_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoDuplicateName_InsertRuleWithTags(s *RuleSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	tags, err := s.f.InsertTagsWithGivenUser(mockCTX, 2, user)
+	s.Require().NoError(err, desc)
+
+	maxPrice := 20.5
+	rule := domain.Rule{
+		Name:         "coffee",
+		Priority:     1,
+		Type:         domain.TransactionTypeExpense,
+		NoteContains: "starbucks",
+		MaxPrice:     &maxPrice,
+		MainCateg:    domain.MainCateg{ID: main.ID},
+		SubCateg:     domain.SubCateg{ID: sub.ID},
+		Tags:         []domain.Tag{{ID: tags[0].ID}, {ID: tags[1].ID}},
+	}
+
+	err = s.repo.Create(mockCTX, rule, user.ID)
+	s.Require().NoError(err, desc)
+
+	rules, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(rules, 1, desc)
+
+	// id is generated
+	rule.ID = rules[0].ID
+	rule.MainCateg.Name = main.Name
+	rule.SubCateg.Name = sub.Name
+	rule.Tags = []domain.Tag{{ID: tags[0].ID, Name: tags[0].Name}, {ID: tags[1].ID, Name: tags[1].Name}}
+	s.Require().Equal([]domain.Rule{rule}, rules, desc)
+}
+
+func create_DuplicateName_ReturnError(s *RuleSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	rule := domain.Rule{
+		Name:         "coffee",
+		Type:         domain.TransactionTypeExpense,
+		NoteContains: "starbucks",
+		MainCateg:    domain.MainCateg{ID: main.ID},
+		SubCateg:     domain.SubCateg{ID: sub.ID},
+	}
+
+	err = s.repo.Create(mockCTX, rule, user.ID)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, rule, user.ID)
+	s.Require().ErrorIs(err, domain.ErrUniqueRuleNameUser, desc)
+}
+
+func (s *RuleSuite) TestGetAll() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when rules exist, return in order of priority": getAll_RulesExist_ReturnInOrder,
+		"when category in trash, leave the rule out":    getAll_CategInTrash_LeaveOut,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getAll_RulesExist_ReturnInOrder(s *RuleSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	for _, r := range []domain.Rule{
+		{Name: "low", Priority: 5, NoteContains: "a"},
+		{Name: "high", Priority: 1, NoteContains: "b"},
+		{Name: "same priority", Priority: 5, NoteContains: "c"},
+	} {
+		r.Type = domain.TransactionTypeExpense
+		r.MainCateg = domain.MainCateg{ID: main.ID}
+		r.SubCateg = domain.SubCateg{ID: sub.ID}
+		s.Require().NoError(s.repo.Create(mockCTX, r, user.ID), desc)
+	}
+
+	// rule of other user
+	user2, main2, sub2, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+	s.Require().NoError(s.repo.Create(mockCTX, domain.Rule{Name: "other", Type: domain.TransactionTypeExpense, NoteContains: "d", MainCateg: domain.MainCateg{ID: main2.ID}, SubCateg: domain.SubCateg{ID: sub2.ID}}, user2.ID), desc)
+
+	rules, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err, desc)
+
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.Name)
+	}
+	s.Require().Equal([]string{"high", "low", "same priority"}, names, desc)
+}
+
+func getAll_CategInTrash_LeaveOut(s *RuleSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	rule := domain.Rule{Name: "coffee", Type: domain.TransactionTypeExpense, NoteContains: "a", MainCateg: domain.MainCateg{ID: main.ID}, SubCateg: domain.SubCateg{ID: sub.ID}}
+	s.Require().NoError(s.repo.Create(mockCTX, rule, user.ID), desc)
+
+	_, err = s.db.Exec("UPDATE sub_categories SET deleted_at = NOW() WHERE id = ?", sub.ID)
+	s.Require().NoError(err, desc)
+
+	rules, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(rules, desc)
+}
+
+func (s *RuleSuite) TestUpdate() {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err)
+
+	tags, err := s.f.InsertTagsWithGivenUser(mockCTX, 2, user)
+	s.Require().NoError(err)
+
+	rule := domain.Rule{Name: "coffee", Type: domain.TransactionTypeExpense, NoteContains: "a", MainCateg: domain.MainCateg{ID: main.ID}, SubCateg: domain.SubCateg{ID: sub.ID}, Tags: []domain.Tag{{ID: tags[0].ID}}}
+	s.Require().NoError(s.repo.Create(mockCTX, rule, user.ID))
+
+	rules, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+
+	minPrice := 3.0
+	rule.ID = rules[0].ID
+	rule.Name = "tea"
+	rule.NoteContains = ""
+	rule.NoteRegex = "(?i)^tea"
+	rule.MinPrice = &minPrice
+	rule.Tags = []domain.Tag{{ID: tags[1].ID}}
+	s.Require().NoError(s.repo.Update(mockCTX, rule))
+
+	rules, err = s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+	s.Require().Len(rules, 1)
+	s.Require().Equal("tea", rules[0].Name)
+	s.Require().Equal("(?i)^tea", rules[0].NoteRegex)
+	s.Require().Equal(&minPrice, rules[0].MinPrice)
+	s.Require().Equal([]domain.Tag{{ID: tags[1].ID, Name: tags[1].Name}}, rules[0].Tags)
+}
+
+func (s *RuleSuite) TestDelete() {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err)
+
+	tags, err := s.f.InsertTagsWithGivenUser(mockCTX, 1, user)
+	s.Require().NoError(err)
+
+	rule := domain.Rule{Name: "coffee", Type: domain.TransactionTypeExpense, NoteContains: "a", MainCateg: domain.MainCateg{ID: main.ID}, SubCateg: domain.SubCateg{ID: sub.ID}, Tags: []domain.Tag{{ID: tags[0].ID}}}
+	s.Require().NoError(s.repo.Create(mockCTX, rule, user.ID))
+
+	rules, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.repo.Delete(mockCTX, rules[0].ID))
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, rules[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrRuleNotFound)
+
+	// the tags of the rule are deleted by cascade
+	var count int
+	s.Require().NoError(s.db.QueryRow("SELECT COUNT(*) FROM rule_tags").Scan(&count))
+	s.Require().Equal(0, count)
+}
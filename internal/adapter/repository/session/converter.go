@@ -0,0 +1,14 @@
+package session
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToDomainSession(s Session) domain.Session {
+	return domain.Session{
+		ID:         s.ID,
+		UserID:     s.UserID,
+		Device:     s.Device,
+		IP:         s.IP,
+		CreatedAt:  s.CreatedAt,
+		LastUsedAt: s.LastUsedAt,
+	}
+}
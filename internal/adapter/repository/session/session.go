@@ -0,0 +1,172 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/repository/session"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+type Session struct {
+	ID               int64
+	UserID           int64 `gofacto:"foreignKey,struct:User"`
+	Device           string
+	IP               string
+	RefreshTokenHash string
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Create(ctx context.Context, session domain.Session, refreshTokenHash string) (int64, error) {
+	qStmt := "INSERT INTO user_sessions (user_id, device, ip, refresh_token_hash) VALUES (?, ?, ?, ?)"
+
+	res, err := r.DB.ExecContext(ctx, qStmt, session.UserID, session.Device, session.IP, refreshTokenHash)
+	if err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repo) GetByUserID(ctx context.Context, userID int64) ([]domain.Session, error) {
+	qStmt := `SELECT id, user_id, device, ip, created_at, last_used_at
+						FROM user_sessions
+						WHERE user_id = ?
+						ORDER BY last_used_at DESC, id DESC`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var sessions []domain.Session
+	for rows.Next() {
+		var s Session
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Device, &s.IP, &s.CreatedAt, &s.LastUsedAt); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		sessions = append(sessions, cvtToDomainSession(s))
+	}
+
+	return sessions, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Session, error) {
+	qStmt := `SELECT id, user_id, device, ip, created_at, last_used_at
+						FROM user_sessions
+						WHERE id = ? AND user_id = ?`
+
+	return r.getOne(ctx, qStmt, id, userID)
+}
+
+func (r *Repo) GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (domain.Session, error) {
+	qStmt := `SELECT id, user_id, device, ip, created_at, last_used_at
+						FROM user_sessions
+						WHERE refresh_token_hash = ?`
+
+	return r.getOne(ctx, qStmt, refreshTokenHash)
+}
+
+// RotateRefreshToken replaces the refresh token of the session only if it's still the old one,
+// so a refresh token can't be used twice even when the requests race
+func (r *Repo) RotateRefreshToken(ctx context.Context, id int64, oldHash, newHash string) error {
+	qStmt := `UPDATE user_sessions
+						SET refresh_token_hash = ?, last_used_at = CURRENT_TIMESTAMP
+						WHERE id = ? AND refresh_token_hash = ?`
+
+	res, err := r.DB.ExecContext(ctx, qStmt, newHash, id, oldHash)
+	if err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("res.RowsAffected failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *Repo) UpdateLastUsedAt(ctx context.Context, id int64) error {
+	qStmt := "UPDATE user_sessions SET last_used_at = CURRENT_TIMESTAMP WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM user_sessions WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteByUserID(ctx context.Context, userID int64) error {
+	qStmt := "DELETE FROM user_sessions WHERE user_id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, userID); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) getOne(ctx context.Context, qStmt string, args ...interface{}) (domain.Session, error) {
+	var s Session
+	if err := r.DB.QueryRowContext(ctx, qStmt, args...).
+		Scan(&s.ID, &s.UserID, &s.Device, &s.IP, &s.CreatedAt, &s.LastUsedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Session{}, domain.ErrSessionNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.Session{}, err
+	}
+
+	return cvtToDomainSession(s), nil
+}
@@ -0,0 +1,218 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type SessionSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestSessionSuite(t *testing.T) {
+	suite.Run(t, new(SessionSuite))
+}
+
+func (s *SessionSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *SessionSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *SessionSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *SessionSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"user_sessions", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *SessionSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *SessionSuite, desc string){
+		"when no error, create successfully": create_NoError_CreateSuccessfully,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *SessionSuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	// action
+	id, err := s.repo.Create(mockCTX, domain.Session{UserID: users[0].ID, Device: "Mozilla/5.0", IP: "203.0.113.7"}, "hash")
+	s.Require().NoError(err, desc)
+
+	// assertion
+	session, err := s.repo.GetByRefreshTokenHash(mockCTX, "hash")
+	s.Require().NoError(err, desc)
+	s.Require().Equal(id, session.ID, desc)
+	s.Require().Equal(users[0].ID, session.UserID, desc)
+	s.Require().Equal("Mozilla/5.0", session.Device, desc)
+	s.Require().Equal("203.0.113.7", session.IP, desc)
+	s.Require().False(session.CreatedAt.IsZero(), desc)
+	s.Require().False(session.LastUsedAt.IsZero(), desc)
+}
+
+func (s *SessionSuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *SessionSuite, desc string){
+		"when session of other user, return error": getByIDAndUserID_OtherUser_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_OtherUser_ReturnError(s *SessionSuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 2)
+	s.Require().NoError(err, desc)
+	id, err := s.repo.Create(mockCTX, domain.Session{UserID: users[0].ID}, "hash")
+	s.Require().NoError(err, desc)
+
+	// action
+	session, err := s.repo.GetByIDAndUserID(mockCTX, id, users[1].ID)
+
+	// assertion
+	s.Require().ErrorIs(err, domain.ErrSessionNotFound, desc)
+	s.Require().Empty(session, desc)
+}
+
+func (s *SessionSuite) TestRotateRefreshToken() {
+	for scenario, fn := range map[string]func(s *SessionSuite, desc string){
+		"when hash matches, rotate successfully":  rotateRefreshToken_HashMatches_RotateSuccessfully,
+		"when hash already rotated, return error": rotateRefreshToken_AlreadyRotated_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func rotateRefreshToken_HashMatches_RotateSuccessfully(s *SessionSuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	id, err := s.repo.Create(mockCTX, domain.Session{UserID: users[0].ID}, "old")
+	s.Require().NoError(err, desc)
+
+	// action
+	err = s.repo.RotateRefreshToken(mockCTX, id, "old", "new")
+	s.Require().NoError(err, desc)
+
+	// assertion
+	_, err = s.repo.GetByRefreshTokenHash(mockCTX, "old")
+	s.Require().ErrorIs(err, domain.ErrSessionNotFound, desc)
+
+	session, err := s.repo.GetByRefreshTokenHash(mockCTX, "new")
+	s.Require().NoError(err, desc)
+	s.Require().Equal(id, session.ID, desc)
+}
+
+func rotateRefreshToken_AlreadyRotated_ReturnError(s *SessionSuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	id, err := s.repo.Create(mockCTX, domain.Session{UserID: users[0].ID}, "old")
+	s.Require().NoError(err, desc)
+	s.Require().NoError(s.repo.RotateRefreshToken(mockCTX, id, "old", "new"), desc)
+
+	// action
+	err = s.repo.RotateRefreshToken(mockCTX, id, "old", "another")
+
+	// assertion
+	s.Require().ErrorIs(err, domain.ErrSessionNotFound, desc)
+}
+
+func (s *SessionSuite) TestDeleteByUserID() {
+	for scenario, fn := range map[string]func(s *SessionSuite, desc string){
+		"when no error, keep sessions of other users": deleteByUserID_NoError_KeepOtherUsers,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func deleteByUserID_NoError_KeepOtherUsers(s *SessionSuite, desc string) {
+	// prepare data
+	users, err := s.f.InsertUsers(mockCTX, 2)
+	s.Require().NoError(err, desc)
+	for _, hash := range []string{"hash1", "hash2"} {
+		_, err := s.repo.Create(mockCTX, domain.Session{UserID: users[0].ID}, hash+"-0")
+		s.Require().NoError(err, desc)
+		_, err = s.repo.Create(mockCTX, domain.Session{UserID: users[1].ID}, hash+"-1")
+		s.Require().NoError(err, desc)
+	}
+
+	// action
+	err = s.repo.DeleteByUserID(mockCTX, users[0].ID)
+	s.Require().NoError(err, desc)
+
+	// assertion
+	sessions, err := s.repo.GetByUserID(mockCTX, users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(sessions, desc)
+
+	sessions, err = s.repo.GetByUserID(mockCTX, users[1].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(sessions, 2, desc)
+}
@@ -0,0 +1,28 @@
+package settlement
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelSettlement(s domain.Settlement, userID int64) Settlement {
+	return Settlement{
+		ID:            s.ID,
+		UserID:        userID,
+		FromContactID: s.FromContactID,
+		ToContactID:   s.ToContactID,
+		Amount:        s.Amount,
+		Currency:      s.Currency,
+		Date:          s.Date,
+		Note:          s.Note,
+	}
+}
+
+func cvtToDomainSettlement(s Settlement) domain.Settlement {
+	return domain.Settlement{
+		ID:            s.ID,
+		FromContactID: s.FromContactID,
+		ToContactID:   s.ToContactID,
+		Amount:        s.Amount,
+		Currency:      s.Currency,
+		Date:          s.Date,
+		Note:          s.Note,
+	}
+}
@@ -0,0 +1,109 @@
+package settlement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/repository/settlement"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+type Settlement struct {
+	ID            int64
+	UserID        int64 `gofacto:"foreignKey,struct:User"`
+	FromContactID int64
+	ToContactID   int64
+	Amount        float64
+	Currency      string
+	Date          time.Time
+	Note          string
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+// Create inserts a settlement, the currency falls back to the user's base currency when it's empty
+func (r *Repo) Create(ctx context.Context, settlement domain.Settlement, userID int64) error {
+	qStmt := `INSERT INTO settlements (user_id, from_contact_id, to_contact_id, amount, currency, date, note)
+						VALUES (?, NULLIF(?, 0), NULLIF(?, 0), ?, COALESCE(NULLIF(?, ''), (SELECT base_currency FROM users WHERE id = ?)), ?, ?)`
+
+	s := cvtToModelSettlement(settlement, userID)
+	if _, err := r.DB.ExecContext(ctx, qStmt, s.UserID, s.FromContactID, s.ToContactID, s.Amount, s.Currency, s.UserID, s.Date, s.Note); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.Settlement, error) {
+	qStmt := `SELECT id, COALESCE(from_contact_id, 0), COALESCE(to_contact_id, 0), amount, currency, date, COALESCE(note, '')
+						FROM settlements
+						WHERE user_id = ?
+						ORDER BY date DESC, id DESC`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var settlements []domain.Settlement
+	for rows.Next() {
+		var s Settlement
+		if err := rows.Scan(&s.ID, &s.FromContactID, &s.ToContactID, &s.Amount, &s.Currency, &s.Date, &s.Note); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		settlements = append(settlements, cvtToDomainSettlement(s))
+	}
+
+	return settlements, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Settlement, error) {
+	qStmt := `SELECT id, COALESCE(from_contact_id, 0), COALESCE(to_contact_id, 0), amount, currency, date, COALESCE(note, '')
+						FROM settlements
+						WHERE id = ? AND user_id = ?`
+
+	var s Settlement
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).
+		Scan(&s.ID, &s.FromContactID, &s.ToContactID, &s.Amount, &s.Currency, &s.Date, &s.Note); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Settlement{}, domain.ErrSettlementNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.Settlement{}, err
+	}
+
+	return cvtToDomainSettlement(s), nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM settlements WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
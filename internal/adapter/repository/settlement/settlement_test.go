@@ -0,0 +1,146 @@
+package settlement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type SettlementSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestSettlementSuite(t *testing.T) {
+	suite.Run(t, new(SettlementSuite))
+}
+
+func (s *SettlementSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *SettlementSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *SettlementSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *SettlementSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"settlements", "contacts", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *SettlementSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *SettlementSuite, desc string){
+		"when currency is empty, use base currency": create_EmptyCurrency_UseBaseCurrency,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_EmptyCurrency_UseBaseCurrency(s *SettlementSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	res, err := s.db.Exec("INSERT INTO contacts (user_id, name) VALUES (?, 'Alice')", users[0].ID)
+	s.Require().NoError(err, desc)
+	contactID, err := res.LastInsertId()
+	s.Require().NoError(err, desc)
+
+	settlement := domain.Settlement{
+		FromContactID: contactID,
+		Amount:        12.5,
+		Date:          time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Note:          "dinner",
+	}
+	err = s.repo.Create(mockCTX, settlement, users[0].ID)
+	s.Require().NoError(err, desc)
+
+	settlements, err := s.repo.GetAll(mockCTX, users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(settlements, 1, desc)
+	s.Require().Equal(contactID, settlements[0].FromContactID, desc)
+	s.Require().Zero(settlements[0].ToContactID, desc)
+	s.Require().Equal(12.5, settlements[0].Amount, desc)
+	s.Require().Equal("USD", settlements[0].Currency, desc)
+	s.Require().Equal("dinner", settlements[0].Note, desc)
+}
+
+func (s *SettlementSuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *SettlementSuite, desc string){
+		"when settlement of other user, return error": getByIDAndUserID_OtherUser_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_OtherUser_ReturnError(s *SettlementSuite, desc string) {
+	users, err := s.f.InsertUsers(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	settlement := domain.Settlement{
+		Amount:   10,
+		Currency: "EUR",
+		Date:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	err = s.repo.Create(mockCTX, settlement, users[0].ID)
+	s.Require().NoError(err, desc)
+
+	var id int64
+	s.Require().NoError(s.db.QueryRow("SELECT id FROM settlements").Scan(&id), desc)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, id, users[1].ID)
+	s.Require().ErrorIs(err, domain.ErrSettlementNotFound, desc)
+}
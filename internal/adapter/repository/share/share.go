@@ -0,0 +1,155 @@
+package share
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/repository/share"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+// Upsert replaces the shares of the transaction, the transaction becomes shared if it isn't
+func (r *Repo) Upsert(ctx context.Context, t domain.SharedTransaction) error {
+	deleteStmt := `DELETE FROM shared_transactions WHERE transaction_id = ?`
+	sharedStmt := `INSERT INTO shared_transactions (transaction_id, method, paid_by) VALUES (?, ?, NULLIF(?, 0))`
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	// the old shares are deleted by cascade
+	if _, err := tx.ExecContext(ctx, deleteStmt, t.TransactionID); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sharedStmt, t.TransactionID, t.Method.ToModelValue(), t.PaidBy); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if len(t.Shares) > 0 {
+		var sb strings.Builder
+		sb.WriteString("INSERT INTO transaction_shares (transaction_id, contact_id, value) VALUES ")
+		args := make([]interface{}, 0, len(t.Shares)*3)
+		for i, s := range t.Shares {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("(?, NULLIF(?, 0), ?)")
+			args = append(args, t.TransactionID, s.ContactID, s.Value)
+		}
+
+		if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByTransID returns the shared transaction with the price and currency of the transaction
+func (r *Repo) GetByTransID(ctx context.Context, transID int64) (domain.SharedTransaction, error) {
+	qStmt := `SELECT st.transaction_id, st.method, COALESCE(st.paid_by, 0), t.price, t.currency, COALESCE(ts.contact_id, 0), ts.value
+						FROM shared_transactions AS st
+						INNER JOIN transactions AS t ON t.id = st.transaction_id
+						INNER JOIN transaction_shares AS ts ON ts.transaction_id = st.transaction_id
+						WHERE st.transaction_id = ?
+						ORDER BY ts.id`
+
+	sharedTrans, err := r.query(ctx, qStmt, transID)
+	if err != nil {
+		return domain.SharedTransaction{}, err
+	}
+
+	if len(sharedTrans) == 0 {
+		return domain.SharedTransaction{}, domain.ErrSharedTransNotFound
+	}
+
+	return sharedTrans[0], nil
+}
+
+// GetAll returns the shared transactions of the user, the transactions in trash are excluded
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.SharedTransaction, error) {
+	qStmt := `SELECT st.transaction_id, st.method, COALESCE(st.paid_by, 0), t.price, t.currency, COALESCE(ts.contact_id, 0), ts.value
+						FROM shared_transactions AS st
+						INNER JOIN transactions AS t ON t.id = st.transaction_id
+						INNER JOIN transaction_shares AS ts ON ts.transaction_id = st.transaction_id
+						WHERE t.user_id = ? AND t.deleted_at IS NULL
+						ORDER BY st.transaction_id, ts.id`
+
+	return r.query(ctx, qStmt, userID)
+}
+
+func (r *Repo) Delete(ctx context.Context, transID int64) error {
+	qStmt := `DELETE FROM shared_transactions WHERE transaction_id = ?`
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, transID); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// query scans the rows of shares, and groups them by the transaction in the order of the rows
+func (r *Repo) query(ctx context.Context, qStmt string, args ...interface{}) ([]domain.SharedTransaction, error) {
+	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var sharedTrans []domain.SharedTransaction
+	for rows.Next() {
+		var t domain.SharedTransaction
+		var method string
+		var s domain.Share
+		if err := rows.Scan(&t.TransactionID, &method, &t.PaidBy, &t.Price, &t.Currency, &s.ContactID, &s.Value); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		if n := len(sharedTrans); n == 0 || sharedTrans[n-1].TransactionID != t.TransactionID {
+			t.Method = domain.CvtToShareMethodType(method)
+			sharedTrans = append(sharedTrans, t)
+		}
+
+		last := &sharedTrans[len(sharedTrans)-1]
+		last.Shares = append(last.Shares, s)
+	}
+
+	return sharedTrans, nil
+}
@@ -0,0 +1,208 @@
+package share
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/transaction"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type ShareSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *transaction.TransactionFactory
+}
+
+func TestShareSuite(t *testing.T) {
+	suite.Run(t, new(ShareSuite))
+}
+
+func (s *ShareSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = transaction.NewTransactionFactory(s.db)
+}
+
+func (s *ShareSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *ShareSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = transaction.NewTransactionFactory(s.db)
+}
+
+func (s *ShareSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"transaction_shares", "shared_transactions", "contacts", "transactions", "sub_categories", "main_categories", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *ShareSuite) TestUpsert() {
+	for scenario, fn := range map[string]func(s *ShareSuite, desc string){
+		"when no error, insert shares":        upsert_NoError_InsertShares,
+		"when already shared, replace shares": upsert_AlreadyShared_ReplaceShares,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func upsert_NoError_InsertShares(s *ShareSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	res, err := s.db.Exec("INSERT INTO contacts (user_id, name) VALUES (?, 'Alice')", user.ID)
+	s.Require().NoError(err, desc)
+	contactID, err := res.LastInsertId()
+	s.Require().NoError(err, desc)
+
+	sharedTrans := domain.SharedTransaction{
+		TransactionID: trans[0].ID,
+		Method:        domain.ShareMethodTypeShares,
+		PaidBy:        contactID,
+		Shares:        []domain.Share{{ContactID: 0, Value: 1}, {ContactID: contactID, Value: 2}},
+	}
+	err = s.repo.Upsert(mockCTX, sharedTrans)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByTransID(mockCTX, trans[0].ID)
+	s.Require().NoError(err, desc)
+	sharedTrans.Price = trans[0].Price
+	sharedTrans.Currency = trans[0].Currency
+	s.Require().Equal(sharedTrans, result, desc)
+}
+
+func upsert_AlreadyShared_ReplaceShares(s *ShareSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	res, err := s.db.Exec("INSERT INTO contacts (user_id, name) VALUES (?, 'Alice')", user.ID)
+	s.Require().NoError(err, desc)
+	contactID, err := res.LastInsertId()
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Upsert(mockCTX, domain.SharedTransaction{
+		TransactionID: trans[0].ID,
+		Method:        domain.ShareMethodTypeEqual,
+		Shares:        []domain.Share{{ContactID: 0}, {ContactID: contactID}},
+	})
+	s.Require().NoError(err, desc)
+
+	sharedTrans := domain.SharedTransaction{
+		TransactionID: trans[0].ID,
+		Method:        domain.ShareMethodTypePercent,
+		Shares:        []domain.Share{{ContactID: contactID, Value: 60}, {ContactID: 0, Value: 40}},
+	}
+	err = s.repo.Upsert(mockCTX, sharedTrans)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByTransID(mockCTX, trans[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.ShareMethodTypePercent, result.Method, desc)
+	s.Require().Equal(sharedTrans.Shares, result.Shares, desc)
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transaction_shares WHERE transaction_id = ?", trans[0].ID).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(2, count, desc)
+}
+
+func (s *ShareSuite) TestGetByTransID() {
+	for scenario, fn := range map[string]func(s *ShareSuite, desc string){
+		"when not shared, return error": getByTransID_NotShared_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByTransID_NotShared_ReturnError(s *ShareSuite, desc string) {
+	trans, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	_, err = s.repo.GetByTransID(mockCTX, trans[0].ID)
+	s.Require().ErrorIs(err, domain.ErrSharedTransNotFound, desc)
+}
+
+func (s *ShareSuite) TestGetAll() {
+	for scenario, fn := range map[string]func(s *ShareSuite, desc string){
+		"when transaction in trash, exclude it": getAll_TransInTrash_ExcludeIt,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getAll_TransInTrash_ExcludeIt(s *ShareSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	res, err := s.db.Exec("INSERT INTO contacts (user_id, name) VALUES (?, 'Alice')", user.ID)
+	s.Require().NoError(err, desc)
+	contactID, err := res.LastInsertId()
+	s.Require().NoError(err, desc)
+
+	for _, t := range trans {
+		err = s.repo.Upsert(mockCTX, domain.SharedTransaction{
+			TransactionID: t.ID,
+			Method:        domain.ShareMethodTypeEqual,
+			Shares:        []domain.Share{{ContactID: 0}, {ContactID: contactID}},
+		})
+		s.Require().NoError(err, desc)
+	}
+
+	_, err = s.db.Exec("UPDATE transactions SET deleted_at = NOW() WHERE id = ?", trans[1].ID)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(result, 1, desc)
+	s.Require().Equal(trans[0].ID, result[0].TransactionID, desc)
+	s.Require().Len(result[0].Shares, 2, desc)
+}
@@ -7,6 +7,7 @@ func cvtToDomainSubCateg(categ *SubCateg) *domain.SubCateg {
 		ID:          categ.ID,
 		Name:        categ.Name,
 		MainCategID: categ.MainCategID,
+		IsArchived:  categ.IsArchived,
 	}
 }
 
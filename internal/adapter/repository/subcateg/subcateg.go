@@ -29,13 +29,18 @@ type SubCateg struct {
 	Name        string `json:"name"`
 	UserID      int64  `json:"user_id" gofacto:"foreignKey,struct:User"`
 	MainCategID int64  `json:"main_category_id" gofacto:"foreignKey,struct:MainCateg,table:main_categories" mysqlf:"main_category_id"`
+	IsArchived  bool   `json:"is_archived" gofacto:"omit"`
 }
 
 func (r *Repo) Create(categ *domain.SubCateg, userID int64) error {
-	stmt := `INSERT INTO sub_categories (name, user_id, main_category_id) VALUES (?, ?, ?)`
+	// the new sub category is placed after the existing ones of the main category
+	stmt := `INSERT INTO sub_categories (name, user_id, main_category_id, position)
+					 SELECT ?, ?, ?, COALESCE(MAX(position), 0) + 1
+					 FROM sub_categories
+					 WHERE main_category_id = ?`
 
 	c := cvtToSubCateg(categ, userID)
-	if _, err := r.DB.Exec(stmt, c.Name, c.UserID, c.MainCategID); err != nil {
+	if _, err := r.DB.Exec(stmt, c.Name, c.UserID, c.MainCategID, c.MainCategID); err != nil {
 		if errorutil.ParseError(err, uniqueNameUserMainCategory) {
 			return domain.ErrUniqueNameUserMainCateg
 		}
@@ -47,10 +52,21 @@ func (r *Repo) Create(categ *domain.SubCateg, userID int64) error {
 	return nil
 }
 
-func (r *Repo) GetByMainCategID(userID, mainCategID int64) ([]*domain.SubCateg, error) {
-	stmt := `SELECT id, name, main_category_id FROM sub_categories WHERE user_id = ? AND main_category_id = ?`
+func (r *Repo) GetByMainCategID(userID, mainCategID int64, includeArchived bool) ([]*domain.SubCateg, error) {
+	var sb strings.Builder
+	sb.WriteString(`SELECT id, name, main_category_id, is_archived
+									FROM sub_categories
+									WHERE user_id = ?
+									AND main_category_id = ?
+									AND deleted_at IS NULL`)
+
+	if !includeArchived {
+		sb.WriteString(` AND is_archived = FALSE`)
+	}
 
-	rows, err := r.DB.Query(stmt, userID, mainCategID)
+	sb.WriteString(` ORDER BY position, id`)
+
+	rows, err := r.DB.Query(sb.String(), userID, mainCategID)
 	if err != nil {
 		logger.Error("r.DB.Query failed", "package", packageName, "err", err)
 		return nil, err
@@ -64,7 +80,7 @@ func (r *Repo) GetByMainCategID(userID, mainCategID int64) ([]*domain.SubCateg,
 	var categs []*domain.SubCateg
 	for rows.Next() {
 		var categ SubCateg
-		if err := rows.Scan(&categ.ID, &categ.Name, &categ.MainCategID); err != nil {
+		if err := rows.Scan(&categ.ID, &categ.Name, &categ.MainCategID, &categ.IsArchived); err != nil {
 			logger.Error("rows.Scan failed", "package", packageName, "err", err)
 			return nil, err
 		}
@@ -96,11 +112,40 @@ func (r *Repo) Update(categ *domain.SubCateg) error {
 	return nil
 }
 
+// Delete moves the sub category to trash, along with its transactions.
+// They share the same deleted_at, so that restoring the sub category only brings back what's deleted with it.
+// The name is only unique among the live categories, so it can be used again right away.
 func (r *Repo) Delete(id int64) error {
-	stmt := `DELETE FROM sub_categories WHERE id = ?`
+	categStmt := `UPDATE sub_categories SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`
+	transStmt := `UPDATE transactions
+								SET deleted_at = (SELECT deleted_at FROM sub_categories WHERE id = ?)
+								WHERE (sub_category_id = ? OR id IN (SELECT transaction_id FROM transaction_splits WHERE sub_category_id = ?))
+								AND deleted_at IS NULL`
 
-	if _, err := r.DB.Exec(stmt, id); err != nil {
-		logger.Error("r.DB.Exec failed", "package", packageName, "err", err)
+	tx, err := r.DB.Begin()
+	if err != nil {
+		logger.Error("r.DB.Begin failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	if _, err := tx.Exec(categStmt, id); err != nil {
+		logger.Error("tx.Exec failed", "package", packageName, "err", err)
+		return err
+	}
+
+	// split transaction is moved to trash as a whole when any of its lines is in the category
+	if _, err := tx.Exec(transStmt, id, id, id); err != nil {
+		logger.Error("tx.Exec failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
 		return err
 	}
 
@@ -108,10 +153,10 @@ func (r *Repo) Delete(id int64) error {
 }
 
 func (r *Repo) GetByID(id, userID int64) (*domain.SubCateg, error) {
-	stmt := `SELECT id, name, main_category_id FROM sub_categories WHERE id = ? AND user_id = ?`
+	stmt := `SELECT id, name, main_category_id, is_archived FROM sub_categories WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
 
 	var categ SubCateg
-	if err := r.DB.QueryRow(stmt, id, userID).Scan(&categ.ID, &categ.Name, &categ.MainCategID); err != nil {
+	if err := r.DB.QueryRow(stmt, id, userID).Scan(&categ.ID, &categ.Name, &categ.MainCategID, &categ.IsArchived); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrSubCategNotFound
 		}
@@ -123,6 +168,50 @@ func (r *Repo) GetByID(id, userID int64) (*domain.SubCateg, error) {
 	return cvtToDomainSubCateg(&categ), nil
 }
 
+func (r *Repo) GetByIDs(ctx context.Context, ids []int64, userID int64) ([]domain.SubCateg, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT id, name, main_category_id, is_archived
+									FROM sub_categories
+									WHERE user_id = ?
+									AND deleted_at IS NULL
+									AND id IN (?`)
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, userID, ids[0])
+	for _, id := range ids[1:] {
+		sb.WriteString(", ?")
+		args = append(args, id)
+	}
+	sb.WriteString(") ORDER BY id")
+
+	rows, err := r.DB.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var categs []domain.SubCateg
+	for rows.Next() {
+		var categ SubCateg
+		if err := rows.Scan(&categ.ID, &categ.Name, &categ.MainCategID, &categ.IsArchived); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		categs = append(categs, *cvtToDomainSubCateg(&categ))
+	}
+
+	return categs, nil
+}
+
 func (r *Repo) BatchCreate(ctx context.Context, categs []domain.SubCateg, userID int64) error {
 	var sb strings.Builder
 	sb.WriteString(`INSERT INTO sub_categories (name, user_id, main_category_id) VALUES `)
@@ -147,3 +236,89 @@ func (r *Repo) BatchCreate(ctx context.Context, categs []domain.SubCateg, userID
 
 	return nil
 }
+
+// Move moves the sub category to another main category, along with the main category of its transactions, split lines, recurring transactions and rules.
+func (r *Repo) Move(ctx context.Context, id, mainCategID int64) error {
+	categStmt := `UPDATE sub_categories SET main_category_id = ? WHERE id = ?`
+	refStmts := []string{
+		`UPDATE transactions SET main_category_id = ? WHERE sub_category_id = ?`,
+		`UPDATE transaction_splits SET main_category_id = ? WHERE sub_category_id = ?`,
+		`UPDATE recurring_transactions SET main_category_id = ? WHERE sub_category_id = ?`,
+		`UPDATE rules SET main_category_id = ? WHERE sub_category_id = ?`,
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, categStmt, mainCategID, id); err != nil {
+		if errorutil.ParseError(err, uniqueNameUserMainCategory) {
+			return domain.ErrUniqueNameUserMainCateg
+		}
+
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	for _, stmt := range refStmts {
+		if _, err := tx.ExecContext(ctx, stmt, mainCategID, id); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) SetArchived(ctx context.Context, id int64, archived bool) error {
+	stmt := `UPDATE sub_categories SET is_archived = ? WHERE id = ?`
+
+	if _, err := r.DB.ExecContext(ctx, stmt, archived, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// Reorder sets the position of the sub categories by the order of ids
+func (r *Repo) Reorder(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// the position follows the order of ids
+	var sb strings.Builder
+	sb.WriteString(`UPDATE sub_categories SET position = CASE id`)
+	args := make([]interface{}, 0, len(ids)*3)
+	for i, id := range ids {
+		sb.WriteString(` WHEN ? THEN ?`)
+		args = append(args, id, i+1)
+	}
+	sb.WriteString(` END WHERE id IN (?`)
+	args = append(args, ids[0])
+	for _, id := range ids[1:] {
+		sb.WriteString(", ?")
+		args = append(args, id)
+	}
+	sb.WriteString(")")
+
+	if _, err := r.DB.ExecContext(ctx, sb.String(), args...); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -145,6 +145,8 @@ func (s *SubCategSuite) TestGetByMainCategID() {
 		"when with one main category, return correct subcategories":    getByMainCategID_WithOneMainCateg_ReturnCorrectSubCategs,
 		"when with many main categories, return correct subcategories": getByMainCategID_WithManyMainCategs_ReturnCorrectSubCategs,
 		"when with many users, return correct subcategories":           getByMainCategID_WithManyUsers_ReturnCorrectSubCategs,
+		"when archived, hide unless included":                          getByMainCategID_Archived_HideUnlessIncluded,
+		"when reordered, return in order of position":                  getByMainCategID_Reordered_ReturnInOrderOfPosition,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -163,7 +165,7 @@ func getByMainCategID_FindNoData_ReturnEmpty(s *SubCategSuite, desc string) {
 	mainCateg := mainCategs[0]
 
 	// action
-	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCateg.ID+9999)
+	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCateg.ID+9999, false)
 	s.Require().NoError(err, desc)
 	s.Require().Nil(result, desc)
 }
@@ -189,7 +191,7 @@ func getByMainCategID_WithOneMainCateg_ReturnCorrectSubCategs(s *SubCategSuite,
 	}
 
 	// action
-	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCateg.ID)
+	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCateg.ID, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -210,7 +212,7 @@ func getByMainCategID_WithManyMainCategs_ReturnCorrectSubCategs(s *SubCategSuite
 	}
 
 	// action
-	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCateg.ID)
+	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCateg.ID, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -237,7 +239,7 @@ func getByMainCategID_WithManyUsers_ReturnCorrectSubCategs(s *SubCategSuite, des
 	}
 
 	// action
-	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCateg.ID)
+	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCateg.ID, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -357,12 +359,12 @@ func (s *SubCategSuite) TestDelete() {
 
 	// check to see if the sub category is deleted
 	var result SubCateg
-	checkStmt := `SELECT id, name, main_category_id FROM sub_categories WHERE id = ?`
+	checkStmt := `SELECT id, name, main_category_id FROM sub_categories WHERE id = ? AND deleted_at IS NULL`
 	err = s.db.QueryRow(checkStmt, mainCategIDToSubCategs[mainCateg.ID][0].ID).Scan(&result.ID, &result.Name, &result.MainCategID)
 	s.Require().ErrorIs(err, sql.ErrNoRows, "test delete")
 
 	// check to see if the first main category still has the other sub categories
-	checkStmt = `SELECT id, name, main_category_id FROM sub_categories WHERE main_category_id = ?`
+	checkStmt = `SELECT id, name, main_category_id FROM sub_categories WHERE main_category_id = ? AND deleted_at IS NULL`
 	rows, err := s.db.Query(checkStmt, mainCateg.ID)
 	s.Require().NoError(err, "test delete")
 	defer func() {
@@ -380,7 +382,7 @@ func (s *SubCategSuite) TestDelete() {
 	s.Require().Len(subCategs, 2, "test delete")
 
 	// check to see if the second main category still has the sub category
-	checkStmt = `SELECT id, name, main_category_id FROM sub_categories WHERE main_category_id = ?`
+	checkStmt = `SELECT id, name, main_category_id FROM sub_categories WHERE main_category_id = ? AND deleted_at IS NULL`
 	rows, err = s.db.Query(checkStmt, mainCategs[1].ID)
 	s.Require().NoError(err, "test delete")
 	defer func() {
@@ -398,7 +400,7 @@ func (s *SubCategSuite) TestDelete() {
 	s.Require().Len(subCategs2, 2, "test delete")
 
 	// check to see if the third main category still has the sub category
-	checkStmt = `SELECT id, name, main_category_id FROM sub_categories WHERE main_category_id = ?`
+	checkStmt = `SELECT id, name, main_category_id FROM sub_categories WHERE main_category_id = ? AND deleted_at IS NULL`
 	rows, err = s.db.Query(checkStmt, mainCategs[2].ID)
 	s.Require().NoError(err, "test delete")
 	defer func() {
@@ -510,6 +512,71 @@ func getByID_WithManyUsers_ReturnCorrectData(s *SubCategSuite, desc string) {
 	s.Require().Equal(expResult, result, desc)
 }
 
+func (s *SubCategSuite) TestGetByIDs() {
+	for scenario, fn := range map[string]func(s *SubCategSuite, desc string){
+		"when with many data, return given ids": getByIDs_WithManyData_ReturnGivenIDs,
+		"when with many users, return own data": getByIDs_WithManyUsers_ReturnOwnData,
+		"when no ids, return empty":             getByIDs_NoIDs_ReturnEmpty,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDs_WithManyData_ReturnGivenIDs(s *SubCategSuite, desc string) {
+	// prepare data
+	mainCategIDToSubCategs, mainCategs, user, err := s.f.InsertSubcategsWithOneOrManyMainCateg(mockCTX, 2, []int{2, 1})
+	s.Require().NoError(err, desc)
+
+	// prepare expected result
+	subCateg1 := mainCategIDToSubCategs[mainCategs[0].ID][1]
+	subCateg2 := mainCategIDToSubCategs[mainCategs[1].ID][0]
+	expResult := []domain.SubCateg{
+		{ID: subCateg1.ID, Name: subCateg1.Name, MainCategID: subCateg1.MainCategID},
+		{ID: subCateg2.ID, Name: subCateg2.Name, MainCategID: subCateg2.MainCategID},
+	}
+
+	// action
+	result, err := s.subCategRepo.GetByIDs(mockCTX, []int64{subCateg2.ID, subCateg1.ID}, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getByIDs_WithManyUsers_ReturnOwnData(s *SubCategSuite, desc string) {
+	// prepare data
+	mainCategIDToSubCategs, mainCategs, user, err := s.f.InsertSubcategsWithOneOrManyMainCateg(mockCTX, 1, []int{1})
+	s.Require().NoError(err, desc)
+
+	// prepare more users
+	mainCategIDToSubCategs2, mainCategs2, _, err := s.f.InsertSubcategsWithOneOrManyMainCateg(mockCTX, 1, []int{1})
+	s.Require().NoError(err, desc)
+
+	subCateg := mainCategIDToSubCategs[mainCategs[0].ID][0]
+	otherSubCateg := mainCategIDToSubCategs2[mainCategs2[0].ID][0]
+	expResult := []domain.SubCateg{
+		{ID: subCateg.ID, Name: subCateg.Name, MainCategID: subCateg.MainCategID},
+	}
+
+	// action
+	result, err := s.subCategRepo.GetByIDs(mockCTX, []int64{subCateg.ID, otherSubCateg.ID}, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getByIDs_NoIDs_ReturnEmpty(s *SubCategSuite, desc string) {
+	// prepare data
+	_, _, user, err := s.f.InsertSubcategsWithOneOrManyMainCateg(mockCTX, 1, []int{1})
+	s.Require().NoError(err, desc)
+
+	// action
+	result, err := s.subCategRepo.GetByIDs(mockCTX, nil, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(result, desc)
+}
+
 func (s *SubCategSuite) TestCreateBatch() {
 	for scenario, fn := range map[string]func(s *SubCategSuite, desc string){
 		"when insert one data, insert successfully":            createBatch_InsertOneData_InsertSuccessfully,
@@ -635,3 +702,92 @@ func createBatch_AlreadyExistData_ReturnError(s *SubCategSuite, desc string) {
 	s.Require().NoError(err, desc)
 	s.Require().Equal(2, count, desc)
 }
+
+func (s *SubCategSuite) TestMove() {
+	for scenario, fn := range map[string]func(s *SubCategSuite, desc string){
+		"when no name conflict, move with transactions": move_NoNameConflict_MoveWithTransactions,
+		"when name conflict, return error":              move_NameConflict_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func move_NoNameConflict_MoveWithTransactions(s *SubCategSuite, desc string) {
+	mainCategIDToSubCategs, mainCategs, user, err := s.f.InsertSubcategsWithOneOrManyMainCateg(mockCTX, 2, []int{1, 1})
+	s.Require().NoError(err, desc)
+	sub := mainCategIDToSubCategs[mainCategs[0].ID][0]
+
+	_, err = s.db.Exec("INSERT INTO transactions (user_id, type, main_category_id, sub_category_id, price, date) VALUES (?, '2', ?, ?, 10, '2024-03-01')", user.ID, mainCategs[0].ID, sub.ID)
+	s.Require().NoError(err, desc)
+
+	err = s.subCategRepo.Move(mockCTX, sub.ID, mainCategs[1].ID)
+	s.Require().NoError(err, desc)
+
+	categ, err := s.subCategRepo.GetByID(sub.ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(mainCategs[1].ID, categ.MainCategID, desc)
+
+	var transMainCategID int64
+	s.Require().NoError(s.db.QueryRow("SELECT main_category_id FROM transactions WHERE sub_category_id = ?", sub.ID).Scan(&transMainCategID), desc)
+	s.Require().Equal(mainCategs[1].ID, transMainCategID, desc)
+}
+
+func move_NameConflict_ReturnError(s *SubCategSuite, desc string) {
+	mainCategIDToSubCategs, mainCategs, user, err := s.f.InsertSubcategsWithOneOrManyMainCateg(mockCTX, 2, []int{1, 1})
+	s.Require().NoError(err, desc)
+	sub := mainCategIDToSubCategs[mainCategs[0].ID][0]
+	other := mainCategIDToSubCategs[mainCategs[1].ID][0]
+
+	_, err = s.db.Exec("UPDATE sub_categories SET name = ? WHERE id = ?", other.Name, sub.ID)
+	s.Require().NoError(err, desc)
+
+	err = s.subCategRepo.Move(mockCTX, sub.ID, mainCategs[1].ID)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUserMainCateg, desc)
+
+	// nothing is changed
+	categ, err := s.subCategRepo.GetByID(sub.ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(mainCategs[0].ID, categ.MainCategID, desc)
+}
+
+func getByMainCategID_Archived_HideUnlessIncluded(s *SubCategSuite, desc string) {
+	mainCategIDToSubCategs, mainCategs, user, err := s.f.InsertSubcategsWithOneOrManyMainCateg(mockCTX, 1, []int{2})
+	s.Require().NoError(err, desc)
+	subCategs := mainCategIDToSubCategs[mainCategs[0].ID]
+
+	err = s.subCategRepo.SetArchived(mockCTX, subCategs[0].ID, true)
+	s.Require().NoError(err, desc)
+
+	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCategs[0].ID, false)
+	s.Require().NoError(err, desc)
+	s.Require().Len(result, 1, desc)
+	s.Require().Equal(subCategs[1].ID, result[0].ID, desc)
+
+	result, err = s.subCategRepo.GetByMainCategID(user.ID, mainCategs[0].ID, true)
+	s.Require().NoError(err, desc)
+	s.Require().Len(result, 2, desc)
+	s.Require().True(result[0].IsArchived, desc)
+}
+
+func getByMainCategID_Reordered_ReturnInOrderOfPosition(s *SubCategSuite, desc string) {
+	mainCategIDToSubCategs, mainCategs, user, err := s.f.InsertSubcategsWithOneOrManyMainCateg(mockCTX, 1, []int{3})
+	s.Require().NoError(err, desc)
+	subCategs := mainCategIDToSubCategs[mainCategs[0].ID]
+
+	expIDs := []int64{subCategs[1].ID, subCategs[2].ID, subCategs[0].ID}
+	err = s.subCategRepo.Reorder(mockCTX, expIDs)
+	s.Require().NoError(err, desc)
+
+	result, err := s.subCategRepo.GetByMainCategID(user.ID, mainCategs[0].ID, false)
+	s.Require().NoError(err, desc)
+
+	ids := make([]int64, 0, len(result))
+	for _, c := range result {
+		ids = append(ids, c.ID)
+	}
+	s.Require().Equal(expIDs, ids, desc)
+}
@@ -0,0 +1,18 @@
+package tag
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToModelTag(t domain.Tag, userID int64) Tag {
+	return Tag{
+		ID:     t.ID,
+		UserID: userID,
+		Name:   t.Name,
+	}
+}
+
+func cvtToDomainTag(t Tag) domain.Tag {
+	return domain.Tag{
+		ID:   t.ID,
+		Name: t.Name,
+	}
+}
@@ -0,0 +1,37 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/user"
+	"github.com/eyo-chen/gofacto"
+	"github.com/eyo-chen/gofacto/db/mysqlf"
+)
+
+type factory struct {
+	tag *gofacto.Factory[Tag]
+}
+
+func newFactory(db *sql.DB) *factory {
+	return &factory{
+		tag: gofacto.New(Tag{}).
+			WithDB(mysqlf.NewConfig(db)).
+			WithStorageName("tags"),
+	}
+}
+
+// InsertTagsWithOneUser inserts tags with one user
+func (f *factory) InsertTagsWithOneUser(ctx context.Context, i int) (user.User, []Tag, error) {
+	u := user.User{}
+	tags, err := f.tag.BuildList(ctx, i).WithOne(&u).Insert()
+	if err != nil {
+		return user.User{}, nil, err
+	}
+
+	return u, tags, nil
+}
+
+func (f *factory) Reset() {
+	f.tag.Reset()
+}
@@ -0,0 +1,166 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errorutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	uniqueNameUser = "tags.unique_name_user"
+	packageName    = "adapter/repository/tag"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+type Tag struct {
+	ID     int64
+	UserID int64 `gofacto:"foreignKey,struct:User"`
+	Name   string
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Create(ctx context.Context, tag domain.Tag, userID int64) error {
+	qStmt := "INSERT INTO tags (user_id, name) VALUES (?, ?)"
+
+	t := cvtToModelTag(tag, userID)
+	if _, err := r.DB.ExecContext(ctx, qStmt, t.UserID, t.Name); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueTagNameUser
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.Tag, error) {
+	qStmt := `SELECT id, name
+						FROM tags
+						WHERE user_id = ?
+						ORDER BY id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var tags []domain.Tag
+	for rows.Next() {
+		var t Tag
+		if err := rows.Scan(&t.ID, &t.Name); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		tags = append(tags, cvtToDomainTag(t))
+	}
+
+	return tags, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Tag, error) {
+	qStmt := `SELECT id, name
+						FROM tags
+						WHERE id = ? AND user_id = ?`
+
+	var t Tag
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).Scan(&t.ID, &t.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Tag{}, domain.ErrTagNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.Tag{}, err
+	}
+
+	return cvtToDomainTag(t), nil
+}
+
+func (r *Repo) GetByIDs(ctx context.Context, ids []int64, userID int64) ([]domain.Tag, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT id, name
+									FROM tags
+									WHERE user_id = ?
+									AND id IN (?`)
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, userID, ids[0])
+	for _, id := range ids[1:] {
+		sb.WriteString(", ?")
+		args = append(args, id)
+	}
+	sb.WriteString(") ORDER BY id")
+
+	rows, err := r.DB.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var tags []domain.Tag
+	for rows.Next() {
+		var t Tag
+		if err := rows.Scan(&t.ID, &t.Name); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		tags = append(tags, cvtToDomainTag(t))
+	}
+
+	return tags, nil
+}
+
+func (r *Repo) Update(ctx context.Context, tag domain.Tag) error {
+	qStmt := "UPDATE tags SET name = ? WHERE id = ?"
+
+	t := cvtToModelTag(tag, 0)
+	if _, err := r.DB.ExecContext(ctx, qStmt, t.Name, t.ID); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueTagNameUser
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM tags WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
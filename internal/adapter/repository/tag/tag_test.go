@@ -0,0 +1,239 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type TagSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestTagSuite(t *testing.T) {
+	suite.Run(t, new(TagSuite))
+}
+
+func (s *TagSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *TagSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *TagSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *TagSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	_, err = tx.Exec("DELETE FROM tags")
+	s.Require().NoError(err)
+
+	_, err = tx.Exec("DELETE FROM users")
+	s.Require().NoError(err)
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *TagSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *TagSuite, desc string){
+		"when no duplicate name, insert tag":      create_NoDuplicateName_InsertTag,
+		"when duplicate name, return error":       create_DuplicateName_ReturnError,
+		"when same name of other user, insert it": create_SameNameOfOtherUser_InsertTag,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoDuplicateName_InsertTag(s *TagSuite, desc string) {
+	user, _, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Tag{Name: "trip-japan"}, user.ID)
+	s.Require().NoError(err, desc)
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM tags WHERE user_id = ? AND name = ?", user.ID, "trip-japan").Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(1, count, desc)
+}
+
+func create_DuplicateName_ReturnError(s *TagSuite, desc string) {
+	user, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Tag{Name: tags[0].Name}, user.ID)
+	s.Require().ErrorIs(err, domain.ErrUniqueTagNameUser, desc)
+}
+
+func create_SameNameOfOtherUser_InsertTag(s *TagSuite, desc string) {
+	_, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	user2, _, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Create(mockCTX, domain.Tag{Name: tags[0].Name}, user2.ID)
+	s.Require().NoError(err, desc)
+}
+
+func (s *TagSuite) TestGetAll() {
+	user, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err)
+
+	// tag of another user
+	_, _, err = s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err)
+
+	expResult := []domain.Tag{
+		cvtToDomainTag(tags[0]),
+		cvtToDomainTag(tags[1]),
+	}
+
+	result, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(expResult, result)
+
+	s.TearDownTest()
+}
+
+func (s *TagSuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *TagSuite, desc string){
+		"when data exists, return data":         getByIDAndUserID_DataExists_ReturnData,
+		"when user not match, return not found": getByIDAndUserID_UserNotMatch_ReturnNotFound,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_DataExists_ReturnData(s *TagSuite, desc string) {
+	user, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndUserID(mockCTX, tags[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(cvtToDomainTag(tags[0]), result, desc)
+}
+
+func getByIDAndUserID_UserNotMatch_ReturnNotFound(s *TagSuite, desc string) {
+	user, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndUserID(mockCTX, tags[0].ID, user.ID+1)
+	s.Require().ErrorIs(err, domain.ErrTagNotFound, desc)
+	s.Require().Empty(result, desc)
+}
+
+func (s *TagSuite) TestGetByIDs() {
+	user, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err)
+
+	// tag of another user is left out
+	_, otherTags, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err)
+
+	expResult := []domain.Tag{
+		cvtToDomainTag(tags[0]),
+		cvtToDomainTag(tags[2]),
+	}
+
+	result, err := s.repo.GetByIDs(mockCTX, []int64{tags[2].ID, tags[0].ID, otherTags[0].ID}, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(expResult, result)
+
+	s.TearDownTest()
+}
+
+func (s *TagSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *TagSuite, desc string){
+		"when no duplicate name, update tag": update_NoDuplicateName_UpdateTag,
+		"when duplicate name, return error":  update_DuplicateName_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoDuplicateName_UpdateTag(s *TagSuite, desc string) {
+	user, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	tag := domain.Tag{ID: tags[0].ID, Name: "reimbursable"}
+	err = s.repo.Update(mockCTX, tag)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndUserID(mockCTX, tags[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(tag, result, desc)
+}
+
+func update_DuplicateName_ReturnError(s *TagSuite, desc string) {
+	_, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Update(mockCTX, domain.Tag{ID: tags[0].ID, Name: tags[1].Name})
+	s.Require().ErrorIs(err, domain.ErrUniqueTagNameUser, desc)
+}
+
+func (s *TagSuite) TestDelete() {
+	user, tags, err := s.f.InsertTagsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err)
+
+	err = s.repo.Delete(mockCTX, tags[0].ID)
+	s.Require().NoError(err)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, tags[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrTagNotFound)
+
+	s.TearDownTest()
+}
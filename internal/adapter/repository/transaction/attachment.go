@@ -0,0 +1,56 @@
+package transaction
+
+import (
+	"context"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+// attachAttachments loads the attachments of the transactions, and sets them on the transactions
+func (r *Repo) attachAttachments(ctx context.Context, trans []domain.Transaction) error {
+	if len(trans) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT id, transaction_id, user_id, file_name, object_key, created_at
+									FROM transaction_attachments
+									WHERE transaction_id IN (?`)
+	args := make([]interface{}, 0, len(trans))
+	args = append(args, trans[0].ID)
+	for _, t := range trans[1:] {
+		sb.WriteString(", ?")
+		args = append(args, t.ID)
+	}
+	sb.WriteString(") ORDER BY id")
+
+	rows, err := r.DB.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	transIDToAttachments := map[int64][]domain.TransAttachment{}
+	for rows.Next() {
+		var a domain.TransAttachment
+		if err := rows.Scan(&a.ID, &a.TransactionID, &a.UserID, &a.FileName, &a.ObjectKey, &a.CreatedAt); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return err
+		}
+
+		transIDToAttachments[a.TransactionID] = append(transIDToAttachments[a.TransactionID], a)
+	}
+
+	for i := range trans {
+		trans[i].Attachments = transIDToAttachments[trans[i].ID]
+	}
+
+	return nil
+}
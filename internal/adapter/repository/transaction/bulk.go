@@ -0,0 +1,178 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+func (r *Repo) GetByIDsAndUserID(ctx context.Context, ids []int64, userID int64) ([]domain.Transaction, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT id, user_id, type, COALESCE(main_category_id, 0), COALESCE(sub_category_id, 0), account_id, to_account_id, price, currency, note, date
+									FROM transactions
+									WHERE user_id = ?
+									AND deleted_at IS NULL
+									AND id IN (?`)
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, userID, ids[0])
+	for _, id := range ids[1:] {
+		sb.WriteString(", ?")
+		args = append(args, id)
+	}
+	sb.WriteString(") ORDER BY id")
+
+	return getWithSplitsAndTags(ctx, r.DB, sb.String(), args)
+}
+
+func (r *Repo) Bulk(ctx context.Context, input domain.BulkTransInput, userID, memberID int64) ([]int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	// the snapshots before the change are read before anything is changed
+	beforeIDs := make([]int64, 0, len(input.Update)+len(input.Delete.IDs))
+	for _, u := range input.Update {
+		beforeIDs = append(beforeIDs, u.ID)
+	}
+	beforeIDs = append(beforeIDs, input.Delete.IDs...)
+
+	idToBefore, err := getSnapshots(ctx, tx, beforeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// each transaction is inserted by itself, so that its id is known for the split lines and tags
+	createdIDs := make([]int64, 0, len(input.Create))
+	createStmt := "INSERT INTO transactions (user_id, type, main_category_id, sub_category_id, account_id, to_account_id, price, currency, note, date, is_recurring) VALUES " + insertValues
+	for _, t := range input.Create {
+		res, err := tx.ExecContext(ctx, createStmt, insertArgs(cvtCreateTransInputToModelTransaction(t))...)
+		if err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		if err := insertSplits(ctx, tx, id, t.Splits); err != nil {
+			return nil, err
+		}
+
+		if err := insertTags(ctx, tx, id, t.TagIDs); err != nil {
+			return nil, err
+		}
+
+		createdIDs = append(createdIDs, id)
+	}
+
+	// NULL keeps the column unchanged
+	updateStmt := `UPDATE transactions
+								 SET main_category_id = COALESCE(?, main_category_id), sub_category_id = COALESCE(?, sub_category_id), note = COALESCE(?, note), date = COALESCE(?, date)
+								 WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
+	for _, u := range input.Update {
+		if _, err := tx.ExecContext(ctx, updateStmt, u.MainCategID, u.SubCategID, u.Note, u.Date, u.ID, userID); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		if err := addTags(ctx, tx, u.ID, u.AddTagIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	// the transactions are moved to trash, the same as deleting one by one
+	for start := 0; start < len(input.Delete.IDs); start += batchInsertSize {
+		end := min(start+batchInsertSize, len(input.Delete.IDs))
+		batch := input.Delete.IDs[start:end]
+
+		var sb strings.Builder
+		sb.WriteString("UPDATE transactions SET deleted_at = NOW() WHERE user_id = ? AND deleted_at IS NULL AND id IN (?")
+		args := make([]interface{}, 0, len(batch)+1)
+		args = append(args, userID, batch[0])
+		for _, id := range batch[1:] {
+			sb.WriteString(", ?")
+			args = append(args, id)
+		}
+		sb.WriteString(")")
+
+		if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return nil, err
+		}
+	}
+
+	if err := recordBulkRevisions(ctx, tx, createdIDs, input, idToBefore, memberID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return nil, err
+	}
+
+	return createdIDs, nil
+}
+
+// recordBulkRevisions appends the changes made by the member to the history of the transactions inside tx.
+// The snapshots after the change are read back inside tx.
+func recordBulkRevisions(ctx context.Context, tx *sql.Tx, createdIDs []int64, input domain.BulkTransInput, idToBefore map[int64]*domain.TransSnapshot, memberID int64) error {
+	afterIDs := make([]int64, 0, len(createdIDs)+len(input.Update))
+	afterIDs = append(afterIDs, createdIDs...)
+	for _, u := range input.Update {
+		afterIDs = append(afterIDs, u.ID)
+	}
+
+	idToAfter, err := getSnapshots(ctx, tx, afterIDs)
+	if err != nil {
+		return err
+	}
+
+	revs := make([]domain.TransRevision, 0, len(afterIDs)+len(input.Delete.IDs))
+	for _, id := range createdIDs {
+		revs = append(revs, domain.TransRevision{
+			TransactionID: id,
+			UserID:        memberID,
+			Action:        domain.RevisionActionTypeCreate,
+			After:         idToAfter[id],
+		})
+	}
+
+	for _, u := range input.Update {
+		revs = append(revs, domain.TransRevision{
+			TransactionID: u.ID,
+			UserID:        memberID,
+			Action:        domain.RevisionActionTypeUpdate,
+			Before:        idToBefore[u.ID],
+			After:         idToAfter[u.ID],
+		})
+	}
+
+	for _, id := range input.Delete.IDs {
+		revs = append(revs, domain.TransRevision{
+			TransactionID: id,
+			UserID:        memberID,
+			Action:        domain.RevisionActionTypeDelete,
+			Before:        idToBefore[id],
+		})
+	}
+
+	return insertRevisions(ctx, tx, revs)
+}
@@ -8,12 +8,15 @@ import (
 
 func cvtToDomainTransaction(t Transaction, m maincateg.MainCateg, s subcateg.SubCateg) domain.Transaction {
 	return domain.Transaction{
-		ID:     t.ID,
-		Type:   domain.CvtToTransactionType(t.Type),
-		UserID: t.UserID,
-		Price:  t.Price,
-		Note:   t.Note,
-		Date:   t.Date,
+		ID:          t.ID,
+		Type:        domain.CvtToTransactionType(t.Type),
+		UserID:      t.UserID,
+		Price:       t.Price,
+		Currency:    t.Currency,
+		Note:        t.Note,
+		Date:        t.Date,
+		AccountID:   cvtToAccountID(t.AccountID),
+		ToAccountID: cvtToAccountID(t.ToAccountID),
 		MainCateg: domain.MainCateg{
 			ID:       m.ID,
 			Name:     m.Name,
@@ -36,8 +39,12 @@ func cvtCreateTransInputToModelTransaction(t domain.CreateTransactionInput) Tran
 		MainCategID: t.MainCategID,
 		SubCategID:  t.SubCategID,
 		Price:       t.Price,
+		Currency:    t.Currency,
 		Note:        t.Note,
 		Date:        t.Date,
+		AccountID:   cvtToModelAccountID(t.AccountID),
+		ToAccountID: cvtToModelAccountID(t.ToAccountID),
+		IsRecurring: t.IsRecurring,
 	}
 }
 
@@ -48,18 +55,58 @@ func cvtUpdateTransInputToModelTransaction(t domain.UpdateTransactionInput) Tran
 		MainCategID: t.MainCategID,
 		SubCategID:  t.SubCategID,
 		Price:       t.Price,
+		Currency:    t.Currency,
 		Note:        t.Note,
 		Date:        t.Date,
+		AccountID:   cvtToModelAccountID(t.AccountID),
+		ToAccountID: cvtToModelAccountID(t.ToAccountID),
 	}
 }
 
-func cvtToDomainTransactionWithoutCategory(t Transaction) domain.Transaction {
+// cvtToDomainTransactionWithCategID converts the transaction with only the ids of its categories
+func cvtToDomainTransactionWithCategID(t Transaction) domain.Transaction {
 	return domain.Transaction{
-		ID:     t.ID,
-		Type:   domain.CvtToTransactionType(t.Type),
-		UserID: t.UserID,
-		Price:  t.Price,
-		Note:   t.Note,
-		Date:   t.Date,
+		ID:          t.ID,
+		Type:        domain.CvtToTransactionType(t.Type),
+		UserID:      t.UserID,
+		MainCateg:   domain.MainCateg{ID: t.MainCategID},
+		SubCateg:    domain.SubCateg{ID: t.SubCategID},
+		Price:       t.Price,
+		Currency:    t.Currency,
+		Note:        t.Note,
+		Date:        t.Date,
+		AccountID:   cvtToAccountID(t.AccountID),
+		ToAccountID: cvtToAccountID(t.ToAccountID),
+	}
+}
+
+// cvtToAccountID converts the nullable account id to the domain id, 0 means no account
+func cvtToAccountID(id *int64) int64 {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+func cvtToModelAccountID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+func cvtToDomainSplit(s Split, mainCategName, mainCategType, subCategName string) domain.TransactionSplit {
+	return domain.TransactionSplit{
+		MainCateg: domain.MainCateg{
+			ID:   s.MainCategID,
+			Name: mainCategName,
+			Type: domain.CvtToTransactionType(mainCategType),
+		},
+		SubCateg: domain.SubCateg{
+			ID:          s.SubCategID,
+			Name:        subCategName,
+			MainCategID: s.MainCategID,
+		},
+		Price: s.Price,
 	}
 }
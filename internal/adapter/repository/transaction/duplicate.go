@@ -0,0 +1,154 @@
+package transaction
+
+import (
+	"context"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	// maxDuplicatePairs is the most pairs of duplicate transactions returned at once
+	maxDuplicatePairs = 1000
+)
+
+func (r *Repo) GetDuplicateCandidates(ctx context.Context, trans domain.CreateTransactionInput, days int) ([]domain.Transaction, error) {
+	// the category of transfer is NULL, so the categories are compared with null-safe equal
+	qStmt := `SELECT id
+						FROM transactions
+						WHERE user_id = ?
+						AND deleted_at IS NULL
+						AND type = ?
+						AND price = ?
+						AND currency = ` + currencyOrBaseValue + `
+						AND main_category_id <=> NULLIF(?, 0)
+						AND sub_category_id <=> NULLIF(?, 0)
+						AND date BETWEEN DATE_SUB(?, INTERVAL ? DAY) AND DATE_ADD(?, INTERVAL ? DAY)
+						ORDER BY id`
+
+	args := []interface{}{
+		trans.UserID,
+		trans.Type.ToModelValue(),
+		trans.Price,
+		trans.Currency, trans.UserID,
+		trans.MainCategID,
+		trans.SubCategID,
+		trans.Date, days, trans.Date, days,
+	}
+
+	ids, err := r.queryIDs(ctx, qStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByIDsAndUserID(ctx, ids, trans.UserID)
+}
+
+// GetDuplicatePairs returns at most maxDuplicatePairs pairs, so a long range of a busy user never loads the whole history.
+// The occurrences of recurring transactions are expected to look alike, so they're never suspected.
+func (r *Repo) GetDuplicatePairs(ctx context.Context, opt domain.GetDuplicatesOpt, userID int64) ([]domain.DuplicatePair, error) {
+	qStmt := `SELECT t1.id, t2.id
+						FROM transactions AS t1
+						INNER JOIN transactions AS t2
+						ON t1.user_id = t2.user_id
+						AND t1.id < t2.id
+						AND t1.type = t2.type
+						AND t1.price = t2.price
+						AND t1.currency = t2.currency
+						AND t1.main_category_id <=> t2.main_category_id
+						AND t1.sub_category_id <=> t2.sub_category_id
+						AND ABS(DATEDIFF(t1.date, t2.date)) <= ?
+						WHERE t1.user_id = ?
+						AND t1.date BETWEEN ? AND ?
+						AND t2.date BETWEEN ? AND ?
+						AND t1.deleted_at IS NULL
+						AND t2.deleted_at IS NULL
+						AND NOT t1.is_recurring
+						AND NOT t2.is_recurring
+						AND NOT EXISTS (
+							SELECT 1 FROM duplicate_dismissals AS dd
+							WHERE dd.transaction_id = t1.id AND dd.other_transaction_id = t2.id
+						)
+						ORDER BY t1.id, t2.id
+						LIMIT ?`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, opt.Days, userID, opt.StartDate, opt.EndDate, opt.StartDate, opt.EndDate, maxDuplicatePairs)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var pairs []domain.DuplicatePair
+	for rows.Next() {
+		var p domain.DuplicatePair
+		if err := rows.Scan(&p.FirstID, &p.SecondID); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		pairs = append(pairs, p)
+	}
+
+	return pairs, nil
+}
+
+func (r *Repo) DismissDuplicates(ctx context.Context, ids []int64) error {
+	// every pair of the transactions is dismissed, the smaller id goes first
+	var sb strings.Builder
+	var args []interface{}
+	for i, first := range ids {
+		for _, second := range ids[i+1:] {
+			args = append(args, min(first, second), max(first, second))
+
+			if sb.Len() > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("(?, ?)")
+		}
+	}
+
+	if sb.Len() == 0 {
+		return nil
+	}
+
+	qStmt := "INSERT IGNORE INTO duplicate_dismissals (transaction_id, other_transaction_id) VALUES " + sb.String()
+	if _, err := r.DB.ExecContext(ctx, qStmt, args...); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// queryIDs returns the ids selected by the query
+func (r *Repo) queryIDs(ctx context.Context, qStmt string, args ...interface{}) ([]int64, error) {
+	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
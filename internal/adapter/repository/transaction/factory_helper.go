@@ -17,10 +17,11 @@ var (
 
 func blueprint(i int) Transaction {
 	return Transaction{
-		Type:  domain.TransactionTypeIncome.ToModelValue(),
-		Price: float64(i*10.0 + 1.0),
-		Note:  "test" + fmt.Sprint(i),
-		Date:  mockTimeNowF,
+		Type:     domain.TransactionTypeIncome.ToModelValue(),
+		Price:    float64(i*10.0 + 1.0),
+		Currency: domain.DefaultCurrency,
+		Note:     "test" + fmt.Sprint(i),
+		Date:     mockTimeNowF,
 	}
 }
 
@@ -44,9 +45,10 @@ func GetAll_GenExpResult(ts []Transaction, u user.User, ms []maincateg.MainCateg
 				Name:        ss[i].Name,
 				MainCategID: ss[i].MainCategID,
 			},
-			Price: ts[i].Price,
-			Note:  ts[i].Note,
-			Date:  ts[i].Date,
+			Price:    ts[i].Price,
+			Currency: ts[i].Currency,
+			Note:     ts[i].Note,
+			Date:     ts[i].Date,
 		})
 	}
 
@@ -10,40 +10,135 @@ import (
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 )
 
+const (
+	// currencyOrBaseValue is the value of currency column, which falls back to the user's base currency when it's empty
+	// the arguments are the currency and the user id
+	currencyOrBaseValue = "COALESCE(NULLIF(?, ''), (SELECT base_currency FROM users WHERE id = ?))"
+
+	// currencyOrOwnerBaseValue is the same as currencyOrBaseValue, but uses the user of the updated transaction
+	// the argument is the currency
+	currencyOrOwnerBaseValue = "COALESCE(NULLIF(?, ''), (SELECT base_currency FROM users WHERE id = transactions.user_id))"
+
+	// baseTransFrom joins transactions(t) with their users(u), so that the price can be converted to the user's base currency
+	// transactions in trash are left out
+	baseTransFrom = "FROM transactions AS t INNER JOIN users AS u ON t.user_id = u.id AND t.deleted_at IS NULL"
+
+	// transLines are the category lines of transactions, which can be used in place of the transactions table.
+	// Each split line of a split transaction is a line with its own category and price,
+	// and other transactions are a line by themselves.
+	transLines = `(SELECT id, user_id, type, main_category_id, sub_category_id, price, currency, date
+		FROM transactions
+		WHERE deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM transaction_splits AS ts WHERE ts.transaction_id = transactions.id)
+		UNION ALL
+		SELECT tr.id, tr.user_id, tr.type, ts.main_category_id, ts.sub_category_id, ts.price, tr.currency, tr.date
+		FROM transaction_splits AS ts
+		INNER JOIN transactions AS tr ON ts.transaction_id = tr.id
+		WHERE tr.deleted_at IS NULL)`
+
+	// baseLineFrom is the same as baseTransFrom, but each split line is attributed to its own category
+	baseLineFrom = "FROM " + transLines + " AS t INNER JOIN users AS u ON t.user_id = u.id"
+
+	// basePrice is the price of transaction t in the base currency of user u.
+	// It's converted with the latest exchange rate on or before the transaction date,
+	// or the inverse of the rate in the opposite direction.
+	// It's NULL when there's no exchange rate, so SUM leaves the transaction out, and unconvertedCount reports it.
+	basePrice = `(t.price * CASE WHEN t.currency = u.base_currency THEN 1 ELSE COALESCE(
+		(SELECT er.rate FROM exchange_rates AS er WHERE er.from_currency = t.currency AND er.to_currency = u.base_currency AND er.date <= t.date ORDER BY er.date DESC LIMIT 1),
+		(SELECT 1 / er.rate FROM exchange_rates AS er WHERE er.from_currency = u.base_currency AND er.to_currency = t.currency AND er.date <= t.date ORDER BY er.date DESC LIMIT 1)
+	) END)`
+
+	// unconvertedCount counts the income and expense which are left out of the sums, because there's no exchange rate
+	unconvertedCount = "COALESCE(SUM(t.type IN ('1', '2') AND " + basePrice + " IS NULL), 0)"
+
+	// signedBasePrice is basePrice with positive income and negative expense
+	// transfer only moves money between accounts, so it's 0
+	signedBasePrice = "(CASE WHEN t.type = '1' THEN 1 WHEN t.type = '2' THEN -1 ELSE 0 END) * " + basePrice
+
+	// insertValues is the placeholders of inserting a transaction, the arguments are built by insertArgs
+	// transfer doesn't have categories, and 0 means no account
+	insertValues = "(?, ?, NULLIF(?, 0), NULLIF(?, 0), ?, ?, ?, " + currencyOrBaseValue + ", ?, ?, ?)"
+)
+
 func getAllQStmt(opt domain.GetTransOpt, decodedNextKeys domain.DecodedNextKeys, t Transaction) string {
 	var sb strings.Builder
 
-	sb.WriteString(`SELECT t.id, t.user_id, t.type, t.price, t.note, t.date, mc.id, mc.name, mc.type, mc.icon_type, mc.icon_data, sc.id, sc.name
+	// transfer doesn't have categories, so the category columns are NULL
+	sb.WriteString(`SELECT t.id, t.user_id, t.type, t.price, t.currency, t.note, t.date, t.account_id, t.to_account_id,
+									COALESCE(mc.id, 0), COALESCE(mc.name, ''), COALESCE(mc.type, ''), COALESCE(mc.icon_type, ''), COALESCE(mc.icon_data, ''),
+									COALESCE(sc.id, 0), COALESCE(sc.name, '')
 									FROM transactions AS t
 									LEFT JOIN main_categories AS mc 
 									ON t.main_category_id = mc.id
 									LEFT JOIN sub_categories AS sc 
 									ON t.sub_category_id = sc.id
 									WHERE t.user_id = ?
+									AND t.deleted_at IS NULL
 									`)
 
+	sb.WriteString(filterStmt(opt))
+
+	operand, dir := cursorOrder(opt)
+
+	// construct the next key query statement
+	// now, we only support 1 or 2 next keys
+	// when it's 1, it means there's no sorting(sort by id)
+	// when it's 2, it means there's sorting(sort by id and other field)
+	if len(decodedNextKeys) != 0 {
+		if len(decodedNextKeys) == 1 {
+			sb.WriteString(fmt.Sprintf(" AND t.%s %s ?", genDBFieldNames(decodedNextKeys[0].Field, t), operand))
+		}
+
+		if len(decodedNextKeys) == 2 {
+			// AND (col_1 < or > val_1
+			// OR (col_1 = val_1 AND col_2 < or > val_2))
+			// the parentheses keep the OR from escaping the conditions of user and filter
+			sb.WriteString(fmt.Sprintf(" AND (t.%s %s ?", genDBFieldNames(decodedNextKeys[0].Field, t), operand))
+			sb.WriteString(fmt.Sprintf(" OR (t.%s = ? AND t.%s %s ?))", genDBFieldNames(decodedNextKeys[0].Field, t), genDBFieldNames(decodedNextKeys[1].Field, t), operand))
+		}
+	}
+
+	if opt.Sort != nil && opt.Sort.By.IsValid() {
+		sb.WriteString(fmt.Sprintf(" ORDER BY t.%s %s, t.id %s", opt.Sort.By.String(), dir, dir))
+	} else {
+		sb.WriteString(fmt.Sprintf(" ORDER BY t.id %s", dir))
+	}
+
+	if opt.Cursor.Size != 0 {
+		sb.WriteString(" LIMIT ?")
+	}
+
+	return sb.String()
+}
+
+// filterStmt is the conditions of WHERE clause narrowing down the transactions by the search and filter of opt,
+// where the transactions are t, and their main and sub categories are mc and sc.
+// The arguments are built by filterArgs
+func filterStmt(opt domain.GetTransOpt) string {
+	var sb strings.Builder
+
 	if opt.Search.Keyword != nil {
-		sb.WriteString(" AND MATCH (note) AGAINST (? IN NATURAL LANGUAGE MODE)")
+		sb.WriteString(" AND MATCH (t.note) AGAINST (? IN NATURAL LANGUAGE MODE)")
 	}
 
-	if opt.Filter.StartDate != nil && opt.Filter.EndDate != nil {
-		sb.WriteString(" AND date BETWEEN ? AND ?")
+	if opt.Search.Query != nil {
+		sb.WriteString(searchQueryStmt(*opt.Search.Query))
 	}
 
 	if opt.Filter.StartDate != nil {
-		sb.WriteString(" AND date >= ?")
+		sb.WriteString(" AND t.date >= ?")
 	}
 
 	if opt.Filter.EndDate != nil {
-		sb.WriteString(" AND date <= ?")
+		sb.WriteString(" AND t.date <= ?")
 	}
 
 	if opt.Filter.MinPrice != nil {
-		sb.WriteString(" AND price >= ?")
+		sb.WriteString(" AND t.price >= ?")
 	}
 
 	if opt.Filter.MaxPrice != nil {
-		sb.WriteString(" AND price <= ?")
+		sb.WriteString(" AND t.price <= ?")
 	}
 
 	if opt.Filter.MainCategIDs != nil {
@@ -62,33 +157,133 @@ func getAllQStmt(opt domain.GetTransOpt, decodedNextKeys domain.DecodedNextKeys,
 		sb.WriteString(")")
 	}
 
-	// construct the next key query statement
-	// now, we only support 1 or 2 next keys
-	// when it's 1, it means there's no sorting(sort by id)
-	// when it's 2, it means there's sorting(sort by id and other field)
-	if len(decodedNextKeys) != 0 {
-		if len(decodedNextKeys) == 1 {
-			sb.WriteString(fmt.Sprintf(" AND t.%s %s ?", genDBFieldNames(decodedNextKeys[0].Field, t), domain.GetOperandFromSort(opt.Sort)))
+	// any: the transaction has at least one of the tags
+	// all: the transaction has every one of the tags
+	if opt.Filter.TagIDs != nil {
+		sb.WriteString(" AND t.id IN (SELECT tt.transaction_id FROM transaction_tags AS tt WHERE tt.tag_id IN (?")
+		for i := 1; i < len(opt.Filter.TagIDs); i++ {
+			sb.WriteString(", ?")
 		}
+		sb.WriteString(")")
 
-		if len(decodedNextKeys) == 2 {
-			// AND col_1 < or > val_1
-			// OR (col_1 = val_1 AND col_2 < or > val_2)
-			// the shorted version is: AND (col_1, col_2) < or > (val_1, val_2)
-			sb.WriteString(fmt.Sprintf(" AND t.%s %s ?", genDBFieldNames(decodedNextKeys[0].Field, t), domain.GetOperandFromSort(opt.Sort)))
-			sb.WriteString(fmt.Sprintf(" OR (t.%s = ? AND t.%s %s ?)", genDBFieldNames(decodedNextKeys[0].Field, t), genDBFieldNames(decodedNextKeys[1].Field, t), domain.GetOperandFromSort(opt.Sort)))
+		if opt.Filter.TagMatch == domain.TagMatchTypeAll {
+			sb.WriteString(" GROUP BY tt.transaction_id HAVING COUNT(DISTINCT tt.tag_id) = ?")
 		}
+		sb.WriteString(")")
 	}
 
-	if opt.Sort != nil {
-		sb.WriteString(fmt.Sprintf(" ORDER BY t.%s %s, t.id %s", opt.Sort.By.String(), opt.Sort.Dir.String(), opt.Sort.Dir.String()))
+	return sb.String()
+}
+
+func filterArgs(opt domain.GetTransOpt) []interface{} {
+	var args []interface{}
+
+	if opt.Search.Keyword != nil {
+		args = append(args, *opt.Search.Keyword)
 	}
 
-	if opt.Cursor.Size != 0 {
-		sb.WriteString(" LIMIT ?")
+	if opt.Search.Query != nil {
+		args = append(args, searchQueryArgs(*opt.Search.Query)...)
 	}
 
-	return sb.String()
+	if opt.Filter.StartDate != nil {
+		args = append(args, *opt.Filter.StartDate)
+	}
+
+	if opt.Filter.EndDate != nil {
+		args = append(args, *opt.Filter.EndDate)
+	}
+
+	if opt.Filter.MinPrice != nil {
+		args = append(args, *opt.Filter.MinPrice)
+	}
+
+	if opt.Filter.MaxPrice != nil {
+		args = append(args, *opt.Filter.MaxPrice)
+	}
+
+	if opt.Filter.MainCategIDs != nil {
+		for _, id := range opt.Filter.MainCategIDs {
+			args = append(args, id)
+		}
+	}
+
+	if opt.Filter.SubCategIDs != nil {
+		for _, id := range opt.Filter.SubCategIDs {
+			args = append(args, id)
+		}
+	}
+
+	if opt.Filter.TagIDs != nil {
+		for _, id := range opt.Filter.TagIDs {
+			args = append(args, id)
+		}
+
+		if opt.Filter.TagMatch == domain.TagMatchTypeAll {
+			args = append(args, countDistinct(opt.Filter.TagIDs))
+		}
+	}
+
+	return args
+}
+
+// chartFilterStmt narrows down the transactions t of chart by the filter and search of opt, e.g. of a saved view,
+// and it's empty when opt is nil.
+// It's a subquery on the transactions, so it works on the lines of split transactions as well.
+// The arguments are built by chartFilterArgs
+func chartFilterStmt(opt *domain.GetTransOpt) string {
+	if opt == nil {
+		return ""
+	}
+
+	return ` AND t.id IN (SELECT t.id FROM transactions AS t
+		LEFT JOIN main_categories AS mc ON t.main_category_id = mc.id
+		LEFT JOIN sub_categories AS sc ON t.sub_category_id = sc.id
+		WHERE t.user_id = ?` + filterStmt(*opt) + ")"
+}
+
+func chartFilterArgs(opt *domain.GetTransOpt, userID int64) []interface{} {
+	if opt == nil {
+		return nil
+	}
+
+	return append([]interface{}{userID}, filterArgs(*opt)...)
+}
+
+// cursorOrder returns the operand comparing the rows with the cursor, and the direction of ordering the rows.
+// The rows before the cursor are wanted for the previous key, so both are flipped,
+// which lists the rows closest to the cursor first, and the caller has to reverse them back
+func cursorOrder(opt domain.GetTransOpt) (string, string) {
+	operand := domain.GetOperandFromSort(opt.Sort)
+	if opt.Cursor.PrevKey != "" {
+		if operand == ">" {
+			operand = "<"
+		} else {
+			operand = ">"
+		}
+	}
+
+	if operand == ">" {
+		return operand, "ASC"
+	}
+	return operand, "DESC"
+}
+
+// getTotalQStmt is the total of the transactions matching the filter and search of opt, regardless of the cursor.
+// The arguments are built by getTotalArgs
+func getTotalQStmt(opt domain.GetTransOpt) string {
+	return `SELECT COUNT(*),
+									COALESCE(SUM(CASE WHEN t.type = '1' THEN ` + basePrice + ` ELSE 0 END), 0),
+									COALESCE(SUM(CASE WHEN t.type = '2' THEN ` + basePrice + ` ELSE 0 END), 0),
+									` + unconvertedCount + `
+									` + baseTransFrom + `
+									LEFT JOIN main_categories AS mc ON t.main_category_id = mc.id
+									LEFT JOIN sub_categories AS sc ON t.sub_category_id = sc.id
+									WHERE t.user_id = ?` + filterStmt(opt)
+}
+
+func getTotalArgs(opt domain.GetTransOpt, userID int64) []interface{} {
+	return append([]interface{}{userID}, filterArgs(opt)...)
 }
 
 // genDBFieldNames generates db field names from struct field names
@@ -134,41 +329,7 @@ func getAllArgs(opt domain.GetTransOpt, decodedNextKeys domain.DecodedNextKeys,
 	var args []interface{}
 	args = append(args, userID)
 
-	if opt.Search.Keyword != nil {
-		args = append(args, *opt.Search.Keyword)
-	}
-
-	if opt.Filter.StartDate != nil && opt.Filter.EndDate != nil {
-		args = append(args, *opt.Filter.StartDate, *opt.Filter.EndDate)
-	}
-
-	if opt.Filter.StartDate != nil {
-		args = append(args, *opt.Filter.StartDate)
-	}
-
-	if opt.Filter.EndDate != nil {
-		args = append(args, *opt.Filter.EndDate)
-	}
-
-	if opt.Filter.MinPrice != nil {
-		args = append(args, *opt.Filter.MinPrice)
-	}
-
-	if opt.Filter.MaxPrice != nil {
-		args = append(args, *opt.Filter.MaxPrice)
-	}
-
-	if opt.Filter.MainCategIDs != nil {
-		for _, id := range opt.Filter.MainCategIDs {
-			args = append(args, id)
-		}
-	}
-
-	if opt.Filter.SubCategIDs != nil {
-		for _, id := range opt.Filter.SubCategIDs {
-			args = append(args, id)
-		}
-	}
+	args = append(args, filterArgs(opt)...)
 
 	if len(decodedNextKeys) != 0 {
 		if len(decodedNextKeys) == 1 {
@@ -191,26 +352,23 @@ func getAccInfoQStmt(query domain.GetAccInfoQuery) string {
 	var sb strings.Builder
 
 	sb.WriteString(`SELECT
-									SUM(CASE WHEN type = '1' THEN price ELSE 0 END) AS total_income,
-									SUM(CASE WHEN type = '2' THEN price ELSE 0 END) AS total_expense,
-									SUM(CASE WHEN type = '1' THEN price ELSE -price END) AS total_balance
-									FROM transactions
-									WHERE user_id = ?
+									COALESCE(SUM(CASE WHEN t.type = '1' THEN ` + basePrice + ` ELSE 0 END), 0) AS total_income,
+									COALESCE(SUM(CASE WHEN t.type = '2' THEN ` + basePrice + ` ELSE 0 END), 0) AS total_expense,
+									COALESCE(SUM(` + signedBasePrice + `), 0) AS total_balance,
+									` + unconvertedCount + ` AS unconverted
+									` + baseTransFrom + `
+									WHERE t.user_id = ?
 									`)
 
-	if query.StartDate != nil && query.EndDate != nil {
-		sb.WriteString(" AND date BETWEEN ? AND ?")
-	}
-
 	if query.StartDate != nil {
-		sb.WriteString(" AND date >= ?")
+		sb.WriteString(" AND t.date >= ?")
 	}
 
 	if query.EndDate != nil {
-		sb.WriteString(" AND date <= ?")
+		sb.WriteString(" AND t.date <= ?")
 	}
 
-	sb.WriteString(" GROUP BY user_id")
+	sb.WriteString(" GROUP BY t.user_id")
 
 	return sb.String()
 }
@@ -219,10 +377,6 @@ func getAccInfoArgs(query domain.GetAccInfoQuery, userID int64) []interface{} {
 	var args []interface{}
 	args = append(args, userID)
 
-	if query.StartDate != nil && query.EndDate != nil {
-		args = append(args, *query.StartDate, *query.EndDate)
-	}
-
 	if query.StartDate != nil {
 		args = append(args, *query.StartDate)
 	}
@@ -234,33 +388,34 @@ func getAccInfoArgs(query domain.GetAccInfoQuery, userID int64) []interface{} {
 	return args
 }
 
-func getGetDailyBarChartDataQuery(mainCategIDs []int64) string {
+func getGetDailyBarChartDataQuery(mainCategIDs []int64, opt *domain.GetTransOpt) string {
 	var sb strings.Builder
 
 	sb.WriteString(`SELECT 
-									DATE_FORMAT(date, '%Y-%m-%d') AS date,
-									SUM(price)
-									FROM transactions
-									WHERE user_id = ?
-									AND type = ?
-									AND date BETWEEN ? AND ?
+									DATE_FORMAT(t.date, '%Y-%m-%d') AS date,
+									COALESCE(SUM(` + basePrice + `), 0)
+									` + baseLineFrom + `
+									WHERE t.user_id = ?
+									AND t.type = ?
+									AND t.date BETWEEN ? AND ?
 									`)
 
 	if mainCategIDs != nil {
-		sb.WriteString("AND main_category_id IN (?")
+		sb.WriteString("AND t.main_category_id IN (?")
 		for i := 1; i < len(mainCategIDs); i++ {
 			sb.WriteString(", ?")
 		}
 		sb.WriteString(")")
 	}
 
-	sb.WriteString(`GROUP BY date
-						      ORDER BY date`)
+	sb.WriteString(chartFilterStmt(opt))
+	sb.WriteString(` GROUP BY t.date
+						      ORDER BY t.date`)
 
 	return sb.String()
 }
 
-func genGetDailyBarChartDataArgs(userID int64, transactionType domain.TransactionType, dateRange domain.ChartDateRange, mainCategIDs []int64) []interface{} {
+func genGetDailyBarChartDataArgs(userID int64, transactionType domain.TransactionType, dateRange domain.ChartDateRange, mainCategIDs []int64, opt *domain.GetTransOpt) []interface{} {
 	l := 4
 	if mainCategIDs != nil {
 		l += len(mainCategIDs)
@@ -273,37 +428,38 @@ func genGetDailyBarChartDataArgs(userID int64, transactionType domain.Transactio
 		args = append(args, id)
 	}
 
-	return args
+	return append(args, chartFilterArgs(opt, userID)...)
 }
 
-func getGetMonthlyBarChartDataQuery(mainCategIDs []int64) string {
+func getGetMonthlyBarChartDataQuery(mainCategIDs []int64, opt *domain.GetTransOpt) string {
 	var sb strings.Builder
 
 	sb.WriteString(`SELECT
-									YEAR(date),
-									LPAD(MONTH(date), 2, '0') AS month,
-									SUM(price)
-									FROM transactions
-									WHERE user_id = ?
-									AND type = ?
-									AND date BETWEEN ? AND ?
+									YEAR(t.date),
+									LPAD(MONTH(t.date), 2, '0') AS month,
+									COALESCE(SUM(` + basePrice + `), 0)
+									` + baseLineFrom + `
+									WHERE t.user_id = ?
+									AND t.type = ?
+									AND t.date BETWEEN ? AND ?
 									`)
 
 	if mainCategIDs != nil {
-		sb.WriteString("AND main_category_id IN (?")
+		sb.WriteString("AND t.main_category_id IN (?")
 		for i := 1; i < len(mainCategIDs); i++ {
 			sb.WriteString(", ?")
 		}
 		sb.WriteString(")")
 	}
 
-	sb.WriteString(`GROUP BY YEAR(date), LPAD(MONTH(date), 2, '0')
-						      ORDER BY YEAR(date), LPAD(MONTH(date), 2, '0')`)
+	sb.WriteString(chartFilterStmt(opt))
+	sb.WriteString(` GROUP BY YEAR(t.date), LPAD(MONTH(t.date), 2, '0')
+						      ORDER BY YEAR(t.date), LPAD(MONTH(t.date), 2, '0')`)
 
 	return sb.String()
 }
 
-func getGetMonthlyBarChartDataArgs(userID int64, transactionType domain.TransactionType, dateRange domain.ChartDateRange, mainCategIDs []int64) []interface{} {
+func getGetMonthlyBarChartDataArgs(userID int64, transactionType domain.TransactionType, dateRange domain.ChartDateRange, mainCategIDs []int64, opt *domain.GetTransOpt) []interface{} {
 	l := 4
 	if mainCategIDs != nil {
 		l += len(mainCategIDs)
@@ -316,5 +472,133 @@ func getGetMonthlyBarChartDataArgs(userID int64, transactionType domain.Transact
 		args = append(args, id)
 	}
 
+	return append(args, chartFilterArgs(opt, userID)...)
+}
+
+// searchQueryStmt compiles the search query into the conditions of WHERE clause, the arguments are built by searchQueryArgs.
+// The terms are matched in boolean mode, where the positive terms are all required.
+// The negated terms are matched separately, because boolean mode with only negated terms matches nothing.
+func searchQueryStmt(q domain.SearchQuery) string {
+	var sb strings.Builder
+
+	must, mustNot := fullTextExprs(q.Terms)
+	if must != "" {
+		sb.WriteString(" AND MATCH (t.note) AGAINST (? IN BOOLEAN MODE)")
+	}
+	if mustNot != "" {
+		sb.WriteString(" AND NOT MATCH (t.note) AGAINST (? IN BOOLEAN MODE)")
+	}
+
+	// the split transaction is matched by its first line, which is the category of the transaction
+	for _, c := range q.Categs {
+		if c.Negated {
+			sb.WriteString(" AND NOT (COALESCE(mc.name, '') = ? OR COALESCE(sc.name, '') = ?)")
+			continue
+		}
+		sb.WriteString(" AND (mc.name = ? OR sc.name = ?)")
+	}
+
+	for _, tg := range q.Tags {
+		if tg.Negated {
+			sb.WriteString(" AND t.id NOT IN")
+		} else {
+			sb.WriteString(" AND t.id IN")
+		}
+		sb.WriteString(" (SELECT tt.transaction_id FROM transaction_tags AS tt INNER JOIN tags AS tg ON tt.tag_id = tg.id WHERE tg.name = ?)")
+	}
+
+	for _, tc := range q.Types {
+		if tc.Negated {
+			sb.WriteString(" AND t.type <> ?")
+			continue
+		}
+		sb.WriteString(" AND t.type = ?")
+	}
+
+	for _, p := range q.Prices {
+		sb.WriteString(fmt.Sprintf(" AND t.price %s ?", p.Op.String()))
+	}
+
+	for _, d := range q.Dates {
+		sb.WriteString(fmt.Sprintf(" AND t.date %s ?", d.Op.String()))
+	}
+
+	return sb.String()
+}
+
+func searchQueryArgs(q domain.SearchQuery) []interface{} {
+	var args []interface{}
+
+	must, mustNot := fullTextExprs(q.Terms)
+	if must != "" {
+		args = append(args, must)
+	}
+	if mustNot != "" {
+		args = append(args, mustNot)
+	}
+
+	for _, c := range q.Categs {
+		args = append(args, c.Value, c.Value)
+	}
+
+	for _, tg := range q.Tags {
+		args = append(args, tg.Value)
+	}
+
+	for _, tc := range q.Types {
+		args = append(args, tc.Type.ToModelValue())
+	}
+
+	for _, p := range q.Prices {
+		args = append(args, p.Value)
+	}
+
+	for _, d := range q.Dates {
+		args = append(args, d.Value)
+	}
+
 	return args
 }
+
+// fullTextExprs returns the boolean mode expressions of the terms.
+// must requires all of the positive terms, e.g. +coffee +"tim hortons",
+// and mustNot matches any of the negated terms.
+func fullTextExprs(terms []domain.SearchTerm) (must string, mustNot string) {
+	var mustTerms, mustNotTerms []string
+	for _, t := range terms {
+		// the operators of boolean mode are removed, so the value is matched as it is
+		words := strings.FieldsFunc(t.Value, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune(`+-<>()~*"@`, r)
+		})
+		if len(words) == 0 {
+			continue
+		}
+
+		expr := words[0]
+		if len(words) > 1 {
+			expr = `"` + strings.Join(words, " ") + `"`
+		}
+
+		if t.Negated {
+			mustNotTerms = append(mustNotTerms, expr)
+			continue
+		}
+		mustTerms = append(mustTerms, "+"+expr)
+	}
+
+	return strings.Join(mustTerms, " "), strings.Join(mustNotTerms, " ")
+}
+
+// countDistinct returns the number of distinct ids
+func countDistinct(ids []int64) int {
+	m := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		m[id] = struct{}{}
+	}
+
+	return len(m)
+}
+
+func insertArgs(t Transaction) []interface{} {
+	return []interface{}{t.UserID, t.Type, t.MainCategID, t.SubCategID, t.AccountID, t.ToAccountID, t.Price, t.Currency, t.UserID, t.Note, t.Date, t.IsRecurring}
+}
@@ -0,0 +1,160 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+// querier is either the database or a database transaction,
+// so that the transactions can be read back inside the database transaction which changes them
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// getWithSplitsAndTags runs the query of transactions, and loads their splits and tags with the same querier.
+// The query must select the same columns as GetByIDAndUserID.
+func getWithSplitsAndTags(ctx context.Context, q querier, qStmt string, args []interface{}) ([]domain.Transaction, error) {
+	rows, err := q.QueryContext(ctx, qStmt, args...)
+	if err != nil {
+		logger.Error("q.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var result []domain.Transaction
+	for rows.Next() {
+		var trans Transaction
+		if err := rows.Scan(&trans.ID, &trans.UserID, &trans.Type, &trans.MainCategID, &trans.SubCategID, &trans.AccountID, &trans.ToAccountID, &trans.Price, &trans.Currency, &trans.Note, &trans.Date); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		result = append(result, cvtToDomainTransactionWithCategID(trans))
+	}
+
+	if err := attachSplits(ctx, q, result); err != nil {
+		return nil, err
+	}
+
+	if err := attachTags(ctx, q, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getSnapshots reads the transactions by ids inside tx, including the ones in trash, and returns their snapshots by id.
+// The snapshots are read from the database, so that they have the values filled by the database, e.g. currency.
+func getSnapshots(ctx context.Context, tx *sql.Tx, ids []int64) (map[int64]*domain.TransSnapshot, error) {
+	idToSnapshot := make(map[int64]*domain.TransSnapshot, len(ids))
+	for start := 0; start < len(ids); start += batchInsertSize {
+		end := min(start+batchInsertSize, len(ids))
+		batch := ids[start:end]
+
+		var sb strings.Builder
+		sb.WriteString(`SELECT id, user_id, type, COALESCE(main_category_id, 0), COALESCE(sub_category_id, 0), account_id, to_account_id, price, currency, note, date
+										FROM transactions
+										WHERE id IN (?`)
+		args := make([]interface{}, 0, len(batch))
+		args = append(args, batch[0])
+		for _, id := range batch[1:] {
+			sb.WriteString(", ?")
+			args = append(args, id)
+		}
+		sb.WriteString(")")
+
+		trans, err := getWithSplitsAndTags(ctx, tx, sb.String(), args)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range trans {
+			idToSnapshot[t.ID] = domain.NewTransSnapshot(t)
+		}
+	}
+
+	return idToSnapshot, nil
+}
+
+// recordRevision appends the change of the transaction made by the member to its history inside tx.
+// The snapshot after the change is read back inside tx, except for delete.
+func recordRevision(ctx context.Context, tx *sql.Tx, id, memberID int64, action domain.RevisionActionType, before *domain.TransSnapshot) error {
+	rev := domain.TransRevision{
+		TransactionID: id,
+		UserID:        memberID,
+		Action:        action,
+		Before:        before,
+	}
+
+	if action != domain.RevisionActionTypeDelete {
+		idToSnapshot, err := getSnapshots(ctx, tx, []int64{id})
+		if err != nil {
+			return err
+		}
+
+		rev.After = idToSnapshot[id]
+	}
+
+	return insertRevisions(ctx, tx, []domain.TransRevision{rev})
+}
+
+// insertRevisions inserts the revisions of the transactions, nil snapshot is stored as NULL
+func insertRevisions(ctx context.Context, tx *sql.Tx, revs []domain.TransRevision) error {
+	for start := 0; start < len(revs); start += batchInsertSize {
+		end := min(start+batchInsertSize, len(revs))
+		batch := revs[start:end]
+
+		var sb strings.Builder
+		sb.WriteString("INSERT INTO transaction_revisions (transaction_id, user_id, action, before_snapshot, after_snapshot) VALUES ")
+		args := make([]interface{}, 0, len(batch)*5)
+		for i, rev := range batch {
+			before, err := marshalSnapshot(rev.Before)
+			if err != nil {
+				return err
+			}
+
+			after, err := marshalSnapshot(rev.After)
+			if err != nil {
+				return err
+			}
+
+			sb.WriteString("(?, ?, ?, ?, ?)")
+			if i < len(batch)-1 {
+				sb.WriteString(", ")
+			}
+
+			args = append(args, rev.TransactionID, rev.UserID, rev.Action.ToModelValue(), before, after)
+		}
+
+		if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marshalSnapshot returns nil for nil snapshot, so that it's stored as NULL
+func marshalSnapshot(s *domain.TransSnapshot) (interface{}, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		logger.Error("json.Marshal failed", "package", packageName, "err", err)
+		return nil, err
+	}
+
+	return b, nil
+}
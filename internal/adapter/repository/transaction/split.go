@@ -0,0 +1,96 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+type Split struct {
+	ID            int64
+	TransactionID int64
+	MainCategID   int64
+	SubCategID    int64
+	Price         float64
+}
+
+// insertSplits inserts the split lines of the transaction
+func insertSplits(ctx context.Context, tx *sql.Tx, transID int64, splits []domain.SplitInput) error {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO transaction_splits (transaction_id, main_category_id, sub_category_id, price) VALUES ")
+	args := make([]interface{}, 0, len(splits)*4)
+	for i, s := range splits {
+		sb.WriteString("(?, ?, ?, ?)")
+		if i < len(splits)-1 {
+			sb.WriteString(", ")
+		}
+
+		args = append(args, transID, s.MainCategID, s.SubCategID, s.Price)
+	}
+
+	if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// attachSplits loads the split lines of the transactions, and sets them on the split transactions
+func attachSplits(ctx context.Context, q querier, trans []domain.Transaction) error {
+	if len(trans) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT ts.transaction_id, ts.price, mc.id, mc.name, mc.type, sc.id, sc.name
+									FROM transaction_splits AS ts
+									INNER JOIN main_categories AS mc
+									ON ts.main_category_id = mc.id
+									INNER JOIN sub_categories AS sc
+									ON ts.sub_category_id = sc.id
+									WHERE ts.transaction_id IN (?`)
+	args := make([]interface{}, 0, len(trans))
+	args = append(args, trans[0].ID)
+	for _, t := range trans[1:] {
+		sb.WriteString(", ?")
+		args = append(args, t.ID)
+	}
+	sb.WriteString(") ORDER BY ts.id")
+
+	rows, err := q.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		logger.Error("q.QueryContext failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	transIDToSplits := map[int64][]domain.TransactionSplit{}
+	for rows.Next() {
+		var s Split
+		var mainCategName, mainCategType, subCategName string
+		if err := rows.Scan(&s.TransactionID, &s.Price, &s.MainCategID, &mainCategName, &mainCategType, &s.SubCategID, &subCategName); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return err
+		}
+
+		transIDToSplits[s.TransactionID] = append(transIDToSplits[s.TransactionID], cvtToDomainSplit(s, mainCategName, mainCategType, subCategName))
+	}
+
+	for i := range trans {
+		trans[i].Splits = transIDToSplits[trans[i].ID]
+	}
+
+	return nil
+}
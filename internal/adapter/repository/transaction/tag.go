@@ -0,0 +1,112 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+// insertTags links the tags to the transaction
+func insertTags(ctx context.Context, tx *sql.Tx, transID int64, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO transaction_tags (transaction_id, tag_id) VALUES ")
+	args := make([]interface{}, 0, len(tagIDs)*2)
+	for i, id := range tagIDs {
+		sb.WriteString("(?, ?)")
+		if i < len(tagIDs)-1 {
+			sb.WriteString(", ")
+		}
+
+		args = append(args, transID, id)
+	}
+
+	if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// addTags links the tags to the transaction, and keeps the tags already on it
+func addTags(ctx context.Context, tx *sql.Tx, transID int64, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("INSERT IGNORE INTO transaction_tags (transaction_id, tag_id) VALUES ")
+	args := make([]interface{}, 0, len(tagIDs)*2)
+	for i, id := range tagIDs {
+		sb.WriteString("(?, ?)")
+		if i < len(tagIDs)-1 {
+			sb.WriteString(", ")
+		}
+
+		args = append(args, transID, id)
+	}
+
+	if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// attachTags loads the tags of the transactions, and sets them on the transactions
+func attachTags(ctx context.Context, q querier, trans []domain.Transaction) error {
+	if len(trans) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT tt.transaction_id, tg.id, tg.name
+									FROM transaction_tags AS tt
+									INNER JOIN tags AS tg
+									ON tt.tag_id = tg.id
+									WHERE tt.transaction_id IN (?`)
+	args := make([]interface{}, 0, len(trans))
+	args = append(args, trans[0].ID)
+	for _, t := range trans[1:] {
+		sb.WriteString(", ?")
+		args = append(args, t.ID)
+	}
+	sb.WriteString(") ORDER BY tg.id")
+
+	rows, err := q.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		logger.Error("q.QueryContext failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	transIDToTags := map[int64][]domain.Tag{}
+	for rows.Next() {
+		var transID int64
+		var tag domain.Tag
+		if err := rows.Scan(&transID, &tag.ID, &tag.Name); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return err
+		}
+
+		transIDToTags[transID] = append(transIDToTags[transID], tag)
+	}
+
+	for i := range trans {
+		trans[i].Tags = transIDToTags[trans[i].ID]
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/maincateg"
@@ -16,6 +18,9 @@ import (
 
 const (
 	packageName = "adapter/repository/transaction"
+
+	// batchInsertSize is the maximum number of rows in one INSERT statement, or ids in one IN list
+	batchInsertSize = 500
 )
 
 type Repo struct {
@@ -29,31 +34,206 @@ type Transaction struct {
 	MainCategID int64 `gofacto:"foreignKey,struct:MainCateg,table:main_categories" mysqlf:"main_category_id"`
 	SubCategID  int64 `gofacto:"foreignKey,struct:SubCateg,table:sub_categories" mysqlf:"sub_category_id"`
 	Price       float64
+	Currency    string
 	Note        string
 	Date        time.Time
+	AccountID   *int64 `gofacto:"omit"`
+	ToAccountID *int64 `gofacto:"omit"`
+	IsRecurring bool   `gofacto:"omit"`
 }
 
 func New(db *sql.DB) *Repo {
 	return &Repo{DB: db}
 }
 
-func (r *Repo) Create(ctx context.Context, trans domain.CreateTransactionInput) error {
+func (r *Repo) Create(ctx context.Context, trans domain.CreateTransactionInput, memberID int64) (int64, error) {
 	tr := cvtCreateTransInputToModelTransaction(trans)
-	qStmt := "INSERT INTO transactions (user_id, type, main_category_id, sub_category_id, price, note, date) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	qStmt := "INSERT INTO transactions (user_id, type, main_category_id, sub_category_id, account_id, to_account_id, price, currency, note, date, is_recurring) VALUES " + insertValues
 
-	if _, err := r.DB.ExecContext(ctx, qStmt, tr.UserID, tr.Type, tr.MainCategID, tr.SubCategID, tr.Price, tr.Note, tr.Date); err != nil {
-		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
-		return err
+	// insert the transaction with its split lines, tags and revision together
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return 0, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, qStmt, insertArgs(tr)...)
+	if err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	if err := insertSplits(ctx, tx, id, trans.Splits); err != nil {
+		return 0, err
+	}
+
+	if err := insertTags(ctx, tx, id, trans.TagIDs); err != nil {
+		return 0, err
+	}
+
+	if err := recordRevision(ctx, tx, id, memberID, domain.RevisionActionTypeCreate, nil); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repo) Import(ctx context.Context, input domain.ImportTransInput, userID int64) (map[int64]int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	// placeholder id -> id of the created category
+	ids := make(map[int64]int64, len(input.MainCategs)+len(input.SubCategs))
+	idOf := func(id int64) int64 {
+		if id < 0 {
+			return ids[id]
+		}
+		return id
+	}
+
+	// the new categories are placed after the existing ones
+	mainStmt := `INSERT INTO main_categories (name, type, user_id, icon_type, icon_data, position)
+							 SELECT ?, ?, ?, ?, ?, COALESCE(MAX(position), 0) + 1
+							 FROM main_categories
+							 WHERE user_id = ?`
+	for _, c := range input.MainCategs {
+		res, err := tx.ExecContext(ctx, mainStmt, c.Name, c.Type.ToModelValue(), userID, c.IconType.ToModelValue(), c.IconData, userID)
+		if err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		if ids[c.ID], err = res.LastInsertId(); err != nil {
+			logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+			return nil, err
+		}
+	}
+
+	subStmt := `INSERT INTO sub_categories (name, user_id, main_category_id, position)
+							SELECT ?, ?, ?, COALESCE(MAX(position), 0) + 1
+							FROM sub_categories
+							WHERE main_category_id = ?`
+	for _, c := range input.SubCategs {
+		mainCategID := idOf(c.MainCategID)
+		res, err := tx.ExecContext(ctx, subStmt, c.Name, userID, mainCategID, mainCategID)
+		if err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		if ids[c.ID], err = res.LastInsertId(); err != nil {
+			logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+			return nil, err
+		}
+	}
+
+	trans := make([]domain.CreateTransactionInput, 0, len(input.Trans))
+	for _, t := range input.Trans {
+		t.MainCategID = idOf(t.MainCategID)
+		t.SubCategID = idOf(t.SubCategID)
+		trans = append(trans, t)
+	}
+
+	if err := insertTrans(ctx, tx, trans); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// insertTrans inserts multiple transactions, along with their tags, in the database transaction
+func insertTrans(ctx context.Context, tx *sql.Tx, trans []domain.CreateTransactionInput) error {
+	// the transaction with tags is inserted by itself, so that its id is known for the tags
+	plain := make([]domain.CreateTransactionInput, 0, len(trans))
+	qStmt := "INSERT INTO transactions (user_id, type, main_category_id, sub_category_id, account_id, to_account_id, price, currency, note, date, is_recurring) VALUES " + insertValues
+	for _, t := range trans {
+		if len(t.TagIDs) == 0 {
+			plain = append(plain, t)
+			continue
+		}
+
+		res, err := tx.ExecContext(ctx, qStmt, insertArgs(cvtCreateTransInputToModelTransaction(t))...)
+		if err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			logger.Error("res.LastInsertId failed", "package", packageName, "err", err)
+			return err
+		}
+
+		if err := insertTags(ctx, tx, id, t.TagIDs); err != nil {
+			return err
+		}
+	}
+
+	for start := 0; start < len(plain); start += batchInsertSize {
+		end := min(start+batchInsertSize, len(plain))
+		batch := plain[start:end]
+
+		var sb strings.Builder
+		sb.WriteString("INSERT INTO transactions (user_id, type, main_category_id, sub_category_id, account_id, to_account_id, price, currency, note, date, is_recurring) VALUES ")
+		args := make([]interface{}, 0, len(batch)*12)
+		for i, t := range batch {
+			sb.WriteString(insertValues)
+			if i < len(batch)-1 {
+				sb.WriteString(", ")
+			}
+
+			args = append(args, insertArgs(cvtCreateTransInputToModelTransaction(t))...)
+		}
+
+		if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
 	}
 
 	return nil
 }
 
 func (r *Repo) GetAll(ctx context.Context, opt domain.GetTransOpt, userID int64) ([]domain.Transaction, domain.DecodedNextKeys, error) {
+	// the previous key is decoded in the same way, and the comparison is flipped by the query
+	encodedKey := opt.Cursor.NextKey
+	if opt.Cursor.PrevKey != "" {
+		encodedKey = opt.Cursor.PrevKey
+	}
+
 	var decodedNextKeys domain.DecodedNextKeys
-	if opt.Cursor.NextKey != "" {
+	if encodedKey != "" {
 		var err error
-		decodedNextKeys, err = codeutil.DecodeNextKeys(opt.Cursor.NextKey, Transaction{})
+		decodedNextKeys, err = codeutil.DecodeNextKeys(encodedKey, Transaction{})
 		if err != nil {
 			logger.Error("codeutil.DecodeCursor failed", "package", packageName, "err", err)
 			return nil, nil, err
@@ -80,7 +260,7 @@ func (r *Repo) GetAll(ctx context.Context, opt domain.GetTransOpt, userID int64)
 		var mainCateg maincateg.MainCateg
 		var subCateg subcateg.SubCateg
 
-		if err := rows.Scan(&trans.ID, &trans.UserID, &trans.Type, &trans.Price, &trans.Note, &trans.Date, &mainCateg.ID, &mainCateg.Name, &mainCateg.Type, &mainCateg.IconType, &mainCateg.IconData, &subCateg.ID, &subCateg.Name); err != nil {
+		if err := rows.Scan(&trans.ID, &trans.UserID, &trans.Type, &trans.Price, &trans.Currency, &trans.Note, &trans.Date, &trans.AccountID, &trans.ToAccountID, &mainCateg.ID, &mainCateg.Name, &mainCateg.Type, &mainCateg.IconType, &mainCateg.IconData, &subCateg.ID, &subCateg.Name); err != nil {
 			logger.Error("rows.Scan failed", "package", packageName, "err", err)
 			return nil, nil, err
 		}
@@ -93,26 +273,171 @@ func (r *Repo) GetAll(ctx context.Context, opt domain.GetTransOpt, userID int64)
 		}
 	}()
 
+	// the rows before the previous key are queried in reverse order
+	if opt.Cursor.PrevKey != "" {
+		slices.Reverse(transactions)
+	}
+
+	if err := attachSplits(ctx, r.DB, transactions); err != nil {
+		return nil, nil, err
+	}
+
+	if err := attachTags(ctx, r.DB, transactions); err != nil {
+		return nil, nil, err
+	}
+
+	if err := r.attachAttachments(ctx, transactions); err != nil {
+		return nil, nil, err
+	}
+
 	return transactions, decodedNextKeys, nil
 }
 
-func (r *Repo) Update(ctx context.Context, trans domain.UpdateTransactionInput) error {
+func (r *Repo) GetTotal(ctx context.Context, opt domain.GetTransOpt, userID int64) (domain.TransTotal, error) {
+	qStmt := getTotalQStmt(opt)
+	args := getTotalArgs(opt, userID)
+
+	var total domain.TransTotal
+	if err := r.DB.QueryRowContext(ctx, qStmt, args...).Scan(&total.Count, &total.Income, &total.Expense, &total.Unconverted); err != nil {
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.TransTotal{}, err
+	}
+
+	return total, nil
+}
+
+func (r *Repo) StreamAll(ctx context.Context, opt domain.GetTransOpt, userID int64, fn func(domain.Transaction) error) error {
+	// export all matching rows, so the cursor is not applied
+	opt.Cursor = domain.Cursor{}
+
+	qStmt := getAllQStmt(opt, nil, Transaction{})
+	args := getAllArgs(opt, nil, userID)
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	for rows.Next() {
+		var trans Transaction
+		var mainCateg maincateg.MainCateg
+		var subCateg subcateg.SubCateg
+
+		if err := rows.Scan(&trans.ID, &trans.UserID, &trans.Type, &trans.Price, &trans.Currency, &trans.Note, &trans.Date, &trans.AccountID, &trans.ToAccountID, &mainCateg.ID, &mainCateg.Name, &mainCateg.Type, &mainCateg.IconType, &mainCateg.IconData, &subCateg.ID, &subCateg.Name); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return err
+		}
+
+		if err := fn(cvtToDomainTransaction(trans, mainCateg, subCateg)); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Error("rows.Err failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Update(ctx context.Context, trans domain.UpdateTransactionInput, action domain.RevisionActionType, memberID int64) error {
 	tr := cvtUpdateTransInputToModelTransaction(trans)
-	qStmt := "UPDATE transactions SET type = ?, main_category_id = ?, sub_category_id = ?, price = ?, note = ?, date = ? WHERE id = ?"
+	// reverting a transaction in trash brings it back, the other updates only reach live transactions
+	qStmt := `UPDATE transactions
+						SET type = ?, main_category_id = NULLIF(?, 0), sub_category_id = NULLIF(?, 0), account_id = ?, to_account_id = ?, price = ?, currency = ` + currencyOrOwnerBaseValue + `, note = ?, date = ?, deleted_at = NULL
+						WHERE id = ?`
+
+	// the split lines, tags and revision are written together with the transaction
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	idToBefore, err := getSnapshots(ctx, tx, []int64{tr.ID})
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, qStmt, tr.Type, tr.MainCategID, tr.SubCategID, tr.AccountID, tr.ToAccountID, tr.Price, tr.Currency, tr.Note, tr.Date, tr.ID); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM transaction_splits WHERE transaction_id = ?", tr.ID); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := insertSplits(ctx, tx, tr.ID, trans.Splits); err != nil {
+		return err
+	}
 
-	if _, err := r.DB.ExecContext(ctx, qStmt, tr.Type, tr.MainCategID, tr.SubCategID, tr.Price, tr.Note, tr.Date, tr.ID); err != nil {
-		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM transaction_tags WHERE transaction_id = ?", tr.ID); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := insertTags(ctx, tx, tr.ID, trans.TagIDs); err != nil {
+		return err
+	}
+
+	if err := recordRevision(ctx, tx, tr.ID, memberID, action, idToBefore[tr.ID]); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
 		return err
 	}
 
 	return nil
 }
 
-func (r *Repo) Delete(ctx context.Context, id int64) error {
-	qStmt := "DELETE FROM transactions WHERE id = ?"
+func (r *Repo) Delete(ctx context.Context, id, memberID int64) error {
+	// the transaction is moved to trash, and it's removed by the purge job after the retention period
+	qStmt := "UPDATE transactions SET deleted_at = NOW() WHERE id = ?"
 
-	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
-		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+	// the revision is written together with the deletion
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	idToBefore, err := getSnapshots(ctx, tx, []int64{id})
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := recordRevision(ctx, tx, id, memberID, domain.RevisionActionTypeDelete, idToBefore[id]); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
 		return err
 	}
 
@@ -125,7 +450,7 @@ func (r *Repo) GetAccInfo(ctx context.Context, query domain.GetAccInfoQuery, use
 
 	var accInfo domain.AccInfo
 	if err := r.DB.QueryRowContext(ctx, qStmt, args...).
-		Scan(&accInfo.TotalIncome, &accInfo.TotalExpense, &accInfo.TotalBalance); err != nil && err != sql.ErrNoRows {
+		Scan(&accInfo.TotalIncome, &accInfo.TotalExpense, &accInfo.TotalBalance, &accInfo.Unconverted); err != nil && err != sql.ErrNoRows {
 		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
 		return domain.AccInfo{}, err
 	}
@@ -134,25 +459,35 @@ func (r *Repo) GetAccInfo(ctx context.Context, query domain.GetAccInfoQuery, use
 }
 
 func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Transaction, error) {
-	qStmt := "SELECT id, user_id, type, main_category_id, sub_category_id, price, note, date FROM transactions WHERE id = ? AND user_id = ?"
+	return r.getByIDAndUserID(ctx, id, userID, false)
+}
 
-	var trans Transaction
-	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).
-		Scan(&trans.ID, &trans.UserID, &trans.Type, &trans.MainCategID, &trans.SubCategID, &trans.Price, &trans.Note, &trans.Date); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return domain.Transaction{}, domain.ErrTransactionDataNotFound
-		}
+func (r *Repo) GetByIDAndUserIDIncludeTrash(ctx context.Context, id, userID int64) (domain.Transaction, error) {
+	return r.getByIDAndUserID(ctx, id, userID, true)
+}
 
-		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+func (r *Repo) getByIDAndUserID(ctx context.Context, id, userID int64, includeTrash bool) (domain.Transaction, error) {
+	qStmt := `SELECT id, user_id, type, COALESCE(main_category_id, 0), COALESCE(sub_category_id, 0), account_id, to_account_id, price, currency, note, date
+						FROM transactions
+						WHERE id = ? AND user_id = ?`
+	if !includeTrash {
+		qStmt += " AND deleted_at IS NULL"
+	}
+
+	result, err := getWithSplitsAndTags(ctx, r.DB, qStmt, []interface{}{id, userID})
+	if err != nil {
 		return domain.Transaction{}, err
 	}
+	if len(result) == 0 {
+		return domain.Transaction{}, domain.ErrTransactionDataNotFound
+	}
 
-	return cvtToDomainTransactionWithoutCategory(trans), nil
+	return result[0], nil
 }
 
-func (r *Repo) GetDailyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, userID int64) (domain.DateToChartData, error) {
-	qStmt := getGetDailyBarChartDataQuery(mainCategIDs)
-	args := genGetDailyBarChartDataArgs(userID, transactionType, dateRange, mainCategIDs)
+func (r *Repo) GetDailyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error) {
+	qStmt := getGetDailyBarChartDataQuery(mainCategIDs, opt)
+	args := genGetDailyBarChartDataArgs(userID, transactionType, dateRange, mainCategIDs, opt)
 
 	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
 	if err != nil {
@@ -180,9 +515,9 @@ func (r *Repo) GetDailyBarChartData(ctx context.Context, dateRange domain.ChartD
 	return dateToData, nil
 }
 
-func (r *Repo) GetMonthlyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, userID int64) (domain.DateToChartData, error) {
-	qStmt := getGetMonthlyBarChartDataQuery(mainCategIDs)
-	args := getGetMonthlyBarChartDataArgs(userID, transactionType, dateRange, mainCategIDs)
+func (r *Repo) GetMonthlyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error) {
+	qStmt := getGetMonthlyBarChartDataQuery(mainCategIDs, opt)
+	args := getGetMonthlyBarChartDataArgs(userID, transactionType, dateRange, mainCategIDs, opt)
 
 	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
 	if err != nil {
@@ -212,37 +547,91 @@ func (r *Repo) GetMonthlyBarChartData(ctx context.Context, dateRange domain.Char
 	return dateToData, nil
 }
 
-func (r *Repo) GetPieChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, userID int64) (domain.ChartData, error) {
+func (r *Repo) GetPieChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) (domain.ChartData, error) {
+	sums, err := r.GetSumByMainCateg(ctx, dateRange, transactionType, opt, userID)
+	if err != nil {
+		return domain.ChartData{}, err
+	}
+
+	var labels []string
+	var datasets []float64
+	for _, s := range sums {
+		labels = append(labels, s.MainCateg.Name)
+		datasets = append(datasets, s.Sum)
+	}
+
+	return domain.ChartData{Labels: labels, Datasets: datasets}, nil
+}
+
+func (r *Repo) GetSumByMainCateg(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) ([]domain.MainCategSum, error) {
 	qStmt := `
-	  SELECT mc.name,
-		       SUM(ts.price)
-		FROM transactions AS ts
+	  SELECT mc.id,
+		       mc.name,
+		       COALESCE(SUM(` + basePrice + `), 0),
+		       ` + unconvertedCount + `
+		` + baseLineFrom + `
 		INNER JOIN main_categories AS mc
-		ON ts.main_category_id = mc.id
-		WHERE ts.user_id = ?
-		AND ts.type = ?
-		AND ts.date BETWEEN ? AND ?
-		GROUP BY mc.name
+		ON t.main_category_id = mc.id
+		WHERE t.user_id = ?
+		AND t.type = ?
+		AND t.date BETWEEN ? AND ?
+		` + chartFilterStmt(opt) + `
+		GROUP BY mc.id, mc.name
 	`
 
-	rows, err := r.DB.QueryContext(ctx, qStmt, userID, transactionType.ToModelValue(), dateRange.Start, dateRange.End)
+	args := []interface{}{userID, transactionType.ToModelValue(), dateRange.Start, dateRange.End}
+	args = append(args, chartFilterArgs(opt, userID)...)
+	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
 	if err != nil {
 		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
-		return domain.ChartData{}, err
+		return nil, err
 	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
 
-	var labels []string
-	var datasets []float64
+	var sums []domain.MainCategSum
 	for rows.Next() {
-		var name string
-		var price float64
-		if err := rows.Scan(&name, &price); err != nil {
+		var s domain.MainCategSum
+		if err := rows.Scan(&s.MainCateg.ID, &s.MainCateg.Name, &s.Sum, &s.Unconverted); err != nil {
 			logger.Error("rows.Scan failed", "package", packageName, "err", err)
-			return domain.ChartData{}, err
+			return nil, err
 		}
 
-		labels = append(labels, name)
-		datasets = append(datasets, price)
+		s.MainCateg.Type = transactionType
+		sums = append(sums, s)
+	}
+
+	return sums, nil
+}
+
+func (r *Repo) GetSumByTag(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) ([]domain.TagSum, error) {
+	// the transaction is summed in each of its tags, so the sums may overlap
+	qStmt := `
+	  SELECT tg.id,
+		       tg.name,
+		       COALESCE(SUM(` + basePrice + `), 0)
+		` + baseTransFrom + `
+		INNER JOIN transaction_tags AS tt
+		ON t.id = tt.transaction_id
+		INNER JOIN tags AS tg
+		ON tt.tag_id = tg.id
+		WHERE t.user_id = ?
+		AND t.type = ?
+		AND t.date BETWEEN ? AND ?
+		` + chartFilterStmt(opt) + `
+		GROUP BY tg.id, tg.name
+		ORDER BY tg.id
+	`
+
+	args := []interface{}{userID, transactionType.ToModelValue(), dateRange.Start, dateRange.End}
+	args = append(args, chartFilterArgs(opt, userID)...)
+	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
@@ -250,10 +639,21 @@ func (r *Repo) GetPieChartData(ctx context.Context, dateRange domain.ChartDateRa
 		}
 	}()
 
-	return domain.ChartData{Labels: labels, Datasets: datasets}, nil
+	var sums []domain.TagSum
+	for rows.Next() {
+		var s domain.TagSum
+		if err := rows.Scan(&s.Tag.ID, &s.Tag.Name, &s.Sum); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		sums = append(sums, s)
+	}
+
+	return sums, nil
 }
 
-func (r *Repo) GetDailyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, userID int64) (domain.DateToChartData, error) {
+func (r *Repo) GetDailyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error) {
 	_, err := r.DB.Exec("SET @csum := 0")
 	if err != nil {
 		logger.Error("r.DB.Exec failed", "package", packageName, "err", err)
@@ -264,21 +664,21 @@ func (r *Repo) GetDailyLineChartData(ctx context.Context, dateRange domain.Chart
 					SELECT DATE_FORMAT(date, '%Y-%m-%d') AS date,
 								 @csum := @csum + total_price
 					FROM (
-						SELECT date, 
-							SUM(
-								CASE WHEN 
-									type = 1 THEN price 
-									ELSE -price 
-								END) AS total_price
-						FROM transactions
-						WHERE user_id = ?
-						AND date BETWEEN ? AND ?
-						GROUP BY date
-						ORDER BY date
+						SELECT t.date, 
+							COALESCE(SUM(` + signedBasePrice + `), 0) AS total_price
+						` + baseTransFrom + `
+						WHERE t.user_id = ?
+						AND t.type IN ('1', '2')
+						AND t.date BETWEEN ? AND ?
+						` + chartFilterStmt(opt) + `
+						GROUP BY t.date
+						ORDER BY t.date
 					) AS temp
 	`
 
-	rows, err := r.DB.QueryContext(ctx, qStmt, userID, dateRange.Start, dateRange.End)
+	args := []interface{}{userID, dateRange.Start, dateRange.End}
+	args = append(args, chartFilterArgs(opt, userID)...)
+	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
 	if err != nil {
 		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
 		return domain.DateToChartData{}, err
@@ -304,7 +704,7 @@ func (r *Repo) GetDailyLineChartData(ctx context.Context, dateRange domain.Chart
 	return dataToDate, nil
 }
 
-func (r *Repo) GetMonthlyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, userID int64) (domain.DateToChartData, error) {
+func (r *Repo) GetMonthlyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error) {
 	_, err := r.DB.Exec("SET @csum := 0")
 	if err != nil {
 		logger.Error("r.DB.Exec failed", "package", packageName, "err", err)
@@ -316,23 +716,22 @@ func (r *Repo) GetMonthlyLineChartData(ctx context.Context, dateRange domain.Cha
 								 month,
 								 @csum := @csum + total_price
 					FROM (
-						SELECT YEAR(date) AS year,
-									 LPAD(MONTH(date), 2, '0') AS month,
-									 SUM(
-										CASE WHEN
-											type = 1 THEN price
-											ELSE -price
-										END
-									) AS total_price
-						FROM transactions
-						WHERE user_id = ?
-						AND date BETWEEN ? AND ?
-						GROUP BY YEAR(date), LPAD(MONTH(date), 2, '0')
-						ORDER BY YEAR(date), LPAD(MONTH(date), 2, '0')
+						SELECT YEAR(t.date) AS year,
+									 LPAD(MONTH(t.date), 2, '0') AS month,
+									 COALESCE(SUM(` + signedBasePrice + `), 0) AS total_price
+						` + baseTransFrom + `
+						WHERE t.user_id = ?
+						AND t.type IN ('1', '2')
+						AND t.date BETWEEN ? AND ?
+						` + chartFilterStmt(opt) + `
+						GROUP BY YEAR(t.date), LPAD(MONTH(t.date), 2, '0')
+						ORDER BY YEAR(t.date), LPAD(MONTH(t.date), 2, '0')
 					) AS temp
 				 `
 
-	rows, err := r.DB.QueryContext(ctx, qStmt, userID, dateRange.Start, dateRange.End)
+	args := []interface{}{userID, dateRange.Start, dateRange.End}
+	args = append(args, chartFilterArgs(opt, userID)...)
+	rows, err := r.DB.QueryContext(ctx, qStmt, args...)
 	if err != nil {
 		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
 		return domain.DateToChartData{}, err
@@ -360,6 +759,32 @@ func (r *Repo) GetMonthlyLineChartData(ctx context.Context, dateRange domain.Cha
 	return dateToData, nil
 }
 
+// CountUnconverted counts the income and expense in the date range which can't be converted to the base currency of the user.
+// The type is not filtered if it's unspecified
+func (r *Repo) CountUnconverted(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) (int64, error) {
+	qStmt := `SELECT ` + unconvertedCount + `
+		` + baseTransFrom + `
+		WHERE t.user_id = ?
+		AND t.date BETWEEN ? AND ?`
+	args := []interface{}{userID, dateRange.Start, dateRange.End}
+
+	if transactionType != domain.TransactionTypeUnSpecified {
+		qStmt += " AND t.type = ?"
+		args = append(args, transactionType.ToModelValue())
+	}
+
+	qStmt += chartFilterStmt(opt)
+	args = append(args, chartFilterArgs(opt, userID)...)
+
+	var count int64
+	if err := r.DB.QueryRowContext(ctx, qStmt, args...).Scan(&count); err != nil {
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *Repo) GetMonthlyData(ctx context.Context, dateRange domain.GetMonthlyDateRange, userID int64) (domain.MonthDayToTransactionType, error) {
 	qStmt := `
 		SELECT
@@ -371,6 +796,8 @@ func (r *Repo) GetMonthlyData(ctx context.Context, dateRange domain.GetMonthlyDa
 		END AS type
 		FROM transactions
 		WHERE user_id = ?
+		AND deleted_at IS NULL
+		AND type IN ('1', '2')
 		AND date BETWEEN ? AND ?
 		GROUP BY DAY(date)
 	`
@@ -407,12 +834,13 @@ func (r *Repo) GetMonthlyAggregatedData(ctx context.Context, date time.Time) ([]
 	endOfMonth := date.AddDate(0, 1, -date.Day()).Format(time.DateOnly)
 
 	qStmt := `
-		SELECT user_id,
-			   SUM(CASE WHEN type = '1' THEN price ELSE 0 END) AS total_income,
-			   SUM(CASE WHEN type = '2' THEN price ELSE 0 END) AS total_expense
-		FROM transactions
-		WHERE date BETWEEN ? AND ?
-		GROUP BY user_id
+		SELECT t.user_id,
+			   COALESCE(SUM(CASE WHEN t.type = '1' THEN ` + basePrice + ` ELSE 0 END), 0) AS total_income,
+			   COALESCE(SUM(CASE WHEN t.type = '2' THEN ` + basePrice + ` ELSE 0 END), 0) AS total_expense,
+			   ` + unconvertedCount + ` AS unconverted
+		` + baseTransFrom + `
+		WHERE t.date BETWEEN ? AND ?
+		GROUP BY t.user_id
 	`
 
 	rows, err := r.DB.QueryContext(ctx, qStmt, startOfMonth, endOfMonth)
@@ -429,7 +857,7 @@ func (r *Repo) GetMonthlyAggregatedData(ctx context.Context, date time.Time) ([]
 	var monthlyDataList []domain.MonthlyAggregatedData
 	for rows.Next() {
 		var monthlyData domain.MonthlyAggregatedData
-		if err := rows.Scan(&monthlyData.UserID, &monthlyData.TotalIncome, &monthlyData.TotalExpense); err != nil {
+		if err := rows.Scan(&monthlyData.UserID, &monthlyData.TotalIncome, &monthlyData.TotalExpense, &monthlyData.Unconverted); err != nil {
 			logger.Error("rows.Scan failed", "package", packageName, "err", err)
 			return []domain.MonthlyAggregatedData{}, err
 		}
@@ -439,3 +867,50 @@ func (r *Repo) GetMonthlyAggregatedData(ctx context.Context, date time.Time) ([]
 
 	return monthlyDataList, nil
 }
+
+func (r *Repo) GetAccountEntries(ctx context.Context, accountID, userID int64) ([]domain.AccountEntry, error) {
+	// transfer is money out of the source account, and money in to the destination account
+	// the amount is NULL when there's no exchange rate, and the entry is reported as unconverted
+	qStmt := `
+		SELECT t.id, t.type, t.date, t.note,
+			   CASE
+					WHEN t.type = '1' THEN 1
+					WHEN t.type = '2' THEN -1
+					WHEN t.account_id = ? THEN -1
+					ELSE 1
+			   END * ` + basePrice + ` AS amount
+		` + baseTransFrom + `
+		WHERE t.user_id = ?
+		AND (t.account_id = ? OR t.to_account_id = ?)
+		ORDER BY t.date, t.id
+	`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, accountID, userID, accountID, accountID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var entries []domain.AccountEntry
+	for rows.Next() {
+		var entry domain.AccountEntry
+		var t string
+		var amount sql.NullFloat64
+		if err := rows.Scan(&entry.TransactionID, &t, &entry.Date, &entry.Note, &amount); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		entry.Type = domain.CvtToTransactionType(t)
+		entry.Amount = amount.Float64
+		entry.IsUnconverted = !amount.Valid
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -3,6 +3,7 @@ package transaction
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -76,6 +77,18 @@ func (s *TransactionSuite) TearDownTest() {
 		s.Require().NoError(err)
 	}
 
+	if _, err := tx.Exec("DELETE FROM exchange_rates"); err != nil {
+		s.Require().NoError(err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM accounts"); err != nil {
+		s.Require().NoError(err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM tags"); err != nil {
+		s.Require().NoError(err)
+	}
+
 	if _, err := tx.Exec("DELETE FROM icons"); err != nil {
 		s.Require().NoError(err)
 	}
@@ -110,29 +123,304 @@ func (s *TransactionSuite) TestCreate() {
 		Date:        mockTimeNow,
 	}
 
-	err = s.repo.Create(mockCTX, t)
+	id, err := s.repo.Create(mockCTX, t, user.ID)
 	s.Require().NoError(err)
 
 	var checkT Transaction
-	stmt := "SELECT user_id, type, main_category_id, sub_category_id, price, note, date FROM transactions WHERE user_id = ?"
-	err = s.db.QueryRow(stmt, user.ID).Scan(&checkT.UserID, &checkT.Type, &checkT.MainCategID, &checkT.SubCategID, &checkT.Price, &checkT.Note, &checkT.Date)
+	stmt := "SELECT id, user_id, type, main_category_id, sub_category_id, price, currency, note, date FROM transactions WHERE user_id = ?"
+	err = s.db.QueryRow(stmt, user.ID).Scan(&checkT.ID, &checkT.UserID, &checkT.Type, &checkT.MainCategID, &checkT.SubCategID, &checkT.Price, &checkT.Currency, &checkT.Note, &checkT.Date)
 	s.Require().NoError(err)
+	s.Equal(id, checkT.ID)
 	s.Equal(t.UserID, checkT.UserID)
 	s.Equal(t.Type.ToModelValue(), checkT.Type)
 	s.Equal(t.MainCategID, checkT.MainCategID)
 	s.Equal(t.SubCategID, checkT.SubCategID)
 	s.Equal(t.Price, checkT.Price)
+	s.Equal(domain.DefaultCurrency, checkT.Currency) // falls back to the user's base currency
 	s.Equal(t.Note, checkT.Note)
 
+	// the revision is recorded with the currency filled by the database
+	revs := getRevisionsOfTrans(s, id)
+	s.Require().Len(revs, 1)
+	s.Equal(domain.RevisionActionTypeCreate, revs[0].Action)
+	s.Equal(user.ID, revs[0].UserID)
+	s.Nil(revs[0].Before)
+	s.Require().NotNil(revs[0].After)
+	s.Equal(domain.DefaultCurrency, revs[0].After.Currency)
+
+	s.TearDownTest()
+}
+
+func (s *TransactionSuite) TestCreateWithSplits() {
+	ow := Transaction{Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow}
+	_, user, mainCategs, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2, ow, ow)
+	s.Require().NoError(err)
+
+	t := domain.CreateTransactionInput{
+		UserID:      user.ID,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: mainCategs[0].ID,
+		SubCategID:  subCategs[0].ID,
+		Price:       100,
+		Note:        "receipt",
+		Date:        mockTimeNow,
+		Splits: []domain.SplitInput{
+			{MainCategID: mainCategs[0].ID, SubCategID: subCategs[0].ID, Price: 30},
+			{MainCategID: mainCategs[1].ID, SubCategID: subCategs[1].ID, Price: 70},
+		},
+	}
+
+	_, err = s.repo.Create(mockCTX, t, user.ID)
+	s.Require().NoError(err)
+
+	// the split lines are returned with the transaction
+	trans, _, err := s.repo.GetAll(mockCTX, domain.GetTransOpt{}, user.ID)
+	s.Require().NoError(err)
+	s.Require().Len(trans, 3)
+
+	var splitTrans domain.Transaction
+	for _, t := range trans {
+		if t.Note == "receipt" {
+			splitTrans = t
+		} else {
+			s.Require().Empty(t.Splits)
+		}
+	}
+	s.Require().Equal([]domain.TransactionSplit{
+		{
+			MainCateg: domain.MainCateg{ID: mainCategs[0].ID, Name: mainCategs[0].Name, Type: domain.TransactionTypeExpense},
+			SubCateg:  domain.SubCateg{ID: subCategs[0].ID, Name: subCategs[0].Name, MainCategID: mainCategs[0].ID},
+			Price:     30,
+		},
+		{
+			MainCateg: domain.MainCateg{ID: mainCategs[1].ID, Name: mainCategs[1].Name, Type: domain.TransactionTypeExpense},
+			SubCateg:  domain.SubCateg{ID: subCategs[1].ID, Name: subCategs[1].Name, MainCategID: mainCategs[1].ID},
+			Price:     70,
+		},
+	}, splitTrans.Splits)
+
+	// each split line is attributed to its own category
+	var sum0, sum1 float64
+	stmt := "SELECT COALESCE(SUM(price), 0) FROM transactions WHERE main_category_id = ? AND note <> 'receipt'"
+	s.Require().NoError(s.db.QueryRow(stmt, mainCategs[0].ID).Scan(&sum0))
+	s.Require().NoError(s.db.QueryRow(stmt, mainCategs[1].ID).Scan(&sum1))
+
+	dateRange := domain.ChartDateRange{Start: mockTimeNow, End: mockTimeNow}
+	sums, err := s.repo.GetSumByMainCateg(mockCTX, dateRange, domain.TransactionTypeExpense, nil, user.ID)
+	s.Require().NoError(err)
+
+	categIDToSum := map[int64]float64{}
+	for _, sum := range sums {
+		categIDToSum[sum.MainCateg.ID] = sum.Sum
+	}
+	s.Require().Equal(sum0+30, categIDToSum[mainCategs[0].ID])
+	s.Require().Equal(sum1+70, categIDToSum[mainCategs[1].ID])
+
+	s.TearDownTest()
+}
+
+func (s *TransactionSuite) TestCreateAndUpdateWithTags() {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err)
+
+	tags := insertTagsOfUser(s, user.ID, 3)
+
+	t := domain.CreateTransactionInput{
+		UserID:      user.ID,
+		Type:        domain.CvtToTransactionType(main.Type),
+		MainCategID: main.ID,
+		SubCategID:  sub.ID,
+		Price:       100,
+		Date:        mockTimeNow,
+		TagIDs:      []int64{tags[0].ID, tags[1].ID},
+	}
+
+	_, err = s.repo.Create(mockCTX, t, user.ID)
+	s.Require().NoError(err)
+
+	trans, _, err := s.repo.GetAll(mockCTX, domain.GetTransOpt{}, user.ID)
+	s.Require().NoError(err)
+	s.Require().Len(trans, 1)
+	s.Require().Equal([]domain.Tag{tags[0], tags[1]}, trans[0].Tags)
+
+	// the tags are replaced on update
+	u := domain.UpdateTransactionInput{
+		ID:          trans[0].ID,
+		Type:        t.Type,
+		MainCategID: t.MainCategID,
+		SubCategID:  t.SubCategID,
+		Price:       t.Price,
+		Date:        t.Date,
+		TagIDs:      []int64{tags[2].ID},
+	}
+	err = s.repo.Update(mockCTX, u, domain.RevisionActionTypeUpdate, user.ID)
+	s.Require().NoError(err)
+
+	trans, _, err = s.repo.GetAll(mockCTX, domain.GetTransOpt{}, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal([]domain.Tag{tags[2]}, trans[0].Tags)
+
 	s.TearDownTest()
 }
 
+func (s *TransactionSuite) TestImport() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, insert all transactions":    import_NoError_InsertAll,
+		"when more rows than one batch, insert all": import_MoreThanOneBatch_InsertAll,
+		"when one row fails, insert nothing":        import_OneRowFail_InsertNothing,
+		"when with new categories, insert all":      import_WithNewCategs_InsertAll,
+		"when one row fails, insert no categories":  import_OneRowFail_InsertNoCategs,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func import_NoError_InsertAll(s *TransactionSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	trans := []domain.CreateTransactionInput{
+		{UserID: user.ID, Type: domain.CvtToTransactionType(main.Type), MainCategID: main.ID, SubCategID: sub.ID, Price: 100, Note: "test1", Date: mockTimeNow},
+		{UserID: user.ID, Type: domain.CvtToTransactionType(main.Type), MainCategID: main.ID, SubCategID: sub.ID, Price: 200, Note: "test2", Date: mockTimeNow},
+	}
+
+	_, err = s.repo.Import(mockCTX, domain.ImportTransInput{Trans: trans}, user.ID)
+	s.Require().NoError(err, desc)
+
+	var count int
+	var sum float64
+	err = s.db.QueryRow("SELECT COUNT(*), SUM(price) FROM transactions WHERE user_id = ?", user.ID).Scan(&count, &sum)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(2, count, desc)
+	s.Require().Equal(float64(300), sum, desc)
+}
+
+func import_MoreThanOneBatch_InsertAll(s *TransactionSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	trans := make([]domain.CreateTransactionInput, batchInsertSize+1)
+	for i := range trans {
+		trans[i] = domain.CreateTransactionInput{UserID: user.ID, Type: domain.CvtToTransactionType(main.Type), MainCategID: main.ID, SubCategID: sub.ID, Price: 1, Date: mockTimeNow}
+	}
+
+	_, err = s.repo.Import(mockCTX, domain.ImportTransInput{Trans: trans}, user.ID)
+	s.Require().NoError(err, desc)
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ?", user.ID).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(batchInsertSize+1, count, desc)
+}
+
+func import_OneRowFail_InsertNothing(s *TransactionSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	// the second batch references a sub category that doesn't exist
+	trans := make([]domain.CreateTransactionInput, batchInsertSize+1)
+	for i := range trans {
+		trans[i] = domain.CreateTransactionInput{UserID: user.ID, Type: domain.CvtToTransactionType(main.Type), MainCategID: main.ID, SubCategID: sub.ID, Price: 1, Date: mockTimeNow}
+	}
+	trans[batchInsertSize].SubCategID = sub.ID + 999
+
+	_, err = s.repo.Import(mockCTX, domain.ImportTransInput{Trans: trans}, user.ID)
+	s.Require().Error(err, desc)
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ?", user.ID).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Zero(count, desc)
+}
+
+func import_WithNewCategs_InsertAll(s *TransactionSuite, desc string) {
+	user, main, _, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	transType := domain.CvtToTransactionType(main.Type)
+	input := domain.ImportTransInput{
+		MainCategs: []domain.MainCateg{{ID: -1, Name: "new main", Type: transType, IconType: domain.IconTypeDefault, IconData: "url"}},
+		SubCategs: []domain.SubCateg{
+			{ID: -2, Name: "new sub", MainCategID: -1},
+			{ID: -3, Name: "new sub of existing main", MainCategID: main.ID},
+		},
+		Trans: []domain.CreateTransactionInput{
+			{UserID: user.ID, Type: transType, MainCategID: -1, SubCategID: -2, Price: 100, Date: mockTimeNow},
+			{UserID: user.ID, Type: transType, MainCategID: main.ID, SubCategID: -3, Price: 200, Date: mockTimeNow},
+		},
+	}
+
+	ids, err := s.repo.Import(mockCTX, input, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(ids, 3, desc)
+
+	var name string
+	err = s.db.QueryRow("SELECT name FROM main_categories WHERE id = ? AND user_id = ?", ids[-1], user.ID).Scan(&name)
+	s.Require().NoError(err, desc)
+	s.Require().Equal("new main", name, desc)
+
+	var mainCategID int64
+	err = s.db.QueryRow("SELECT main_category_id FROM sub_categories WHERE id = ? AND user_id = ?", ids[-2], user.ID).Scan(&mainCategID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(ids[-1], mainCategID, desc)
+
+	err = s.db.QueryRow("SELECT main_category_id FROM sub_categories WHERE id = ? AND user_id = ?", ids[-3], user.ID).Scan(&mainCategID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(main.ID, mainCategID, desc)
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ? AND main_category_id = ? AND sub_category_id = ?", user.ID, ids[-1], ids[-2]).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(1, count, desc)
+
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ? AND main_category_id = ? AND sub_category_id = ?", user.ID, main.ID, ids[-3]).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(1, count, desc)
+}
+
+func import_OneRowFail_InsertNoCategs(s *TransactionSuite, desc string) {
+	user, main, sub, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	// the second transaction references a sub category that doesn't exist
+	transType := domain.CvtToTransactionType(main.Type)
+	input := domain.ImportTransInput{
+		MainCategs: []domain.MainCateg{{ID: -1, Name: "new main", Type: transType, IconType: domain.IconTypeDefault, IconData: "url"}},
+		SubCategs:  []domain.SubCateg{{ID: -2, Name: "new sub", MainCategID: -1}},
+		Trans: []domain.CreateTransactionInput{
+			{UserID: user.ID, Type: transType, MainCategID: -1, SubCategID: -2, Price: 100, Date: mockTimeNow},
+			{UserID: user.ID, Type: transType, MainCategID: main.ID, SubCategID: sub.ID + 999, Price: 200, Date: mockTimeNow},
+		},
+	}
+
+	_, err = s.repo.Import(mockCTX, input, user.ID)
+	s.Require().Error(err, desc)
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM main_categories WHERE user_id = ?", user.ID).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(1, count, desc)
+
+	err = s.db.QueryRow("SELECT COUNT(*) FROM sub_categories WHERE user_id = ?", user.ID).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(1, count, desc)
+
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ?", user.ID).Scan(&count)
+	s.Require().NoError(err, desc)
+	s.Require().Zero(count, desc)
+}
+
 func (s *TransactionSuite) TestGetAll() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
 		"when no error, return successfully":                                          getAll_NoError_ReturnSuccessfully,
 		"when with multiple users, return successfully":                               getAll_WithMultipleUsers_ReturnSuccessfully,
 		"when with many transactions, return all transactions":                        getAll_WithManyTransaction_ReturnSuccessfully,
 		"when with search keyword, return data with keyword":                          getAll_WithSearchKeyword_ReturnDataWithKeyword,
+		"when with search query, return data matching all terms":                      getAll_WithSearchQuery_ReturnMatchedData,
 		"when filter by start date, return data after start date":                     getAll_FilterByStartDate_ReturnDataAfterStartDate,
 		"when filter by end date, return data before end date":                        getAll_FilterByEndDate_ReturnDataBeforeEndDate,
 		"when filter by start and end date, return data between them":                 getAll_FilterByStartAndEndDate_ReturnDataBetweenStartAndEndDate,
@@ -141,6 +429,9 @@ func (s *TransactionSuite) TestGetAll() {
 		"when filter by main category id, return data with main category id":          getAll_FilterByMainCategID_ReturnDataWithMainCategID,
 		"when filter by sub category id, return data with sub category id":            getAll_FilterBySubCategID_ReturnDataWithSubCategID,
 		"when filter by date, price and main category, return correct data":           getAll_FilterByDateAndPriceAndMainCateg_ReturnCorrectData,
+		"when filter by any of tag ids, return data with any of the tags":             getAll_FilterByAnyTagIDs_ReturnDataWithAnyTag,
+		"when filter by all of tag ids, return data with all of the tags":             getAll_FilterByAllTagIDs_ReturnDataWithAllTags,
+		"when with attachments, return data with attachments":                         getAll_WithAttachments_ReturnDataWithAttachments,
 		"when sort by date asc, return correct order":                                 getAll_SortByDateAsc_ReturnCorrectOrder,
 		"when sort by date desc, return correct order":                                getAll_SortByDateDesc_ReturnCorrectOrder,
 		"when sort by price asc, return correct order":                                getAll_SortByPriceAsc_ReturnCorrectOrder,
@@ -151,6 +442,9 @@ func (s *TransactionSuite) TestGetAll() {
 		"when with next key cursor and sort by date, return correct data":             getAll_WithNextKeyCursorAndSortByDate_ReturnCorrectData,
 		"when with next key cursor and sort by price, return correct data":            getAll_WithNextKeyCursorAndSortByPrice_ReturnCorrectData,
 		"when with next key cursor and sort by transaction type, return correct data": getAll_WithNextKeyCursorAndSortByTransType_ReturnCorrectData,
+		"when with next key cursor and sort, not return data of other users":          getAll_WithNextKeyCursorAndSort_NotReturnDataOfOtherUsers,
+		"when with prev key cursor, return data before cursor key":                    getAll_WithPrevKeyCursor_ReturnDataBeforeCursorKey,
+		"when with prev key cursor and sort by price, return correct data":            getAll_WithPrevKeyCursorAndSortByPrice_ReturnCorrectData,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -217,6 +511,36 @@ func getAll_WithSearchKeyword_ReturnDataWithKeyword(s *TransactionSuite, desc st
 	s.Require().Empty(decodedNextKey, desc)
 }
 
+func getAll_WithSearchQuery_ReturnMatchedData(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Note: "coffee beans", Price: 30, Date: mockTimeNow.AddDate(0, 0, -1)}
+	ow2 := Transaction{Note: "coffee cup", Price: 30, Date: mockTimeNow.AddDate(0, 0, -1)}
+	ow3 := Transaction{Note: "coffee beans", Price: 10, Date: mockTimeNow.AddDate(0, 0, -1)}
+	ow4 := Transaction{Note: "coffee beans", Price: 30, Date: mockTimeNow.AddDate(0, 0, -10)}
+	ow5 := Transaction{Note: "tea leaves", Price: 30, Date: mockTimeNow.AddDate(0, 0, -1)}
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 5, ow1, ow2, ow3, ow4, ow5)
+	s.Require().NoError(err, desc)
+
+	// prepare more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1, ow1)
+	s.Require().NoError(err, desc)
+
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 0)
+
+	opt := domain.GetTransOpt{
+		Search: domain.Search{
+			Query: &domain.SearchQuery{
+				Terms:  []domain.SearchTerm{{Value: "coffee"}, {Value: "cup", Negated: true}},
+				Prices: []domain.SearchPriceCond{{Op: domain.CmpOpTypeGt, Value: 20}},
+				Dates:  []domain.SearchDateCond{{Op: domain.CmpOpTypeGte, Value: mockTimeNow.AddDate(0, 0, -5)}},
+			},
+		},
+	}
+	trans, decodedNextKey, err := s.repo.GetAll(mockCTX, opt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+	s.Require().Empty(decodedNextKey, desc)
+}
+
 func getAll_WithManyTransaction_ReturnSuccessfully(s *TransactionSuite, desc string) {
 	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
 	s.Require().NoError(err, desc)
@@ -402,6 +726,140 @@ func getAll_FilterByMainCategID_ReturnDataWithMainCategID(s *TransactionSuite, d
 	s.Require().Empty(decodedNextKey, desc)
 }
 
+func getAll_FilterByAnyTagIDs_ReturnDataWithAnyTag(s *TransactionSuite, desc string) {
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4)
+	s.Require().NoError(err, desc)
+
+	tags := insertTagsOfUser(s, user.ID, 2)
+	setTags(s, transactionList[0].ID, tags[0].ID)
+	setTags(s, transactionList[1].ID, tags[0].ID, tags[1].ID)
+	setTags(s, transactionList[2].ID, tags[1].ID)
+
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 0, 1)
+	expResult[0].Tags = []domain.Tag{tags[0]}
+	expResult[1].Tags = []domain.Tag{tags[0], tags[1]}
+
+	opt := domain.GetTransOpt{
+		Filter: domain.Filter{
+			TagIDs:   []int64{tags[0].ID},
+			TagMatch: domain.TagMatchTypeAny,
+		},
+	}
+	trans, decodedNextKey, err := s.repo.GetAll(mockCTX, opt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+	s.Require().Empty(decodedNextKey, desc)
+}
+
+func getAll_FilterByAllTagIDs_ReturnDataWithAllTags(s *TransactionSuite, desc string) {
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4)
+	s.Require().NoError(err, desc)
+
+	tags := insertTagsOfUser(s, user.ID, 2)
+	setTags(s, transactionList[0].ID, tags[0].ID)
+	setTags(s, transactionList[1].ID, tags[0].ID, tags[1].ID)
+	setTags(s, transactionList[2].ID, tags[1].ID)
+
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 1)
+	expResult[0].Tags = []domain.Tag{tags[0], tags[1]}
+
+	opt := domain.GetTransOpt{
+		Filter: domain.Filter{
+			TagIDs:   []int64{tags[0].ID, tags[1].ID},
+			TagMatch: domain.TagMatchTypeAll,
+		},
+	}
+	trans, decodedNextKey, err := s.repo.GetAll(mockCTX, opt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+	s.Require().Empty(decodedNextKey, desc)
+}
+
+func getAll_WithAttachments_ReturnDataWithAttachments(s *TransactionSuite, desc string) {
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 0, 1)
+	for _, fileName := range []string{"a.png", "b.pdf"} {
+		objectKey, err := domain.GenAttachmentObjectKey(user.ID, transactionList[0].ID, fileName)
+		s.Require().NoError(err, desc)
+		res, err := s.db.Exec("INSERT INTO transaction_attachments (transaction_id, user_id, file_name, object_key) VALUES (?, ?, ?, ?)", transactionList[0].ID, user.ID, fileName, objectKey)
+		s.Require().NoError(err, desc)
+		id, err := res.LastInsertId()
+		s.Require().NoError(err, desc)
+
+		expResult[0].Attachments = append(expResult[0].Attachments, domain.TransAttachment{
+			ID:            id,
+			TransactionID: transactionList[0].ID,
+			UserID:        user.ID,
+			FileName:      fileName,
+			ObjectKey:     objectKey,
+		})
+	}
+
+	trans, _, err := s.repo.GetAll(mockCTX, domain.GetTransOpt{}, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(trans, 2, desc)
+
+	// created_at is set by the database
+	for i := range trans[0].Attachments {
+		s.Require().False(trans[0].Attachments[i].CreatedAt.IsZero(), desc)
+		trans[0].Attachments[i].CreatedAt = time.Time{}
+	}
+	s.Require().Equal(expResult, trans, desc)
+}
+
+// insertTagsOfUser inserts i tags of the user
+func insertTagsOfUser(s *TransactionSuite, userID int64, i int) []domain.Tag {
+	tags := make([]domain.Tag, i)
+	for k := range tags {
+		tags[k].Name = fmt.Sprintf("tag%d", k)
+		res, err := s.db.Exec("INSERT INTO tags (user_id, name) VALUES (?, ?)", userID, tags[k].Name)
+		s.Require().NoError(err)
+
+		tags[k].ID, err = res.LastInsertId()
+		s.Require().NoError(err)
+	}
+
+	return tags
+}
+
+// setTags puts the tags on the transaction
+func setTags(s *TransactionSuite, transID int64, tagIDs ...int64) {
+	for _, id := range tagIDs {
+		_, err := s.db.Exec("INSERT INTO transaction_tags (transaction_id, tag_id) VALUES (?, ?)", transID, id)
+		s.Require().NoError(err)
+	}
+}
+
+// getRevisionsOfTrans returns the revisions of the transaction, from the oldest to the newest
+func getRevisionsOfTrans(s *TransactionSuite, transID int64) []domain.TransRevision {
+	rows, err := s.db.Query("SELECT user_id, action, before_snapshot, after_snapshot FROM transaction_revisions WHERE transaction_id = ? ORDER BY id", transID)
+	s.Require().NoError(err)
+	defer rows.Close()
+
+	var revs []domain.TransRevision
+	for rows.Next() {
+		var rev domain.TransRevision
+		var action string
+		var before, after []byte
+		s.Require().NoError(rows.Scan(&rev.UserID, &action, &before, &after))
+
+		rev.Action = domain.CvtToRevisionActionType(action)
+		if before != nil {
+			s.Require().NoError(json.Unmarshal(before, &rev.Before))
+		}
+		if after != nil {
+			s.Require().NoError(json.Unmarshal(after, &rev.After))
+		}
+
+		revs = append(revs, rev)
+	}
+	s.Require().NoError(rows.Err())
+
+	return revs
+}
+
 func getAll_FilterBySubCategID_ReturnDataWithSubCategID(s *TransactionSuite, desc string) {
 	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4)
 	s.Require().NoError(err, desc)
@@ -806,44 +1264,280 @@ func getAll_WithNextKeyCursorAndSortByTransType_ReturnCorrectData(s *Transaction
 	s.Require().Equal(expDecodedNextKey, deencodedNextKey, desc)
 }
 
-func (s *TransactionSuite) TestUpdate() {
-	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
-		"when with one data, update successfully":       update_WithOneData_UpdateSuccessfully,
-		"when with multiple data, update successfully":  update_WithMultipleData_UpdateSuccessfully,
-		"when with multiple users, update successfully": update_WithMultipleUsers_UpdateSuccessfully,
-	} {
-		s.Run(testutil.GetFunName(fn), func() {
-			s.SetupTest()
-			fn(s, scenario)
-			s.TearDownTest()
-		})
-	}
-}
-
-func update_WithOneData_UpdateSuccessfully(s *TransactionSuite, desc string) {
-	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+func getAll_WithNextKeyCursorAndSort_NotReturnDataOfOtherUsers(s *TransactionSuite, desc string) {
+	// the transaction of other user has the same price as the cursor and smaller id
+	_, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1, Transaction{Price: 1000})
 	s.Require().NoError(err, desc)
 
-	t := domain.UpdateTransactionInput{
-		ID:          transactions[0].ID,
-		Type:        domain.CvtToTransactionType(transactions[0].Type),
-		MainCategID: transactions[0].MainCategID,
-		SubCategID:  transactions[0].SubCategID,
-		Price:       999,
-		Note:        "update note",
-		Date:        mockTimeNow.AddDate(0, 0, -1),
-	}
+	ow1 := Transaction{Price: 300}
+	ow2 := Transaction{Price: 500}
+	ow3 := Transaction{Price: 1000}
+	ow4 := Transaction{Price: 1000}
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	s.Require().NoError(err, desc)
 
-	err = s.repo.Update(mockCTX, t)
+	encodedNextKey, err := codeutil.EncodeNextKeys(domain.DecodedNextKeys{{Field: "Price"}, {Field: "ID"}}, transactionList[3])
 	s.Require().NoError(err, desc)
 
-	var checkT Transaction
-	stmt := "SELECT type, main_category_id, sub_category_id, price, note, date FROM transactions WHERE id = ?"
-	err = s.db.QueryRow(stmt, transactions[0].ID).Scan(&checkT.Type, &checkT.MainCategID, &checkT.SubCategID, &checkT.Price, &checkT.Note, &checkT.Date)
-	s.Require().NoError(err)
-	s.Require().Equal(t.Type.ToModelValue(), checkT.Type)
-	s.Require().Equal(t.MainCategID, checkT.MainCategID)
-	s.Require().Equal(t.SubCategID, checkT.SubCategID)
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 2, 1, 0)
+
+	opt := domain.GetTransOpt{
+		Cursor: domain.Cursor{
+			NextKey: encodedNextKey,
+			Size:    3,
+		},
+		Sort: &domain.Sort{
+			By:  domain.SortByTypePrice,
+			Dir: domain.SortDirTypeDesc,
+		},
+	}
+	trans, _, err := s.repo.GetAll(mockCTX, opt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+}
+
+func getAll_WithPrevKeyCursor_ReturnDataBeforeCursorKey(s *TransactionSuite, desc string) {
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 8)
+	s.Require().NoError(err, desc)
+
+	// prepare encodedPrevKey, which is the same as next key
+	encodedPrevKey, err := codeutil.EncodeNextKeys(domain.DecodedNextKeys{{Field: "ID"}}, transactionList[5])
+	s.Require().NoError(err, desc)
+
+	// prepare more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	// the closest ones before the cursor, in the original order
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 2, 3, 4)
+
+	opt := domain.GetTransOpt{
+		Cursor: domain.Cursor{
+			PrevKey: encodedPrevKey,
+			Size:    3,
+		},
+	}
+	trans, decodedPrevKey, err := s.repo.GetAll(mockCTX, opt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+	s.Require().Equal(domain.DecodedNextKeys{{Field: "ID", Value: fmt.Sprint(transactionList[5].ID)}}, decodedPrevKey, desc)
+}
+
+func getAll_WithPrevKeyCursorAndSortByPrice_ReturnCorrectData(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 300}
+	ow2 := Transaction{Price: 300}
+	ow3 := Transaction{Price: 500}
+	ow4 := Transaction{Price: 1000}
+	ow5 := Transaction{Price: 1000}
+	ow6 := Transaction{Price: 2000}
+	ow7 := Transaction{Price: 2500}
+	ow8 := Transaction{Price: 3000}
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 8, ow1, ow2, ow3, ow4, ow5, ow6, ow7, ow8)
+	s.Require().NoError(err, desc)
+
+	encodedPrevKey, err := codeutil.EncodeNextKeys(domain.DecodedNextKeys{{Field: "Price"}, {Field: "ID"}}, transactionList[2])
+	s.Require().NoError(err, desc)
+
+	// prepare more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 2, ow3, ow4)
+	s.Require().NoError(err, desc)
+
+	// in desc order, the ones before 500 are 3000, 2500, 2000, 1000(id 5), 1000(id 4)
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 5, 4, 3)
+
+	opt := domain.GetTransOpt{
+		Cursor: domain.Cursor{
+			PrevKey: encodedPrevKey,
+			Size:    3,
+		},
+		Sort: &domain.Sort{
+			By:  domain.SortByTypePrice,
+			Dir: domain.SortDirTypeDesc,
+		},
+	}
+	trans, _, err := s.repo.GetAll(mockCTX, opt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+}
+
+func (s *TransactionSuite) TestGetTotal() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no filter, return total of all data":       getTotal_NoFilter_ReturnTotalOfAllData,
+		"when with filter, return total of matched data": getTotal_WithFilter_ReturnTotalOfMatchedData,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getTotal_NoFilter_ReturnTotalOfAllData(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue()}
+	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue()}
+	ow3 := Transaction{Price: 500, Type: domain.TransactionTypeIncome.ToModelValue()}
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3, ow1, ow2, ow3)
+	s.Require().NoError(err, desc)
+
+	// prepare more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1, ow1)
+	s.Require().NoError(err, desc)
+
+	// the cursor is ignored
+	opt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 1}}
+	total, err := s.repo.GetTotal(mockCTX, opt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.TransTotal{Count: 3, Income: 500, Expense: 1999}, total, desc)
+}
+
+func getTotal_WithFilter_ReturnTotalOfMatchedData(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -5)}
+	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -1)}
+	ow3 := Transaction{Price: 500, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -1)}
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3, ow1, ow2, ow3)
+	s.Require().NoError(err, desc)
+
+	startDate := mockTimeNow.AddDate(0, 0, -2)
+	endDate := mockTimeNow
+	opt := domain.GetTransOpt{Filter: domain.Filter{StartDate: &startDate, EndDate: &endDate}}
+	total, err := s.repo.GetTotal(mockCTX, opt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.TransTotal{Count: 2, Income: 500, Expense: 1000}, total, desc)
+}
+
+func (s *TransactionSuite) TestStreamAll() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, stream all transactions":          streamAll_NoError_StreamAllTrans,
+		"when with cursor, ignore cursor":                 streamAll_WithCursor_IgnoreCursor,
+		"when with filter and sort, stream matching data": streamAll_WithFilterAndSort_StreamMatchingData,
+		"when callback fail, stop and return error":       streamAll_CallbackFail_StopAndReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func streamAll_NoError_StreamAllTrans(s *TransactionSuite, desc string) {
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, desc)
+
+	// prepare more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 0, 1, 2)
+
+	var trans []domain.Transaction
+	err = s.repo.StreamAll(mockCTX, domain.GetTransOpt{}, user.ID, func(t domain.Transaction) error {
+		trans = append(trans, t)
+		return nil
+	})
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+}
+
+func streamAll_WithCursor_IgnoreCursor(s *TransactionSuite, desc string) {
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, desc)
+
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 0, 1, 2)
+
+	opt := domain.GetTransOpt{Cursor: domain.Cursor{NextKey: "invalid", Size: 1}}
+	var trans []domain.Transaction
+	err = s.repo.StreamAll(mockCTX, opt, user.ID, func(t domain.Transaction) error {
+		trans = append(trans, t)
+		return nil
+	})
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+}
+
+func streamAll_WithFilterAndSort_StreamMatchingData(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Date: mockTimeNow.AddDate(0, 0, -3), Price: 100}
+	ow2 := Transaction{Date: mockTimeNow.AddDate(0, 0, -1), Price: 200}
+	ow3 := Transaction{Date: mockTimeNow.AddDate(0, 0, -2), Price: 300}
+	ow4 := Transaction{Date: mockTimeNow.AddDate(0, 0, 0), Price: 400}
+
+	transactionList, user, mainList, subList, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	s.Require().NoError(err, desc)
+
+	expResult := GetAll_GenExpResult(transactionList, user, mainList, subList, 3, 1, 2)
+
+	minPrice := 200.0
+	opt := domain.GetTransOpt{
+		Filter: domain.Filter{MinPrice: &minPrice},
+		Sort: &domain.Sort{
+			By:  domain.SortByTypeDate,
+			Dir: domain.SortDirTypeDesc,
+		},
+	}
+	var trans []domain.Transaction
+	err = s.repo.StreamAll(mockCTX, opt, user.ID, func(t domain.Transaction) error {
+		trans = append(trans, t)
+		return nil
+	})
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+}
+
+func streamAll_CallbackFail_StopAndReturnError(s *TransactionSuite, desc string) {
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, desc)
+
+	mockErr := errors.New("callback fail")
+	var count int
+	err = s.repo.StreamAll(mockCTX, domain.GetTransOpt{}, user.ID, func(t domain.Transaction) error {
+		count++
+		return mockErr
+	})
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Equal(1, count, desc)
+}
+
+func (s *TransactionSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when with one data, update successfully":       update_WithOneData_UpdateSuccessfully,
+		"when with multiple data, update successfully":  update_WithMultipleData_UpdateSuccessfully,
+		"when with multiple users, update successfully": update_WithMultipleUsers_UpdateSuccessfully,
+		"when updated, record revision":                 update_NoError_RecordRevision,
+		"when revert trashed one, bring it back":        update_RevertTrashed_BringBack,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_WithOneData_UpdateSuccessfully(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	t := domain.UpdateTransactionInput{
+		ID:          transactions[0].ID,
+		Type:        domain.CvtToTransactionType(transactions[0].Type),
+		MainCategID: transactions[0].MainCategID,
+		SubCategID:  transactions[0].SubCategID,
+		Price:       999,
+		Note:        "update note",
+		Date:        mockTimeNow.AddDate(0, 0, -1),
+	}
+
+	err = s.repo.Update(mockCTX, t, domain.RevisionActionTypeUpdate, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+
+	var checkT Transaction
+	stmt := "SELECT type, main_category_id, sub_category_id, price, note, date FROM transactions WHERE id = ?"
+	err = s.db.QueryRow(stmt, transactions[0].ID).Scan(&checkT.Type, &checkT.MainCategID, &checkT.SubCategID, &checkT.Price, &checkT.Note, &checkT.Date)
+	s.Require().NoError(err)
+	s.Require().Equal(t.Type.ToModelValue(), checkT.Type)
+	s.Require().Equal(t.MainCategID, checkT.MainCategID)
+	s.Require().Equal(t.SubCategID, checkT.SubCategID)
 	s.Require().Equal(t.Price, checkT.Price)
 	s.Require().Equal(t.Note, checkT.Note)
 	s.Require().Equal(t.Date, checkT.Date)
@@ -863,7 +1557,7 @@ func update_WithMultipleData_UpdateSuccessfully(s *TransactionSuite, desc string
 		Date:        mockTimeNow.AddDate(0, 0, -1),
 	}
 
-	err = s.repo.Update(mockCTX, t)
+	err = s.repo.Update(mockCTX, t, domain.RevisionActionTypeUpdate, transactions[0].UserID)
 	s.Require().NoError(err, desc)
 
 	var checkT Transaction
@@ -903,7 +1597,7 @@ func update_WithMultipleUsers_UpdateSuccessfully(s *TransactionSuite, desc strin
 		Date:        mockTimeNow,
 	}
 
-	err = s.repo.Update(mockCTX, t)
+	err = s.repo.Update(mockCTX, t, domain.RevisionActionTypeUpdate, transactions[0].UserID)
 	s.Require().NoError(err, desc)
 
 	var checkT Transaction
@@ -925,11 +1619,74 @@ func update_WithMultipleUsers_UpdateSuccessfully(s *TransactionSuite, desc strin
 	s.Require().Equal(transactions2[0].Date, checkT2.Date)
 }
 
+func update_NoError_RecordRevision(s *TransactionSuite, desc string) {
+	transactions, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	// prepare the member who makes the change
+	member, _, _, err := s.f.PrepareUserMainAndSubCateg(mockCTX)
+	s.Require().NoError(err, desc)
+
+	t := domain.UpdateTransactionInput{
+		ID:          transactions[0].ID,
+		Type:        domain.CvtToTransactionType(transactions[0].Type),
+		MainCategID: transactions[0].MainCategID,
+		SubCategID:  transactions[0].SubCategID,
+		Price:       999,
+		Note:        "update note",
+		Date:        transactions[0].Date,
+	}
+
+	err = s.repo.Update(mockCTX, t, domain.RevisionActionTypeUpdate, member.ID)
+	s.Require().NoError(err, desc)
+
+	revs := getRevisionsOfTrans(s, transactions[0].ID)
+	s.Require().Len(revs, 1, desc)
+	s.Require().Equal(domain.RevisionActionTypeUpdate, revs[0].Action, desc)
+	s.Require().Equal(member.ID, revs[0].UserID, desc)
+	s.Require().NotEqual(user.ID, revs[0].UserID, desc)
+	s.Require().Equal(transactions[0].Price, revs[0].Before.Price, desc)
+	s.Require().Equal(transactions[0].Note, revs[0].Before.Note, desc)
+	s.Require().Equal(t.Price, revs[0].After.Price, desc)
+	s.Require().Equal(t.Note, revs[0].After.Note, desc)
+}
+
+func update_RevertTrashed_BringBack(s *TransactionSuite, desc string) {
+	transactions, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Delete(mockCTX, transactions[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+
+	t := domain.UpdateTransactionInput{
+		ID:          transactions[0].ID,
+		Type:        domain.CvtToTransactionType(transactions[0].Type),
+		MainCategID: transactions[0].MainCategID,
+		SubCategID:  transactions[0].SubCategID,
+		Price:       transactions[0].Price,
+		Note:        transactions[0].Note,
+		Date:        transactions[0].Date,
+	}
+
+	err = s.repo.Update(mockCTX, t, domain.RevisionActionTypeRevert, user.ID)
+	s.Require().NoError(err, desc)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+
+	revs := getRevisionsOfTrans(s, transactions[0].ID)
+	s.Require().Len(revs, 2, desc)
+	s.Require().Equal(domain.RevisionActionTypeDelete, revs[0].Action, desc)
+	s.Require().Equal(domain.RevisionActionTypeRevert, revs[1].Action, desc)
+}
+
 func (s *TransactionSuite) TestDelete() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
 		"when with one data, delete successfully":       delete_WithOneData_DeleteSuccessfully,
 		"when with multiple data, delete successfully":  delete_WithMultipleData_DeleteSuccessfully,
 		"when with multiple users, delete successfully": delete_WithMultipleUsers_DeleteSuccessfully,
+		"when deleted, leave it out of reads":           delete_Deleted_LeaveOutOfReads,
+		"when deleted, record revision":                 delete_NoError_RecordRevision,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -943,17 +1700,17 @@ func delete_WithOneData_DeleteSuccessfully(s *TransactionSuite, desc string) {
 	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
 	s.Require().NoError(err, desc)
 
-	err = s.repo.Delete(mockCTX, transactions[0].ID)
+	err = s.repo.Delete(mockCTX, transactions[0].ID, transactions[0].UserID)
 	s.Require().NoError(err, desc)
 
 	var count int
-	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&count)
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE deleted_at IS NULL").Scan(&count)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(0, count, desc)
 
 	// check if data exists
 	var checkT Transaction
-	stmt := "SELECT id FROM transactions WHERE id = ?"
+	stmt := "SELECT id FROM transactions WHERE id = ? AND deleted_at IS NULL"
 	err = s.db.QueryRow(stmt, transactions[0].ID).Scan(&checkT.ID)
 	s.Require().ErrorIs(err, sql.ErrNoRows, desc)
 }
@@ -962,17 +1719,17 @@ func delete_WithMultipleData_DeleteSuccessfully(s *TransactionSuite, desc string
 	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
 	s.Require().NoError(err, desc)
 
-	err = s.repo.Delete(mockCTX, transactions[0].ID)
+	err = s.repo.Delete(mockCTX, transactions[0].ID, transactions[0].UserID)
 	s.Require().NoError(err, desc)
 
 	var count int
-	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&count)
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE deleted_at IS NULL").Scan(&count)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(2, count, desc)
 
 	// check if data exists
 	var checkT Transaction
-	stmt := "SELECT id FROM transactions WHERE id = ?"
+	stmt := "SELECT id FROM transactions WHERE id = ? AND deleted_at IS NULL"
 	err = s.db.QueryRow(stmt, transactions[0].ID).Scan(&checkT.ID)
 	s.Require().ErrorIs(err, sql.ErrNoRows, desc)
 }
@@ -985,28 +1742,64 @@ func delete_WithMultipleUsers_DeleteSuccessfully(s *TransactionSuite, desc strin
 	transactions2, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
 	s.Require().NoError(err, desc)
 
-	err = s.repo.Delete(mockCTX, transactions[0].ID)
+	err = s.repo.Delete(mockCTX, transactions[0].ID, transactions[0].UserID)
 	s.Require().NoError(err, desc)
 
 	var count int
-	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&count)
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE deleted_at IS NULL").Scan(&count)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(3, count, desc)
 
 	// check if data exists
 	var checkT Transaction
-	stmt := "SELECT id FROM transactions WHERE id = ?"
+	stmt := "SELECT id FROM transactions WHERE id = ? AND deleted_at IS NULL"
 	err = s.db.QueryRow(stmt, transactions[0].ID).Scan(&checkT.ID)
 	s.Require().ErrorIs(err, sql.ErrNoRows, desc)
 
 	// check if other user's data still exists
 	var countUser2 int
-	stmt = "SELECT COUNT(*) FROM transactions WHERE user_id = ?"
+	stmt = "SELECT COUNT(*) FROM transactions WHERE user_id = ? AND deleted_at IS NULL"
 	err = s.db.QueryRow(stmt, transactions2[0].UserID).Scan(&countUser2)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(1, countUser2, desc)
 }
 
+func delete_Deleted_LeaveOutOfReads(s *TransactionSuite, desc string) {
+	ow := Transaction{Type: domain.TransactionTypeExpense.ToModelValue(), Price: 100}
+	transactions, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2, ow, ow)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Delete(mockCTX, transactions[0].ID, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+
+	result, _, err := s.repo.GetAll(mockCTX, domain.GetTransOpt{}, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(result, 1, desc)
+	s.Require().Equal(transactions[1].ID, result[0].ID, desc)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+
+	accInfo, err := s.repo.GetAccInfo(mockCTX, domain.GetAccInfoQuery{}, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(float64(100), accInfo.TotalExpense, desc)
+}
+
+func delete_NoError_RecordRevision(s *TransactionSuite, desc string) {
+	transactions, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Delete(mockCTX, transactions[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+
+	revs := getRevisionsOfTrans(s, transactions[0].ID)
+	s.Require().Len(revs, 1, desc)
+	s.Require().Equal(domain.RevisionActionTypeDelete, revs[0].Action, desc)
+	s.Require().Equal(user.ID, revs[0].UserID, desc)
+	s.Require().Equal(transactions[0].Price, revs[0].Before.Price, desc)
+	s.Require().Nil(revs[0].After, desc)
+}
+
 func (s *TransactionSuite) TestGetAccInfo() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
 		"when no error, return successfully":                                  getAccInfo_NoError_ReturnSuccessfully,
@@ -1015,6 +1808,8 @@ func (s *TransactionSuite) TestGetAccInfo() {
 		"when query start date, return accumulated data after start date":     getAccInfo_QueryStartDate_ReturnDataAfterStartDate,
 		"when query end date, return accumulated data before end date":        getAccInfo_QueryEndDate_ReturnDataBeforeEndDate,
 		"when query start and end date, return accumulated data between them": getAccInfo_QueryStartAndEndDate_ReturnDataBetweenStartAndEndDate,
+		"when with other currencies, return data in base currency":            getAccInfo_WithOtherCurrencies_ReturnDataInBaseCurrency,
+		"when with transfer, exclude transfer":                                getAccInfo_WithTransfer_ExcludeTransfer,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -1055,134 +1850,560 @@ func getAccInfo_WithMultipleUsers_ReturnDataOnlyWithOneUser(s *TransactionSuite,
 		TotalIncome:  0,
 		TotalBalance: -999,
 	}
-
-	query := domain.GetAccInfoQuery{}
-	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
-	s.Require().NoError(err, desc)
-	s.Require().Equal(expResult, accInfo, desc)
+
+	query := domain.GetAccInfoQuery{}
+	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, accInfo, desc)
+}
+
+func getAccInfo_WithManyTransaction_ReturnCorrectCalculation(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue()}
+	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue()}
+	ow3 := Transaction{Price: 1000, Type: domain.TransactionTypeIncome.ToModelValue()}
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3, ow1, ow2, ow3)
+	s.Require().NoError(err, desc)
+
+	// prepare two more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	expResult := domain.AccInfo{
+		TotalExpense: 1999,
+		TotalIncome:  1000,
+		TotalBalance: -999,
+	}
+
+	query := domain.GetAccInfoQuery{}
+	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, accInfo, desc)
+}
+
+func getAccInfo_QueryStartDate_ReturnDataAfterStartDate(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -3)}
+	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -2)}
+	ow3 := Transaction{Price: 1000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -1)}
+	ow4 := Transaction{Price: 2000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 0)}
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	s.Require().NoError(err, desc)
+
+	// prepare two more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	expResult := domain.AccInfo{
+		TotalExpense: 0,
+		TotalIncome:  3000,
+		TotalBalance: 3000,
+	}
+
+	startDate := mockTimeNow.AddDate(0, 0, -1).Format(time.DateOnly)
+	query := domain.GetAccInfoQuery{
+		StartDate: &startDate,
+	}
+	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, accInfo, desc)
+}
+
+func getAccInfo_QueryEndDate_ReturnDataBeforeEndDate(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -3)}
+	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -2)}
+	ow3 := Transaction{Price: 1000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -1)}
+	ow4 := Transaction{Price: 2000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 0)}
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	s.Require().NoError(err, desc)
+
+	// prepare two more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	expResult := domain.AccInfo{
+		TotalExpense: 1999,
+		TotalIncome:  1000,
+		TotalBalance: -999,
+	}
+
+	endDate := mockTimeNow.AddDate(0, 0, -1).Format(time.DateOnly)
+	query := domain.GetAccInfoQuery{
+		EndDate: &endDate,
+	}
+	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, accInfo, desc)
+}
+
+func getAccInfo_QueryStartAndEndDate_ReturnDataBetweenStartAndEndDate(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -3)}
+	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -2)}
+	ow3 := Transaction{Price: 1000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -1)}
+	ow4 := Transaction{Price: 2000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 0)}
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	s.Require().NoError(err, desc)
+
+	// prepare two more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	expResult := domain.AccInfo{
+		TotalExpense: 1000,
+		TotalIncome:  1000,
+		TotalBalance: 0,
+	}
+
+	startDate := mockTimeNow.AddDate(0, 0, -2).Format(time.DateOnly)
+	endDate := mockTimeNow.AddDate(0, 0, -1).Format(time.DateOnly)
+	query := domain.GetAccInfoQuery{
+		StartDate: &startDate,
+		EndDate:   &endDate,
+	}
+	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, accInfo, desc)
+}
+
+func getAccInfo_WithOtherCurrencies_ReturnDataInBaseCurrency(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 100, Currency: "USD", Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow}
+	ow2 := Transaction{Price: 100, Currency: "EUR", Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow}
+	ow3 := Transaction{Price: 100, Currency: "GBP", Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow}
+	ow4 := Transaction{Price: 1000, Currency: "JPY", Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow}
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	s.Require().NoError(err, desc)
+
+	// the latest EUR rate on or before the date is used
+	// GBP is converted with the inverse rate, and JPY is skipped because there is no rate
+	stmt := "INSERT INTO exchange_rates (from_currency, to_currency, date, rate) VALUES (?, ?, ?, ?)"
+	rates := []domain.ExchangeRate{
+		{From: "EUR", To: "USD", Date: mockTimeNow.AddDate(0, 0, -10), Rate: 1.1},
+		{From: "EUR", To: "USD", Date: mockTimeNow.AddDate(0, 0, -1), Rate: 1.2},
+		{From: "EUR", To: "USD", Date: mockTimeNow.AddDate(0, 0, 1), Rate: 1.3},
+		{From: "USD", To: "GBP", Date: mockTimeNow, Rate: 0.8},
+	}
+	for _, r := range rates {
+		_, err := s.db.Exec(stmt, r.From, r.To, r.Date, r.Rate)
+		s.Require().NoError(err, desc)
+	}
+
+	accInfo, err := s.repo.GetAccInfo(mockCTX, domain.GetAccInfoQuery{}, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().InDelta(220, accInfo.TotalExpense, 0.0001, desc)
+	s.Require().InDelta(125, accInfo.TotalIncome, 0.0001, desc)
+	s.Require().InDelta(-95, accInfo.TotalBalance, 0.0001, desc)
+	s.Require().Equal(int64(1), accInfo.Unconverted, desc)
+}
+
+func getAccInfo_WithTransfer_ExcludeTransfer(s *TransactionSuite, desc string) {
+	ow1 := Transaction{Price: 100, Type: domain.TransactionTypeExpense.ToModelValue()}
+	ow2 := Transaction{Price: 300, Type: domain.TransactionTypeIncome.ToModelValue()}
+	ow3 := Transaction{Price: 500, Type: domain.TransactionTypeIncome.ToModelValue()}
+	transactions, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3, ow1, ow2, ow3)
+	s.Require().NoError(err, desc)
+
+	accountIDs := insertAccounts(s, user.ID, 2)
+	setTransfer(s, transactions[2].ID, accountIDs[0], accountIDs[1])
+
+	accInfo, err := s.repo.GetAccInfo(mockCTX, domain.GetAccInfoQuery{}, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(float64(100), accInfo.TotalExpense, desc)
+	s.Require().Equal(float64(300), accInfo.TotalIncome, desc)
+	s.Require().Equal(float64(200), accInfo.TotalBalance, desc)
+}
+
+// insertAccounts inserts i accounts of the user, and returns their ids
+func insertAccounts(s *TransactionSuite, userID int64, i int) []int64 {
+	ids := make([]int64, i)
+	for k := range ids {
+		res, err := s.db.Exec("INSERT INTO accounts (user_id, name, type) VALUES (?, ?, ?)", userID, fmt.Sprintf("account%d", k), domain.AccountTypeBank.ToModelValue())
+		s.Require().NoError(err)
+
+		ids[k], err = res.LastInsertId()
+		s.Require().NoError(err)
+	}
+
+	return ids
+}
+
+// setTransfer turns the transaction into a transfer between the accounts
+func setTransfer(s *TransactionSuite, id, accountID, toAccountID int64) {
+	stmt := "UPDATE transactions SET type = ?, main_category_id = NULL, sub_category_id = NULL, account_id = ?, to_account_id = ? WHERE id = ?"
+	_, err := s.db.Exec(stmt, domain.TransactionTypeTransfer.ToModelValue(), accountID, toAccountID, id)
+	s.Require().NoError(err)
+}
+
+func (s *TransactionSuite) TestGetByIDAndUserID() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when only one data, return successfully":       getByIDAndUserID_OnlyOneData_ReturnSuccessfully,
+		"when with multiple data, return successfully":  getByIDAndUserID_WithMultipleData_ReturnSuccessfully,
+		"when with multiple users, return successfully": getByIDAndUserID_WithMultipleUsers_ReturnSuccessfully,
+		"when id not found, return error":               getByIDAndUserID_IDNotFound_ReturnError,
+		"when user id not found, return error":          getByIDAndUserID_UserIDNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndUserID_OnlyOneData_ReturnSuccessfully(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	expResult := domain.Transaction{
+		ID:        transactions[0].ID,
+		UserID:    transactions[0].UserID,
+		Type:      domain.CvtToTransactionType(transactions[0].Type),
+		MainCateg: domain.MainCateg{ID: transactions[0].MainCategID},
+		SubCateg:  domain.SubCateg{ID: transactions[0].SubCategID},
+		Price:     transactions[0].Price,
+		Currency:  transactions[0].Currency,
+		Note:      transactions[0].Note,
+		Date:      transactions[0].Date,
+	}
+
+	trans, err := s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+}
+
+func getByIDAndUserID_WithMultipleData_ReturnSuccessfully(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, desc)
+
+	expResult := domain.Transaction{
+		ID:        transactions[0].ID,
+		UserID:    transactions[0].UserID,
+		Type:      domain.CvtToTransactionType(transactions[0].Type),
+		MainCateg: domain.MainCateg{ID: transactions[0].MainCategID},
+		SubCateg:  domain.SubCateg{ID: transactions[0].SubCategID},
+		Price:     transactions[0].Price,
+		Currency:  transactions[0].Currency,
+		Note:      transactions[0].Note,
+		Date:      transactions[0].Date,
+	}
+
+	trans, err := s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, err)
+}
+
+func getByIDAndUserID_WithMultipleUsers_ReturnSuccessfully(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, desc)
+
+	// prepare more users
+	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	expResult := domain.Transaction{
+		ID:        transactions[0].ID,
+		UserID:    transactions[0].UserID,
+		Type:      domain.CvtToTransactionType(transactions[0].Type),
+		MainCateg: domain.MainCateg{ID: transactions[0].MainCategID},
+		SubCateg:  domain.SubCateg{ID: transactions[0].SubCategID},
+		Price:     transactions[0].Price,
+		Currency:  transactions[0].Currency,
+		Note:      transactions[0].Note,
+		Date:      transactions[0].Date,
+	}
+
+	trans, err := s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, err)
+}
+
+func getByIDAndUserID_IDNotFound_ReturnError(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID+1, transactions[0].UserID)
+	s.Require().Error(err, desc)
+}
+
+func getByIDAndUserID_UserIDNotFound_ReturnError(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, transactions[0].UserID+1)
+	s.Require().Error(err, desc)
+}
+
+func (s *TransactionSuite) TestGetByIDAndUserIDIncludeTrash() {
+	transactions, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err)
+
+	err = s.repo.Delete(mockCTX, transactions[0].ID, user.ID)
+	s.Require().NoError(err)
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound)
+
+	trans, err := s.repo.GetByIDAndUserIDIncludeTrash(mockCTX, transactions[0].ID, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(transactions[0].ID, trans.ID)
+
+	_, err = s.repo.GetByIDAndUserIDIncludeTrash(mockCTX, transactions[0].ID, user.ID+1)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound)
+
+	s.TearDownTest()
+}
+
+func (s *TransactionSuite) TestGetByIDsAndUserID() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when with multiple data, return given ids": getByIDsAndUserID_WithMultipleData_ReturnGivenIDs,
+		"when with multiple users, return own data": getByIDsAndUserID_WithMultipleUsers_ReturnOwnData,
+		"when deleted, leave it out":                getByIDsAndUserID_Deleted_LeaveOut,
+		"when no ids, return empty":                 getByIDsAndUserID_NoIDs_ReturnEmpty,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDsAndUserID_WithMultipleData_ReturnGivenIDs(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, desc)
+
+	expResult := []domain.Transaction{}
+	for _, t := range []Transaction{transactions[0], transactions[2]} {
+		expResult = append(expResult, domain.Transaction{
+			ID:        t.ID,
+			UserID:    t.UserID,
+			Type:      domain.CvtToTransactionType(t.Type),
+			MainCateg: domain.MainCateg{ID: t.MainCategID},
+			SubCateg:  domain.SubCateg{ID: t.SubCategID},
+			Price:     t.Price,
+			Currency:  t.Currency,
+			Note:      t.Note,
+			Date:      t.Date,
+		})
+	}
+
+	trans, err := s.repo.GetByIDsAndUserID(mockCTX, []int64{transactions[2].ID, transactions[0].ID}, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, trans, desc)
+}
+
+func getByIDsAndUserID_WithMultipleUsers_ReturnOwnData(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	// prepare more users
+	transactions2, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	trans, err := s.repo.GetByIDsAndUserID(mockCTX, []int64{transactions[0].ID, transactions2[0].ID}, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(trans, 1, desc)
+	s.Require().Equal(transactions[0].ID, trans[0].ID, desc)
+}
+
+func getByIDsAndUserID_Deleted_LeaveOut(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Delete(mockCTX, transactions[0].ID, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+
+	trans, err := s.repo.GetByIDsAndUserID(mockCTX, []int64{transactions[0].ID, transactions[1].ID}, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(trans, 1, desc)
+	s.Require().Equal(transactions[1].ID, trans[0].ID, desc)
+}
+
+func getByIDsAndUserID_NoIDs_ReturnEmpty(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	trans, err := s.repo.GetByIDsAndUserID(mockCTX, nil, transactions[0].UserID)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(trans, desc)
+}
+
+func (s *TransactionSuite) TestBulk() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, apply all operations":            bulk_NoError_ApplyAllOperations,
+		"when update some fields, keep other fields":     bulk_UpdateSomeFields_KeepOtherFields,
+		"when with multiple users, only change own data": bulk_WithMultipleUsers_OnlyChangeOwnData,
+		"when one operation fail, roll back all":         bulk_OneOperationFail_RollBackAll,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
 }
 
-func getAccInfo_WithManyTransaction_ReturnCorrectCalculation(s *TransactionSuite, desc string) {
-	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue()}
-	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue()}
-	ow3 := Transaction{Price: 1000, Type: domain.TransactionTypeIncome.ToModelValue()}
-	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3, ow1, ow2, ow3)
+func bulk_NoError_ApplyAllOperations(s *TransactionSuite, desc string) {
+	transactions, _, _, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
 	s.Require().NoError(err, desc)
+	userID := transactions[0].UserID
 
-	// prepare two more users
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
-	s.Require().NoError(err, desc)
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	note := "updated"
+	date := mockTimeNow.AddDate(0, 0, -1)
+	input := domain.BulkTransInput{
+		Create: []domain.CreateTransactionInput{
+			{
+				UserID:      userID,
+				Type:        domain.TransactionTypeExpense,
+				MainCategID: subCategs[0].MainCategID,
+				SubCategID:  subCategs[0].ID,
+				Price:       10,
+				Currency:    "USD",
+				Note:        "created",
+				Date:        mockTimeNow,
+			},
+		},
+		Update: []domain.BulkUpdateTransInput{
+			{ID: transactions[0].ID, Note: &note, Date: &date},
+		},
+		Delete: domain.BulkDeleteTransInput{IDs: []int64{transactions[1].ID}},
+	}
+
+	createdIDs, err := s.repo.Bulk(mockCTX, input, userID, userID)
 	s.Require().NoError(err, desc)
+	s.Require().Len(createdIDs, 1, desc)
 
-	expResult := domain.AccInfo{
-		TotalExpense: 1999,
-		TotalIncome:  1000,
-		TotalBalance: -999,
-	}
+	var createdNote string
+	err = s.db.QueryRow("SELECT note FROM transactions WHERE id = ?", createdIDs[0]).Scan(&createdNote)
+	s.Require().NoError(err, desc)
+	s.Require().Equal("created", createdNote, desc)
 
-	query := domain.GetAccInfoQuery{}
-	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	updated, err := s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, userID)
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResult, accInfo, desc)
-}
+	s.Require().Equal(note, updated.Note, desc)
+	s.Require().Equal(date, updated.Date, desc)
 
-func getAccInfo_QueryStartDate_ReturnDataAfterStartDate(s *TransactionSuite, desc string) {
-	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -3)}
-	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -2)}
-	ow3 := Transaction{Price: 1000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -1)}
-	ow4 := Transaction{Price: 2000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 0)}
-	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ? AND deleted_at IS NULL", userID).Scan(&count)
 	s.Require().NoError(err, desc)
+	s.Require().Equal(3, count, desc)
 
-	// prepare two more users
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	// the deleted transaction is moved to trash
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE id = ? AND deleted_at IS NOT NULL", transactions[1].ID).Scan(&count)
 	s.Require().NoError(err, desc)
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().Equal(1, count, desc)
+
+	// every change is recorded in the history
+	revs := getRevisionsOfTrans(s, createdIDs[0])
+	s.Require().Len(revs, 1, desc)
+	s.Require().Equal(domain.RevisionActionTypeCreate, revs[0].Action, desc)
+	s.Require().Equal("created", revs[0].After.Note, desc)
+
+	revs = getRevisionsOfTrans(s, transactions[0].ID)
+	s.Require().Len(revs, 1, desc)
+	s.Require().Equal(domain.RevisionActionTypeUpdate, revs[0].Action, desc)
+	s.Require().Equal(transactions[0].Note, revs[0].Before.Note, desc)
+	s.Require().Equal(note, revs[0].After.Note, desc)
+
+	revs = getRevisionsOfTrans(s, transactions[1].ID)
+	s.Require().Len(revs, 1, desc)
+	s.Require().Equal(domain.RevisionActionTypeDelete, revs[0].Action, desc)
+	s.Require().Equal(userID, revs[0].UserID, desc)
+	s.Require().Nil(revs[0].After, desc)
+}
+
+func bulk_UpdateSomeFields_KeepOtherFields(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
 	s.Require().NoError(err, desc)
+	userID := transactions[0].UserID
 
-	expResult := domain.AccInfo{
-		TotalExpense: 0,
-		TotalIncome:  3000,
-		TotalBalance: 3000,
+	note := "updated"
+	input := domain.BulkTransInput{
+		Update: []domain.BulkUpdateTransInput{{ID: transactions[0].ID, Note: &note}},
 	}
 
-	startDate := mockTimeNow.AddDate(0, 0, -1).Format(time.DateOnly)
-	query := domain.GetAccInfoQuery{
-		StartDate: &startDate,
-	}
-	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	_, err = s.repo.Bulk(mockCTX, input, userID, userID)
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResult, accInfo, desc)
-}
 
-func getAccInfo_QueryEndDate_ReturnDataBeforeEndDate(s *TransactionSuite, desc string) {
-	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -3)}
-	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -2)}
-	ow3 := Transaction{Price: 1000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -1)}
-	ow4 := Transaction{Price: 2000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 0)}
-	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	updated, err := s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, userID)
 	s.Require().NoError(err, desc)
+	s.Require().Equal(note, updated.Note, desc)
+	s.Require().Equal(transactions[0].Date, updated.Date, desc)
+	s.Require().Equal(transactions[0].MainCategID, updated.MainCateg.ID, desc)
+	s.Require().Equal(transactions[0].SubCategID, updated.SubCateg.ID, desc)
+}
 
-	// prepare two more users
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+func bulk_WithMultipleUsers_OnlyChangeOwnData(s *TransactionSuite, desc string) {
+	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
 	s.Require().NoError(err, desc)
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+
+	// prepare more users
+	transactions2, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
 	s.Require().NoError(err, desc)
 
-	expResult := domain.AccInfo{
-		TotalExpense: 1999,
-		TotalIncome:  1000,
-		TotalBalance: -999,
+	note := "updated"
+	input := domain.BulkTransInput{
+		Update: []domain.BulkUpdateTransInput{{ID: transactions2[0].ID, Note: &note}},
+		Delete: domain.BulkDeleteTransInput{IDs: []int64{transactions2[0].ID}},
 	}
 
-	endDate := mockTimeNow.AddDate(0, 0, -1).Format(time.DateOnly)
-	query := domain.GetAccInfoQuery{
-		EndDate: &endDate,
-	}
-	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	_, err = s.repo.Bulk(mockCTX, input, transactions[0].UserID, transactions[0].UserID)
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResult, accInfo, desc)
-}
 
-func getAccInfo_QueryStartAndEndDate_ReturnDataBetweenStartAndEndDate(s *TransactionSuite, desc string) {
-	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -3)}
-	ow2 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -2)}
-	ow3 := Transaction{Price: 1000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, -1)}
-	ow4 := Transaction{Price: 2000, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 0)}
-	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 4, ow1, ow2, ow3, ow4)
+	other, err := s.repo.GetByIDAndUserID(mockCTX, transactions2[0].ID, transactions2[0].UserID)
 	s.Require().NoError(err, desc)
+	s.Require().Equal(transactions2[0].Note, other.Note, desc)
+}
 
-	// prepare two more users
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
-	s.Require().NoError(err, desc)
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+func bulk_OneOperationFail_RollBackAll(s *TransactionSuite, desc string) {
+	transactions, _, _, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2)
 	s.Require().NoError(err, desc)
+	userID := transactions[0].UserID
 
-	expResult := domain.AccInfo{
-		TotalExpense: 1000,
-		TotalIncome:  1000,
-		TotalBalance: 0,
+	// the sub category doesn't exist, so that inserting fails on the foreign key
+	input := domain.BulkTransInput{
+		Create: []domain.CreateTransactionInput{
+			{
+				UserID:      userID,
+				Type:        domain.TransactionTypeExpense,
+				MainCategID: subCategs[0].MainCategID,
+				SubCategID:  subCategs[0].ID + 100,
+				Price:       10,
+				Currency:    "USD",
+				Date:        mockTimeNow,
+			},
+		},
+		Delete: domain.BulkDeleteTransInput{IDs: []int64{transactions[0].ID}},
 	}
 
-	startDate := mockTimeNow.AddDate(0, 0, -2).Format(time.DateOnly)
-	endDate := mockTimeNow.AddDate(0, 0, -1).Format(time.DateOnly)
-	query := domain.GetAccInfoQuery{
-		StartDate: &startDate,
-		EndDate:   &endDate,
-	}
-	accInfo, err := s.repo.GetAccInfo(mockCTX, query, user.ID)
+	_, err = s.repo.Bulk(mockCTX, input, userID, userID)
+	s.Require().Error(err, desc)
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ? AND deleted_at IS NULL", userID).Scan(&count)
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResult, accInfo, desc)
+	s.Require().Equal(2, count, desc)
+
+	// the history is rolled back along with the changes
+	s.Require().Empty(getRevisionsOfTrans(s, transactions[0].ID), desc)
 }
 
-func (s *TransactionSuite) TestGetByIDAndUserID() {
+var mockDuplicatesOpt = domain.GetDuplicatesOpt{
+	Days:      3,
+	StartDate: mockTimeNow.AddDate(0, 0, -domain.DuplicateRangeDays),
+	EndDate:   mockTimeNow,
+}
+
+func (s *TransactionSuite) TestGetDuplicatePairs() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
-		"when only one data, return successfully":       getByIDAndUserID_OnlyOneData_ReturnSuccessfully,
-		"when with multiple data, return successfully":  getByIDAndUserID_WithMultipleData_ReturnSuccessfully,
-		"when with multiple users, return successfully": getByIDAndUserID_WithMultipleUsers_ReturnSuccessfully,
-		"when id not found, return error":               getByIDAndUserID_IDNotFound_ReturnError,
-		"when user id not found, return error":          getByIDAndUserID_UserIDNotFound_ReturnError,
+		"when similar transactions in window, return pairs": getDuplicatePairs_SimilarInWindow_ReturnPairs,
+		"when pair dismissed, leave the pair out":           getDuplicatePairs_PairDismissed_LeaveOut,
+		"when out of range or recurring, leave out":         getDuplicatePairs_OutOfRangeOrRecurring_LeaveOut,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -1192,78 +2413,88 @@ func (s *TransactionSuite) TestGetByIDAndUserID() {
 	}
 }
 
-func getByIDAndUserID_OnlyOneData_ReturnSuccessfully(s *TransactionSuite, desc string) {
-	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+func getDuplicatePairs_SimilarInWindow_ReturnPairs(s *TransactionSuite, desc string) {
+	mainCategList, user, err := s.f.InsertMainCategList(mockCTX, 1)
 	s.Require().NoError(err, desc)
 
-	expResult := domain.Transaction{
-		ID:     transactions[0].ID,
-		UserID: transactions[0].UserID,
-		Type:   domain.CvtToTransactionType(transactions[0].Type),
-		Price:  transactions[0].Price,
-		Note:   transactions[0].Note,
-		Date:   transactions[0].Date,
-	}
+	expense := domain.TransactionTypeExpense.ToModelValue()
+	ow1 := Transaction{Price: 10, Currency: "USD", Type: expense, Date: mockTimeNow, MainCategID: mainCategList[0].ID}
+	ow2 := Transaction{Price: 10, Currency: "USD", Type: expense, Date: mockTimeNow.AddDate(0, 0, -2), MainCategID: mainCategList[0].ID}
+	ow3 := Transaction{Price: 10, Currency: "USD", Type: expense, Date: mockTimeNow.AddDate(0, 0, -10), MainCategID: mainCategList[0].ID} // out of window
+	ow4 := Transaction{Price: 11, Currency: "USD", Type: expense, Date: mockTimeNow, MainCategID: mainCategList[0].ID}                    // different price
+	ow5 := Transaction{Price: 10, Currency: "EUR", Type: expense, Date: mockTimeNow, MainCategID: mainCategList[0].ID}                    // different currency
+	transList, _, err := s.f.InsertTransactionWithGivenUser(mockCTX, 5, user, ow1, ow2, ow3, ow4, ow5)
+	s.Require().NoError(err, desc)
 
-	trans, err := s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, transactions[0].UserID)
+	pairs, err := s.repo.GetDuplicatePairs(mockCTX, mockDuplicatesOpt, user.ID)
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResult, trans, desc)
+	s.Require().Equal([]domain.DuplicatePair{{FirstID: transList[0].ID, SecondID: transList[1].ID}}, pairs, desc)
 }
 
-func getByIDAndUserID_WithMultipleData_ReturnSuccessfully(s *TransactionSuite, desc string) {
-	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+func getDuplicatePairs_PairDismissed_LeaveOut(s *TransactionSuite, desc string) {
+	mainCategList, user, err := s.f.InsertMainCategList(mockCTX, 1)
 	s.Require().NoError(err, desc)
 
-	expResult := domain.Transaction{
-		ID:     transactions[0].ID,
-		UserID: transactions[0].UserID,
-		Type:   domain.CvtToTransactionType(transactions[0].Type),
-		Price:  transactions[0].Price,
-		Note:   transactions[0].Note,
-		Date:   transactions[0].Date,
-	}
-
-	trans, err := s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, transactions[0].UserID)
+	ow := Transaction{Price: 10, Currency: "USD", Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow, MainCategID: mainCategList[0].ID}
+	transList, _, err := s.f.InsertTransactionWithGivenUser(mockCTX, 3, user, ow, ow, ow)
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResult, trans, err)
-}
 
-func getByIDAndUserID_WithMultipleUsers_ReturnSuccessfully(s *TransactionSuite, desc string) {
-	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	// the order of the ids doesn't matter
+	err = s.repo.DismissDuplicates(mockCTX, []int64{transList[1].ID, transList[0].ID})
 	s.Require().NoError(err, desc)
 
-	// prepare more users
-	_, _, _, _, err = s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	pairs, err := s.repo.GetDuplicatePairs(mockCTX, mockDuplicatesOpt, user.ID)
 	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.DuplicatePair{
+		{FirstID: transList[0].ID, SecondID: transList[2].ID},
+		{FirstID: transList[1].ID, SecondID: transList[2].ID},
+	}, pairs, desc)
+}
 
-	expResult := domain.Transaction{
-		ID:     transactions[0].ID,
-		UserID: transactions[0].UserID,
-		Type:   domain.CvtToTransactionType(transactions[0].Type),
-		Price:  transactions[0].Price,
-		Note:   transactions[0].Note,
-		Date:   transactions[0].Date,
-	}
+func getDuplicatePairs_OutOfRangeOrRecurring_LeaveOut(s *TransactionSuite, desc string) {
+	mainCategList, user, err := s.f.InsertMainCategList(mockCTX, 1)
+	s.Require().NoError(err, desc)
 
-	trans, err := s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, transactions[0].UserID)
+	expense := domain.TransactionTypeExpense.ToModelValue()
+	ow1 := Transaction{Price: 10, Currency: "USD", Type: expense, Date: mockTimeNow, MainCategID: mainCategList[0].ID}
+	ow2 := Transaction{Price: 10, Currency: "USD", Type: expense, Date: mockTimeNow.AddDate(0, 0, -1), MainCategID: mainCategList[0].ID}
+	ow3 := Transaction{Price: 20, Currency: "USD", Type: expense, Date: mockDuplicatesOpt.StartDate, MainCategID: mainCategList[0].ID}
+	ow4 := Transaction{Price: 20, Currency: "USD", Type: expense, Date: mockDuplicatesOpt.StartDate.AddDate(0, 0, -1), MainCategID: mainCategList[0].ID} // out of range
+	transList, _, err := s.f.InsertTransactionWithGivenUser(mockCTX, 4, user, ow1, ow2, ow3, ow4)
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResult, trans, err)
-}
 
-func getByIDAndUserID_IDNotFound_ReturnError(s *TransactionSuite, desc string) {
-	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	// the occurrences of recurring transactions look alike
+	_, err = s.db.Exec("UPDATE transactions SET is_recurring = TRUE WHERE id = ?", transList[1].ID)
 	s.Require().NoError(err, desc)
 
-	_, err = s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID+1, transactions[0].UserID)
-	s.Require().Error(err, desc)
+	pairs, err := s.repo.GetDuplicatePairs(mockCTX, mockDuplicatesOpt, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(pairs, desc)
 }
 
-func getByIDAndUserID_UserIDNotFound_ReturnError(s *TransactionSuite, desc string) {
-	transactions, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
-	s.Require().NoError(err, desc)
+func (s *TransactionSuite) TestGetDuplicateCandidates() {
+	mainCategList, user, err := s.f.InsertMainCategList(mockCTX, 1)
+	s.Require().NoError(err)
 
-	_, err = s.repo.GetByIDAndUserID(mockCTX, transactions[0].ID, transactions[0].UserID+1)
-	s.Require().Error(err, desc)
+	expense := domain.TransactionTypeExpense.ToModelValue()
+	ow1 := Transaction{Price: 10, Currency: "USD", Type: expense, Date: mockTimeNow.AddDate(0, 0, -1), MainCategID: mainCategList[0].ID}
+	ow2 := Transaction{Price: 10, Currency: "USD", Type: expense, Date: mockTimeNow.AddDate(0, 0, -5), MainCategID: mainCategList[0].ID} // out of window
+	transList, sub, err := s.f.InsertTransactionWithGivenUser(mockCTX, 2, user, ow1, ow2)
+	s.Require().NoError(err)
+
+	input := domain.CreateTransactionInput{
+		UserID:      user.ID,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: mainCategList[0].ID,
+		SubCategID:  sub.ID,
+		Price:       10,
+		Currency:    "USD",
+		Date:        mockTimeNow,
+	}
+	candidates, err := s.repo.GetDuplicateCandidates(mockCTX, input, 3)
+	s.Require().NoError(err)
+	s.Require().Len(candidates, 1)
+	s.Require().Equal(transList[0].ID, candidates[0].ID)
 }
 
 func (s *TransactionSuite) TestGetDailyBarChartData() {
@@ -1304,7 +2535,7 @@ func getDailyBarChartData_WithOneData_ReturnSuccessfully(s *TransactionSuite, de
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetDailyBarChartData(mockCTX, dataRange, transactionType, nil, user.ID)
+	chartData, err := s.repo.GetDailyBarChartData(mockCTX, dataRange, transactionType, nil, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1351,7 +2582,7 @@ func getDailyBarChartData_WithMultipleData_ReturnSuccessfully(s *TransactionSuit
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetDailyBarChartData(mockCTX, dataRange, transactionType, mainCategIDs, user.ID)
+	chartData, err := s.repo.GetDailyBarChartData(mockCTX, dataRange, transactionType, mainCategIDs, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1409,7 +2640,7 @@ func getDailyBarChartData_WithMultipleUsers_ReturnSuccessfully(s *TransactionSui
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetDailyBarChartData(mockCTX, dataRange, transactionType, mainCategIDs, user.ID)
+	chartData, err := s.repo.GetDailyBarChartData(mockCTX, dataRange, transactionType, mainCategIDs, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1455,7 +2686,7 @@ func getDailyBarChartData_NoMainCategIDs_DoNotFilterByMainCategIDs(s *Transactio
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetDailyBarChartData(mockCTX, dataRange, transactionType, nil, user.ID)
+	chartData, err := s.repo.GetDailyBarChartData(mockCTX, dataRange, transactionType, nil, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1498,7 +2729,7 @@ func getMonthlyBarChartData_WithOneData_ReturnSuccessfully(s *TransactionSuite,
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetMonthlyBarChartData(mockCTX, dataRange, transactionType, nil, user.ID)
+	chartData, err := s.repo.GetMonthlyBarChartData(mockCTX, dataRange, transactionType, nil, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1545,7 +2776,7 @@ func getMonthlyBarChartData_WithMultipleData_ReturnSuccessfully(s *TransactionSu
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetMonthlyBarChartData(mockCTX, dataRange, transactionType, mainCategIDs, user.ID)
+	chartData, err := s.repo.GetMonthlyBarChartData(mockCTX, dataRange, transactionType, mainCategIDs, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1603,7 +2834,7 @@ func getMonthlyBarChartData_WithMultipleUsers_ReturnSuccessfully(s *TransactionS
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetMonthlyBarChartData(mockCTX, dataRange, transactionType, mainCategIDs, user.ID)
+	chartData, err := s.repo.GetMonthlyBarChartData(mockCTX, dataRange, transactionType, mainCategIDs, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1649,7 +2880,7 @@ func getMonthlyBarChartData_NoMainCategIDs_DoNotFilterByMainCategIDs(s *Transact
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetMonthlyBarChartData(mockCTX, dataRange, transactionType, nil, user.ID)
+	chartData, err := s.repo.GetMonthlyBarChartData(mockCTX, dataRange, transactionType, nil, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1693,7 +2924,7 @@ func getPieChartData_WithOneData_ReturnSuccessfully(s *TransactionSuite, desc st
 	}
 	transactionType := domain.TransactionTypeExpense
 
-	chartData, err := s.repo.GetPieChartData(mockCTX, dataRange, transactionType, user.ID)
+	chartData, err := s.repo.GetPieChartData(mockCTX, dataRange, transactionType, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1739,7 +2970,7 @@ func getPieChartData_WithMultipleData_ReturnSuccessfully(s *TransactionSuite, de
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetPieChartData(mockCTX, dataRange, transactionType, user.ID)
+	chartData, err := s.repo.GetPieChartData(mockCTX, dataRange, transactionType, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1796,11 +3027,116 @@ func getPieChartData_WithMultipleUsers_ReturnSuccessfully(s *TransactionSuite, d
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetPieChartData(mockCTX, dataRange, transactionType, user.ID)
+	chartData, err := s.repo.GetPieChartData(mockCTX, dataRange, transactionType, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
 
+func (s *TransactionSuite) TestGetSumByMainCateg() {
+	start, err := time.Parse(time.DateOnly, "2024-03-17")
+	s.Require().NoError(err)
+	end, err := time.Parse(time.DateOnly, "2024-03-21")
+	s.Require().NoError(err)
+
+	mainCategOW1 := maincateg.MainCateg{Name: "food", Type: domain.TransactionTypeExpense.ToModelValue()}
+	mainCategOW2 := maincateg.MainCateg{Name: "clothes", Type: domain.TransactionTypeExpense.ToModelValue()}
+	mainCategOW3 := maincateg.MainCateg{Name: "salary", Type: domain.TransactionTypeIncome.ToModelValue()} // income type
+	mainCategList, user, err := s.f.InsertMainCategList(mockCTX, 3, mainCategOW1, mainCategOW2, mainCategOW3)
+	s.Require().NoError(err)
+
+	ow1 := Transaction{Price: 999, Type: mainCategList[0].Type, MainCategID: mainCategList[0].ID, Date: start}
+	ow2 := Transaction{Price: 1, Type: mainCategList[0].Type, MainCategID: mainCategList[0].ID, Date: end}
+	ow3 := Transaction{Price: 1000, Type: mainCategList[1].Type, MainCategID: mainCategList[1].ID, Date: start}
+	ow4 := Transaction{Price: 500, Type: mainCategList[2].Type, MainCategID: mainCategList[2].ID, Date: start}                    // income type
+	ow5 := Transaction{Price: 1000, Type: mainCategList[1].Type, MainCategID: mainCategList[1].ID, Date: start.AddDate(0, 0, 10)} // out of date range
+	_, _, err = s.f.InsertTransactionWithGivenUser(mockCTX, 5, user, ow1, ow2, ow3, ow4, ow5)
+	s.Require().NoError(err)
+
+	expResult := []domain.MainCategSum{
+		{MainCateg: domain.MainCateg{ID: mainCategList[0].ID, Name: mainCategList[0].Name, Type: domain.TransactionTypeExpense}, Sum: 1000},
+		{MainCateg: domain.MainCateg{ID: mainCategList[1].ID, Name: mainCategList[1].Name, Type: domain.TransactionTypeExpense}, Sum: 1000},
+	}
+
+	dataRange := domain.ChartDateRange{
+		Start: start,
+		End:   end,
+	}
+	result, err := s.repo.GetSumByMainCateg(mockCTX, dataRange, domain.TransactionTypeExpense, nil, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(expResult, result)
+
+	s.TearDownTest()
+}
+
+func (s *TransactionSuite) TestGetSumByTag() {
+	start, err := time.Parse(time.DateOnly, "2024-03-17")
+	s.Require().NoError(err)
+	end, err := time.Parse(time.DateOnly, "2024-03-21")
+	s.Require().NoError(err)
+
+	ow1 := Transaction{Price: 999, Type: domain.TransactionTypeExpense.ToModelValue(), Date: start}
+	ow2 := Transaction{Price: 1, Type: domain.TransactionTypeExpense.ToModelValue(), Date: end}
+	ow3 := Transaction{Price: 500, Type: domain.TransactionTypeIncome.ToModelValue(), Date: start}                     // income type
+	ow4 := Transaction{Price: 1000, Type: domain.TransactionTypeExpense.ToModelValue(), Date: start.AddDate(0, 0, 10)} // out of date range
+	ow5 := Transaction{Price: 300, Type: domain.TransactionTypeExpense.ToModelValue(), Date: start}                    // no tag
+	transactions, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 5, ow1, ow2, ow3, ow4, ow5)
+	s.Require().NoError(err)
+
+	tags := insertTagsOfUser(s, user.ID, 2)
+	setTags(s, transactions[0].ID, tags[0].ID, tags[1].ID)
+	setTags(s, transactions[1].ID, tags[0].ID)
+	setTags(s, transactions[2].ID, tags[0].ID)
+	setTags(s, transactions[3].ID, tags[1].ID)
+
+	// the transaction with both tags is summed in each of them
+	expResult := []domain.TagSum{
+		{Tag: tags[0], Sum: 1000},
+		{Tag: tags[1], Sum: 999},
+	}
+
+	dataRange := domain.ChartDateRange{
+		Start: start,
+		End:   end,
+	}
+	result, err := s.repo.GetSumByTag(mockCTX, dataRange, domain.TransactionTypeExpense, nil, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(expResult, result)
+
+	s.TearDownTest()
+}
+
+func (s *TransactionSuite) TestCountUnconverted() {
+	start, err := time.Parse(time.DateOnly, "2024-03-17")
+	s.Require().NoError(err)
+	end, err := time.Parse(time.DateOnly, "2024-03-21")
+	s.Require().NoError(err)
+
+	ow1 := Transaction{Price: 100, Currency: "USD", Type: domain.TransactionTypeExpense.ToModelValue(), Date: start}
+	ow2 := Transaction{Price: 100, Currency: "EUR", Type: domain.TransactionTypeExpense.ToModelValue(), Date: start} // converted
+	ow3 := Transaction{Price: 100, Currency: "JPY", Type: domain.TransactionTypeExpense.ToModelValue(), Date: start}
+	ow4 := Transaction{Price: 100, Currency: "JPY", Type: domain.TransactionTypeIncome.ToModelValue(), Date: end}
+	ow5 := Transaction{Price: 100, Currency: "JPY", Type: domain.TransactionTypeExpense.ToModelValue(), Date: start.AddDate(0, 0, 10)} // out of date range
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 5, ow1, ow2, ow3, ow4, ow5)
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec("INSERT INTO exchange_rates (from_currency, to_currency, date, rate) VALUES (?, ?, ?, ?)", "EUR", "USD", start, 1.1)
+	s.Require().NoError(err)
+
+	dataRange := domain.ChartDateRange{
+		Start: start,
+		End:   end,
+	}
+	count, err := s.repo.CountUnconverted(mockCTX, dataRange, domain.TransactionTypeExpense, nil, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), count)
+
+	count, err = s.repo.CountUnconverted(mockCTX, dataRange, domain.TransactionTypeUnSpecified, nil, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(2), count)
+
+	s.TearDownTest()
+}
+
 func (s *TransactionSuite) TestGetDailyLineChartData() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
 		"when with two data, return successfully":       getDailyLineChartData_WithTwoData_ReturnSuccessFully,
@@ -1835,7 +3171,7 @@ func getDailyLineChartData_WithTwoData_ReturnSuccessFully(s *TransactionSuite, d
 		End:   end,
 	}
 
-	chartData, err := s.repo.GetDailyLineChartData(mockCTX, dataRange, user.ID)
+	chartData, err := s.repo.GetDailyLineChartData(mockCTX, dataRange, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1873,7 +3209,7 @@ func getDailyLineChartData_WithMultipleData_ReturnSuccessfully(s *TransactionSui
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetDailyLineChartData(mockCTX, dataRange, user.ID)
+	chartData, err := s.repo.GetDailyLineChartData(mockCTX, dataRange, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1922,7 +3258,7 @@ func getDailyLineChartData_WithMultipleUsers_ReturnSuccessfully(s *TransactionSu
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetDailyLineChartData(mockCTX, dataRange, user.ID)
+	chartData, err := s.repo.GetDailyLineChartData(mockCTX, dataRange, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1961,7 +3297,7 @@ func getMonthlyLineChartData_WithTwoData_ReturnSuccessFully(s *TransactionSuite,
 		End:   end,
 	}
 
-	chartData, err := s.repo.GetMonthlyLineChartData(mockCTX, dataRange, user.ID)
+	chartData, err := s.repo.GetMonthlyLineChartData(mockCTX, dataRange, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -1999,7 +3335,7 @@ func getMonthlyLineChartData_WithMultipleData_ReturnSuccessfully(s *TransactionS
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetMonthlyLineChartData(mockCTX, dataRange, user.ID)
+	chartData, err := s.repo.GetMonthlyLineChartData(mockCTX, dataRange, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -2048,7 +3384,7 @@ func getMonthlyLineChartData_WithMultipleUsers_ReturnSuccessfully(s *Transaction
 		Start: start,
 		End:   end,
 	}
-	chartData, err := s.repo.GetMonthlyLineChartData(mockCTX, dataRange, user.ID)
+	chartData, err := s.repo.GetMonthlyLineChartData(mockCTX, dataRange, nil, user.ID)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, chartData, desc)
 }
@@ -2177,6 +3513,7 @@ func (s *TransactionSuite) TestGetMonthlyAggregatedData() {
 		"when with multiple data, return successfully":  getMonthlyAggregatedData_WithMultipleData_ReturnSuccessfully,
 		"when with multiple users, return successfully": getMonthlyAggregatedData_WithMultipleUsers_ReturnSuccessfully,
 		"when with no data, return successfully":        getMonthlyAggregatedData_WithNoData_ReturnSuccessfully,
+		"when without exchange rate, count unconverted": getMonthlyAggregatedData_WithoutRate_CountUnconverted,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -2208,6 +3545,31 @@ func getMonthlyAggregatedData_WithOneData_ReturnSuccessfully(s *TransactionSuite
 	s.Require().Equal(expResult, monthlyDataList, desc)
 }
 
+func getMonthlyAggregatedData_WithoutRate_CountUnconverted(s *TransactionSuite, desc string) {
+	date, err := time.Parse(time.DateOnly, "2024-10-01")
+	s.Require().NoError(err, desc)
+
+	ow1 := Transaction{Type: domain.TransactionTypeExpense.ToModelValue(), Date: date, Price: 1000, Currency: "USD"}
+	ow2 := Transaction{Type: domain.TransactionTypeIncome.ToModelValue(), Date: date, Price: 3000, Currency: "USD"}
+	ow3 := Transaction{Type: domain.TransactionTypeExpense.ToModelValue(), Date: date, Price: 500, Currency: "JPY"} // no exchange rate
+	_, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3, ow1, ow2, ow3)
+	s.Require().NoError(err, desc)
+
+	// the month is still aggregated, with the transaction without exchange rate left out
+	expResult := []domain.MonthlyAggregatedData{
+		{
+			UserID:       user.ID,
+			TotalExpense: 1000,
+			TotalIncome:  3000,
+			Unconverted:  1,
+		},
+	}
+
+	monthlyDataList, err := s.repo.GetMonthlyAggregatedData(mockCTX, date)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, monthlyDataList, desc)
+}
+
 func getMonthlyAggregatedData_WithMultipleData_ReturnSuccessfully(s *TransactionSuite, desc string) {
 	date, err := time.Parse(time.DateOnly, "2024-10-01")
 	s.Require().NoError(err, desc)
@@ -2287,3 +3649,39 @@ func getMonthlyAggregatedData_WithNoData_ReturnSuccessfully(s *TransactionSuite,
 	s.Require().NoError(err, desc)
 	s.Require().Empty(monthlyDataList, desc)
 }
+
+func (s *TransactionSuite) TestGetAccountEntries() {
+	ow1 := Transaction{Price: 300, Type: domain.TransactionTypeIncome.ToModelValue(), Date: mockTimeNow}
+	ow2 := Transaction{Price: 100, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 1)}
+	ow3 := Transaction{Price: 50, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 2)}
+	ow4 := Transaction{Price: 80, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 3)}
+	ow5 := Transaction{Price: 20, Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 4)}
+	ow6 := Transaction{Price: 1000, Currency: "JPY", Type: domain.TransactionTypeExpense.ToModelValue(), Date: mockTimeNow.AddDate(0, 0, 5)} // no exchange rate
+	transactions, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 6, ow1, ow2, ow3, ow4, ow5, ow6)
+	s.Require().NoError(err)
+
+	accountIDs := insertAccounts(s, user.ID, 2)
+	for _, t := range []Transaction{transactions[0], transactions[1], transactions[5]} {
+		_, err := s.db.Exec("UPDATE transactions SET account_id = ? WHERE id = ?", accountIDs[0], t.ID)
+		s.Require().NoError(err)
+	}
+
+	// money out of the account, then money in to the account
+	setTransfer(s, transactions[2].ID, accountIDs[0], accountIDs[1])
+	setTransfer(s, transactions[3].ID, accountIDs[1], accountIDs[0])
+	// transactions[4] doesn't belong to any account
+
+	expResult := []domain.AccountEntry{
+		{TransactionID: transactions[0].ID, Type: domain.TransactionTypeIncome, Date: transactions[0].Date, Note: transactions[0].Note, Amount: 300},
+		{TransactionID: transactions[1].ID, Type: domain.TransactionTypeExpense, Date: transactions[1].Date, Note: transactions[1].Note, Amount: -100},
+		{TransactionID: transactions[2].ID, Type: domain.TransactionTypeTransfer, Date: transactions[2].Date, Note: transactions[2].Note, Amount: -50},
+		{TransactionID: transactions[3].ID, Type: domain.TransactionTypeTransfer, Date: transactions[3].Date, Note: transactions[3].Note, Amount: 80},
+		{TransactionID: transactions[5].ID, Type: domain.TransactionTypeExpense, Date: transactions[5].Date, Note: transactions[5].Note, IsUnconverted: true},
+	}
+
+	result, err := s.repo.GetAccountEntries(mockCTX, accountIDs[0], user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(expResult, result)
+
+	s.TearDownTest()
+}
@@ -0,0 +1,42 @@
+package transrevision
+
+import (
+	"encoding/json"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+func cvtToDomainTransRevision(m TransRevision) (domain.TransRevision, error) {
+	before, err := unmarshalSnapshot(m.BeforeSnapshot)
+	if err != nil {
+		return domain.TransRevision{}, err
+	}
+
+	after, err := unmarshalSnapshot(m.AfterSnapshot)
+	if err != nil {
+		return domain.TransRevision{}, err
+	}
+
+	return domain.TransRevision{
+		ID:            m.ID,
+		TransactionID: m.TransactionID,
+		UserID:        m.UserID,
+		Action:        domain.CvtToRevisionActionType(m.Action),
+		Before:        before,
+		After:         after,
+		CreatedAt:     m.CreatedAt,
+	}, nil
+}
+
+func unmarshalSnapshot(b []byte) (*domain.TransSnapshot, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	var s domain.TransSnapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
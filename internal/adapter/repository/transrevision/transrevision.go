@@ -0,0 +1,96 @@
+package transrevision
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/repository/transrevision"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+// TransRevision is the model of transaction revision, the snapshots are stored as JSON
+type TransRevision struct {
+	ID             int64
+	TransactionID  int64
+	UserID         int64
+	Action         string
+	BeforeSnapshot []byte
+	AfterSnapshot  []byte
+	CreatedAt      time.Time
+}
+
+func (r *Repo) GetByTransID(ctx context.Context, transID int64) ([]domain.TransRevision, error) {
+	qStmt := `SELECT id, transaction_id, user_id, action, before_snapshot, after_snapshot, created_at
+						FROM transaction_revisions
+						WHERE transaction_id = ?
+						ORDER BY id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, transID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var revs []domain.TransRevision
+	for rows.Next() {
+		var m TransRevision
+		if err := rows.Scan(&m.ID, &m.TransactionID, &m.UserID, &m.Action, &m.BeforeSnapshot, &m.AfterSnapshot, &m.CreatedAt); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		rev, err := cvtToDomainTransRevision(m)
+		if err != nil {
+			logger.Error("cvtToDomainTransRevision failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		revs = append(revs, rev)
+	}
+
+	return revs, nil
+}
+
+func (r *Repo) GetByIDAndTransID(ctx context.Context, id, transID int64) (domain.TransRevision, error) {
+	qStmt := `SELECT id, transaction_id, user_id, action, before_snapshot, after_snapshot, created_at
+						FROM transaction_revisions
+						WHERE id = ? AND transaction_id = ?`
+
+	var m TransRevision
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, transID).
+		Scan(&m.ID, &m.TransactionID, &m.UserID, &m.Action, &m.BeforeSnapshot, &m.AfterSnapshot, &m.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TransRevision{}, domain.ErrTransRevisionNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.TransRevision{}, err
+	}
+
+	rev, err := cvtToDomainTransRevision(m)
+	if err != nil {
+		logger.Error("cvtToDomainTransRevision failed", "package", packageName, "err", err)
+		return domain.TransRevision{}, err
+	}
+
+	return rev, nil
+}
@@ -0,0 +1,163 @@
+package transrevision
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/transaction"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX  = context.Background()
+	mockDate = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type TransRevisionSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *transaction.TransactionFactory
+}
+
+func TestTransRevisionSuite(t *testing.T) {
+	suite.Run(t, new(TransRevisionSuite))
+}
+
+func (s *TransRevisionSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = transaction.NewTransactionFactory(s.db)
+}
+
+func (s *TransRevisionSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *TransRevisionSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = transaction.NewTransactionFactory(s.db)
+}
+
+func (s *TransRevisionSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"transaction_revisions", "transactions", "sub_categories", "main_categories", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *TransRevisionSuite) TestGetByTransID() {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, "test get by trans id")
+
+	before := &domain.TransSnapshot{Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 100, Currency: "USD", Date: mockDate, Note: "lunch"}
+	after := &domain.TransSnapshot{Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 120, Currency: "USD", Date: mockDate, Note: "lunch", TagIDs: []int64{3}}
+	revs := []domain.TransRevision{
+		{TransactionID: trans[0].ID, UserID: user.ID, Action: domain.RevisionActionTypeCreate, After: before},
+		{TransactionID: trans[0].ID, UserID: user.ID, Action: domain.RevisionActionTypeUpdate, Before: before, After: after},
+		{TransactionID: trans[1].ID, UserID: user.ID, Action: domain.RevisionActionTypeDelete, Before: after},
+	}
+	for _, rev := range revs {
+		s.insertRevision(rev)
+	}
+
+	result, err := s.repo.GetByTransID(mockCTX, trans[0].ID)
+	s.Require().NoError(err, "test get by trans id")
+	s.Require().Len(result, 2, "test get by trans id")
+	for i, r := range result {
+		s.Require().NotZero(r.ID, "test get by trans id")
+		s.Require().False(r.CreatedAt.IsZero(), "test get by trans id")
+		r.ID, r.CreatedAt = 0, time.Time{}
+		s.Require().Equal(revs[i], r, "test get by trans id")
+	}
+}
+
+func (s *TransRevisionSuite) TestGetByIDAndTransID() {
+	for scenario, fn := range map[string]func(s *TransRevisionSuite, desc string){
+		"when revision of the transaction, return it":      getByIDAndTransID_RevisionOfTrans_ReturnIt,
+		"when revision of other transaction, return error": getByIDAndTransID_RevisionOfOtherTrans_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getByIDAndTransID_RevisionOfTrans_ReturnIt(s *TransRevisionSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	after := &domain.TransSnapshot{Type: domain.TransactionTypeIncome, Price: 10, Currency: "USD", Date: mockDate}
+	s.insertRevision(domain.TransRevision{TransactionID: trans[0].ID, UserID: user.ID, Action: domain.RevisionActionTypeCreate, After: after})
+
+	revs, err := s.repo.GetByTransID(mockCTX, trans[0].ID)
+	s.Require().NoError(err, desc)
+
+	result, err := s.repo.GetByIDAndTransID(mockCTX, revs[0].ID, trans[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(revs[0], result, desc)
+}
+
+func getByIDAndTransID_RevisionOfOtherTrans_ReturnError(s *TransRevisionSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 2)
+	s.Require().NoError(err, desc)
+
+	s.insertRevision(domain.TransRevision{TransactionID: trans[0].ID, UserID: user.ID, Action: domain.RevisionActionTypeDelete, Before: &domain.TransSnapshot{}})
+
+	revs, err := s.repo.GetByTransID(mockCTX, trans[0].ID)
+	s.Require().NoError(err, desc)
+
+	_, err = s.repo.GetByIDAndTransID(mockCTX, revs[0].ID, trans[1].ID)
+	s.Require().ErrorIs(err, domain.ErrTransRevisionNotFound, desc)
+}
+
+// insertRevision inserts the revision directly, because the revisions are written by the transaction repository
+func (s *TransRevisionSuite) insertRevision(rev domain.TransRevision) {
+	var before, after []byte
+	var err error
+	if rev.Before != nil {
+		before, err = json.Marshal(rev.Before)
+		s.Require().NoError(err)
+	}
+	if rev.After != nil {
+		after, err = json.Marshal(rev.After)
+		s.Require().NoError(err)
+	}
+
+	_, err = s.db.Exec("INSERT INTO transaction_revisions (transaction_id, user_id, action, before_snapshot, after_snapshot) VALUES (?, ?, ?, ?, ?)",
+		rev.TransactionID, rev.UserID, rev.Action.ToModelValue(), before, after)
+	s.Require().NoError(err)
+}
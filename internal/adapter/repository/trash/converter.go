@@ -0,0 +1,23 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+func cvtToDomainTrashItem(item TrashItem) domain.TrashItem {
+	var date *time.Time
+	if item.Date.Valid {
+		date = &item.Date.Time
+	}
+
+	return domain.TrashItem{
+		Kind:      domain.CvtToTrashKind(item.Kind),
+		ID:        item.ID,
+		Name:      item.Name,
+		Price:     item.Price,
+		Date:      date,
+		DeletedAt: item.DeletedAt,
+	}
+}
@@ -0,0 +1,277 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errorutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName                = "adapter/repository/trash"
+	uniqueNameUserType         = "main_categories.unique_name_user_type"
+	uniqueNameUserMainCategory = "sub_categories.unique_name_user_maincategory"
+
+	// transCategInTrash checks if any category of transaction t, including the categories of its split lines, is in trash
+	transCategInTrash = `(EXISTS (SELECT 1 FROM main_categories AS mc
+			WHERE mc.deleted_at IS NOT NULL
+			AND (mc.id = t.main_category_id OR mc.id IN (SELECT ts.main_category_id FROM transaction_splits AS ts WHERE ts.transaction_id = t.id)))
+		OR EXISTS (SELECT 1 FROM sub_categories AS sc
+			WHERE sc.deleted_at IS NOT NULL
+			AND (sc.id = t.sub_category_id OR sc.id IN (SELECT ts.sub_category_id FROM transaction_splits AS ts WHERE ts.transaction_id = t.id))))`
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+type TrashItem struct {
+	Kind      string
+	ID        int64
+	Name      string
+	Price     float64
+	Date      sql.NullTime
+	DeletedAt time.Time
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.TrashItem, error) {
+	// items whose category is also in trash are left out, they come back when the category is restored
+	qStmt := `
+		SELECT 'transaction' AS kind, t.id, COALESCE(t.note, ''), t.price, t.date, t.deleted_at
+		FROM transactions AS t
+		WHERE t.user_id = ?
+		AND t.deleted_at IS NOT NULL
+		AND NOT ` + transCategInTrash + `
+		UNION ALL
+		SELECT 'main-category', mc.id, mc.name, 0, NULL, mc.deleted_at
+		FROM main_categories AS mc
+		WHERE mc.user_id = ?
+		AND mc.deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'sub-category', sc.id, sc.name, 0, NULL, sc.deleted_at
+		FROM sub_categories AS sc
+		INNER JOIN main_categories AS mc
+		ON sc.main_category_id = mc.id
+		WHERE sc.user_id = ?
+		AND sc.deleted_at IS NOT NULL
+		AND mc.deleted_at IS NULL
+		ORDER BY deleted_at DESC, id DESC
+	`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID, userID, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var items []domain.TrashItem
+	for rows.Next() {
+		var item TrashItem
+		if err := rows.Scan(&item.Kind, &item.ID, &item.Name, &item.Price, &item.Date, &item.DeletedAt); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		items = append(items, cvtToDomainTrashItem(item))
+	}
+
+	return items, nil
+}
+
+func (r *Repo) Restore(ctx context.Context, kind domain.TrashKind, id, userID int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	switch kind {
+	case domain.TrashKindTransaction:
+		err = restoreTrans(ctx, tx, id, userID)
+	case domain.TrashKindMainCateg:
+		err = restoreMainCateg(ctx, tx, id, userID)
+	case domain.TrashKindSubCateg:
+		err = restoreSubCateg(ctx, tx, id, userID)
+	default:
+		err = domain.ErrTrashItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Purge(ctx context.Context, before time.Time) error {
+	// the categories are deleted after the transactions, so ON DELETE CASCADE never removes a transaction which is not in trash
+	stmts := []string{
+		"DELETE FROM transactions WHERE deleted_at < ?",
+		"DELETE FROM sub_categories WHERE deleted_at < ?",
+		"DELETE FROM main_categories WHERE deleted_at < ?",
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("r.DB.BeginTx failed", "package", packageName, "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("tx.Rollback failed", "package", packageName, "err", err)
+		}
+	}()
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt, before); err != nil {
+			logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("tx.Commit failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func restoreTrans(ctx context.Context, tx *sql.Tx, id, userID int64) error {
+	qStmt := `SELECT ` + transCategInTrash + `
+						FROM transactions AS t
+						WHERE t.id = ? AND t.user_id = ? AND t.deleted_at IS NOT NULL
+						FOR UPDATE`
+
+	var categInTrash bool
+	if err := tx.QueryRowContext(ctx, qStmt, id, userID).Scan(&categInTrash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrTrashItemNotFound
+		}
+
+		logger.Error("tx.QueryRowContext failed", "package", packageName, "err", err)
+		return err
+	}
+	if categInTrash {
+		return domain.ErrTrashParentInTrash
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE transactions SET deleted_at = NULL WHERE id = ?", id); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// restoreMainCateg restores the main category, and the sub categories and transactions moved to trash along with it
+func restoreMainCateg(ctx context.Context, tx *sql.Tx, id, userID int64) error {
+	qStmt := `SELECT deleted_at
+						FROM main_categories
+						WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
+						FOR UPDATE`
+
+	var deletedAt time.Time
+	if err := tx.QueryRowContext(ctx, qStmt, id, userID).Scan(&deletedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrTrashItemNotFound
+		}
+
+		logger.Error("tx.QueryRowContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	transStmt := `UPDATE transactions
+								SET deleted_at = NULL
+								WHERE (main_category_id = ? OR id IN (SELECT transaction_id FROM transaction_splits WHERE main_category_id = ?))
+								AND deleted_at = ?`
+	if _, err := tx.ExecContext(ctx, transStmt, id, id, deletedAt); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	subCategStmt := `UPDATE sub_categories SET deleted_at = NULL WHERE main_category_id = ? AND deleted_at = ?`
+	if _, err := tx.ExecContext(ctx, subCategStmt, id, deletedAt); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	// the name may be used by a live category created after the category was moved to trash
+	if _, err := tx.ExecContext(ctx, "UPDATE main_categories SET deleted_at = NULL WHERE id = ?", id); err != nil {
+		if errorutil.ParseError(err, uniqueNameUserType) {
+			return domain.ErrUniqueNameUserType
+		}
+
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// restoreSubCateg restores the sub category, and the transactions moved to trash along with it
+func restoreSubCateg(ctx context.Context, tx *sql.Tx, id, userID int64) error {
+	qStmt := `SELECT sc.deleted_at, mc.deleted_at IS NOT NULL
+						FROM sub_categories AS sc
+						INNER JOIN main_categories AS mc
+						ON sc.main_category_id = mc.id
+						WHERE sc.id = ? AND sc.user_id = ? AND sc.deleted_at IS NOT NULL
+						FOR UPDATE`
+
+	var deletedAt time.Time
+	var mainCategInTrash bool
+	if err := tx.QueryRowContext(ctx, qStmt, id, userID).Scan(&deletedAt, &mainCategInTrash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrTrashItemNotFound
+		}
+
+		logger.Error("tx.QueryRowContext failed", "package", packageName, "err", err)
+		return err
+	}
+	if mainCategInTrash {
+		return domain.ErrTrashParentInTrash
+	}
+
+	transStmt := `UPDATE transactions
+								SET deleted_at = NULL
+								WHERE (sub_category_id = ? OR id IN (SELECT transaction_id FROM transaction_splits WHERE sub_category_id = ?))
+								AND deleted_at = ?`
+	if _, err := tx.ExecContext(ctx, transStmt, id, id, deletedAt); err != nil {
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	// the name may be used by a live category created after the category was moved to trash
+	if _, err := tx.ExecContext(ctx, "UPDATE sub_categories SET deleted_at = NULL WHERE id = ?", id); err != nil {
+		if errorutil.ParseError(err, uniqueNameUserMainCategory) {
+			return domain.ErrUniqueNameUserMainCateg
+		}
+
+		logger.Error("tx.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,312 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/transaction"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX       = context.Background()
+	mockDeletedAt = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+)
+
+type TrashSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *transaction.TransactionFactory
+}
+
+func TestTrashSuite(t *testing.T) {
+	suite.Run(t, new(TrashSuite))
+}
+
+func (s *TrashSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = transaction.NewTransactionFactory(s.db)
+}
+
+func (s *TrashSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *TrashSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = transaction.NewTransactionFactory(s.db)
+}
+
+func (s *TrashSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"transactions", "sub_categories", "main_categories", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *TrashSuite) TestGetAll() {
+	for scenario, fn := range map[string]func(s *TrashSuite, desc string){
+		"when items in trash, return them by deleted time":       getAll_ItemsInTrash_ReturnByDeletedTime,
+		"when category in trash, leave out its items":            getAll_CategInTrash_LeaveOutItsItems,
+		"when other user has items in trash, return only user's": getAll_OtherUserHasItems_ReturnOnlyUsers,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getAll_ItemsInTrash_ReturnByDeletedTime(s *TrashSuite, desc string) {
+	trans, user, mainCategs, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, desc)
+
+	s.moveToTrash("transactions", trans[0].ID, mockDeletedAt)
+	s.moveToTrash("sub_categories", subCategs[1].ID, mockDeletedAt.Add(time.Hour))
+	s.moveToTrash("main_categories", mainCategs[2].ID, mockDeletedAt.Add(2*time.Hour))
+
+	transDate := trans[0].Date.Truncate(24 * time.Hour)
+	expResult := []domain.TrashItem{
+		{Kind: domain.TrashKindMainCateg, ID: mainCategs[2].ID, Name: mainCategs[2].Name, DeletedAt: mockDeletedAt.Add(2 * time.Hour)},
+		{Kind: domain.TrashKindSubCateg, ID: subCategs[1].ID, Name: subCategs[1].Name, DeletedAt: mockDeletedAt.Add(time.Hour)},
+		{Kind: domain.TrashKindTransaction, ID: trans[0].ID, Name: trans[0].Note, Price: trans[0].Price, Date: &transDate, DeletedAt: mockDeletedAt},
+	}
+
+	result, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getAll_CategInTrash_LeaveOutItsItems(s *TrashSuite, desc string) {
+	trans, user, mainCategs, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	s.moveToTrash("transactions", trans[0].ID, mockDeletedAt)
+	s.moveToTrash("sub_categories", subCategs[0].ID, mockDeletedAt)
+	s.moveToTrash("main_categories", mainCategs[0].ID, mockDeletedAt)
+
+	expResult := []domain.TrashItem{
+		{Kind: domain.TrashKindMainCateg, ID: mainCategs[0].ID, Name: mainCategs[0].Name, DeletedAt: mockDeletedAt},
+	}
+
+	result, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getAll_OtherUserHasItems_ReturnOnlyUsers(s *TrashSuite, desc string) {
+	_, user, mainCategs, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("main_categories", mainCategs[0].ID, mockDeletedAt)
+
+	trans2, _, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("transactions", trans2[0].ID, mockDeletedAt)
+
+	result, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(result, 1, desc)
+	s.Require().Equal(mainCategs[0].ID, result[0].ID, desc)
+}
+
+func (s *TrashSuite) TestRestore() {
+	for scenario, fn := range map[string]func(s *TrashSuite, desc string){
+		"when transaction in trash, restore it":                            restore_TransInTrash_RestoreIt,
+		"when category of transaction in trash, return error":              restore_CategOfTransInTrash_ReturnError,
+		"when main category in trash, restore items deleted with it":       restore_MainCategInTrash_RestoreItemsDeletedWithIt,
+		"when sub category in trash, restore transactions deleted with it": restore_SubCategInTrash_RestoreTransDeletedWithIt,
+		"when main category of sub category in trash, return error":        restore_MainCategOfSubCategInTrash_ReturnError,
+		"when item not in trash, return error":                             restore_ItemNotInTrash_ReturnError,
+		"when item of other user, return error":                            restore_ItemOfOtherUser_ReturnError,
+		"when name of main category used again, return error":              restore_MainCategNameUsedAgain_ReturnError,
+		"when name of sub category used again, return error":               restore_SubCategNameUsedAgain_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func restore_TransInTrash_RestoreIt(s *TrashSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("transactions", trans[0].ID, mockDeletedAt)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindTransaction, trans[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().False(s.inTrash("transactions", trans[0].ID), desc)
+}
+
+func restore_CategOfTransInTrash_ReturnError(s *TrashSuite, desc string) {
+	trans, user, _, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("transactions", trans[0].ID, mockDeletedAt)
+	s.moveToTrash("sub_categories", subCategs[0].ID, mockDeletedAt)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindTransaction, trans[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrTrashParentInTrash, desc)
+	s.Require().True(s.inTrash("transactions", trans[0].ID), desc)
+}
+
+func restore_MainCategInTrash_RestoreItemsDeletedWithIt(s *TrashSuite, desc string) {
+	trans, user, mainCategs, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	// the transaction deleted before the main category stays in trash
+	trans2, _, err := s.f.InsertTransactionWithGivenUser(mockCTX, 1, user, transaction.Transaction{MainCategID: mainCategs[0].ID})
+	s.Require().NoError(err, desc)
+	s.moveToTrash("transactions", trans2[0].ID, mockDeletedAt.Add(-time.Hour))
+
+	s.moveToTrash("transactions", trans[0].ID, mockDeletedAt)
+	s.moveToTrash("sub_categories", subCategs[0].ID, mockDeletedAt)
+	s.moveToTrash("main_categories", mainCategs[0].ID, mockDeletedAt)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindMainCateg, mainCategs[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().False(s.inTrash("main_categories", mainCategs[0].ID), desc)
+	s.Require().False(s.inTrash("sub_categories", subCategs[0].ID), desc)
+	s.Require().False(s.inTrash("transactions", trans[0].ID), desc)
+	s.Require().True(s.inTrash("transactions", trans2[0].ID), desc)
+}
+
+func restore_SubCategInTrash_RestoreTransDeletedWithIt(s *TrashSuite, desc string) {
+	trans, user, _, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("transactions", trans[0].ID, mockDeletedAt)
+	s.moveToTrash("sub_categories", subCategs[0].ID, mockDeletedAt)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindSubCateg, subCategs[0].ID, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().False(s.inTrash("sub_categories", subCategs[0].ID), desc)
+	s.Require().False(s.inTrash("transactions", trans[0].ID), desc)
+}
+
+func restore_MainCategOfSubCategInTrash_ReturnError(s *TrashSuite, desc string) {
+	_, user, mainCategs, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("sub_categories", subCategs[0].ID, mockDeletedAt)
+	s.moveToTrash("main_categories", mainCategs[0].ID, mockDeletedAt)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindSubCateg, subCategs[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrTrashParentInTrash, desc)
+}
+
+func restore_ItemNotInTrash_ReturnError(s *TrashSuite, desc string) {
+	trans, user, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindTransaction, trans[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrTrashItemNotFound, desc)
+}
+
+func restore_ItemOfOtherUser_ReturnError(s *TrashSuite, desc string) {
+	_, _, mainCategs, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("main_categories", mainCategs[0].ID, mockDeletedAt)
+
+	_, user2, _, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindMainCateg, mainCategs[0].ID, user2.ID)
+	s.Require().ErrorIs(err, domain.ErrTrashItemNotFound, desc)
+	s.Require().True(s.inTrash("main_categories", mainCategs[0].ID), desc)
+}
+
+func restore_MainCategNameUsedAgain_ReturnError(s *TrashSuite, desc string) {
+	_, user, mainCategs, _, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("main_categories", mainCategs[0].ID, mockDeletedAt)
+
+	// the name is only unique among the live categories, so it can be used while the category is in trash
+	_, err = s.db.Exec("INSERT INTO main_categories (name, type, user_id, icon_type, icon_data) VALUES (?, ?, ?, ?, ?)",
+		mainCategs[0].Name, mainCategs[0].Type, user.ID, mainCategs[0].IconType, mainCategs[0].IconData)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindMainCateg, mainCategs[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUserType, desc)
+	s.Require().True(s.inTrash("main_categories", mainCategs[0].ID), desc)
+}
+
+func restore_SubCategNameUsedAgain_ReturnError(s *TrashSuite, desc string) {
+	_, user, mainCategs, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.moveToTrash("sub_categories", subCategs[0].ID, mockDeletedAt)
+
+	_, err = s.db.Exec("INSERT INTO sub_categories (name, user_id, main_category_id) VALUES (?, ?, ?)", subCategs[0].Name, user.ID, mainCategs[0].ID)
+	s.Require().NoError(err, desc)
+
+	err = s.repo.Restore(mockCTX, domain.TrashKindSubCateg, subCategs[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUserMainCateg, desc)
+	s.Require().True(s.inTrash("sub_categories", subCategs[0].ID), desc)
+}
+
+func (s *TrashSuite) TestPurge() {
+	trans, _, mainCategs, subCategs, err := s.f.InsertTransactionsWithOneUser(mockCTX, 3)
+	s.Require().NoError(err, "test purge")
+
+	// the first transaction and the second main category are out of the retention period
+	s.moveToTrash("transactions", trans[0].ID, mockDeletedAt.Add(-time.Hour))
+	s.moveToTrash("transactions", trans[1].ID, mockDeletedAt.Add(-time.Hour))
+	s.moveToTrash("sub_categories", subCategs[1].ID, mockDeletedAt.Add(-time.Hour))
+	s.moveToTrash("main_categories", mainCategs[1].ID, mockDeletedAt.Add(-time.Hour))
+	s.moveToTrash("main_categories", mainCategs[2].ID, mockDeletedAt.Add(time.Hour))
+
+	err = s.repo.Purge(mockCTX, mockDeletedAt)
+	s.Require().NoError(err, "test purge")
+
+	for table, expCount := range map[string]int{"transactions": 1, "sub_categories": 2, "main_categories": 2} {
+		var count int
+		err = s.db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
+		s.Require().NoError(err, "test purge")
+		s.Require().Equal(expCount, count, "test purge: "+table)
+	}
+
+	s.Require().True(s.inTrash("main_categories", mainCategs[2].ID), "test purge")
+}
+
+func (s *TrashSuite) moveToTrash(table string, id int64, deletedAt time.Time) {
+	_, err := s.db.Exec("UPDATE "+table+" SET deleted_at = ? WHERE id = ?", deletedAt, id)
+	s.Require().NoError(err)
+}
+
+func (s *TrashSuite) inTrash(table string, id int64) bool {
+	var inTrash bool
+	err := s.db.QueryRow("SELECT deleted_at IS NOT NULL FROM "+table+" WHERE id = ?", id).Scan(&inTrash)
+	s.Require().NoError(err)
+	return inTrash
+}
@@ -12,12 +12,29 @@ func genUpdateStmtAndVal(opt domain.UpdateUserOpt, userID int64) (string, []inte
 	var sb strings.Builder
 	sb.WriteString(`UPDATE users SET `)
 
+	var sets []string
 	if opt.IsSetInitCategory != nil {
-		sb.WriteString(`is_set_init_category = ? `)
+		sets = append(sets, `is_set_init_category = ?`)
 		val = append(val, *opt.IsSetInitCategory)
 	}
 
-	sb.WriteString(`WHERE id = ?`)
+	if opt.BaseCurrency != nil {
+		sets = append(sets, `base_currency = ?`)
+		val = append(val, *opt.BaseCurrency)
+	}
+
+	if opt.PasswordHash != nil {
+		sets = append(sets, `password_hash = ?`)
+		val = append(val, *opt.PasswordHash)
+	}
+
+	if opt.IsEmailVerified != nil {
+		sets = append(sets, `email_verified_at = IF(?, CURRENT_TIMESTAMP, NULL)`)
+		val = append(val, *opt.IsEmailVerified)
+	}
+
+	sb.WriteString(strings.Join(sets, ", "))
+	sb.WriteString(` WHERE id = ?`)
 	val = append(val, userID)
 	return sb.String(), val
 }
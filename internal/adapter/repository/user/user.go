@@ -25,11 +25,54 @@ type User struct {
 	Password_hash     string `json:"password_hash"`
 }
 
+// Create inserts a user along with the personal ledger, which is owned by the user
 func (r *Repo) Create(name, email, passwordHash string) error {
 	stmt := `INSERT INTO users (name, email, password_hash) VALUES (?, ?, ?)`
+	ledgerStmt := `INSERT INTO ledgers (name, user_id, is_personal) VALUES ('Personal', ?, TRUE)`
+	memberStmt := `INSERT INTO ledger_members (ledger_id, user_id, role) VALUES (?, ?, ?)`
 
-	if _, err := r.DB.Exec(stmt, name, email, passwordHash); err != nil {
-		logger.Error("users INSERT r.DB.Exec", "err", err)
+	tx, err := r.DB.Begin()
+	if err != nil {
+		logger.Error("users r.DB.Begin", "err", err)
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("users tx.Rollback", "err", err)
+		}
+	}()
+
+	res, err := tx.Exec(stmt, name, email, passwordHash)
+	if err != nil {
+		logger.Error("users INSERT tx.Exec", "err", err)
+		return err
+	}
+
+	userID, err := res.LastInsertId()
+	if err != nil {
+		logger.Error("users res.LastInsertId", "err", err)
+		return err
+	}
+
+	res, err = tx.Exec(ledgerStmt, userID)
+	if err != nil {
+		logger.Error("ledgers INSERT tx.Exec", "err", err)
+		return err
+	}
+
+	ledgerID, err := res.LastInsertId()
+	if err != nil {
+		logger.Error("ledgers res.LastInsertId", "err", err)
+		return err
+	}
+
+	if _, err := tx.Exec(memberStmt, ledgerID, userID, domain.LedgerRoleTypeOwner.ToModelValue()); err != nil {
+		logger.Error("ledger_members INSERT tx.Exec", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("users tx.Commit", "err", err)
 		return err
 	}
 
@@ -53,10 +96,14 @@ func (r *Repo) FindByEmail(email string) (domain.User, error) {
 }
 
 func (r *Repo) GetInfo(userID int64) (domain.User, error) {
-	stmt := `SELECT id, name, email, is_set_init_category FROM users WHERE id = ?`
+	stmt := `SELECT id, name, COALESCE(email, ''), is_set_init_category, base_currency, email_verified_at IS NOT NULL FROM users WHERE id = ?`
 
+	// base currency and email verification are not part of the User model,
+	// so that the factories keep inserting users with the default values
 	var user User
-	if err := r.DB.QueryRow(stmt, userID).Scan(&user.ID, &user.Name, &user.Email, &user.IsSetInitCategory); err != nil {
+	var baseCurrency string
+	var isEmailVerified bool
+	if err := r.DB.QueryRow(stmt, userID).Scan(&user.ID, &user.Name, &user.Email, &user.IsSetInitCategory, &baseCurrency, &isEmailVerified); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.User{}, domain.ErrUserIDNotFound
 		}
@@ -65,7 +112,10 @@ func (r *Repo) GetInfo(userID int64) (domain.User, error) {
 		return domain.User{}, err
 	}
 
-	return cvtToDomainUser(user), nil
+	u := cvtToDomainUser(user)
+	u.BaseCurrency = baseCurrency
+	u.IsEmailVerified = isEmailVerified
+	return u, nil
 }
 
 func (r *Repo) Update(ctx context.Context, userID int64, opt domain.UpdateUserOpt) error {
@@ -80,6 +80,18 @@ func (s *UserSuite) TestCreate() {
 	s.Require().Equal(user.Name, checkedUser.Name)
 	s.Require().Equal(user.Email, checkedUser.Email)
 	s.Require().Equal(user.Password_hash, checkedUser.Password_hash)
+
+	// check if personal ledger is created with the user as owner
+	var isPersonal bool
+	var role string
+	err = s.db.QueryRow(`SELECT l.is_personal, lm.role
+											 FROM ledgers AS l
+											 INNER JOIN ledger_members AS lm ON lm.ledger_id = l.id AND lm.user_id = l.user_id
+											 INNER JOIN users AS u ON u.id = l.user_id
+											 WHERE u.email = ?`, user.Email).Scan(&isPersonal, &role)
+	s.Require().NoError(err)
+	s.Require().True(isPersonal)
+	s.Require().Equal(domain.LedgerRoleTypeOwner.ToModelValue(), role)
 }
 
 func (s *UserSuite) TestFindByEmail() {
@@ -142,6 +154,7 @@ func getInfo_FoundUser_ReturnSuccessfully(s *UserSuite, desc string) {
 		Name:              users[0].Name,
 		Email:             users[0].Email,
 		IsSetInitCategory: users[0].IsSetInitCategory,
+		BaseCurrency:      domain.DefaultCurrency,
 	}
 
 	user, err := s.repo.GetInfo(users[0].ID)
@@ -161,6 +174,9 @@ func getInfo_NotFound_ReturnError(s *UserSuite, desc string) {
 func (s *UserSuite) TestUpdate() {
 	for scenario, fn := range map[string]func(s *UserSuite, desc string){
 		"when is_set_init_category is set, update successfully": update_IsSetInitCategory_UpdateSuccessfully,
+		"when base_currency is set, update successfully":        update_BaseCurrency_UpdateSuccessfully,
+		"when password_hash is set, update successfully":        update_PasswordHash_UpdateSuccessfully,
+		"when is_email_verified is set, update successfully":    update_IsEmailVerified_UpdateSuccessfully,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -200,3 +216,68 @@ func update_IsSetInitCategory_UpdateSuccessfully(s *UserSuite, desc string) {
 	s.Require().Equal(users[1].Name, checkedUser2.Name, desc)
 	s.Require().Equal(users[1].Email, checkedUser2.Email, desc)
 }
+
+func update_BaseCurrency_UpdateSuccessfully(s *UserSuite, desc string) {
+	// prepare mock data
+	users, err := s.f.BuildList(mockCTX, 2).Insert()
+	s.Require().NoError(err, desc)
+
+	// prepare update option
+	currency := "EUR"
+	opt := domain.UpdateUserOpt{BaseCurrency: &currency}
+
+	// action
+	err = s.repo.Update(mockCTX, users[0].ID, opt)
+	s.Require().NoError(err, desc)
+
+	// check if only the user is updated
+	user, err := s.repo.GetInfo(users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(currency, user.BaseCurrency, desc)
+
+	user2, err := s.repo.GetInfo(users[1].ID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.DefaultCurrency, user2.BaseCurrency, desc)
+}
+
+func update_PasswordHash_UpdateSuccessfully(s *UserSuite, desc string) {
+	// prepare mock data
+	users, err := s.f.BuildList(mockCTX, 1).Insert()
+	s.Require().NoError(err, desc)
+
+	// prepare update option
+	passwordHash := "new-hash"
+	opt := domain.UpdateUserOpt{PasswordHash: &passwordHash}
+
+	// action
+	err = s.repo.Update(mockCTX, users[0].ID, opt)
+	s.Require().NoError(err, desc)
+
+	// check if user is updated
+	user, err := s.repo.FindByEmail(users[0].Email)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(passwordHash, user.Password_hash, desc)
+}
+
+func update_IsEmailVerified_UpdateSuccessfully(s *UserSuite, desc string) {
+	// prepare mock data
+	users, err := s.f.BuildList(mockCTX, 2).Insert()
+	s.Require().NoError(err, desc)
+
+	// prepare update option
+	t := true
+	opt := domain.UpdateUserOpt{IsEmailVerified: &t}
+
+	// action
+	err = s.repo.Update(mockCTX, users[0].ID, opt)
+	s.Require().NoError(err, desc)
+
+	// check if only the user is verified
+	user, err := s.repo.GetInfo(users[0].ID)
+	s.Require().NoError(err, desc)
+	s.Require().True(user.IsEmailVerified, desc)
+
+	user2, err := s.repo.GetInfo(users[1].ID)
+	s.Require().NoError(err, desc)
+	s.Require().False(user2.IsEmailVerified, desc)
+}
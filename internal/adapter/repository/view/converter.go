@@ -0,0 +1,47 @@
+package view
+
+import (
+	"encoding/json"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+// viewOpt is the stored JSON of the filter, sort and search of view
+type viewOpt struct {
+	Filter domain.Filter `json:"filter"`
+	Sort   *domain.Sort  `json:"sort"`
+	Search domain.Search `json:"search"`
+}
+
+func cvtToModelView(v domain.View, userID int64) (View, error) {
+	opt, err := json.Marshal(viewOpt{
+		Filter: v.Filter,
+		Sort:   v.Sort,
+		Search: v.Search,
+	})
+	if err != nil {
+		return View{}, err
+	}
+
+	return View{
+		ID:     v.ID,
+		UserID: userID,
+		Name:   v.Name,
+		Opt:    opt,
+	}, nil
+}
+
+func cvtToDomainView(v View) (domain.View, error) {
+	var opt viewOpt
+	if err := json.Unmarshal(v.Opt, &opt); err != nil {
+		return domain.View{}, err
+	}
+
+	return domain.View{
+		ID:     v.ID,
+		Name:   v.Name,
+		Filter: opt.Filter,
+		Sort:   opt.Sort,
+		Search: opt.Search,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/repository/user"
+	"github.com/eyo-chen/gofacto"
+	"github.com/eyo-chen/gofacto/db/mysqlf"
+)
+
+type factory struct {
+	user *gofacto.Factory[user.User]
+}
+
+func newFactory(db *sql.DB) *factory {
+	return &factory{
+		user: gofacto.New(user.User{}).WithDB(mysqlf.NewConfig(db)),
+	}
+}
+
+// InsertUser inserts a user, the views are inserted by the repo because the option is JSON
+func (f *factory) InsertUser(ctx context.Context) (user.User, error) {
+	return f.user.Build(ctx).Insert()
+}
+
+func (f *factory) Reset() {
+	f.user.Reset()
+}
@@ -0,0 +1,146 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errorutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	uniqueNameUser = "views.unique_name_user"
+	packageName    = "adapter/repository/view"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+// View is the model of view, Opt is the JSON of the filter, sort and search
+type View struct {
+	ID     int64
+	UserID int64
+	Name   string
+	Opt    []byte
+}
+
+func New(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+func (r *Repo) Create(ctx context.Context, view domain.View, userID int64) error {
+	qStmt := "INSERT INTO views (user_id, name, opt) VALUES (?, ?, ?)"
+
+	v, err := cvtToModelView(view, userID)
+	if err != nil {
+		logger.Error("cvtToModelView failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, v.UserID, v.Name, v.Opt); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueViewNameUser
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetAll(ctx context.Context, userID int64) ([]domain.View, error) {
+	qStmt := `SELECT id, name, opt
+						FROM views
+						WHERE user_id = ?
+						ORDER BY id`
+
+	rows, err := r.DB.QueryContext(ctx, qStmt, userID)
+	if err != nil {
+		logger.Error("r.DB.QueryContext failed", "package", packageName, "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Error("Unable to close rows", "package", packageName, "err", err)
+		}
+	}()
+
+	var views []domain.View
+	for rows.Next() {
+		var v View
+		if err := rows.Scan(&v.ID, &v.Name, &v.Opt); err != nil {
+			logger.Error("rows.Scan failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		view, err := cvtToDomainView(v)
+		if err != nil {
+			logger.Error("cvtToDomainView failed", "package", packageName, "err", err)
+			return nil, err
+		}
+
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+func (r *Repo) GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.View, error) {
+	qStmt := `SELECT id, name, opt
+						FROM views
+						WHERE id = ? AND user_id = ?`
+
+	var v View
+	if err := r.DB.QueryRowContext(ctx, qStmt, id, userID).Scan(&v.ID, &v.Name, &v.Opt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.View{}, domain.ErrViewNotFound
+		}
+
+		logger.Error("r.DB.QueryRowContext failed", "package", packageName, "err", err)
+		return domain.View{}, err
+	}
+
+	view, err := cvtToDomainView(v)
+	if err != nil {
+		logger.Error("cvtToDomainView failed", "package", packageName, "err", err)
+		return domain.View{}, err
+	}
+
+	return view, nil
+}
+
+func (r *Repo) Update(ctx context.Context, view domain.View) error {
+	qStmt := "UPDATE views SET name = ?, opt = ? WHERE id = ?"
+
+	v, err := cvtToModelView(view, 0)
+	if err != nil {
+		logger.Error("cvtToModelView failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, v.Name, v.Opt, v.ID); err != nil {
+		if errorutil.ParseError(err, uniqueNameUser) {
+			return domain.ErrUniqueViewNameUser
+		}
+
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	qStmt := "DELETE FROM views WHERE id = ?"
+
+	if _, err := r.DB.ExecContext(ctx, qStmt, id); err != nil {
+		logger.Error("r.DB.ExecContext failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
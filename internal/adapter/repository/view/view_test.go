@@ -0,0 +1,193 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/dockerutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-migrate/migrate"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type ViewSuite struct {
+	suite.Suite
+	dk      *dockerutil.Container
+	db      *sql.DB
+	migrate *migrate.Migrate
+	repo    *Repo
+	f       *factory
+}
+
+func TestViewSuite(t *testing.T) {
+	suite.Run(t, new(ViewSuite))
+}
+
+func (s *ViewSuite) SetupSuite() {
+	s.dk = dockerutil.RunDocker(dockerutil.ImageMySQL)
+	db, migrate := testutil.ConnToDB(s.dk.Port)
+	logger.Register()
+
+	s.db = db
+	s.migrate = migrate
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *ViewSuite) TearDownSuite() {
+	if err := s.db.Close(); err != nil {
+		logger.Error("Unable to close mysql database", "error", err)
+	}
+	s.migrate.Close()
+	s.dk.PurgeDocker()
+}
+
+func (s *ViewSuite) SetupTest() {
+	s.repo = New(s.db)
+	s.f = newFactory(s.db)
+}
+
+func (s *ViewSuite) TearDownTest() {
+	tx, err := s.db.Begin()
+	s.Require().NoError(err)
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Require().NoError(err)
+		}
+	}()
+
+	for _, table := range []string{"views", "users"} {
+		_, err = tx.Exec("DELETE FROM " + table)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(tx.Commit())
+	s.f.Reset()
+}
+
+func (s *ViewSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *ViewSuite, desc string){
+		"when no duplicate name, insert view with option": create_NoDuplicateName_InsertViewWithOpt,
+		"when duplicate name, return error":               create_DuplicateName_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoDuplicateName_InsertViewWithOpt(s *ViewSuite, desc string) {
+	user, err := s.f.InsertUser(mockCTX)
+	s.Require().NoError(err, desc)
+
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	minPrice := 50.0
+	view := domain.View{
+		Name: "dining this quarter",
+		Filter: domain.Filter{
+			StartDate:    &startDate,
+			MinPrice:     &minPrice,
+			MainCategIDs: []int64{1, 2},
+			TagMatch:     domain.TagMatchTypeAll,
+		},
+		Sort: &domain.Sort{By: domain.SortByTypePrice, Dir: domain.SortDirTypeDesc},
+		Search: domain.Search{
+			Query: &domain.SearchQuery{
+				Raw:    "-tag:work",
+				Tags:   []domain.SearchTerm{{Value: "work", Negated: true}},
+				Prices: []domain.SearchPriceCond{{Op: domain.CmpOpTypeGt, Value: 20}},
+			},
+		},
+	}
+
+	err = s.repo.Create(mockCTX, view, user.ID)
+	s.Require().NoError(err, desc)
+
+	views, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err, desc)
+	s.Require().Len(views, 1, desc)
+
+	// id is generated
+	view.ID = views[0].ID
+	s.Require().Equal([]domain.View{view}, views, desc)
+}
+
+func create_DuplicateName_ReturnError(s *ViewSuite, desc string) {
+	user, err := s.f.InsertUser(mockCTX)
+	s.Require().NoError(err, desc)
+
+	view := domain.View{Name: "dining"}
+	s.Require().NoError(s.repo.Create(mockCTX, view, user.ID), desc)
+
+	err = s.repo.Create(mockCTX, view, user.ID)
+	s.Require().ErrorIs(err, domain.ErrUniqueViewNameUser, desc)
+}
+
+func (s *ViewSuite) TestGetByIDAndUserID() {
+	user, err := s.f.InsertUser(mockCTX)
+	s.Require().NoError(err)
+
+	user2, err := s.f.InsertUser(mockCTX)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.repo.Create(mockCTX, domain.View{Name: "dining"}, user.ID))
+
+	views, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+
+	view, err := s.repo.GetByIDAndUserID(mockCTX, views[0].ID, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(views[0], view)
+
+	// view of other user
+	_, err = s.repo.GetByIDAndUserID(mockCTX, views[0].ID, user2.ID)
+	s.Require().ErrorIs(err, domain.ErrViewNotFound)
+}
+
+func (s *ViewSuite) TestUpdate() {
+	user, err := s.f.InsertUser(mockCTX)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.repo.Create(mockCTX, domain.View{Name: "dining"}, user.ID))
+
+	views, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+
+	keyword := "lunch"
+	view := domain.View{
+		ID:     views[0].ID,
+		Name:   "lunch",
+		Search: domain.Search{Keyword: &keyword},
+	}
+	s.Require().NoError(s.repo.Update(mockCTX, view))
+
+	views, err = s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+	s.Require().Equal([]domain.View{view}, views)
+}
+
+func (s *ViewSuite) TestDelete() {
+	user, err := s.f.InsertUser(mockCTX)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.repo.Create(mockCTX, domain.View{Name: "dining"}, user.ID))
+
+	views, err := s.repo.GetAll(mockCTX, user.ID)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.repo.Delete(mockCTX, views[0].ID))
+
+	_, err = s.repo.GetByIDAndUserID(mockCTX, views[0].ID, user.ID)
+	s.Require().ErrorIs(err, domain.ErrViewNotFound)
+}
@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+/*
+The file client is for local development, so the mails can be read without a SMTP server.
+The messages are appended to the file, or logged when the path is empty.
+*/
+
+type fileClient struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileClient initializes a new file client.
+func NewFileClient(path string) interfaces.MailClient {
+	return &fileClient{path: path}
+}
+
+// SendMail appends the message to the file, or logs it when the path is empty.
+func (c *fileClient) SendMail(ctx context.Context, from string, to []string, msg []byte) error {
+	if c.path == "" {
+		logger.Info("Mail sent", "package", packageName, "from", from, "to", to, "msg", string(msg))
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(msg, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "adapter/service/mailer"
+)
+
+type Service struct {
+	From       string
+	MailClient interfaces.MailClient
+}
+
+// New initializes a new mail service, the mails are logged when the mail client is not provided.
+func New(from string, mailClient interfaces.MailClient) *Service {
+	if mailClient == nil {
+		mailClient = NewFileClient("")
+	}
+
+	return &Service{From: from, MailClient: mailClient}
+}
+
+func (s *Service) Send(ctx context.Context, mail domain.Mail) error {
+	if err := s.MailClient.SendMail(ctx, s.From, []string{mail.To}, genMessage(s.From, mail)); err != nil {
+		logger.Error("s.MailClient.SendMail failed", "package", packageName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// genMessage generates the plain text message with the headers
+func genMessage(from string, mail domain.Mail) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", from)
+	fmt.Fprintf(&sb, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&sb, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mail.Subject))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+	sb.WriteString("\r\n")
+
+	return []byte(sb.String())
+}
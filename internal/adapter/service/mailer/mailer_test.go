@@ -0,0 +1,97 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCTX  = context.Background()
+	mockFrom = "no-reply@a.com"
+)
+
+type mailerServiceSuite struct {
+	suite.Suite
+	service        *Service
+	mockMailClient *mocks.MailClient
+}
+
+func TestMailerServiceSuite(t *testing.T) {
+	suite.Run(t, new(mailerServiceSuite))
+}
+
+func (s *mailerServiceSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *mailerServiceSuite) SetupTest() {
+	s.mockMailClient = mocks.NewMailClient(s.T())
+	s.service = New(mockFrom, s.mockMailClient)
+}
+
+func (s *mailerServiceSuite) TearDownTest() {
+	s.mockMailClient.AssertExpectations(s.T())
+}
+
+func (s *mailerServiceSuite) TestSend() {
+	for scenario, fn := range map[string]func(s *mailerServiceSuite, desc string){
+		"when no error, send successfully": send_NoError_SendSuccessfully,
+		"when send failed, return error":   send_Error_ReturnError,
+		"when file client, append to file": send_FileClient_AppendToFile,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func send_NoError_SendSuccessfully(s *mailerServiceSuite, desc string) {
+	mail := domain.Mail{To: "a@a.com", Subject: "subject", Body: "line1\nline2"}
+	expMsg := "From: no-reply@a.com\r\n" +
+		"To: a@a.com\r\n" +
+		"Subject: subject\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=\"utf-8\"\r\n" +
+		"\r\n" +
+		"line1\r\nline2\r\n"
+
+	s.mockMailClient.On("SendMail", mockCTX, mockFrom, []string{"a@a.com"}, []byte(expMsg)).Return(nil).Once()
+
+	err := s.service.Send(mockCTX, mail)
+	s.Require().NoError(err, desc)
+}
+
+func send_Error_ReturnError(s *mailerServiceSuite, desc string) {
+	mail := domain.Mail{To: "a@a.com", Subject: "subject", Body: "body"}
+	mockErr := errors.New("send failed")
+
+	s.mockMailClient.On("SendMail", mockCTX, mockFrom, []string{"a@a.com"}, genMessage(mockFrom, mail)).Return(mockErr).Once()
+
+	err := s.service.Send(mockCTX, mail)
+	s.Require().ErrorIs(err, mockErr, desc)
+}
+
+func send_FileClient_AppendToFile(s *mailerServiceSuite, desc string) {
+	path := filepath.Join(s.T().TempDir(), "mails.log")
+	service := New(mockFrom, NewFileClient(path))
+
+	mail1 := domain.Mail{To: "a@a.com", Subject: "first", Body: "body"}
+	mail2 := domain.Mail{To: "b@b.com", Subject: "second", Body: "body"}
+	s.Require().NoError(service.Send(mockCTX, mail1), desc)
+	s.Require().NoError(service.Send(mockCTX, mail2), desc)
+
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(string(genMessage(mockFrom, mail1))+"\n"+string(genMessage(mockFrom, mail2))+"\n", string(content), desc)
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/adapter/interfaces"
+)
+
+type smtpClient struct {
+	addr string
+	auth smtp.Auth
+}
+
+// NewSMTPClient initializes a new SMTP client, the plain auth is skipped when the username is empty.
+func NewSMTPClient(host, port, username, password string) interfaces.MailClient {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpClient{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+	}
+}
+
+// SendMail sends the message through the SMTP server.
+func (c *smtpClient) SendMail(ctx context.Context, from string, to []string, msg []byte) error {
+	return smtp.SendMail(c.addr, c.auth, from, to, msg)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Account contains information of where the money lives, e.g. a wallet or a bank account
+type Account struct {
+	ID             int64       `json:"id"`
+	Name           string      `json:"name"`
+	Type           AccountType `json:"type"`
+	InitialBalance float64     `json:"initial_balance"`
+}
+
+// AccountEntry contains the amount moved in or out of an account by a transaction,
+// and the balance after the transaction
+type AccountEntry struct {
+	TransactionID int64           `json:"transaction_id"`
+	Type          TransactionType `json:"type"`
+	Date          time.Time       `json:"date"`
+	Note          string          `json:"note"`
+	Amount        float64         `json:"amount"`
+	Balance       float64         `json:"balance"`
+
+	// IsUnconverted is true when there's no exchange rate to the base currency,
+	// the amount is unknown, so it's 0 and left out of the balance
+	IsUnconverted bool `json:"is_unconverted"`
+}
+
+// AccountBalance contains the running balance of an account in the user's base currency
+type AccountBalance struct {
+	Account Account        `json:"account"`
+	Balance float64        `json:"balance"`
+	Entries []AccountEntry `json:"entries"`
+
+	// Unconverted is the number of entries left out of the balance, because there's no exchange rate
+	Unconverted int64 `json:"unconverted"`
+}
@@ -0,0 +1,66 @@
+package domain
+
+// AccountType is an enumeration of account types
+type AccountType int64
+
+const (
+	// AccountTypeUnSpecified is an enumeration of unspecified account type
+	AccountTypeUnSpecified AccountType = iota
+
+	// AccountTypeCash is an enumeration of cash account type
+	AccountTypeCash
+
+	// AccountTypeBank is an enumeration of bank account type
+	AccountTypeBank
+
+	// AccountTypeCreditCard is an enumeration of credit card account type
+	AccountTypeCreditCard
+)
+
+// IsValid checks if the account type is valid
+func (t AccountType) IsValid() bool {
+	switch t {
+	case AccountTypeCash, AccountTypeBank, AccountTypeCreditCard:
+		return true
+	}
+	return false
+}
+
+// ToString returns the string representation of the account type
+func (t AccountType) ToString() string {
+	switch t {
+	case AccountTypeCash:
+		return "cash"
+	case AccountTypeBank:
+		return "bank"
+	case AccountTypeCreditCard:
+		return "credit_card"
+	}
+	return "unknown account type"
+}
+
+// ToModelValue returns the string enum of mysql
+func (t AccountType) ToModelValue() string {
+	switch t {
+	case AccountTypeCash:
+		return "1"
+	case AccountTypeBank:
+		return "2"
+	case AccountTypeCreditCard:
+		return "3"
+	}
+	return "0"
+}
+
+// CvtToAccountType converts string to AccountType
+func CvtToAccountType(s string) AccountType {
+	switch s {
+	case "cash", "1":
+		return AccountTypeCash
+	case "bank", "2":
+		return AccountTypeBank
+	case "credit_card", "3":
+		return AccountTypeCreditCard
+	}
+	return AccountTypeUnSpecified
+}
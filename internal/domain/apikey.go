@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// ScopeTransactionsRead allows reading the transactions
+	ScopeTransactionsRead = "transactions:read"
+
+	// ScopeTransactionsWrite allows creating, updating and deleting the transactions
+	ScopeTransactionsWrite = "transactions:write"
+
+	// ScopeChartsRead allows reading the charts and the summaries of the transactions
+	ScopeChartsRead = "charts:read"
+
+	// ScopeCategoriesRead allows reading the main and sub categories
+	ScopeCategoriesRead = "categories:read"
+)
+
+// APIKeyScopes are all the scopes which can be granted to an API key
+var APIKeyScopes = []string{
+	ScopeTransactionsRead,
+	ScopeTransactionsWrite,
+	ScopeChartsRead,
+	ScopeCategoriesRead,
+}
+
+// APIKey contains the personal API key of the user for scripts and integrations,
+// Prefix is the beginning of the key, the key itself is never stored
+type APIKey struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Prefix     string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// IsExpired returns true if the API key has an expiry which is passed
+func (k APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// HasScope returns true if the scope is granted to the API key
+func (k APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AttachmentURLLifetime is how long the presigned URL to get an attachment is valid
+const AttachmentURLLifetime = 7 * 24 * time.Hour
+
+// AttachmentPutURL is the presigned URL to upload an attachment, along with the object key it's uploaded to
+type AttachmentPutURL struct {
+	URL       string `json:"url"`
+	ObjectKey string `json:"object_key"`
+}
+
+// TransAttachment is a receipt image or PDF of a transaction, which is uploaded to S3 with a presigned URL.
+// URL is the presigned URL to get the file, which is only filled when getting transactions.
+type TransAttachment struct {
+	ID            int64     `json:"id"`
+	TransactionID int64     `json:"transaction_id"`
+	UserID        int64     `json:"user_id"`
+	FileName      string    `json:"file_name"`
+	ObjectKey     string    `json:"object_key"`
+	URL           string    `json:"url"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// GenAttachmentObjectKey generates the S3 object key of the attachment.
+// The key has a random part, so that uploading a file with the same name never overwrites another one.
+func GenAttachmentObjectKey(userID, transID int64, fileName string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%s/%s", attachmentObjectKeyPrefix(userID, transID), hex.EncodeToString(b), fileName), nil
+}
+
+// IsAttachmentObjectKeyOf checks if the object key is generated for the file of the transaction
+func IsAttachmentObjectKeyOf(objectKey string, userID, transID int64, fileName string) bool {
+	random, ok := strings.CutPrefix(objectKey, attachmentObjectKeyPrefix(userID, transID))
+	if !ok {
+		return false
+	}
+
+	random, ok = strings.CutSuffix(random, "/"+fileName)
+	return ok && random != "" && !strings.Contains(random, "/")
+}
+
+func attachmentObjectKeyPrefix(userID, transID int64) string {
+	return fmt.Sprintf("receipts/%d/%d/", userID, transID)
+}
+
+// GenAttachmentCacheKey generates a cache key for the presigned URL of attachment
+func GenAttachmentCacheKey(objectKey string) string {
+	return fmt.Sprintf("attachment-%s", objectKey)
+}
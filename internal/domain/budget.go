@@ -0,0 +1,27 @@
+package domain
+
+// Budget contains monthly spending limit of an expense main category
+type Budget struct {
+	ID        int64     `json:"id"`
+	MainCateg MainCateg `json:"main_category"`
+	Amount    float64   `json:"amount"`
+}
+
+// BudgetStatus contains spent amount against the limit of a budget in a month
+type BudgetStatus struct {
+	Budget       Budget  `json:"budget"`
+	Spent        float64 `json:"spent"`
+	Remaining    float64 `json:"remaining"`
+	PercentUsed  float64 `json:"percent_used"`
+	IsOverBudget bool    `json:"is_over_budget"`
+	// Unconverted is the number of expenses left out of spent, because there's no exchange rate
+	Unconverted int64 `json:"unconverted"`
+}
+
+// MainCategSum contains summed price of transactions by main category
+type MainCategSum struct {
+	MainCateg MainCateg `json:"main_category"`
+	Sum       float64   `json:"sum"`
+	// Unconverted is the number of transactions left out of sum, because there's no exchange rate
+	Unconverted int64 `json:"unconverted"`
+}
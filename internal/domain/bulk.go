@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// BulkTransInput contains the operations on transactions which are applied together, either all of them or none of them
+type BulkTransInput struct {
+	Create []CreateTransactionInput `json:"create"`
+	Update []BulkUpdateTransInput   `json:"update"`
+	Delete BulkDeleteTransInput     `json:"delete"`
+}
+
+// BulkUpdateTransInput changes the given fields of a transaction, nil field is unchanged.
+// The main category and sub category are changed together.
+// AddTagIDs are put on the transaction, and its other tags are kept.
+type BulkUpdateTransInput struct {
+	ID          int64      `json:"id"`
+	MainCategID *int64     `json:"main_category_id"`
+	SubCategID  *int64     `json:"sub_category_id"`
+	Note        *string    `json:"note"`
+	Date        *time.Time `json:"date"`
+	AddTagIDs   []int64    `json:"add_tag_ids"`
+}
+
+// BulkDeleteTransInput selects the transactions to delete by ids, or by the filter and search of Opt when it's not nil
+type BulkDeleteTransInput struct {
+	IDs []int64      `json:"ids"`
+	Opt *GetTransOpt `json:"opt"`
+}
+
+// BulkTransResult contains the ids of created transactions, and the number of updated and deleted transactions
+type BulkTransResult struct {
+	CreatedIDs []int64 `json:"created_ids"`
+	Updated    int     `json:"updated"`
+	Deleted    int     `json:"deleted"`
+}
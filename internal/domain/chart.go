@@ -14,6 +14,8 @@ type DateToChartData map[string]float64
 type ChartData struct {
 	Labels   []string  `json:"labels"`
 	Datasets []float64 `json:"datasets"`
+	// Unconverted is the number of transactions left out, because there's no exchange rate to the base currency
+	Unconverted int64 `json:"unconverted"`
 }
 
 // ChartDateRange contains start date and end date for chart data
@@ -0,0 +1,51 @@
+package domain
+
+// CmpOpType is an enumeration of comparison operator types in search query
+type CmpOpType int8
+
+const (
+	// CmpOpTypeEq is an enumeration of equal comparison operator type
+	CmpOpTypeEq CmpOpType = iota
+
+	// CmpOpTypeGt is an enumeration of greater than comparison operator type
+	CmpOpTypeGt
+
+	// CmpOpTypeGte is an enumeration of greater than or equal comparison operator type
+	CmpOpTypeGte
+
+	// CmpOpTypeLt is an enumeration of less than comparison operator type
+	CmpOpTypeLt
+
+	// CmpOpTypeLte is an enumeration of less than or equal comparison operator type
+	CmpOpTypeLte
+)
+
+// CvtToCmpOpType converts an operator in search query to a comparison operator type, it's equal when the operator is ":" or unknown
+func CvtToCmpOpType(s string) CmpOpType {
+	switch s {
+	case ">":
+		return CmpOpTypeGt
+	case ">=":
+		return CmpOpTypeGte
+	case "<":
+		return CmpOpTypeLt
+	case "<=":
+		return CmpOpTypeLte
+	}
+	return CmpOpTypeEq
+}
+
+// String returns the operator of the comparison operator type, which is the same in SQL
+func (t CmpOpType) String() string {
+	switch t {
+	case CmpOpTypeGt:
+		return ">"
+	case CmpOpTypeGte:
+		return ">="
+	case CmpOpTypeLt:
+		return "<"
+	case CmpOpTypeLte:
+		return "<="
+	}
+	return "="
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DuplicateWindowDays is the default number of days between the dates of duplicate transactions
+	DuplicateWindowDays = 3
+
+	// DuplicateRangeDays is the default number of days looked back for duplicate transactions
+	DuplicateRangeDays = 90
+)
+
+// DuplicatePair is a pair of transactions of a user with the same type, price, currency and categories,
+// whose dates are close to each other. FirstID is less than SecondID.
+type DuplicatePair struct {
+	FirstID  int64 `json:"first_id"`
+	SecondID int64 `json:"second_id"`
+}
+
+// GetDuplicatesOpt contains the options of finding duplicate transactions.
+// Only the transactions dated from StartDate to EndDate are compared, and the dates of duplicate ones are within Days of each other.
+type GetDuplicatesOpt struct {
+	Days      int
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// DuplicateGroup is a group of suspected duplicate transactions, in the order of date
+type DuplicateGroup struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+// IsSimilarNote reports whether the notes of duplicate transactions are similar.
+// The notes are compared case-insensitively with extra spaces removed,
+// and they're similar when one contains the other, or at least half of their words are shared.
+// An empty note is only similar to another empty note.
+func IsSimilarNote(a, b string) bool {
+	wordsA := strings.Fields(strings.ToLower(a))
+	wordsB := strings.Fields(strings.ToLower(b))
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return len(wordsA) == len(wordsB)
+	}
+
+	noteA, noteB := strings.Join(wordsA, " "), strings.Join(wordsB, " ")
+	if strings.Contains(noteA, noteB) || strings.Contains(noteB, noteA) {
+		return true
+	}
+
+	setA := make(map[string]bool, len(wordsA))
+	for _, w := range wordsA {
+		setA[w] = true
+	}
+	setB := make(map[string]bool, len(wordsB))
+	for _, w := range wordsB {
+		setB[w] = true
+	}
+
+	shared := 0
+	for w := range setB {
+		if setA[w] {
+			shared++
+		}
+	}
+
+	return shared*2 >= len(setA)+len(setB)-shared
+}
+
+// GroupDuplicates groups the transactions connected by the pairs with similar notes.
+// Every transaction of a group pairs with the first one of the group, so they're all within the window of the first one,
+// and the transactions repeated regularly, like the daily coffee, never chain into one group covering the whole history.
+// The pairs whose transactions are not in trans are skipped.
+// The groups are in the order of their first transaction.
+func GroupDuplicates(pairs []DuplicatePair, trans []Transaction) []DuplicateGroup {
+	idToTrans := make(map[int64]Transaction, len(trans))
+	for _, t := range trans {
+		idToTrans[t.ID] = t
+	}
+
+	paired := make(map[DuplicatePair]bool, len(pairs))
+	var pairedTrans []Transaction
+	for _, p := range pairs {
+		first, ok1 := idToTrans[p.FirstID]
+		second, ok2 := idToTrans[p.SecondID]
+		if !ok1 || !ok2 || !IsSimilarNote(first.Note, second.Note) {
+			continue
+		}
+
+		paired[genDuplicatePair(p.FirstID, p.SecondID)] = true
+		pairedTrans = append(pairedTrans, first, second)
+	}
+
+	slices.SortFunc(pairedTrans, func(a, b Transaction) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	pairedTrans = slices.CompactFunc(pairedTrans, func(a, b Transaction) bool {
+		return a.ID == b.ID
+	})
+
+	grouped := make(map[int64]bool, len(pairedTrans))
+	groups := []DuplicateGroup{}
+	for i, first := range pairedTrans {
+		if grouped[first.ID] {
+			continue
+		}
+
+		ts := []Transaction{first}
+		for _, t := range pairedTrans[i+1:] {
+			if !grouped[t.ID] && paired[genDuplicatePair(first.ID, t.ID)] {
+				ts = append(ts, t)
+			}
+		}
+		if len(ts) < 2 {
+			continue
+		}
+
+		for _, t := range ts {
+			grouped[t.ID] = true
+		}
+		groups = append(groups, DuplicateGroup{Transactions: ts})
+	}
+
+	return groups
+}
+
+// genDuplicatePair generates the pair of the transactions, the smaller id goes first
+func genDuplicatePair(id1, id2 int64) DuplicatePair {
+	return DuplicatePair{FirstID: min(id1, id2), SecondID: max(id1, id2)}
+}
@@ -41,6 +41,9 @@ var (
 	// main category unique name error
 	ErrUniqueNameUserType = errors.New("name already used by another main category with the same type")
 
+	// main categories of different types error
+	ErrMainCategTypeNotSame = errors.New("main categories must have the same type")
+
 	// sub category not found error
 	ErrSubCategNotFound = errors.New("sub category not found")
 
@@ -76,4 +79,145 @@ var (
 
 	// unique user date error
 	ErrUniqueUserDate = errors.New("unique user date")
+
+	// recurring transaction not found error
+	ErrRecurringTransNotFound = errors.New("recurring transaction not found")
+
+	// recurring transaction next date is already advanced by another run
+	ErrRecurringTransNotDue = errors.New("recurring transaction is not due")
+
+	// budget not found error
+	ErrBudgetNotFound = errors.New("budget not found")
+
+	// budget can only be set on expense main category
+	ErrBudgetCategNotExpense = errors.New("budget can only be set on expense main category")
+
+	// account not found error
+	ErrAccountNotFound = errors.New("account not found")
+
+	// account unique name error
+	ErrUniqueNameUser = errors.New("name already used by another account")
+
+	// transfer must move money between two different accounts
+	ErrTransferSameAccount = errors.New("transfer must be between two different accounts")
+
+	// tag not found error
+	ErrTagNotFound = errors.New("tag not found")
+
+	// tag unique name error
+	ErrUniqueTagNameUser = errors.New("name already used by another tag")
+
+	// rule not found error
+	ErrRuleNotFound = errors.New("rule not found")
+
+	// rule unique name error
+	ErrUniqueRuleNameUser = errors.New("name already used by another rule")
+
+	// no rule matches the transaction without categories
+	ErrNoRuleMatched = errors.New("no rule matches the transaction")
+
+	// view not found error
+	ErrViewNotFound = errors.New("view not found")
+
+	// view unique name error
+	ErrUniqueViewNameUser = errors.New("name already used by another view")
+
+	// trash item not found error
+	ErrTrashItemNotFound = errors.New("trash item not found")
+
+	// the category of the trash item is also in trash
+	ErrTrashParentInTrash = errors.New("category of the item is in trash, restore the category first")
+
+	// transaction revision not found error
+	ErrTransRevisionNotFound = errors.New("transaction revision not found")
+
+	// the transaction is deleted in the revision, so there's nothing to revert to
+	ErrTransRevisionNotRevertible = errors.New("can't revert to a deleted revision")
+
+	// attachment not found error
+	ErrAttachmentNotFound = errors.New("attachment not found")
+
+	// attachment unique file name error
+	ErrUniqueAttachmentFileName = errors.New("file name already used by another attachment of the transaction")
+
+	// the object key isn't issued for the attachment of the transaction
+	ErrAttachmentObjectKeyInvalid = errors.New("object key doesn't match the attachment")
+
+	// the split transaction is categorized by its lines, so its category can't be changed alone
+	ErrBulkUpdateSplitCateg = errors.New("can't change category of split transaction in bulk")
+
+	// the transaction to update is also selected by the filter of deletion
+	ErrBulkUpdateDeletedTrans = errors.New("can't update and delete the same transaction")
+
+	// the created transaction is similar to an existing one, and strict mode refuses it
+	ErrDuplicateTransaction = errors.New("transaction may be a duplicate of an existing one")
+
+	// no default icon for the main category created by import
+	ErrNoDefaultIcon = errors.New("no default icon for new main category")
+
+	// ledger not found error, the user is not a member of the ledger
+	ErrLedgerNotFound = errors.New("ledger not found")
+
+	// the role of the member doesn't allow the action on the ledger
+	ErrLedgerPermissionDenied = errors.New("permission denied on the ledger")
+
+	// personal ledger can't be shared with others
+	ErrLedgerPersonal = errors.New("personal ledger can't be shared")
+
+	// ledger member not found error
+	ErrLedgerMemberNotFound = errors.New("ledger member not found")
+
+	// the user is already a member of the ledger
+	ErrLedgerMemberExists = errors.New("user is already a member of the ledger")
+
+	// the owner of the ledger can't be changed or removed
+	ErrLedgerOwnerUnchangeable = errors.New("owner of the ledger can't be changed or removed")
+
+	// ledger invite not found error
+	ErrLedgerInviteNotFound = errors.New("ledger invite not found")
+
+	// ledger invite unique email error
+	ErrUniqueLedgerInviteEmail = errors.New("email already invited to the ledger")
+
+	// contact not found error
+	ErrContactNotFound = errors.New("contact not found")
+
+	// contact unique name error
+	ErrUniqueContactNameUser = errors.New("name already used by another contact")
+
+	// the contact still has shared transactions or settlements, so the balances would change if it's deleted
+	ErrContactInUse = errors.New("contact has shared transactions or settlements")
+
+	// only expense can be shared among the participants
+	ErrShareNotExpense = errors.New("only expense can be shared")
+
+	// the exact amounts of the shares must sum to the price of the transaction
+	ErrShareAmountMismatch = errors.New("sum of the share amounts must equal the price")
+
+	// the transaction isn't shared
+	ErrSharedTransNotFound = errors.New("transaction is not shared")
+
+	// settlement not found error
+	ErrSettlementNotFound = errors.New("settlement not found")
+
+	// the one-time token of password reset or email verification is used, expired or never issued
+	ErrOneTimeToken = errors.New("invalid or expired token")
+
+	// email already verified error
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+
+	// the email of the user isn't verified yet
+	ErrEmailNotVerified = errors.New("email not verified")
+
+	// the session is revoked, expired or owned by another user
+	ErrSessionNotFound = errors.New("session not found")
+
+	// the API key is deleted, expired or owned by another user
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	// the API key isn't granted the scope of the request, or the request doesn't accept API keys
+	ErrAPIKeyScope = errors.New("api key is not allowed to access this resource")
+
+	// the admin key of the request is missing or wrong
+	ErrAdminKey = errors.New("admin key is required to access this resource")
 )
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// DefaultCurrency is the base currency of new users, and the currency of transactions created before multi-currency
+const DefaultCurrency = "USD"
+
+// ExchangeRate contains the rate of converting one unit of From currency to To currency on the date
+type ExchangeRate struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	Date time.Time `json:"date"`
+	Rate float64   `json:"rate"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+// ExportFormatType is an enumeration of transaction export formats
+type ExportFormatType int64
+
+const (
+	// ExportFormatTypeUnSpecified is an enumeration of unspecified export format
+	ExportFormatTypeUnSpecified ExportFormatType = iota
+
+	// ExportFormatTypeCSV is an enumeration of CSV export format
+	ExportFormatTypeCSV
+
+	// ExportFormatTypeJSON is an enumeration of JSON export format
+	ExportFormatTypeJSON
+
+	// ExportFormatTypeOFX is an enumeration of OFX export format
+	ExportFormatTypeOFX
+)
+
+// IsValid checks if the export format type is valid
+func (t ExportFormatType) IsValid() bool {
+	switch t {
+	case ExportFormatTypeCSV, ExportFormatTypeJSON, ExportFormatTypeOFX:
+		return true
+	}
+	return false
+}
+
+// ToString returns the string representation of the export format type
+func (t ExportFormatType) ToString() string {
+	switch t {
+	case ExportFormatTypeCSV:
+		return "csv"
+	case ExportFormatTypeJSON:
+		return "json"
+	case ExportFormatTypeOFX:
+		return "ofx"
+	}
+	return "unspecified"
+}
+
+// CvtToExportFormatType converts a string to an export format type
+func CvtToExportFormatType(s string) ExportFormatType {
+	switch s {
+	case "csv":
+		return ExportFormatTypeCSV
+	case "json":
+		return ExportFormatTypeJSON
+	case "ofx":
+		return ExportFormatTypeOFX
+	}
+	return ExportFormatTypeUnSpecified
+}
@@ -0,0 +1,46 @@
+package domain
+
+// ImportModeType is an enumeration of the ways a category template is imported
+type ImportModeType int64
+
+const (
+	// ImportModeTypeUnSpecified is an enumeration of unspecified import mode
+	ImportModeTypeUnSpecified ImportModeType = iota
+
+	// ImportModeTypeMerge is an enumeration of merge import mode, existing categories are kept
+	ImportModeTypeMerge
+
+	// ImportModeTypeReplace is an enumeration of replace import mode, existing categories not in the template are moved to trash
+	ImportModeTypeReplace
+)
+
+// IsValid checks if the import mode type is valid
+func (t ImportModeType) IsValid() bool {
+	switch t {
+	case ImportModeTypeMerge, ImportModeTypeReplace:
+		return true
+	}
+	return false
+}
+
+// ToString returns the string representation of the import mode type
+func (t ImportModeType) ToString() string {
+	switch t {
+	case ImportModeTypeMerge:
+		return "merge"
+	case ImportModeTypeReplace:
+		return "replace"
+	}
+	return "unspecified"
+}
+
+// CvtToImportModeType converts a string to an import mode type
+func CvtToImportModeType(s string) ImportModeType {
+	switch s {
+	case "merge":
+		return ImportModeTypeMerge
+	case "replace":
+		return ImportModeTypeReplace
+	}
+	return ImportModeTypeUnSpecified
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+const (
+	// ImportRowStatusImported means the row is inserted as a transaction
+	ImportRowStatusImported = "imported"
+
+	// ImportRowStatusValid means the row is valid, but not inserted because of dry-run
+	ImportRowStatusValid = "valid"
+
+	// ImportRowStatusInvalid means the row is skipped because of validation errors
+	ImportRowStatusInvalid = "invalid"
+)
+
+// ImportTransMapping contains the CSV header names of each transaction field
+type ImportTransMapping struct {
+	Date       string `json:"date"`
+	Amount     string `json:"amount"`
+	Note       string `json:"note"`
+	MainCateg  string `json:"main_category"`
+	SubCateg   string `json:"sub_category"`
+	Type       string `json:"type"`
+	Currency   string `json:"currency"`
+	DateFormat string `json:"date_format"`
+}
+
+// ImportTransRow contains a parsed row of the CSV file
+type ImportTransRow struct {
+	Line          int
+	Type          TransactionType
+	MainCategName string
+	SubCategName  string
+	Price         float64
+	Currency      string
+	Date          time.Time
+	Note          string
+
+	// Errors contains the validation errors of the row, the row is skipped if it's not empty
+	Errors map[string]string
+}
+
+// ImportTransRowResult contains the import result of a row
+type ImportTransRowResult struct {
+	Line         int               `json:"line"`
+	Status       string            `json:"status"`
+	MainCategID  int64             `json:"main_category_id,omitempty"`
+	SubCategID   int64             `json:"sub_category_id,omitempty"`
+	NewMainCateg bool              `json:"new_main_category"`
+	NewSubCateg  bool              `json:"new_sub_category"`
+	RuleID       int64             `json:"rule_id,omitempty"`
+	Errors       map[string]string `json:"errors,omitempty"`
+}
+
+// ImportTransResult contains the report of a CSV import
+type ImportTransResult struct {
+	DryRun   bool                   `json:"dry_run"`
+	Total    int                    `json:"total"`
+	Imported int                    `json:"imported"`
+	Invalid  int                    `json:"invalid"`
+	Rows     []ImportTransRowResult `json:"rows"`
+}
+
+// ImportTransInput contains the new categories and the transactions created by a CSV import.
+// The new categories have negative placeholder ids, which the sub categories and the transactions refer to until they're created
+type ImportTransInput struct {
+	MainCategs []MainCateg
+	SubCategs  []SubCateg
+	Trans      []CreateTransactionInput
+}
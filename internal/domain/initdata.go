@@ -12,3 +12,21 @@ type InitDataMainCateg struct {
 	Icon      DefaultIcon `json:"icon"`
 	SubCategs []string    `json:"sub_categories"`
 }
+
+// CategTemplate represents a built-in template of main and sub categories.
+type CategTemplate struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	InitData
+}
+
+// ImportCategInput contains the changes of importing a category template.
+// The replaced categories are archived, so their transactions are kept.
+// The new main categories have negative placeholder ids, which the new sub categories refer to until they're created
+type ImportCategInput struct {
+	ArchiveMainCategIDs []int64
+	ArchiveSubCategIDs  []int64
+	MainCategs          []MainCateg
+	SubCategs           []SubCateg
+}
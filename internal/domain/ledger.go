@@ -0,0 +1,30 @@
+package domain
+
+// Ledger is a book of categories and transactions shared by its members, e.g. a household.
+// The data of the ledger belongs to UserID, which is the member themself for a personal ledger,
+// and a user without email for a shared one, so the data is scoped by user id as before
+type Ledger struct {
+	ID         int64          `json:"id"`
+	Name       string         `json:"name"`
+	UserID     int64          `json:"-"`
+	IsPersonal bool           `json:"is_personal"`
+	Role       LedgerRoleType `json:"role"`
+}
+
+// LedgerMember is a user joined to a ledger
+type LedgerMember struct {
+	UserID int64          `json:"user_id"`
+	Name   string         `json:"name"`
+	Email  string         `json:"email"`
+	Role   LedgerRoleType `json:"role"`
+}
+
+// LedgerInvite is an invitation to join a ledger, which is accepted by the user with the email
+type LedgerInvite struct {
+	ID         int64          `json:"id"`
+	LedgerID   int64          `json:"ledger_id"`
+	LedgerName string         `json:"ledger_name"`
+	Email      string         `json:"email"`
+	Role       LedgerRoleType `json:"role"`
+	InvitedBy  int64          `json:"invited_by"`
+}
@@ -0,0 +1,77 @@
+package domain
+
+// LedgerRoleType is an enumeration of the roles of ledger members
+type LedgerRoleType int64
+
+const (
+	// LedgerRoleTypeUnSpecified is an enumeration of unspecified ledger role
+	LedgerRoleTypeUnSpecified LedgerRoleType = iota
+
+	// LedgerRoleTypeOwner is an enumeration of owner role, who manages the members
+	LedgerRoleTypeOwner
+
+	// LedgerRoleTypeEditor is an enumeration of editor role, who reads and writes the data
+	LedgerRoleTypeEditor
+
+	// LedgerRoleTypeViewer is an enumeration of viewer role, who only reads the data
+	LedgerRoleTypeViewer
+)
+
+// IsValid checks if the ledger role type is valid
+func (t LedgerRoleType) IsValid() bool {
+	switch t {
+	case LedgerRoleTypeOwner, LedgerRoleTypeEditor, LedgerRoleTypeViewer:
+		return true
+	}
+	return false
+}
+
+// CanWrite checks if the role can change the data of the ledger
+func (t LedgerRoleType) CanWrite() bool {
+	return t == LedgerRoleTypeOwner || t == LedgerRoleTypeEditor
+}
+
+// CanManage checks if the role can run the destructive operations on the data of the ledger,
+// e.g. deleting main categories, or replacing them by a template
+func (t LedgerRoleType) CanManage() bool {
+	return t == LedgerRoleTypeOwner
+}
+
+// ToString returns the string representation of the ledger role type
+func (t LedgerRoleType) ToString() string {
+	switch t {
+	case LedgerRoleTypeOwner:
+		return "owner"
+	case LedgerRoleTypeEditor:
+		return "editor"
+	case LedgerRoleTypeViewer:
+		return "viewer"
+	}
+	return "unspecified"
+}
+
+// ToModelValue returns the string enum of mysql
+func (t LedgerRoleType) ToModelValue() string {
+	switch t {
+	case LedgerRoleTypeOwner:
+		return "1"
+	case LedgerRoleTypeEditor:
+		return "2"
+	case LedgerRoleTypeViewer:
+		return "3"
+	}
+	return "0"
+}
+
+// CvtToLedgerRoleType converts string to LedgerRoleType
+func CvtToLedgerRoleType(s string) LedgerRoleType {
+	switch s {
+	case "owner", "1":
+		return LedgerRoleTypeOwner
+	case "editor", "2":
+		return LedgerRoleTypeEditor
+	case "viewer", "3":
+		return LedgerRoleTypeViewer
+	}
+	return LedgerRoleTypeUnSpecified
+}
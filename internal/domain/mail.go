@@ -0,0 +1,8 @@
+package domain
+
+// Mail contains the plain text mail sent to the user
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
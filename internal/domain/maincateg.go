@@ -2,11 +2,12 @@ package domain
 
 // MainCateg contains main category information with icon	info
 type MainCateg struct {
-	ID       int64           `json:"id"`
-	Name     string          `json:"name"`
-	Type     TransactionType `json:"type"`
-	IconType IconType        `json:"icon_type"`
-	IconData string          `json:"icon_data"`
+	ID         int64           `json:"id"`
+	Name       string          `json:"name"`
+	Type       TransactionType `json:"type"`
+	IconType   IconType        `json:"icon_type"`
+	IconData   string          `json:"icon_data"`
+	IsArchived bool            `json:"is_archived"`
 }
 
 // CreateMainCategInput is the input for creating a main category
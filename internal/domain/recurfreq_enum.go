@@ -0,0 +1,75 @@
+package domain
+
+// RecurFreqType is an enumeration of recurring frequency types
+type RecurFreqType int64
+
+const (
+	// RecurFreqTypeUnSpecified is an enumeration of unspecified recurring frequency type
+	RecurFreqTypeUnSpecified RecurFreqType = iota
+
+	// RecurFreqTypeDaily is an enumeration of daily recurring frequency type
+	RecurFreqTypeDaily
+
+	// RecurFreqTypeWeekly is an enumeration of weekly recurring frequency type
+	RecurFreqTypeWeekly
+
+	// RecurFreqTypeMonthly is an enumeration of monthly recurring frequency type
+	RecurFreqTypeMonthly
+
+	// RecurFreqTypeYearly is an enumeration of yearly recurring frequency type
+	RecurFreqTypeYearly
+)
+
+// IsValid checks if the recurring frequency type is valid
+func (t RecurFreqType) IsValid() bool {
+	switch t {
+	case RecurFreqTypeDaily, RecurFreqTypeWeekly, RecurFreqTypeMonthly, RecurFreqTypeYearly:
+		return true
+	}
+	return false
+}
+
+// ToString returns the string representation of the recurring frequency type
+func (t RecurFreqType) ToString() string {
+	switch t {
+	case RecurFreqTypeDaily:
+		return "daily"
+	case RecurFreqTypeWeekly:
+		return "weekly"
+	case RecurFreqTypeMonthly:
+		return "monthly"
+	case RecurFreqTypeYearly:
+		return "yearly"
+	}
+	return "unknown frequency"
+}
+
+// ToModelValue returns the string enum of mysql
+func (t RecurFreqType) ToModelValue() string {
+	switch t {
+	case RecurFreqTypeDaily:
+		return "1"
+	case RecurFreqTypeWeekly:
+		return "2"
+	case RecurFreqTypeMonthly:
+		return "3"
+	case RecurFreqTypeYearly:
+		return "4"
+	}
+	return "0"
+}
+
+// CvtToRecurFreqType converts string to RecurFreqType
+func CvtToRecurFreqType(s string) RecurFreqType {
+	switch s {
+	case "daily", "1":
+		return RecurFreqTypeDaily
+	case "weekly", "2":
+		return RecurFreqTypeWeekly
+	case "monthly", "3":
+		return RecurFreqTypeMonthly
+	case "yearly", "4":
+		return RecurFreqTypeYearly
+	}
+	return RecurFreqTypeUnSpecified
+}
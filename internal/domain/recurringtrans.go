@@ -0,0 +1,96 @@
+package domain
+
+import "time"
+
+// RecurringTrans contains a recurring transaction template and its schedule
+type RecurringTrans struct {
+	ID       int64                  `json:"id"`
+	Template CreateTransactionInput `json:"template"`
+	Schedule RecurringSchedule      `json:"schedule"`
+	NextDate time.Time              `json:"next_date"`
+}
+
+// RecurringSchedule contains RRULE-like schedule of a recurring transaction
+// e.g. Freq: monthly, Interval: 1, StartDate: 2024-01-31 means every month on the 31st(or the last day of the month)
+type RecurringSchedule struct {
+	Freq      RecurFreqType `json:"frequency"`
+	Interval  int           `json:"interval"`
+	StartDate time.Time     `json:"start_date"`
+	EndDate   *time.Time    `json:"end_date"`
+}
+
+// UpdateRecurringTransInput represents input for updating recurring transaction
+type UpdateRecurringTransInput struct {
+	ID       int64
+	Template CreateTransactionInput
+	Schedule RecurringSchedule
+}
+
+// Occurrence returns the n-th(0-based) occurrence of the schedule.
+// For monthly and yearly frequency, the day is clamped to the last day of the month
+// e.g. start date 2024-01-31 with monthly frequency, the next occurrence is 2024-02-29
+func (s RecurringSchedule) Occurrence(n int) time.Time {
+	switch s.Freq {
+	case RecurFreqTypeDaily:
+		return s.StartDate.AddDate(0, 0, n*s.Interval)
+	case RecurFreqTypeWeekly:
+		return s.StartDate.AddDate(0, 0, 7*n*s.Interval)
+	case RecurFreqTypeMonthly:
+		return addMonthsClamped(s.StartDate, n*s.Interval)
+	case RecurFreqTypeYearly:
+		return addMonthsClamped(s.StartDate, 12*n*s.Interval)
+	}
+
+	return s.StartDate
+}
+
+// Next returns the first occurrence of the schedule strictly after the given date
+func (s RecurringSchedule) Next(after time.Time) time.Time {
+	if after.Before(s.StartDate) {
+		return s.StartDate
+	}
+
+	// estimate the index of the occurrence, then walk forward to the exact one
+	var n int
+	switch s.Freq {
+	case RecurFreqTypeDaily:
+		n = int(after.Sub(s.StartDate).Hours()/24) / s.Interval
+	case RecurFreqTypeWeekly:
+		n = int(after.Sub(s.StartDate).Hours()/24) / (7 * s.Interval)
+	case RecurFreqTypeMonthly:
+		n = monthsBetween(s.StartDate, after) / s.Interval
+	case RecurFreqTypeYearly:
+		n = monthsBetween(s.StartDate, after) / (12 * s.Interval)
+	}
+
+	for n > 0 && s.Occurrence(n).After(after) {
+		n--
+	}
+
+	for !s.Occurrence(n).After(after) {
+		n++
+	}
+
+	return s.Occurrence(n)
+}
+
+// IsEnded checks if the given date is after the end date of the schedule
+func (s RecurringSchedule) IsEnded(date time.Time) bool {
+	return s.EndDate != nil && date.After(*s.EndDate)
+}
+
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, months, 0)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+func monthsBetween(start, end time.Time) int {
+	return (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+}
@@ -0,0 +1,75 @@
+package domain
+
+// RevisionActionType is an enumeration of the changes recorded in transaction history
+type RevisionActionType int64
+
+const (
+	// RevisionActionTypeUnSpecified is an enumeration of unspecified revision action type
+	RevisionActionTypeUnSpecified RevisionActionType = iota
+
+	// RevisionActionTypeCreate is an enumeration of creating transaction
+	RevisionActionTypeCreate
+
+	// RevisionActionTypeUpdate is an enumeration of updating transaction
+	RevisionActionTypeUpdate
+
+	// RevisionActionTypeDelete is an enumeration of deleting transaction
+	RevisionActionTypeDelete
+
+	// RevisionActionTypeRevert is an enumeration of reverting transaction to a previous revision
+	RevisionActionTypeRevert
+)
+
+// IsValid checks if the revision action type is valid
+func (t RevisionActionType) IsValid() bool {
+	switch t {
+	case RevisionActionTypeCreate, RevisionActionTypeUpdate, RevisionActionTypeDelete, RevisionActionTypeRevert:
+		return true
+	}
+	return false
+}
+
+// ToString returns the string representation of the revision action type
+func (t RevisionActionType) ToString() string {
+	switch t {
+	case RevisionActionTypeCreate:
+		return "create"
+	case RevisionActionTypeUpdate:
+		return "update"
+	case RevisionActionTypeDelete:
+		return "delete"
+	case RevisionActionTypeRevert:
+		return "revert"
+	}
+	return "unspecified"
+}
+
+// ToModelValue returns the string enum of mysql
+func (t RevisionActionType) ToModelValue() string {
+	switch t {
+	case RevisionActionTypeCreate:
+		return "1"
+	case RevisionActionTypeUpdate:
+		return "2"
+	case RevisionActionTypeDelete:
+		return "3"
+	case RevisionActionTypeRevert:
+		return "4"
+	}
+	return "0"
+}
+
+// CvtToRevisionActionType converts string to RevisionActionType
+func CvtToRevisionActionType(s string) RevisionActionType {
+	switch s {
+	case "create", "1":
+		return RevisionActionTypeCreate
+	case "update", "2":
+		return RevisionActionTypeUpdate
+	case "delete", "3":
+		return RevisionActionTypeDelete
+	case "revert", "4":
+		return RevisionActionTypeRevert
+	}
+	return RevisionActionTypeUnSpecified
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Rule categorizes the transactions which match all of its conditions.
+// NoteContains matches the note case-insensitively, and NoteRegex is in RE2 syntax.
+// Empty NoteContains, NoteRegex, and nil MinPrice, MaxPrice are not conditions.
+// The rules of a user are matched in order of Priority, then ID, and the first matched rule wins.
+type Rule struct {
+	ID           int64           `json:"id"`
+	Name         string          `json:"name"`
+	Priority     int             `json:"priority"`
+	Type         TransactionType `json:"type"`
+	NoteContains string          `json:"note_contains"`
+	NoteRegex    string          `json:"note_regex"`
+	MinPrice     *float64        `json:"min_price"`
+	MaxPrice     *float64        `json:"max_price"`
+	MainCateg    MainCateg       `json:"main_category"`
+	SubCateg     SubCateg        `json:"sub_category"`
+	Tags         []Tag           `json:"tags"`
+}
+
+// TagIDs returns the ids of tags put on the matched transactions
+func (r Rule) TagIDs() []int64 {
+	ids := make([]int64, 0, len(r.Tags))
+	for _, t := range r.Tags {
+		ids = append(ids, t.ID)
+	}
+
+	return ids
+}
+
+// RuleSet matches transactions against the ordered rules of a user, the regular expressions are compiled once
+type RuleSet struct {
+	rules   []Rule
+	regexps []*regexp.Regexp
+}
+
+// NewRuleSet creates a RuleSet, the rules must be in the order of matching.
+// The rule with invalid regular expression never matches.
+func NewRuleSet(rules []Rule) RuleSet {
+	s := RuleSet{
+		rules:   rules,
+		regexps: make([]*regexp.Regexp, len(rules)),
+	}
+
+	for i, r := range rules {
+		if r.NoteRegex == "" {
+			continue
+		}
+
+		// nil never matches
+		s.regexps[i], _ = regexp.Compile(r.NoteRegex)
+	}
+
+	return s
+}
+
+// Match returns the first rule which matches the transaction
+func (s RuleSet) Match(transType TransactionType, price float64, note string) (Rule, bool) {
+	lowerNote := strings.ToLower(note)
+	for i, r := range s.rules {
+		if r.Type != transType {
+			continue
+		}
+
+		if r.MinPrice != nil && price < *r.MinPrice {
+			continue
+		}
+
+		if r.MaxPrice != nil && price > *r.MaxPrice {
+			continue
+		}
+
+		if r.NoteContains != "" && !strings.Contains(lowerNote, strings.ToLower(r.NoteContains)) {
+			continue
+		}
+
+		if r.NoteRegex != "" && (s.regexps[i] == nil || !s.regexps[i].MatchString(note)) {
+			continue
+		}
+
+		return r, true
+	}
+
+	return Rule{}, false
+}
+
+// Categorize sets the categories of the transaction by the first matched rule, and adds the tags of the rule.
+// It returns false if no rule matches, and the transaction is unchanged.
+func (s RuleSet) Categorize(t *CreateTransactionInput) (Rule, bool) {
+	r, ok := s.Match(t.Type, t.Price, t.Note)
+	if !ok {
+		return Rule{}, false
+	}
+
+	t.MainCategID, t.SubCategID = r.MainCateg.ID, r.SubCateg.ID
+	for _, id := range r.TagIDs() {
+		if !slices.Contains(t.TagIDs, id) {
+			t.TagIDs = append(t.TagIDs, id)
+		}
+	}
+
+	return r, true
+}
+
+// RuleRerunOpt contains the date range of transactions to re-run the rules over, nil means unbounded.
+// The changes are only previewed when DryRun is true.
+type RuleRerunOpt struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+	DryRun    bool
+}
+
+// RuleChange is the change made by re-running the rules on a transaction
+// AddedTags are the tags of the rule which the transaction doesn't have yet
+type RuleChange struct {
+	TransactionID int64     `json:"transaction_id"`
+	RuleID        int64     `json:"rule_id"`
+	Date          time.Time `json:"date"`
+	Price         float64   `json:"price"`
+	Note          string    `json:"note"`
+	FromMainCateg MainCateg `json:"from_main_category"`
+	FromSubCateg  SubCateg  `json:"from_sub_category"`
+	ToMainCateg   MainCateg `json:"to_main_category"`
+	ToSubCateg    SubCateg  `json:"to_sub_category"`
+	AddedTags     []Tag     `json:"added_tags"`
+}
+
+// RuleRerunResult contains the changes of re-running the rules, which are applied unless it's dry-run
+type RuleRerunResult struct {
+	DryRun  bool         `json:"dry_run"`
+	Changes []RuleChange `json:"changes"`
+}
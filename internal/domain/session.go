@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+const (
+	// SessionTTL is how long a session stays alive without being used
+	SessionTTL = 7 * 24 * time.Hour
+)
+
+// Session contains the login of the user on a device
+type Session struct {
+	ID         int64
+	UserID     int64
+	Device     string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// IsExpired returns true if the session isn't used within the TTL
+func (s Session) IsExpired(now time.Time) bool {
+	return now.Sub(s.LastUsedAt) > SessionTTL
+}
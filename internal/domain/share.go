@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Contact is a person the user shares expenses with, who doesn't need to be a user
+type Contact struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Share is the part of a shared transaction taken by a participant
+// ContactID is 0 when the participant is the user
+// Value is the amount, percentage or number of shares depending on the method, and it's ignored by the equal method
+// Amount is allocated from the price of the transaction by Allocate
+type Share struct {
+	ContactID int64   `json:"contact_id"`
+	Value     float64 `json:"value"`
+	Amount    float64 `json:"amount"`
+}
+
+// SharedTransaction is an expense whose price is shared among the user and the contacts
+// PaidBy is the contact who paid the expense, 0 means the user
+// Price and Currency are the ones of the transaction
+type SharedTransaction struct {
+	TransactionID int64           `json:"transaction_id"`
+	Method        ShareMethodType `json:"method"`
+	PaidBy        int64           `json:"paid_by"`
+	Price         float64         `json:"price"`
+	Currency      string          `json:"currency"`
+	Shares        []Share         `json:"shares"`
+}
+
+// ContactIDs returns the ids of the contacts in the shared transaction, including the payer
+func (t SharedTransaction) ContactIDs() []int64 {
+	seen := map[int64]bool{}
+	var ids []int64
+	for _, id := range append([]int64{t.PaidBy}, shareContactIDs(t.Shares)...) {
+		if id == 0 || seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// Allocate fills the amounts of the shares from the price by the method.
+// The amounts are rounded to cents, and the cents left by rounding go to the first shares, so that they sum to the price.
+// The exact method keeps the values as the amounts.
+func (t *SharedTransaction) Allocate() {
+	if t.Method == ShareMethodTypeExact {
+		for i := range t.Shares {
+			t.Shares[i].Amount = t.Shares[i].Value
+		}
+		return
+	}
+
+	weights := make([]float64, len(t.Shares))
+	var sumWeight float64
+	for i, s := range t.Shares {
+		weights[i] = s.Value
+		if t.Method == ShareMethodTypeEqual {
+			weights[i] = 1
+		}
+		sumWeight += weights[i]
+	}
+	if sumWeight <= 0 {
+		return
+	}
+
+	priceCents := int64(math.Round(t.Price * 100))
+	var allocated int64
+	cents := make([]int64, len(t.Shares))
+	for i, w := range weights {
+		cents[i] = int64(math.Floor(float64(priceCents) * w / sumWeight))
+		allocated += cents[i]
+	}
+
+	for i := 0; allocated < priceCents; i = (i + 1) % len(cents) {
+		cents[i]++
+		allocated++
+	}
+
+	for i := range t.Shares {
+		t.Shares[i].Amount = float64(cents[i]) / 100
+	}
+}
+
+// Settlement is a payment from one participant to another, which clears the debt between them
+// FromContactID and ToContactID are 0 when the participant is the user
+type Settlement struct {
+	ID            int64     `json:"id"`
+	FromContactID int64     `json:"from_contact_id"`
+	ToContactID   int64     `json:"to_contact_id"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Date          time.Time `json:"date"`
+	Note          string    `json:"note"`
+}
+
+// Balance is the net amount of a participant in a currency, positive means the participant should receive money
+// ContactID is 0 when the participant is the user
+type Balance struct {
+	ContactID int64   `json:"contact_id"`
+	Currency  string  `json:"currency"`
+	Amount    float64 `json:"amount"`
+}
+
+// SettleUp is a payment of the plan which settles all the balances
+type SettleUp struct {
+	FromContactID int64   `json:"from_contact_id"`
+	ToContactID   int64   `json:"to_contact_id"`
+	Currency      string  `json:"currency"`
+	Amount        float64 `json:"amount"`
+}
+
+// Balances contains the net balances of the participants, and the plan to settle them
+type Balances struct {
+	Balances []Balance  `json:"balances"`
+	Plan     []SettleUp `json:"plan"`
+}
+
+func shareContactIDs(shares []Share) []int64 {
+	ids := make([]int64, len(shares))
+	for i, s := range shares {
+		ids[i] = s.ContactID
+	}
+
+	return ids
+}
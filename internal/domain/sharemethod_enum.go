@@ -0,0 +1,75 @@
+package domain
+
+// ShareMethodType is an enumeration of the methods sharing the price of a transaction
+type ShareMethodType int64
+
+const (
+	// ShareMethodTypeUnSpecified is an enumeration of unspecified share method type
+	ShareMethodTypeUnSpecified ShareMethodType = iota
+
+	// ShareMethodTypeEqual is an enumeration of sharing the price equally
+	ShareMethodTypeEqual
+
+	// ShareMethodTypeExact is an enumeration of sharing the price by exact amounts
+	ShareMethodTypeExact
+
+	// ShareMethodTypePercent is an enumeration of sharing the price by percentages
+	ShareMethodTypePercent
+
+	// ShareMethodTypeShares is an enumeration of sharing the price by numbers of shares
+	ShareMethodTypeShares
+)
+
+// IsValid checks if the share method type is valid
+func (t ShareMethodType) IsValid() bool {
+	switch t {
+	case ShareMethodTypeEqual, ShareMethodTypeExact, ShareMethodTypePercent, ShareMethodTypeShares:
+		return true
+	}
+	return false
+}
+
+// ToString returns the string representation of the share method type
+func (t ShareMethodType) ToString() string {
+	switch t {
+	case ShareMethodTypeEqual:
+		return "equal"
+	case ShareMethodTypeExact:
+		return "exact"
+	case ShareMethodTypePercent:
+		return "percent"
+	case ShareMethodTypeShares:
+		return "shares"
+	}
+	return "unknown share method type"
+}
+
+// ToModelValue returns the string enum of mysql
+func (t ShareMethodType) ToModelValue() string {
+	switch t {
+	case ShareMethodTypeEqual:
+		return "1"
+	case ShareMethodTypeExact:
+		return "2"
+	case ShareMethodTypePercent:
+		return "3"
+	case ShareMethodTypeShares:
+		return "4"
+	}
+	return "0"
+}
+
+// CvtToShareMethodType converts string to ShareMethodType
+func CvtToShareMethodType(s string) ShareMethodType {
+	switch s {
+	case "equal", "1":
+		return ShareMethodTypeEqual
+	case "exact", "2":
+		return ShareMethodTypeExact
+	case "percent", "3":
+		return ShareMethodTypePercent
+	case "shares", "4":
+		return ShareMethodTypeShares
+	}
+	return ShareMethodTypeUnSpecified
+}
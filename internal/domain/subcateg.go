@@ -5,4 +5,5 @@ type SubCateg struct {
 	ID          int64  `json:"id"`
 	Name        string `json:"name"`
 	MainCategID int64  `json:"main_category_id"`
+	IsArchived  bool   `json:"is_archived"`
 }
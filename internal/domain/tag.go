@@ -0,0 +1,13 @@
+package domain
+
+// Tag contains information of a free-form label, which can be put on any transaction regardless of its category
+type Tag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// TagSum contains summed price of transactions by tag
+type TagSum struct {
+	Tag Tag     `json:"tag"`
+	Sum float64 `json:"sum"`
+}
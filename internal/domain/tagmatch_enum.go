@@ -0,0 +1,39 @@
+package domain
+
+// TagMatchType is an enumeration of how transactions are matched by tag filter
+type TagMatchType int8
+
+const (
+	// TagMatchTypeAny is an enumeration of matching transactions with any of the tags, which is the default
+	TagMatchTypeAny TagMatchType = iota
+
+	// TagMatchTypeAll is an enumeration of matching transactions with all of the tags
+	TagMatchTypeAll
+)
+
+// IsValid checks if the tag match type is valid
+func (t TagMatchType) IsValid() bool {
+	switch t {
+	case TagMatchTypeAny, TagMatchTypeAll:
+		return true
+	}
+	return false
+}
+
+// CvtToTagMatchType converts a string to a tag match type, it's any when the string is empty or unknown
+func CvtToTagMatchType(s string) TagMatchType {
+	switch s {
+	case "all":
+		return TagMatchTypeAll
+	}
+	return TagMatchTypeAny
+}
+
+// String returns the string representation of the tag match type
+func (t TagMatchType) String() string {
+	switch t {
+	case TagMatchTypeAll:
+		return "all"
+	}
+	return "any"
+}
@@ -5,37 +5,87 @@ import (
 )
 
 // Transaction contains transaction information with main category and sub category
+// AccountID is the account of the transaction, or the source account of transfer, 0 means no account
+// ToAccountID is the destination account of transfer
+// Splits is empty unless the transaction is split across multiple categories
+// Tags are the cross-cutting labels of the transaction
+// Attachments are the receipts of the transaction
 type Transaction struct {
-	ID        int64           `json:"id"`
-	Type      TransactionType `json:"type"`
-	UserID    int64           `json:"user_id"`
-	MainCateg MainCateg       `json:"main_category"`
-	SubCateg  SubCateg        `json:"sub_category"`
-	Price     float64         `json:"price"`
-	Date      time.Time       `json:"date"`
-	Note      string          `json:"note"`
+	ID          int64              `json:"id"`
+	Type        TransactionType    `json:"type"`
+	UserID      int64              `json:"user_id"`
+	MainCateg   MainCateg          `json:"main_category"`
+	SubCateg    SubCateg           `json:"sub_category"`
+	Price       float64            `json:"price"`
+	Currency    string             `json:"currency"`
+	Date        time.Time          `json:"date"`
+	Note        string             `json:"note"`
+	AccountID   int64              `json:"account_id"`
+	ToAccountID int64              `json:"to_account_id"`
+	Splits      []TransactionSplit `json:"splits"`
+	Tags        []Tag              `json:"tags"`
+	Attachments []TransAttachment  `json:"attachments"`
+}
+
+// TransactionSplit is a line of a split transaction, which has its own category and amount
+type TransactionSplit struct {
+	MainCateg MainCateg `json:"main_category"`
+	SubCateg  SubCateg  `json:"sub_category"`
+	Price     float64   `json:"price"`
+}
+
+// SplitInput represents input of a line of a split transaction
+type SplitInput struct {
+	MainCategID int64   `json:"main_category_id"`
+	SubCategID  int64   `json:"sub_category_id"`
+	Price       float64 `json:"price"`
 }
 
 // CreateTransactionInput represents input for creating transaction
+// Currency is the user's base currency when it's empty
+// Transfer doesn't have categories, but has both AccountID and ToAccountID
+// Splits are the lines of a split transaction, which sum to the price, and the transaction is categorized by the first line
+// TagIDs are the tags of the transaction, which must belong to the user
+// IsRecurring is set when the transaction is an occurrence created by a recurring transaction, it's never set by the client
 type CreateTransactionInput struct {
 	UserID      int64           `json:"user_id"`
 	Type        TransactionType `json:"type"`
 	MainCategID int64           `json:"main_category_id"`
 	SubCategID  int64           `json:"sub_category_id"`
 	Price       float64         `json:"price"`
+	Currency    string          `json:"currency"`
 	Date        time.Time       `json:"date"`
 	Note        string          `json:"note"`
+	AccountID   int64           `json:"account_id"`
+	ToAccountID int64           `json:"to_account_id"`
+	Splits      []SplitInput    `json:"splits"`
+	TagIDs      []int64         `json:"tag_ids"`
+	IsRecurring bool            `json:"-"`
+}
+
+// IsUncategorized returns true if the client leaves the categories out, so that the transaction is categorized by rules
+func (t CreateTransactionInput) IsUncategorized() bool {
+	return t.Type != TransactionTypeTransfer && len(t.Splits) == 0 && t.MainCategID == 0 && t.SubCategID == 0
 }
 
 // UpdateTransactionInput represents input for updating transaction
+// Currency is the user's base currency when it's empty
+// Transfer doesn't have categories, but has both AccountID and ToAccountID
+// Splits are the lines of a split transaction, which sum to the price, and the transaction is categorized by the first line
+// TagIDs are the tags of the transaction, which must belong to the user
 type UpdateTransactionInput struct {
 	ID          int64           `json:"id"`
 	Type        TransactionType `json:"type"`
 	MainCategID int64           `json:"main_category_id"`
 	SubCategID  int64           `json:"sub_category_id"`
 	Price       float64         `json:"price"`
+	Currency    string          `json:"currency"`
 	Date        time.Time       `json:"date"`
 	Note        string          `json:"note"`
+	AccountID   int64           `json:"account_id"`
+	ToAccountID int64           `json:"to_account_id"`
+	Splits      []SplitInput    `json:"splits"`
+	TagIDs      []int64         `json:"tag_ids"`
 }
 
 // AccInfo contains accumulated information
@@ -43,16 +93,20 @@ type AccInfo struct {
 	TotalIncome  float64 `json:"total_income"`
 	TotalExpense float64 `json:"total_expense"`
 	TotalBalance float64 `json:"total_balance"`
+	Unconverted  int64   `json:"unconverted"`
 }
 
 // Filter contains filter for getting transactions
+// TagMatch decides if a transaction must have any or all of TagIDs
 type Filter struct {
-	StartDate    *time.Time
-	EndDate      *time.Time
-	MinPrice     *float64
-	MaxPrice     *float64
-	MainCategIDs []int64
-	SubCategIDs  []int64
+	StartDate    *time.Time   `json:"start_date"`
+	EndDate      *time.Time   `json:"end_date"`
+	MinPrice     *float64     `json:"min_price"`
+	MaxPrice     *float64     `json:"max_price"`
+	MainCategIDs []int64      `json:"main_category_ids"`
+	SubCategIDs  []int64      `json:"sub_category_ids"`
+	TagIDs       []int64      `json:"tag_ids"`
+	TagMatch     TagMatchType `json:"tag_match"`
 }
 
 // Sort contains sort by and sort direction
@@ -61,14 +115,56 @@ type Sort struct {
 	Dir SortDirType `json:"sort_direction"`
 }
 
-// Search contains keyword for searching transactions
+// Search contains keyword and the parsed search query for searching transactions
 type Search struct {
-	Keyword *string `json:"keyword"`
+	Keyword *string      `json:"keyword"`
+	Query   *SearchQuery `json:"query"`
+}
+
+// SearchQuery is the parsed search query, a transaction must match all of its conditions.
+// Raw is the query as the user typed it.
+// Terms are words or phrases matched against the note by full-text search.
+// Categs match the name of the main or sub category, and Tags match the name of any tag of the transaction.
+type SearchQuery struct {
+	Raw    string            `json:"raw"`
+	Terms  []SearchTerm      `json:"terms"`
+	Categs []SearchTerm      `json:"categories"`
+	Tags   []SearchTerm      `json:"tags"`
+	Types  []SearchTypeCond  `json:"types"`
+	Prices []SearchPriceCond `json:"prices"`
+	Dates  []SearchDateCond  `json:"dates"`
+}
+
+// SearchTerm is a value to match in search query, and the transaction must not match it when Negated is true
+type SearchTerm struct {
+	Value   string `json:"value"`
+	Negated bool   `json:"negated"`
+}
+
+// SearchTypeCond is the condition on the type of transaction in search query
+type SearchTypeCond struct {
+	Type    TransactionType `json:"type"`
+	Negated bool            `json:"negated"`
+}
+
+// SearchPriceCond compares the price of transaction with Value
+type SearchPriceCond struct {
+	Op    CmpOpType `json:"op"`
+	Value float64   `json:"value"`
 }
 
-// Cursor contains next key for pagination
+// SearchDateCond compares the date of transaction with Value
+type SearchDateCond struct {
+	Op    CmpOpType `json:"op"`
+	Value time.Time `json:"value"`
+}
+
+// Cursor contains next key and previous key for pagination
+// NextKey is the position of the last transaction of the page, and the next page comes after it.
+// PrevKey is the position of the first transaction of the page, and the previous page comes before it.
 type Cursor struct {
 	NextKey string `json:"next_key"`
+	PrevKey string `json:"prev_key"`
 	Size    int    `json:"size"`
 }
 
@@ -82,11 +178,23 @@ type DecodedNextKeyInfo struct {
 }
 
 // GetTransOpt contains options for getting transactions
+// ViewID is the saved view to apply, which replaces the filter, sort and search, 0 means no view
 type GetTransOpt struct {
 	Filter Filter `json:"filter"`
 	Sort   *Sort  `json:"sort"`
 	Search Search `json:"search"`
 	Cursor Cursor `json:"cursor"`
+	ViewID int64  `json:"view_id"`
+}
+
+// TransTotal is the total of the transactions matching the filter and search, regardless of the cursor.
+// Income and Expense are in the base currency of the user
+type TransTotal struct {
+	Count   int64   `json:"count"`
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+	// Unconverted is the number of transactions left out of income and expense, because there's no exchange rate
+	Unconverted int64 `json:"unconverted"`
 }
 
 // GetAccInfoQuery contains query for getting accumulated information
@@ -109,4 +217,6 @@ type MonthlyAggregatedData struct {
 	UserID       int64
 	TotalIncome  float64
 	TotalExpense float64
+	// Unconverted is the number of transactions left out of the totals, because there's no exchange rate
+	Unconverted int64
 }
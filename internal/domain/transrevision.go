@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// TransRevision is an entry of the append-only history of a transaction.
+// Before is nil on create, and After is nil on delete.
+// UserID is the user who made the change.
+type TransRevision struct {
+	ID            int64              `json:"id"`
+	TransactionID int64              `json:"transaction_id"`
+	UserID        int64              `json:"user_id"`
+	Action        RevisionActionType `json:"action"`
+	Before        *TransSnapshot     `json:"before"`
+	After         *TransSnapshot     `json:"after"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+// TransSnapshot contains the editable fields of a transaction at a point in time
+type TransSnapshot struct {
+	Type        TransactionType `json:"type"`
+	MainCategID int64           `json:"main_category_id"`
+	SubCategID  int64           `json:"sub_category_id"`
+	Price       float64         `json:"price"`
+	Currency    string          `json:"currency"`
+	Date        time.Time       `json:"date"`
+	Note        string          `json:"note"`
+	AccountID   int64           `json:"account_id"`
+	ToAccountID int64           `json:"to_account_id"`
+	Splits      []SplitInput    `json:"splits"`
+	TagIDs      []int64         `json:"tag_ids"`
+}
+
+// NewTransSnapshot takes a snapshot of the transaction
+func NewTransSnapshot(t Transaction) *TransSnapshot {
+	s := &TransSnapshot{
+		Type:        t.Type,
+		MainCategID: t.MainCateg.ID,
+		SubCategID:  t.SubCateg.ID,
+		Price:       t.Price,
+		Currency:    t.Currency,
+		Date:        t.Date,
+		Note:        t.Note,
+		AccountID:   t.AccountID,
+		ToAccountID: t.ToAccountID,
+	}
+
+	for _, sp := range t.Splits {
+		s.Splits = append(s.Splits, SplitInput{
+			MainCategID: sp.MainCateg.ID,
+			SubCategID:  sp.SubCateg.ID,
+			Price:       sp.Price,
+		})
+	}
+
+	for _, tag := range t.Tags {
+		s.TagIDs = append(s.TagIDs, tag.ID)
+	}
+
+	return s
+}
+
+// ToUpdateInput converts the snapshot to the input for updating the transaction back to it
+func (s TransSnapshot) ToUpdateInput(id int64) UpdateTransactionInput {
+	return UpdateTransactionInput{
+		ID:          id,
+		Type:        s.Type,
+		MainCategID: s.MainCategID,
+		SubCategID:  s.SubCategID,
+		Price:       s.Price,
+		Currency:    s.Currency,
+		Date:        s.Date,
+		Note:        s.Note,
+		AccountID:   s.AccountID,
+		ToAccountID: s.ToAccountID,
+		Splits:      s.Splits,
+		TagIDs:      s.TagIDs,
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+const (
+	// TrashRetention is how long items stay in trash before they're purged
+	TrashRetention = 30 * 24 * time.Hour
+)
+
+// TrashItem contains information of a deleted transaction or category, which can be restored until it's purged.
+// Name is the note of the transaction, or the name of the category.
+// Price and Date are only set on transaction.
+type TrashItem struct {
+	Kind      TrashKind  `json:"kind"`
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Price     float64    `json:"price"`
+	Date      *time.Time `json:"date"`
+	DeletedAt time.Time  `json:"deleted_at"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+// TrashKind is an enumeration of the kinds of items in trash
+type TrashKind int64
+
+const (
+	// TrashKindUnSpecified is an enumeration of unspecified trash kind
+	TrashKindUnSpecified TrashKind = iota
+
+	// TrashKindTransaction is an enumeration of transaction in trash
+	TrashKindTransaction
+
+	// TrashKindMainCateg is an enumeration of main category in trash
+	TrashKindMainCateg
+
+	// TrashKindSubCateg is an enumeration of sub category in trash
+	TrashKindSubCateg
+)
+
+// IsValid checks if the trash kind is valid
+func (k TrashKind) IsValid() bool {
+	switch k {
+	case TrashKindTransaction, TrashKindMainCateg, TrashKindSubCateg:
+		return true
+	}
+	return false
+}
+
+// ToString returns the string representation of the trash kind
+func (k TrashKind) ToString() string {
+	switch k {
+	case TrashKindTransaction:
+		return "transaction"
+	case TrashKindMainCateg:
+		return "main-category"
+	case TrashKindSubCateg:
+		return "sub-category"
+	}
+	return "unspecified"
+}
+
+// CvtToTrashKind converts a string to a trash kind
+func CvtToTrashKind(s string) TrashKind {
+	switch s {
+	case "transaction":
+		return TrashKindTransaction
+	case "main-category":
+		return TrashKindMainCateg
+	case "sub-category":
+		return TrashKindSubCateg
+	}
+	return TrashKindUnSpecified
+}
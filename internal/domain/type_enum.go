@@ -13,6 +13,9 @@ const (
 	// TransactionTypeBoth is an enumeration of both income and expense transaction type
 	// it's only used in monthly data
 	TransactionTypeBoth
+	// TransactionTypeTransfer is an enumeration of transfer transaction type
+	// it moves money between two accounts, and is neither income nor expense
+	TransactionTypeTransfer
 )
 
 // ToString returns the string representation of TransactionType
@@ -24,6 +27,8 @@ func (t TransactionType) ToString() string {
 		return "expense"
 	case TransactionTypeBoth:
 		return "both"
+	case TransactionTypeTransfer:
+		return "transfer"
 	}
 	return "unknown type"
 }
@@ -35,6 +40,8 @@ func (t TransactionType) ToModelValue() string {
 		return "1"
 	case TransactionTypeExpense:
 		return "2"
+	case TransactionTypeTransfer:
+		return "3"
 	}
 	return "0"
 }
@@ -57,10 +64,14 @@ func CvtToTransactionType(s string) TransactionType {
 		return TransactionTypeExpense
 	case "both":
 		return TransactionTypeBoth
+	case "transfer":
+		return TransactionTypeTransfer
 	case "1":
 		return TransactionTypeIncome
 	case "2":
 		return TransactionTypeExpense
+	case "3":
+		return TransactionTypeTransfer
 	}
 	return TransactionTypeUnSpecified
 }
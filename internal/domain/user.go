@@ -6,6 +6,8 @@ type User struct {
 	Name              string
 	Email             string
 	IsSetInitCategory bool
+	BaseCurrency      string
+	IsEmailVerified   bool
 	Password          string
 	Password_hash     string
 }
@@ -13,6 +15,9 @@ type User struct {
 // UpdateUserOpt contains option to update user
 type UpdateUserOpt struct {
 	IsSetInitCategory *bool
+	BaseCurrency      *string
+	PasswordHash      *string
+	IsEmailVerified   *bool
 }
 
 // Token contains access token and refresh token
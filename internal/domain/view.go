@@ -0,0 +1,22 @@
+package domain
+
+// View is a saved combination of filter, sort and search for getting transactions, e.g. "dining this quarter over $50"
+// Sort is nil when the transactions are in the default order
+type View struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Filter Filter `json:"filter"`
+	Sort   *Sort  `json:"sort"`
+	Search Search `json:"search"`
+}
+
+// Apply returns opt with the filter, sort and search of the view, and the cursor of opt is kept
+func (v View) Apply(opt GetTransOpt) GetTransOpt {
+	return GetTransOpt{
+		Filter: v.Filter,
+		Sort:   v.Sort,
+		Search: v.Search,
+		Cursor: opt.Cursor,
+		ViewID: v.ID,
+	}
+}
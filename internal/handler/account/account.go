@@ -0,0 +1,197 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+)
+
+const (
+	packageName = "handler/account"
+)
+
+type Hlr struct {
+	account interfaces.AccountUC
+}
+
+func New(a interfaces.AccountUC) *Hlr {
+	return &Hlr{
+		account: a,
+	}
+}
+
+func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
+	var input accountReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	a := domain.Account{
+		Name:           strings.TrimSpace(input.Name),
+		Type:           domain.CvtToAccountType(input.Type),
+		InitialBalance: input.InitialBalance,
+	}
+
+	v := validator.New()
+	if !v.CreateAccount(a) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.account.Create(r.Context(), a, user.ID); err != nil {
+		if errors.Is(err, domain.ErrUniqueNameUser) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetLedgerUser(r)
+	accounts, err := h.account.GetAll(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"accounts": cvtToAccountsResp(accounts),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input accountReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	a := domain.Account{
+		ID:             id,
+		Name:           strings.TrimSpace(input.Name),
+		Type:           domain.CvtToAccountType(input.Type),
+		InitialBalance: input.InitialBalance,
+	}
+
+	v := validator.New()
+	if !v.UpdateAccount(a) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrAccountNotFound,
+		domain.ErrUniqueNameUser,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.account.Update(r.Context(), a, user.ID); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.Delete(id) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.account.Delete(r.Context(), id, user.ID); err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetBalance(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	balance, err := h.account.GetBalance(r.Context(), id, user.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"balance": cvtToAccountBalanceResp(balance),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
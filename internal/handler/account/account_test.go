@@ -0,0 +1,233 @@
+package account_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/account"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type AccountSuite struct {
+	suite.Suite
+	hlr           *account.Hlr
+	mockAccountUC *mocks.AccountUC
+}
+
+func TestAccountSuite(t *testing.T) {
+	suite.Run(t, new(AccountSuite))
+}
+
+func (s *AccountSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *AccountSuite) SetupTest() {
+	s.mockAccountUC = mocks.NewAccountUC(s.T())
+	s.hlr = account.New(s.mockAccountUC)
+}
+
+func (s *AccountSuite) TearDownTest() {
+	s.mockAccountUC.AssertExpectations(s.T())
+}
+
+func (s *AccountSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *AccountSuite, desc string){
+		"when no error, create successfully":          create_NoError_CreateSuccessfully,
+		"when type is invalid, return bad request":    create_InvalidType_ReturnBadReq,
+		"when name is duplicated, return bad request": create_DuplicateName_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *AccountSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "wallet", "type": "cash", "initial_balance": 100})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/account", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	a := domain.Account{Name: "wallet", Type: domain.AccountTypeCash, InitialBalance: 100}
+	s.mockAccountUC.On("Create", req.Context(), a, int64(1)).Return(nil).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_InvalidType_ReturnBadReq(s *AccountSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "wallet", "type": "stock"})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/account", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"type": "Type must be cash, bank or credit_card"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_DuplicateName_ReturnBadReq(s *AccountSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "wallet", "type": "cash"})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/account", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	a := domain.Account{Name: "wallet", Type: domain.AccountTypeCash}
+	s.mockAccountUC.On("Create", req.Context(), a, int64(1)).Return(domain.ErrUniqueNameUser).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *AccountSuite) TestGetBalance() {
+	for scenario, fn := range map[string]func(s *AccountSuite, desc string){
+		"when no error, return balance":              getBalance_NoError_ReturnBalance,
+		"when account not found, return bad request": getBalance_AccountNotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getBalance_NoError_ReturnBalance(s *AccountSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.GetBalance))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/account/1/balance", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	balance := domain.AccountBalance{
+		Account: domain.Account{ID: 1, Name: "bank", Type: domain.AccountTypeBank, InitialBalance: 100},
+		Balance: 30,
+		Entries: []domain.AccountEntry{
+			{TransactionID: 2, Type: domain.TransactionTypeTransfer, Date: date, Note: "rent", Amount: -70, Balance: 30},
+			{TransactionID: 3, Type: domain.TransactionTypeExpense, Date: date, Note: "sushi", Balance: 30, IsUnconverted: true},
+		},
+		Unconverted: 1,
+	}
+	s.mockAccountUC.On("GetBalance", req.Context(), int64(1), int64(1)).Return(balance, nil).Once()
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"balance": map[string]interface{}{
+			"account": map[string]interface{}{
+				"id":              float64(1),
+				"name":            "bank",
+				"type":            "bank",
+				"initial_balance": float64(100),
+			},
+			"balance": float64(30),
+			"entries": []interface{}{
+				map[string]interface{}{
+					"transaction_id": float64(2),
+					"type":           "transfer",
+					"date":           "2026-01-01T00:00:00Z",
+					"note":           "rent",
+					"amount":         float64(-70),
+					"balance":        float64(30),
+					"is_unconverted": false,
+				},
+				map[string]interface{}{
+					"transaction_id": float64(3),
+					"type":           "expense",
+					"date":           "2026-01-01T00:00:00Z",
+					"note":           "sushi",
+					"amount":         float64(0),
+					"balance":        float64(30),
+					"is_unconverted": true,
+				},
+			},
+			"unconverted": float64(1),
+		},
+	}
+
+	s.hlr.GetBalance(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func getBalance_AccountNotFound_ReturnBadReq(s *AccountSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.GetBalance))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/account/1/balance", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockAccountUC.On("GetBalance", req.Context(), int64(1), int64(1)).Return(domain.AccountBalance{}, domain.ErrAccountNotFound).Once()
+
+	s.hlr.GetBalance(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
@@ -0,0 +1,45 @@
+package account
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToAccountResp(a domain.Account) account {
+	return account{
+		ID:             a.ID,
+		Name:           a.Name,
+		Type:           a.Type.ToString(),
+		InitialBalance: a.InitialBalance,
+	}
+}
+
+func cvtToAccountsResp(accounts []domain.Account) []account {
+	resp := make([]account, 0, len(accounts))
+
+	for _, a := range accounts {
+		resp = append(resp, cvtToAccountResp(a))
+	}
+
+	return resp
+}
+
+func cvtToAccountBalanceResp(b domain.AccountBalance) accountBalance {
+	entries := make([]accountEntry, 0, len(b.Entries))
+
+	for _, e := range b.Entries {
+		entries = append(entries, accountEntry{
+			TransactionID: e.TransactionID,
+			Type:          e.Type.ToString(),
+			Date:          e.Date,
+			Note:          e.Note,
+			Amount:        e.Amount,
+			Balance:       e.Balance,
+			IsUnconverted: e.IsUnconverted,
+		})
+	}
+
+	return accountBalance{
+		Account:     cvtToAccountResp(b.Account),
+		Balance:     b.Balance,
+		Entries:     entries,
+		Unconverted: b.Unconverted,
+	}
+}
@@ -0,0 +1,33 @@
+package account
+
+import "time"
+
+type accountReq struct {
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	InitialBalance float64 `json:"initial_balance"`
+}
+
+type account struct {
+	ID             int64   `json:"id"`
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	InitialBalance float64 `json:"initial_balance"`
+}
+
+type accountEntry struct {
+	TransactionID int64     `json:"transaction_id"`
+	Type          string    `json:"type"`
+	Date          time.Time `json:"date"`
+	Note          string    `json:"note"`
+	Amount        float64   `json:"amount"`
+	Balance       float64   `json:"balance"`
+	IsUnconverted bool      `json:"is_unconverted"`
+}
+
+type accountBalance struct {
+	Account     account        `json:"account"`
+	Balance     float64        `json:"balance"`
+	Entries     []accountEntry `json:"entries"`
+	Unconverted int64          `json:"unconverted"`
+}
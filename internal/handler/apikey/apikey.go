@@ -0,0 +1,117 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+)
+
+const (
+	packageName = "handler/apikey"
+)
+
+type Hlr struct {
+	apiKey interfaces.APIKeyUC
+}
+
+func New(k interfaces.APIKeyUC) *Hlr {
+	return &Hlr{
+		apiKey: k,
+	}
+}
+
+func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
+	var input apiKeyReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	k := domain.APIKey{
+		Name:      strings.TrimSpace(input.Name),
+		Scopes:    input.Scopes,
+		ExpiresAt: input.ExpiresAt,
+	}
+
+	v := validator.New()
+	if !v.CreateAPIKey(k) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	key, rawKey, err := h.apiKey.Create(r.Context(), k, user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	// the key is only stored as hash, so it can't be shown again
+	respData := map[string]interface{}{
+		"api_key": cvtToAPIKeyResp(key),
+		"key":     rawKey,
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetUser(r)
+	keys, err := h.apiKey.GetAll(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"api_keys": cvtToAPIKeysResp(keys),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.Delete(id) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	if err := h.apiKey.Delete(r.Context(), id, user.ID); err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,181 @@
+package apikey_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/apikey"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeySuite struct {
+	suite.Suite
+	hlr          *apikey.Hlr
+	mockAPIKeyUC *mocks.APIKeyUC
+}
+
+func TestAPIKeySuite(t *testing.T) {
+	suite.Run(t, new(APIKeySuite))
+}
+
+func (s *APIKeySuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *APIKeySuite) SetupTest() {
+	s.mockAPIKeyUC = mocks.NewAPIKeyUC(s.T())
+	s.hlr = apikey.New(s.mockAPIKeyUC)
+}
+
+func (s *APIKeySuite) TearDownTest() {
+	s.mockAPIKeyUC.AssertExpectations(s.T())
+}
+
+func (s *APIKeySuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *APIKeySuite, desc string){
+		"when no error, return key once":            create_NoError_ReturnKeyOnce,
+		"when scope is invalid, return bad request": create_InvalidScope_ReturnBadReq,
+		"when expiry is passed, return bad request": create_ExpiryPassed_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_ReturnKeyOnce(s *APIKeySuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"name":   " spreadsheet ",
+		"scopes": []string{"transactions:read", "charts:read"},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/api-keys", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	createdAt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	expKey := domain.APIKey{
+		Name:   "spreadsheet",
+		Scopes: []string{domain.ScopeTransactionsRead, domain.ScopeChartsRead},
+	}
+	created := domain.APIKey{
+		ID:        2,
+		UserID:    1,
+		Name:      "spreadsheet",
+		Prefix:    "etk_12345678",
+		Scopes:    []string{domain.ScopeTransactionsRead, domain.ScopeChartsRead},
+		CreatedAt: createdAt,
+	}
+	s.mockAPIKeyUC.On("Create", req.Context(), expKey, int64(1)).Return(created, "etk_12345678abcdef", nil).Once()
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"api_key": map[string]interface{}{
+			"id":           float64(2),
+			"name":         "spreadsheet",
+			"prefix":       "etk_12345678",
+			"scopes":       []interface{}{"transactions:read", "charts:read"},
+			"expires_at":   nil,
+			"last_used_at": nil,
+			"created_at":   "2026-10-18T00:00:00Z",
+		},
+		"key": "etk_12345678abcdef",
+	}
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_InvalidScope_ReturnBadReq(s *APIKeySuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"name":   "spreadsheet",
+		"scopes": []string{"transactions:read", "budgets:read", "transactions:read"},
+	})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/api-keys", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	expResp := map[string]interface{}{
+		"scopes[1]": "Scope must be transactions:read, transactions:write, charts:read or categories:read",
+		"scopes[2]": "Scopes can't be duplicated",
+	}
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_ExpiryPassed_ReturnBadReq(s *APIKeySuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"name":       "spreadsheet",
+		"scopes":     []string{"charts:read"},
+		"expires_at": "2020-01-01T00:00:00Z",
+	})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/api-keys", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"expires_at": "Expires at must be in the future"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *APIKeySuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *APIKeySuite, desc string){
+		"when key not found, return bad request": delete_NotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NotFound_ReturnBadReq(s *APIKeySuite, desc string) {
+	user := domain.User{ID: 1}
+	req := httptest.NewRequest(http.MethodDelete, "/v1/user/api-keys/2", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+
+	s.mockAPIKeyUC.On("Delete", req.Context(), int64(2), int64(1)).Return(domain.ErrAPIKeyNotFound).Once()
+
+	s.hlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
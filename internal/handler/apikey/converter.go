@@ -0,0 +1,24 @@
+package apikey
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToAPIKeyResp(k domain.APIKey) apiKey {
+	return apiKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+func cvtToAPIKeysResp(keys []domain.APIKey) []apiKey {
+	resp := make([]apiKey, 0, len(keys))
+	for _, k := range keys {
+		resp = append(resp, cvtToAPIKeyResp(k))
+	}
+
+	return resp
+}
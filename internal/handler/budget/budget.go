@@ -0,0 +1,153 @@
+package budget
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+)
+
+const (
+	packageName = "handler/budget"
+	monthLayout = "2006-01"
+)
+
+type Hlr struct {
+	budget interfaces.BudgetUC
+}
+
+func New(b interfaces.BudgetUC) *Hlr {
+	return &Hlr{
+		budget: b,
+	}
+}
+
+func (h *Hlr) Set(w http.ResponseWriter, r *http.Request) {
+	var input setBudgetReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	b := domain.Budget{
+		MainCateg: domain.MainCateg{ID: input.MainCategID},
+		Amount:    input.Amount,
+	}
+
+	v := validator.New()
+	if !v.SetBudget(b) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrMainCategNotFound,
+		domain.ErrBudgetCategNotExpense,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.budget.Set(r.Context(), b, user.ID); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetLedgerUser(r)
+	budgets, err := h.budget.GetAll(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"budgets": cvtToBudgetResp(budgets),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.Delete(id) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.budget.Delete(r.Context(), id, user.ID); err != nil {
+		if errors.Is(err, domain.ErrBudgetNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetStatus(w http.ResponseWriter, r *http.Request) {
+	// default to current month
+	month := time.Now()
+	if m := r.URL.Query().Get("month"); m != "" {
+		t, err := time.Parse(monthLayout, m)
+		if err != nil {
+			errutil.VildateErrorResponse(w, r, map[string]string{"month": "Month must be in YYYY-MM format"})
+			return
+		}
+
+		month = t
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	statuses, err := h.budget.GetStatus(r.Context(), month, user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"month":   month.Format(monthLayout),
+		"budgets": cvtToBudgetStatusResp(statuses),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
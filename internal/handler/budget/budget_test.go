@@ -0,0 +1,210 @@
+package budget_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/budget"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetSuite struct {
+	suite.Suite
+	hlr          *budget.Hlr
+	mockBudgetUC *mocks.BudgetUC
+}
+
+func TestBudgetSuite(t *testing.T) {
+	suite.Run(t, new(BudgetSuite))
+}
+
+func (s *BudgetSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *BudgetSuite) SetupTest() {
+	s.mockBudgetUC = mocks.NewBudgetUC(s.T())
+	s.hlr = budget.New(s.mockBudgetUC)
+}
+
+func (s *BudgetSuite) TearDownTest() {
+	s.mockBudgetUC.AssertExpectations(s.T())
+}
+
+func (s *BudgetSuite) TestSet() {
+	for scenario, fn := range map[string]func(s *BudgetSuite, desc string){
+		"when no error, set successfully":                       set_NoError_SetSuccessfully,
+		"when amount is not positive, return bad request":       set_InvalidAmount_ReturnBadReq,
+		"when main category is not expense, return bad request": set_CategNotExpense_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func set_NoError_SetSuccessfully(s *BudgetSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"main_category_id": 2, "amount": 500})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Set))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/budget", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	b := domain.Budget{MainCateg: domain.MainCateg{ID: 2}, Amount: 500}
+	s.mockBudgetUC.On("Set", req.Context(), b, int64(1)).Return(nil).Once()
+
+	s.hlr.Set(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func set_InvalidAmount_ReturnBadReq(s *BudgetSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"main_category_id": 2, "amount": 0})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Set))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/budget", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Set(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"amount": "Amount must be greater than 0"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func set_CategNotExpense_ReturnBadReq(s *BudgetSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"main_category_id": 2, "amount": 500})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Set))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/budget", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	b := domain.Budget{MainCateg: domain.MainCateg{ID: 2}, Amount: 500}
+	s.mockBudgetUC.On("Set", req.Context(), b, int64(1)).Return(domain.ErrBudgetCategNotExpense).Once()
+
+	s.hlr.Set(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *BudgetSuite) TestGetStatus() {
+	for scenario, fn := range map[string]func(s *BudgetSuite, desc string){
+		"when no error, return status":                   getStatus_NoError_ReturnStatus,
+		"when month is wrong format, return bad request": getStatus_WrongMonthFormat_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getStatus_NoError_ReturnStatus(s *BudgetSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.GetStatus))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/budget/status?month=2024-02", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	statuses := []domain.BudgetStatus{
+		{
+			Budget:       domain.Budget{ID: 1, MainCateg: domain.MainCateg{ID: 2, Name: "food"}, Amount: 100},
+			Spent:        150,
+			Remaining:    -50,
+			PercentUsed:  150,
+			IsOverBudget: true,
+		},
+	}
+	month := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	s.mockBudgetUC.On("GetStatus", req.Context(), month, int64(1)).Return(statuses, nil).Once()
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"month": "2024-02",
+		"budgets": []interface{}{
+			map[string]interface{}{
+				"id":             float64(1),
+				"main_category":  map[string]interface{}{"id": float64(2), "name": "food"},
+				"limit":          float64(100),
+				"spent":          float64(150),
+				"remaining":      float64(-50),
+				"percent_used":   float64(150),
+				"unconverted":    float64(0),
+				"is_over_budget": true,
+			},
+		},
+	}
+
+	s.hlr.GetStatus(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func getStatus_WrongMonthFormat_ReturnBadReq(s *BudgetSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.GetStatus))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/budget/status?month=2024/02", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.GetStatus(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
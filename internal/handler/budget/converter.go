@@ -0,0 +1,42 @@
+package budget
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToBudgetResp(budgets []domain.Budget) []budget {
+	resp := make([]budget, 0, len(budgets))
+
+	for _, b := range budgets {
+		resp = append(resp, budget{
+			ID: b.ID,
+			MainCateg: mainCateg{
+				ID:   b.MainCateg.ID,
+				Name: b.MainCateg.Name,
+			},
+			Amount: b.Amount,
+		})
+	}
+
+	return resp
+}
+
+func cvtToBudgetStatusResp(statuses []domain.BudgetStatus) []budgetStatus {
+	resp := make([]budgetStatus, 0, len(statuses))
+
+	for _, s := range statuses {
+		resp = append(resp, budgetStatus{
+			ID: s.Budget.ID,
+			MainCateg: mainCateg{
+				ID:   s.Budget.MainCateg.ID,
+				Name: s.Budget.MainCateg.Name,
+			},
+			Limit:        s.Budget.Amount,
+			Spent:        s.Spent,
+			Remaining:    s.Remaining,
+			PercentUsed:  s.PercentUsed,
+			IsOverBudget: s.IsOverBudget,
+			Unconverted:  s.Unconverted,
+		})
+	}
+
+	return resp
+}
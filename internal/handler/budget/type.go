@@ -0,0 +1,28 @@
+package budget
+
+type setBudgetReq struct {
+	MainCategID int64   `json:"main_category_id"`
+	Amount      float64 `json:"amount"`
+}
+
+type mainCateg struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type budget struct {
+	ID        int64     `json:"id"`
+	MainCateg mainCateg `json:"main_category"`
+	Amount    float64   `json:"amount"`
+}
+
+type budgetStatus struct {
+	ID           int64     `json:"id"`
+	MainCateg    mainCateg `json:"main_category"`
+	Limit        float64   `json:"limit"`
+	Spent        float64   `json:"spent"`
+	Remaining    float64   `json:"remaining"`
+	PercentUsed  float64   `json:"percent_used"`
+	IsOverBudget bool      `json:"is_over_budget"`
+	Unconverted  int64     `json:"unconverted"`
+}
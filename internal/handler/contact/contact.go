@@ -0,0 +1,171 @@
+package contact
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+)
+
+const (
+	packageName = "handler/contact"
+)
+
+type Hlr struct {
+	contact interfaces.ContactUC
+}
+
+func New(c interfaces.ContactUC) *Hlr {
+	return &Hlr{
+		contact: c,
+	}
+}
+
+func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
+	var input contactReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	c := domain.Contact{
+		Name:  strings.TrimSpace(input.Name),
+		Email: strings.TrimSpace(input.Email),
+	}
+
+	v := validator.New()
+	if !v.CreateContact(c) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.contact.Create(r.Context(), c, user.ID); err != nil {
+		if errors.Is(err, domain.ErrUniqueContactNameUser) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetLedgerUser(r)
+	contacts, err := h.contact.GetAll(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"contacts": cvtToContactsResp(contacts),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input contactReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	c := domain.Contact{
+		ID:    id,
+		Name:  strings.TrimSpace(input.Name),
+		Email: strings.TrimSpace(input.Email),
+	}
+
+	v := validator.New()
+	if !v.UpdateContact(c) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrContactNotFound,
+		domain.ErrUniqueContactNameUser,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.contact.Update(r.Context(), c, user.ID); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.Delete(id) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrContactNotFound,
+		domain.ErrContactInUse,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.contact.Delete(r.Context(), id, user.ID); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
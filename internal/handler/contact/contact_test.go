@@ -0,0 +1,134 @@
+package contact_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/contact"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContactSuite struct {
+	suite.Suite
+	hlr           *contact.Hlr
+	mockContactUC *mocks.ContactUC
+}
+
+func TestContactSuite(t *testing.T) {
+	suite.Run(t, new(ContactSuite))
+}
+
+func (s *ContactSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ContactSuite) SetupTest() {
+	s.mockContactUC = mocks.NewContactUC(s.T())
+	s.hlr = contact.New(s.mockContactUC)
+}
+
+func (s *ContactSuite) TearDownTest() {
+	s.mockContactUC.AssertExpectations(s.T())
+}
+
+func (s *ContactSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *ContactSuite, desc string){
+		"when no error, create successfully":     create_NoError_CreateSuccessfully,
+		"when name is empty, return bad request": create_EmptyName_ReturnBadReq,
+		"when name is used, return bad request":  create_NameUsed_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *ContactSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": " Alice ", "email": "alice@a.com"})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/contact", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockContactUC.On("Create", req.Context(), domain.Contact{Name: "Alice", Email: "alice@a.com"}, int64(1)).Return(nil).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_EmptyName_ReturnBadReq(s *ContactSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": " "})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/contact", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"name": "Name can't be empty"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_NameUsed_ReturnBadReq(s *ContactSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "Alice"})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/contact", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.mockContactUC.On("Create", req.Context(), domain.Contact{Name: "Alice"}, int64(1)).Return(domain.ErrUniqueContactNameUser).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *ContactSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *ContactSuite, desc string){
+		"when contact in use, return bad request": delete_InUse_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_InUse_ReturnBadReq(s *ContactSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/contact/2", nil)
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.mockContactUC.On("Delete", req.Context(), int64(2), int64(1)).Return(domain.ErrContactInUse).Once()
+
+	s.hlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
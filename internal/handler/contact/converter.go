@@ -0,0 +1,17 @@
+package contact
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToContactsResp(contacts []domain.Contact) []contact {
+	resp := make([]contact, 0, len(contacts))
+
+	for _, c := range contacts {
+		resp = append(resp, contact{
+			ID:    c.ID,
+			Name:  c.Name,
+			Email: c.Email,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,12 @@
+package contact
+
+type contactReq struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type contact struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
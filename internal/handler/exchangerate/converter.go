@@ -0,0 +1,41 @@
+package exchangerate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+// cvtToDomainExchangeRates converts the request to domain exchange rates.
+// The date is left zero when it's not in YYYY-MM-DD format, so that the validator reports it.
+func cvtToDomainExchangeRates(rates []exchangeRate) []domain.ExchangeRate {
+	result := make([]domain.ExchangeRate, 0, len(rates))
+
+	for _, r := range rates {
+		date, _ := time.Parse(time.DateOnly, r.Date)
+		result = append(result, domain.ExchangeRate{
+			From: strings.ToUpper(r.From),
+			To:   strings.ToUpper(r.To),
+			Date: date,
+			Rate: r.Rate,
+		})
+	}
+
+	return result
+}
+
+func cvtToExchangeRateResp(rates []domain.ExchangeRate) []exchangeRate {
+	resp := make([]exchangeRate, 0, len(rates))
+
+	for _, r := range rates {
+		resp = append(resp, exchangeRate{
+			From: r.From,
+			To:   r.To,
+			Date: r.Date.Format(time.DateOnly),
+			Rate: r.Rate,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,80 @@
+package exchangerate
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+)
+
+const (
+	packageName = "handler/exchangerate"
+)
+
+type Hlr struct {
+	exchangeRate interfaces.ExchangeRateUC
+}
+
+func New(e interfaces.ExchangeRateUC) *Hlr {
+	return &Hlr{
+		exchangeRate: e,
+	}
+}
+
+func (h *Hlr) Import(w http.ResponseWriter, r *http.Request) {
+	var input importExchangeRatesReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	rates := cvtToDomainExchangeRates(input.Rates)
+
+	v := validator.New()
+	if !v.ImportExchangeRates(rates) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	if err := h.exchangeRate.Import(r.Context(), rates); err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, map[string]interface{}{"imported": len(rates)}, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
+	from := strings.ToUpper(r.URL.Query().Get("from"))
+	to := strings.ToUpper(r.URL.Query().Get("to"))
+
+	v := validator.New()
+	if !v.GetExchangeRates(from, to) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	rates, err := h.exchangeRate.GetAll(r.Context(), from, to)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"exchange_rates": cvtToExchangeRateResp(rates),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
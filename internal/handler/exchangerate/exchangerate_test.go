@@ -0,0 +1,164 @@
+package exchangerate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/exchangerate"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExchangeRateSuite struct {
+	suite.Suite
+	hlr                *exchangerate.Hlr
+	mockExchangeRateUC *mocks.ExchangeRateUC
+}
+
+func TestExchangeRateSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateSuite))
+}
+
+func (s *ExchangeRateSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ExchangeRateSuite) SetupTest() {
+	s.mockExchangeRateUC = mocks.NewExchangeRateUC(s.T())
+	s.hlr = exchangerate.New(s.mockExchangeRateUC)
+}
+
+func (s *ExchangeRateSuite) TearDownTest() {
+	s.mockExchangeRateUC.AssertExpectations(s.T())
+}
+
+func (s *ExchangeRateSuite) TestImport() {
+	for scenario, fn := range map[string]func(s *ExchangeRateSuite, desc string){
+		"when no error, import successfully":       import_NoError_ImportSuccessfully,
+		"when rate is invalid, return bad request": import_InvalidRate_ReturnBadReq,
+		"when import fail, return server error":    import_ImportFail_ReturnServerError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func import_NoError_ImportSuccessfully(s *ExchangeRateSuite, desc string) {
+	body := []byte(`{"rates":[{"from":"eur","to":"usd","date":"2024-01-10","rate":1.1},{"from":"GBP","to":"USD","date":"2024-01-10","rate":1.27}]}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/exchange-rate/import", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+
+	rates := []domain.ExchangeRate{
+		{From: "EUR", To: "USD", Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+		{From: "GBP", To: "USD", Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Rate: 1.27},
+	}
+	s.mockExchangeRateUC.On("Import", req.Context(), rates).Return(nil).Once()
+
+	s.hlr.Import(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+	s.Require().Equal(map[string]interface{}{"imported": float64(2)}, responseBody, desc)
+}
+
+func import_InvalidRate_ReturnBadReq(s *ExchangeRateSuite, desc string) {
+	body := []byte(`{"rates":[{"from":"EUR","to":"EUR","date":"10/01/2024","rate":0}]}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/exchange-rate/import", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+
+	expResp := map[string]interface{}{
+		"rates[0].to":   "To must be different from from",
+		"rates[0].date": "Date must be in YYYY-MM-DD format",
+		"rates[0].rate": "Rate must be greater than 0",
+	}
+
+	s.hlr.Import(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+}
+
+func import_ImportFail_ReturnServerError(s *ExchangeRateSuite, desc string) {
+	body := []byte(`{"rates":[{"from":"EUR","to":"USD","date":"2024-01-10","rate":1.1}]}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/exchange-rate/import", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+
+	rates := []domain.ExchangeRate{
+		{From: "EUR", To: "USD", Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+	}
+	s.mockExchangeRateUC.On("Import", req.Context(), rates).Return(errors.New("error")).Once()
+
+	s.hlr.Import(res, req)
+
+	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
+}
+
+func (s *ExchangeRateSuite) TestGetAll() {
+	for scenario, fn := range map[string]func(s *ExchangeRateSuite, desc string){
+		"when no error, return rates":                  getAll_NoError_ReturnRates,
+		"when currency is invalid, return bad request": getAll_InvalidCurrency_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getAll_NoError_ReturnRates(s *ExchangeRateSuite, desc string) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/exchange-rate?from=eur&to=USD", nil)
+	res := httptest.NewRecorder()
+
+	rates := []domain.ExchangeRate{
+		{From: "EUR", To: "USD", Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+	}
+	s.mockExchangeRateUC.On("GetAll", req.Context(), "EUR", "USD").Return(rates, nil).Once()
+
+	expResp := map[string]interface{}{
+		"exchange_rates": []interface{}{
+			map[string]interface{}{"from": "EUR", "to": "USD", "date": "2024-01-10", "rate": 1.1},
+		},
+	}
+
+	s.hlr.GetAll(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+}
+
+func getAll_InvalidCurrency_ReturnBadReq(s *ExchangeRateSuite, desc string) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/exchange-rate?from=EUR", nil)
+	res := httptest.NewRecorder()
+
+	expResp := map[string]interface{}{
+		"to": "To must be a 3-letter currency code",
+	}
+
+	s.hlr.GetAll(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+}
@@ -0,0 +1,12 @@
+package exchangerate
+
+type importExchangeRatesReq struct {
+	Rates []exchangeRate `json:"rates"`
+}
+
+type exchangeRate struct {
+	From string  `json:"from"`
+	To   string  `json:"to"`
+	Date string  `json:"date"`
+	Rate float64 `json:"rate"`
+}
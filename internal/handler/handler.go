@@ -1,16 +1,30 @@
 package handler
 
 import (
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/account"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/apikey"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/budget"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/contact"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/exchangerate"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/hisport"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/icon"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/importtrans"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/initdata"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/ledger"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/maincateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/recurringtrans"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/rule"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/settlement"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/share"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/stock"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/subcateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/tag"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/transaction"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/trash"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/user"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/usericon"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/view"
 )
 
 type Handler struct {
@@ -18,6 +32,20 @@ type Handler struct {
 	MainCateg           *maincateg.Hlr
 	SubCateg            *subcateg.Hlr
 	Transaction         *transaction.Hlr
+	RecurringTrans      *recurringtrans.Hlr
+	Budget              *budget.Hlr
+	ImportTrans         *importtrans.Hlr
+	ExchangeRate        *exchangerate.Hlr
+	Account             *account.Hlr
+	Tag                 *tag.Hlr
+	Rule                *rule.Hlr
+	View                *view.Hlr
+	Ledger              *ledger.Hlr
+	Contact             *contact.Hlr
+	Share               *share.Hlr
+	Settlement          *settlement.Hlr
+	APIKey              *apikey.Hlr
+	Trash               *trash.Hlr
 	Icon                *icon.Hlr
 	UserIcon            *usericon.Hlr
 	InitData            *initdata.Hlr
@@ -34,12 +62,40 @@ func New(u interfaces.UserUC,
 	in interfaces.InitDataUC,
 	st interfaces.StockUC,
 	hp interfaces.HistoricalPortfolioUC,
+	rt interfaces.RecurringTransUC,
+	b interfaces.BudgetUC,
+	it interfaces.ImportTransUC,
+	er interfaces.ExchangeRateUC,
+	a interfaces.AccountUC,
+	tg interfaces.TagUC,
+	tr interfaces.TrashUC,
+	rl interfaces.RuleUC,
+	vw interfaces.ViewUC,
+	ld interfaces.LedgerUC,
+	ct interfaces.ContactUC,
+	sh interfaces.ShareUC,
+	sl interfaces.SettlementUC,
+	ak interfaces.APIKeyUC,
 ) *Handler {
 	return &Handler{
 		User:                user.New(u),
 		MainCateg:           maincateg.New(m),
 		SubCateg:            subcateg.New(s),
 		Transaction:         transaction.New(t),
+		RecurringTrans:      recurringtrans.New(rt),
+		Budget:              budget.New(b),
+		ImportTrans:         importtrans.New(it),
+		ExchangeRate:        exchangerate.New(er),
+		Account:             account.New(a),
+		Tag:                 tag.New(tg),
+		Rule:                rule.New(rl),
+		View:                view.New(vw),
+		Ledger:              ledger.New(ld),
+		Contact:             contact.New(ct),
+		Share:               share.New(sh),
+		Settlement:          settlement.New(sl),
+		APIKey:              apikey.New(ak),
+		Trash:               trash.New(tr),
 		Icon:                icon.New(i),
 		UserIcon:            usericon.New(ui),
 		InitData:            initdata.New(in),
@@ -48,7 +48,7 @@ func (h *Hlr) List(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Hlr) ListByUserID(w http.ResponseWriter, r *http.Request) {
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	icons, err := h.Icon.ListByUserID(r.Context(), user.ID)
 	if err != nil {
 		errutil.ServerErrorResponse(w, r, err)
@@ -0,0 +1,167 @@
+package importtrans
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+)
+
+// fileError is returned when the CSV file can't be imported as a whole
+type fileError struct {
+	key string
+	msg string
+}
+
+func (e *fileError) Error() string {
+	return e.key + ": " + e.msg
+}
+
+// columns contains the index of each mapped column in the CSV header, -1 means not mapped
+type columns struct {
+	date      int
+	amount    int
+	note      int
+	mainCateg int
+	subCateg  int
+	transType int
+	currency  int
+}
+
+// parseRows reads the CSV file, and converts each record to an import row.
+// The validation errors of each record are stored in the row instead of returning an error.
+func parseRows(r io.Reader, mapping domain.ImportTransMapping) ([]domain.ImportTransRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &fileError{key: "file", msg: "File is empty"}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cols, err := findColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []domain.ImportTransRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) == maxRows {
+			return nil, &fileError{key: "file", msg: fmt.Sprintf("File can't have more than %d rows", maxRows)}
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseRow(record, line, cols, mapping.DateFormat))
+	}
+
+	return rows, nil
+}
+
+func findColumns(header []string, mapping domain.ImportTransMapping) (columns, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		// the first header may start with UTF-8 BOM exported by spreadsheet applications
+		h = strings.TrimPrefix(h, "\ufeff")
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	var missing *fileError
+	find := func(key, name string) int {
+		if name == "" {
+			return -1
+		}
+
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok && missing == nil {
+			missing = &fileError{key: key, msg: fmt.Sprintf("Column %q not found in CSV header", name)}
+		}
+		if !ok {
+			return -1
+		}
+
+		return i
+	}
+
+	cols := columns{
+		date:      find("date", mapping.Date),
+		amount:    find("amount", mapping.Amount),
+		note:      find("note", mapping.Note),
+		mainCateg: find("main_category", mapping.MainCateg),
+		subCateg:  find("sub_category", mapping.SubCateg),
+		transType: find("type", mapping.Type),
+		currency:  find("currency", mapping.Currency),
+	}
+	if missing != nil {
+		return columns{}, missing
+	}
+
+	return cols, nil
+}
+
+func parseRow(record []string, line int, cols columns, dateLayout string) domain.ImportTransRow {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	v := validator.New()
+	row := domain.ImportTransRow{
+		Line:          line,
+		MainCategName: field(cols.mainCateg),
+		SubCategName:  field(cols.subCateg),
+		Note:          field(cols.note),
+		Currency:      strings.ToUpper(field(cols.currency)),
+	}
+
+	if d := field(cols.date); d != "" {
+		date, err := time.Parse(dateLayout, d)
+		if err != nil {
+			v.AddError("date", fmt.Sprintf("Date must be in %s format", dateLayout))
+		}
+		row.Date = date
+	}
+
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(field(cols.amount), ",", ""), 64)
+	if err != nil {
+		v.AddError("price", "Price must be a number")
+	}
+	row.Price = amount
+	if amount < 0 {
+		row.Price = -amount
+	}
+
+	// the type column takes precedence, otherwise negative amount is expense and positive amount is income
+	if t := field(cols.transType); t != "" {
+		row.Type = domain.CvtToTransactionType(strings.ToLower(t))
+	} else if amount < 0 {
+		row.Type = domain.TransactionTypeExpense
+	} else if amount > 0 {
+		row.Type = domain.TransactionTypeIncome
+	}
+
+	if !v.ImportTransRow(row) {
+		row.Errors = v.Error
+	}
+
+	return row
+}
@@ -0,0 +1,127 @@
+package importtrans
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+)
+
+const (
+	packageName = "handler/importtrans"
+
+	maxFileBytes  = 10 << 20 // 10MB
+	maxMemory     = 2 << 20  // 2MB, the rest of the file is stored in temporary files
+	maxRows       = 5000
+	defaultLayout = "2006-01-02"
+)
+
+type Hlr struct {
+	importTrans interfaces.ImportTransUC
+}
+
+func New(i interfaces.ImportTransUC) *Hlr {
+	return &Hlr{
+		importTrans: i,
+	}
+}
+
+func (h *Hlr) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxFileBytes)
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		logger.Error("r.ParseMultipartForm failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var mapping domain.ImportTransMapping
+	if err := json.Unmarshal([]byte(r.FormValue("mapping")), &mapping); err != nil {
+		errutil.VildateErrorResponse(w, r, map[string]string{"mapping": "Mapping must be a JSON object"})
+		return
+	}
+	if mapping.DateFormat == "" {
+		mapping.DateFormat = defaultLayout
+	}
+
+	v := validator.New()
+	if !v.ImportTransMapping(mapping) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	var dryRun bool
+	if d := r.FormValue("dry_run"); d != "" {
+		b, err := strconv.ParseBool(d)
+		if err != nil {
+			errutil.VildateErrorResponse(w, r, map[string]string{"dry_run": "Dry run must be true or false"})
+			return
+		}
+
+		dryRun = b
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		logger.Error("r.FormFile failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	rows, err := parseRows(file, mapping)
+	if err != nil {
+		var fileErr *fileError
+		if errors.As(err, &fileErr) {
+			errutil.VildateErrorResponse(w, r, map[string]string{fileErr.key: fileErr.msg})
+			return
+		}
+
+		logger.Error("parseRows failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	errs := []error{
+		domain.ErrUniqueNameUserType,
+		domain.ErrUniqueNameUserMainCateg,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	result, err := h.importTrans.Import(r.Context(), rows, dryRun, user.ID)
+	if err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+		if errors.Is(err, domain.ErrLedgerPermissionDenied) {
+			errutil.ForbiddenResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+
+	respData := map[string]interface{}{
+		"result": result,
+	}
+	if err := jsonutil.WriteJSON(w, status, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
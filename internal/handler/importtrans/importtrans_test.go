@@ -0,0 +1,232 @@
+package importtrans_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/importtrans"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockMapping = map[string]string{
+		"date":          "Date",
+		"amount":        "Amount",
+		"note":          "Memo",
+		"main_category": "Category",
+		"sub_category":  "Sub Category",
+	}
+)
+
+type ImportTransSuite struct {
+	suite.Suite
+	hlr               *importtrans.Hlr
+	mockImportTransUC *mocks.ImportTransUC
+}
+
+func TestImportTransSuite(t *testing.T) {
+	suite.Run(t, new(ImportTransSuite))
+}
+
+func (s *ImportTransSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ImportTransSuite) SetupTest() {
+	s.mockImportTransUC = mocks.NewImportTransUC(s.T())
+	s.hlr = importtrans.New(s.mockImportTransUC)
+}
+
+func (s *ImportTransSuite) TearDownTest() {
+	s.mockImportTransUC.AssertExpectations(s.T())
+}
+
+func (s *ImportTransSuite) TestImport() {
+	for scenario, fn := range map[string]func(s *ImportTransSuite, desc string){
+		"when no error, import successfully":            import_NoError_ImportSuccessfully,
+		"when dry run, return ok":                       import_DryRun_ReturnOK,
+		"when currency is mapped, pass currency":        import_CurrencyMapped_PassCurrency,
+		"when row is invalid, pass row errors":          import_InvalidRow_PassRowErrors,
+		"when required mapping missing, return bad req": import_RequiredMappingMissing_ReturnBadReq,
+		"when column not in header, return bad req":     import_ColumnNotInHeader_ReturnBadReq,
+		"when file is empty, return bad req":            import_EmptyFile_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func import_NoError_ImportSuccessfully(s *ImportTransSuite, desc string) {
+	csv := "Date,Amount,Memo,Category,Sub Category\n" +
+		"2024-01-10,-100,noodle,food,lunch\n" +
+		"2024-01-11,\"1,000\",,salary,\n"
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, MainCategName: "food", SubCategName: "lunch", Price: 100, Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Note: "noodle"},
+		{Line: 3, Type: domain.TransactionTypeIncome, MainCategName: "salary", Price: 1000, Date: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+	}
+	result := domain.ImportTransResult{Total: 2, Imported: 2}
+
+	req, res := s.genReq(desc, csv, mockMapping, "")
+
+	s.mockImportTransUC.On("Import", mock.Anything, rows, false, int64(1)).Return(result, nil).Once()
+
+	s.hlr.Import(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func import_DryRun_ReturnOK(s *ImportTransSuite, desc string) {
+	csv := "Date,Amount,Memo,Category,Sub Category\n" +
+		"2024-01-10,100,,food,lunch\n"
+	mapping := map[string]string{
+		"date":          "Date",
+		"amount":        "Amount",
+		"main_category": "Category",
+	}
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeIncome, MainCategName: "food", Price: 100, Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	result := domain.ImportTransResult{DryRun: true, Total: 1, Imported: 1}
+
+	req, res := s.genReq(desc, csv, mapping, "true")
+
+	s.mockImportTransUC.On("Import", mock.Anything, rows, true, int64(1)).Return(result, nil).Once()
+
+	s.hlr.Import(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func import_CurrencyMapped_PassCurrency(s *ImportTransSuite, desc string) {
+	csv := "Date,Amount,Category,Currency\n" +
+		"2024-01-10,-100,food,eur\n" +
+		"2024-01-11,-200,food,\n"
+	mapping := map[string]string{
+		"date":          "Date",
+		"amount":        "Amount",
+		"main_category": "Category",
+		"currency":      "Currency",
+	}
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, MainCategName: "food", Price: 100, Currency: "EUR", Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{Line: 3, Type: domain.TransactionTypeExpense, MainCategName: "food", Price: 200, Date: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+	}
+	result := domain.ImportTransResult{Total: 2, Imported: 2}
+
+	req, res := s.genReq(desc, csv, mapping, "")
+
+	s.mockImportTransUC.On("Import", mock.Anything, rows, false, int64(1)).Return(result, nil).Once()
+
+	s.hlr.Import(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func import_InvalidRow_PassRowErrors(s *ImportTransSuite, desc string) {
+	csv := "Date,Amount,Memo,Category,Sub Category\n" +
+		"10/01/2024,abc,,food,lunch\n"
+	rows := []domain.ImportTransRow{
+		{
+			Line:          2,
+			MainCategName: "food",
+			SubCategName:  "lunch",
+			Errors: map[string]string{
+				"date":  "Date must be in 2006-01-02 format",
+				"price": "Price must be a number",
+				"type":  "Type must be income or expense",
+			},
+		},
+	}
+	result := domain.ImportTransResult{Total: 1, Invalid: 1}
+
+	req, res := s.genReq(desc, csv, mockMapping, "")
+
+	s.mockImportTransUC.On("Import", mock.Anything, rows, false, int64(1)).Return(result, nil).Once()
+
+	s.hlr.Import(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func import_RequiredMappingMissing_ReturnBadReq(s *ImportTransSuite, desc string) {
+	csv := "Date,Amount,Memo,Category,Sub Category\n"
+	mapping := map[string]string{
+		"date":          "Date",
+		"main_category": "Category",
+	}
+
+	req, res := s.genReq(desc, csv, mapping, "")
+
+	s.hlr.Import(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"amount": "Amount column is required"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func import_ColumnNotInHeader_ReturnBadReq(s *ImportTransSuite, desc string) {
+	csv := "Date,Amount,Category\n"
+
+	req, res := s.genReq(desc, csv, mockMapping, "")
+
+	s.hlr.Import(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"note": `Column "Memo" not found in CSV header`}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func import_EmptyFile_ReturnBadReq(s *ImportTransSuite, desc string) {
+	req, res := s.genReq(desc, "", mockMapping, "")
+
+	s.hlr.Import(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"file": "File is empty"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *ImportTransSuite) genReq(desc, csv string, mapping map[string]string, dryRun string) (*http.Request, *httptest.ResponseRecorder) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", "transactions.csv")
+	s.Require().NoError(err, desc)
+	_, err = part.Write([]byte(csv))
+	s.Require().NoError(err, desc)
+
+	m, err := json.Marshal(mapping)
+	s.Require().NoError(err, desc)
+	s.Require().NoError(writer.WriteField("mapping", string(m)), desc)
+
+	if dryRun != "" {
+		s.Require().NoError(writer.WriteField("dry_run", dryRun), desc)
+	}
+	s.Require().NoError(writer.Close(), desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req = ctxutil.SetUser(req, &domain.User{ID: 1})
+
+	return req, httptest.NewRecorder()
+}
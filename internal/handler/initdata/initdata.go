@@ -1,13 +1,17 @@
 package initdata
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
 	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
 )
 
 const (
@@ -49,7 +53,7 @@ func (i *Hlr) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	initData := cvtToInitData(input)
 
 	ctx := r.Context()
@@ -64,3 +68,79 @@ func (i *Hlr) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (i *Hlr) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templs, err := i.InitData.ListTemplates()
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"templates": templs,
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (i *Hlr) Export(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetLedgerUser(r)
+
+	templ, err := i.InitData.Export(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"template": templ,
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (i *Hlr) Import(w http.ResponseWriter, r *http.Request) {
+	var input importTemplateInput
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJson failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	mode := domain.CvtToImportModeType(input.Mode)
+	templ := cvtToInitData(input.Template)
+
+	v := validator.New()
+	if !v.ImportCategTemplate(mode, templ) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := i.InitData.Import(r.Context(), templ, mode, user.ID); err != nil {
+		// a category with the same name may still be in trash
+		if errors.Is(err, domain.ErrUniqueNameUserType) || errors.Is(err, domain.ErrUniqueNameUserMainCateg) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+		if errors.Is(err, domain.ErrLedgerPermissionDenied) {
+			errutil.ForbiddenResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
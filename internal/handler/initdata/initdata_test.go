@@ -255,3 +255,216 @@ func create_CreateFail_ReturnError(s *InitDataSuite, desc string) {
 	s.Require().Equal(expResp, responseBody, desc)
 	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
 }
+
+func (s *InitDataSuite) TestListTemplates() {
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodGet, "/v1/init-data/templates", nil)
+	res := httptest.NewRecorder()
+
+	// mock service
+	s.mockInitDataUC.On("ListTemplates").Return([]domain.CategTemplate{
+		{
+			Key:  "student",
+			Name: "Student",
+			InitData: domain.InitData{
+				Expense: []domain.InitDataMainCateg{
+					{Name: "food", Icon: domain.DefaultIcon{ID: 1, URL: "url1"}, SubCategs: []string{"snacks"}},
+				},
+			},
+		},
+	}, nil).Once()
+
+	// action
+	s.hlr.ListTemplates(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err)
+	s.Require().Equal(map[string]interface{}{
+		"templates": []interface{}{
+			map[string]interface{}{
+				"key":         "student",
+				"name":        "Student",
+				"description": "",
+				"expense": []interface{}{
+					map[string]interface{}{
+						"name":           "food",
+						"icon":           map[string]interface{}{"id": float64(1), "url": "url1"},
+						"sub_categories": []interface{}{"snacks"},
+					},
+				},
+				"income": nil,
+			},
+		},
+	}, responseBody)
+	s.Require().Equal(http.StatusOK, res.Code)
+}
+
+func (s *InitDataSuite) TestExport() {
+	mockUser := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodGet, "/v1/init-data/export", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	s.mockInitDataUC.On("Export", req.Context(), int64(1)).Return(domain.InitData{
+		Income:  []domain.InitDataMainCateg{{Name: "salary", Icon: domain.DefaultIcon{ID: 12, URL: "url12"}, SubCategs: []string{"bonus"}}},
+		Expense: []domain.InitDataMainCateg{},
+	}, nil).Once()
+
+	// action
+	s.hlr.Export(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err)
+	s.Require().Equal(map[string]interface{}{
+		"template": map[string]interface{}{
+			"income": []interface{}{
+				map[string]interface{}{
+					"name":           "salary",
+					"icon":           map[string]interface{}{"id": float64(12), "url": "url12"},
+					"sub_categories": []interface{}{"bonus"},
+				},
+			},
+			"expense": []interface{}{},
+		},
+	}, responseBody)
+	s.Require().Equal(http.StatusOK, res.Code)
+}
+
+func (s *InitDataSuite) TestImport() {
+	for scenario, fn := range map[string]func(s *InitDataSuite, desc string){
+		"when no error, return successfully":             import_NoError_ReturnSuccessfully,
+		"when mode is invalid, return bad request":       import_InvalidMode_ReturnBadRequest,
+		"when names are duplicated, return bad request":  import_DuplicateNames_ReturnBadRequest,
+		"when name is used in trash, return bad request": import_NameUsedInTrash_ReturnBadRequest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func import_NoError_ReturnSuccessfully(s *InitDataSuite, desc string) {
+	mockUser := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"mode": "replace",
+		"template": map[string]interface{}{
+			"expense": []interface{}{
+				map[string]interface{}{"name": "food", "icon": map[string]interface{}{"id": 1}, "sub_categories": []string{"lunch"}},
+			},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/init-data/import", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	expData := domain.InitData{
+		Income: []domain.InitDataMainCateg{},
+		Expense: []domain.InitDataMainCateg{
+			{Name: "food", Icon: domain.DefaultIcon{ID: 1}, SubCategs: []string{"lunch"}},
+		},
+	}
+	s.mockInitDataUC.On("Import", req.Context(), expData, domain.ImportModeTypeReplace, int64(1)).Return(nil).Once()
+
+	// action
+	s.hlr.Import(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func import_InvalidMode_ReturnBadRequest(s *InitDataSuite, desc string) {
+	mockUser := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"mode": "overwrite",
+		"template": map[string]interface{}{
+			"income": []interface{}{map[string]interface{}{"name": "salary"}},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/init-data/import", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// action
+	s.hlr.Import(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"mode": "Mode must be merge or replace"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func import_DuplicateNames_ReturnBadRequest(s *InitDataSuite, desc string) {
+	mockUser := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"mode": "merge",
+		"template": map[string]interface{}{
+			"expense": []interface{}{
+				map[string]interface{}{"name": "food", "sub_categories": []string{"lunch", "lunch"}},
+			},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/init-data/import", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// action
+	s.hlr.Import(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"template": "Sub category names must be unique in the same main category"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func import_NameUsedInTrash_ReturnBadRequest(s *InitDataSuite, desc string) {
+	mockUser := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"mode": "merge",
+		"template": map[string]interface{}{
+			"income": []interface{}{map[string]interface{}{"name": "salary"}},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/init-data/import", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	expData := domain.InitData{
+		Income:  []domain.InitDataMainCateg{{Name: "salary"}},
+		Expense: []domain.InitDataMainCateg{},
+	}
+	s.mockInitDataUC.On("Import", req.Context(), expData, domain.ImportModeTypeMerge, int64(1)).
+		Return(domain.ErrUniqueNameUserType).Once()
+
+	// action
+	s.hlr.Import(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
@@ -15,3 +15,8 @@ type icon struct {
 	ID  int64  `json:"id"`
 	URL string `json:"url"`
 }
+
+type importTemplateInput struct {
+	Mode     string              `json:"mode"`
+	Template createInitDataInput `json:"template"`
+}
@@ -9,17 +9,44 @@ import (
 
 // UserUC is the interface that wraps the basic methods for user usecase.
 type UserUC interface {
-	// Signup registers a user.
-	Signup(ctx context.Context, user domain.User) (domain.Token, error)
+	// Signup registers a user, and starts a session on the device.
+	Signup(ctx context.Context, user domain.User, session domain.Session) (domain.Token, error)
 
-	// Login logs in a user.
-	Login(ctx context.Context, user domain.User) (domain.Token, error)
+	// Login logs in a user, and starts a session on the device.
+	Login(ctx context.Context, user domain.User, session domain.Session) (domain.Token, error)
 
 	// GetInfo returns the user information by user id.
 	GetInfo(userID int64) (domain.User, error)
 
 	// Token returns the access token and refresh token by refresh token.
 	Token(ctx context.Context, refreshToken string) (domain.Token, error)
+
+	// UpdateBaseCurrency updates the base currency of the user.
+	UpdateBaseCurrency(ctx context.Context, userID int64, currency string) error
+
+	// RequestPasswordReset mails a password reset token to the user if the email is registered.
+	RequestPasswordReset(ctx context.Context, email string) error
+
+	// ResetPassword updates the password of the user by the password reset token.
+	ResetPassword(ctx context.Context, token, password string) error
+
+	// RequestEmailVerification mails an email verification token to the user.
+	RequestEmailVerification(ctx context.Context, userID int64) error
+
+	// VerifyEmail marks the email of the user as verified by the email verification token.
+	VerifyEmail(ctx context.Context, token string) error
+
+	// ValidateSession returns error if the session is revoked or expired.
+	ValidateSession(ctx context.Context, id, userID int64) error
+
+	// ListSessions returns the active sessions of the user.
+	ListSessions(ctx context.Context, userID int64) ([]domain.Session, error)
+
+	// RevokeSession revokes a session of the user.
+	RevokeSession(ctx context.Context, id, userID int64) error
+
+	// RevokeAllSessions revokes all sessions of the user, which logs the user out everywhere.
+	RevokeAllSessions(ctx context.Context, userID int64) error
 }
 
 // MainCategUC is the interface that wraps the basic methods for main category usecase.
@@ -27,14 +54,23 @@ type MainCategUC interface {
 	// Create creates a main category.
 	Create(ctx context.Context, categ domain.CreateMainCategInput, userID int64) error
 
-	// GetAll returns all main categories by user id.
-	GetAll(ctx context.Context, userID int64, transType domain.TransactionType) ([]domain.MainCateg, error)
+	// GetAll returns all main categories by user id in the user-defined order. Archived ones are left out unless includeArchived is true.
+	GetAll(ctx context.Context, userID int64, transType domain.TransactionType, includeArchived bool) ([]domain.MainCateg, error)
 
 	// Update updates a main category.
 	Update(ctx context.Context, categ domain.UpdateMainCategInput, userID int64) error
 
-	// Delete deletes a main category.
-	Delete(id int64) error
+	// Delete moves a main category to trash, along with its sub categories and transactions. Only the owner of the ledger can delete it.
+	Delete(ctx context.Context, id int64) error
+
+	// Merge moves the sub categories and transactions of a main category into the target main category of the same type, and deletes the main category.
+	Merge(ctx context.Context, id, targetID, userID int64) error
+
+	// SetArchived archives or unarchives a main category. Archived main category is hidden from the list, but still shown in the transactions and charts.
+	SetArchived(ctx context.Context, id int64, archived bool, userID int64) error
+
+	// Reorder sets the order of the main categories by the order of ids.
+	Reorder(ctx context.Context, ids []int64, userID int64) error
 }
 
 // SubCategUC is the interface that wraps the basic methods for sub category usecase.
@@ -42,46 +78,306 @@ type SubCategUC interface {
 	// Create creates a sub category.
 	Create(categ *domain.SubCateg, userID int64) error
 
-	// GetByMainCategID returns all sub categories by user id and main category id.
-	GetByMainCategID(userID, mainCategID int64) ([]*domain.SubCateg, error)
+	// GetByMainCategID returns all sub categories by user id and main category id in the user-defined order. Archived ones are left out unless includeArchived is true.
+	GetByMainCategID(userID, mainCategID int64, includeArchived bool) ([]*domain.SubCateg, error)
 
 	// Update updates a sub category.
 	Update(categ *domain.SubCateg, userID int64) error
 
-	// Delete deletes a sub category.
+	// Delete moves a sub category to trash, along with its transactions.
 	Delete(id int64) error
+
+	// Move moves a sub category, along with its transactions, to another main category of the same type.
+	Move(ctx context.Context, id, mainCategID, userID int64) error
+
+	// SetArchived archives or unarchives a sub category. Archived sub category is hidden from the list, but still shown in the transactions and charts.
+	SetArchived(ctx context.Context, id int64, archived bool, userID int64) error
+
+	// Reorder sets the order of the sub categories of a main category by the order of ids.
+	Reorder(ctx context.Context, mainCategID int64, ids []int64, userID int64) error
 }
 
 // TransactionUC is the interface that wraps the basic methods for transaction usecase.
 type TransactionUC interface {
-	// Create creates a transaction.
-	Create(ctx context.Context, trans domain.CreateTransactionInput) error
+	// Create creates a transaction, and returns the existing transactions it may be a duplicate of.
+	// When strict is true, it returns ErrDuplicateTransaction instead of creating the transaction if there's any.
+	Create(ctx context.Context, trans domain.CreateTransactionInput, strict bool) ([]domain.Transaction, error)
 
-	// GetAll returns all transactions by query option and user id.
+	// GetAll returns all transactions by query option and user id, and the saved view of opt.ViewID is applied if it's set.
 	GetAll(ctx context.Context, opt domain.GetTransOpt, user domain.User) ([]domain.Transaction, domain.Cursor, error)
 
+	// GetTotal returns the count and sum of the transactions matching the query option, regardless of the page. The saved view is applied as in GetAll.
+	GetTotal(ctx context.Context, opt domain.GetTransOpt, user domain.User) (domain.TransTotal, error)
+
+	// Export calls fn with every transaction matching the query option, without pagination. The saved view is applied as in GetAll.
+	Export(ctx context.Context, opt domain.GetTransOpt, user domain.User, fn func(domain.Transaction) error) error
+
 	// Update updates a transaction.
 	Update(ctx context.Context, trans domain.UpdateTransactionInput, user domain.User) error
 
 	// Delete deletes a transaction by id.
 	Delete(ctx context.Context, id int64, user domain.User) error
 
+	// GetHistory returns the change history of a transaction, from the oldest to the newest.
+	GetHistory(ctx context.Context, id int64, user domain.User) ([]domain.TransRevision, error)
+
+	// Revert updates a transaction back to the snapshot after the given revision.
+	Revert(ctx context.Context, id, revisionID int64, user domain.User) error
+
+	// Bulk applies the creates, updates and deletes of transactions together, either all of them or none of them.
+	Bulk(ctx context.Context, input domain.BulkTransInput, user domain.User) (domain.BulkTransResult, error)
+
+	// GetDuplicates returns the groups of suspected duplicate transactions dated in the range of the option,
+	// whose dates are within the days of the first one of the group.
+	GetDuplicates(ctx context.Context, opt domain.GetDuplicatesOpt, user domain.User) ([]domain.DuplicateGroup, error)
+
+	// MergeDuplicates keeps the transaction of keepID with the tags of the others, and moves the others of ids to trash.
+	MergeDuplicates(ctx context.Context, keepID int64, ids []int64, user domain.User) error
+
+	// DismissDuplicates marks the transactions as not duplicates of each other, so they're no longer suspected.
+	DismissDuplicates(ctx context.Context, ids []int64, user domain.User) error
+
+	// GetAttachmentPutURL returns a presigned URL to upload an attachment of a transaction, along with a unique object key.
+	GetAttachmentPutURL(ctx context.Context, id int64, fileName string, user domain.User) (domain.AttachmentPutURL, error)
+
+	// CreateAttachment records an attachment of a transaction after it's uploaded to the object key from GetAttachmentPutURL.
+	CreateAttachment(ctx context.Context, id int64, fileName, objectKey string, user domain.User) error
+
+	// DeleteAttachment deletes an attachment of a transaction, and its uploaded file.
+	DeleteAttachment(ctx context.Context, id, attachmentID int64, user domain.User) error
+
 	// GetAccInfo returns the accunulated information by user id.
 	GetAccInfo(ctx context.Context, user domain.User, query domain.GetAccInfoQuery, timeRange domain.TimeRangeType) (domain.AccInfo, error)
 
 	// GetBarChartData returns bar chart data.
-	GetBarChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, transactionType domain.TransactionType, mainCategIDs []int64, user domain.User) (domain.ChartData, error)
+	// The chart data below is narrowed down by the filter and search of the saved view of viewID, 0 means no view.
+	GetBarChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, transactionType domain.TransactionType, mainCategIDs []int64, viewID int64, user domain.User) (domain.ChartData, error)
 
 	// GetPieChartData returns pie chart data.
-	GetPieChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, user domain.User) (domain.ChartData, error)
+	GetPieChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, viewID int64, user domain.User) (domain.ChartData, error)
+
+	// GetTagChartData returns chart data of summed price grouped by tag.
+	GetTagChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, viewID int64, user domain.User) (domain.ChartData, error)
 
 	// GetLineChartData returns line chart data.
-	GetLineChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, user domain.User) (domain.ChartData, error)
+	GetLineChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, viewID int64, user domain.User) (domain.ChartData, error)
 
 	// GetMonthlyData returns monthly data.
 	GetMonthlyData(ctx context.Context, dateRange domain.GetMonthlyDateRange, user domain.User) ([]domain.TransactionType, error)
 }
 
+// RecurringTransUC is the interface that wraps the basic methods for recurring transaction usecase.
+type RecurringTransUC interface {
+	// Create creates a recurring transaction.
+	Create(ctx context.Context, rt domain.RecurringTrans) error
+
+	// GetAll returns all recurring transactions by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.RecurringTrans, error)
+
+	// Update updates a recurring transaction.
+	Update(ctx context.Context, input domain.UpdateRecurringTransInput, userID int64) error
+
+	// Delete deletes a recurring transaction by id.
+	Delete(ctx context.Context, id, userID int64) error
+}
+
+// BudgetUC is the interface that wraps the basic methods for budget usecase.
+type BudgetUC interface {
+	// Set sets the monthly limit of an expense main category.
+	Set(ctx context.Context, budget domain.Budget, userID int64) error
+
+	// GetAll returns all budgets by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Budget, error)
+
+	// Delete deletes a budget by id.
+	Delete(ctx context.Context, id, userID int64) error
+
+	// GetStatus returns spent amount against the limit of each budget in the given month.
+	GetStatus(ctx context.Context, month time.Time, userID int64) ([]domain.BudgetStatus, error)
+}
+
+// ExchangeRateUC is the interface that wraps the basic methods for exchange rate usecase.
+type ExchangeRateUC interface {
+	// Import stores the exchange rates, and overwrites the existing rates on the same date.
+	Import(ctx context.Context, rates []domain.ExchangeRate) error
+
+	// GetAll returns all exchange rates of the currency pair, the latest first.
+	GetAll(ctx context.Context, from, to string) ([]domain.ExchangeRate, error)
+}
+
+// AccountUC is the interface that wraps the basic methods for account usecase.
+type AccountUC interface {
+	// Create creates an account.
+	Create(ctx context.Context, account domain.Account, userID int64) error
+
+	// GetAll returns all accounts by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Account, error)
+
+	// Update updates an account.
+	Update(ctx context.Context, account domain.Account, userID int64) error
+
+	// Delete deletes an account by id.
+	Delete(ctx context.Context, id, userID int64) error
+
+	// GetBalance returns the current balance of the account, and the running balance after each transaction.
+	GetBalance(ctx context.Context, id, userID int64) (domain.AccountBalance, error)
+}
+
+// TagUC is the interface that wraps the basic methods for tag usecase.
+type TagUC interface {
+	// Create creates a tag.
+	Create(ctx context.Context, tag domain.Tag, userID int64) error
+
+	// GetAll returns all tags by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Tag, error)
+
+	// Update updates a tag.
+	Update(ctx context.Context, tag domain.Tag, userID int64) error
+
+	// Delete deletes a tag by id.
+	Delete(ctx context.Context, id, userID int64) error
+}
+
+// RuleUC is the interface that wraps the basic methods for rule usecase.
+type RuleUC interface {
+	// Create creates a rule.
+	Create(ctx context.Context, rule domain.Rule, userID int64) error
+
+	// GetAll returns all rules by user id in the order of matching.
+	GetAll(ctx context.Context, userID int64) ([]domain.Rule, error)
+
+	// Update updates a rule.
+	Update(ctx context.Context, rule domain.Rule, userID int64) error
+
+	// Delete deletes a rule by id.
+	Delete(ctx context.Context, id, userID int64) error
+
+	// Rerun matches the transactions of the user against the rules, and applies the changes unless it's dry-run.
+	// Viewers of the ledger can only run it as dry-run.
+	Rerun(ctx context.Context, opt domain.RuleRerunOpt, user domain.User) (domain.RuleRerunResult, error)
+}
+
+// ViewUC is the interface that wraps the basic methods for view usecase.
+type ViewUC interface {
+	// Create creates a view.
+	Create(ctx context.Context, view domain.View, userID int64) error
+
+	// GetAll returns all views by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.View, error)
+
+	// Update updates a view.
+	Update(ctx context.Context, view domain.View, userID int64) error
+
+	// Delete deletes a view by id.
+	Delete(ctx context.Context, id, userID int64) error
+}
+
+// LedgerUC is the interface that wraps the basic methods for ledger usecase.
+type LedgerUC interface {
+	// Create creates a shared ledger with the user as owner.
+	Create(ctx context.Context, name string, userID int64) (domain.Ledger, error)
+
+	// List returns the ledgers the user is a member of.
+	List(ctx context.Context, userID int64) ([]domain.Ledger, error)
+
+	// Resolve returns the ledger selected by the user, and checks the role of the user allows writing.
+	Resolve(ctx context.Context, id, userID int64, write bool) (domain.Ledger, error)
+
+	// ListMembers returns the members of a ledger.
+	ListMembers(ctx context.Context, id, userID int64) ([]domain.LedgerMember, error)
+
+	// Invite invites an email to join a ledger.
+	Invite(ctx context.Context, invite domain.LedgerInvite, userID int64) error
+
+	// ListInvites returns the invites sent to the email.
+	ListInvites(ctx context.Context, email string) ([]domain.LedgerInvite, error)
+
+	// AcceptInvite joins the user to the ledger of the invite, the email of the user must be verified.
+	AcceptInvite(ctx context.Context, id int64, user domain.User) error
+
+	// DeclineInvite deletes the invite sent to the user.
+	DeclineInvite(ctx context.Context, id int64, user domain.User) error
+
+	// UpdateMemberRole updates the role of a member.
+	UpdateMemberRole(ctx context.Context, id, memberID int64, role domain.LedgerRoleType, userID int64) error
+
+	// RemoveMember removes a member from a ledger.
+	RemoveMember(ctx context.Context, id, memberID, userID int64) error
+}
+
+// ContactUC is the interface that wraps the basic methods for contact usecase.
+type ContactUC interface {
+	// Create creates a contact.
+	Create(ctx context.Context, contact domain.Contact, userID int64) error
+
+	// GetAll returns all contacts by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Contact, error)
+
+	// Update updates a contact.
+	Update(ctx context.Context, contact domain.Contact, userID int64) error
+
+	// Delete deletes a contact by id, the contact must not have shared transactions or settlements.
+	Delete(ctx context.Context, id, userID int64) error
+}
+
+// ShareUC is the interface that wraps the basic methods for sharing transactions.
+type ShareUC interface {
+	// Update shares a transaction among the participants, and returns the allocated shares.
+	Update(ctx context.Context, sharedTrans domain.SharedTransaction, userID int64) (domain.SharedTransaction, error)
+
+	// Get returns the allocated shares of a transaction.
+	Get(ctx context.Context, transID, userID int64) (domain.SharedTransaction, error)
+
+	// Delete stops sharing a transaction.
+	Delete(ctx context.Context, transID, userID int64) error
+}
+
+// SettlementUC is the interface that wraps the basic methods for settlement usecase.
+type SettlementUC interface {
+	// Create creates a settlement.
+	Create(ctx context.Context, settlement domain.Settlement, userID int64) error
+
+	// GetAll returns all settlements by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Settlement, error)
+
+	// Delete deletes a settlement by id.
+	Delete(ctx context.Context, id, userID int64) error
+
+	// GetBalances returns the net balances of the participants, and the plan to settle them.
+	GetBalances(ctx context.Context, userID int64) (domain.Balances, error)
+}
+
+// APIKeyUC is the interface that wraps the basic methods for API key usecase.
+type APIKeyUC interface {
+	// Create creates an API key, and returns it with the key, which is never returned again.
+	Create(ctx context.Context, key domain.APIKey, userID int64) (domain.APIKey, string, error)
+
+	// GetAll returns all API keys by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.APIKey, error)
+
+	// Delete deletes an API key by id.
+	Delete(ctx context.Context, id, userID int64) error
+
+	// Authenticate returns the owner and the API key of the key, it returns error if the key is deleted or expired.
+	Authenticate(ctx context.Context, rawKey string) (domain.User, domain.APIKey, error)
+}
+
+// TrashUC is the interface that wraps the basic methods for trash usecase.
+type TrashUC interface {
+	// GetAll returns all items in trash by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.TrashItem, error)
+
+	// Restore restores an item from trash. Only the owner of the ledger can restore it.
+	Restore(ctx context.Context, kind domain.TrashKind, id, userID int64) error
+}
+
+// ImportTransUC is the interface that wraps the basic methods for importing transactions usecase.
+type ImportTransUC interface {
+	// Import inserts the valid rows as transactions, and creates the missing categories.
+	// Nothing is written to the database when dryRun is true, and viewers of the ledger can only run it as dry-run.
+	Import(ctx context.Context, rows []domain.ImportTransRow, dryRun bool, userID int64) (domain.ImportTransResult, error)
+}
+
 // IconUC is the interface that wraps the basic methods for icon usecase.
 type IconUC interface {
 	// List returns all icons.
@@ -107,6 +403,16 @@ type InitDataUC interface {
 
 	// Create creates the initial data.
 	Create(ctx context.Context, data domain.InitData, userID int64) error
+
+	// ListTemplates returns the built-in category templates.
+	ListTemplates() ([]domain.CategTemplate, error)
+
+	// Export returns the main and sub categories of the user as a template.
+	Export(ctx context.Context, userID int64) (domain.InitData, error)
+
+	// Import creates the main and sub categories of the template, merging into or replacing the existing ones, the replaced ones are archived.
+	// Only the owner of the ledger can replace them.
+	Import(ctx context.Context, data domain.InitData, mode domain.ImportModeType, userID int64) error
 }
 
 // StockUC is the interface that wraps the basic methods for stock usecase.
@@ -0,0 +1,50 @@
+package ledger
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToLedgerResp(l domain.Ledger) ledgerResp {
+	return ledgerResp{
+		ID:         l.ID,
+		Name:       l.Name,
+		IsPersonal: l.IsPersonal,
+		Role:       l.Role.ToString(),
+	}
+}
+
+func cvtToLedgersResp(ledgers []domain.Ledger) []ledgerResp {
+	resp := make([]ledgerResp, len(ledgers))
+	for i, l := range ledgers {
+		resp[i] = cvtToLedgerResp(l)
+	}
+
+	return resp
+}
+
+func cvtToMembersResp(members []domain.LedgerMember) []memberResp {
+	resp := make([]memberResp, len(members))
+	for i, m := range members {
+		resp[i] = memberResp{
+			UserID: m.UserID,
+			Name:   m.Name,
+			Email:  m.Email,
+			Role:   m.Role.ToString(),
+		}
+	}
+
+	return resp
+}
+
+func cvtToInvitesResp(invites []domain.LedgerInvite) []inviteResp {
+	resp := make([]inviteResp, len(invites))
+	for i, inv := range invites {
+		resp[i] = inviteResp{
+			ID:         inv.ID,
+			LedgerID:   inv.LedgerID,
+			LedgerName: inv.LedgerName,
+			Email:      inv.Email,
+			Role:       inv.Role.ToString(),
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,306 @@
+package ledger
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+	"github.com/gorilla/mux"
+)
+
+const (
+	packageName = "handler/ledger"
+)
+
+var (
+	// errs are the errors of managing ledger caused by the input
+	errs = []error{
+		domain.ErrLedgerNotFound,
+		domain.ErrLedgerPersonal,
+		domain.ErrLedgerMemberNotFound,
+		domain.ErrLedgerMemberExists,
+		domain.ErrLedgerOwnerUnchangeable,
+		domain.ErrLedgerInviteNotFound,
+		domain.ErrUniqueLedgerInviteEmail,
+	}
+)
+
+// Hlr manages the ledgers of the user, so the ledgers are always handled by the user themself instead of the selected ledger
+type Hlr struct {
+	ledger interfaces.LedgerUC
+}
+
+func New(l interfaces.LedgerUC) *Hlr {
+	return &Hlr{
+		ledger: l,
+	}
+}
+
+func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
+	var input createLedgerReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.CreateLedger(input.Name) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	ledger, err := h.ledger.Create(r.Context(), input.Name, user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"ledger": cvtToLedgerResp(ledger),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) List(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetUser(r)
+	ledgers, err := h.ledger.List(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"ledgers": cvtToLedgersResp(ledgers),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) ListMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	members, err := h.ledger.ListMembers(r.Context(), id, user.ID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"members": cvtToMembersResp(members),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Invite(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input inviteReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	invite := domain.LedgerInvite{
+		LedgerID: id,
+		Email:    input.Email,
+		Role:     domain.CvtToLedgerRoleType(input.Role),
+	}
+
+	v := validator.New()
+	if !v.InviteLedgerMember(invite.Email, invite.Role) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	if err := h.ledger.Invite(r.Context(), invite, user.ID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	id, memberID, err := readIDAndMemberID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input updateMemberRoleReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	role := domain.CvtToLedgerRoleType(input.Role)
+
+	v := validator.New()
+	if !v.UpdateLedgerMemberRole(role) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	if err := h.ledger.UpdateMemberRole(r.Context(), id, memberID, role, user.ID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, memberID, err := readIDAndMemberID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	if err := h.ledger.RemoveMember(r.Context(), id, memberID, user.ID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) ListInvites(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetUser(r)
+	invites, err := h.ledger.ListInvites(r.Context(), user.Email)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"invites": cvtToInvitesResp(invites),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	if err := h.ledger.AcceptInvite(r.Context(), id, *user); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) DeclineInvite(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	if err := h.ledger.DeclineInvite(r.Context(), id, *user); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+// readIDAndMemberID reads the id of the ledger and the user id of the member from the path
+func readIDAndMemberID(r *http.Request) (int64, int64, error) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", packageName, "err", err)
+		return 0, 0, err
+	}
+
+	memberID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+	if err != nil {
+		logger.Error("strconv.ParseInt failed", "package", packageName, "err", err)
+		return 0, 0, err
+	}
+
+	return id, memberID, nil
+}
+
+// handleError responds the error of the ledger usecase
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, domain.ErrLedgerPermissionDenied) || errors.Is(err, domain.ErrEmailNotVerified) {
+		errutil.ForbiddenResponse(w, r, err)
+		return
+	}
+
+	if slices.Contains(errs, err) {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	errutil.ServerErrorResponse(w, r, err)
+}
@@ -0,0 +1,228 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/ledger"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerSuite struct {
+	suite.Suite
+	hlr          *ledger.Hlr
+	mockLedgerUC *mocks.LedgerUC
+}
+
+func TestLedgerSuite(t *testing.T) {
+	suite.Run(t, new(LedgerSuite))
+}
+
+func (s *LedgerSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *LedgerSuite) SetupTest() {
+	s.mockLedgerUC = mocks.NewLedgerUC(s.T())
+	s.hlr = ledger.New(s.mockLedgerUC)
+}
+
+func (s *LedgerSuite) TearDownTest() {
+	s.mockLedgerUC.AssertExpectations(s.T())
+}
+
+func (s *LedgerSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when no error, create successfully":     create_NoError_CreateSuccessfully,
+		"when name is empty, return bad request": create_EmptyName_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "household"})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/ledger", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockLedgerUC.On("Create", req.Context(), "household", int64(1)).
+		Return(domain.Ledger{ID: 2, Name: "household", UserID: 3, Role: domain.LedgerRoleTypeOwner}, nil).Once()
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	expResp := map[string]interface{}{
+		"ledger": map[string]interface{}{
+			"id":          float64(2),
+			"name":        "household",
+			"is_personal": false,
+			"role":        "owner",
+		},
+	}
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_EmptyName_ReturnBadReq(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": ""})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ledger", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *LedgerSuite) TestInvite() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when no error, invite successfully":          invite_NoError_InviteSuccessfully,
+		"when role is owner, return bad request":      invite_OwnerRole_ReturnBadReq,
+		"when not the owner, return forbidden":        invite_NotOwner_ReturnForbidden,
+		"when ledger is personal, return bad request": invite_PersonalLedger_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func invite_NoError_InviteSuccessfully(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"email": "a@a.com", "role": "viewer"})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ledger/2/invite", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	invite := domain.LedgerInvite{LedgerID: 2, Email: "a@a.com", Role: domain.LedgerRoleTypeViewer}
+	s.mockLedgerUC.On("Invite", req.Context(), invite, int64(1)).Return(nil).Once()
+
+	s.hlr.Invite(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func invite_OwnerRole_ReturnBadReq(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"email": "a@a.com", "role": "owner"})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ledger/2/invite", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Invite(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"role": "Role must be editor or viewer"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func invite_NotOwner_ReturnForbidden(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"email": "a@a.com", "role": "editor"})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ledger/2/invite", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	invite := domain.LedgerInvite{LedgerID: 2, Email: "a@a.com", Role: domain.LedgerRoleTypeEditor}
+	s.mockLedgerUC.On("Invite", req.Context(), invite, int64(1)).Return(domain.ErrLedgerPermissionDenied).Once()
+
+	s.hlr.Invite(res, req)
+
+	s.Require().Equal(http.StatusForbidden, res.Code, desc)
+}
+
+func invite_PersonalLedger_ReturnBadReq(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"email": "a@a.com", "role": "editor"})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/ledger/2/invite", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	invite := domain.LedgerInvite{LedgerID: 2, Email: "a@a.com", Role: domain.LedgerRoleTypeEditor}
+	s.mockLedgerUC.On("Invite", req.Context(), invite, int64(1)).Return(domain.ErrLedgerPersonal).Once()
+
+	s.hlr.Invite(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *LedgerSuite) TestRemoveMember() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when no error, remove successfully":            removeMember_NoError_RemoveSuccessfully,
+		"when member id is invalid, return bad request": removeMember_InvalidMemberID_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func removeMember_NoError_RemoveSuccessfully(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/ledger/2/member/3", nil)
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "2", "user_id": "3"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.mockLedgerUC.On("RemoveMember", req.Context(), int64(2), int64(3), int64(1)).Return(nil).Once()
+
+	s.hlr.RemoveMember(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func removeMember_InvalidMemberID_ReturnBadReq(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/ledger/2/member/a", nil)
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "2", "user_id": "a"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.RemoveMember(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
@@ -0,0 +1,36 @@
+package ledger
+
+type createLedgerReq struct {
+	Name string `json:"name"`
+}
+
+type inviteReq struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type updateMemberRoleReq struct {
+	Role string `json:"role"`
+}
+
+type ledgerResp struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	IsPersonal bool   `json:"is_personal"`
+	Role       string `json:"role"`
+}
+
+type memberResp struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+type inviteResp struct {
+	ID         int64  `json:"id"`
+	LedgerID   int64  `json:"ledger_id"`
+	LedgerName string `json:"ledger_name"`
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+}
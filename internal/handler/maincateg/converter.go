@@ -7,11 +7,12 @@ func cvtToGetAllMainCategResp(c []domain.MainCateg) getAllMainCategResp {
 
 	for _, v := range c {
 		categs = append(categs, mainCateg{
-			ID:       v.ID,
-			Name:     v.Name,
-			Type:     v.Type.ToString(),
-			IconType: v.IconType.ToString(),
-			IconData: v.IconData,
+			ID:         v.ID,
+			Name:       v.Name,
+			Type:       v.Type.ToString(),
+			IconType:   v.IconType.ToString(),
+			IconData:   v.IconData,
+			IsArchived: v.IsArchived,
 		})
 	}
 
@@ -0,0 +1,16 @@
+package maincateg
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// genIncludeArchived returns whether archived categories are included, which is false by default
+func genIncludeArchived(r *http.Request) (bool, error) {
+	rawIncludeArchived := r.URL.Query().Get("include_archived")
+	if rawIncludeArchived == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(rawIncludeArchived)
+}
@@ -1,8 +1,10 @@
 package maincateg
 
 import (
+	"errors"
 	"net/http"
 	"slices"
+	"strconv"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
@@ -11,6 +13,7 @@ import (
 	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
 	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+	"github.com/gorilla/mux"
 )
 
 type Hlr struct {
@@ -49,7 +52,7 @@ func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	if err := h.MainCateg.Create(r.Context(), categ, user.ID); err != nil {
 		errors := []error{
 			domain.ErrIconNotFound,
@@ -76,10 +79,17 @@ func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
 func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
 	qType := r.URL.Query().Get("type")
 	categType := domain.CvtToTransactionType(qType)
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	ctx := r.Context()
 
-	categs, err := h.MainCateg.GetAll(ctx, user.ID, categType)
+	includeArchived, err := genIncludeArchived(r)
+	if err != nil {
+		logger.Error("genIncludeArchived failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	categs, err := h.MainCateg.GetAll(ctx, user.ID, categType, includeArchived)
 	if err != nil {
 		errutil.ServerErrorResponse(w, r, err)
 		return
@@ -131,7 +141,7 @@ func (h *Hlr) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	if err := h.MainCateg.Update(r.Context(), categ, user.ID); err != nil {
 		errors := []error{
 			domain.ErrUniqueNameUserType,
@@ -164,7 +174,127 @@ func (h *Hlr) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.MainCateg.Delete(id); err != nil {
+	if err := h.MainCateg.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrLedgerPermissionDenied) {
+			errutil.ForbiddenResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Merge(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	targetID, err := strconv.ParseInt(mux.Vars(r)["target"], 10, 64)
+	if err != nil {
+		logger.Error("strconv.ParseInt failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.MergeMainCateg(id, targetID) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.MainCateg.Merge(r.Context(), id, targetID, user.ID); err != nil {
+		errors := []error{
+			domain.ErrMainCategNotFound,
+			domain.ErrMainCategTypeNotSame,
+			domain.ErrUniqueNameUserMainCateg,
+		}
+		if slices.Contains(errors, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) SetArchived(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input setArchivedReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.SetCategArchived(input.Archived) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.MainCateg.SetArchived(r.Context(), id, *input.Archived, user.ID); err != nil {
+		if errors.Is(err, domain.ErrMainCategNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Reorder(w http.ResponseWriter, r *http.Request) {
+	var input reorderReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.ReorderCategs(input.IDs) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.MainCateg.Reorder(r.Context(), input.IDs, user.ID); err != nil {
+		if errors.Is(err, domain.ErrMainCategNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
 		errutil.ServerErrorResponse(w, r, err)
 		return
 	}
@@ -356,8 +356,9 @@ func create_ServerError_ReturnServerError(s *MainCategSuite, desc string) {
 
 func (s *MainCategSuite) TestGetAll() {
 	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
-		"when no error, return successfully":        getAll_NoError_GetAllSuccessfully,
-		"when in correct type, return successfully": getAll_InCorrectType_GetAllSuccessfully,
+		"when no error, return successfully":         getAll_NoError_GetAllSuccessfully,
+		"when in correct type, return successfully":  getAll_InCorrectType_GetAllSuccessfully,
+		"when include archived, return archived too": getAll_IncludeArchived_ReturnArchivedToo,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -402,24 +403,26 @@ func getAll_NoError_GetAllSuccessfully(s *MainCategSuite, desc string) {
 	expResp := map[string]interface{}{
 		"categories": []interface{}{
 			map[string]interface{}{
-				"id":        float64(1),
-				"name":      "Food",
-				"type":      "income",
-				"icon_type": "default",
-				"icon_data": "url",
+				"id":          float64(1),
+				"name":        "Food",
+				"type":        "income",
+				"icon_type":   "default",
+				"icon_data":   "url",
+				"is_archived": false,
 			},
 			map[string]interface{}{
-				"id":        float64(2),
-				"name":      "Transportation",
-				"type":      "expense",
-				"icon_type": "custom",
-				"icon_data": "url",
+				"id":          float64(2),
+				"name":        "Transportation",
+				"type":        "expense",
+				"icon_type":   "custom",
+				"icon_data":   "url",
+				"is_archived": false,
 			},
 		},
 	}
 
 	// mock service
-	s.mockMainCategUC.On("GetAll", req.Context(), mockUser.ID, domain.TransactionTypeIncome).
+	s.mockMainCategUC.On("GetAll", req.Context(), mockUser.ID, domain.TransactionTypeIncome, false).
 		Return(mockCategs, nil)
 
 	// action
@@ -455,7 +458,7 @@ func getAll_InCorrectType_GetAllSuccessfully(s *MainCategSuite, desc string) {
 	}
 
 	// mock service
-	s.mockMainCategUC.On("GetAll", req.Context(), mockUser.ID, domain.TransactionTypeUnSpecified).
+	s.mockMainCategUC.On("GetAll", req.Context(), mockUser.ID, domain.TransactionTypeUnSpecified, false).
 		Return(mockCategs, nil)
 
 	// action
@@ -820,6 +823,7 @@ func (s *MainCategSuite) TestDelete() {
 		"when no error, return successfully":         delete_NoError_DeleteSuccessfully,
 		"when bad request error, return bad request": delete_BadRequestError_ReturnBadRequest,
 		"when server error, return server error":     delete_ServerError_ReturnServerError,
+		"when permission denied, return forbidden":   delete_PermissionDenied_ReturnForbidden,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -840,7 +844,7 @@ func delete_NoError_DeleteSuccessfully(s *MainCategSuite, desc string) {
 	req = mux.SetURLVars(req, map[string]string{"id": "1"})
 
 	// mock service
-	s.mockMainCategUC.On("Delete", int64(1)).Return(nil)
+	s.mockMainCategUC.On("Delete", req.Context(), int64(1)).Return(nil)
 
 	// action
 	s.hlr.Delete(res, req)
@@ -893,7 +897,7 @@ func delete_ServerError_ReturnServerError(s *MainCategSuite, desc string) {
 	}
 
 	// mock service
-	s.mockMainCategUC.On("Delete", int64(1)).Return(mockErr)
+	s.mockMainCategUC.On("Delete", req.Context(), int64(1)).Return(mockErr)
 
 	// action
 	s.hlr.Delete(res, req)
@@ -905,3 +909,265 @@ func delete_ServerError_ReturnServerError(s *MainCategSuite, desc string) {
 	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
 	s.Require().Equal(expResp, responseBody, desc)
 }
+
+func delete_PermissionDenied_ReturnForbidden(s *MainCategSuite, desc string) {
+	// prepare mock request
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/main-category/id", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+	// mock service
+	s.mockMainCategUC.On("Delete", req.Context(), int64(1)).Return(domain.ErrLedgerPermissionDenied)
+
+	// action
+	s.hlr.Delete(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusForbidden, res.Code, desc)
+	s.Require().Equal(map[string]interface{}{"error": domain.ErrLedgerPermissionDenied.Error()}, responseBody, desc)
+}
+
+func (s *MainCategSuite) TestMerge() {
+	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
+		"when no error, merge successfully":          merge_NoError_MergeSuccessfully,
+		"when merge into itself, return bad request": merge_IntoItself_ReturnBadRequest,
+		"when name conflict, return bad request":     merge_NameConflict_ReturnBadRequest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func merge_NoError_MergeSuccessfully(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1}
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodPost, "/v1/main-category/1/merge-into/2", nil)
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "target": "2"})
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	s.mockMainCategUC.On("Merge", req.Context(), int64(1), int64(2), mockUser.ID).Return(nil)
+
+	// action
+	s.hlr.Merge(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func merge_IntoItself_ReturnBadRequest(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1}
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodPost, "/v1/main-category/1/merge-into/1", nil)
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "target": "1"})
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// action
+	s.hlr.Merge(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Equal(map[string]interface{}{"target": "Main category can't be merged into itself"}, responseBody, desc)
+}
+
+func merge_NameConflict_ReturnBadRequest(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1}
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodPost, "/v1/main-category/1/merge-into/2", nil)
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "target": "2"})
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	s.mockMainCategUC.On("Merge", req.Context(), int64(1), int64(2), mockUser.ID).Return(domain.ErrUniqueNameUserMainCateg)
+
+	// action
+	s.hlr.Merge(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Equal(map[string]interface{}{"error": domain.ErrUniqueNameUserMainCateg.Error()}, responseBody, desc)
+}
+
+func getAll_IncludeArchived_ReturnArchivedToo(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockCategs := []domain.MainCateg{{ID: 1, Name: "Food", Type: domain.TransactionTypeExpense, IconType: domain.IconTypeDefault, IconData: "url", IsArchived: true}}
+	mockUser := domain.User{ID: 1}
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodGet, "/v1/main-category?include_archived=true", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	s.mockMainCategUC.On("GetAll", req.Context(), mockUser.ID, domain.TransactionTypeUnSpecified, true).
+		Return(mockCategs, nil)
+
+	// action
+	s.hlr.GetAll(res, req)
+
+	// assertion
+	var responseBody map[string][]map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal(true, responseBody["categories"][0]["is_archived"], desc)
+}
+
+func (s *MainCategSuite) TestSetArchived() {
+	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
+		"when no error, set successfully":           setArchived_NoError_SetSuccessfully,
+		"when archived missing, return bad request": setArchived_ArchivedMissing_ReturnBadRequest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func setArchived_NoError_SetSuccessfully(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1}
+	mockBody, err := json.Marshal(map[string]interface{}{"archived": true})
+	s.Require().NoError(err, desc)
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodPatch, "/v1/main-category/1/archive", bytes.NewBuffer(mockBody))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	s.mockMainCategUC.On("SetArchived", req.Context(), int64(1), true, mockUser.ID).Return(nil)
+
+	// action
+	s.hlr.SetArchived(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func setArchived_ArchivedMissing_ReturnBadRequest(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1}
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodPatch, "/v1/main-category/1/archive", bytes.NewBuffer([]byte("{}")))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// action
+	s.hlr.SetArchived(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Equal(map[string]interface{}{"archived": "Archived is required"}, responseBody, desc)
+}
+
+func (s *MainCategSuite) TestReorder() {
+	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
+		"when no error, reorder successfully":              reorder_NoError_ReorderSuccessfully,
+		"when ids repeated, return bad request":            reorder_IDsRepeated_ReturnBadRequest,
+		"when main category not found, return bad request": reorder_MainCategNotFound_ReturnBadRequest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func reorder_NoError_ReorderSuccessfully(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1}
+	mockBody, err := json.Marshal(map[string]interface{}{"ids": []int64{3, 1, 2}})
+	s.Require().NoError(err, desc)
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodPut, "/v1/main-category/order", bytes.NewBuffer(mockBody))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	s.mockMainCategUC.On("Reorder", req.Context(), []int64{3, 1, 2}, mockUser.ID).Return(nil)
+
+	// action
+	s.hlr.Reorder(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func reorder_IDsRepeated_ReturnBadRequest(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1}
+	mockBody, err := json.Marshal(map[string]interface{}{"ids": []int64{1, 1}})
+	s.Require().NoError(err, desc)
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodPut, "/v1/main-category/order", bytes.NewBuffer(mockBody))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// action
+	s.hlr.Reorder(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Equal(map[string]interface{}{"ids": "IDs can't be repeated"}, responseBody, desc)
+}
+
+func reorder_MainCategNotFound_ReturnBadRequest(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1}
+	mockBody, err := json.Marshal(map[string]interface{}{"ids": []int64{1, 2}})
+	s.Require().NoError(err, desc)
+
+	// prepare mock request
+	req := httptest.NewRequest(http.MethodPut, "/v1/main-category/order", bytes.NewBuffer(mockBody))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &mockUser)
+
+	// mock service
+	s.mockMainCategUC.On("Reorder", req.Context(), []int64{1, 2}, mockUser.ID).Return(domain.ErrMainCategNotFound)
+
+	// action
+	s.hlr.Reorder(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
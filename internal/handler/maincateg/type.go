@@ -1,11 +1,20 @@
 package maincateg
 
 type mainCateg struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	IconType string `json:"icon_type"`
-	IconData string `json:"icon_data"`
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	IconType   string `json:"icon_type"`
+	IconData   string `json:"icon_data"`
+	IsArchived bool   `json:"is_archived"`
+}
+
+type setArchivedReq struct {
+	Archived *bool `json:"archived"`
+}
+
+type reorderReq struct {
+	IDs []int64 `json:"ids"`
 }
 
 type getAllMainCategResp struct {
@@ -0,0 +1,51 @@
+package recurringtrans
+
+import (
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+func cvtToCreateTransactionInput(req recurringTransReq, userID int64) domain.CreateTransactionInput {
+	return domain.CreateTransactionInput{
+		UserID:      userID,
+		Type:        domain.CvtToTransactionType(req.Type),
+		MainCategID: req.MainCategID,
+		SubCategID:  req.SubCategID,
+		Price:       req.Price,
+		Currency:    strings.ToUpper(req.Currency),
+		Note:        req.Note,
+	}
+}
+
+func cvtToRecurringSchedule(req recurringTransReq) domain.RecurringSchedule {
+	return domain.RecurringSchedule{
+		Freq:      domain.CvtToRecurFreqType(req.Frequency),
+		Interval:  req.Interval,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+}
+
+func cvtToRecurringTransResp(rts []domain.RecurringTrans) []recurringTrans {
+	resp := make([]recurringTrans, 0, len(rts))
+
+	for _, rt := range rts {
+		resp = append(resp, recurringTrans{
+			ID:          rt.ID,
+			Type:        rt.Template.Type.ToString(),
+			MainCategID: rt.Template.MainCategID,
+			SubCategID:  rt.Template.SubCategID,
+			Price:       rt.Template.Price,
+			Currency:    rt.Template.Currency,
+			Note:        rt.Template.Note,
+			Frequency:   rt.Schedule.Freq.ToString(),
+			Interval:    rt.Schedule.Interval,
+			StartDate:   rt.Schedule.StartDate,
+			EndDate:     rt.Schedule.EndDate,
+			NextDate:    rt.NextDate,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,174 @@
+package recurringtrans
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+)
+
+const (
+	packageName = "handler/recurringtrans"
+)
+
+type Hlr struct {
+	recurringTrans interfaces.RecurringTransUC
+}
+
+func New(rt interfaces.RecurringTransUC) *Hlr {
+	return &Hlr{
+		recurringTrans: rt,
+	}
+}
+
+func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
+	var input recurringTransReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	rt := domain.RecurringTrans{
+		Template: cvtToCreateTransactionInput(input, user.ID),
+		Schedule: cvtToRecurringSchedule(input),
+	}
+
+	v := validator.New()
+	if !v.CreateRecurringTrans(rt) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrMainCategNotFound,
+		domain.ErrTypeNotConsistent,
+		domain.ErrSubCategNotFound,
+		domain.ErrMainCategNotConsistent,
+	}
+
+	if err := h.recurringTrans.Create(r.Context(), rt); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetLedgerUser(r)
+	rts, err := h.recurringTrans.GetAll(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"recurring_transactions": cvtToRecurringTransResp(rts),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input recurringTransReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	rt := domain.UpdateRecurringTransInput{
+		ID:       id,
+		Template: cvtToCreateTransactionInput(input, user.ID),
+		Schedule: cvtToRecurringSchedule(input),
+	}
+
+	v := validator.New()
+	if !v.UpdateRecurringTrans(rt) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrMainCategNotFound,
+		domain.ErrTypeNotConsistent,
+		domain.ErrSubCategNotFound,
+		domain.ErrMainCategNotConsistent,
+		domain.ErrRecurringTransNotFound,
+	}
+
+	if err := h.recurringTrans.Update(r.Context(), rt, user.ID); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.Delete(id) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.recurringTrans.Delete(r.Context(), id, user.ID); err != nil {
+		if errors.Is(err, domain.ErrRecurringTransNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
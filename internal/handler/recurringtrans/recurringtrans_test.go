@@ -0,0 +1,260 @@
+package recurringtrans_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/recurringtrans"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockStartDate = time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+)
+
+type RecurringTransSuite struct {
+	suite.Suite
+	hlr                  *recurringtrans.Hlr
+	mockRecurringTransUC *mocks.RecurringTransUC
+}
+
+func TestRecurringTransSuite(t *testing.T) {
+	suite.Run(t, new(RecurringTransSuite))
+}
+
+func (s *RecurringTransSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *RecurringTransSuite) SetupTest() {
+	s.mockRecurringTransUC = mocks.NewRecurringTransUC(s.T())
+	s.hlr = recurringtrans.New(s.mockRecurringTransUC)
+}
+
+func (s *RecurringTransSuite) TearDownTest() {
+	s.mockRecurringTransUC.AssertExpectations(s.T())
+}
+
+func (s *RecurringTransSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when no error, create successfully":              create_NoError_CreateSuccessfully,
+		"when frequency is invalid, return bad request":   create_InvalidFrequency_ReturnBadReq,
+		"when end date before start date, return bad req": create_EndDateBeforeStartDate_ReturnBadReq,
+		"when category not consistent, return bad req":    create_CategNotConsistent_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *RecurringTransSuite, desc string) {
+	user := domain.User{ID: 1}
+	body := genReqBody(s, desc, "monthly", nil)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/recurring-transaction", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockRecurringTransUC.On("Create", req.Context(), genRecurringTrans(domain.RecurFreqTypeMonthly)).Return(nil).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_InvalidFrequency_ReturnBadReq(s *RecurringTransSuite, desc string) {
+	user := domain.User{ID: 1}
+	body := genReqBody(s, desc, "hourly", nil)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/recurring-transaction", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"frequency": "Frequency must be daily, weekly, monthly or yearly"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_EndDateBeforeStartDate_ReturnBadReq(s *RecurringTransSuite, desc string) {
+	user := domain.User{ID: 1}
+	endDate := mockStartDate.AddDate(0, 0, -1)
+	body := genReqBody(s, desc, "monthly", &endDate)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/recurring-transaction", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_CategNotConsistent_ReturnBadReq(s *RecurringTransSuite, desc string) {
+	user := domain.User{ID: 1}
+	body := genReqBody(s, desc, "weekly", nil)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/recurring-transaction", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockRecurringTransUC.On("Create", req.Context(), genRecurringTrans(domain.RecurFreqTypeWeekly)).Return(domain.ErrMainCategNotConsistent).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *RecurringTransSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when no error, delete successfully":       delete_NoError_DeleteSuccessfully,
+		"when id is incorrect, return bad request": delete_IncorrectID_ReturnBadReq,
+		"when data not found, return bad request":  delete_DataNotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NoError_DeleteSuccessfully(s *RecurringTransSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/recurring-transaction/id", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockRecurringTransUC.On("Delete", req.Context(), int64(1), int64(1)).Return(nil).Once()
+
+	s.hlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func delete_IncorrectID_ReturnBadReq(s *RecurringTransSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/recurring-transaction/id", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "a"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func delete_DataNotFound_ReturnBadReq(s *RecurringTransSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/recurring-transaction/id", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockRecurringTransUC.On("Delete", req.Context(), int64(1), int64(1)).Return(domain.ErrRecurringTransNotFound).Once()
+
+	s.hlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func genReqBody(s *RecurringTransSuite, desc string, freq string, endDate *time.Time) []byte {
+	body, err := json.Marshal(map[string]interface{}{
+		"type":             "expense",
+		"main_category_id": 1,
+		"sub_category_id":  2,
+		"price":            100,
+		"note":             "rent",
+		"frequency":        freq,
+		"interval":         1,
+		"start_date":       mockStartDate,
+		"end_date":         endDate,
+	})
+	s.Require().NoError(err, desc)
+
+	return body
+}
+
+func genRecurringTrans(freq domain.RecurFreqType) domain.RecurringTrans {
+	return domain.RecurringTrans{
+		Template: domain.CreateTransactionInput{
+			UserID:      1,
+			Type:        domain.TransactionTypeExpense,
+			MainCategID: 1,
+			SubCategID:  2,
+			Price:       100,
+			Note:        "rent",
+		},
+		Schedule: domain.RecurringSchedule{
+			Freq:      freq,
+			Interval:  1,
+			StartDate: mockStartDate,
+		},
+	}
+}
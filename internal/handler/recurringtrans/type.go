@@ -0,0 +1,31 @@
+package recurringtrans
+
+import "time"
+
+type recurringTransReq struct {
+	Type        string     `json:"type"`
+	MainCategID int64      `json:"main_category_id"`
+	SubCategID  int64      `json:"sub_category_id"`
+	Price       float64    `json:"price"`
+	Currency    string     `json:"currency"`
+	Note        string     `json:"note"`
+	Frequency   string     `json:"frequency"`
+	Interval    int        `json:"interval"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+}
+
+type recurringTrans struct {
+	ID          int64      `json:"id"`
+	Type        string     `json:"type"`
+	MainCategID int64      `json:"main_category_id"`
+	SubCategID  int64      `json:"sub_category_id"`
+	Price       float64    `json:"price"`
+	Currency    string     `json:"currency"`
+	Note        string     `json:"note"`
+	Frequency   string     `json:"frequency"`
+	Interval    int        `json:"interval"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	NextDate    time.Time  `json:"next_date"`
+}
@@ -0,0 +1,84 @@
+package rule
+
+import (
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+func cvtToDomainRule(req ruleReq, id int64) domain.Rule {
+	tags := make([]domain.Tag, 0, len(req.TagIDs))
+	for _, id := range req.TagIDs {
+		tags = append(tags, domain.Tag{ID: id})
+	}
+
+	return domain.Rule{
+		ID:           id,
+		Name:         strings.TrimSpace(req.Name),
+		Priority:     req.Priority,
+		Type:         domain.CvtToTransactionType(req.Type),
+		NoteContains: req.NoteContains,
+		NoteRegex:    req.NoteRegex,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		MainCateg:    domain.MainCateg{ID: req.MainCategID},
+		SubCateg:     domain.SubCateg{ID: req.SubCategID},
+		Tags:         tags,
+	}
+}
+
+func cvtToRulesResp(rules []domain.Rule) []rule {
+	resp := make([]rule, 0, len(rules))
+
+	for _, r := range rules {
+		resp = append(resp, rule{
+			ID:           r.ID,
+			Name:         r.Name,
+			Priority:     r.Priority,
+			Type:         r.Type.ToString(),
+			NoteContains: r.NoteContains,
+			NoteRegex:    r.NoteRegex,
+			MinPrice:     r.MinPrice,
+			MaxPrice:     r.MaxPrice,
+			MainCateg:    categ{ID: r.MainCateg.ID, Name: r.MainCateg.Name},
+			SubCateg:     categ{ID: r.SubCateg.ID, Name: r.SubCateg.Name},
+			Tags:         cvtToTagsResp(r.Tags),
+		})
+	}
+
+	return resp
+}
+
+func cvtToRuleChangesResp(changes []domain.RuleChange) []ruleChange {
+	resp := make([]ruleChange, 0, len(changes))
+
+	for _, c := range changes {
+		resp = append(resp, ruleChange{
+			TransactionID: c.TransactionID,
+			RuleID:        c.RuleID,
+			Date:          c.Date,
+			Price:         c.Price,
+			Note:          c.Note,
+			FromMainCateg: categ{ID: c.FromMainCateg.ID, Name: c.FromMainCateg.Name},
+			FromSubCateg:  categ{ID: c.FromSubCateg.ID, Name: c.FromSubCateg.Name},
+			ToMainCateg:   categ{ID: c.ToMainCateg.ID, Name: c.ToMainCateg.Name},
+			ToSubCateg:    categ{ID: c.ToSubCateg.ID, Name: c.ToSubCateg.Name},
+			AddedTags:     cvtToTagsResp(c.AddedTags),
+		})
+	}
+
+	return resp
+}
+
+func cvtToTagsResp(tags []domain.Tag) []tag {
+	resp := make([]tag, 0, len(tags))
+
+	for _, t := range tags {
+		resp = append(resp, tag{
+			ID:   t.ID,
+			Name: t.Name,
+		})
+	}
+
+	return resp
+}
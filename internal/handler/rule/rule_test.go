@@ -0,0 +1,481 @@
+package rule_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/rule"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type RuleSuite struct {
+	suite.Suite
+	hlr        *rule.Hlr
+	mockRuleUC *mocks.RuleUC
+}
+
+func TestRuleSuite(t *testing.T) {
+	suite.Run(t, new(RuleSuite))
+}
+
+func (s *RuleSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *RuleSuite) SetupTest() {
+	s.mockRuleUC = mocks.NewRuleUC(s.T())
+	s.hlr = rule.New(s.mockRuleUC)
+}
+
+func (s *RuleSuite) TearDownTest() {
+	s.mockRuleUC.AssertExpectations(s.T())
+}
+
+func (s *RuleSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when no error, create successfully":               create_NoError_CreateSuccessfully,
+		"when no condition, return bad request":            create_NoCondition_ReturnBadReq,
+		"when regex is invalid, return bad request":        create_InvalidRegex_ReturnBadReq,
+		"when max price less than min, return bad request": create_MaxLessThanMin_ReturnBadReq,
+		"when type not consistent, return bad request":     create_TypeNotConsistent_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *RuleSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"name":             " coffee ",
+		"priority":         1,
+		"type":             "expense",
+		"note_contains":    "starbucks",
+		"max_price":        20,
+		"main_category_id": 1,
+		"sub_category_id":  2,
+		"tag_ids":          []int64{3},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/rule", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	maxPrice := 20.0
+	r := domain.Rule{
+		Name:         "coffee",
+		Priority:     1,
+		Type:         domain.TransactionTypeExpense,
+		NoteContains: "starbucks",
+		MaxPrice:     &maxPrice,
+		MainCateg:    domain.MainCateg{ID: 1},
+		SubCateg:     domain.SubCateg{ID: 2},
+		Tags:         []domain.Tag{{ID: 3}},
+	}
+	s.mockRuleUC.On("Create", req.Context(), r, int64(1)).Return(nil).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_NoCondition_ReturnBadReq(s *RuleSuite, desc string) {
+	body := map[string]interface{}{
+		"name":             "everything",
+		"type":             "expense",
+		"main_category_id": 1,
+		"sub_category_id":  2,
+	}
+
+	code, resp := s.sendCreate(desc, body)
+
+	s.Require().Equal(map[string]interface{}{"rule": "At least one condition is required"}, resp, desc)
+	s.Require().Equal(http.StatusBadRequest, code, desc)
+}
+
+func create_InvalidRegex_ReturnBadReq(s *RuleSuite, desc string) {
+	body := map[string]interface{}{
+		"name":             "coffee",
+		"type":             "expense",
+		"note_regex":       "starbucks(",
+		"main_category_id": 1,
+		"sub_category_id":  2,
+	}
+
+	code, resp := s.sendCreate(desc, body)
+
+	s.Require().Equal(map[string]interface{}{"note_regex": "Note regex is invalid"}, resp, desc)
+	s.Require().Equal(http.StatusBadRequest, code, desc)
+}
+
+func create_MaxLessThanMin_ReturnBadReq(s *RuleSuite, desc string) {
+	body := map[string]interface{}{
+		"name":             "coffee",
+		"type":             "expense",
+		"min_price":        20,
+		"max_price":        10,
+		"main_category_id": 1,
+		"sub_category_id":  2,
+	}
+
+	code, resp := s.sendCreate(desc, body)
+
+	s.Require().Equal(map[string]interface{}{"max_price": "Max price must be greater than or equal to min price"}, resp, desc)
+	s.Require().Equal(http.StatusBadRequest, code, desc)
+}
+
+func create_TypeNotConsistent_ReturnBadReq(s *RuleSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"name":             "salary",
+		"type":             "income",
+		"note_contains":    "salary",
+		"main_category_id": 1,
+		"sub_category_id":  2,
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/rule", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	r := domain.Rule{
+		Name:         "salary",
+		Type:         domain.TransactionTypeIncome,
+		NoteContains: "salary",
+		MainCateg:    domain.MainCateg{ID: 1},
+		SubCateg:     domain.SubCateg{ID: 2},
+		Tags:         []domain.Tag{},
+	}
+	s.mockRuleUC.On("Create", req.Context(), r, int64(1)).Return(domain.ErrTypeNotConsistent).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *RuleSuite) TestGetAll() {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.GetAll))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/rule", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	minPrice := 5.0
+	rules := []domain.Rule{
+		{
+			ID:           1,
+			Name:         "coffee",
+			Priority:     1,
+			Type:         domain.TransactionTypeExpense,
+			NoteContains: "starbucks",
+			MinPrice:     &minPrice,
+			MainCateg:    domain.MainCateg{ID: 1, Name: "food"},
+			SubCateg:     domain.SubCateg{ID: 2, Name: "drink"},
+			Tags:         []domain.Tag{{ID: 3, Name: "daily"}},
+		},
+	}
+	s.mockRuleUC.On("GetAll", req.Context(), int64(1)).Return(rules, nil).Once()
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{
+				"id":            float64(1),
+				"name":          "coffee",
+				"priority":      float64(1),
+				"type":          "expense",
+				"note_contains": "starbucks",
+				"note_regex":    "",
+				"min_price":     float64(5),
+				"max_price":     nil,
+				"main_category": map[string]interface{}{"id": float64(1), "name": "food"},
+				"sub_category":  map[string]interface{}{"id": float64(2), "name": "drink"},
+				"tags": []interface{}{
+					map[string]interface{}{"id": float64(3), "name": "daily"},
+				},
+			},
+		},
+	}
+
+	s.hlr.GetAll(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err)
+	s.Require().Equal(expResp, responseBody)
+	s.Require().Equal(http.StatusOK, res.Code)
+}
+
+func (s *RuleSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when no error, update successfully":      update_NoError_UpdateSuccessfully,
+		"when rule not found, return bad request": update_RuleNotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoError_UpdateSuccessfully(s *RuleSuite, desc string) {
+	s.sendUpdate(desc, nil)
+}
+
+func update_RuleNotFound_ReturnBadReq(s *RuleSuite, desc string) {
+	s.sendUpdate(desc, domain.ErrRuleNotFound)
+}
+
+func (s *RuleSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when no error, delete successfully":      delete_NoError_DeleteSuccessfully,
+		"when rule not found, return bad request": delete_RuleNotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NoError_DeleteSuccessfully(s *RuleSuite, desc string) {
+	code := s.sendDelete(nil)
+	s.Require().Equal(http.StatusOK, code, desc)
+}
+
+func delete_RuleNotFound_ReturnBadReq(s *RuleSuite, desc string) {
+	code := s.sendDelete(domain.ErrRuleNotFound)
+	s.Require().Equal(http.StatusBadRequest, code, desc)
+}
+
+func (s *RuleSuite) TestRerun() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when no error, return changes":                      rerun_NoError_ReturnChanges,
+		"when start date after end date, return bad request": rerun_StartAfterEnd_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func rerun_NoError_ReturnChanges(s *RuleSuite, desc string) {
+	user := domain.User{ID: 1}
+	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	body, err := json.Marshal(map[string]interface{}{
+		"start_date": startDate,
+		"dry_run":    true,
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Rerun))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/rule/rerun", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	result := domain.RuleRerunResult{
+		DryRun: true,
+		Changes: []domain.RuleChange{
+			{
+				TransactionID: 5,
+				RuleID:        1,
+				Date:          startDate,
+				Price:         12,
+				Note:          "uber",
+				FromMainCateg: domain.MainCateg{ID: 3, Name: "food"},
+				FromSubCateg:  domain.SubCateg{ID: 4, Name: "lunch"},
+				ToMainCateg:   domain.MainCateg{ID: 1, Name: "transport"},
+				ToSubCateg:    domain.SubCateg{ID: 2, Name: "taxi"},
+			},
+		},
+	}
+	s.mockRuleUC.On("Rerun", req.Context(), domain.RuleRerunOpt{StartDate: &startDate, DryRun: true}, user).Return(result, nil).Once()
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"dry_run": true,
+		"changes": []interface{}{
+			map[string]interface{}{
+				"transaction_id":     float64(5),
+				"rule_id":            float64(1),
+				"date":               "2024-03-01T00:00:00Z",
+				"price":              float64(12),
+				"note":               "uber",
+				"from_main_category": map[string]interface{}{"id": float64(3), "name": "food"},
+				"from_sub_category":  map[string]interface{}{"id": float64(4), "name": "lunch"},
+				"to_main_category":   map[string]interface{}{"id": float64(1), "name": "transport"},
+				"to_sub_category":    map[string]interface{}{"id": float64(2), "name": "taxi"},
+				"added_tags":         []interface{}{},
+			},
+		},
+	}
+
+	s.hlr.Rerun(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func rerun_StartAfterEnd_ReturnBadReq(s *RuleSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"start_date": time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		"end_date":   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Rerun))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/rule/rerun", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Rerun(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"start_date": "Start date must be before end date"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *RuleSuite) sendCreate(desc string, body map[string]interface{}) (int, map[string]interface{}) {
+	user := domain.User{ID: 1}
+	b, err := json.Marshal(body)
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/rule", bytes.NewBuffer(b))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+
+	return res.Code, responseBody
+}
+
+func (s *RuleSuite) sendUpdate(desc string, ucErr error) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"name":             "coffee",
+		"type":             "expense",
+		"note_regex":       "^starbucks",
+		"main_category_id": 1,
+		"sub_category_id":  2,
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Update))
+	req := httptest.NewRequest(http.MethodPut, srv.URL+"/v1/rule/1", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	r := domain.Rule{
+		ID:        1,
+		Name:      "coffee",
+		Type:      domain.TransactionTypeExpense,
+		NoteRegex: "^starbucks",
+		MainCateg: domain.MainCateg{ID: 1},
+		SubCateg:  domain.SubCateg{ID: 2},
+		Tags:      []domain.Tag{},
+	}
+	s.mockRuleUC.On("Update", req.Context(), r, int64(1)).Return(ucErr).Once()
+
+	s.hlr.Update(res, req)
+
+	expCode := http.StatusOK
+	if ucErr != nil {
+		expCode = http.StatusBadRequest
+	}
+	s.Require().Equal(expCode, res.Code, desc)
+}
+
+func (s *RuleSuite) sendDelete(ucErr error) int {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/rule/1", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockRuleUC.On("Delete", req.Context(), int64(1), int64(1)).Return(ucErr).Once()
+
+	s.hlr.Delete(res, req)
+
+	return res.Code
+}
@@ -0,0 +1,59 @@
+package rule
+
+import "time"
+
+type ruleReq struct {
+	Name         string   `json:"name"`
+	Priority     int      `json:"priority"`
+	Type         string   `json:"type"`
+	NoteContains string   `json:"note_contains"`
+	NoteRegex    string   `json:"note_regex"`
+	MinPrice     *float64 `json:"min_price"`
+	MaxPrice     *float64 `json:"max_price"`
+	MainCategID  int64    `json:"main_category_id"`
+	SubCategID   int64    `json:"sub_category_id"`
+	TagIDs       []int64  `json:"tag_ids"`
+}
+
+type rerunReq struct {
+	StartDate *time.Time `json:"start_date"`
+	EndDate   *time.Time `json:"end_date"`
+	DryRun    bool       `json:"dry_run"`
+}
+
+type rule struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Priority     int      `json:"priority"`
+	Type         string   `json:"type"`
+	NoteContains string   `json:"note_contains"`
+	NoteRegex    string   `json:"note_regex"`
+	MinPrice     *float64 `json:"min_price"`
+	MaxPrice     *float64 `json:"max_price"`
+	MainCateg    categ    `json:"main_category"`
+	SubCateg     categ    `json:"sub_category"`
+	Tags         []tag    `json:"tags"`
+}
+
+type categ struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type tag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type ruleChange struct {
+	TransactionID int64     `json:"transaction_id"`
+	RuleID        int64     `json:"rule_id"`
+	Date          time.Time `json:"date"`
+	Price         float64   `json:"price"`
+	Note          string    `json:"note"`
+	FromMainCateg categ     `json:"from_main_category"`
+	FromSubCateg  categ     `json:"from_sub_category"`
+	ToMainCateg   categ     `json:"to_main_category"`
+	ToSubCateg    categ     `json:"to_sub_category"`
+	AddedTags     []tag     `json:"added_tags"`
+}
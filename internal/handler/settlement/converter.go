@@ -0,0 +1,62 @@
+package settlement
+
+import (
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+func cvtToDomainSettlement(req settlementReq) domain.Settlement {
+	return domain.Settlement{
+		FromContactID: req.FromContactID,
+		ToContactID:   req.ToContactID,
+		Amount:        req.Amount,
+		Currency:      strings.ToUpper(req.Currency),
+		Date:          req.Date,
+		Note:          req.Note,
+	}
+}
+
+func cvtToSettlementsResp(settlements []domain.Settlement) []settlement {
+	resp := make([]settlement, 0, len(settlements))
+	for _, s := range settlements {
+		resp = append(resp, settlement{
+			ID:            s.ID,
+			FromContactID: s.FromContactID,
+			ToContactID:   s.ToContactID,
+			Amount:        s.Amount,
+			Currency:      s.Currency,
+			Date:          s.Date,
+			Note:          s.Note,
+		})
+	}
+
+	return resp
+}
+
+func cvtToBalancesResp(balances []domain.Balance) []balance {
+	resp := make([]balance, 0, len(balances))
+	for _, b := range balances {
+		resp = append(resp, balance{
+			ContactID: b.ContactID,
+			Currency:  b.Currency,
+			Amount:    b.Amount,
+		})
+	}
+
+	return resp
+}
+
+func cvtToPlanResp(plan []domain.SettleUp) []settleUp {
+	resp := make([]settleUp, 0, len(plan))
+	for _, p := range plan {
+		resp = append(resp, settleUp{
+			FromContactID: p.FromContactID,
+			ToContactID:   p.ToContactID,
+			Currency:      p.Currency,
+			Amount:        p.Amount,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,151 @@
+package settlement_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/settlement"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type SettlementSuite struct {
+	suite.Suite
+	hlr              *settlement.Hlr
+	mockSettlementUC *mocks.SettlementUC
+}
+
+func TestSettlementSuite(t *testing.T) {
+	suite.Run(t, new(SettlementSuite))
+}
+
+func (s *SettlementSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *SettlementSuite) SetupTest() {
+	s.mockSettlementUC = mocks.NewSettlementUC(s.T())
+	s.hlr = settlement.New(s.mockSettlementUC)
+}
+
+func (s *SettlementSuite) TearDownTest() {
+	s.mockSettlementUC.AssertExpectations(s.T())
+}
+
+func (s *SettlementSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *SettlementSuite, desc string){
+		"when same participant, return bad request":  create_SameParticipant_ReturnBadReq,
+		"when contact not found, return bad request": create_ContactNotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_SameParticipant_ReturnBadReq(s *SettlementSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"from_contact_id": 2,
+		"to_contact_id":   2,
+		"amount":          10,
+		"date":            "2024-03-01T00:00:00Z",
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/settlement", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"to_contact_id": "To contact ID must be different from from contact ID"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_ContactNotFound_ReturnBadReq(s *SettlementSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"from_contact_id": 2,
+		"amount":          10,
+		"date":            "2024-03-01T00:00:00Z",
+	})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/settlement", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	input := domain.Settlement{
+		FromContactID: 2,
+		Amount:        10,
+		Date:          time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	s.mockSettlementUC.On("Create", req.Context(), input, int64(1)).Return(domain.ErrContactNotFound).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *SettlementSuite) TestGetBalances() {
+	for scenario, fn := range map[string]func(s *SettlementSuite, desc string){
+		"when no error, return balances and plan": getBalances_NoError_ReturnBalancesAndPlan,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getBalances_NoError_ReturnBalancesAndPlan(s *SettlementSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/balances", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	mockRes := domain.Balances{
+		Balances: []domain.Balance{
+			{ContactID: 0, Currency: "USD", Amount: 10},
+			{ContactID: 2, Currency: "USD", Amount: -10},
+		},
+		Plan: []domain.SettleUp{
+			{FromContactID: 2, ToContactID: 0, Currency: "USD", Amount: 10},
+		},
+	}
+	s.mockSettlementUC.On("GetBalances", req.Context(), int64(1)).Return(mockRes, nil).Once()
+
+	s.hlr.GetBalances(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	expResp := map[string]interface{}{
+		"balances": []interface{}{
+			map[string]interface{}{"contact_id": float64(0), "currency": "USD", "amount": float64(10)},
+			map[string]interface{}{"contact_id": float64(2), "currency": "USD", "amount": float64(-10)},
+		},
+		"plan": []interface{}{
+			map[string]interface{}{"from_contact_id": float64(2), "to_contact_id": float64(0), "currency": "USD", "amount": float64(10)},
+		},
+	}
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
@@ -0,0 +1,35 @@
+package settlement
+
+import "time"
+
+type settlementReq struct {
+	FromContactID int64     `json:"from_contact_id"`
+	ToContactID   int64     `json:"to_contact_id"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Date          time.Time `json:"date"`
+	Note          string    `json:"note"`
+}
+
+type settlement struct {
+	ID            int64     `json:"id"`
+	FromContactID int64     `json:"from_contact_id"`
+	ToContactID   int64     `json:"to_contact_id"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Date          time.Time `json:"date"`
+	Note          string    `json:"note"`
+}
+
+type balance struct {
+	ContactID int64   `json:"contact_id"`
+	Currency  string  `json:"currency"`
+	Amount    float64 `json:"amount"`
+}
+
+type settleUp struct {
+	FromContactID int64   `json:"from_contact_id"`
+	ToContactID   int64   `json:"to_contact_id"`
+	Currency      string  `json:"currency"`
+	Amount        float64 `json:"amount"`
+}
@@ -0,0 +1,40 @@
+package share
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToDomainSharedTrans(id int64, req updateSharesReq) domain.SharedTransaction {
+	shares := make([]domain.Share, 0, len(req.Shares))
+	for _, s := range req.Shares {
+		shares = append(shares, domain.Share{
+			ContactID: s.ContactID,
+			Value:     s.Value,
+		})
+	}
+
+	return domain.SharedTransaction{
+		TransactionID: id,
+		Method:        domain.CvtToShareMethodType(req.Method),
+		PaidBy:        req.PaidBy,
+		Shares:        shares,
+	}
+}
+
+func cvtToSharedTransResp(t domain.SharedTransaction) sharedTrans {
+	shares := make([]share, 0, len(t.Shares))
+	for _, s := range t.Shares {
+		shares = append(shares, share{
+			ContactID: s.ContactID,
+			Value:     s.Value,
+			Amount:    s.Amount,
+		})
+	}
+
+	return sharedTrans{
+		TransactionID: t.TransactionID,
+		Method:        t.Method.ToString(),
+		PaidBy:        t.PaidBy,
+		Price:         t.Price,
+		Currency:      t.Currency,
+		Shares:        shares,
+	}
+}
@@ -0,0 +1,176 @@
+package share_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/share"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShareSuite struct {
+	suite.Suite
+	hlr         *share.Hlr
+	mockShareUC *mocks.ShareUC
+}
+
+func TestShareSuite(t *testing.T) {
+	suite.Run(t, new(ShareSuite))
+}
+
+func (s *ShareSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ShareSuite) SetupTest() {
+	s.mockShareUC = mocks.NewShareUC(s.T())
+	s.hlr = share.New(s.mockShareUC)
+}
+
+func (s *ShareSuite) TearDownTest() {
+	s.mockShareUC.AssertExpectations(s.T())
+}
+
+func (s *ShareSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *ShareSuite, desc string){
+		"when no error, return allocated shares":              update_NoError_ReturnAllocatedShares,
+		"when percentages not sum to 100, return bad request": update_PercentNotSumTo100_ReturnBadReq,
+		"when participant is duplicated, return bad request":  update_DuplicateParticipant_ReturnBadReq,
+		"when amounts not sum to price, return bad request":   update_AmountMismatch_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoError_ReturnAllocatedShares(s *ShareSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"method": "equal",
+		"shares": []map[string]interface{}{{"contact_id": 0}, {"contact_id": 2}},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPut, "/v1/transaction/1/share", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	input := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeEqual,
+		Shares:        []domain.Share{{ContactID: 0}, {ContactID: 2}},
+	}
+	mockRes := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeEqual,
+		Price:         20,
+		Currency:      "USD",
+		Shares:        []domain.Share{{ContactID: 0, Amount: 10}, {ContactID: 2, Amount: 10}},
+	}
+	s.mockShareUC.On("Update", req.Context(), input, int64(1)).Return(mockRes, nil).Once()
+
+	s.hlr.Update(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	expResp := map[string]interface{}{
+		"shared_transaction": map[string]interface{}{
+			"transaction_id": float64(1),
+			"method":         "equal",
+			"paid_by":        float64(0),
+			"price":          float64(20),
+			"currency":       "USD",
+			"shares": []interface{}{
+				map[string]interface{}{"contact_id": float64(0), "value": float64(0), "amount": float64(10)},
+				map[string]interface{}{"contact_id": float64(2), "value": float64(0), "amount": float64(10)},
+			},
+		},
+	}
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func update_PercentNotSumTo100_ReturnBadReq(s *ShareSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"method": "percent",
+		"shares": []map[string]interface{}{{"contact_id": 0, "value": 50}, {"contact_id": 2, "value": 40}},
+	})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/transaction/1/share", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Update(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"shares": "Sum of the percentages must equal 100"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func update_DuplicateParticipant_ReturnBadReq(s *ShareSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"method": "equal",
+		"shares": []map[string]interface{}{{"contact_id": 2}, {"contact_id": 2}},
+	})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/transaction/1/share", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Update(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"shares[1].contact_id": "Participants can't be duplicated"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func update_AmountMismatch_ReturnBadReq(s *ShareSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"method": "exact",
+		"shares": []map[string]interface{}{{"contact_id": 0, "value": 5}, {"contact_id": 2, "value": 5}},
+	})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/transaction/1/share", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	input := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeExact,
+		Shares:        []domain.Share{{ContactID: 0, Value: 5}, {ContactID: 2, Value: 5}},
+	}
+	s.mockShareUC.On("Update", req.Context(), input, int64(1)).Return(domain.SharedTransaction{}, domain.ErrShareAmountMismatch).Once()
+
+	s.hlr.Update(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
@@ -0,0 +1,27 @@
+package share
+
+type shareReq struct {
+	ContactID int64   `json:"contact_id"`
+	Value     float64 `json:"value"`
+}
+
+type updateSharesReq struct {
+	Method string     `json:"method"`
+	PaidBy int64      `json:"paid_by"`
+	Shares []shareReq `json:"shares"`
+}
+
+type share struct {
+	ContactID int64   `json:"contact_id"`
+	Value     float64 `json:"value"`
+	Amount    float64 `json:"amount"`
+}
+
+type sharedTrans struct {
+	TransactionID int64   `json:"transaction_id"`
+	Method        string  `json:"method"`
+	PaidBy        int64   `json:"paid_by"`
+	Price         float64 `json:"price"`
+	Currency      string  `json:"currency"`
+	Shares        []share `json:"shares"`
+}
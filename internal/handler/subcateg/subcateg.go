@@ -3,6 +3,7 @@ package subcateg
 import (
 	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
@@ -45,7 +46,7 @@ func (h *Hlr) CreateSubCateg(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	if err := h.SubCateg.Create(&categ, user.ID); err != nil {
 		if errors.Is(err, domain.ErrUniqueNameUserMainCateg) {
 			errutil.BadRequestResponse(w, r, err)
@@ -65,8 +66,15 @@ func (h *Hlr) GetByMainCategID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
-	categs, err := h.SubCateg.GetByMainCategID(user.ID, id)
+	includeArchived, err := genIncludeArchived(r)
+	if err != nil {
+		logger.Error("genIncludeArchived failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	categs, err := h.SubCateg.GetByMainCategID(user.ID, id, includeArchived)
 	if err != nil {
 		errutil.ServerErrorResponse(w, r, err)
 		return
@@ -112,7 +120,7 @@ func (h *Hlr) UpdateSubCateg(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	if err := h.SubCateg.Update(&categ, user.ID); err != nil {
 		if errors.Is(err, domain.ErrUniqueNameUserMainCateg) {
 			errutil.BadRequestResponse(w, r, err)
@@ -143,3 +151,133 @@ func (h *Hlr) DeleteSubCateg(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (h *Hlr) MoveSubCateg(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input struct {
+		MainCategID int64 `json:"main_category_id"`
+	}
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.MoveSubCateg(input.MainCategID) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrSubCategNotFound,
+		domain.ErrMainCategNotFound,
+		domain.ErrMainCategTypeNotSame,
+		domain.ErrUniqueNameUserMainCateg,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.SubCateg.Move(r.Context(), id, input.MainCategID, user.ID); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) SetArchived(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input struct {
+		Archived *bool `json:"archived"`
+	}
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.SetCategArchived(input.Archived) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.SubCateg.SetArchived(r.Context(), id, *input.Archived, user.ID); err != nil {
+		if errors.Is(err, domain.ErrSubCategNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Reorder(w http.ResponseWriter, r *http.Request) {
+	mainCategID, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input struct {
+		IDs []int64 `json:"ids"`
+	}
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.ReorderCategs(input.IDs) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.SubCateg.Reorder(r.Context(), mainCategID, input.IDs, user.ID); err != nil {
+		if errors.Is(err, domain.ErrSubCategNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,16 @@
+package tag
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+func cvtToTagsResp(tags []domain.Tag) []tag {
+	resp := make([]tag, 0, len(tags))
+
+	for _, t := range tags {
+		resp = append(resp, tag{
+			ID:   t.ID,
+			Name: t.Name,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,242 @@
+package tag_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/tag"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type TagSuite struct {
+	suite.Suite
+	hlr       *tag.Hlr
+	mockTagUC *mocks.TagUC
+}
+
+func TestTagSuite(t *testing.T) {
+	suite.Run(t, new(TagSuite))
+}
+
+func (s *TagSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *TagSuite) SetupTest() {
+	s.mockTagUC = mocks.NewTagUC(s.T())
+	s.hlr = tag.New(s.mockTagUC)
+}
+
+func (s *TagSuite) TearDownTest() {
+	s.mockTagUC.AssertExpectations(s.T())
+}
+
+func (s *TagSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *TagSuite, desc string){
+		"when no error, create successfully":          create_NoError_CreateSuccessfully,
+		"when name is empty, return bad request":      create_EmptyName_ReturnBadReq,
+		"when name is duplicated, return bad request": create_DuplicateName_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *TagSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": " trip-japan "})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/tag", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTagUC.On("Create", req.Context(), domain.Tag{Name: "trip-japan"}, int64(1)).Return(nil).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_EmptyName_ReturnBadReq(s *TagSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "  "})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/tag", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"name": "Name can't be empty"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_DuplicateName_ReturnBadReq(s *TagSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "reimbursable"})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/tag", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTagUC.On("Create", req.Context(), domain.Tag{Name: "reimbursable"}, int64(1)).Return(domain.ErrUniqueTagNameUser).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *TagSuite) TestGetAll() {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.GetAll))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/tag", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	tags := []domain.Tag{{ID: 1, Name: "trip-japan"}, {ID: 2, Name: "reimbursable"}}
+	s.mockTagUC.On("GetAll", req.Context(), int64(1)).Return(tags, nil).Once()
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"tags": []interface{}{
+			map[string]interface{}{"id": float64(1), "name": "trip-japan"},
+			map[string]interface{}{"id": float64(2), "name": "reimbursable"},
+		},
+	}
+
+	s.hlr.GetAll(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err)
+	s.Require().Equal(expResp, responseBody)
+	s.Require().Equal(http.StatusOK, res.Code)
+}
+
+func (s *TagSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *TagSuite, desc string){
+		"when no error, update successfully":     update_NoError_UpdateSuccessfully,
+		"when tag not found, return bad request": update_TagNotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoError_UpdateSuccessfully(s *TagSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "trip-korea"})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Update))
+	req := httptest.NewRequest(http.MethodPut, srv.URL+"/v1/tag/1", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTagUC.On("Update", req.Context(), domain.Tag{ID: 1, Name: "trip-korea"}, int64(1)).Return(nil).Once()
+
+	s.hlr.Update(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func update_TagNotFound_ReturnBadReq(s *TagSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "trip-korea"})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Update))
+	req := httptest.NewRequest(http.MethodPut, srv.URL+"/v1/tag/1", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTagUC.On("Update", req.Context(), domain.Tag{ID: 1, Name: "trip-korea"}, int64(1)).Return(domain.ErrTagNotFound).Once()
+
+	s.hlr.Update(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *TagSuite) TestDelete() {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/tag/1", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTagUC.On("Delete", req.Context(), int64(1), int64(1)).Return(nil).Once()
+
+	s.hlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code)
+}
@@ -0,0 +1,10 @@
+package tag
+
+type tagReq struct {
+	Name string `json:"name"`
+}
+
+type tag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
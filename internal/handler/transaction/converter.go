@@ -1,6 +1,12 @@
 package transaction
 
-import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
 
 func cvtToGetTransactionResp(trans []domain.Transaction) getTransactionResp {
 	resp := make([]transaction, 0, len(trans))
@@ -20,15 +26,33 @@ func cvtToGetTransactionResp(trans []domain.Transaction) getTransactionResp {
 				ID:   t.SubCateg.ID,
 				Name: t.SubCateg.Name,
 			},
-			Price: t.Price,
-			Note:  t.Note,
-			Date:  t.Date,
+			Price:       t.Price,
+			Currency:    t.Currency,
+			Note:        t.Note,
+			Date:        t.Date,
+			AccountID:   t.AccountID,
+			ToAccountID: t.ToAccountID,
+			Splits:      cvtToSplitResp(t.Splits),
+			Tags:        cvtToTagResp(t.Tags),
+			Attachments: cvtToAttachmentResp(t.Attachments),
 		})
 	}
 
 	return getTransactionResp{Transactions: resp}
 }
 
+func cvtToDuplicateGroupsResp(groups []domain.DuplicateGroup) []duplicateGroup {
+	resp := make([]duplicateGroup, 0, len(groups))
+
+	for _, g := range groups {
+		resp = append(resp, duplicateGroup{
+			Transactions: cvtToGetTransactionResp(g.Transactions).Transactions,
+		})
+	}
+
+	return resp
+}
+
 func cvtToGetMonthlyResp(data []domain.TransactionType) []string {
 	resp := make([]string, len(data))
 
@@ -42,3 +66,193 @@ func cvtToGetMonthlyResp(data []domain.TransactionType) []string {
 
 	return resp
 }
+
+func cvtToSplitResp(splits []domain.TransactionSplit) []split {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	resp := make([]split, 0, len(splits))
+	for _, s := range splits {
+		resp = append(resp, split{
+			MainCateg: subCateg{
+				ID:   s.MainCateg.ID,
+				Name: s.MainCateg.Name,
+			},
+			SubCateg: subCateg{
+				ID:   s.SubCateg.ID,
+				Name: s.SubCateg.Name,
+			},
+			Price: s.Price,
+		})
+	}
+
+	return resp
+}
+
+func cvtToSplitInputs(splits []splitReq) []domain.SplitInput {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	inputs := make([]domain.SplitInput, 0, len(splits))
+	for _, s := range splits {
+		inputs = append(inputs, domain.SplitInput{
+			MainCategID: s.MainCategID,
+			SubCategID:  s.SubCategID,
+			Price:       s.Price,
+		})
+	}
+
+	return inputs
+}
+
+func cvtToBulkTransInput(req bulkTransactionReq, userID int64) (domain.BulkTransInput, error) {
+	var input domain.BulkTransInput
+
+	for _, t := range req.Create {
+		input.Create = append(input.Create, domain.CreateTransactionInput{
+			UserID:      userID,
+			Type:        domain.CvtToTransactionType(t.Type),
+			MainCategID: t.MainCategID,
+			SubCategID:  t.SubCategID,
+			Price:       t.Price,
+			Currency:    strings.ToUpper(t.Currency),
+			Date:        t.Date,
+			Note:        t.Note,
+			AccountID:   t.AccountID,
+			ToAccountID: t.ToAccountID,
+			Splits:      cvtToSplitInputs(t.Splits),
+			TagIDs:      t.TagIDs,
+		})
+	}
+
+	for _, t := range req.Update {
+		input.Update = append(input.Update, domain.BulkUpdateTransInput{
+			ID:          t.ID,
+			MainCategID: t.MainCategID,
+			SubCategID:  t.SubCategID,
+			Note:        t.Note,
+			Date:        t.Date,
+			AddTagIDs:   t.AddTagIDs,
+		})
+	}
+
+	if req.Delete == nil {
+		return input, nil
+	}
+
+	input.Delete.IDs = req.Delete.IDs
+	if req.Delete.Filter == nil {
+		return input, nil
+	}
+
+	f := req.Delete.Filter
+	opt := domain.GetTransOpt{
+		Search: domain.Search{Keyword: f.Keyword},
+		Filter: domain.Filter{
+			MinPrice:     f.MinPrice,
+			MaxPrice:     f.MaxPrice,
+			MainCategIDs: f.MainCategIDs,
+			SubCategIDs:  f.SubCategIDs,
+			TagIDs:       f.TagIDs,
+			TagMatch:     domain.CvtToTagMatchType(f.TagMatch),
+		},
+	}
+
+	if f.StartDate != nil {
+		date, err := time.Parse(time.DateOnly, *f.StartDate)
+		if err != nil {
+			return domain.BulkTransInput{}, errors.New("start date must be in YYYY-MM-DD format")
+		}
+		opt.Filter.StartDate = &date
+	}
+
+	if f.EndDate != nil {
+		date, err := time.Parse(time.DateOnly, *f.EndDate)
+		if err != nil {
+			return domain.BulkTransInput{}, errors.New("end date must be in YYYY-MM-DD format")
+		}
+		opt.Filter.EndDate = &date
+	}
+
+	input.Delete.Opt = &opt
+	return input, nil
+}
+
+func cvtToAttachmentResp(attachments []domain.TransAttachment) []attachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	resp := make([]attachment, 0, len(attachments))
+	for _, a := range attachments {
+		resp = append(resp, attachment{
+			ID:       a.ID,
+			FileName: a.FileName,
+			URL:      a.URL,
+		})
+	}
+
+	return resp
+}
+
+func cvtToTagResp(tags []domain.Tag) []tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	resp := make([]tag, 0, len(tags))
+	for _, t := range tags {
+		resp = append(resp, tag{
+			ID:   t.ID,
+			Name: t.Name,
+		})
+	}
+
+	return resp
+}
+
+func cvtToRevisionResp(revs []domain.TransRevision) []revision {
+	resp := make([]revision, 0, len(revs))
+	for _, r := range revs {
+		resp = append(resp, revision{
+			ID:        r.ID,
+			Action:    r.Action.ToString(),
+			Before:    cvtToSnapshotResp(r.Before),
+			After:     cvtToSnapshotResp(r.After),
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return resp
+}
+
+func cvtToSnapshotResp(s *domain.TransSnapshot) *snapshot {
+	if s == nil {
+		return nil
+	}
+
+	resp := &snapshot{
+		Type:        s.Type.ToString(),
+		MainCategID: s.MainCategID,
+		SubCategID:  s.SubCategID,
+		Price:       s.Price,
+		Currency:    s.Currency,
+		Date:        s.Date,
+		Note:        s.Note,
+		AccountID:   s.AccountID,
+		ToAccountID: s.ToAccountID,
+		TagIDs:      s.TagIDs,
+	}
+
+	for _, sp := range s.Splits {
+		resp.Splits = append(resp.Splits, splitReq{
+			MainCategID: sp.MainCategID,
+			SubCategID:  sp.SubCategID,
+			Price:       sp.Price,
+		})
+	}
+
+	return resp
+}
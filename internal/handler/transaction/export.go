@@ -0,0 +1,223 @@
+package transaction
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+const (
+	// ofxCurrency is the currency of exported OFX statements
+	ofxCurrency = "USD"
+
+	// ofxNameMaxLen is the max length of <NAME> in OFX
+	ofxNameMaxLen = 32
+)
+
+var (
+	csvHeader = []string{"id", "date", "type", "main_category", "sub_category", "price", "currency", "note"}
+)
+
+// transWriter writes transactions in an export format
+type transWriter interface {
+	// ContentType returns the MIME type of the format
+	ContentType() string
+
+	// Begin writes the content before the first transaction
+	Begin() error
+
+	// Write writes a transaction
+	Write(t domain.Transaction) error
+
+	// End writes the content after the last transaction, and flushes the buffered data
+	End() error
+}
+
+func newTransWriter(format domain.ExportFormatType, w io.Writer, opt domain.GetTransOpt, userID int64) transWriter {
+	switch format {
+	case domain.ExportFormatTypeJSON:
+		return &jsonTransWriter{w: bufio.NewWriter(w)}
+	case domain.ExportFormatTypeOFX:
+		return &ofxTransWriter{w: bufio.NewWriter(w), opt: opt, userID: userID}
+	}
+
+	return &csvTransWriter{w: csv.NewWriter(w)}
+}
+
+type csvTransWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvTransWriter) ContentType() string {
+	return "text/csv"
+}
+
+func (c *csvTransWriter) Begin() error {
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvTransWriter) Write(t domain.Transaction) error {
+	return c.w.Write([]string{
+		strconv.FormatInt(t.ID, 10),
+		t.Date.Format(time.DateOnly),
+		t.Type.ToString(),
+		t.MainCateg.Name,
+		t.SubCateg.Name,
+		strconv.FormatFloat(t.Price, 'f', -1, 64),
+		t.Currency,
+		t.Note,
+	})
+}
+
+func (c *csvTransWriter) End() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonTransWriter struct {
+	w     *bufio.Writer
+	count int
+}
+
+type exportTransaction struct {
+	ID            int64   `json:"id"`
+	Date          string  `json:"date"`
+	Type          string  `json:"type"`
+	MainCategID   int64   `json:"main_category_id"`
+	MainCategName string  `json:"main_category"`
+	SubCategID    int64   `json:"sub_category_id"`
+	SubCategName  string  `json:"sub_category"`
+	Price         float64 `json:"price"`
+	Currency      string  `json:"currency"`
+	Note          string  `json:"note"`
+}
+
+func (j *jsonTransWriter) ContentType() string {
+	return "application/json"
+}
+
+func (j *jsonTransWriter) Begin() error {
+	_, err := j.w.WriteString(`{"transactions":[`)
+	return err
+}
+
+func (j *jsonTransWriter) Write(t domain.Transaction) error {
+	if j.count > 0 {
+		if err := j.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	j.count++
+
+	b, err := json.Marshal(exportTransaction{
+		ID:            t.ID,
+		Date:          t.Date.Format(time.DateOnly),
+		Type:          t.Type.ToString(),
+		MainCategID:   t.MainCateg.ID,
+		MainCategName: t.MainCateg.Name,
+		SubCategID:    t.SubCateg.ID,
+		SubCategName:  t.SubCateg.Name,
+		Price:         t.Price,
+		Currency:      t.Currency,
+		Note:          t.Note,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = j.w.Write(b)
+	return err
+}
+
+func (j *jsonTransWriter) End() error {
+	if _, err := j.w.WriteString("]}\n"); err != nil {
+		return err
+	}
+
+	return j.w.Flush()
+}
+
+// ofxTransWriter writes an OFX 2.2 bank statement, income is credit and expense is debit
+type ofxTransWriter struct {
+	w      *bufio.Writer
+	opt    domain.GetTransOpt
+	userID int64
+}
+
+func (o *ofxTransWriter) ContentType() string {
+	return "application/x-ofx"
+}
+
+func (o *ofxTransWriter) Begin() error {
+	now := time.Now().Format("20060102150405")
+
+	var dateRange string
+	if o.opt.Filter.StartDate != nil {
+		dateRange += "<DTSTART>" + o.opt.Filter.StartDate.Format("20060102") + "</DTSTART>"
+	}
+	if o.opt.Filter.EndDate != nil {
+		dateRange += "<DTEND>" + o.opt.Filter.EndDate.Format("20060102") + "</DTEND>"
+	}
+
+	_, err := fmt.Fprintf(o.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS><CURDEF>%s</CURDEF>
+<BANKACCTFROM><BANKID>expense-tracker</BANKID><ACCTID>%d</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST>%s
+`, now, ofxCurrency, o.userID, dateRange)
+	return err
+}
+
+func (o *ofxTransWriter) Write(t domain.Transaction) error {
+	// the statement covers all accounts of the user, so transfer between them doesn't move money
+	if t.Type == domain.TransactionTypeTransfer {
+		return nil
+	}
+
+	trnType, amount := "CREDIT", t.Price
+	if t.Type == domain.TransactionTypeExpense {
+		trnType, amount = "DEBIT", -t.Price
+	}
+
+	name := t.MainCateg.Name
+	if t.SubCateg.Name != "" {
+		name += "/" + t.SubCateg.Name
+	}
+	if r := []rune(name); len(r) > ofxNameMaxLen {
+		name = string(r[:ofxNameMaxLen])
+	}
+
+	if _, err := fmt.Fprintf(o.w, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%d</FITID><NAME>",
+		trnType, t.Date.Format("20060102"), strconv.FormatFloat(amount, 'f', 2, 64), t.ID); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(o.w, []byte(name)); err != nil {
+		return err
+	}
+	if _, err := o.w.WriteString("</NAME><MEMO>"); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(o.w, []byte(t.Note)); err != nil {
+		return err
+	}
+	_, err := o.w.WriteString("</MEMO></STMTTRN>\n")
+	return err
+}
+
+func (o *ofxTransWriter) End() error {
+	if _, err := o.w.WriteString("</BANKTRANLIST>\n</STMTRS></STMTTRNRS></BANKMSGSRSV1>\n</OFX>\n"); err != nil {
+		return err
+	}
+
+	return o.w.Flush()
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/searchutil"
 )
 
 func genGetTransOpt(r *http.Request) (domain.GetTransOpt, error) {
@@ -93,11 +94,29 @@ func genGetTransOpt(r *http.Request) (domain.GetTransOpt, error) {
 	}
 	opt.Filter.SubCategIDs = subCategIDs
 
+	tagIDs, err := genTagIDs(r)
+	if err != nil {
+		return domain.GetTransOpt{}, err
+	}
+	opt.Filter.TagIDs = tagIDs
+	opt.Filter.TagMatch = domain.CvtToTagMatchType(r.URL.Query().Get("tag_match"))
+
+	viewID, err := genViewID(r)
+	if err != nil {
+		return domain.GetTransOpt{}, err
+	}
+	opt.ViewID = viewID
+
 	nextKey := r.URL.Query().Get("next_key")
 	if nextKey != "" {
 		opt.Cursor.NextKey = nextKey
 	}
 
+	prevKey := r.URL.Query().Get("prev_key")
+	if prevKey != "" {
+		opt.Cursor.PrevKey = prevKey
+	}
+
 	rawSize := r.URL.Query().Get("size")
 	if rawSize != "" {
 		size, err := strconv.Atoi(rawSize)
@@ -110,6 +129,86 @@ func genGetTransOpt(r *http.Request) (domain.GetTransOpt, error) {
 	return opt, nil
 }
 
+// genSearchQuery parses the search query in q, it's nil when q isn't given
+func genSearchQuery(r *http.Request) (*domain.SearchQuery, error) {
+	rawQuery := r.URL.Query().Get("q")
+	if rawQuery == "" {
+		return nil, nil
+	}
+
+	query, err := searchutil.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query, nil
+}
+
+// genViewID returns the id of the saved view to apply, 0 means no view
+func genViewID(r *http.Request) (int64, error) {
+	rawViewID := r.URL.Query().Get("view")
+	if rawViewID == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(rawViewID, 10, 64)
+}
+
+// genWithTotal returns whether the total of the transactions is wanted along with the page
+func genWithTotal(r *http.Request) (bool, error) {
+	rawWithTotal := r.URL.Query().Get("with_total")
+	if rawWithTotal == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(rawWithTotal)
+}
+
+// genStrict returns whether the transaction is refused when it may be a duplicate
+func genStrict(r *http.Request) (bool, error) {
+	rawStrict := r.URL.Query().Get("strict")
+	if rawStrict == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(rawStrict)
+}
+
+// genGetDuplicatesOpt generates the option of getting duplicate transactions,
+// the range is the last DuplicateRangeDays days when the dates are not given
+func genGetDuplicatesOpt(r *http.Request) (domain.GetDuplicatesOpt, error) {
+	opt := domain.GetDuplicatesOpt{Days: domain.DuplicateWindowDays}
+
+	if rawDays := r.URL.Query().Get("days"); rawDays != "" {
+		days, err := strconv.Atoi(rawDays)
+		if err != nil {
+			return domain.GetDuplicatesOpt{}, err
+		}
+		opt.Days = days
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	opt.EndDate = today
+	if rawEndDate := r.URL.Query().Get("end_date"); rawEndDate != "" {
+		endDate, err := time.Parse(time.DateOnly, rawEndDate)
+		if err != nil {
+			return domain.GetDuplicatesOpt{}, errors.New("end date must be in YYYY-MM-DD format")
+		}
+		opt.EndDate = endDate
+	}
+
+	opt.StartDate = opt.EndDate.AddDate(0, 0, -domain.DuplicateRangeDays)
+	if rawStartDate := r.URL.Query().Get("start_date"); rawStartDate != "" {
+		startDate, err := time.Parse(time.DateOnly, rawStartDate)
+		if err != nil {
+			return domain.GetDuplicatesOpt{}, errors.New("start date must be in YYYY-MM-DD format")
+		}
+		opt.StartDate = startDate
+	}
+
+	return opt, nil
+}
+
 func genGetAccInfoQuery(r *http.Request) domain.GetAccInfoQuery {
 	rawStartDate := r.URL.Query().Get("start_date")
 	rawEndDate := r.URL.Query().Get("end_date")
@@ -203,3 +302,23 @@ func genSubCategIDs(r *http.Request) ([]int64, error) {
 
 	return intSlice, nil
 }
+
+func genTagIDs(r *http.Request) ([]int64, error) {
+	rawTagIDs := r.URL.Query().Get("tag_ids")
+	if rawTagIDs == "" {
+		return nil, nil
+	}
+
+	strSlice := strings.Split(rawTagIDs, ",")
+	intSlice := make([]int64, len(strSlice))
+
+	for i, str := range strSlice {
+		num, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, err
+		}
+		intSlice[i] = int64(num)
+	}
+
+	return intSlice, nil
+}
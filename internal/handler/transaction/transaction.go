@@ -2,8 +2,12 @@ package transaction
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
@@ -12,6 +16,7 @@ import (
 	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
 	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+	"github.com/gorilla/mux"
 )
 
 const (
@@ -36,15 +41,27 @@ func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	strict, err := genStrict(r)
+	if err != nil {
+		logger.Error("genStrict failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
 	trans := domain.CreateTransactionInput{
 		UserID:      user.ID,
 		Type:        domain.CvtToTransactionType(input.Type),
 		MainCategID: input.MainCategID,
 		SubCategID:  input.SubCategID,
 		Price:       input.Price,
+		Currency:    strings.ToUpper(input.Currency),
 		Date:        input.Date,
 		Note:        input.Note,
+		AccountID:   input.AccountID,
+		ToAccountID: input.ToAccountID,
+		Splits:      cvtToSplitInputs(input.Splits),
+		TagIDs:      input.TagIDs,
 	}
 
 	v := validator.New()
@@ -53,9 +70,27 @@ func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	errs := []error{
+		domain.ErrDataNotFound,
+		domain.ErrMainCategNotFound,
+		domain.ErrTypeNotConsistent,
+		domain.ErrSubCategNotFound,
+		domain.ErrMainCategNotConsistent,
+		domain.ErrAccountNotFound,
+		domain.ErrTransferSameAccount,
+		domain.ErrTagNotFound,
+		domain.ErrNoRuleMatched,
+	}
+
 	ctx := r.Context()
-	if err := h.transaction.Create(ctx, trans); err != nil {
-		if errors.Is(err, domain.ErrDataNotFound) {
+	duplicates, err := h.transaction.Create(ctx, trans, strict)
+	if err != nil {
+		if errors.Is(err, domain.ErrDuplicateTransaction) {
+			errutil.ConflictResponse(w, r, err)
+			return
+		}
+
+		if slices.Contains(errs, err) {
 			errutil.BadRequestResponse(w, r, err)
 			return
 		}
@@ -64,7 +99,16 @@ func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := jsonutil.WriteJSON(w, http.StatusCreated, nil, nil); err != nil {
+	// the transaction is created, and the suspected duplicates are sent as a warning
+	var respData map[string]interface{}
+	if len(duplicates) > 0 {
+		respData = map[string]interface{}{
+			"warning":    domain.ErrDuplicateTransaction.Error(),
+			"duplicates": cvtToGetTransactionResp(duplicates).Transactions,
+		}
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, respData, nil); err != nil {
 		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
 		errutil.ServerErrorResponse(w, r, err)
 		return
@@ -78,16 +122,34 @@ func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	withTotal, err := genWithTotal(r)
+	if err != nil {
+		logger.Error("genWithTotal failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	query, err := genSearchQuery(r)
+	opt.Search.Query = query
+
 	v := validator.New()
+	if err != nil {
+		v.AddError("q", err.Error())
+	}
 	if !v.GetTransaction(opt) {
 		errutil.VildateErrorResponse(w, r, v.Error)
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	ctx := r.Context()
 	transactions, cursor, err := h.transaction.GetAll(ctx, opt, *user)
 	if err != nil {
+		if errors.Is(err, domain.ErrViewNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
 		errutil.ServerErrorResponse(w, r, err)
 		return
 	}
@@ -98,6 +160,16 @@ func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
 		"cursor":       cursor,
 	}
 
+	if withTotal {
+		total, err := h.transaction.GetTotal(ctx, opt, *user)
+		if err != nil {
+			errutil.ServerErrorResponse(w, r, err)
+			return
+		}
+
+		respData["total"] = total
+	}
+
 	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
 		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
 		errutil.ServerErrorResponse(w, r, err)
@@ -105,6 +177,86 @@ func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Hlr) Export(w http.ResponseWriter, r *http.Request) {
+	format := domain.ExportFormatTypeCSV
+	if rawFormat := r.URL.Query().Get("format"); rawFormat != "" {
+		format = domain.CvtToExportFormatType(rawFormat)
+	}
+	if !format.IsValid() {
+		errutil.VildateErrorResponse(w, r, map[string]string{"format": "Format must be csv, json or ofx"})
+		return
+	}
+
+	opt, err := genGetTransOpt(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	query, err := genSearchQuery(r)
+	opt.Search.Query = query
+
+	v := validator.New()
+	if err != nil {
+		v.AddError("q", err.Error())
+	}
+	if !v.GetTransaction(opt) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	tw := newTransWriter(format, w, opt, user.ID)
+
+	// the response is started lazily, so that an error before the first row can still be reported with a proper status
+	var started bool
+	begin := func() error {
+		started = true
+		filename := fmt.Sprintf("transactions-%s.%s", time.Now().Format("20060102"), format.ToString())
+		w.Header().Set("Content-Type", tw.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		w.WriteHeader(http.StatusOK)
+		return tw.Begin()
+	}
+
+	err = h.transaction.Export(r.Context(), opt, *user, func(t domain.Transaction) error {
+		if !started {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+
+		return tw.Write(t)
+	})
+	if err != nil {
+		if !started && errors.Is(err, domain.ErrViewNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		if !started {
+			errutil.ServerErrorResponse(w, r, err)
+			return
+		}
+
+		// the status is already sent, the client gets a truncated file
+		logger.Error("h.transaction.Export failed", "package", packageName, "err", err)
+		return
+	}
+
+	if !started {
+		if err := begin(); err != nil {
+			logger.Error("begin export failed", "package", packageName, "err", err)
+			return
+		}
+	}
+
+	if err := tw.End(); err != nil {
+		logger.Error("end export failed", "package", packageName, "err", err)
+		return
+	}
+}
+
 func (h *Hlr) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := jsonutil.ReadID(r)
 	if err != nil {
@@ -119,15 +271,20 @@ func (h *Hlr) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	trans := domain.UpdateTransactionInput{
 		ID:          id,
 		Type:        domain.CvtToTransactionType(input.Type),
 		MainCategID: input.MainCategID,
 		SubCategID:  input.SubCategID,
 		Price:       input.Price,
+		Currency:    strings.ToUpper(input.Currency),
 		Date:        input.Date,
 		Note:        input.Note,
+		AccountID:   input.AccountID,
+		ToAccountID: input.ToAccountID,
+		Splits:      cvtToSplitInputs(input.Splits),
+		TagIDs:      input.TagIDs,
 	}
 
 	v := validator.New()
@@ -142,6 +299,9 @@ func (h *Hlr) Update(w http.ResponseWriter, r *http.Request) {
 		domain.ErrSubCategNotFound,
 		domain.ErrMainCategNotConsistent,
 		domain.ErrTransactionDataNotFound,
+		domain.ErrAccountNotFound,
+		domain.ErrTransferSameAccount,
+		domain.ErrTagNotFound,
 	}
 
 	if err := h.transaction.Update(r.Context(), trans, *user); err != nil {
@@ -175,7 +335,7 @@ func (h *Hlr) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	if err := h.transaction.Delete(ctx, id, *user); err != nil {
 		if errors.Is(err, domain.ErrTransactionDataNotFound) {
 			errutil.BadRequestResponse(w, r, err)
@@ -193,6 +353,380 @@ func (h *Hlr) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Hlr) Bulk(w http.ResponseWriter, r *http.Request) {
+	var req bulkTransactionReq
+	if err := jsonutil.ReadJson(w, r, &req); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	input, err := cvtToBulkTransInput(req, user.ID)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.BulkTransaction(input) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrTransactionDataNotFound,
+		domain.ErrBulkUpdateSplitCateg,
+		domain.ErrBulkUpdateDeletedTrans,
+		domain.ErrMainCategNotFound,
+		domain.ErrTypeNotConsistent,
+		domain.ErrSubCategNotFound,
+		domain.ErrMainCategNotConsistent,
+		domain.ErrAccountNotFound,
+		domain.ErrTransferSameAccount,
+		domain.ErrTagNotFound,
+		domain.ErrNoRuleMatched,
+	}
+
+	result, err := h.transaction.Bulk(r.Context(), input, *user)
+	if err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	createdIDs := result.CreatedIDs
+	if createdIDs == nil {
+		createdIDs = []int64{}
+	}
+
+	respData := map[string]interface{}{
+		"created_ids": createdIDs,
+		"updated":     result.Updated,
+		"deleted":     result.Deleted,
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetDuplicates(w http.ResponseWriter, r *http.Request) {
+	opt, err := genGetDuplicatesOpt(r)
+	if err != nil {
+		logger.Error("genGetDuplicatesOpt failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.GetDuplicates(opt) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	groups, err := h.transaction.GetDuplicates(r.Context(), opt, *user)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"groups": cvtToDuplicateGroupsResp(groups),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) MergeDuplicates(w http.ResponseWriter, r *http.Request) {
+	var req mergeDuplicatesReq
+	if err := jsonutil.ReadJson(w, r, &req); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.MergeDuplicates(req.KeepID, req.IDs) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.transaction.MergeDuplicates(r.Context(), req.KeepID, req.IDs, *user); err != nil {
+		if errors.Is(err, domain.ErrTransactionDataNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) DismissDuplicates(w http.ResponseWriter, r *http.Request) {
+	var req dismissDuplicatesReq
+	if err := jsonutil.ReadJson(w, r, &req); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.DismissDuplicates(req.IDs) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.transaction.DismissDuplicates(r.Context(), req.IDs, *user); err != nil {
+		if errors.Is(err, domain.ErrTransactionDataNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.GetTransHistory(id) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	history, err := h.transaction.GetHistory(r.Context(), id, *user)
+	if err != nil {
+		if errors.Is(err, domain.ErrTransactionDataNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"history": cvtToRevisionResp(history),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Revert(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	revisionID, err := strconv.ParseInt(mux.Vars(r)["revision_id"], 10, 64)
+	if err != nil {
+		logger.Error("strconv.ParseInt failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.RevertTransaction(id, revisionID) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrTransactionDataNotFound,
+		domain.ErrTransRevisionNotFound,
+		domain.ErrTransRevisionNotRevertible,
+		domain.ErrMainCategNotFound,
+		domain.ErrTypeNotConsistent,
+		domain.ErrSubCategNotFound,
+		domain.ErrMainCategNotConsistent,
+		domain.ErrAccountNotFound,
+		domain.ErrTransferSameAccount,
+		domain.ErrTagNotFound,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.transaction.Revert(r.Context(), id, revisionID, *user); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetAttachmentPutURL(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input attachmentReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.GetAttachmentPutURL(id, input.FileName) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	putURL, err := h.transaction.GetAttachmentPutURL(r.Context(), id, input.FileName, *user)
+	if err != nil {
+		if errors.Is(err, domain.ErrTransactionDataNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"url":        putURL.URL,
+		"object_key": putURL.ObjectKey,
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) CreateAttachment(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	var input attachmentReq
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJSON failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.CreateAttachment(id, input.FileName, input.ObjectKey) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrTransactionDataNotFound,
+		domain.ErrUniqueAttachmentFileName,
+		domain.ErrAttachmentObjectKeyInvalid,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.transaction.CreateAttachment(r.Context(), id, input.FileName, input.ObjectKey, *user); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusCreated, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	attachmentID, err := strconv.ParseInt(mux.Vars(r)["attachment_id"], 10, 64)
+	if err != nil {
+		logger.Error("strconv.ParseInt failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.DeleteAttachment(id, attachmentID) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrTransactionDataNotFound,
+		domain.ErrAttachmentNotFound,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.transaction.DeleteAttachment(r.Context(), id, attachmentID, *user); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
 func (h *Hlr) GetAccInfo(w http.ResponseWriter, r *http.Request) {
 	query := genGetAccInfoQuery(r)
 	rawTimeRangeType := r.URL.Query().Get("time_range")
@@ -204,7 +738,7 @@ func (h *Hlr) GetAccInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	ctx := r.Context()
 	info, err := h.transaction.GetAccInfo(ctx, *user, query, timeRangeType)
 	if err != nil {
@@ -216,6 +750,7 @@ func (h *Hlr) GetAccInfo(w http.ResponseWriter, r *http.Request) {
 		"total_income":  info.TotalIncome,
 		"total_expense": info.TotalExpense,
 		"total_balance": info.TotalBalance,
+		"unconverted":   info.Unconverted,
 	}
 
 	if err := jsonutil.WriteJSON(w, http.StatusOK, resp, nil); err != nil {
@@ -240,6 +775,13 @@ func (h *Hlr) GetBarChartData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	viewID, err := genViewID(r)
+	if err != nil {
+		logger.Error("genViewID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
 	rawTransactionType := r.URL.Query().Get("type")
 	transactionType := domain.CvtToTransactionType(rawTransactionType)
 
@@ -252,10 +794,15 @@ func (h *Hlr) GetBarChartData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	ctx := r.Context()
-	data, err := h.transaction.GetBarChartData(ctx, dateRange, timeRangeType, transactionType, mainCatagIDs, *user)
+	data, err := h.transaction.GetBarChartData(ctx, dateRange, timeRangeType, transactionType, mainCatagIDs, viewID, *user)
 	if err != nil {
+		if errors.Is(err, domain.ErrViewNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
 		errutil.ServerErrorResponse(w, r, err)
 		return
 	}
@@ -279,6 +826,13 @@ func (h *Hlr) GetPieChartData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	viewID, err := genViewID(r)
+	if err != nil {
+		logger.Error("genViewID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
 	rawTransactionType := r.URL.Query().Get("type")
 	transactionType := domain.CvtToTransactionType(rawTransactionType)
 
@@ -288,9 +842,61 @@ func (h *Hlr) GetPieChartData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
-	data, err := h.transaction.GetPieChartData(r.Context(), dateRange, transactionType, *user)
+	user := ctxutil.GetLedgerUser(r)
+	data, err := h.transaction.GetPieChartData(r.Context(), dateRange, transactionType, viewID, *user)
 	if err != nil {
+		if errors.Is(err, domain.ErrViewNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	resp := map[string]interface{}{
+		"chart_data": data,
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, resp, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) GetTagChartData(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := genChartDateRange(r)
+	if err != nil {
+		logger.Error("genChartDateRange failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	viewID, err := genViewID(r)
+	if err != nil {
+		logger.Error("genViewID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	rawTransactionType := r.URL.Query().Get("type")
+	transactionType := domain.CvtToTransactionType(rawTransactionType)
+
+	v := validator.New()
+	if !v.GetTagChartData(dateRange, transactionType) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	data, err := h.transaction.GetTagChartData(r.Context(), dateRange, transactionType, viewID, *user)
+	if err != nil {
+		if errors.Is(err, domain.ErrViewNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
 		errutil.ServerErrorResponse(w, r, err)
 		return
 	}
@@ -314,6 +920,13 @@ func (h *Hlr) GetLineChartData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	viewID, err := genViewID(r)
+	if err != nil {
+		logger.Error("genViewID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
 	rawTimeRangeType := r.URL.Query().Get("time_range")
 	timeRangeType := domain.CvtToTimeRangeType(rawTimeRangeType)
 
@@ -323,9 +936,14 @@ func (h *Hlr) GetLineChartData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
-	data, err := h.transaction.GetLineChartData(r.Context(), dateRange, timeRangeType, *user)
+	user := ctxutil.GetLedgerUser(r)
+	data, err := h.transaction.GetLineChartData(r.Context(), dateRange, timeRangeType, viewID, *user)
 	if err != nil {
+		if errors.Is(err, domain.ErrViewNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
 		errutil.ServerErrorResponse(w, r, err)
 		return
 	}
@@ -360,7 +978,7 @@ func (h *Hlr) GetMonthlyData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	data, err := h.transaction.GetMonthlyData(r.Context(), dateRange, *user)
 	if err != nil {
 		errutil.ServerErrorResponse(w, r, err)
@@ -1,10 +1,13 @@
 package transaction_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
 	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
 	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -41,6 +45,225 @@ func (s *TransactionSuite) TearDownTest() {
 	s.mockTransactionUC.AssertExpectations(s.T())
 }
 
+func (s *TransactionSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when split, create successfully":                 create_Split_CreateSuccessfully,
+		"when split not sum to price, return bad request": create_SplitNotSumToPrice_ReturnBadReq,
+		"when similar transaction exists, return warning": create_SimilarTransExists_ReturnWarning,
+		"when strict and similar exists, return conflict": create_StrictSimilarTransExists_ReturnConflict,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_Split_CreateSuccessfully(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	body, err := json.Marshal(map[string]interface{}{
+		"type":  "expense",
+		"price": 100.5,
+		"date":  date,
+		"splits": []map[string]interface{}{
+			{"main_category_id": 1, "sub_category_id": 2, "price": 60.25},
+			{"main_category_id": 3, "sub_category_id": 4, "price": 40.25},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/transaction", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	trans := domain.CreateTransactionInput{
+		UserID: 1,
+		Type:   domain.TransactionTypeExpense,
+		Price:  100.5,
+		Date:   date,
+		Splits: []domain.SplitInput{
+			{MainCategID: 1, SubCategID: 2, Price: 60.25},
+			{MainCategID: 3, SubCategID: 4, Price: 40.25},
+		},
+	}
+	s.mockTransactionUC.On("Create", req.Context(), trans, false).Return(nil, nil).Once()
+
+	s.transactionHlr.Create(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_SplitNotSumToPrice_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"type":  "expense",
+		"price": 100,
+		"date":  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"splits": []map[string]interface{}{
+			{"main_category_id": 1, "sub_category_id": 2, "price": 60},
+			{"main_category_id": 3, "sub_category_id": 4, "price": 30},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Create))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/transaction", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"splits": "Sum of the split prices must equal the price"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_SimilarTransExists_ReturnWarning(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	body, err := json.Marshal(map[string]interface{}{
+		"type":             "expense",
+		"main_category_id": 1,
+		"sub_category_id":  2,
+		"price":            10,
+		"date":             date,
+		"note":             "coffee",
+	})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	trans := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: 1,
+		SubCategID:  2,
+		Price:       10,
+		Date:        date,
+		Note:        "coffee",
+	}
+	duplicates := []domain.Transaction{{ID: 3, Type: domain.TransactionTypeExpense, Price: 10, Date: date, Note: "Coffee"}}
+	s.mockTransactionUC.On("Create", req.Context(), trans, false).Return(duplicates, nil).Once()
+
+	s.transactionHlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.ErrDuplicateTransaction.Error(), responseBody["warning"], desc)
+	s.Require().Len(responseBody["duplicates"], 1, desc)
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_StrictSimilarTransExists_ReturnConflict(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	body, err := json.Marshal(map[string]interface{}{
+		"type":             "expense",
+		"main_category_id": 1,
+		"sub_category_id":  2,
+		"price":            10,
+		"date":             date,
+	})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction?strict=true", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	trans := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: 1,
+		SubCategID:  2,
+		Price:       10,
+		Date:        date,
+	}
+	s.mockTransactionUC.On("Create", req.Context(), trans, true).Return(nil, domain.ErrDuplicateTransaction).Once()
+
+	s.transactionHlr.Create(res, req)
+
+	s.Require().Equal(http.StatusConflict, res.Code, desc)
+}
+
+func (s *TransactionSuite) TestMergeDuplicates() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, merge successfully":              mergeDuplicates_NoError_MergeSuccessfully,
+		"when ids contain keep id, return bad request":   mergeDuplicates_IDsContainKeepID_ReturnBadReq,
+		"when transaction not found, return bad request": mergeDuplicates_TransNotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func mergeDuplicates_NoError_MergeSuccessfully(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"keep_id": 1, "ids": []int64{2, 3}})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/duplicates/merge", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.mockTransactionUC.On("MergeDuplicates", req.Context(), int64(1), []int64{2, 3}, user).Return(nil).Once()
+
+	s.transactionHlr.MergeDuplicates(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func mergeDuplicates_IDsContainKeepID_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"keep_id": 1, "ids": []int64{1, 2}})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/duplicates/merge", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.MergeDuplicates(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func mergeDuplicates_TransNotFound_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"keep_id": 1, "ids": []int64{2}})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/duplicates/merge", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.mockTransactionUC.On("MergeDuplicates", req.Context(), int64(1), []int64{2}, user).Return(domain.ErrTransactionDataNotFound).Once()
+
+	s.transactionHlr.MergeDuplicates(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
 func (s *TransactionSuite) TestDelete() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
 		"when no error, delete successfully":         delete_NoError_DeleteSuccessfully,
@@ -61,93 +284,880 @@ func delete_NoError_DeleteSuccessfully(s *TransactionSuite, desc string) {
 		ID: 1,
 	}
 
-	// prepare request, and response recorder
-	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Delete))
-	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/transaction/id", nil)
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/transaction/id", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("Delete", req.Context(), int64(1), user).Return(nil)
+
+	s.transactionHlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func delete_IncorrectID_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{
+		ID: 1,
+	}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/transaction/id", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "a"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func delete_IDLessThanZero_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{
+		ID: 1,
+	}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/transaction/id", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "-1"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func delete_DataNotFound_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{
+		ID: 1,
+	}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Delete))
+	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/transaction/id", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("Delete", req.Context(), int64(1), user).Return(domain.ErrTransactionDataNotFound)
+
+	s.transactionHlr.Delete(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *TransactionSuite) TestGetHistory() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, return history":           getHistory_NoError_ReturnHistory,
+		"when data not found, return bad request": getHistory_DataNotFound_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getHistory_NoError_ReturnHistory(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := []domain.TransRevision{
+		{
+			ID:        1,
+			Action:    domain.RevisionActionTypeCreate,
+			After:     &domain.TransSnapshot{Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 90, Currency: "USD", Date: date},
+			CreatedAt: date,
+		},
+		{
+			ID:        2,
+			Action:    domain.RevisionActionTypeDelete,
+			Before:    &domain.TransSnapshot{Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 90, Currency: "USD", Date: date, TagIDs: []int64{3}},
+			CreatedAt: date,
+		},
+	}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction/1/history", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("GetHistory", req.Context(), int64(1), user).Return(history, nil)
+
+	s.transactionHlr.GetHistory(res, req)
+
+	snapshot := map[string]interface{}{
+		"type":             "expense",
+		"main_category_id": float64(1),
+		"sub_category_id":  float64(2),
+		"price":            float64(90),
+		"currency":         "USD",
+		"date":             "2024-01-01T00:00:00Z",
+		"note":             "",
+		"account_id":       float64(0),
+		"to_account_id":    float64(0),
+	}
+	deleted := map[string]interface{}{"tag_ids": []interface{}{float64(3)}}
+	for k, v := range snapshot {
+		deleted[k] = v
+	}
+	expResp := map[string]interface{}{
+		"history": []interface{}{
+			map[string]interface{}{"id": float64(1), "action": "create", "before": nil, "after": snapshot, "created_at": "2024-01-01T00:00:00Z"},
+			map[string]interface{}{"id": float64(2), "action": "delete", "before": deleted, "after": nil, "created_at": "2024-01-01T00:00:00Z"},
+		},
+	}
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+}
+
+func getHistory_DataNotFound_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction/1/history", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("GetHistory", req.Context(), int64(1), user).Return(nil, domain.ErrTransactionDataNotFound)
+
+	s.transactionHlr.GetHistory(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *TransactionSuite) TestGetAttachmentPutURL() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, return url":                       getAttachmentPutURL_NoError_ReturnURL,
+		"when file is not image or pdf, return bad req":   getAttachmentPutURL_InvalidExt_ReturnBadReq,
+		"when file name contains slash, return bad req":   getAttachmentPutURL_FileNameWithSlash_ReturnBadReq,
+		"when transaction not found, return bad request":  getAttachmentPutURL_TransNotFound_ReturnBadReq,
+		"when get url fail, return internal server error": getAttachmentPutURL_GetURLFail_ReturnServerError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getAttachmentPutURL_NoError_ReturnURL(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"receipt.PNG"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment/upload-url", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("GetAttachmentPutURL", req.Context(), int64(1), "receipt.PNG", user).Return(domain.AttachmentPutURL{URL: "https://example.com/put", ObjectKey: "receipts/1/1/0a1b2c/receipt.PNG"}, nil)
+
+	s.transactionHlr.GetAttachmentPutURL(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal(map[string]interface{}{"url": "https://example.com/put", "object_key": "receipts/1/1/0a1b2c/receipt.PNG"}, responseBody, desc)
+}
+
+func getAttachmentPutURL_InvalidExt_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"receipt.exe"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment/upload-url", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.GetAttachmentPutURL(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Contains(res.Body.String(), "File must be jpg, jpeg, png, heic, webp or pdf", desc)
+}
+
+func getAttachmentPutURL_FileNameWithSlash_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"../2/receipt.png"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment/upload-url", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.GetAttachmentPutURL(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Contains(res.Body.String(), "File name can't contain slash", desc)
+}
+
+func getAttachmentPutURL_TransNotFound_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"receipt.pdf"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment/upload-url", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("GetAttachmentPutURL", req.Context(), int64(1), "receipt.pdf", user).Return(domain.AttachmentPutURL{}, domain.ErrTransactionDataNotFound)
+
+	s.transactionHlr.GetAttachmentPutURL(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func getAttachmentPutURL_GetURLFail_ReturnServerError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"receipt.pdf"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment/upload-url", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("GetAttachmentPutURL", req.Context(), int64(1), "receipt.pdf", user).Return(domain.AttachmentPutURL{}, errors.New("error"))
+
+	s.transactionHlr.GetAttachmentPutURL(res, req)
+
+	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
+}
+
+func (s *TransactionSuite) TestCreateAttachment() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, create successfully":             createAttachment_NoError_CreateSuccessfully,
+		"when file name is empty, return bad request":    createAttachment_EmptyFileName_ReturnBadReq,
+		"when file name is used, return bad request":     createAttachment_FileNameUsed_ReturnBadReq,
+		"when object key is empty, return bad request":   createAttachment_EmptyObjectKey_ReturnBadReq,
+		"when object key is invalid, return bad request": createAttachment_InvalidObjectKey_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func createAttachment_NoError_CreateSuccessfully(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"receipt.pdf","object_key":"receipts/1/1/0a1b2c/receipt.pdf"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("CreateAttachment", req.Context(), int64(1), "receipt.pdf", "receipts/1/1/0a1b2c/receipt.pdf", user).Return(nil)
+
+	s.transactionHlr.CreateAttachment(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func createAttachment_EmptyFileName_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"","object_key":"receipts/1/1/0a1b2c/receipt.pdf"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.CreateAttachment(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Contains(res.Body.String(), "File name can't be empty", desc)
+}
+
+func createAttachment_FileNameUsed_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"receipt.pdf","object_key":"receipts/1/1/0a1b2c/receipt.pdf"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("CreateAttachment", req.Context(), int64(1), "receipt.pdf", "receipts/1/1/0a1b2c/receipt.pdf", user).Return(domain.ErrUniqueAttachmentFileName)
+
+	s.transactionHlr.CreateAttachment(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func createAttachment_EmptyObjectKey_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"receipt.pdf"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.CreateAttachment(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Contains(res.Body.String(), "Object key can't be empty", desc)
+}
+
+func createAttachment_InvalidObjectKey_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	body := []byte(`{"file_name":"receipt.pdf","object_key":"receipts/1/2/0a1b2c/receipt.pdf"}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/attachment", bytes.NewReader(body))
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("CreateAttachment", req.Context(), int64(1), "receipt.pdf", "receipts/1/2/0a1b2c/receipt.pdf", user).Return(domain.ErrAttachmentObjectKeyInvalid)
+
+	s.transactionHlr.CreateAttachment(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *TransactionSuite) TestDeleteAttachment() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, delete successfully":                  deleteAttachment_NoError_DeleteSuccessfully,
+		"when attachment not found, return bad request":       deleteAttachment_NotFound_ReturnBadReq,
+		"when attachment id is incorrect, return bad request": deleteAttachment_IncorrectAttachmentID_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func deleteAttachment_NoError_DeleteSuccessfully(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodDelete, "/v1/transaction/1/attachment/2", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "attachment_id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("DeleteAttachment", req.Context(), int64(1), int64(2), user).Return(nil)
+
+	s.transactionHlr.DeleteAttachment(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func deleteAttachment_NotFound_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodDelete, "/v1/transaction/1/attachment/2", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "attachment_id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("DeleteAttachment", req.Context(), int64(1), int64(2), user).Return(domain.ErrAttachmentNotFound)
+
+	s.transactionHlr.DeleteAttachment(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func deleteAttachment_IncorrectAttachmentID_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodDelete, "/v1/transaction/1/attachment/a", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "attachment_id": "a"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.DeleteAttachment(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *TransactionSuite) TestRevert() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, revert successfully":              revert_NoError_RevertSuccessfully,
+		"when revision id is incorrect, return bad req":   revert_IncorrectRevisionID_ReturnBadReq,
+		"when revision not revertible, return bad req":    revert_NotRevertible_ReturnBadReq,
+		"when revert fail, return internal server error":  revert_RevertFail_ReturnServerError,
+		"when revision id is less than 0, return bad req": revert_RevisionIDLessThanZero_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func revert_NoError_RevertSuccessfully(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/history/2/revert", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "revision_id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("Revert", req.Context(), int64(1), int64(2), user).Return(nil)
+
+	s.transactionHlr.Revert(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func revert_IncorrectRevisionID_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/history/a/revert", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "revision_id": "a"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Revert(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func revert_RevisionIDLessThanZero_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/history/-1/revert", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "revision_id": "-1"})
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Revert(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func revert_NotRevertible_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/history/2/revert", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "revision_id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("Revert", req.Context(), int64(1), int64(2), user).Return(domain.ErrTransRevisionNotRevertible)
+
+	s.transactionHlr.Revert(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func revert_RevertFail_ReturnServerError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/1/history/2/revert", nil)
+	res := httptest.NewRecorder()
+
+	// set context value on request
+	req = mux.SetURLVars(req, map[string]string{"id": "1", "revision_id": "2"})
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("Revert", req.Context(), int64(1), int64(2), user).Return(errors.New("error"))
+
+	s.transactionHlr.Revert(res, req)
+
+	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
+}
+
+func (s *TransactionSuite) TestGetAll() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when query is valid, pass parsed query":       getAll_ValidQuery_PassParsedQuery,
+		"when query has unclosed quote, return 400":    getAll_UnclosedQuote_ReturnBadReq,
+		"when query has unknown field, return 400":     getAll_UnknownField_ReturnBadReq,
+		"when query has invalid date, return 400":      getAll_InvalidDate_ReturnBadReq,
+		"when query range start after end, return 400": getAll_RangeStartAfterEnd_ReturnBadReq,
+		"when with total, return total":                getAll_WithTotal_ReturnTotal,
+		"when with next and previous key, return 400":  getAll_WithNextAndPrevKey_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getAll_ValidQuery_PassParsedQuery(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	q := `note:"coffee beans" category:food price>20 date:2024-01..2024-03 -tag:work type:expense latte`
+	opt := domain.GetTransOpt{
+		Search: domain.Search{
+			Query: &domain.SearchQuery{
+				Raw:    q,
+				Terms:  []domain.SearchTerm{{Value: "coffee beans"}, {Value: "latte"}},
+				Categs: []domain.SearchTerm{{Value: "food"}},
+				Tags:   []domain.SearchTerm{{Value: "work", Negated: true}},
+				Types:  []domain.SearchTypeCond{{Type: domain.TransactionTypeExpense}},
+				Prices: []domain.SearchPriceCond{{Op: domain.CmpOpTypeGt, Value: 20}},
+				Dates: []domain.SearchDateCond{
+					{Op: domain.CmpOpTypeGte, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Op: domain.CmpOpTypeLt, Value: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction?q="+url.QueryEscape(q), nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.mockTransactionUC.On("GetAll", req.Context(), opt, user).
+		Return([]domain.Transaction{}, domain.Cursor{}, nil).Once()
+
+	s.transactionHlr.GetAll(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func getAll_UnclosedQuote_ReturnBadReq(s *TransactionSuite, desc string) {
+	assertGetAllQueryErr(s, desc, `note:"coffee`, "query has an unclosed quote")
+}
+
+func getAll_UnknownField_ReturnBadReq(s *TransactionSuite, desc string) {
+	assertGetAllQueryErr(s, desc, "amount>20", `unknown field "amount" in "amount>20", use note, category, tag, type, price or date`)
+}
+
+func getAll_InvalidDate_ReturnBadReq(s *TransactionSuite, desc string) {
+	assertGetAllQueryErr(s, desc, "date:2024-13", `"date:2024-13" must have a date in YYYY, YYYY-MM or YYYY-MM-DD format`)
+}
+
+func getAll_RangeStartAfterEnd_ReturnBadReq(s *TransactionSuite, desc string) {
+	assertGetAllQueryErr(s, desc, "price:50..20", `"price:50..20" must have the start of the range less than or equal to the end`)
+}
+
+func getAll_WithTotal_ReturnTotal(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	opt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 1, PrevKey: "SUQ6NQ=="}}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction?size=1&prev_key=SUQ6NQ%3D%3D&with_total=true", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	cursor := domain.Cursor{NextKey: "SUQ6NA==", PrevKey: "SUQ6NA==", Size: 1}
+	s.mockTransactionUC.On("GetAll", req.Context(), opt, user).
+		Return([]domain.Transaction{}, cursor, nil).Once()
+	s.mockTransactionUC.On("GetTotal", req.Context(), opt, user).
+		Return(domain.TransTotal{Count: 12, Income: 100, Expense: 40.5}, nil).Once()
+
+	s.transactionHlr.GetAll(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"next_key": "SUQ6NA==", "prev_key": "SUQ6NA==", "size": float64(1)}, responseBody["cursor"], desc)
+	s.Require().Equal(map[string]interface{}{"count": float64(12), "income": float64(100), "expense": 40.5, "unconverted": float64(0)}, responseBody["total"], desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func getAll_WithNextAndPrevKey_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction?size=1&next_key=SUQ6NQ%3D%3D&prev_key=SUQ6NQ%3D%3D", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.GetAll(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"cursor": "Next key and previous key can't be used together"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func assertGetAllQueryErr(s *TransactionSuite, desc, q, expErr string) {
+	user := domain.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction?q="+url.QueryEscape(q), nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.GetAll(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"q": expErr}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *TransactionSuite) TestExport() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when format is csv, return csv file":           export_CSV_ReturnCSVFile,
+		"when format is json, return json file":         export_JSON_ReturnJSONFile,
+		"when format is ofx, return ofx file":           export_OFX_ReturnOFXFile,
+		"when no transaction, return only header":       export_NoTransaction_ReturnOnlyHeader,
+		"when format is invalid, return bad request":    export_InvalidFormat_ReturnBadReq,
+		"when export fail before first row, return 500": export_FailBeforeFirstRow_ReturnServerError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+var mockExportTrans = []domain.Transaction{
+	{
+		ID:        1,
+		Type:      domain.TransactionTypeExpense,
+		MainCateg: domain.MainCateg{ID: 1, Name: "food"},
+		SubCateg:  domain.SubCateg{ID: 2, Name: "lunch"},
+		Price:     100.5,
+		Currency:  "EUR",
+		Date:      time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		Note:      "noodle, large",
+	},
+	{
+		ID:        2,
+		Type:      domain.TransactionTypeIncome,
+		MainCateg: domain.MainCateg{ID: 3, Name: "salary"},
+		SubCateg:  domain.SubCateg{ID: 4, Name: "bonus"},
+		Price:     1000,
+		Currency:  "USD",
+		Date:      time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+		Note:      "Q&A",
+	},
+}
+
+func streamTrans(trans []domain.Transaction) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(3).(func(domain.Transaction) error)
+		for _, t := range trans {
+			if err := fn(t); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func export_CSV_ReturnCSVFile(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	opt := domain.GetTransOpt{Filter: domain.Filter{StartDate: &startDate}}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction/export?format=csv&start_date=2024-01-01", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.mockTransactionUC.On("Export", req.Context(), opt, user, mock.Anything).
+		Run(streamTrans(mockExportTrans)).Return(nil).Once()
+
+	s.transactionHlr.Export(res, req)
+
+	expResp := "id,date,type,main_category,sub_category,price,currency,note\n" +
+		"1,2024-01-10,expense,food,lunch,100.5,EUR,\"noodle, large\"\n" +
+		"2,2024-01-11,income,salary,bonus,1000,USD,Q&A\n"
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal("text/csv", res.Header().Get("Content-Type"), desc)
+	s.Require().Contains(res.Header().Get("Content-Disposition"), ".csv", desc)
+	s.Require().Equal(expResp, res.Body.String(), desc)
+}
+
+func export_JSON_ReturnJSONFile(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction/export?format=json", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.mockTransactionUC.On("Export", req.Context(), domain.GetTransOpt{}, user, mock.Anything).
+		Run(streamTrans(mockExportTrans)).Return(nil).Once()
+
+	s.transactionHlr.Export(res, req)
+
+	var responseBody map[string][]map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal("application/json", res.Header().Get("Content-Type"), desc)
+	s.Require().Len(responseBody["transactions"], 2, desc)
+	s.Require().Equal(map[string]interface{}{
+		"id":               float64(1),
+		"date":             "2024-01-10",
+		"type":             "expense",
+		"main_category_id": float64(1),
+		"main_category":    "food",
+		"sub_category_id":  float64(2),
+		"sub_category":     "lunch",
+		"price":            100.5,
+		"currency":         "EUR",
+		"note":             "noodle, large",
+	}, responseBody["transactions"][0], desc)
+}
+
+func export_OFX_ReturnOFXFile(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction/export?format=ofx", nil)
 	res := httptest.NewRecorder()
-	defer srv.Close()
-	defer req.Body.Close()
-	defer res.Result().Body.Close()
-
-	// set context value on request
-	req = mux.SetURLVars(req, map[string]string{"id": "1"})
 	req = ctxutil.SetUser(req, &user)
 
-	// mock service
-	s.mockTransactionUC.On("Delete", req.Context(), int64(1), user).Return(nil)
+	s.mockTransactionUC.On("Export", req.Context(), domain.GetTransOpt{}, user, mock.Anything).
+		Run(streamTrans(mockExportTrans)).Return(nil).Once()
 
-	s.transactionHlr.Delete(res, req)
+	s.transactionHlr.Export(res, req)
 
+	body := res.Body.String()
 	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal("application/x-ofx", res.Header().Get("Content-Type"), desc)
+	s.Require().Contains(body, "<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240110</DTPOSTED><TRNAMT>-100.50</TRNAMT><FITID>1</FITID><NAME>food/lunch</NAME><MEMO>noodle, large</MEMO></STMTTRN>", desc)
+	s.Require().Contains(body, "<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20240111</DTPOSTED><TRNAMT>1000.00</TRNAMT><FITID>2</FITID><NAME>salary/bonus</NAME><MEMO>Q&amp;A</MEMO></STMTTRN>", desc)
+	s.Require().True(strings.HasSuffix(body, "</OFX>\n"), desc)
 }
 
-func delete_IncorrectID_ReturnBadReq(s *TransactionSuite, desc string) {
-	user := domain.User{
-		ID: 1,
-	}
+func export_NoTransaction_ReturnOnlyHeader(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
 
-	// prepare request, and response recorder
-	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Delete))
-	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/transaction/id", nil)
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction/export", nil)
 	res := httptest.NewRecorder()
-	defer srv.Close()
-	defer req.Body.Close()
-	defer res.Result().Body.Close()
-
-	// set context value on request
-	req = mux.SetURLVars(req, map[string]string{"id": "a"})
 	req = ctxutil.SetUser(req, &user)
 
-	s.transactionHlr.Delete(res, req)
+	s.mockTransactionUC.On("Export", req.Context(), domain.GetTransOpt{}, user, mock.Anything).Return(nil).Once()
 
-	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.transactionHlr.Export(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal("id,date,type,main_category,sub_category,price,currency,note\n", res.Body.String(), desc)
 }
 
-func delete_IDLessThanZero_ReturnBadReq(s *TransactionSuite, desc string) {
-	user := domain.User{
-		ID: 1,
-	}
+func export_InvalidFormat_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
 
-	// prepare request, and response recorder
-	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Delete))
-	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/transaction/id", nil)
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction/export?format=xml", nil)
 	res := httptest.NewRecorder()
-	defer srv.Close()
-	defer req.Body.Close()
-	defer res.Result().Body.Close()
-
-	// set context value on request
-	req = mux.SetURLVars(req, map[string]string{"id": "-1"})
 	req = ctxutil.SetUser(req, &user)
 
-	s.transactionHlr.Delete(res, req)
+	s.transactionHlr.Export(res, req)
 
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"format": "Format must be csv, json or ofx"}, responseBody, desc)
 	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
 }
 
-func delete_DataNotFound_ReturnBadReq(s *TransactionSuite, desc string) {
-	user := domain.User{
-		ID: 1,
-	}
+func export_FailBeforeFirstRow_ReturnServerError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
 
-	// prepare request, and response recorder
-	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.Delete))
-	req := httptest.NewRequest(http.MethodDelete, srv.URL+"/v1/transaction/id", nil)
+	req := httptest.NewRequest(http.MethodGet, "/v1/transaction/export?format=json", nil)
 	res := httptest.NewRecorder()
-	defer srv.Close()
-	defer req.Body.Close()
-	defer res.Result().Body.Close()
-
-	// set context value on request
-	req = mux.SetURLVars(req, map[string]string{"id": "1"})
 	req = ctxutil.SetUser(req, &user)
 
-	// mock service
-	s.mockTransactionUC.On("Delete", req.Context(), int64(1), user).Return(domain.ErrTransactionDataNotFound)
+	s.mockTransactionUC.On("Export", req.Context(), domain.GetTransOpt{}, user, mock.Anything).
+		Return(errors.New("export fail")).Once()
 
-	s.transactionHlr.Delete(res, req)
+	s.transactionHlr.Export(res, req)
 
-	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
 }
 
 func (s *TransactionSuite) TestGetBarChartData() {
@@ -202,6 +1212,7 @@ func getBarChartData_NoError_ReturnData(s *TransactionSuite, desc string) {
 		domain.TimeRangeTypeOneWeekDay,
 		domain.TransactionTypeExpense,
 		mainCategIDs,
+		int64(0),
 		user,
 	).Return(domain.ChartData{
 		Labels:   []string{"Mon", "Tue", "Wed"},
@@ -211,8 +1222,9 @@ func getBarChartData_NoError_ReturnData(s *TransactionSuite, desc string) {
 	// expected expected response
 	expResp := map[string]interface{}{
 		"chart_data": map[string]interface{}{
-			"labels":   []interface{}{"Mon", "Tue", "Wed"},
-			"datasets": []interface{}{100.0, 200.0, 300.0},
+			"labels":      []interface{}{"Mon", "Tue", "Wed"},
+			"datasets":    []interface{}{100.0, 200.0, 300.0},
+			"unconverted": float64(0),
 		},
 	}
 
@@ -258,6 +1270,7 @@ func getBarChartData_NoMainCategoryIDs_PassNilToService(s *TransactionSuite, des
 		domain.TimeRangeTypeOneWeekDay,
 		domain.TransactionTypeExpense,
 		[]int64(nil),
+		int64(0),
 		user,
 	).Return(domain.ChartData{
 		Labels:   []string{"Mon", "Tue", "Wed"},
@@ -267,8 +1280,9 @@ func getBarChartData_NoMainCategoryIDs_PassNilToService(s *TransactionSuite, des
 	// expected expected response
 	expResp := map[string]interface{}{
 		"chart_data": map[string]interface{}{
-			"labels":   []interface{}{"Mon", "Tue", "Wed"},
-			"datasets": []interface{}{100.0, 200.0, 300.0},
+			"labels":      []interface{}{"Mon", "Tue", "Wed"},
+			"datasets":    []interface{}{100.0, 200.0, 300.0},
+			"unconverted": float64(0),
 		},
 	}
 
@@ -476,6 +1490,7 @@ func getPieChartData_NoError_ReturnData(s *TransactionSuite, desc string) {
 			End:   end,
 		},
 		domain.TransactionTypeExpense,
+		int64(0),
 		user,
 	).Return(domain.ChartData{
 		Labels:   []string{"2024-03-01", "2024-03-02", "2024-03-03"},
@@ -485,8 +1500,9 @@ func getPieChartData_NoError_ReturnData(s *TransactionSuite, desc string) {
 	// expected expected response
 	expResp := map[string]interface{}{
 		"chart_data": map[string]interface{}{
-			"labels":   []interface{}{"2024-03-01", "2024-03-02", "2024-03-03"},
-			"datasets": []interface{}{100.0, 200.0, 300.0},
+			"labels":      []interface{}{"2024-03-01", "2024-03-02", "2024-03-03"},
+			"datasets":    []interface{}{100.0, 200.0, 300.0},
+			"unconverted": float64(0),
 		},
 	}
 
@@ -620,6 +1636,103 @@ func getPieChartData_NoType_ReturnBadReq(s *TransactionSuite, desc string) {
 	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
 }
 
+func (s *TransactionSuite) TestGetTagChartData() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, return data":       getTagChartData_NoError_ReturnData,
+		"when no type, return bad request": getTagChartData_NoType_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getTagChartData_NoError_ReturnData(s *TransactionSuite, desc string) {
+	start, err := time.Parse(time.DateOnly, "2024-03-01")
+	s.Require().NoError(err, desc)
+	end, err := time.Parse(time.DateOnly, "2024-03-08")
+	s.Require().NoError(err, desc)
+	user := domain.User{
+		ID: 1,
+	}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.GetTagChartData))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/transaction/tag-chart?start_date=2024-03-01&end_date=2024-03-08&type=expense", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	// set context value on request
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("GetTagChartData",
+		req.Context(),
+		domain.ChartDateRange{
+			Start: start,
+			End:   end,
+		},
+		domain.TransactionTypeExpense,
+		int64(0),
+		user,
+	).Return(domain.ChartData{
+		Labels:   []string{"trip-japan", "reimbursable"},
+		Datasets: []float64{100, 200},
+	}, nil)
+
+	// expected expected response
+	expResp := map[string]interface{}{
+		"chart_data": map[string]interface{}{
+			"labels":      []interface{}{"trip-japan", "reimbursable"},
+			"datasets":    []interface{}{100.0, 200.0},
+			"unconverted": float64(0),
+		},
+	}
+
+	// action
+	s.transactionHlr.GetTagChartData(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func getTagChartData_NoType_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{
+		ID: 1,
+	}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.transactionHlr.GetTagChartData))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/transaction/tag-chart?start_date=2024-03-01&end_date=2024-03-08", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	// set context value on request
+	req = ctxutil.SetUser(req, &user)
+
+	// action
+	s.transactionHlr.GetTagChartData(res, req)
+
+	expResp := map[string]interface{}{
+		"type": "transaction type must be income or expense",
+	}
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
 func (s *TransactionSuite) TestGetLineChartData() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
 		"when no error, return data":                         getLineChartData_NoError_ReturnData,
@@ -666,6 +1779,7 @@ func getLineChartData_NoError_ReturnData(s *TransactionSuite, desc string) {
 		req.Context(),
 		dateRange,
 		domain.TimeRangeTypeSixMonths,
+		int64(0),
 		user,
 	).Return(domain.ChartData{
 		Labels:   []string{"2024-03-01", "2024-03-02", "2024-03-03"},
@@ -675,8 +1789,9 @@ func getLineChartData_NoError_ReturnData(s *TransactionSuite, desc string) {
 	// expected expected response
 	expResp := map[string]interface{}{
 		"chart_data": map[string]interface{}{
-			"labels":   []interface{}{"2024-03-01", "2024-03-02", "2024-03-03"},
-			"datasets": []interface{}{100.0, 200.0, 300.0},
+			"labels":      []interface{}{"2024-03-01", "2024-03-02", "2024-03-03"},
+			"datasets":    []interface{}{100.0, 200.0, 300.0},
+			"unconverted": float64(0),
 		},
 	}
 
@@ -1017,3 +2132,230 @@ func getMonthlyData_GetMonthylDataFail_ReturnInternalServerError(s *TransactionS
 	s.Require().Equal(expResult, responseBody, desc)
 	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
 }
+
+func (s *TransactionSuite) TestBulk() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, return result":                            bulk_NoError_ReturnResult,
+		"when delete by filter, pass filter to usecase":           bulk_DeleteByFilter_PassFilter,
+		"when delete by ids and filter, return bad req":           bulk_DeleteByIDsAndFilter_ReturnBadReq,
+		"when filter has no condition, return bad req":            bulk_EmptyFilter_ReturnBadReq,
+		"when created transaction is invalid, return bad req":     bulk_InvalidCreate_ReturnBadReq,
+		"when update and delete same transaction, return bad req": bulk_UpdateAndDeleteSameTrans_ReturnBadReq,
+		"when update category of split, return bad req":           bulk_UpdateSplitCateg_ReturnBadReq,
+		"when bulk fail, return internal server error":            bulk_BulkFail_ReturnServerError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func bulk_NoError_ReturnResult(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	body, err := json.Marshal(map[string]interface{}{
+		"create": []map[string]interface{}{
+			{"type": "expense", "main_category_id": 1, "sub_category_id": 2, "price": 10, "currency": "usd", "date": date},
+		},
+		"update": []map[string]interface{}{
+			{"id": 3, "note": "lunch"},
+		},
+		"delete": map[string]interface{}{
+			"ids": []int64{4, 5},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/bulk", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	note := "lunch"
+	input := domain.BulkTransInput{
+		Create: []domain.CreateTransactionInput{
+			{UserID: 1, Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 10, Currency: "USD", Date: date},
+		},
+		Update: []domain.BulkUpdateTransInput{{ID: 3, Note: &note}},
+		Delete: domain.BulkDeleteTransInput{IDs: []int64{4, 5}},
+	}
+	result := domain.BulkTransResult{CreatedIDs: []int64{6}, Updated: 1, Deleted: 2}
+	s.mockTransactionUC.On("Bulk", req.Context(), input, user).Return(result, nil).Once()
+
+	s.transactionHlr.Bulk(res, req)
+
+	var resp map[string]interface{}
+	s.Require().NoError(json.Unmarshal(res.Body.Bytes(), &resp), desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+	s.Require().Equal([]interface{}{float64(6)}, resp["created_ids"], desc)
+	s.Require().Equal(float64(1), resp["updated"], desc)
+	s.Require().Equal(float64(2), resp["deleted"], desc)
+}
+
+func bulk_DeleteByFilter_PassFilter(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"delete": map[string]interface{}{
+			"filter": map[string]interface{}{
+				"start_date":        "2024-03-01",
+				"main_category_ids": []int64{1},
+			},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/bulk", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	input := domain.BulkTransInput{
+		Delete: domain.BulkDeleteTransInput{
+			Opt: &domain.GetTransOpt{
+				Filter: domain.Filter{
+					StartDate:    &startDate,
+					MainCategIDs: []int64{1},
+					TagMatch:     domain.CvtToTagMatchType(""),
+				},
+			},
+		},
+	}
+	s.mockTransactionUC.On("Bulk", req.Context(), input, user).Return(domain.BulkTransResult{Deleted: 3}, nil).Once()
+
+	s.transactionHlr.Bulk(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func bulk_DeleteByIDsAndFilter_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"delete": map[string]interface{}{
+			"ids":    []int64{1},
+			"filter": map[string]interface{}{"keyword": "coffee"},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/bulk", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Bulk(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func bulk_EmptyFilter_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"delete": map[string]interface{}{
+			"filter": map[string]interface{}{},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/bulk", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Bulk(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Contains(res.Body.String(), "delete.filter", desc)
+}
+
+func bulk_InvalidCreate_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"create": []map[string]interface{}{
+			{"type": "expense", "main_category_id": 1, "sub_category_id": 2, "price": 0, "date": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/bulk", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Bulk(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+	s.Require().Contains(res.Body.String(), "create[0].price", desc)
+}
+
+func bulk_UpdateAndDeleteSameTrans_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"update": []map[string]interface{}{
+			{"id": 3, "note": "lunch"},
+		},
+		"delete": map[string]interface{}{
+			"ids": []int64{3},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/bulk", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.transactionHlr.Bulk(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func bulk_UpdateSplitCateg_ReturnBadReq(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"update": []map[string]interface{}{
+			{"id": 3, "main_category_id": 1, "sub_category_id": 2},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/bulk", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("Bulk", req.Context(), mock.Anything, user).
+		Return(domain.BulkTransResult{}, domain.ErrBulkUpdateSplitCateg).Once()
+
+	s.transactionHlr.Bulk(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func bulk_BulkFail_ReturnServerError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"delete": map[string]interface{}{
+			"ids": []int64{3},
+		},
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/transaction/bulk", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTransactionUC.On("Bulk", req.Context(), mock.Anything, user).
+		Return(domain.BulkTransResult{}, errors.New("error")).Once()
+
+	s.transactionHlr.Bulk(res, req)
+
+	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
+}
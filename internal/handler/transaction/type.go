@@ -3,21 +3,70 @@ package transaction
 import "time"
 
 type createTransactionReq struct {
-	Type        string    `json:"type"`
-	MainCategID int64     `json:"main_category_id"`
-	SubCategID  int64     `json:"sub_category_id"`
-	Price       float64   `json:"price"`
-	Date        time.Time `json:"date"`
-	Note        string    `json:"note"`
+	Type        string     `json:"type"`
+	MainCategID int64      `json:"main_category_id"`
+	SubCategID  int64      `json:"sub_category_id"`
+	Price       float64    `json:"price"`
+	Currency    string     `json:"currency"`
+	Date        time.Time  `json:"date"`
+	Note        string     `json:"note"`
+	AccountID   int64      `json:"account_id"`
+	ToAccountID int64      `json:"to_account_id"`
+	Splits      []splitReq `json:"splits"`
+	TagIDs      []int64    `json:"tag_ids"`
+}
+
+type splitReq struct {
+	MainCategID int64   `json:"main_category_id"`
+	SubCategID  int64   `json:"sub_category_id"`
+	Price       float64 `json:"price"`
 }
 
 type updateTransactionReq struct {
-	Type        string    `json:"type"`
-	MainCategID int64     `json:"main_category_id"`
-	SubCategID  int64     `json:"sub_category_id"`
-	Price       float64   `json:"price"`
-	Date        time.Time `json:"date"`
-	Note        string    `json:"note"`
+	Type        string     `json:"type"`
+	MainCategID int64      `json:"main_category_id"`
+	SubCategID  int64      `json:"sub_category_id"`
+	Price       float64    `json:"price"`
+	Currency    string     `json:"currency"`
+	Date        time.Time  `json:"date"`
+	Note        string     `json:"note"`
+	AccountID   int64      `json:"account_id"`
+	ToAccountID int64      `json:"to_account_id"`
+	Splits      []splitReq `json:"splits"`
+	TagIDs      []int64    `json:"tag_ids"`
+}
+
+type bulkTransactionReq struct {
+	Create []createTransactionReq `json:"create"`
+	Update []bulkUpdateReq        `json:"update"`
+	Delete *bulkDeleteReq         `json:"delete"`
+}
+
+type bulkUpdateReq struct {
+	ID          int64      `json:"id"`
+	MainCategID *int64     `json:"main_category_id"`
+	SubCategID  *int64     `json:"sub_category_id"`
+	Note        *string    `json:"note"`
+	Date        *time.Time `json:"date"`
+	AddTagIDs   []int64    `json:"add_tag_ids"`
+}
+
+type bulkDeleteReq struct {
+	IDs    []int64        `json:"ids"`
+	Filter *bulkFilterReq `json:"filter"`
+}
+
+// bulkFilterReq is the same as the query of getting transactions
+type bulkFilterReq struct {
+	Keyword      *string  `json:"keyword"`
+	StartDate    *string  `json:"start_date"`
+	EndDate      *string  `json:"end_date"`
+	MinPrice     *float64 `json:"min_price"`
+	MaxPrice     *float64 `json:"max_price"`
+	MainCategIDs []int64  `json:"main_category_ids"`
+	SubCategIDs  []int64  `json:"sub_category_ids"`
+	TagIDs       []int64  `json:"tag_ids"`
+	TagMatch     string   `json:"tag_match"`
 }
 
 type getTransactionResp struct {
@@ -38,11 +87,74 @@ type subCateg struct {
 }
 
 type transaction struct {
+	ID          int64        `json:"id"`
+	Type        string       `json:"type"`
+	MainCateg   mainCateg    `json:"main_category"`
+	SubCateg    subCateg     `json:"sub_category"`
+	Price       float64      `json:"price"`
+	Currency    string       `json:"currency"`
+	Note        string       `json:"note"`
+	Date        time.Time    `json:"date"`
+	AccountID   int64        `json:"account_id"`
+	ToAccountID int64        `json:"to_account_id"`
+	Splits      []split      `json:"splits,omitempty"`
+	Tags        []tag        `json:"tags,omitempty"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+type split struct {
+	MainCateg subCateg `json:"main_category"`
+	SubCateg  subCateg `json:"sub_category"`
+	Price     float64  `json:"price"`
+}
+
+type attachment struct {
+	ID       int64  `json:"id"`
+	FileName string `json:"file_name"`
+	URL      string `json:"url"`
+}
+
+type mergeDuplicatesReq struct {
+	KeepID int64   `json:"keep_id"`
+	IDs    []int64 `json:"ids"`
+}
+
+type dismissDuplicatesReq struct {
+	IDs []int64 `json:"ids"`
+}
+
+type duplicateGroup struct {
+	Transactions []transaction `json:"transactions"`
+}
+
+type attachmentReq struct {
+	FileName  string `json:"file_name"`
+	ObjectKey string `json:"object_key"`
+}
+
+type tag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type revision struct {
 	ID        int64     `json:"id"`
-	Type      string    `json:"type"`
-	MainCateg mainCateg `json:"main_category"`
-	SubCateg  subCateg  `json:"sub_category"`
-	Price     float64   `json:"price"`
-	Note      string    `json:"note"`
-	Date      time.Time `json:"date"`
+	Action    string    `json:"action"`
+	Before    *snapshot `json:"before"`
+	After     *snapshot `json:"after"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type snapshot struct {
+	Type        string     `json:"type"`
+	MainCategID int64      `json:"main_category_id"`
+	SubCategID  int64      `json:"sub_category_id"`
+	Price       float64    `json:"price"`
+	Currency    string     `json:"currency"`
+	Date        time.Time  `json:"date"`
+	Note        string     `json:"note"`
+	AccountID   int64      `json:"account_id"`
+	ToAccountID int64      `json:"to_account_id"`
+	Splits      []splitReq `json:"splits,omitempty"`
+	TagIDs      []int64    `json:"tag_ids,omitempty"`
 }
@@ -0,0 +1,29 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+func cvtToTrashItemsResp(items []domain.TrashItem) []trashItem {
+	resp := make([]trashItem, 0, len(items))
+
+	for _, item := range items {
+		var date string
+		if item.Date != nil {
+			date = item.Date.Format(time.DateOnly)
+		}
+
+		resp = append(resp, trashItem{
+			Kind:      item.Kind.ToString(),
+			ID:        item.ID,
+			Name:      item.Name,
+			Price:     item.Price,
+			Date:      date,
+			DeletedAt: item.DeletedAt.Format(time.RFC3339),
+		})
+	}
+
+	return resp
+}
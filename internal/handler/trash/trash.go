@@ -0,0 +1,93 @@
+package trash
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/jsonutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/validator"
+	"github.com/gorilla/mux"
+)
+
+const (
+	packageName = "handler/trash"
+)
+
+type Hlr struct {
+	trash interfaces.TrashUC
+}
+
+func New(t interfaces.TrashUC) *Hlr {
+	return &Hlr{
+		trash: t,
+	}
+}
+
+func (h *Hlr) GetAll(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetLedgerUser(r)
+	items, err := h.trash.GetAll(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	respData := map[string]interface{}{
+		"items": cvtToTrashItemsResp(items),
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, respData, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) Restore(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", packageName, "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	kind := domain.CvtToTrashKind(mux.Vars(r)["kind"])
+
+	v := validator.New()
+	if !v.RestoreTrash(kind, id) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	errs := []error{
+		domain.ErrTrashItemNotFound,
+		domain.ErrTrashParentInTrash,
+		domain.ErrUniqueNameUserType,
+		domain.ErrUniqueNameUserMainCateg,
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.trash.Restore(r.Context(), kind, id, user.ID); err != nil {
+		if slices.Contains(errs, err) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+		if errors.Is(err, domain.ErrLedgerPermissionDenied) {
+			errutil.ForbiddenResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", packageName, "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
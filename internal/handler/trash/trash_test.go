@@ -0,0 +1,206 @@
+package trash_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/trash"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrashSuite struct {
+	suite.Suite
+	hlr         *trash.Hlr
+	mockTrashUC *mocks.TrashUC
+}
+
+func TestTrashSuite(t *testing.T) {
+	suite.Run(t, new(TrashSuite))
+}
+
+func (s *TrashSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *TrashSuite) SetupTest() {
+	s.mockTrashUC = mocks.NewTrashUC(s.T())
+	s.hlr = trash.New(s.mockTrashUC)
+}
+
+func (s *TrashSuite) TearDownTest() {
+	s.mockTrashUC.AssertExpectations(s.T())
+}
+
+func (s *TrashSuite) TestGetAll() {
+	user := domain.User{ID: 1}
+	date := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.GetAll))
+	req := httptest.NewRequest(http.MethodGet, srv.URL+"/v1/trash", nil)
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	items := []domain.TrashItem{
+		{Kind: domain.TrashKindMainCateg, ID: 2, Name: "food", DeletedAt: deletedAt},
+		{Kind: domain.TrashKindTransaction, ID: 3, Name: "lunch", Price: 120, Date: &date, DeletedAt: deletedAt},
+	}
+	s.mockTrashUC.On("GetAll", req.Context(), int64(1)).Return(items, nil).Once()
+
+	s.hlr.GetAll(res, req)
+
+	expResp := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"kind": "main-category", "id": float64(2), "name": "food", "deleted_at": "2024-03-01T10:00:00Z"},
+			map[string]interface{}{"kind": "transaction", "id": float64(3), "name": "lunch", "price": float64(120), "date": "2024-02-29", "deleted_at": "2024-03-01T10:00:00Z"},
+		},
+	}
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, "test get all")
+	s.Require().Equal(expResp, responseBody, "test get all")
+	s.Require().Equal(http.StatusOK, res.Code, "test get all")
+}
+
+func (s *TrashSuite) TestRestore() {
+	for scenario, fn := range map[string]func(s *TrashSuite, desc string){
+		"when no error, restore successfully":           restore_NoError_RestoreSuccessfully,
+		"when kind is invalid, return bad request":      restore_InvalidKind_ReturnBadReq,
+		"when parent is in trash, return bad request":   restore_ParentInTrash_ReturnBadReq,
+		"when item is not in trash, return bad request": restore_ItemNotFound_ReturnBadReq,
+		"when name is used again, return bad request":   restore_NameUsedAgain_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func restore_NoError_RestoreSuccessfully(s *TrashSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Restore))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/trash/main-category/2/restore", nil)
+	req = mux.SetURLVars(req, map[string]string{"kind": "main-category", "id": "2"})
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTrashUC.On("Restore", req.Context(), domain.TrashKindMainCateg, int64(2), int64(1)).Return(nil).Once()
+
+	s.hlr.Restore(res, req)
+
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func restore_InvalidKind_ReturnBadReq(s *TrashSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Restore))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/trash/tag/2/restore", nil)
+	req = mux.SetURLVars(req, map[string]string{"kind": "tag", "id": "2"})
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Restore(res, req)
+
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"kind": "Kind must be transaction, main-category or sub-category"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func restore_ParentInTrash_ReturnBadReq(s *TrashSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Restore))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/trash/transaction/3/restore", nil)
+	req = mux.SetURLVars(req, map[string]string{"kind": "transaction", "id": "3"})
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTrashUC.On("Restore", req.Context(), domain.TrashKindTransaction, int64(3), int64(1)).Return(domain.ErrTrashParentInTrash).Once()
+
+	s.hlr.Restore(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func restore_ItemNotFound_ReturnBadReq(s *TrashSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Restore))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/trash/sub-category/4/restore", nil)
+	req = mux.SetURLVars(req, map[string]string{"kind": "sub-category", "id": "4"})
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTrashUC.On("Restore", req.Context(), domain.TrashKindSubCateg, int64(4), int64(1)).Return(domain.ErrTrashItemNotFound).Once()
+
+	s.hlr.Restore(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func restore_NameUsedAgain_ReturnBadReq(s *TrashSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// prepare request, and response recorder
+	srv := httptest.NewServer(http.HandlerFunc(s.hlr.Restore))
+	req := httptest.NewRequest(http.MethodPost, srv.URL+"/v1/trash/main-category/2/restore", nil)
+	req = mux.SetURLVars(req, map[string]string{"kind": "main-category", "id": "2"})
+	res := httptest.NewRecorder()
+	defer srv.Close()
+	defer req.Body.Close()
+	defer res.Result().Body.Close()
+
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	s.mockTrashUC.On("Restore", req.Context(), domain.TrashKindMainCateg, int64(2), int64(1)).Return(domain.ErrUniqueNameUserType).Once()
+
+	s.hlr.Restore(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
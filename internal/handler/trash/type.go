@@ -0,0 +1,10 @@
+package trash
+
+type trashItem struct {
+	Kind      string  `json:"kind"`
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Price     float64 `json:"price,omitempty"`
+	Date      string  `json:"date,omitempty"`
+	DeletedAt string  `json:"deleted_at"`
+}
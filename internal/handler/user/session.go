@@ -0,0 +1,69 @@
+package user
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+const (
+	// maxDeviceLen is the length of the device column
+	maxDeviceLen = 255
+)
+
+type session struct {
+	ID         int64     `json:"id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	IsCurrent  bool      `json:"is_current"`
+}
+
+// readSession reads the device and the ip of the client starting the session
+func readSession(r *http.Request) domain.Session {
+	device := r.UserAgent()
+	if len(device) > maxDeviceLen {
+		device = device[:maxDeviceLen]
+	}
+
+	return domain.Session{
+		Device: device,
+		IP:     readClientIP(r),
+	}
+}
+
+// readClientIP returns the first address of X-Forwarded-For when the server is behind a proxy, otherwise the remote address
+func readClientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(ip)
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func cvtToSessionsResp(sessions []domain.Session, currentID int64) []session {
+	resp := make([]session, 0, len(sessions))
+
+	for _, s := range sessions {
+		resp = append(resp, session{
+			ID:         s.ID,
+			Device:     s.Device,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			IsCurrent:  s.ID == currentID,
+		})
+	}
+
+	return resp
+}
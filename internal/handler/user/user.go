@@ -3,6 +3,7 @@ package user
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
@@ -44,7 +45,7 @@ func (h *Hlr) Signup(w http.ResponseWriter, r *http.Request) {
 		Email:    input.Email,
 		Password: input.Password,
 	}
-	token, err := h.User.Signup(r.Context(), user)
+	token, err := h.User.Signup(r.Context(), user, readSession(r))
 	if err != nil {
 		if errors.Is(err, domain.ErrEmailAlreadyExists) {
 			errutil.BadRequestResponse(w, r, err)
@@ -88,7 +89,7 @@ func (h *Hlr) Login(w http.ResponseWriter, r *http.Request) {
 		Password: input.Password,
 	}
 
-	token, err := h.User.Login(r.Context(), user)
+	token, err := h.User.Login(r.Context(), user, readSession(r))
 	if err != nil {
 		if errors.Is(err, domain.ErrAuthentication) {
 			errutil.AuthenticationErrorResponse(w, r, err)
@@ -120,6 +121,11 @@ func (h *Hlr) Token(w http.ResponseWriter, r *http.Request) {
 
 	token, err := h.User.Token(r.Context(), refreshToken)
 	if err != nil {
+		if errors.Is(err, domain.ErrAuthToken) {
+			errutil.AuthenticationErrorResponse(w, r, err)
+			return
+		}
+
 		errutil.ServerErrorResponse(w, r, err)
 		return
 	}
@@ -153,6 +159,170 @@ func (h *Hlr) GetInfo(w http.ResponseWriter, r *http.Request) {
 		"name":                 user.Name,
 		"email":                user.Email,
 		"is_set_init_category": user.IsSetInitCategory,
+		"base_currency":        user.BaseCurrency,
+		"is_email_verified":    user.IsEmailVerified,
+	}
+	if err := jsonutil.WriteJSON(w, http.StatusOK, resp, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) UpdateBaseCurrency(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		BaseCurrency string `json:"base_currency"`
+	}
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJson failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	currency := strings.ToUpper(input.BaseCurrency)
+	v := validator.New()
+	if !v.UpdateBaseCurrency(currency) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	user := ctxutil.GetLedgerUser(r)
+	if err := h.User.UpdateBaseCurrency(r.Context(), user.ID, currency); err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Email string `json:"email"`
+	}
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJson failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.RequestPasswordReset(input.Email) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	if err := h.User.RequestPasswordReset(r.Context(), input.Email); err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJson failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.ResetPassword(input.Token, input.Password) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	if err := h.User.ResetPassword(r.Context(), input.Token, input.Password); err != nil {
+		if errors.Is(err, domain.ErrOneTimeToken) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) RequestEmailVerification(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetUser(r)
+	if err := h.User.RequestEmailVerification(r.Context(), user.ID); err != nil {
+		if errors.Is(err, domain.ErrEmailAlreadyVerified) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Token string `json:"token"`
+	}
+	if err := jsonutil.ReadJson(w, r, &input); err != nil {
+		logger.Error("jsonutil.ReadJson failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if !v.VerifyEmail(input.Token) {
+		errutil.VildateErrorResponse(w, r, v.Error)
+		return
+	}
+
+	if err := h.User.VerifyEmail(r.Context(), input.Token); err != nil {
+		if errors.Is(err, domain.ErrOneTimeToken) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+func (h *Hlr) ListSessions(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetUser(r)
+	sessions, err := h.User.ListSessions(r.Context(), user.ID)
+	if err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	resp := map[string]interface{}{
+		"sessions": cvtToSessionsResp(sessions, ctxutil.GetSessionID(r)),
 	}
 	if err := jsonutil.WriteJSON(w, http.StatusOK, resp, nil); err != nil {
 		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
@@ -160,3 +330,44 @@ func (h *Hlr) GetInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (h *Hlr) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	id, err := jsonutil.ReadID(r)
+	if err != nil {
+		logger.Error("jsonutil.ReadID failed", "package", "handler", "err", err)
+		errutil.BadRequestResponse(w, r, err)
+		return
+	}
+
+	user := ctxutil.GetUser(r)
+	if err := h.User.RevokeSession(r.Context(), id, user.ID); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			errutil.BadRequestResponse(w, r, err)
+			return
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
+
+// RevokeAllSessions logs the user out everywhere, including the current session
+func (h *Hlr) RevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	user := ctxutil.GetUser(r)
+	if err := h.User.RevokeAllSessions(r.Context(), user.ID); err != nil {
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := jsonutil.WriteJSON(w, http.StatusOK, nil, nil); err != nil {
+		logger.Error("jsonutil.WriteJSON failed", "package", "handler", "err", err)
+		errutil.ServerErrorResponse(w, r, err)
+		return
+	}
+}
@@ -8,17 +8,22 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/mocks"
 	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
 	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/suite"
 )
 
 var (
 	mockCTX = context.Background()
+
+	// httptest.NewRequest sets the remote address to 192.0.2.1:1234
+	mockSession = domain.Session{IP: "192.0.2.1"}
 )
 
 type UserSuite struct {
@@ -80,7 +85,7 @@ func signup_NoError_CreateSuccessfully(s *UserSuite, desc string) {
 	defer res.Result().Body.Close()
 
 	// prepare service
-	s.mockUserUC.On("Signup", mockCTX, user).Return(domain.Token{
+	s.mockUserUC.On("Signup", mockCTX, user, mockSession).Return(domain.Token{
 		Access:  "access_token",
 		Refresh: "refresh_token",
 	}, nil).Once()
@@ -223,7 +228,7 @@ func signup_EmailExists_CreateSuccessfully(s *UserSuite, desc string) {
 	defer res.Result().Body.Close()
 
 	// prepare service
-	s.mockUserUC.On("Signup", mockCTX, user).Return(domain.Token{}, domain.ErrEmailAlreadyExists).Once()
+	s.mockUserUC.On("Signup", mockCTX, user, mockSession).Return(domain.Token{}, domain.ErrEmailAlreadyExists).Once()
 
 	// prepare expected response
 	expResp := map[string]interface{}{
@@ -260,7 +265,7 @@ func signup_SignupFail_CreateSuccessfully(s *UserSuite, desc string) {
 	defer res.Result().Body.Close()
 
 	// prepare service
-	s.mockUserUC.On("Signup", mockCTX, user).Return(domain.Token{}, errors.New("error")).Once()
+	s.mockUserUC.On("Signup", mockCTX, user, mockSession).Return(domain.Token{}, errors.New("error")).Once()
 
 	// prepare expected response
 	expResp := map[string]interface{}{
@@ -312,7 +317,7 @@ func login_NoError_LoginSuccessfully(s *UserSuite, desc string) {
 	defer res.Result().Body.Close()
 
 	// prepare service
-	s.mockUserUC.On("Login", mockCTX, user).Return(domain.Token{
+	s.mockUserUC.On("Login", mockCTX, user, mockSession).Return(domain.Token{
 		Access:  "access_token",
 		Refresh: "refresh_token",
 	}, nil).Once()
@@ -418,7 +423,7 @@ func login_AuthError_ReturnError(s *UserSuite, desc string) {
 	defer res.Result().Body.Close()
 
 	// prepare service
-	s.mockUserUC.On("Login", mockCTX, user).Return(domain.Token{}, domain.ErrAuthentication).Once()
+	s.mockUserUC.On("Login", mockCTX, user, mockSession).Return(domain.Token{}, domain.ErrAuthentication).Once()
 
 	// prepare expected response
 	expResp := map[string]interface{}{
@@ -454,7 +459,7 @@ func login_LoginFail_ReturnError(s *UserSuite, desc string) {
 	defer res.Result().Body.Close()
 
 	// prepare service
-	s.mockUserUC.On("Login", mockCTX, user).Return(domain.Token{}, errors.New("error")).Once()
+	s.mockUserUC.On("Login", mockCTX, user, mockSession).Return(domain.Token{}, errors.New("error")).Once()
 
 	// prepare expected response
 	expResp := map[string]interface{}{
@@ -603,6 +608,7 @@ func getInfo_NoError_GetInfoSuccessfully(s *UserSuite, desc string) {
 		Name:              "username",
 		Email:             "aaa@gmail.com",
 		IsSetInitCategory: true,
+		BaseCurrency:      "EUR",
 	}
 
 	s.mockUserUC.On("GetInfo", user.ID).Return(user, nil).Once()
@@ -616,6 +622,8 @@ func getInfo_NoError_GetInfoSuccessfully(s *UserSuite, desc string) {
 		"name":                 "username",
 		"email":                "aaa@gmail.com",
 		"is_set_init_category": true,
+		"base_currency":        "EUR",
+		"is_email_verified":    false,
 	}
 
 	// action
@@ -698,3 +706,370 @@ func getInfo_GetInfoFail_ReturnError(s *UserSuite, desc string) {
 	s.Require().Equal(expResp, responseBody, desc)
 	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
 }
+
+func (s *UserSuite) TestUpdateBaseCurrency() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when no error, update successfully":           updateBaseCurrency_NoError_UpdateSuccessfully,
+		"when currency is invalid, return bad request": updateBaseCurrency_InvalidCurrency_ReturnBadRequest,
+		"when update fail, return error":               updateBaseCurrency_UpdateFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func updateBaseCurrency_NoError_UpdateSuccessfully(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	body := []byte(`{"base_currency":"eur"}`)
+	req := httptest.NewRequest(http.MethodPut, "/v1/user/base-currency", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &domain.User{ID: 1})
+
+	// prepare service
+	s.mockUserUC.On("UpdateBaseCurrency", req.Context(), int64(1), "EUR").Return(nil).Once()
+
+	// action
+	s.hlr.UpdateBaseCurrency(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func updateBaseCurrency_InvalidCurrency_ReturnBadRequest(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	body := []byte(`{"base_currency":"euro"}`)
+	req := httptest.NewRequest(http.MethodPut, "/v1/user/base-currency", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &domain.User{ID: 1})
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"base_currency": "Base currency must be a 3-letter currency code",
+	}
+
+	// action
+	s.hlr.UpdateBaseCurrency(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func updateBaseCurrency_UpdateFail_ReturnError(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	body := []byte(`{"base_currency":"EUR"}`)
+	req := httptest.NewRequest(http.MethodPut, "/v1/user/base-currency", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &domain.User{ID: 1})
+
+	// prepare service
+	s.mockUserUC.On("UpdateBaseCurrency", req.Context(), int64(1), "EUR").Return(errors.New("error")).Once()
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"error": "error",
+	}
+
+	// action
+	s.hlr.UpdateBaseCurrency(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusInternalServerError, res.Code, desc)
+}
+
+func (s *UserSuite) TestRequestPasswordReset() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when no error, return ok":               requestPasswordReset_NoError_ReturnOK,
+		"when invalid email, return bad request": requestPasswordReset_InvalidEmail_ReturnBadRequest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func requestPasswordReset_NoError_ReturnOK(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	body, err := json.Marshal(map[string]interface{}{"email": "aaa@gmail.com"})
+	s.Require().NoError(err, desc)
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/password-reset", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+
+	// prepare service
+	s.mockUserUC.On("RequestPasswordReset", req.Context(), "aaa@gmail.com").Return(nil).Once()
+
+	// action
+	s.hlr.RequestPasswordReset(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func requestPasswordReset_InvalidEmail_ReturnBadRequest(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	body, err := json.Marshal(map[string]interface{}{"email": "aaa"})
+	s.Require().NoError(err, desc)
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/password-reset", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+
+	// action
+	s.hlr.RequestPasswordReset(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"email": "Invalid email address"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *UserSuite) TestResetPassword() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when no error, return ok":                  resetPassword_NoError_ReturnOK,
+		"when empty token, return bad request":      resetPassword_EmptyToken_ReturnBadRequest,
+		"when token is invalid, return bad request": resetPassword_InvalidToken_ReturnBadRequest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func resetPassword_NoError_ReturnOK(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	body, err := json.Marshal(map[string]interface{}{"token": "token", "password": "new-password"})
+	s.Require().NoError(err, desc)
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/password-reset/confirm", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+
+	// prepare service
+	s.mockUserUC.On("ResetPassword", req.Context(), "token", "new-password").Return(nil).Once()
+
+	// action
+	s.hlr.ResetPassword(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func resetPassword_EmptyToken_ReturnBadRequest(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	body, err := json.Marshal(map[string]interface{}{"password": "new-password"})
+	s.Require().NoError(err, desc)
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/password-reset/confirm", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+
+	// action
+	s.hlr.ResetPassword(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"token": "Token can't be empty"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func resetPassword_InvalidToken_ReturnBadRequest(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	body, err := json.Marshal(map[string]interface{}{"token": "token", "password": "new-password"})
+	s.Require().NoError(err, desc)
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/password-reset/confirm", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+
+	// prepare service
+	s.mockUserUC.On("ResetPassword", req.Context(), "token", "new-password").Return(domain.ErrOneTimeToken).Once()
+
+	// action
+	s.hlr.ResetPassword(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *UserSuite) TestRequestEmailVerification() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when email verified, return bad request": requestEmailVerification_Verified_ReturnBadRequest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func requestEmailVerification_Verified_ReturnBadRequest(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/verify-email", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &domain.User{ID: 1})
+
+	// prepare service
+	s.mockUserUC.On("RequestEmailVerification", req.Context(), int64(1)).Return(domain.ErrEmailAlreadyVerified).Once()
+
+	// action
+	s.hlr.RequestEmailVerification(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func (s *UserSuite) TestLoginSession() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when behind proxy, read forwarded address": loginSession_BehindProxy_ReadForwardedAddress,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func loginSession_BehindProxy_ReadForwardedAddress(s *UserSuite, desc string) {
+	// prepare data
+	user := domain.User{
+		Email:    "email@gmail.com",
+		Password: "password",
+	}
+	body, err := json.Marshal(user)
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/login", bytes.NewBuffer(body))
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	res := httptest.NewRecorder()
+
+	// prepare service
+	expSession := domain.Session{Device: "Mozilla/5.0", IP: "203.0.113.7"}
+	s.mockUserUC.On("Login", mockCTX, user, expSession).Return(domain.Token{Access: "access_token", Refresh: "refresh_token"}, nil).Once()
+
+	// action
+	s.hlr.Login(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func (s *UserSuite) TestListSessions() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when no error, mark current session": listSessions_NoError_MarkCurrentSession,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func listSessions_NoError_MarkCurrentSession(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodGet, "/v1/user/sessions", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &domain.User{ID: 1})
+	req = ctxutil.SetSessionID(req, 3)
+
+	// prepare service
+	mockTime := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	mockSessions := []domain.Session{
+		{ID: 3, UserID: 1, Device: "Mozilla/5.0", IP: "203.0.113.7", CreatedAt: mockTime, LastUsedAt: mockTime},
+		{ID: 2, UserID: 1, Device: "curl/8.0", IP: "192.0.2.1", CreatedAt: mockTime, LastUsedAt: mockTime},
+	}
+	s.mockUserUC.On("ListSessions", req.Context(), int64(1)).Return(mockSessions, nil).Once()
+
+	// prepare expected response
+	expResp := map[string]interface{}{
+		"sessions": []interface{}{
+			map[string]interface{}{
+				"id":           float64(3),
+				"device":       "Mozilla/5.0",
+				"ip":           "203.0.113.7",
+				"created_at":   "2026-10-18T00:00:00Z",
+				"last_used_at": "2026-10-18T00:00:00Z",
+				"is_current":   true,
+			},
+			map[string]interface{}{
+				"id":           float64(2),
+				"device":       "curl/8.0",
+				"ip":           "192.0.2.1",
+				"created_at":   "2026-10-18T00:00:00Z",
+				"last_used_at": "2026-10-18T00:00:00Z",
+				"is_current":   false,
+			},
+		},
+	}
+
+	// action
+	s.hlr.ListSessions(res, req)
+
+	// assertion
+	var responseBody map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResp, responseBody, desc)
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func (s *UserSuite) TestRevokeSession() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when no error, return successfully":         revokeSession_NoError_ReturnSuccessfully,
+		"when session not found, return bad request": revokeSession_NotFound_ReturnBadRequest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func revokeSession_NoError_ReturnSuccessfully(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodDelete, "/v1/user/sessions/2", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &domain.User{ID: 1})
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+
+	// prepare service
+	s.mockUserUC.On("RevokeSession", req.Context(), int64(2), int64(1)).Return(nil).Once()
+
+	// action
+	s.hlr.RevokeSession(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusOK, res.Code, desc)
+}
+
+func revokeSession_NotFound_ReturnBadRequest(s *UserSuite, desc string) {
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodDelete, "/v1/user/sessions/2", nil)
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &domain.User{ID: 1})
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+
+	// prepare service
+	s.mockUserUC.On("RevokeSession", req.Context(), int64(2), int64(1)).Return(domain.ErrSessionNotFound).Once()
+
+	// action
+	s.hlr.RevokeSession(res, req)
+
+	// assertion
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
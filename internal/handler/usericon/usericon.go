@@ -37,7 +37,7 @@ func (h *Hlr) GetPutObjectURL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	url, err := h.UserIcon.GetPutObjectURL(r.Context(), input.FileName, user.ID)
 	if err != nil {
 		errutil.ServerErrorResponse(w, r, err)
@@ -72,7 +72,7 @@ func (h *Hlr) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := ctxutil.GetUser(r)
+	user := ctxutil.GetLedgerUser(r)
 	err := h.UserIcon.Create(r.Context(), input.FileName, user.ID)
 	if err != nil {
 		errutil.ServerErrorResponse(w, r, err)
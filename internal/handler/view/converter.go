@@ -0,0 +1,119 @@
+package view
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/searchutil"
+)
+
+// cvtToDomainView converts the request to view, except the search query which is parsed by genSearchQuery
+func cvtToDomainView(req viewReq, id int64) (domain.View, error) {
+	v := domain.View{
+		ID:   id,
+		Name: strings.TrimSpace(req.Name),
+		Filter: domain.Filter{
+			MinPrice:     req.MinPrice,
+			MaxPrice:     req.MaxPrice,
+			MainCategIDs: req.MainCategIDs,
+			SubCategIDs:  req.SubCategIDs,
+			TagIDs:       req.TagIDs,
+			TagMatch:     domain.CvtToTagMatchType(req.TagMatch),
+		},
+	}
+
+	if req.Keyword != "" {
+		v.Search.Keyword = &req.Keyword
+	}
+
+	if req.StartDate != "" {
+		date, err := time.Parse(time.DateOnly, req.StartDate)
+		if err != nil {
+			return domain.View{}, err
+		}
+		v.Filter.StartDate = &date
+	}
+
+	if req.EndDate != "" {
+		date, err := time.Parse(time.DateOnly, req.EndDate)
+		if err != nil {
+			return domain.View{}, err
+		}
+		v.Filter.EndDate = &date
+	}
+
+	if req.SortBy != "" || req.SortDir != "" {
+		v.Sort = &domain.Sort{}
+	}
+
+	if req.SortBy != "" {
+		v.Sort.By = domain.CvtToSortByType(req.SortBy)
+		if !v.Sort.By.IsValid() {
+			return domain.View{}, domain.ErrSortByTypeNotValid
+		}
+	}
+
+	if req.SortDir != "" {
+		v.Sort.Dir = domain.CvtToSortDirType(req.SortDir)
+		if !v.Sort.Dir.IsValid() {
+			return domain.View{}, domain.ErrSortDirTypeNotValid
+		}
+	}
+
+	return v, nil
+}
+
+// genSearchQuery parses the search query of the view, it's nil when the query is empty
+func genSearchQuery(raw string) (*domain.SearchQuery, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	query, err := searchutil.ParseQuery(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query, nil
+}
+
+func cvtToViewsResp(views []domain.View) []view {
+	resp := make([]view, 0, len(views))
+
+	for _, v := range views {
+		r := view{
+			ID:           v.ID,
+			Name:         v.Name,
+			MinPrice:     v.Filter.MinPrice,
+			MaxPrice:     v.Filter.MaxPrice,
+			MainCategIDs: v.Filter.MainCategIDs,
+			SubCategIDs:  v.Filter.SubCategIDs,
+			TagIDs:       v.Filter.TagIDs,
+			TagMatch:     v.Filter.TagMatch.String(),
+		}
+
+		if v.Search.Keyword != nil {
+			r.Keyword = *v.Search.Keyword
+		}
+		if v.Search.Query != nil {
+			r.Query = v.Search.Query.Raw
+		}
+		if v.Filter.StartDate != nil {
+			r.StartDate = v.Filter.StartDate.Format(time.DateOnly)
+		}
+		if v.Filter.EndDate != nil {
+			r.EndDate = v.Filter.EndDate.Format(time.DateOnly)
+		}
+		if v.Sort != nil && v.Sort.By.IsValid() {
+			r.SortBy = v.Sort.By.String()
+		}
+		if v.Sort != nil && v.Sort.Dir.IsValid() {
+			r.SortDir = v.Sort.Dir.String()
+		}
+
+		resp = append(resp, r)
+	}
+
+	return resp
+}
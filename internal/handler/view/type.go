@@ -0,0 +1,35 @@
+package view
+
+// viewReq has the same filter, sort and search as the query parameters of getting transactions
+type viewReq struct {
+	Name         string   `json:"name"`
+	Keyword      string   `json:"keyword"`
+	Query        string   `json:"q"`
+	StartDate    string   `json:"start_date"`
+	EndDate      string   `json:"end_date"`
+	MinPrice     *float64 `json:"min_price"`
+	MaxPrice     *float64 `json:"max_price"`
+	MainCategIDs []int64  `json:"main_category_ids"`
+	SubCategIDs  []int64  `json:"sub_category_ids"`
+	TagIDs       []int64  `json:"tag_ids"`
+	TagMatch     string   `json:"tag_match"`
+	SortBy       string   `json:"sort_by"`
+	SortDir      string   `json:"sort_direction"`
+}
+
+type view struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Keyword      string   `json:"keyword"`
+	Query        string   `json:"q"`
+	StartDate    string   `json:"start_date"`
+	EndDate      string   `json:"end_date"`
+	MinPrice     *float64 `json:"min_price"`
+	MaxPrice     *float64 `json:"max_price"`
+	MainCategIDs []int64  `json:"main_category_ids"`
+	SubCategIDs  []int64  `json:"sub_category_ids"`
+	TagIDs       []int64  `json:"tag_ids"`
+	TagMatch     string   `json:"tag_match"`
+	SortBy       string   `json:"sort_by"`
+	SortDir      string   `json:"sort_direction"`
+}
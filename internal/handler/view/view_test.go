@@ -0,0 +1,144 @@
+package view_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/view"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type ViewSuite struct {
+	suite.Suite
+	hlr        *view.Hlr
+	mockViewUC *mocks.ViewUC
+}
+
+func TestViewSuite(t *testing.T) {
+	suite.Run(t, new(ViewSuite))
+}
+
+func (s *ViewSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ViewSuite) SetupTest() {
+	s.mockViewUC = mocks.NewViewUC(s.T())
+	s.hlr = view.New(s.mockViewUC)
+}
+
+func (s *ViewSuite) TearDownTest() {
+	s.mockViewUC.AssertExpectations(s.T())
+}
+
+func (s *ViewSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *ViewSuite, desc string){
+		"when no error, create successfully":                 create_NoError_CreateSuccessfully,
+		"when no filter, sort or search, return bad request": create_NoFilter_ReturnBadReq,
+		"when query is invalid, return bad request":          create_InvalidQuery_ReturnBadReq,
+		"when name is duplicated, return bad request":        create_DuplicateName_ReturnBadReq,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *ViewSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{
+		"name":              " big food ",
+		"q":                 "price>20",
+		"main_category_ids": []int64{1, 2},
+		"sort_by":           "price",
+		"sort_direction":    "desc",
+	})
+	s.Require().NoError(err, desc)
+
+	// prepare request, and response recorder
+	req := httptest.NewRequest(http.MethodPost, "/v1/view", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	// mock service
+	expView := domain.View{
+		Name:   "big food",
+		Filter: domain.Filter{MainCategIDs: []int64{1, 2}},
+		Sort:   &domain.Sort{By: domain.SortByTypePrice, Dir: domain.SortDirTypeDesc},
+		Search: domain.Search{
+			Query: &domain.SearchQuery{
+				Raw:    "price>20",
+				Prices: []domain.SearchPriceCond{{Op: domain.CmpOpTypeGt, Value: 20}},
+			},
+		},
+	}
+	s.mockViewUC.On("Create", req.Context(), expView, int64(1)).Return(nil).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusCreated, res.Code, desc)
+}
+
+func create_NoFilter_ReturnBadReq(s *ViewSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "all"})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/view", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(map[string]interface{}{"view": "At least one filter, sort or search is required"}, responseBody, desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_InvalidQuery_ReturnBadReq(s *ViewSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "coffee", "q": `note:"coffee`})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/view", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	s.hlr.Create(res, req)
+
+	var responseBody map[string]interface{}
+	err = json.Unmarshal(res.Body.Bytes(), &responseBody)
+	s.Require().NoError(err, desc)
+	s.Require().Equal("query has an unclosed quote", responseBody["q"], desc)
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
+
+func create_DuplicateName_ReturnBadReq(s *ViewSuite, desc string) {
+	user := domain.User{ID: 1}
+	body, err := json.Marshal(map[string]interface{}{"name": "coffee", "keyword": "coffee"})
+	s.Require().NoError(err, desc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/view", bytes.NewBuffer(body))
+	res := httptest.NewRecorder()
+	req = ctxutil.SetUser(req, &user)
+
+	keyword := "coffee"
+	expView := domain.View{Name: "coffee", Search: domain.Search{Keyword: &keyword}}
+	s.mockViewUC.On("Create", req.Context(), expView, int64(1)).Return(domain.ErrUniqueViewNameUser).Once()
+
+	s.hlr.Create(res, req)
+
+	s.Require().Equal(http.StatusBadRequest, res.Code, desc)
+}
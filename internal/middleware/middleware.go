@@ -1,17 +1,47 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"errors"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/handler/interfaces"
 	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/errutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	// ledgerHeader selects the ledger of the request, the personal ledger is used without it
+	ledgerHeader = "X-Ledger-ID"
+
+	// apiKeyScheme is the authorization scheme of the API keys, e.g. "Authorization: ApiKey etk_..."
+	apiKeyScheme = "ApiKey"
+
+	// adminKeyHeader carries the admin key of the routes changing the data shared by all users
+	adminKeyHeader = "X-Admin-Key"
+)
+
+// Middleware holds the usecases the middlewares depend on
+type Middleware struct {
+	Ledger interfaces.LedgerUC
+	User   interfaces.UserUC
+	APIKey interfaces.APIKeyUC
+}
+
+func New(l interfaces.LedgerUC, u interfaces.UserUC, k interfaces.APIKeyUC) *Middleware {
+	return &Middleware{
+		Ledger: l,
+		User:   u,
+		APIKey: k,
+	}
+}
+
 func LogRequest(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger.Info("request started", "method", r.Method, "url", r.URL)
@@ -20,7 +50,7 @@ func LogRequest(next http.Handler) http.Handler {
 	})
 }
 
-func Authenticate(next http.Handler) http.Handler {
+func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authorizationHeader := r.Header.Get("Authorization")
 		if authorizationHeader == "" {
@@ -29,40 +59,164 @@ func Authenticate(next http.Handler) http.Handler {
 		}
 
 		auth := strings.Split(authorizationHeader, " ")
-		if len(auth) != 2 || auth[0] != "Bearer" {
+		if len(auth) != 2 {
 			errutil.AuthenticationErrorResponse(w, r, domain.ErrAuthToken)
 			return
 		}
 
-		token, err := jwt.Parse(auth[1], func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				logger.Error("Unexpected signing method", "package", "middleware")
-				errutil.ServerErrorResponse(w, r, domain.ErrServer)
+		var ok bool
+		switch auth[0] {
+		case "Bearer":
+			r, ok = m.authenticateToken(w, r, auth[1])
+		case apiKeyScheme:
+			r, ok = m.authenticateAPIKey(w, r, auth[1])
+		default:
+			errutil.AuthenticationErrorResponse(w, r, domain.ErrAuthToken)
+			return
+		}
+		if !ok {
+			return
+		}
+		user := ctxutil.GetUser(r)
+
+		rawLedgerID := r.Header.Get(ledgerHeader)
+		if rawLedgerID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ledgerID, err := strconv.ParseInt(rawLedgerID, 10, 64)
+		if err != nil {
+			errutil.BadRequestResponse(w, r, domain.ErrLedgerNotFound)
+			return
+		}
+
+		// viewers are only allowed to read, unless the usecase checks the role by the operation
+		write := r.Method != http.MethodGet && r.Method != http.MethodHead && !ctxutil.IsRoleCheckDeferred(r)
+		ledger, err := m.Ledger.Resolve(r.Context(), ledgerID, user.ID, write)
+		if err != nil {
+			if errors.Is(err, domain.ErrLedgerNotFound) {
+				errutil.BadRequestResponse(w, r, err)
+				return
+			}
+			if errors.Is(err, domain.ErrLedgerPermissionDenied) {
+				errutil.ForbiddenResponse(w, r, err)
+				return
 			}
 
-			return []byte(os.Getenv("JWT_SECRET_KEY")), nil
+			errutil.ServerErrorResponse(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, ctxutil.SetLedger(r, &ledger))
+	})
+}
+
+// RequireScope lets the API keys granted the scope access the route, it must run before Authenticate.
+// The routes without a scope only accept the access token
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, ctxutil.SetScope(r, scope))
 		})
-		if err != nil {
-			logger.Error("jwt.Parse failed", "package", "middleware", "err", err)
-			errutil.AuthenticationErrorResponse(w, r, err)
+	}
+}
+
+// RequireAdminKey lets only the operator access the route with the admin key, e.g. importing the exchange rates shared by all users.
+// The route is closed when ADMIN_API_KEY is not set
+func RequireAdminKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adminKey := os.Getenv("ADMIN_API_KEY")
+		if adminKey == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get(adminKeyHeader)), []byte(adminKey)) != 1 {
+			errutil.ForbiddenResponse(w, r, domain.ErrAdminKey)
 			return
 		}
 
-		user := domain.User{
-			ID:    int64(token.Claims.(jwt.MapClaims)["user_id"].(float64)),
-			Email: token.Claims.(jwt.MapClaims)["user_email"].(string),
-			Name:  token.Claims.(jwt.MapClaims)["user_name"].(string),
+		next.ServeHTTP(w, r)
+	})
+}
+
+// DeferRoleCheck lets the viewers of the ledger reach the route with any method, it must run before Authenticate.
+// It's for the routes whose usecase checks the role by the operation, e.g. the dry-run of an import
+func DeferRoleCheck(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, ctxutil.SetDeferRoleCheck(r))
+	})
+}
+
+// authenticateToken stores the user and the session of the access token in the request context,
+// it writes the error response and returns false if the token is invalid or the session is revoked
+func (m *Middleware) authenticateToken(w http.ResponseWriter, r *http.Request, tokenString string) (*http.Request, bool) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			logger.Error("Unexpected signing method", "package", "middleware")
+			errutil.ServerErrorResponse(w, r, domain.ErrServer)
 		}
 
-		next.ServeHTTP(w, ctxutil.SetUser(r, &user))
+		return []byte(os.Getenv("JWT_SECRET_KEY")), nil
 	})
+	if err != nil {
+		logger.Error("jwt.Parse failed", "package", "middleware", "err", err)
+		errutil.AuthenticationErrorResponse(w, r, err)
+		return r, false
+	}
+
+	user := domain.User{
+		ID:    int64(token.Claims.(jwt.MapClaims)["user_id"].(float64)),
+		Email: token.Claims.(jwt.MapClaims)["user_email"].(string),
+		Name:  token.Claims.(jwt.MapClaims)["user_name"].(string),
+	}
+
+	// the jti is the id of the session, the token is rejected as soon as the session is revoked
+	jti, _ := token.Claims.(jwt.MapClaims)["jti"].(string)
+	sessionID, err := strconv.ParseInt(jti, 10, 64)
+	if err != nil {
+		errutil.AuthenticationErrorResponse(w, r, domain.ErrAuthToken)
+		return r, false
+	}
+
+	if err := m.User.ValidateSession(r.Context(), sessionID, user.ID); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			errutil.AuthenticationErrorResponse(w, r, domain.ErrAuthToken)
+			return r, false
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return r, false
+	}
+
+	r = ctxutil.SetUser(r, &user)
+	return ctxutil.SetSessionID(r, sessionID), true
+}
+
+// authenticateAPIKey stores the owner of the API key in the request context,
+// it writes the error response and returns false if the key is invalid or isn't granted the scope of the route
+func (m *Middleware) authenticateAPIKey(w http.ResponseWriter, r *http.Request, rawKey string) (*http.Request, bool) {
+	user, key, err := m.APIKey.Authenticate(r.Context(), rawKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			errutil.AuthenticationErrorResponse(w, r, domain.ErrAuthToken)
+			return r, false
+		}
+
+		errutil.ServerErrorResponse(w, r, err)
+		return r, false
+	}
+
+	scope := ctxutil.GetScope(r)
+	if scope == "" || !key.HasScope(scope) {
+		errutil.ForbiddenResponse(w, r, domain.ErrAPIKeyScope)
+		return r, false
+	}
+
+	return ctxutil.SetUser(r, &user), true
 }
 
 func EnableCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, Authorization, Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Origin, Authorization, Content-Type, X-Ledger-ID")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		if r.Method == http.MethodOptions {
@@ -3,6 +3,7 @@ package router
 import (
 	"net/http"
 
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	hd "github.com/eyo-chen/expense-tracker-go/internal/handler"
 	"github.com/eyo-chen/expense-tracker-go/internal/middleware"
 	"github.com/gorilla/mux"
@@ -10,24 +11,50 @@ import (
 )
 
 // New initializes a new router and returns it
-func New(handler *hd.Handler) http.Handler {
+func New(handler *hd.Handler, mw *middleware.Middleware) http.Handler {
 	r := mux.NewRouter()
 
 	// user
 	r.HandleFunc("/v1/user/signup", handler.User.Signup).Methods(http.MethodPost)
 	r.HandleFunc("/v1/user/login", handler.User.Login).Methods(http.MethodPost)
 	r.HandleFunc("/v1/user/token", handler.User.Token).Methods(http.MethodGet)
+	r.HandleFunc("/v1/user/password-reset", handler.User.RequestPasswordReset).Methods(http.MethodPost)
+	r.HandleFunc("/v1/user/password-reset/confirm", handler.User.ResetPassword).Methods(http.MethodPost)
+	r.HandleFunc("/v1/user/verify-email/confirm", handler.User.VerifyEmail).Methods(http.MethodPost)
 
 	// init data
 	r.Handle("/v1/init-data", http.HandlerFunc(handler.InitData.List)).Methods(http.MethodGet)
+	r.Handle("/v1/init-data/templates", http.HandlerFunc(handler.InitData.ListTemplates)).Methods(http.MethodGet)
 
 	// icon
 	r.Handle("/v1/icon", http.HandlerFunc(handler.Icon.List)).Methods(http.MethodGet)
 
-	auth := alice.New(middleware.Authenticate)
+	auth := alice.New(mw.Authenticate)
+
+	// the scoped routes also accept the API keys granted the scope, the others only accept the access token
+	scoped := func(scope string) alice.Chain {
+		return alice.New(middleware.RequireScope(scope), mw.Authenticate)
+	}
+	transRead := scoped(domain.ScopeTransactionsRead)
+	transWrite := scoped(domain.ScopeTransactionsWrite)
+	chartsRead := scoped(domain.ScopeChartsRead)
+	categRead := scoped(domain.ScopeCategoriesRead)
+
+	// the usecases of these routes check the role of the ledger member by the operation, e.g. viewers can dry-run
+	roleChecked := func(chain alice.Chain) alice.Chain {
+		return alice.New(middleware.DeferRoleCheck).Extend(chain)
+	}
 
 	// user with auth
 	r.Handle("/v1/user", auth.ThenFunc(handler.User.GetInfo)).Methods(http.MethodGet)
+	r.Handle("/v1/user/base-currency", auth.ThenFunc(handler.User.UpdateBaseCurrency)).Methods(http.MethodPut)
+	r.Handle("/v1/user/verify-email", auth.ThenFunc(handler.User.RequestEmailVerification)).Methods(http.MethodPost)
+	r.Handle("/v1/user/sessions", auth.ThenFunc(handler.User.ListSessions)).Methods(http.MethodGet)
+	r.Handle("/v1/user/sessions", auth.ThenFunc(handler.User.RevokeAllSessions)).Methods(http.MethodDelete)
+	r.Handle("/v1/user/sessions/{id}", auth.ThenFunc(handler.User.RevokeSession)).Methods(http.MethodDelete)
+	r.Handle("/v1/user/api-keys", auth.ThenFunc(handler.APIKey.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/user/api-keys", auth.ThenFunc(handler.APIKey.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/user/api-keys/{id}", auth.ThenFunc(handler.APIKey.Delete)).Methods(http.MethodDelete)
 
 	// user icon
 	r.Handle("/v1/user-icon", auth.ThenFunc(handler.Icon.ListByUserID)).Methods(http.MethodGet)
@@ -36,29 +63,126 @@ func New(handler *hd.Handler) http.Handler {
 
 	// init data with auth
 	r.Handle("/v1/init-data", auth.ThenFunc(handler.InitData.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/init-data/export", auth.ThenFunc(handler.InitData.Export)).Methods(http.MethodGet)
+	r.Handle("/v1/init-data/import", auth.ThenFunc(handler.InitData.Import)).Methods(http.MethodPost)
 
 	// main category
 	r.Handle("/v1/main-category", auth.ThenFunc(handler.MainCateg.Create)).Methods(http.MethodPost)
-	r.Handle("/v1/main-category", auth.ThenFunc(handler.MainCateg.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/main-category", categRead.ThenFunc(handler.MainCateg.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/main-category/order", auth.ThenFunc(handler.MainCateg.Reorder)).Methods(http.MethodPut)
 	r.Handle("/v1/main-category/{id}", auth.ThenFunc(handler.MainCateg.Update)).Methods(http.MethodPatch)
 	r.Handle("/v1/main-category/{id}", auth.ThenFunc(handler.MainCateg.Delete)).Methods(http.MethodDelete)
+	r.Handle("/v1/main-category/{id}/merge-into/{target}", auth.ThenFunc(handler.MainCateg.Merge)).Methods(http.MethodPost)
+	r.Handle("/v1/main-category/{id}/archive", auth.ThenFunc(handler.MainCateg.SetArchived)).Methods(http.MethodPatch)
 
 	// sub category
 	r.Handle("/v1/sub-category", auth.ThenFunc(handler.SubCateg.CreateSubCateg)).Methods(http.MethodPost)
-	r.Handle("/v1/main-category/{id}/sub-category", auth.ThenFunc(handler.SubCateg.GetByMainCategID)).Methods(http.MethodGet)
+	r.Handle("/v1/main-category/{id}/sub-category", categRead.ThenFunc(handler.SubCateg.GetByMainCategID)).Methods(http.MethodGet)
+	r.Handle("/v1/main-category/{id}/sub-category/order", auth.ThenFunc(handler.SubCateg.Reorder)).Methods(http.MethodPut)
 	r.Handle("/v1/sub-category/{id}", auth.ThenFunc(handler.SubCateg.UpdateSubCateg)).Methods(http.MethodPatch)
 	r.Handle("/v1/sub-category/{id}", auth.ThenFunc(handler.SubCateg.DeleteSubCateg)).Methods(http.MethodDelete)
+	r.Handle("/v1/sub-category/{id}/move", auth.ThenFunc(handler.SubCateg.MoveSubCateg)).Methods(http.MethodPost)
+	r.Handle("/v1/sub-category/{id}/archive", auth.ThenFunc(handler.SubCateg.SetArchived)).Methods(http.MethodPatch)
 
 	// transaction
-	r.Handle("/v1/transaction", auth.ThenFunc(handler.Transaction.Create)).Methods(http.MethodPost)
-	r.Handle("/v1/transaction", auth.ThenFunc(handler.Transaction.GetAll)).Methods(http.MethodGet)
-	r.Handle("/v1/transaction/{id}", auth.ThenFunc(handler.Transaction.Update)).Methods(http.MethodPut)
-	r.Handle("/v1/transaction/{id}", auth.ThenFunc(handler.Transaction.Delete)).Methods(http.MethodDelete)
-	r.Handle("/v1/transaction/info", auth.ThenFunc(handler.Transaction.GetAccInfo)).Methods(http.MethodGet)
-	r.Handle("/v1/transaction/bar-chart", auth.ThenFunc(handler.Transaction.GetBarChartData)).Methods(http.MethodGet)
-	r.Handle("/v1/transaction/pie-chart", auth.ThenFunc(handler.Transaction.GetPieChartData)).Methods(http.MethodGet)
-	r.Handle("/v1/transaction/line-chart", auth.ThenFunc(handler.Transaction.GetLineChartData)).Methods(http.MethodGet)
-	r.Handle("/v1/transaction/monthly-data", auth.ThenFunc(handler.Transaction.GetMonthlyData)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction", transWrite.ThenFunc(handler.Transaction.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/transaction", transRead.ThenFunc(handler.Transaction.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/export", transRead.ThenFunc(handler.Transaction.Export)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/bulk", transWrite.ThenFunc(handler.Transaction.Bulk)).Methods(http.MethodPost)
+	r.Handle("/v1/transaction/duplicates", transRead.ThenFunc(handler.Transaction.GetDuplicates)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/duplicates/merge", transWrite.ThenFunc(handler.Transaction.MergeDuplicates)).Methods(http.MethodPost)
+	r.Handle("/v1/transaction/duplicates/dismiss", transWrite.ThenFunc(handler.Transaction.DismissDuplicates)).Methods(http.MethodPost)
+	r.Handle("/v1/transaction/{id}", transWrite.ThenFunc(handler.Transaction.Update)).Methods(http.MethodPut)
+	r.Handle("/v1/transaction/{id}", transWrite.ThenFunc(handler.Transaction.Delete)).Methods(http.MethodDelete)
+	r.Handle("/v1/transaction/{id}/history", transRead.ThenFunc(handler.Transaction.GetHistory)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/{id}/history/{revision_id}/revert", transWrite.ThenFunc(handler.Transaction.Revert)).Methods(http.MethodPost)
+	r.Handle("/v1/transaction/{id}/attachment/upload-url", transWrite.ThenFunc(handler.Transaction.GetAttachmentPutURL)).Methods(http.MethodPost)
+	r.Handle("/v1/transaction/{id}/attachment", transWrite.ThenFunc(handler.Transaction.CreateAttachment)).Methods(http.MethodPost)
+	r.Handle("/v1/transaction/{id}/attachment/{attachment_id}", transWrite.ThenFunc(handler.Transaction.DeleteAttachment)).Methods(http.MethodDelete)
+	r.Handle("/v1/transaction/{id}/share", transWrite.ThenFunc(handler.Share.Update)).Methods(http.MethodPut)
+	r.Handle("/v1/transaction/{id}/share", transRead.ThenFunc(handler.Share.Get)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/{id}/share", transWrite.ThenFunc(handler.Share.Delete)).Methods(http.MethodDelete)
+	r.Handle("/v1/transaction/info", chartsRead.ThenFunc(handler.Transaction.GetAccInfo)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/bar-chart", chartsRead.ThenFunc(handler.Transaction.GetBarChartData)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/pie-chart", chartsRead.ThenFunc(handler.Transaction.GetPieChartData)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/tag-chart", chartsRead.ThenFunc(handler.Transaction.GetTagChartData)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/line-chart", chartsRead.ThenFunc(handler.Transaction.GetLineChartData)).Methods(http.MethodGet)
+	r.Handle("/v1/transaction/monthly-data", transRead.ThenFunc(handler.Transaction.GetMonthlyData)).Methods(http.MethodGet)
+
+	// import transaction
+	r.Handle("/v1/transaction/import", roleChecked(transWrite).ThenFunc(handler.ImportTrans.Import)).Methods(http.MethodPost)
+
+	// recurring transaction
+	r.Handle("/v1/recurring-transaction", auth.ThenFunc(handler.RecurringTrans.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/recurring-transaction", auth.ThenFunc(handler.RecurringTrans.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/recurring-transaction/{id}", auth.ThenFunc(handler.RecurringTrans.Update)).Methods(http.MethodPut)
+	r.Handle("/v1/recurring-transaction/{id}", auth.ThenFunc(handler.RecurringTrans.Delete)).Methods(http.MethodDelete)
+
+	// budget
+	r.Handle("/v1/budget", auth.ThenFunc(handler.Budget.Set)).Methods(http.MethodPost)
+	r.Handle("/v1/budget", auth.ThenFunc(handler.Budget.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/budget/status", auth.ThenFunc(handler.Budget.GetStatus)).Methods(http.MethodGet)
+	r.Handle("/v1/budget/{id}", auth.ThenFunc(handler.Budget.Delete)).Methods(http.MethodDelete)
+
+	// exchange rate
+	// the rates are shared by all users, so only the operator imports them with the admin key
+	r.Handle("/v1/exchange-rate/import", alice.New(middleware.RequireAdminKey).ThenFunc(handler.ExchangeRate.Import)).Methods(http.MethodPost)
+	r.Handle("/v1/exchange-rate", auth.ThenFunc(handler.ExchangeRate.GetAll)).Methods(http.MethodGet)
+
+	// account
+	r.Handle("/v1/account", auth.ThenFunc(handler.Account.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/account", auth.ThenFunc(handler.Account.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/account/{id}", auth.ThenFunc(handler.Account.Update)).Methods(http.MethodPut)
+	r.Handle("/v1/account/{id}", auth.ThenFunc(handler.Account.Delete)).Methods(http.MethodDelete)
+	r.Handle("/v1/account/{id}/balance", auth.ThenFunc(handler.Account.GetBalance)).Methods(http.MethodGet)
+
+	// tag
+	r.Handle("/v1/tag", auth.ThenFunc(handler.Tag.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/tag", auth.ThenFunc(handler.Tag.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/tag/{id}", auth.ThenFunc(handler.Tag.Update)).Methods(http.MethodPut)
+	r.Handle("/v1/tag/{id}", auth.ThenFunc(handler.Tag.Delete)).Methods(http.MethodDelete)
+
+	// contact
+	r.Handle("/v1/contact", auth.ThenFunc(handler.Contact.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/contact", auth.ThenFunc(handler.Contact.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/contact/{id}", auth.ThenFunc(handler.Contact.Update)).Methods(http.MethodPut)
+	r.Handle("/v1/contact/{id}", auth.ThenFunc(handler.Contact.Delete)).Methods(http.MethodDelete)
+
+	// settlement
+	r.Handle("/v1/settlement", auth.ThenFunc(handler.Settlement.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/settlement", auth.ThenFunc(handler.Settlement.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/settlement/{id}", auth.ThenFunc(handler.Settlement.Delete)).Methods(http.MethodDelete)
+	r.Handle("/v1/balances", auth.ThenFunc(handler.Settlement.GetBalances)).Methods(http.MethodGet)
+
+	// rule
+	r.Handle("/v1/rule", auth.ThenFunc(handler.Rule.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/rule", auth.ThenFunc(handler.Rule.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/rule/rerun", roleChecked(auth).ThenFunc(handler.Rule.Rerun)).Methods(http.MethodPost)
+	r.Handle("/v1/rule/{id}", auth.ThenFunc(handler.Rule.Update)).Methods(http.MethodPut)
+	r.Handle("/v1/rule/{id}", auth.ThenFunc(handler.Rule.Delete)).Methods(http.MethodDelete)
+
+	// view
+	r.Handle("/v1/view", auth.ThenFunc(handler.View.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/view", auth.ThenFunc(handler.View.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/view/{id}", auth.ThenFunc(handler.View.Update)).Methods(http.MethodPut)
+	r.Handle("/v1/view/{id}", auth.ThenFunc(handler.View.Delete)).Methods(http.MethodDelete)
+
+	// ledger
+	r.Handle("/v1/ledger", auth.ThenFunc(handler.Ledger.Create)).Methods(http.MethodPost)
+	r.Handle("/v1/ledger", auth.ThenFunc(handler.Ledger.List)).Methods(http.MethodGet)
+	r.Handle("/v1/ledger/{id}/member", auth.ThenFunc(handler.Ledger.ListMembers)).Methods(http.MethodGet)
+	r.Handle("/v1/ledger/{id}/member/{user_id}", auth.ThenFunc(handler.Ledger.UpdateMemberRole)).Methods(http.MethodPatch)
+	r.Handle("/v1/ledger/{id}/member/{user_id}", auth.ThenFunc(handler.Ledger.RemoveMember)).Methods(http.MethodDelete)
+	r.Handle("/v1/ledger/{id}/invite", auth.ThenFunc(handler.Ledger.Invite)).Methods(http.MethodPost)
+
+	// ledger invite
+	r.Handle("/v1/ledger-invite", auth.ThenFunc(handler.Ledger.ListInvites)).Methods(http.MethodGet)
+	r.Handle("/v1/ledger-invite/{id}/accept", auth.ThenFunc(handler.Ledger.AcceptInvite)).Methods(http.MethodPost)
+	r.Handle("/v1/ledger-invite/{id}", auth.ThenFunc(handler.Ledger.DeclineInvite)).Methods(http.MethodDelete)
+
+	// trash
+	r.Handle("/v1/trash", auth.ThenFunc(handler.Trash.GetAll)).Methods(http.MethodGet)
+	r.Handle("/v1/trash/{kind}/{id}/restore", auth.ThenFunc(handler.Trash.Restore)).Methods(http.MethodPost)
 
 	// stock
 	r.Handle("/v1/stock", auth.ThenFunc(handler.Stock.Create)).Methods(http.MethodPost)
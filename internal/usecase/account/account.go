@@ -0,0 +1,81 @@
+package account
+
+import (
+	"context"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+)
+
+type UC struct {
+	Account     interfaces.AccountRepo
+	Transaction interfaces.TransactionRepo
+}
+
+func New(a interfaces.AccountRepo, t interfaces.TransactionRepo) *UC {
+	return &UC{
+		Account:     a,
+		Transaction: t,
+	}
+}
+
+func (u *UC) Create(ctx context.Context, account domain.Account, userID int64) error {
+	return u.Account.Create(ctx, account, userID)
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.Account, error) {
+	return u.Account.GetAll(ctx, userID)
+}
+
+func (u *UC) Update(ctx context.Context, account domain.Account, userID int64) error {
+	// check permission
+	if _, err := u.Account.GetByIDAndUserID(ctx, account.ID, userID); err != nil {
+		return err
+	}
+
+	return u.Account.Update(ctx, account)
+}
+
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.Account.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return u.Account.Delete(ctx, id)
+}
+
+func (u *UC) GetBalance(ctx context.Context, id, userID int64) (domain.AccountBalance, error) {
+	account, err := u.Account.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return domain.AccountBalance{}, err
+	}
+
+	entries, err := u.Transaction.GetAccountEntries(ctx, id, userID)
+	if err != nil {
+		return domain.AccountBalance{}, err
+	}
+
+	// the unconverted entries are left out of the balance, and reported by their number
+	balance := account.InitialBalance
+	var unconverted int64
+	for i := range entries {
+		if entries[i].IsUnconverted {
+			unconverted++
+		} else {
+			balance += entries[i].Amount
+		}
+		entries[i].Balance = balance
+	}
+
+	if entries == nil {
+		entries = []domain.AccountEntry{}
+	}
+
+	return domain.AccountBalance{
+		Account:     account,
+		Balance:     balance,
+		Entries:     entries,
+		Unconverted: unconverted,
+	}, nil
+}
@@ -0,0 +1,211 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type AccountSuite struct {
+	suite.Suite
+	uc                  *UC
+	mockAccountRepo     *mocks.AccountRepo
+	mockTransactionRepo *mocks.TransactionRepo
+}
+
+func TestAccountSuite(t *testing.T) {
+	suite.Run(t, new(AccountSuite))
+}
+
+func (s *AccountSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *AccountSuite) SetupTest() {
+	s.mockAccountRepo = mocks.NewAccountRepo(s.T())
+	s.mockTransactionRepo = mocks.NewTransactionRepo(s.T())
+	s.uc = New(s.mockAccountRepo, s.mockTransactionRepo)
+}
+
+func (s *AccountSuite) TearDownTest() {
+	s.mockAccountRepo.AssertExpectations(s.T())
+	s.mockTransactionRepo.AssertExpectations(s.T())
+}
+
+func (s *AccountSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *AccountSuite, desc string){
+		"when no error, update successfully":   update_NoError_UpdateSuccessfully,
+		"when account not found, return error": update_AccountNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoError_UpdateSuccessfully(s *AccountSuite, desc string) {
+	account := domain.Account{ID: 1, Name: "wallet", Type: domain.AccountTypeCash}
+
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(account, nil).Once()
+	s.mockAccountRepo.On("Update", mockCtx, account).Return(nil).Once()
+
+	err := s.uc.Update(mockCtx, account, 1)
+	s.Require().NoError(err, desc)
+}
+
+func update_AccountNotFound_ReturnError(s *AccountSuite, desc string) {
+	account := domain.Account{ID: 1, Name: "wallet", Type: domain.AccountTypeCash}
+
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Account{}, domain.ErrAccountNotFound).Once()
+
+	err := s.uc.Update(mockCtx, account, 1)
+	s.Require().ErrorIs(err, domain.ErrAccountNotFound, desc)
+}
+
+func (s *AccountSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *AccountSuite, desc string){
+		"when no error, delete successfully":   delete_NoError_DeleteSuccessfully,
+		"when account not found, return error": delete_AccountNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NoError_DeleteSuccessfully(s *AccountSuite, desc string) {
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Account{ID: 1}, nil).Once()
+	s.mockAccountRepo.On("Delete", mockCtx, int64(1)).Return(nil).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+}
+
+func delete_AccountNotFound_ReturnError(s *AccountSuite, desc string) {
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Account{}, domain.ErrAccountNotFound).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrAccountNotFound, desc)
+}
+
+func (s *AccountSuite) TestGetBalance() {
+	for scenario, fn := range map[string]func(s *AccountSuite, desc string){
+		"when no error, return running balance":       getBalance_NoError_ReturnRunningBalance,
+		"when entry is unconverted, leave it out":     getBalance_UnconvertedEntry_LeaveOut,
+		"when no transaction, return initial balance": getBalance_NoTransaction_ReturnInitialBalance,
+		"when account not found, return error":        getBalance_AccountNotFound_ReturnError,
+		"when get account entries fail, return error": getBalance_GetEntriesFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getBalance_NoError_ReturnRunningBalance(s *AccountSuite, desc string) {
+	account := domain.Account{ID: 1, Name: "bank", Type: domain.AccountTypeBank, InitialBalance: 100}
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []domain.AccountEntry{
+		{TransactionID: 1, Type: domain.TransactionTypeIncome, Date: date, Amount: 50},
+		{TransactionID: 2, Type: domain.TransactionTypeExpense, Date: date, Amount: -30},
+		{TransactionID: 3, Type: domain.TransactionTypeTransfer, Date: date, Amount: -70},
+	}
+
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(account, nil).Once()
+	s.mockTransactionRepo.On("GetAccountEntries", mockCtx, int64(1), int64(1)).Return(entries, nil).Once()
+
+	expResult := domain.AccountBalance{
+		Account: account,
+		Balance: 50,
+		Entries: []domain.AccountEntry{
+			{TransactionID: 1, Type: domain.TransactionTypeIncome, Date: date, Amount: 50, Balance: 150},
+			{TransactionID: 2, Type: domain.TransactionTypeExpense, Date: date, Amount: -30, Balance: 120},
+			{TransactionID: 3, Type: domain.TransactionTypeTransfer, Date: date, Amount: -70, Balance: 50},
+		},
+	}
+
+	result, err := s.uc.GetBalance(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getBalance_UnconvertedEntry_LeaveOut(s *AccountSuite, desc string) {
+	account := domain.Account{ID: 1, Name: "bank", Type: domain.AccountTypeBank, InitialBalance: 100}
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []domain.AccountEntry{
+		{TransactionID: 1, Type: domain.TransactionTypeIncome, Date: date, Amount: 50},
+		{TransactionID: 2, Type: domain.TransactionTypeExpense, Date: date, IsUnconverted: true},
+		{TransactionID: 3, Type: domain.TransactionTypeExpense, Date: date, Amount: -30},
+	}
+
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(account, nil).Once()
+	s.mockTransactionRepo.On("GetAccountEntries", mockCtx, int64(1), int64(1)).Return(entries, nil).Once()
+
+	expResult := domain.AccountBalance{
+		Account: account,
+		Balance: 120,
+		Entries: []domain.AccountEntry{
+			{TransactionID: 1, Type: domain.TransactionTypeIncome, Date: date, Amount: 50, Balance: 150},
+			{TransactionID: 2, Type: domain.TransactionTypeExpense, Date: date, Balance: 150, IsUnconverted: true},
+			{TransactionID: 3, Type: domain.TransactionTypeExpense, Date: date, Amount: -30, Balance: 120},
+		},
+		Unconverted: 1,
+	}
+
+	result, err := s.uc.GetBalance(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getBalance_NoTransaction_ReturnInitialBalance(s *AccountSuite, desc string) {
+	account := domain.Account{ID: 1, Name: "wallet", Type: domain.AccountTypeCash, InitialBalance: 100}
+
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(account, nil).Once()
+	s.mockTransactionRepo.On("GetAccountEntries", mockCtx, int64(1), int64(1)).Return(nil, nil).Once()
+
+	expResult := domain.AccountBalance{
+		Account: account,
+		Balance: 100,
+		Entries: []domain.AccountEntry{},
+	}
+
+	result, err := s.uc.GetBalance(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getBalance_AccountNotFound_ReturnError(s *AccountSuite, desc string) {
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Account{}, domain.ErrAccountNotFound).Once()
+
+	result, err := s.uc.GetBalance(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrAccountNotFound, desc)
+	s.Require().Empty(result, desc)
+}
+
+func getBalance_GetEntriesFail_ReturnError(s *AccountSuite, desc string) {
+	mockErr := errors.New("get account entries fail")
+
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Account{ID: 1}, nil).Once()
+	s.mockTransactionRepo.On("GetAccountEntries", mockCtx, int64(1), int64(1)).Return(nil, mockErr).Once()
+
+	result, err := s.uc.GetBalance(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(result, desc)
+}
@@ -0,0 +1,115 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "usecase/apikey"
+
+	// keyPrefix marks the key as an API key of the app, so a leaked key is easy to recognize
+	keyPrefix = "etk_"
+
+	// prefixLen is the length of the beginning of the key which is stored to tell the keys apart
+	prefixLen = 12
+
+	// touchInterval is how often the last used time of an API key is updated
+	touchInterval = time.Minute
+)
+
+type UC struct {
+	APIKey interfaces.APIKeyRepo
+	User   interfaces.UserRepo
+}
+
+func New(k interfaces.APIKeyRepo, u interfaces.UserRepo) *UC {
+	return &UC{
+		APIKey: k,
+		User:   u,
+	}
+}
+
+// Create generates the key of the API key, only the hash of the key is stored, so it's the only time the key is returned
+func (u *UC) Create(ctx context.Context, key domain.APIKey, userID int64) (domain.APIKey, string, error) {
+	rawKey, err := genKey()
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	key.UserID = userID
+	key.Prefix = rawKey[:prefixLen]
+	id, err := u.APIKey.Create(ctx, key, hashKey(rawKey))
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	created, err := u.APIKey.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	return created, rawKey, nil
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	return u.APIKey.GetAll(ctx, userID)
+}
+
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.APIKey.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return u.APIKey.Delete(ctx, id)
+}
+
+func (u *UC) Authenticate(ctx context.Context, rawKey string) (domain.User, domain.APIKey, error) {
+	key, err := u.APIKey.GetByKeyHash(ctx, hashKey(rawKey))
+	if err != nil {
+		return domain.User{}, domain.APIKey{}, err
+	}
+
+	now := time.Now()
+	if key.IsExpired(now) {
+		return domain.User{}, domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+
+	user, err := u.User.GetInfo(key.UserID)
+	if err != nil {
+		return domain.User{}, domain.APIKey{}, err
+	}
+
+	// the last used time is only updated once in a while, so that not every request writes the database
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > touchInterval {
+		// it's ok to fail
+		if err := u.APIKey.UpdateLastUsedAt(ctx, key.ID); err != nil {
+			logger.Error("u.APIKey.UpdateLastUsedAt failed", "package", packageName, "err", err)
+		}
+	}
+
+	return user, key, nil
+}
+
+func genKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		logger.Error("rand.Read failed", "package", packageName, "err", err)
+		return "", err
+	}
+
+	return keyPrefix + hex.EncodeToString(b), nil
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,180 @@
+package apikey
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type APIKeySuite struct {
+	suite.Suite
+	uc             *UC
+	mockAPIKeyRepo *mocks.APIKeyRepo
+	mockUserRepo   *mocks.UserRepo
+}
+
+func TestAPIKeySuite(t *testing.T) {
+	suite.Run(t, new(APIKeySuite))
+}
+
+func (s *APIKeySuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *APIKeySuite) SetupTest() {
+	s.mockAPIKeyRepo = mocks.NewAPIKeyRepo(s.T())
+	s.mockUserRepo = mocks.NewUserRepo(s.T())
+	s.uc = New(s.mockAPIKeyRepo, s.mockUserRepo)
+}
+
+func (s *APIKeySuite) TearDownTest() {
+	s.mockAPIKeyRepo.AssertExpectations(s.T())
+	s.mockUserRepo.AssertExpectations(s.T())
+}
+
+func (s *APIKeySuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *APIKeySuite, desc string){
+		"when no error, store hash of key": create_NoError_StoreHashOfKey,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_StoreHashOfKey(s *APIKeySuite, desc string) {
+	// prepare mock data
+	input := domain.APIKey{Name: "spreadsheet", Scopes: []string{domain.ScopeTransactionsRead}}
+	created := domain.APIKey{ID: 2, UserID: 1, Name: "spreadsheet", Prefix: "etk_12345678", Scopes: []string{domain.ScopeTransactionsRead}}
+
+	// prepare mock service
+	var stored domain.APIKey
+	var keyHash string
+	s.mockAPIKeyRepo.On("Create", mockCtx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(1).(domain.APIKey)
+			keyHash = args.String(2)
+		}).
+		Return(int64(2), nil).Once()
+	s.mockAPIKeyRepo.On("GetByIDAndUserID", mockCtx, int64(2), int64(1)).Return(created, nil).Once()
+
+	// action, assertion
+	key, rawKey, err := s.uc.Create(mockCtx, input, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(created, key, desc)
+	s.Require().True(strings.HasPrefix(rawKey, keyPrefix), desc)
+	s.Require().Equal(hashKey(rawKey), keyHash, desc)
+	s.Require().NotEqual(rawKey, keyHash, desc)
+	s.Require().Equal(int64(1), stored.UserID, desc)
+	s.Require().Equal(rawKey[:prefixLen], stored.Prefix, desc)
+}
+
+func (s *APIKeySuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *APIKeySuite, desc string){
+		"when no error, delete successfully":   delete_NoError_DeleteSuccessfully,
+		"when key of other user, return error": delete_OtherUser_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NoError_DeleteSuccessfully(s *APIKeySuite, desc string) {
+	s.mockAPIKeyRepo.On("GetByIDAndUserID", mockCtx, int64(2), int64(1)).Return(domain.APIKey{ID: 2, UserID: 1}, nil).Once()
+	s.mockAPIKeyRepo.On("Delete", mockCtx, int64(2)).Return(nil).Once()
+
+	err := s.uc.Delete(mockCtx, 2, 1)
+	s.Require().NoError(err, desc)
+}
+
+func delete_OtherUser_ReturnError(s *APIKeySuite, desc string) {
+	s.mockAPIKeyRepo.On("GetByIDAndUserID", mockCtx, int64(2), int64(1)).Return(domain.APIKey{}, domain.ErrAPIKeyNotFound).Once()
+
+	err := s.uc.Delete(mockCtx, 2, 1)
+	s.Require().ErrorIs(err, domain.ErrAPIKeyNotFound, desc)
+}
+
+func (s *APIKeySuite) TestAuthenticate() {
+	for scenario, fn := range map[string]func(s *APIKeySuite, desc string){
+		"when never used, update last used":         authenticate_NeverUsed_UpdateLastUsed,
+		"when recently used, dont update last used": authenticate_RecentlyUsed_DontUpdateLastUsed,
+		"when key expired, return error":            authenticate_Expired_ReturnError,
+		"when key not found, return error":          authenticate_NotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func authenticate_NeverUsed_UpdateLastUsed(s *APIKeySuite, desc string) {
+	// prepare mock data
+	expiresAt := time.Now().Add(time.Hour)
+	key := domain.APIKey{ID: 2, UserID: 1, Scopes: []string{domain.ScopeChartsRead}, ExpiresAt: &expiresAt}
+	user := domain.User{ID: 1, Name: "username", Email: "email.com"}
+
+	// prepare mock service
+	s.mockAPIKeyRepo.On("GetByKeyHash", mockCtx, hashKey("etk_key")).Return(key, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(1)).Return(user, nil).Once()
+	s.mockAPIKeyRepo.On("UpdateLastUsedAt", mockCtx, int64(2)).Return(nil).Once()
+
+	// action, assertion
+	resUser, resKey, err := s.uc.Authenticate(mockCtx, "etk_key")
+	s.Require().NoError(err, desc)
+	s.Require().Equal(user, resUser, desc)
+	s.Require().Equal(key, resKey, desc)
+}
+
+func authenticate_RecentlyUsed_DontUpdateLastUsed(s *APIKeySuite, desc string) {
+	// prepare mock data
+	lastUsedAt := time.Now()
+	key := domain.APIKey{ID: 2, UserID: 1, LastUsedAt: &lastUsedAt}
+	user := domain.User{ID: 1}
+
+	// prepare mock service
+	s.mockAPIKeyRepo.On("GetByKeyHash", mockCtx, hashKey("etk_key")).Return(key, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(1)).Return(user, nil).Once()
+
+	// action, assertion
+	_, _, err := s.uc.Authenticate(mockCtx, "etk_key")
+	s.Require().NoError(err, desc)
+}
+
+func authenticate_Expired_ReturnError(s *APIKeySuite, desc string) {
+	// prepare mock data
+	expiresAt := time.Now().Add(-time.Hour)
+	key := domain.APIKey{ID: 2, UserID: 1, ExpiresAt: &expiresAt}
+
+	// prepare mock service
+	s.mockAPIKeyRepo.On("GetByKeyHash", mockCtx, hashKey("etk_key")).Return(key, nil).Once()
+
+	// action, assertion
+	_, _, err := s.uc.Authenticate(mockCtx, "etk_key")
+	s.Require().ErrorIs(err, domain.ErrAPIKeyNotFound, desc)
+}
+
+func authenticate_NotFound_ReturnError(s *APIKeySuite, desc string) {
+	s.mockAPIKeyRepo.On("GetByKeyHash", mockCtx, hashKey("etk_key")).Return(domain.APIKey{}, domain.ErrAPIKeyNotFound).Once()
+
+	_, _, err := s.uc.Authenticate(mockCtx, "etk_key")
+	s.Require().ErrorIs(err, domain.ErrAPIKeyNotFound, desc)
+}
@@ -0,0 +1,107 @@
+package budget
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "usecase/budget"
+)
+
+type UC struct {
+	Budget      interfaces.BudgetRepo
+	MainCateg   interfaces.MainCategRepo
+	Transaction interfaces.TransactionRepo
+}
+
+func New(b interfaces.BudgetRepo, m interfaces.MainCategRepo, t interfaces.TransactionRepo) *UC {
+	return &UC{
+		Budget:      b,
+		MainCateg:   m,
+		Transaction: t,
+	}
+}
+
+func (u *UC) Set(ctx context.Context, budget domain.Budget, userID int64) error {
+	// check if the main category exists
+	mainCateg, err := u.MainCateg.GetByID(budget.MainCateg.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	// budget only makes sense on spending
+	if mainCateg.Type != domain.TransactionTypeExpense {
+		logger.Error("Set Budget failed", "package", packageName, "err", domain.ErrBudgetCategNotExpense)
+		return domain.ErrBudgetCategNotExpense
+	}
+
+	return u.Budget.Upsert(ctx, budget, userID)
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.Budget, error) {
+	return u.Budget.GetAll(ctx, userID)
+}
+
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.Budget.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return u.Budget.Delete(ctx, id)
+}
+
+func (u *UC) GetStatus(ctx context.Context, month time.Time, userID int64) ([]domain.BudgetStatus, error) {
+	budgets, err := u.Budget.GetAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(budgets) == 0 {
+		return []domain.BudgetStatus{}, nil
+	}
+
+	firstDay := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	dateRange := domain.ChartDateRange{
+		Start: firstDay,
+		End:   firstDay.AddDate(0, 1, -1),
+	}
+
+	sums, err := u.Transaction.GetSumByMainCateg(ctx, dateRange, domain.TransactionTypeExpense, nil, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mainCategIDToSum := make(map[int64]domain.MainCategSum, len(sums))
+	for _, s := range sums {
+		mainCategIDToSum[s.MainCateg.ID] = s
+	}
+
+	statuses := make([]domain.BudgetStatus, 0, len(budgets))
+	for _, b := range budgets {
+		sum := mainCategIDToSum[b.MainCateg.ID]
+		spent := sum.Sum
+
+		var percentUsed float64
+		if b.Amount > 0 {
+			percentUsed = math.Round(spent/b.Amount*10000) / 100
+		}
+
+		statuses = append(statuses, domain.BudgetStatus{
+			Budget:       b,
+			Spent:        spent,
+			Remaining:    b.Amount - spent,
+			PercentUsed:  percentUsed,
+			IsOverBudget: spent > b.Amount,
+			Unconverted:  sum.Unconverted,
+		})
+	}
+
+	return statuses, nil
+}
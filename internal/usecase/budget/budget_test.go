@@ -0,0 +1,164 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type BudgetSuite struct {
+	suite.Suite
+	uc                  *UC
+	mockBudgetRepo      *mocks.BudgetRepo
+	mockMainCategRepo   *mocks.MainCategRepo
+	mockTransactionRepo *mocks.TransactionRepo
+}
+
+func TestBudgetSuite(t *testing.T) {
+	suite.Run(t, new(BudgetSuite))
+}
+
+func (s *BudgetSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *BudgetSuite) SetupTest() {
+	s.mockBudgetRepo = mocks.NewBudgetRepo(s.T())
+	s.mockMainCategRepo = mocks.NewMainCategRepo(s.T())
+	s.mockTransactionRepo = mocks.NewTransactionRepo(s.T())
+	s.uc = New(s.mockBudgetRepo, s.mockMainCategRepo, s.mockTransactionRepo)
+}
+
+func (s *BudgetSuite) TearDownTest() {
+	s.mockBudgetRepo.AssertExpectations(s.T())
+	s.mockMainCategRepo.AssertExpectations(s.T())
+	s.mockTransactionRepo.AssertExpectations(s.T())
+}
+
+func (s *BudgetSuite) TestSet() {
+	for scenario, fn := range map[string]func(s *BudgetSuite, desc string){
+		"when no error, set successfully":                 set_NoError_SetSuccessfully,
+		"when main category not found, return error":      set_MainCategNotFound_ReturnError,
+		"when main category is income type, return error": set_IncomeMainCateg_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func set_NoError_SetSuccessfully(s *BudgetSuite, desc string) {
+	budget := domain.Budget{MainCateg: domain.MainCateg{ID: 1}, Amount: 500}
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockBudgetRepo.On("Upsert", mockCtx, budget, int64(1)).Return(nil).Once()
+
+	err := s.uc.Set(mockCtx, budget, 1)
+	s.Require().NoError(err, desc)
+}
+
+func set_MainCategNotFound_ReturnError(s *BudgetSuite, desc string) {
+	budget := domain.Budget{MainCateg: domain.MainCateg{ID: 1}, Amount: 500}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(nil, domain.ErrMainCategNotFound).Once()
+
+	err := s.uc.Set(mockCtx, budget, 1)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotFound, desc)
+}
+
+func set_IncomeMainCateg_ReturnError(s *BudgetSuite, desc string) {
+	budget := domain.Budget{MainCateg: domain.MainCateg{ID: 1}, Amount: 500}
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeIncome}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+
+	err := s.uc.Set(mockCtx, budget, 1)
+	s.Require().ErrorIs(err, domain.ErrBudgetCategNotExpense, desc)
+}
+
+func (s *BudgetSuite) TestGetStatus() {
+	for scenario, fn := range map[string]func(s *BudgetSuite, desc string){
+		"when no error, return status of each budget": getStatus_NoError_ReturnStatus,
+		"when no budget, return empty list":           getStatus_NoBudget_ReturnEmptyList,
+		"when get sum fail, return error":             getStatus_GetSumFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getStatus_NoError_ReturnStatus(s *BudgetSuite, desc string) {
+	month := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	dateRange := domain.ChartDateRange{
+		Start: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+	}
+
+	budgets := []domain.Budget{
+		{ID: 1, MainCateg: domain.MainCateg{ID: 1, Name: "food"}, Amount: 300},
+		{ID: 2, MainCateg: domain.MainCateg{ID: 2, Name: "clothes"}, Amount: 100},
+		{ID: 3, MainCateg: domain.MainCateg{ID: 3, Name: "travel"}, Amount: 1000},
+	}
+	sums := []domain.MainCategSum{
+		{MainCateg: domain.MainCateg{ID: 1, Name: "food"}, Sum: 100},
+		{MainCateg: domain.MainCateg{ID: 2, Name: "clothes"}, Sum: 150},
+		{MainCateg: domain.MainCateg{ID: 4, Name: "rent"}, Sum: 999},
+	}
+
+	s.mockBudgetRepo.On("GetAll", mockCtx, int64(1)).Return(budgets, nil).Once()
+	s.mockTransactionRepo.On("GetSumByMainCateg", mockCtx, dateRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).Return(sums, nil).Once()
+
+	expResult := []domain.BudgetStatus{
+		{Budget: budgets[0], Spent: 100, Remaining: 200, PercentUsed: 33.33, IsOverBudget: false},
+		{Budget: budgets[1], Spent: 150, Remaining: -50, PercentUsed: 150, IsOverBudget: true},
+		{Budget: budgets[2], Spent: 0, Remaining: 1000, PercentUsed: 0, IsOverBudget: false},
+	}
+
+	result, err := s.uc.GetStatus(mockCtx, month, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getStatus_NoBudget_ReturnEmptyList(s *BudgetSuite, desc string) {
+	s.mockBudgetRepo.On("GetAll", mockCtx, int64(1)).Return(nil, nil).Once()
+
+	result, err := s.uc.GetStatus(mockCtx, time.Now(), 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.BudgetStatus{}, result, desc)
+}
+
+func getStatus_GetSumFail_ReturnError(s *BudgetSuite, desc string) {
+	month := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	dateRange := domain.ChartDateRange{
+		Start: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+	}
+	budgets := []domain.Budget{
+		{ID: 1, MainCateg: domain.MainCateg{ID: 1, Name: "food"}, Amount: 300},
+	}
+	mockErr := errors.New("get sum fail")
+
+	s.mockBudgetRepo.On("GetAll", mockCtx, int64(1)).Return(budgets, nil).Once()
+	s.mockTransactionRepo.On("GetSumByMainCateg", mockCtx, dateRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).Return(nil, mockErr).Once()
+
+	result, err := s.uc.GetStatus(mockCtx, month, 1)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Nil(result, desc)
+}
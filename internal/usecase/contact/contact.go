@@ -0,0 +1,55 @@
+package contact
+
+import (
+	"context"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+)
+
+type UC struct {
+	Contact interfaces.ContactRepo
+}
+
+func New(c interfaces.ContactRepo) *UC {
+	return &UC{
+		Contact: c,
+	}
+}
+
+func (u *UC) Create(ctx context.Context, contact domain.Contact, userID int64) error {
+	return u.Contact.Create(ctx, contact, userID)
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.Contact, error) {
+	return u.Contact.GetAll(ctx, userID)
+}
+
+func (u *UC) Update(ctx context.Context, contact domain.Contact, userID int64) error {
+	// check permission
+	if _, err := u.Contact.GetByIDAndUserID(ctx, contact.ID, userID); err != nil {
+		return err
+	}
+
+	return u.Contact.Update(ctx, contact)
+}
+
+// Delete deletes the contact, which is refused if the contact has shared transactions or settlements,
+// because deleting them would silently change the balances of the others
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.Contact.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	inUse, err := u.Contact.IsInUse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if inUse {
+		return domain.ErrContactInUse
+	}
+
+	return u.Contact.Delete(ctx, id)
+}
@@ -0,0 +1,77 @@
+package contact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type ContactSuite struct {
+	suite.Suite
+	uc              *UC
+	mockContactRepo *mocks.ContactRepo
+}
+
+func TestContactSuite(t *testing.T) {
+	suite.Run(t, new(ContactSuite))
+}
+
+func (s *ContactSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ContactSuite) SetupTest() {
+	s.mockContactRepo = mocks.NewContactRepo(s.T())
+	s.uc = New(s.mockContactRepo)
+}
+
+func (s *ContactSuite) TearDownTest() {
+	s.mockContactRepo.AssertExpectations(s.T())
+}
+
+func (s *ContactSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *ContactSuite, desc string){
+		"when not in use, delete successfully": delete_NotInUse_DeleteSuccessfully,
+		"when in use, return error":            delete_InUse_ReturnError,
+		"when contact not found, return error": delete_ContactNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NotInUse_DeleteSuccessfully(s *ContactSuite, desc string) {
+	s.mockContactRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Contact{ID: 1}, nil).Once()
+	s.mockContactRepo.On("IsInUse", mockCtx, int64(1)).Return(false, nil).Once()
+	s.mockContactRepo.On("Delete", mockCtx, int64(1)).Return(nil).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+}
+
+func delete_InUse_ReturnError(s *ContactSuite, desc string) {
+	s.mockContactRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Contact{ID: 1}, nil).Once()
+	s.mockContactRepo.On("IsInUse", mockCtx, int64(1)).Return(true, nil).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrContactInUse, desc)
+}
+
+func delete_ContactNotFound_ReturnError(s *ContactSuite, desc string) {
+	s.mockContactRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Contact{}, domain.ErrContactNotFound).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrContactNotFound, desc)
+}
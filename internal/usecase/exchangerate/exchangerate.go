@@ -0,0 +1,24 @@
+package exchangerate
+
+import (
+	"context"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+)
+
+type UC struct {
+	ExchangeRate interfaces.ExchangeRateRepo
+}
+
+func New(e interfaces.ExchangeRateRepo) *UC {
+	return &UC{ExchangeRate: e}
+}
+
+func (u *UC) Import(ctx context.Context, rates []domain.ExchangeRate) error {
+	return u.ExchangeRate.Upsert(ctx, rates)
+}
+
+func (u *UC) GetAll(ctx context.Context, from, to string) ([]domain.ExchangeRate, error) {
+	return u.ExchangeRate.GetAll(ctx, from, to)
+}
@@ -0,0 +1,346 @@
+package importtrans
+
+import (
+	"context"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "usecase/importtrans"
+
+	// defaultSubCategName is used when the row doesn't specify a sub category
+	defaultSubCategName = "others"
+)
+
+type UC struct {
+	Transaction interfaces.TransactionRepo
+	MainCateg   interfaces.MainCategRepo
+	SubCateg    interfaces.SubCategRepo
+	Icon        interfaces.IconRepo
+	Rule        interfaces.RuleRepo
+}
+
+func New(t interfaces.TransactionRepo,
+	m interfaces.MainCategRepo,
+	s interfaces.SubCategRepo,
+	i interfaces.IconRepo,
+	rl interfaces.RuleRepo) *UC {
+	return &UC{
+		Transaction: t,
+		MainCateg:   m,
+		SubCateg:    s,
+		Icon:        i,
+		Rule:        rl,
+	}
+}
+
+func (u *UC) Import(ctx context.Context, rows []domain.ImportTransRow, dryRun bool, userID int64) (domain.ImportTransResult, error) {
+	// viewers can only check the rows
+	if !dryRun && !ctxutil.GetLedgerRole(ctx).CanWrite() {
+		return domain.ImportTransResult{}, domain.ErrLedgerPermissionDenied
+	}
+
+	result := domain.ImportTransResult{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]domain.ImportTransRowResult, 0, len(rows)),
+	}
+
+	status := domain.ImportRowStatusValid
+	if !dryRun {
+		status = domain.ImportRowStatusImported
+	}
+
+	r := newResolver(u, userID, dryRun)
+	trans := make([]domain.CreateTransactionInput, 0, len(rows))
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			result.Invalid++
+			result.Rows = append(result.Rows, domain.ImportTransRowResult{
+				Line:   row.Line,
+				Status: domain.ImportRowStatusInvalid,
+				Errors: row.Errors,
+			})
+			continue
+		}
+
+		// the row without category is categorized by the rules of the user
+		if row.MainCategName == "" {
+			t, ruleID, err := r.categorizeByRules(ctx, row)
+			if err != nil {
+				return domain.ImportTransResult{}, err
+			}
+
+			if ruleID == 0 {
+				result.Invalid++
+				result.Rows = append(result.Rows, domain.ImportTransRowResult{
+					Line:   row.Line,
+					Status: domain.ImportRowStatusInvalid,
+					Errors: map[string]string{"main_category": "Main category can't be empty when no rule matches"},
+				})
+				continue
+			}
+
+			result.Imported++
+			result.Rows = append(result.Rows, domain.ImportTransRowResult{
+				Line:        row.Line,
+				Status:      status,
+				MainCategID: t.MainCategID,
+				SubCategID:  t.SubCategID,
+				RuleID:      ruleID,
+			})
+			trans = append(trans, t)
+			continue
+		}
+
+		mainCateg, isNewMain, err := r.mainCateg(ctx, row.MainCategName, row.Type)
+		if err != nil {
+			return domain.ImportTransResult{}, err
+		}
+
+		subCategName := row.SubCategName
+		if subCategName == "" {
+			subCategName = defaultSubCategName
+		}
+
+		subCategID, isNewSub, err := r.subCateg(subCategName, mainCateg.ID)
+		if err != nil {
+			return domain.ImportTransResult{}, err
+		}
+
+		result.Imported++
+		result.Rows = append(result.Rows, domain.ImportTransRowResult{
+			Line:         row.Line,
+			Status:       status,
+			MainCategID:  mainCateg.ID,
+			SubCategID:   subCategID,
+			NewMainCateg: isNewMain,
+			NewSubCateg:  isNewSub,
+		})
+
+		trans = append(trans, domain.CreateTransactionInput{
+			UserID:      userID,
+			Type:        row.Type,
+			MainCategID: mainCateg.ID,
+			SubCategID:  subCategID,
+			Price:       row.Price,
+			Currency:    row.Currency,
+			Date:        row.Date,
+			Note:        row.Note,
+		})
+	}
+
+	if dryRun || len(trans) == 0 {
+		// the new categories are not created, so they have no id
+		for i := range result.Rows {
+			result.Rows[i].MainCategID = max(result.Rows[i].MainCategID, 0)
+			result.Rows[i].SubCategID = max(result.Rows[i].SubCategID, 0)
+		}
+
+		return result, nil
+	}
+
+	input := domain.ImportTransInput{
+		MainCategs: r.newMainCategs,
+		SubCategs:  r.newSubCategs,
+		Trans:      trans,
+	}
+	ids, err := u.Transaction.Import(ctx, input, userID)
+	if err != nil {
+		return domain.ImportTransResult{}, err
+	}
+
+	for i, row := range result.Rows {
+		if row.MainCategID < 0 {
+			result.Rows[i].MainCategID = ids[row.MainCategID]
+		}
+		if row.SubCategID < 0 {
+			result.Rows[i].SubCategID = ids[row.SubCategID]
+		}
+	}
+
+	return result, nil
+}
+
+// resolver finds categories by name, and collects the missing ones to create along with the transactions.
+// The missing categories get negative placeholder ids, and are cached like the existing ones,
+// so each category is looked up at most once per import.
+type resolver struct {
+	uc       *UC
+	userID   int64
+	dryRun   bool
+	iconData string
+
+	// type -> lower case name -> main category
+	mainCategs map[domain.TransactionType]map[string]domain.MainCateg
+	// main category id -> lower case name -> sub category id
+	subCategs map[int64]map[string]int64
+	// rules is loaded at the first row without category
+	rules *domain.RuleSet
+
+	newMainCategs []domain.MainCateg
+	newSubCategs  []domain.SubCateg
+	// lastPlaceholderID is the placeholder id of the last new category
+	lastPlaceholderID int64
+}
+
+func newResolver(uc *UC, userID int64, dryRun bool) *resolver {
+	return &resolver{
+		uc:         uc,
+		userID:     userID,
+		dryRun:     dryRun,
+		mainCategs: map[domain.TransactionType]map[string]domain.MainCateg{},
+		subCategs:  map[int64]map[string]int64{},
+	}
+}
+
+func (r *resolver) mainCateg(ctx context.Context, name string, transType domain.TransactionType) (domain.MainCateg, bool, error) {
+	key := strings.ToLower(name)
+
+	if _, ok := r.mainCategs[transType]; !ok {
+		if err := r.loadMainCategs(ctx, transType); err != nil {
+			return domain.MainCateg{}, false, err
+		}
+	}
+
+	if categ, ok := r.mainCategs[transType][key]; ok {
+		return categ, false, nil
+	}
+
+	categ := domain.MainCateg{
+		ID:       r.nextPlaceholderID(),
+		Name:     name,
+		Type:     transType,
+		IconType: domain.IconTypeDefault,
+	}
+
+	if !r.dryRun {
+		iconData, err := r.defaultIconData()
+		if err != nil {
+			return domain.MainCateg{}, false, err
+		}
+		categ.IconData = iconData
+	}
+
+	r.mainCategs[transType][key] = categ
+	// the main category doesn't exist yet, so it has no sub categories
+	r.subCategs[categ.ID] = map[string]int64{}
+	r.newMainCategs = append(r.newMainCategs, categ)
+
+	return categ, true, nil
+}
+
+func (r *resolver) subCateg(name string, mainCategID int64) (int64, bool, error) {
+	key := strings.ToLower(name)
+
+	if _, ok := r.subCategs[mainCategID]; !ok {
+		if err := r.loadSubCategs(mainCategID); err != nil {
+			return 0, false, err
+		}
+	}
+
+	if id, ok := r.subCategs[mainCategID][key]; ok {
+		return id, false, nil
+	}
+
+	categ := domain.SubCateg{
+		ID:          r.nextPlaceholderID(),
+		Name:        name,
+		MainCategID: mainCategID,
+	}
+	r.subCategs[mainCategID][key] = categ.ID
+	r.newSubCategs = append(r.newSubCategs, categ)
+
+	return categ.ID, true, nil
+}
+
+func (r *resolver) nextPlaceholderID() int64 {
+	r.lastPlaceholderID--
+	return r.lastPlaceholderID
+}
+
+// categorizeByRules returns the transaction of the row categorized by the first matched rule, and the id of the rule.
+// The id is 0 if no rule matches.
+func (r *resolver) categorizeByRules(ctx context.Context, row domain.ImportTransRow) (domain.CreateTransactionInput, int64, error) {
+	if r.rules == nil {
+		rules, err := r.uc.Rule.GetAll(ctx, r.userID)
+		if err != nil {
+			return domain.CreateTransactionInput{}, 0, err
+		}
+
+		set := domain.NewRuleSet(rules)
+		r.rules = &set
+	}
+
+	t := domain.CreateTransactionInput{
+		UserID:   r.userID,
+		Type:     row.Type,
+		Price:    row.Price,
+		Currency: row.Currency,
+		Date:     row.Date,
+		Note:     row.Note,
+	}
+
+	rule, ok := r.rules.Categorize(&t)
+	if !ok {
+		return domain.CreateTransactionInput{}, 0, nil
+	}
+
+	return t, rule.ID, nil
+}
+
+func (r *resolver) loadMainCategs(ctx context.Context, transType domain.TransactionType) error {
+	// archived categories are matched as well, imported data is usually historical
+	categs, err := r.uc.MainCateg.GetAll(ctx, r.userID, transType, true)
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]domain.MainCateg, len(categs))
+	for _, c := range categs {
+		m[strings.ToLower(c.Name)] = c
+	}
+	r.mainCategs[transType] = m
+
+	return nil
+}
+
+func (r *resolver) loadSubCategs(mainCategID int64) error {
+	categs, err := r.uc.SubCateg.GetByMainCategID(r.userID, mainCategID, true)
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]int64, len(categs))
+	for _, c := range categs {
+		m[strings.ToLower(c.Name)] = c.ID
+	}
+	r.subCategs[mainCategID] = m
+
+	return nil
+}
+
+// defaultIconData returns the icon of new main categories, which is the first default icon
+func (r *resolver) defaultIconData() (string, error) {
+	if r.iconData != "" {
+		return r.iconData, nil
+	}
+
+	icons, err := r.uc.Icon.List()
+	if err != nil {
+		return "", err
+	}
+
+	if len(icons) == 0 {
+		logger.Error("no default icon", "package", packageName, "err", domain.ErrNoDefaultIcon)
+		return "", domain.ErrNoDefaultIcon
+	}
+
+	r.iconData = icons[0].URL
+	return r.iconData, nil
+}
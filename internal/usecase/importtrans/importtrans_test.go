@@ -0,0 +1,283 @@
+package importtrans
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx  = context.Background()
+	mockDate = time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+)
+
+type ImportTransSuite struct {
+	suite.Suite
+	uc                  *UC
+	mockTransactionRepo *mocks.TransactionRepo
+	mockMainCategRepo   *mocks.MainCategRepo
+	mockSubCategRepo    *mocks.SubCategRepo
+	mockIconRepo        *mocks.IconRepo
+	mockRuleRepo        *mocks.RuleRepo
+}
+
+func TestImportTransSuite(t *testing.T) {
+	suite.Run(t, new(ImportTransSuite))
+}
+
+func (s *ImportTransSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ImportTransSuite) SetupTest() {
+	s.mockTransactionRepo = mocks.NewTransactionRepo(s.T())
+	s.mockMainCategRepo = mocks.NewMainCategRepo(s.T())
+	s.mockSubCategRepo = mocks.NewSubCategRepo(s.T())
+	s.mockIconRepo = mocks.NewIconRepo(s.T())
+	s.mockRuleRepo = mocks.NewRuleRepo(s.T())
+	s.uc = New(s.mockTransactionRepo, s.mockMainCategRepo, s.mockSubCategRepo, s.mockIconRepo, s.mockRuleRepo)
+}
+
+func (s *ImportTransSuite) TearDownTest() {
+	s.mockTransactionRepo.AssertExpectations(s.T())
+	s.mockMainCategRepo.AssertExpectations(s.T())
+	s.mockSubCategRepo.AssertExpectations(s.T())
+	s.mockIconRepo.AssertExpectations(s.T())
+	s.mockRuleRepo.AssertExpectations(s.T())
+}
+
+func (s *ImportTransSuite) TestImport() {
+	for scenario, fn := range map[string]func(s *ImportTransSuite, desc string){
+		"when categories exist, import all rows":         import_CategsExist_ImportAllRows,
+		"when categories not exist, create categories":   import_CategsNotExist_CreateCategs,
+		"when dry run, not write anything":               import_DryRun_NotWriteAnything,
+		"when row is invalid, skip the row":              import_InvalidRow_SkipRow,
+		"when no default icon, return error":             import_NoDefaultIcon_ReturnError,
+		"when import fail, return error":                 import_ImportFail_ReturnError,
+		"when all rows are invalid, not call import":     import_AllRowsInvalid_NotCallImport,
+		"when row without category, categorize by rules": import_NoCateg_CategorizeByRules,
+		"when viewer not dry run, return error":          import_ViewerNotDryRun_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func import_CategsExist_ImportAllRows(s *ImportTransSuite, desc string) {
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, MainCategName: "Food", SubCategName: "lunch", Price: 100, Date: mockDate, Note: "noodle"},
+		{Line: 3, Type: domain.TransactionTypeExpense, MainCategName: "food", Price: 50, Date: mockDate},
+	}
+	mainCategs := []domain.MainCateg{{ID: 1, Name: "food", Type: domain.TransactionTypeExpense}}
+	subCategs := []*domain.SubCateg{{ID: 2, Name: "Lunch", MainCategID: 1}, {ID: 3, Name: "others", MainCategID: 1}}
+	trans := []domain.CreateTransactionInput{
+		{UserID: 1, Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 100, Date: mockDate, Note: "noodle"},
+		{UserID: 1, Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 3, Price: 50, Date: mockDate},
+	}
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, int64(1), domain.TransactionTypeExpense, true).Return(mainCategs, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", int64(1), int64(1), true).Return(subCategs, nil).Once()
+	s.mockTransactionRepo.On("Import", mockCtx, domain.ImportTransInput{Trans: trans}, int64(1)).Return(map[int64]int64{}, nil).Once()
+
+	result, err := s.uc.Import(mockCtx, rows, false, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.ImportTransResult{
+		Total:    2,
+		Imported: 2,
+		Rows: []domain.ImportTransRowResult{
+			{Line: 2, Status: domain.ImportRowStatusImported, MainCategID: 1, SubCategID: 2},
+			{Line: 3, Status: domain.ImportRowStatusImported, MainCategID: 1, SubCategID: 3},
+		},
+	}, result, desc)
+}
+
+func import_CategsNotExist_CreateCategs(s *ImportTransSuite, desc string) {
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeIncome, MainCategName: "salary", SubCategName: "bonus", Price: 1000, Date: mockDate},
+		{Line: 3, Type: domain.TransactionTypeIncome, MainCategName: "Salary", SubCategName: "Bonus", Price: 2000, Date: mockDate},
+	}
+	icons := []domain.DefaultIcon{{ID: 1, URL: "url1"}, {ID: 2, URL: "url2"}}
+	// the new categories refer to each other by the placeholder ids until they're created
+	input := domain.ImportTransInput{
+		MainCategs: []domain.MainCateg{{ID: -1, Name: "salary", Type: domain.TransactionTypeIncome, IconType: domain.IconTypeDefault, IconData: "url1"}},
+		SubCategs:  []domain.SubCateg{{ID: -2, Name: "bonus", MainCategID: -1}},
+		Trans: []domain.CreateTransactionInput{
+			{UserID: 1, Type: domain.TransactionTypeIncome, MainCategID: -1, SubCategID: -2, Price: 1000, Date: mockDate},
+			{UserID: 1, Type: domain.TransactionTypeIncome, MainCategID: -1, SubCategID: -2, Price: 2000, Date: mockDate},
+		},
+	}
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, int64(1), domain.TransactionTypeIncome, true).Return([]domain.MainCateg{}, nil).Once()
+	s.mockIconRepo.On("List").Return(icons, nil).Once()
+	s.mockTransactionRepo.On("Import", mockCtx, input, int64(1)).Return(map[int64]int64{-1: 1, -2: 2}, nil).Once()
+
+	result, err := s.uc.Import(mockCtx, rows, false, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.ImportTransResult{
+		Total:    2,
+		Imported: 2,
+		Rows: []domain.ImportTransRowResult{
+			{Line: 2, Status: domain.ImportRowStatusImported, MainCategID: 1, SubCategID: 2, NewMainCateg: true, NewSubCateg: true},
+			{Line: 3, Status: domain.ImportRowStatusImported, MainCategID: 1, SubCategID: 2},
+		},
+	}, result, desc)
+}
+
+func import_NoCateg_CategorizeByRules(s *ImportTransSuite, desc string) {
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, Price: 30, Date: mockDate, Note: "Uber trip"},
+		{Line: 3, Type: domain.TransactionTypeExpense, Price: 20, Date: mockDate, Note: "unknown shop"},
+		{Line: 4, Type: domain.TransactionTypeExpense, Price: 15, Date: mockDate, Note: "uber eats"},
+	}
+	rules := []domain.Rule{
+		{ID: 5, Type: domain.TransactionTypeExpense, NoteContains: "uber", MainCateg: domain.MainCateg{ID: 1}, SubCateg: domain.SubCateg{ID: 2}, Tags: []domain.Tag{{ID: 7}}},
+	}
+	trans := []domain.CreateTransactionInput{
+		{UserID: 1, Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 30, Date: mockDate, Note: "Uber trip", TagIDs: []int64{7}},
+		{UserID: 1, Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 15, Date: mockDate, Note: "uber eats", TagIDs: []int64{7}},
+	}
+
+	// the rules are loaded once for all rows
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return(rules, nil).Once()
+	s.mockTransactionRepo.On("Import", mockCtx, domain.ImportTransInput{Trans: trans}, int64(1)).Return(map[int64]int64{}, nil).Once()
+
+	result, err := s.uc.Import(mockCtx, rows, false, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.ImportTransResult{
+		Total:    3,
+		Imported: 2,
+		Invalid:  1,
+		Rows: []domain.ImportTransRowResult{
+			{Line: 2, Status: domain.ImportRowStatusImported, MainCategID: 1, SubCategID: 2, RuleID: 5},
+			{Line: 3, Status: domain.ImportRowStatusInvalid, Errors: map[string]string{"main_category": "Main category can't be empty when no rule matches"}},
+			{Line: 4, Status: domain.ImportRowStatusImported, MainCategID: 1, SubCategID: 2, RuleID: 5},
+		},
+	}, result, desc)
+}
+
+func import_DryRun_NotWriteAnything(s *ImportTransSuite, desc string) {
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, MainCategName: "food", SubCategName: "dinner", Price: 100, Date: mockDate},
+		{Line: 3, Type: domain.TransactionTypeExpense, MainCategName: "travel", SubCategName: "hotel", Price: 500, Date: mockDate},
+	}
+	mainCategs := []domain.MainCateg{{ID: 1, Name: "food", Type: domain.TransactionTypeExpense}}
+	subCategs := []*domain.SubCateg{{ID: 2, Name: "lunch", MainCategID: 1}}
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, int64(1), domain.TransactionTypeExpense, true).Return(mainCategs, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", int64(1), int64(1), true).Return(subCategs, nil).Once()
+
+	result, err := s.uc.Import(mockCtx, rows, true, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.ImportTransResult{
+		DryRun:   true,
+		Total:    2,
+		Imported: 2,
+		Rows: []domain.ImportTransRowResult{
+			{Line: 2, Status: domain.ImportRowStatusValid, MainCategID: 1, NewSubCateg: true},
+			{Line: 3, Status: domain.ImportRowStatusValid, NewMainCateg: true, NewSubCateg: true},
+		},
+	}, result, desc)
+}
+
+func import_InvalidRow_SkipRow(s *ImportTransSuite, desc string) {
+	rowErrs := map[string]string{"price": "Price must be a number"}
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, MainCategName: "food", SubCategName: "lunch", Price: 100, Date: mockDate},
+		{Line: 3, MainCategName: "food", Errors: rowErrs},
+	}
+	mainCategs := []domain.MainCateg{{ID: 1, Name: "food", Type: domain.TransactionTypeExpense}}
+	subCategs := []*domain.SubCateg{{ID: 2, Name: "lunch", MainCategID: 1}}
+	trans := []domain.CreateTransactionInput{
+		{UserID: 1, Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 100, Date: mockDate},
+	}
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, int64(1), domain.TransactionTypeExpense, true).Return(mainCategs, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", int64(1), int64(1), true).Return(subCategs, nil).Once()
+	s.mockTransactionRepo.On("Import", mockCtx, domain.ImportTransInput{Trans: trans}, int64(1)).Return(map[int64]int64{}, nil).Once()
+
+	result, err := s.uc.Import(mockCtx, rows, false, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.ImportTransResult{
+		Total:    2,
+		Imported: 1,
+		Invalid:  1,
+		Rows: []domain.ImportTransRowResult{
+			{Line: 2, Status: domain.ImportRowStatusImported, MainCategID: 1, SubCategID: 2},
+			{Line: 3, Status: domain.ImportRowStatusInvalid, Errors: rowErrs},
+		},
+	}, result, desc)
+}
+
+func import_NoDefaultIcon_ReturnError(s *ImportTransSuite, desc string) {
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, MainCategName: "food", Price: 100, Date: mockDate},
+	}
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, int64(1), domain.TransactionTypeExpense, true).Return([]domain.MainCateg{}, nil).Once()
+	s.mockIconRepo.On("List").Return([]domain.DefaultIcon{}, nil).Once()
+
+	result, err := s.uc.Import(mockCtx, rows, false, 1)
+	s.Require().ErrorIs(err, domain.ErrNoDefaultIcon, desc)
+	s.Require().Empty(result, desc)
+}
+
+func import_ImportFail_ReturnError(s *ImportTransSuite, desc string) {
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, MainCategName: "food", SubCategName: "lunch", Price: 100, Date: mockDate},
+	}
+	mainCategs := []domain.MainCateg{{ID: 1, Name: "food", Type: domain.TransactionTypeExpense}}
+	subCategs := []*domain.SubCateg{{ID: 2, Name: "lunch", MainCategID: 1}}
+	trans := []domain.CreateTransactionInput{
+		{UserID: 1, Type: domain.TransactionTypeExpense, MainCategID: 1, SubCategID: 2, Price: 100, Date: mockDate},
+	}
+	mockErr := errors.New("import fail")
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, int64(1), domain.TransactionTypeExpense, true).Return(mainCategs, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", int64(1), int64(1), true).Return(subCategs, nil).Once()
+	s.mockTransactionRepo.On("Import", mockCtx, domain.ImportTransInput{Trans: trans}, int64(1)).Return(nil, mockErr).Once()
+
+	result, err := s.uc.Import(mockCtx, rows, false, 1)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(result, desc)
+}
+
+func import_AllRowsInvalid_NotCallImport(s *ImportTransSuite, desc string) {
+	rowErrs := map[string]string{"date": "Date can't be empty"}
+	rows := []domain.ImportTransRow{
+		{Line: 2, Errors: rowErrs},
+	}
+
+	result, err := s.uc.Import(mockCtx, rows, false, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.ImportTransResult{
+		Total:   1,
+		Invalid: 1,
+		Rows: []domain.ImportTransRowResult{
+			{Line: 2, Status: domain.ImportRowStatusInvalid, Errors: rowErrs},
+		},
+	}, result, desc)
+}
+
+func import_ViewerNotDryRun_ReturnError(s *ImportTransSuite, desc string) {
+	rows := []domain.ImportTransRow{
+		{Line: 2, Type: domain.TransactionTypeExpense, MainCategName: "food", Price: 100, Date: mockDate},
+	}
+	req := ctxutil.SetLedger(httptest.NewRequest(http.MethodPost, "/", nil), &domain.Ledger{ID: 1, UserID: 1, Role: domain.LedgerRoleTypeViewer})
+
+	result, err := s.uc.Import(req.Context(), rows, false, 1)
+	s.Require().ErrorIs(err, domain.ErrLedgerPermissionDenied, desc)
+	s.Require().Empty(result, desc)
+}
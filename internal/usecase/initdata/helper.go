@@ -2,6 +2,8 @@ package initdata
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 )
@@ -44,7 +46,7 @@ func genAllSubCategs(data domain.InitData, categs []domain.MainCateg) []domain.S
 func genKeyToMainCategIDMap(categs []domain.MainCateg) map[string]int64 {
 	keyToMainCategID := make(map[string]int64)
 	for _, c := range categs {
-		key := fmt.Sprintf("%s:%s", c.Name, c.Type.ToString())
+		key := genMainCategKey(c.Name, c.Type)
 		keyToMainCategID[key] = c.ID
 	}
 
@@ -56,7 +58,7 @@ func genSubCategs(categs []domain.InitDataMainCateg, t domain.TransactionType, k
 	subCategs := make([]domain.SubCateg, 0, len(categs))
 
 	for _, c := range categs {
-		key := fmt.Sprintf("%s:%s", c.Name, t.ToString())
+		key := genMainCategKey(c.Name, t)
 		mainCategID, ok := keyToMainCategIDMap[key]
 		if !ok {
 			continue
@@ -72,3 +74,75 @@ func genSubCategs(categs []domain.InitDataMainCateg, t domain.TransactionType, k
 
 	return subCategs
 }
+
+// genMainCategKey generates the key of a main category, which is unique among the main categories of a user.
+// The name is lower-cased, because the unique index of the category names is case-insensitive.
+func genMainCategKey(name string, t domain.TransactionType) string {
+	return fmt.Sprintf("%s:%s", strings.ToLower(name), t.ToString())
+}
+
+// templateCateg is a main category of a template along with its type.
+type templateCateg struct {
+	domain.InitDataMainCateg
+	Type domain.TransactionType
+}
+
+func (c templateCateg) key() string {
+	return genMainCategKey(c.Name, c.Type)
+}
+
+// flattenTemplate lists the expense and income main categories of the template in one slice.
+func flattenTemplate(data domain.InitData) []templateCateg {
+	categs := make([]templateCateg, 0, len(data.Expense)+len(data.Income))
+	for _, c := range data.Expense {
+		categs = append(categs, templateCateg{InitDataMainCateg: c, Type: domain.TransactionTypeExpense})
+	}
+	for _, c := range data.Income {
+		categs = append(categs, templateCateg{InitDataMainCateg: c, Type: domain.TransactionTypeIncome})
+	}
+
+	return categs
+}
+
+// genNewMainCategs generates the main categories of the template which don't exist yet, with negative placeholder ids.
+// The categories with unknown or custom icons get the fallback icon.
+func genNewMainCategs(categs []templateCateg, existingKeyToID map[string]int64, idToIcon map[int64]domain.DefaultIcon) []domain.MainCateg {
+	var mainCategs []domain.MainCateg
+	seen := make(map[string]bool)
+	for _, c := range categs {
+		if _, ok := existingKeyToID[c.key()]; ok || seen[c.key()] {
+			continue
+		}
+		seen[c.key()] = true
+
+		icon, ok := idToIcon[c.Icon.ID]
+		if !ok {
+			icon = idToIcon[fallbackIconID]
+		}
+
+		mainCategs = append(mainCategs, domain.MainCateg{
+			ID:       -int64(len(mainCategs) + 1),
+			Name:     c.Name,
+			Type:     c.Type,
+			IconType: domain.IconTypeDefault,
+			IconData: icon.URL,
+		})
+	}
+
+	return mainCategs
+}
+
+// genIconIDs generates the sorted and unique icon ids used by the init data.
+func genIconIDs(data domain.InitData) []int64 {
+	var ids []int64
+	for _, categs := range [][]domain.InitDataMainCateg{data.Expense, data.Income} {
+		for _, c := range categs {
+			if c.Icon.ID > 0 {
+				ids = append(ids, c.Icon.ID)
+			}
+		}
+	}
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
@@ -2,9 +2,16 @@ package initdata
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+)
+
+const (
+	packageName = "usecase/initdata"
 )
 
 type UC struct {
@@ -28,126 +35,35 @@ func New(
 	}
 }
 
-// [food 1], [transportation 4], [utilities 9], [housing 3], [clothing 2], [entertainment 6], [gifts 7], [education 5], [insurance 10], [debt 11], [healthcare 8], [others 14]
-// [salary 12], [investment 15], [others 14]
 func (u *UC) List() (domain.InitData, error) {
-	iconIDs := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 14, 15}
-	idToIcon, err := u.icon.GetByIDs(iconIDs)
+	templ, err := loadTemplate(defaultTemplateKey)
 	if err != nil {
 		return domain.InitData{}, err
 	}
 
-	return domain.InitData{
-		Expense: []domain.InitDataMainCateg{
-			{
-				Name: "food",
-				Icon: idToIcon[1],
-				SubCategs: []string{
-					"breakfast", "brunch", "lunch", "dinner", "groceries", "drink", "snak",
-				},
-			},
-			{
-				Name: "transportation",
-				Icon: idToIcon[4],
-				SubCategs: []string{
-					"bus", "train", "MRT", "taxi", "uber", "gasoline", "parking fees", "repairs", "maintenance",
-				},
-			},
-			{
-				Name: "utilities",
-				Icon: idToIcon[9],
-				SubCategs: []string{
-					"electricity", "water", "internet", "phones", "garbage", "cable",
-				},
-			},
-			{
-				Name: "housing",
-				Icon: idToIcon[3],
-				SubCategs: []string{
-					"rent", "mortgage", "property taxes", "insurance", "repairs", "furnishings",
-				},
-			},
-			{
-				Name: "clothing",
-				Icon: idToIcon[2],
-				SubCategs: []string{
-					"shirts", "pants", "shoes", "accessories", "jewelry", "underwear", "socks",
-				},
-			},
-			{
-				Name: "entertainment",
-				Icon: idToIcon[6],
-				SubCategs: []string{
-					"movies", "concerts", "shows", "games", "toys", "hobbies", "books", "magazines", "music", "apps", "party", "vacations", "membership", "subscriptions",
-				},
-			},
-			{
-				Name: "gifts",
-				Icon: idToIcon[7],
-				SubCategs: []string{
-					"birthday", "wedding", "baby shower", "anniversary", "graduation", "holiday", "charities",
-				},
-			},
-			{
-				Name: "education",
-				Icon: idToIcon[5],
-				SubCategs: []string{
-					"tuition", "books", "course",
-				},
-			},
-			{
-				Name: "insurance",
-				Icon: idToIcon[10],
-				SubCategs: []string{
-					"health", "life", "auto", "home", "disability", "liability",
-				},
-			},
-			{
-				Name: "debt",
-				Icon: idToIcon[11],
-				SubCategs: []string{
-					"credit card", "student loans", "personal loans",
-				},
-			},
-			{
-				Name: "healthcare",
-				Icon: idToIcon[8],
-				SubCategs: []string{
-					"doctor", "dentist", "optometrist", "medication", "pharmacy", "hospital", "medical devices",
-				},
-			},
-			{
-				Name: "others",
-				Icon: idToIcon[14],
-				SubCategs: []string{
-					"others",
-				},
-			},
-		},
-		Income: []domain.InitDataMainCateg{
-			{
-				Name: "salary",
-				Icon: idToIcon[12],
-				SubCategs: []string{
-					"salary", "bonus", "commission", "tips",
-				},
-			},
-			{
-				Name: "investment",
-				Icon: idToIcon[15],
-				SubCategs: []string{
-					"dividends", "capital gains", "interest",
-				},
-			},
-			{
-				Name: "others",
-				Icon: idToIcon[14],
-				SubCategs: []string{
-					"others",
-				},
-			},
-		},
-	}, nil
+	if err := u.fillIcons(&templ.InitData); err != nil {
+		return domain.InitData{}, err
+	}
+
+	return templ.InitData, nil
+}
+
+func (u *UC) ListTemplates() ([]domain.CategTemplate, error) {
+	templs, err := loadTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	datas := make([]*domain.InitData, len(templs))
+	for i := range templs {
+		datas[i] = &templs[i].InitData
+	}
+
+	if err := u.fillIcons(datas...); err != nil {
+		return nil, err
+	}
+
+	return templs, nil
 }
 
 func (u *UC) Create(ctx context.Context, data domain.InitData, userID int64) error {
@@ -160,7 +76,7 @@ func (u *UC) Create(ctx context.Context, data domain.InitData, userID int64) err
 		return err
 	}
 
-	allCategs, err := u.mainCateg.GetAll(ctx, userID, domain.TransactionTypeUnSpecified)
+	allCategs, err := u.mainCateg.GetAll(ctx, userID, domain.TransactionTypeUnSpecified, true)
 	if err != nil {
 		return err
 	}
@@ -178,3 +94,193 @@ func (u *UC) Create(ctx context.Context, data domain.InitData, userID int64) err
 	opt := domain.UpdateUserOpt{IsSetInitCategory: &t}
 	return u.user.Update(ctx, userID, opt)
 }
+
+func (u *UC) Export(ctx context.Context, userID int64) (domain.InitData, error) {
+	categs, err := u.mainCateg.GetAll(ctx, userID, domain.TransactionTypeUnSpecified, true)
+	if err != nil {
+		return domain.InitData{}, err
+	}
+
+	icons, err := u.icon.List()
+	if err != nil {
+		return domain.InitData{}, err
+	}
+
+	urlToIcon := make(map[string]domain.DefaultIcon, len(icons))
+	for _, i := range icons {
+		urlToIcon[i.URL] = i
+	}
+
+	data := domain.InitData{
+		Income:  []domain.InitDataMainCateg{},
+		Expense: []domain.InitDataMainCateg{},
+	}
+	for _, c := range categs {
+		subCategs, err := u.subCateg.GetByMainCategID(userID, c.ID, true)
+		if err != nil {
+			return domain.InitData{}, err
+		}
+
+		names := make([]string, len(subCategs))
+		for i, s := range subCategs {
+			names[i] = s.Name
+		}
+
+		// custom icons belong to the user, so they are left empty and replaced when importing
+		var icon domain.DefaultIcon
+		if c.IconType == domain.IconTypeDefault {
+			icon = urlToIcon[c.IconData]
+		}
+
+		categ := domain.InitDataMainCateg{
+			Name:      c.Name,
+			Icon:      icon,
+			SubCategs: names,
+		}
+		if c.Type == domain.TransactionTypeIncome {
+			data.Income = append(data.Income, categ)
+		} else {
+			data.Expense = append(data.Expense, categ)
+		}
+	}
+
+	return data, nil
+}
+
+func (u *UC) Import(ctx context.Context, data domain.InitData, mode domain.ImportModeType, userID int64) error {
+	// replacing archives the existing categories
+	if mode == domain.ImportModeTypeReplace && !ctxutil.GetLedgerRole(ctx).CanManage() {
+		return domain.ErrLedgerPermissionDenied
+	}
+
+	existing, err := u.mainCateg.GetAll(ctx, userID, domain.TransactionTypeUnSpecified, true)
+	if err != nil {
+		return err
+	}
+
+	// the icons are looked up by id, so the url of the template is never trusted
+	idToIcon, err := u.icon.GetByIDs(append(genIconIDs(data), fallbackIconID))
+	if err != nil {
+		return err
+	}
+
+	templCategs := flattenTemplate(data)
+	existingKeyToID := genKeyToMainCategIDMap(existing)
+
+	var input domain.ImportCategInput
+	if mode == domain.ImportModeTypeReplace {
+		templKeys := make(map[string]bool, len(templCategs))
+		for _, c := range templCategs {
+			templKeys[c.key()] = true
+		}
+
+		for _, c := range existing {
+			if !templKeys[genMainCategKey(c.Name, c.Type)] {
+				input.ArchiveMainCategIDs = append(input.ArchiveMainCategIDs, c.ID)
+			}
+		}
+	}
+
+	input.MainCategs = genNewMainCategs(templCategs, existingKeyToID, idToIcon)
+	keyToID := make(map[string]int64, len(existingKeyToID)+len(input.MainCategs))
+	for key, id := range existingKeyToID {
+		keyToID[key] = id
+	}
+	for _, c := range input.MainCategs {
+		keyToID[genMainCategKey(c.Name, c.Type)] = c.ID
+	}
+
+	// the template may list a main category more than once, its sub categories are synced only once
+	synced := make(map[int64]map[string]bool)
+	for _, c := range templCategs {
+		mainCategID := keyToID[c.key()]
+		usedNames, ok := synced[mainCategID]
+		if !ok {
+			usedNames = make(map[string]bool)
+			if mainCategID > 0 {
+				archiveIDs, names, err := u.syncExistingSubCategs(mainCategID, templCategs, c.key(), mode, userID)
+				if err != nil {
+					return err
+				}
+
+				input.ArchiveSubCategIDs = append(input.ArchiveSubCategIDs, archiveIDs...)
+				usedNames = names
+			}
+			synced[mainCategID] = usedNames
+		}
+
+		for _, name := range c.SubCategs {
+			// the unique index of the category names is case-insensitive
+			if usedNames[strings.ToLower(name)] {
+				continue
+			}
+			usedNames[strings.ToLower(name)] = true
+
+			input.SubCategs = append(input.SubCategs, domain.SubCateg{
+				Name:        name,
+				MainCategID: mainCategID,
+			})
+		}
+	}
+
+	return u.mainCateg.Import(ctx, input, userID)
+}
+
+// syncExistingSubCategs returns the lower-cased names of the sub categories kept under the existing main category.
+// When replacing, the ids of the sub categories not in any template category of the key are returned to be archived.
+func (u *UC) syncExistingSubCategs(mainCategID int64, templCategs []templateCateg, key string, mode domain.ImportModeType, userID int64) ([]int64, map[string]bool, error) {
+	subCategs, err := u.subCateg.GetByMainCategID(userID, mainCategID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	templNames := make(map[string]bool)
+	for _, c := range templCategs {
+		if c.key() != key {
+			continue
+		}
+
+		for _, name := range c.SubCategs {
+			templNames[strings.ToLower(name)] = true
+		}
+	}
+
+	var archiveIDs []int64
+	names := make(map[string]bool, len(subCategs))
+	for _, s := range subCategs {
+		name := strings.ToLower(s.Name)
+		if mode == domain.ImportModeTypeReplace && !templNames[name] {
+			archiveIDs = append(archiveIDs, s.ID)
+			continue
+		}
+
+		names[name] = true
+	}
+
+	return archiveIDs, names, nil
+}
+
+// fillIcons sets the url of the icons of the init data, the icons of all the init data are fetched at once.
+func (u *UC) fillIcons(datas ...*domain.InitData) error {
+	var iconIDs []int64
+	for _, d := range datas {
+		iconIDs = append(iconIDs, genIconIDs(*d)...)
+	}
+	slices.Sort(iconIDs)
+	iconIDs = slices.Compact(iconIDs)
+
+	idToIcon, err := u.icon.GetByIDs(iconIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range datas {
+		for _, categs := range [][]domain.InitDataMainCateg{d.Expense, d.Income} {
+			for i := range categs {
+				categs[i].Icon = idToIcon[categs[i].Icon.ID]
+			}
+		}
+	}
+
+	return nil
+}
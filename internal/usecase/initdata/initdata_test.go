@@ -458,7 +458,7 @@ func create_NoError_CreateSuccessfully(s *InitDataSuite, desc string) {
 	// mock service
 	s.mockMainCategRepo.On("BatchCreate", mockCtx, mockMainCategs, mockUserID).Return(nil).Once()
 
-	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified).Return(mockMainCategsWithID, nil).Once()
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).Return(mockMainCategsWithID, nil).Once()
 
 	s.mockSubCategRepo.On("BatchCreate", mockCtx, mockSubCategs, mockUserID).Return(nil).Once()
 
@@ -500,7 +500,7 @@ func create_NoSubCategory_DoNotCreateSubCategory(s *InitDataSuite, desc string)
 	// mock service
 	s.mockMainCategRepo.On("BatchCreate", mockCtx, mockMainCategs, mockUserID).Return(nil).Once()
 
-	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified).Return(mockMainCategsWithID, nil).Once()
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).Return(mockMainCategsWithID, nil).Once()
 
 	err := s.uc.Create(mockCtx, mockData, mockUserID)
 	s.Require().NoError(err, desc)
@@ -0,0 +1,65 @@
+package initdata
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	// defaultTemplateKey is the key of the template listed as the initial data
+	defaultTemplateKey = "default"
+
+	// fallbackIconID is the id of the "others" icon, used when the icon of a category can't be found
+	fallbackIconID = int64(14)
+)
+
+// templateFS holds the built-in templates, the key of a template is its file name.
+// The icons of the templates only have ids, the urls are filled when listing.
+//
+//go:embed templates/*.json
+var templateFS embed.FS
+
+// loadTemplates parses all the built-in templates in the order of their keys.
+func loadTemplates() ([]domain.CategTemplate, error) {
+	entries, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		logger.Error("fs.ReadDir failed", "package", packageName, "err", err)
+		return nil, err
+	}
+
+	templs := make([]domain.CategTemplate, 0, len(entries))
+	for _, e := range entries {
+		templ, err := loadTemplate(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+
+		templs = append(templs, templ)
+	}
+
+	return templs, nil
+}
+
+// loadTemplate parses the built-in template of the key.
+func loadTemplate(key string) (domain.CategTemplate, error) {
+	b, err := templateFS.ReadFile(path.Join("templates", key+".json"))
+	if err != nil {
+		logger.Error("templateFS.ReadFile failed", "package", packageName, "err", err)
+		return domain.CategTemplate{}, err
+	}
+
+	var templ domain.CategTemplate
+	if err := json.Unmarshal(b, &templ); err != nil {
+		logger.Error("json.Unmarshal failed", "package", packageName, "err", err)
+		return domain.CategTemplate{}, err
+	}
+	templ.Key = key
+
+	return templ, nil
+}
@@ -0,0 +1,293 @@
+package initdata
+
+import (
+	"errors"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *InitDataSuite) TestListTemplates() {
+	for scenario, fn := range map[string]func(s *InitDataSuite, desc string){
+		"when no error, return templates with icons": listTemplates_NoError_ReturnTemplatesWithIcons,
+		"when get icon fail, return error":           listTemplates_GetIconFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func listTemplates_NoError_ReturnTemplatesWithIcons(s *InitDataSuite, desc string) {
+	mockIDToIcon := map[int64]domain.DefaultIcon{
+		1:  {ID: 1, URL: "url1"},
+		5:  {ID: 5, URL: "url5"},
+		14: {ID: 14, URL: "url14"},
+	}
+	s.mockIconRepo.On("GetByIDs", mock.Anything).Return(mockIDToIcon, nil).Once()
+
+	templs, err := s.uc.ListTemplates()
+	s.Require().NoError(err, desc)
+
+	keys := make([]string, len(templs))
+	for i, t := range templs {
+		keys[i] = t.Key
+		s.Require().NotEmpty(t.Name, desc)
+		s.Require().NotEmpty(t.Expense, desc)
+		s.Require().NotEmpty(t.Income, desc)
+	}
+	s.Require().Equal([]string{"default", "family", "freelancer", "student"}, keys, desc)
+
+	// the student template starts with food and education
+	s.Require().Equal(domain.DefaultIcon{ID: 1, URL: "url1"}, templs[3].Expense[0].Icon, desc)
+	s.Require().Equal(domain.DefaultIcon{ID: 5, URL: "url5"}, templs[3].Expense[1].Icon, desc)
+}
+
+func listTemplates_GetIconFail_ReturnError(s *InitDataSuite, desc string) {
+	s.mockIconRepo.On("GetByIDs", mock.Anything).Return(nil, errors.New("GetByIDs failed")).Once()
+
+	templs, err := s.uc.ListTemplates()
+	s.Require().EqualError(err, "GetByIDs failed", desc)
+	s.Require().Nil(templs, desc)
+}
+
+func (s *InitDataSuite) TestExport() {
+	for scenario, fn := range map[string]func(s *InitDataSuite, desc string){
+		"when no error, return categories as template": export_NoError_ReturnTemplate,
+		"when get all fail, return error":              export_GetAllFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func export_NoError_ReturnTemplate(s *InitDataSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockCategs := []domain.MainCateg{
+		{ID: 1, Name: "food", Type: domain.TransactionTypeExpense, IconType: domain.IconTypeDefault, IconData: "url1"},
+		{ID: 2, Name: "pets", Type: domain.TransactionTypeExpense, IconType: domain.IconTypeCustom, IconData: "object-key"},
+		{ID: 3, Name: "salary", Type: domain.TransactionTypeIncome, IconType: domain.IconTypeDefault, IconData: "url12"},
+	}
+	mockIcons := []domain.DefaultIcon{
+		{ID: 1, URL: "url1"},
+		{ID: 12, URL: "url12"},
+	}
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).
+		Return(mockCategs, nil).Once()
+	s.mockIconRepo.On("List").Return(mockIcons, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, int64(1), true).
+		Return([]*domain.SubCateg{{ID: 1, Name: "lunch"}, {ID: 2, Name: "dinner"}}, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, int64(2), true).
+		Return([]*domain.SubCateg{}, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, int64(3), true).
+		Return([]*domain.SubCateg{{ID: 3, Name: "bonus"}}, nil).Once()
+
+	// action, assertion
+	expRes := domain.InitData{
+		Expense: []domain.InitDataMainCateg{
+			{Name: "food", Icon: domain.DefaultIcon{ID: 1, URL: "url1"}, SubCategs: []string{"lunch", "dinner"}},
+			{Name: "pets", SubCategs: []string{}},
+		},
+		Income: []domain.InitDataMainCateg{
+			{Name: "salary", Icon: domain.DefaultIcon{ID: 12, URL: "url12"}, SubCategs: []string{"bonus"}},
+		},
+	}
+	res, err := s.uc.Export(mockCtx, mockUserID)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expRes, res, desc)
+}
+
+func export_GetAllFail_ReturnError(s *InitDataSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockErr := errors.New("get all fail")
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).
+		Return(nil, mockErr).Once()
+
+	// action, assertion
+	res, err := s.uc.Export(mockCtx, mockUserID)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(res, desc)
+}
+
+func (s *InitDataSuite) TestImport() {
+	for scenario, fn := range map[string]func(s *InitDataSuite, desc string){
+		"when merge, keep existing and create missing ones":   import_Merge_KeepExistingAndCreateMissing,
+		"when replace, archive categories not in template":    import_Replace_ArchiveCategsNotInTemplate,
+		"when names differ only in case, match existing ones": import_NamesDifferInCase_MatchExisting,
+		"when import fail, return error":                      import_ImportFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func import_Merge_KeepExistingAndCreateMissing(s *InitDataSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockData := domain.InitData{
+		Expense: []domain.InitDataMainCateg{
+			{Name: "food", Icon: domain.DefaultIcon{ID: 1}, SubCategs: []string{"lunch", "snacks"}},
+			{Name: "pets", SubCategs: []string{"vet"}},
+		},
+	}
+	mockExisting := []domain.MainCateg{
+		{ID: 1, Name: "food", Type: domain.TransactionTypeExpense},
+		{ID: 2, Name: "travel", Type: domain.TransactionTypeExpense},
+	}
+	mockIDToIcon := map[int64]domain.DefaultIcon{
+		1:  {ID: 1, URL: "url1"},
+		14: {ID: 14, URL: "url14"},
+	}
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).
+		Return(mockExisting, nil).Once()
+	s.mockIconRepo.On("GetByIDs", []int64{1, 14}).Return(mockIDToIcon, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, int64(1), true).
+		Return([]*domain.SubCateg{{ID: 1, Name: "lunch"}, {ID: 2, Name: "dinner"}}, nil).Once()
+
+	// pets has no icon, so the fallback icon is used
+	expInput := domain.ImportCategInput{
+		MainCategs: []domain.MainCateg{
+			{ID: -1, Name: "pets", Type: domain.TransactionTypeExpense, IconType: domain.IconTypeDefault, IconData: "url14"},
+		},
+		SubCategs: []domain.SubCateg{
+			{Name: "snacks", MainCategID: 1},
+			{Name: "vet", MainCategID: -1},
+		},
+	}
+	s.mockMainCategRepo.On("Import", mockCtx, expInput, mockUserID).
+		Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.Import(mockCtx, mockData, domain.ImportModeTypeMerge, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func import_Replace_ArchiveCategsNotInTemplate(s *InitDataSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockData := domain.InitData{
+		Expense: []domain.InitDataMainCateg{
+			{Name: "food", Icon: domain.DefaultIcon{ID: 1}, SubCategs: []string{"lunch"}},
+		},
+	}
+	mockExisting := []domain.MainCateg{
+		{ID: 1, Name: "food", Type: domain.TransactionTypeExpense},
+		{ID: 2, Name: "food", Type: domain.TransactionTypeIncome},
+	}
+	mockIDToIcon := map[int64]domain.DefaultIcon{
+		1:  {ID: 1, URL: "url1"},
+		14: {ID: 14, URL: "url14"},
+	}
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).
+		Return(mockExisting, nil).Once()
+	s.mockIconRepo.On("GetByIDs", []int64{1, 14}).Return(mockIDToIcon, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, int64(1), true).
+		Return([]*domain.SubCateg{{ID: 1, Name: "lunch"}, {ID: 2, Name: "dinner"}}, nil).Once()
+
+	// the categories are archived in the same database transaction as the import, so their transactions are kept
+	expInput := domain.ImportCategInput{
+		ArchiveMainCategIDs: []int64{2},
+		ArchiveSubCategIDs:  []int64{2},
+	}
+	s.mockMainCategRepo.On("Import", mockCtx, expInput, mockUserID).
+		Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.Import(mockCtx, mockData, domain.ImportModeTypeReplace, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func import_NamesDifferInCase_MatchExisting(s *InitDataSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockData := domain.InitData{
+		Expense: []domain.InitDataMainCateg{
+			{Name: "Food", Icon: domain.DefaultIcon{ID: 1}, SubCategs: []string{"Lunch", "snacks", "Snacks"}},
+			{Name: "PETS", SubCategs: []string{"vet"}},
+			{Name: "pets", SubCategs: []string{"Vet", "toys"}},
+		},
+	}
+	mockExisting := []domain.MainCateg{
+		{ID: 1, Name: "food", Type: domain.TransactionTypeExpense},
+	}
+	mockIDToIcon := map[int64]domain.DefaultIcon{
+		1:  {ID: 1, URL: "url1"},
+		14: {ID: 14, URL: "url14"},
+	}
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).
+		Return(mockExisting, nil).Once()
+	s.mockIconRepo.On("GetByIDs", []int64{1, 14}).Return(mockIDToIcon, nil).Once()
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, int64(1), true).
+		Return([]*domain.SubCateg{{ID: 1, Name: "lunch"}, {ID: 2, Name: "dinner"}}, nil).Once()
+
+	// the unique index of the category names is case-insensitive, so the names are matched regardless of case
+	expInput := domain.ImportCategInput{
+		ArchiveSubCategIDs: []int64{2},
+		MainCategs: []domain.MainCateg{
+			{ID: -1, Name: "PETS", Type: domain.TransactionTypeExpense, IconType: domain.IconTypeDefault, IconData: "url14"},
+		},
+		SubCategs: []domain.SubCateg{
+			{Name: "snacks", MainCategID: 1},
+			{Name: "vet", MainCategID: -1},
+			{Name: "toys", MainCategID: -1},
+		},
+	}
+	s.mockMainCategRepo.On("Import", mockCtx, expInput, mockUserID).
+		Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.Import(mockCtx, mockData, domain.ImportModeTypeReplace, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func import_ImportFail_ReturnError(s *InitDataSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockData := domain.InitData{
+		Income: []domain.InitDataMainCateg{
+			{Name: "salary", Icon: domain.DefaultIcon{ID: 12}},
+		},
+	}
+	mockIDToIcon := map[int64]domain.DefaultIcon{
+		12: {ID: 12, URL: "url12"},
+		14: {ID: 14, URL: "url14"},
+	}
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).
+		Return(nil, nil).Once()
+	s.mockIconRepo.On("GetByIDs", []int64{12, 14}).Return(mockIDToIcon, nil).Once()
+
+	expInput := domain.ImportCategInput{
+		MainCategs: []domain.MainCateg{
+			{ID: -1, Name: "salary", Type: domain.TransactionTypeIncome, IconType: domain.IconTypeDefault, IconData: "url12"},
+		},
+	}
+	s.mockMainCategRepo.On("Import", mockCtx, expInput, mockUserID).
+		Return(domain.ErrUniqueNameUserType).Once()
+
+	// action, assertion
+	err := s.uc.Import(mockCtx, mockData, domain.ImportModeTypeReplace, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUserType, desc)
+}
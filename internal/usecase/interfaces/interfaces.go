@@ -27,13 +27,13 @@ type MainCategRepo interface {
 	// Create inserts a new main category into the database.
 	Create(ctx context.Context, categ domain.MainCateg, userID int64) error
 
-	// GetAll returns all main categories by user id.
-	GetAll(ctx context.Context, userID int64, transType domain.TransactionType) ([]domain.MainCateg, error)
+	// GetAll returns all main categories by user id in the user-defined order. Archived ones are left out unless includeArchived is true.
+	GetAll(ctx context.Context, userID int64, transType domain.TransactionType, includeArchived bool) ([]domain.MainCateg, error)
 
 	// Update updates a main category.
 	Update(ctx context.Context, categ domain.MainCateg) error
 
-	// Delete deletes a main category.
+	// Delete moves a main category to trash, along with its sub categories and transactions.
 	Delete(id int64) error
 
 	// GetByID returns a main category by id and user id.
@@ -41,6 +41,19 @@ type MainCategRepo interface {
 
 	// BatchCreate inserts multiple main categories into the database.
 	BatchCreate(ctx context.Context, categs []domain.MainCateg, userID int64) error
+
+	// Merge moves the sub categories and transactions of a main category into the target main category, and deletes the main category.
+	Merge(ctx context.Context, id, targetID int64) error
+
+	// SetArchived archives or unarchives a main category.
+	SetArchived(ctx context.Context, id int64, archived bool) error
+
+	// Reorder sets the position of the main categories by the order of ids.
+	Reorder(ctx context.Context, ids []int64) error
+
+	// Import archives the replaced categories, inserts the new main and sub categories of a category template,
+	// and marks the init categories of the user as set, all in one database transaction.
+	Import(ctx context.Context, input domain.ImportCategInput, userID int64) error
 }
 
 // SubCategRepo is the interface that wraps the basic methods for sub category repository.
@@ -51,17 +64,29 @@ type SubCategRepo interface {
 	// Update updates a sub category.
 	Update(categ *domain.SubCateg) error
 
-	// GetByMainCategID returns all sub categories by user id and main category id.
-	GetByMainCategID(userID, mainCategID int64) ([]*domain.SubCateg, error)
+	// GetByMainCategID returns all sub categories by user id and main category id in the user-defined order. Archived ones are left out unless includeArchived is true.
+	GetByMainCategID(userID, mainCategID int64, includeArchived bool) ([]*domain.SubCateg, error)
 
-	// Delete deletes a sub category.
+	// Delete moves a sub category to trash, along with its transactions.
 	Delete(id int64) error
 
 	// GetByID returns a sub category by id and user id.
 	GetByID(id, userID int64) (*domain.SubCateg, error)
 
+	// GetByIDs returns the sub categories of the user among the ids. Ids of other users are left out.
+	GetByIDs(ctx context.Context, ids []int64, userID int64) ([]domain.SubCateg, error)
+
 	// BatchCreate inserts multiple sub categories into the database.
 	BatchCreate(ctx context.Context, categs []domain.SubCateg, userID int64) error
+
+	// Move moves a sub category, along with its transactions, to another main category.
+	Move(ctx context.Context, id, mainCategID int64) error
+
+	// SetArchived archives or unarchives a sub category.
+	SetArchived(ctx context.Context, id int64, archived bool) error
+
+	// Reorder sets the position of the sub categories by the order of ids.
+	Reorder(ctx context.Context, ids []int64) error
 }
 
 // IconRepo is the interface that wraps the basic methods for icon repository.
@@ -90,44 +115,96 @@ type UserIconRepo interface {
 
 // TransactionRepo is the interface that wraps the basic methods for transaction repository.
 type TransactionRepo interface {
-	// Create inserts a new transaction into the database.
-	Create(ctx context.Context, trans domain.CreateTransactionInput) error
+	// Create inserts a new transaction into the database, and returns its id.
+	// The revision made by the member is written in the same database transaction.
+	Create(ctx context.Context, trans domain.CreateTransactionInput, memberID int64) (int64, error)
+
+	// Import inserts the new categories and the transactions of a CSV import in one database transaction.
+	// It returns the ids of the new categories keyed by their placeholder ids.
+	Import(ctx context.Context, input domain.ImportTransInput, userID int64) (map[int64]int64, error)
 
 	// GetAll returns all transactions by user id and query option.
+	// The transactions before the previous key are returned when it's set, otherwise the ones after the next key.
 	GetAll(ctx context.Context, query domain.GetTransOpt, userID int64) ([]domain.Transaction, domain.DecodedNextKeys, error)
 
-	// Update updates a transaction.
-	Update(ctx context.Context, trans domain.UpdateTransactionInput) error
+	// GetTotal returns the count and sum of the transactions matching the query option, ignoring the cursor.
+	GetTotal(ctx context.Context, query domain.GetTransOpt, userID int64) (domain.TransTotal, error)
 
-	// Delete deletes a transaction by id.
-	Delete(ctx context.Context, id int64) error
+	// StreamAll calls fn with every transaction matching the query option, ignoring the cursor. It stops at the first error returned by fn.
+	StreamAll(ctx context.Context, query domain.GetTransOpt, userID int64, fn func(domain.Transaction) error) error
+
+	// Update updates a transaction, and records the change made by the member as the action in the same database transaction.
+	// A transaction in trash is brought back, so that it can be reverted.
+	Update(ctx context.Context, trans domain.UpdateTransactionInput, action domain.RevisionActionType, memberID int64) error
+
+	// Delete moves a transaction to trash by id, and records the deletion made by the member in the same database transaction.
+	Delete(ctx context.Context, id, memberID int64) error
 
 	// GetAccInfo returns accumulated information by user id and query.
 	GetAccInfo(ctx context.Context, query domain.GetAccInfoQuery, userID int64) (domain.AccInfo, error)
 
-	// GetByIDAndUserID returns a transaction by id and user id. Note that returned transaction is not included main category, sub category, and icon.
+	// GetByIDAndUserID returns a transaction by id and user id, along with its splits and tags.
+	// Note that only the ids of main category and sub category are included, without names and icon.
 	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Transaction, error)
 
+	// GetByIDAndUserIDIncludeTrash is the same as GetByIDAndUserID, but the transaction in trash is also returned.
+	GetByIDAndUserIDIncludeTrash(ctx context.Context, id, userID int64) (domain.Transaction, error)
+
+	// GetByIDsAndUserID is the same as GetByIDAndUserID, but returns the transactions of the user among the ids.
+	// Ids of other users or in trash are left out.
+	GetByIDsAndUserID(ctx context.Context, ids []int64, userID int64) ([]domain.Transaction, error)
+
+	// Bulk creates, updates and deletes transactions of the user in one database transaction, and returns the ids of created transactions.
+	// The transactions to delete are selected by Delete.IDs only, so the filter must be resolved to ids beforehand.
+	// The revisions made by the member are written in the same database transaction.
+	Bulk(ctx context.Context, input domain.BulkTransInput, userID, memberID int64) ([]int64, error)
+
+	// GetDuplicateCandidates returns the transactions of the user with the same type, price, currency and categories as trans,
+	// and a date within the days of it. The note is not compared.
+	GetDuplicateCandidates(ctx context.Context, trans domain.CreateTransactionInput, days int) ([]domain.Transaction, error)
+
+	// GetDuplicatePairs returns the pairs of transactions of the user with the same type, price, currency and categories,
+	// and dates in the range of the option and within the days of each other, except the dismissed pairs and the occurrences of recurring transactions.
+	// The note is not compared.
+	GetDuplicatePairs(ctx context.Context, opt domain.GetDuplicatesOpt, userID int64) ([]domain.DuplicatePair, error)
+
+	// DismissDuplicates marks every pair among the transactions as not duplicates.
+	DismissDuplicates(ctx context.Context, ids []int64) error
+
 	// GetDailyBarChartData returns bar chart data grouped by date.
-	GetDailyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, userID int64) (domain.DateToChartData, error)
+	// The chart data below is narrowed down by the filter and search of opt, e.g. of a saved view, unless opt is nil.
+	GetDailyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error)
 
 	// GetMonthlyBarChartData returns bar chart data grouped by month.
-	GetMonthlyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, userID int64) (domain.DateToChartData, error)
+	GetMonthlyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error)
 
 	// GetPieChartData returns pie chart data.
-	GetPieChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, userID int64) (domain.ChartData, error)
+	GetPieChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) (domain.ChartData, error)
+
+	// CountUnconverted counts the transactions in the date range which can't be converted to the base currency, because there's no exchange rate.
+	CountUnconverted(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) (int64, error)
+
+	// GetSumByMainCateg returns summed price grouped by main category. It's the aggregation behind the pie chart.
+	GetSumByMainCateg(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) ([]domain.MainCategSum, error)
+
+	// GetSumByTag returns summed price grouped by tag. A transaction with multiple tags is summed in each of them.
+	GetSumByTag(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) ([]domain.TagSum, error)
 
 	// GetDailyLineChartData returns line chart data grouped by date.
-	GetDailyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, userID int64) (domain.DateToChartData, error)
+	GetDailyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error)
 
 	// GetMonthlyLineChartData returns line chart data grouped by month.
-	GetMonthlyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, userID int64) (domain.DateToChartData, error)
+	GetMonthlyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error)
 
 	// GetMonthlyData returns monthly data.
 	GetMonthlyData(ctx context.Context, dateRange domain.GetMonthlyDateRange, userID int64) (domain.MonthDayToTransactionType, error)
 
 	// GetMonthlyAggregatedData returns monthly aggregated data.
 	GetMonthlyAggregatedData(ctx context.Context, date time.Time) ([]domain.MonthlyAggregatedData, error)
+
+	// GetAccountEntries returns the signed amount of each transaction moving money in or out of the account, the oldest first.
+	// The entries without an exchange rate to the base currency are marked as unconverted.
+	GetAccountEntries(ctx context.Context, accountID, userID int64) ([]domain.AccountEntry, error)
 }
 
 // MonthlyTransRepo is the interface that wraps the basic methods for monthly transaction repository.
@@ -139,6 +216,318 @@ type MonthlyTransRepo interface {
 	GetByUserIDAndMonthDate(ctx context.Context, userID int64, monthDate time.Time) (domain.AccInfo, error)
 }
 
+// RecurringTransRepo is the interface that wraps the basic methods for recurring transaction repository.
+type RecurringTransRepo interface {
+	// Create inserts a new recurring transaction into the database.
+	Create(ctx context.Context, rt domain.RecurringTrans) error
+
+	// GetAll returns all recurring transactions by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.RecurringTrans, error)
+
+	// GetByIDAndUserID returns a recurring transaction by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.RecurringTrans, error)
+
+	// Update updates a recurring transaction.
+	Update(ctx context.Context, rt domain.RecurringTrans) error
+
+	// Delete deletes a recurring transaction by id.
+	Delete(ctx context.Context, id int64) error
+
+	// GetDue returns all recurring transactions whose next date is on or before the given date.
+	GetDue(ctx context.Context, date time.Time) ([]domain.RecurringTrans, error)
+
+	// UpdateNextDate moves the next date from `from` to `to`. It returns ErrRecurringTransNotDue if the next date is not `from` anymore.
+	UpdateNextDate(ctx context.Context, id int64, from, to time.Time) error
+}
+
+// TransactionCreator is the interface that wraps the create method of transaction usecase.
+type TransactionCreator interface {
+	// Create creates a transaction, and returns the existing transactions it may be a duplicate of.
+	// When strict is true, it returns ErrDuplicateTransaction instead of creating the transaction if there's any.
+	Create(ctx context.Context, trans domain.CreateTransactionInput, strict bool) ([]domain.Transaction, error)
+}
+
+// TransactionBulker is the interface that wraps the bulk method of transaction usecase.
+type TransactionBulker interface {
+	// Bulk creates, updates and deletes transactions of the user together.
+	Bulk(ctx context.Context, input domain.BulkTransInput, user domain.User) (domain.BulkTransResult, error)
+}
+
+// BudgetRepo is the interface that wraps the basic methods for budget repository.
+type BudgetRepo interface {
+	// Upsert inserts a budget, or updates the amount if the main category already has one.
+	Upsert(ctx context.Context, budget domain.Budget, userID int64) error
+
+	// GetAll returns all budgets by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Budget, error)
+
+	// GetByIDAndUserID returns a budget by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Budget, error)
+
+	// Delete deletes a budget by id.
+	Delete(ctx context.Context, id int64) error
+}
+
+// ExchangeRateRepo is the interface that wraps the basic methods for exchange rate repository.
+type ExchangeRateRepo interface {
+	// Upsert inserts exchange rates, or updates the rate if the currency pair already has one on the date.
+	Upsert(ctx context.Context, rates []domain.ExchangeRate) error
+
+	// GetAll returns all exchange rates of the currency pair, the latest first.
+	GetAll(ctx context.Context, from, to string) ([]domain.ExchangeRate, error)
+}
+
+// AccountRepo is the interface that wraps the basic methods for account repository.
+type AccountRepo interface {
+	// Create inserts a new account into the database.
+	Create(ctx context.Context, account domain.Account, userID int64) error
+
+	// GetAll returns all accounts by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Account, error)
+
+	// GetByIDAndUserID returns an account by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Account, error)
+
+	// Update updates an account.
+	Update(ctx context.Context, account domain.Account) error
+
+	// Delete deletes an account by id.
+	Delete(ctx context.Context, id int64) error
+}
+
+// TagRepo is the interface that wraps the basic methods for tag repository.
+type TagRepo interface {
+	// Create inserts a new tag into the database.
+	Create(ctx context.Context, tag domain.Tag, userID int64) error
+
+	// GetAll returns all tags by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Tag, error)
+
+	// GetByIDAndUserID returns a tag by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Tag, error)
+
+	// GetByIDs returns the tags of the user among the ids. Ids of other users are left out.
+	GetByIDs(ctx context.Context, ids []int64, userID int64) ([]domain.Tag, error)
+
+	// Update updates a tag.
+	Update(ctx context.Context, tag domain.Tag) error
+
+	// Delete deletes a tag by id, and removes it from the transactions.
+	Delete(ctx context.Context, id int64) error
+}
+
+// RuleRepo is the interface that wraps the basic methods for rule repository.
+type RuleRepo interface {
+	// Create inserts a new rule, along with its tags, into the database.
+	Create(ctx context.Context, rule domain.Rule, userID int64) error
+
+	// GetAll returns all rules by user id in the order of matching, along with the names of categories and tags.
+	// The rules whose category is in trash are left out.
+	GetAll(ctx context.Context, userID int64) ([]domain.Rule, error)
+
+	// GetByIDAndUserID returns a rule by id and user id, without the names of categories and tags.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Rule, error)
+
+	// Update updates a rule, and replaces its tags.
+	Update(ctx context.Context, rule domain.Rule) error
+
+	// Delete deletes a rule by id.
+	Delete(ctx context.Context, id int64) error
+}
+
+// ViewRepo is the interface that wraps the basic methods for view repository.
+type ViewRepo interface {
+	// Create inserts a new view into the database.
+	Create(ctx context.Context, view domain.View, userID int64) error
+
+	// GetAll returns all views by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.View, error)
+
+	// GetByIDAndUserID returns a view by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.View, error)
+
+	// Update updates a view.
+	Update(ctx context.Context, view domain.View) error
+
+	// Delete deletes a view by id.
+	Delete(ctx context.Context, id int64) error
+}
+
+// LedgerRepo is the interface that wraps the basic methods for ledger repository.
+type LedgerRepo interface {
+	// Create inserts a shared ledger with the user as owner, and returns the id of the ledger.
+	Create(ctx context.Context, name string, userID int64) (int64, error)
+
+	// GetByUserID returns the ledgers the user is a member of, the personal ledger comes first.
+	GetByUserID(ctx context.Context, userID int64) ([]domain.Ledger, error)
+
+	// GetByIDAndUserID returns a ledger with the role of the user.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Ledger, error)
+
+	// GetMembers returns the members of a ledger.
+	GetMembers(ctx context.Context, id int64) ([]domain.LedgerMember, error)
+
+	// UpdateMemberRole updates the role of a member.
+	UpdateMemberRole(ctx context.Context, id, userID int64, role domain.LedgerRoleType) error
+
+	// DeleteMember removes a member from a ledger.
+	DeleteMember(ctx context.Context, id, userID int64) error
+
+	// CreateInvite inserts an invite to a ledger.
+	CreateInvite(ctx context.Context, invite domain.LedgerInvite) error
+
+	// GetInvitesByEmail returns the invites sent to the email.
+	GetInvitesByEmail(ctx context.Context, email string) ([]domain.LedgerInvite, error)
+
+	// GetInviteByID returns an invite by id.
+	GetInviteByID(ctx context.Context, id int64) (domain.LedgerInvite, error)
+
+	// AcceptInvite adds the user to the ledger of the invite, and deletes the invite.
+	AcceptInvite(ctx context.Context, id, userID int64) error
+
+	// DeleteInvite deletes an invite by id.
+	DeleteInvite(ctx context.Context, id int64) error
+}
+
+// ContactRepo is the interface that wraps the basic methods for contact repository.
+type ContactRepo interface {
+	// Create inserts a new contact into the database.
+	Create(ctx context.Context, contact domain.Contact, userID int64) error
+
+	// GetAll returns all contacts by user id.
+	GetAll(ctx context.Context, userID int64) ([]domain.Contact, error)
+
+	// GetByIDAndUserID returns a contact by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Contact, error)
+
+	// GetByIDs returns the contacts of the user by ids, the ids not belonging to the user are skipped.
+	GetByIDs(ctx context.Context, ids []int64, userID int64) ([]domain.Contact, error)
+
+	// Update updates a contact.
+	Update(ctx context.Context, contact domain.Contact) error
+
+	// IsInUse returns true if the contact takes part in any shared transaction or settlement.
+	IsInUse(ctx context.Context, id int64) (bool, error)
+
+	// Delete deletes a contact by id.
+	Delete(ctx context.Context, id int64) error
+}
+
+// ShareRepo is the interface that wraps the basic methods for the shares of transactions.
+type ShareRepo interface {
+	// Upsert replaces the shares of a transaction.
+	Upsert(ctx context.Context, t domain.SharedTransaction) error
+
+	// GetByTransID returns the shares of a transaction.
+	GetByTransID(ctx context.Context, transID int64) (domain.SharedTransaction, error)
+
+	// GetAll returns all shared transactions by user id, the transactions in trash are excluded.
+	GetAll(ctx context.Context, userID int64) ([]domain.SharedTransaction, error)
+
+	// Delete deletes the shares of a transaction.
+	Delete(ctx context.Context, transID int64) error
+}
+
+// SettlementRepo is the interface that wraps the basic methods for settlement repository.
+type SettlementRepo interface {
+	// Create inserts a new settlement into the database.
+	Create(ctx context.Context, settlement domain.Settlement, userID int64) error
+
+	// GetAll returns all settlements by user id, from the newest to the oldest.
+	GetAll(ctx context.Context, userID int64) ([]domain.Settlement, error)
+
+	// GetByIDAndUserID returns a settlement by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Settlement, error)
+
+	// Delete deletes a settlement by id.
+	Delete(ctx context.Context, id int64) error
+}
+
+// SessionRepo is the interface that wraps the basic methods for session repository.
+type SessionRepo interface {
+	// Create inserts a new session with the hash of its refresh token, and returns the id.
+	Create(ctx context.Context, session domain.Session, refreshTokenHash string) (int64, error)
+
+	// GetByUserID returns all sessions of the user, from the most recently used.
+	GetByUserID(ctx context.Context, userID int64) ([]domain.Session, error)
+
+	// GetByIDAndUserID returns a session by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.Session, error)
+
+	// GetByRefreshTokenHash returns the session of the refresh token.
+	GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (domain.Session, error)
+
+	// RotateRefreshToken replaces the refresh token hash of the session if it still matches the old one.
+	RotateRefreshToken(ctx context.Context, id int64, oldHash, newHash string) error
+
+	// UpdateLastUsedAt marks the session as used now.
+	UpdateLastUsedAt(ctx context.Context, id int64) error
+
+	// Delete deletes a session by id.
+	Delete(ctx context.Context, id int64) error
+
+	// DeleteByUserID deletes all sessions of the user.
+	DeleteByUserID(ctx context.Context, userID int64) error
+}
+
+// APIKeyRepo is the interface that wraps the basic methods for API key repository.
+type APIKeyRepo interface {
+	// Create inserts a new API key with the hash of its key, and returns the id.
+	Create(ctx context.Context, key domain.APIKey, keyHash string) (int64, error)
+
+	// GetAll returns all API keys of the user.
+	GetAll(ctx context.Context, userID int64) ([]domain.APIKey, error)
+
+	// GetByIDAndUserID returns an API key by id and user id.
+	GetByIDAndUserID(ctx context.Context, id, userID int64) (domain.APIKey, error)
+
+	// GetByKeyHash returns the API key of the key hash.
+	GetByKeyHash(ctx context.Context, keyHash string) (domain.APIKey, error)
+
+	// UpdateLastUsedAt marks the API key as used now.
+	UpdateLastUsedAt(ctx context.Context, id int64) error
+
+	// Delete deletes an API key by id.
+	Delete(ctx context.Context, id int64) error
+}
+
+// TransRevisionRepo is the interface that wraps the basic methods for transaction revision repository.
+type TransRevisionRepo interface {
+	// GetByTransID returns the history of a transaction, from the oldest to the newest.
+	GetByTransID(ctx context.Context, transID int64) ([]domain.TransRevision, error)
+
+	// GetByIDAndTransID returns a revision by id and transaction id.
+	GetByIDAndTransID(ctx context.Context, id, transID int64) (domain.TransRevision, error)
+}
+
+// AttachmentRepo is the interface that wraps the basic methods for transaction attachment repository.
+type AttachmentRepo interface {
+	// Create inserts the metadata of an uploaded attachment.
+	Create(ctx context.Context, a domain.TransAttachment) error
+
+	// GetByIDAndTransID returns an attachment by id and transaction id.
+	GetByIDAndTransID(ctx context.Context, id, transID int64) (domain.TransAttachment, error)
+
+	// GetByPurgeableTrans returns the attachments of the transactions moved to trash before the given time.
+	GetByPurgeableTrans(ctx context.Context, before time.Time) ([]domain.TransAttachment, error)
+
+	// Delete deletes an attachment by id.
+	Delete(ctx context.Context, id int64) error
+}
+
+// TrashRepo is the interface that wraps the basic methods for trash repository.
+type TrashRepo interface {
+	// GetAll returns all items in trash by user id, except the ones whose category is also in trash.
+	GetAll(ctx context.Context, userID int64) ([]domain.TrashItem, error)
+
+	// Restore restores an item from trash, along with the items moved to trash with it.
+	// It returns ErrTrashParentInTrash if the category of the item is still in trash.
+	Restore(ctx context.Context, kind domain.TrashKind, id, userID int64) error
+
+	// Purge permanently deletes the items moved to trash before the given time.
+	Purge(ctx context.Context, before time.Time) error
+}
+
 // RedisService is the interface that wraps the basic methods for redis service.
 type RedisService interface {
 	// GetByFunc returns a value by key. If the value is not found, it will call the function to get the value and cache it.
@@ -163,6 +552,12 @@ type S3Service interface {
 	DeleteObject(ctx context.Context, objectKey string) error
 }
 
+// Mailer is the interface that wraps the basic methods for sending mails.
+type Mailer interface {
+	// Send sends the mail to the recipient.
+	Send(ctx context.Context, mail domain.Mail) error
+}
+
 // StockService is the interface that wraps the basic methods for stock service.
 type StockService interface {
 	// Create creates a new stock.
@@ -0,0 +1,181 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+)
+
+type UC struct {
+	Ledger interfaces.LedgerRepo
+	User   interfaces.UserRepo
+}
+
+func New(l interfaces.LedgerRepo, u interfaces.UserRepo) *UC {
+	return &UC{
+		Ledger: l,
+		User:   u,
+	}
+}
+
+func (u *UC) Create(ctx context.Context, name string, userID int64) (domain.Ledger, error) {
+	id, err := u.Ledger.Create(ctx, name, userID)
+	if err != nil {
+		return domain.Ledger{}, err
+	}
+
+	return u.Ledger.GetByIDAndUserID(ctx, id, userID)
+}
+
+func (u *UC) List(ctx context.Context, userID int64) ([]domain.Ledger, error) {
+	return u.Ledger.GetByUserID(ctx, userID)
+}
+
+// Resolve returns the ledger selected by the user, viewers are denied when the request writes the data of the ledger
+func (u *UC) Resolve(ctx context.Context, id, userID int64, write bool) (domain.Ledger, error) {
+	ledger, err := u.Ledger.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return domain.Ledger{}, err
+	}
+
+	if write && !ledger.Role.CanWrite() {
+		return domain.Ledger{}, domain.ErrLedgerPermissionDenied
+	}
+
+	return ledger, nil
+}
+
+func (u *UC) ListMembers(ctx context.Context, id, userID int64) ([]domain.LedgerMember, error) {
+	// check permission
+	if _, err := u.Ledger.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return u.Ledger.GetMembers(ctx, id)
+}
+
+func (u *UC) Invite(ctx context.Context, invite domain.LedgerInvite, userID int64) error {
+	ledger, err := u.getOwnedLedger(ctx, invite.LedgerID, userID)
+	if err != nil {
+		return err
+	}
+
+	if ledger.IsPersonal {
+		return domain.ErrLedgerPersonal
+	}
+
+	invite.InvitedBy = userID
+	return u.Ledger.CreateInvite(ctx, invite)
+}
+
+func (u *UC) ListInvites(ctx context.Context, email string) ([]domain.LedgerInvite, error) {
+	return u.Ledger.GetInvitesByEmail(ctx, email)
+}
+
+func (u *UC) AcceptInvite(ctx context.Context, id int64, user domain.User) error {
+	if _, err := u.getInviteOfUser(ctx, id, user.ID); err != nil {
+		return err
+	}
+
+	return u.Ledger.AcceptInvite(ctx, id, user.ID)
+}
+
+func (u *UC) DeclineInvite(ctx context.Context, id int64, user domain.User) error {
+	if _, err := u.getInviteOfUser(ctx, id, user.ID); err != nil {
+		return err
+	}
+
+	return u.Ledger.DeleteInvite(ctx, id)
+}
+
+func (u *UC) UpdateMemberRole(ctx context.Context, id, memberID int64, role domain.LedgerRoleType, userID int64) error {
+	if _, err := u.getOwnedLedger(ctx, id, userID); err != nil {
+		return err
+	}
+
+	member, err := u.getMember(ctx, id, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == domain.LedgerRoleTypeOwner {
+		return domain.ErrLedgerOwnerUnchangeable
+	}
+
+	return u.Ledger.UpdateMemberRole(ctx, id, memberID, role)
+}
+
+// RemoveMember removes a member from the ledger, the owner removes others, and the others leave by removing themselves
+func (u *UC) RemoveMember(ctx context.Context, id, memberID, userID int64) error {
+	ledger, err := u.Ledger.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if memberID != userID && ledger.Role != domain.LedgerRoleTypeOwner {
+		return domain.ErrLedgerPermissionDenied
+	}
+
+	member, err := u.getMember(ctx, id, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == domain.LedgerRoleTypeOwner {
+		return domain.ErrLedgerOwnerUnchangeable
+	}
+
+	return u.Ledger.DeleteMember(ctx, id, memberID)
+}
+
+// getOwnedLedger returns the ledger if the user is the owner
+func (u *UC) getOwnedLedger(ctx context.Context, id, userID int64) (domain.Ledger, error) {
+	ledger, err := u.Ledger.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return domain.Ledger{}, err
+	}
+
+	if ledger.Role != domain.LedgerRoleTypeOwner {
+		return domain.Ledger{}, domain.ErrLedgerPermissionDenied
+	}
+
+	return ledger, nil
+}
+
+// getMember returns the ledger with the role of the member
+func (u *UC) getMember(ctx context.Context, id, memberID int64) (domain.Ledger, error) {
+	member, err := u.Ledger.GetByIDAndUserID(ctx, id, memberID)
+	if errors.Is(err, domain.ErrLedgerNotFound) {
+		return domain.Ledger{}, domain.ErrLedgerMemberNotFound
+	}
+
+	return member, err
+}
+
+// getInviteOfUser returns the invite if it's sent to the verified email of the user.
+// The user is loaded from the database, so that the email and its verification are up to date.
+func (u *UC) getInviteOfUser(ctx context.Context, id, userID int64) (domain.LedgerInvite, error) {
+	invite, err := u.Ledger.GetInviteByID(ctx, id)
+	if err != nil {
+		return domain.LedgerInvite{}, err
+	}
+
+	user, err := u.User.GetInfo(userID)
+	if err != nil {
+		return domain.LedgerInvite{}, err
+	}
+
+	if !strings.EqualFold(invite.Email, user.Email) {
+		return domain.LedgerInvite{}, domain.ErrLedgerInviteNotFound
+	}
+
+	// anyone can sign up with the email, only the owner of the email can verify it
+	if !user.IsEmailVerified {
+		return domain.LedgerInvite{}, domain.ErrEmailNotVerified
+	}
+
+	return invite, nil
+}
@@ -0,0 +1,252 @@
+package ledger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type LedgerSuite struct {
+	suite.Suite
+	uc             *UC
+	mockLedgerRepo *mocks.LedgerRepo
+	mockUserRepo   *mocks.UserRepo
+}
+
+func TestLedgerSuite(t *testing.T) {
+	suite.Run(t, new(LedgerSuite))
+}
+
+func (s *LedgerSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *LedgerSuite) SetupTest() {
+	s.mockLedgerRepo = mocks.NewLedgerRepo(s.T())
+	s.mockUserRepo = mocks.NewUserRepo(s.T())
+	s.uc = New(s.mockLedgerRepo, s.mockUserRepo)
+}
+
+func (s *LedgerSuite) TearDownTest() {
+	s.mockLedgerRepo.AssertExpectations(s.T())
+	s.mockUserRepo.AssertExpectations(s.T())
+}
+
+func (s *LedgerSuite) TestResolve() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when viewer reads, return ledger":  resolve_ViewerReads_ReturnLedger,
+		"when viewer writes, return error":  resolve_ViewerWrites_ReturnError,
+		"when editor writes, return ledger": resolve_EditorWrites_ReturnLedger,
+		"when not a member, return error":   resolve_NotMember_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func resolve_ViewerReads_ReturnLedger(s *LedgerSuite, desc string) {
+	ledger := domain.Ledger{ID: 1, UserID: 10, Role: domain.LedgerRoleTypeViewer}
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).Return(ledger, nil).Once()
+
+	res, err := s.uc.Resolve(mockCtx, 1, 2, false)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(ledger, res, desc)
+}
+
+func resolve_ViewerWrites_ReturnError(s *LedgerSuite, desc string) {
+	ledger := domain.Ledger{ID: 1, UserID: 10, Role: domain.LedgerRoleTypeViewer}
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).Return(ledger, nil).Once()
+
+	res, err := s.uc.Resolve(mockCtx, 1, 2, true)
+	s.Require().ErrorIs(err, domain.ErrLedgerPermissionDenied, desc)
+	s.Require().Empty(res, desc)
+}
+
+func resolve_EditorWrites_ReturnLedger(s *LedgerSuite, desc string) {
+	ledger := domain.Ledger{ID: 1, UserID: 10, Role: domain.LedgerRoleTypeEditor}
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).Return(ledger, nil).Once()
+
+	res, err := s.uc.Resolve(mockCtx, 1, 2, true)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(ledger, res, desc)
+}
+
+func resolve_NotMember_ReturnError(s *LedgerSuite, desc string) {
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).Return(domain.Ledger{}, domain.ErrLedgerNotFound).Once()
+
+	res, err := s.uc.Resolve(mockCtx, 1, 2, false)
+	s.Require().ErrorIs(err, domain.ErrLedgerNotFound, desc)
+	s.Require().Empty(res, desc)
+}
+
+func (s *LedgerSuite) TestInvite() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when owner invites, create invite":     invite_OwnerInvites_CreateInvite,
+		"when editor invites, return error":     invite_EditorInvites_ReturnError,
+		"when ledger is personal, return error": invite_PersonalLedger_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func invite_OwnerInvites_CreateInvite(s *LedgerSuite, desc string) {
+	invite := domain.LedgerInvite{LedgerID: 1, Email: "a@a.com", Role: domain.LedgerRoleTypeEditor}
+	expInvite := invite
+	expInvite.InvitedBy = 2
+
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).
+		Return(domain.Ledger{ID: 1, Role: domain.LedgerRoleTypeOwner}, nil).Once()
+	s.mockLedgerRepo.On("CreateInvite", mockCtx, expInvite).Return(nil).Once()
+
+	err := s.uc.Invite(mockCtx, invite, 2)
+	s.Require().NoError(err, desc)
+}
+
+func invite_EditorInvites_ReturnError(s *LedgerSuite, desc string) {
+	invite := domain.LedgerInvite{LedgerID: 1, Email: "a@a.com", Role: domain.LedgerRoleTypeEditor}
+
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).
+		Return(domain.Ledger{ID: 1, Role: domain.LedgerRoleTypeEditor}, nil).Once()
+
+	err := s.uc.Invite(mockCtx, invite, 2)
+	s.Require().ErrorIs(err, domain.ErrLedgerPermissionDenied, desc)
+}
+
+func invite_PersonalLedger_ReturnError(s *LedgerSuite, desc string) {
+	invite := domain.LedgerInvite{LedgerID: 1, Email: "a@a.com", Role: domain.LedgerRoleTypeEditor}
+
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).
+		Return(domain.Ledger{ID: 1, IsPersonal: true, Role: domain.LedgerRoleTypeOwner}, nil).Once()
+
+	err := s.uc.Invite(mockCtx, invite, 2)
+	s.Require().ErrorIs(err, domain.ErrLedgerPersonal, desc)
+}
+
+func (s *LedgerSuite) TestAcceptInvite() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when email matches, accept invite":      acceptInvite_EmailMatches_AcceptInvite,
+		"when email not match, return error":     acceptInvite_EmailNotMatch_ReturnError,
+		"when email not verified, return error":  acceptInvite_EmailNotVerified_ReturnError,
+		"when claims are stale, use stored user": acceptInvite_StaleClaims_UseStoredUser,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func acceptInvite_EmailMatches_AcceptInvite(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 2, Email: "A@a.com", IsEmailVerified: true}
+
+	s.mockLedgerRepo.On("GetInviteByID", mockCtx, int64(1)).
+		Return(domain.LedgerInvite{ID: 1, LedgerID: 3, Email: "a@a.com"}, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(2)).Return(user, nil).Once()
+	s.mockLedgerRepo.On("AcceptInvite", mockCtx, int64(1), int64(2)).Return(nil).Once()
+
+	err := s.uc.AcceptInvite(mockCtx, 1, user)
+	s.Require().NoError(err, desc)
+}
+
+func acceptInvite_EmailNotMatch_ReturnError(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 2, Email: "b@b.com", IsEmailVerified: true}
+
+	s.mockLedgerRepo.On("GetInviteByID", mockCtx, int64(1)).
+		Return(domain.LedgerInvite{ID: 1, LedgerID: 3, Email: "a@a.com"}, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(2)).Return(user, nil).Once()
+
+	err := s.uc.AcceptInvite(mockCtx, 1, user)
+	s.Require().ErrorIs(err, domain.ErrLedgerInviteNotFound, desc)
+}
+
+func acceptInvite_EmailNotVerified_ReturnError(s *LedgerSuite, desc string) {
+	user := domain.User{ID: 2, Email: "a@a.com"}
+
+	s.mockLedgerRepo.On("GetInviteByID", mockCtx, int64(1)).
+		Return(domain.LedgerInvite{ID: 1, LedgerID: 3, Email: "a@a.com"}, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(2)).Return(user, nil).Once()
+
+	err := s.uc.AcceptInvite(mockCtx, 1, user)
+	s.Require().ErrorIs(err, domain.ErrEmailNotVerified, desc)
+}
+
+func acceptInvite_StaleClaims_UseStoredUser(s *LedgerSuite, desc string) {
+	// the claims of the token say the email is verified, but the stored user doesn't
+	claimedUser := domain.User{ID: 2, Email: "a@a.com", IsEmailVerified: true}
+	storedUser := domain.User{ID: 2, Email: "a@a.com"}
+
+	s.mockLedgerRepo.On("GetInviteByID", mockCtx, int64(1)).
+		Return(domain.LedgerInvite{ID: 1, LedgerID: 3, Email: "a@a.com"}, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(2)).Return(storedUser, nil).Once()
+
+	err := s.uc.AcceptInvite(mockCtx, 1, claimedUser)
+	s.Require().ErrorIs(err, domain.ErrEmailNotVerified, desc)
+}
+
+func (s *LedgerSuite) TestRemoveMember() {
+	for scenario, fn := range map[string]func(s *LedgerSuite, desc string){
+		"when owner removes member, delete member": removeMember_OwnerRemovesMember_DeleteMember,
+		"when member leaves, delete member":        removeMember_MemberLeaves_DeleteMember,
+		"when editor removes other, return error":  removeMember_EditorRemovesOther_ReturnError,
+		"when owner removes self, return error":    removeMember_OwnerRemovesSelf_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func removeMember_OwnerRemovesMember_DeleteMember(s *LedgerSuite, desc string) {
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).
+		Return(domain.Ledger{ID: 1, Role: domain.LedgerRoleTypeOwner}, nil).Once()
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(3)).
+		Return(domain.Ledger{ID: 1, Role: domain.LedgerRoleTypeViewer}, nil).Once()
+	s.mockLedgerRepo.On("DeleteMember", mockCtx, int64(1), int64(3)).Return(nil).Once()
+
+	err := s.uc.RemoveMember(mockCtx, 1, 3, 2)
+	s.Require().NoError(err, desc)
+}
+
+func removeMember_MemberLeaves_DeleteMember(s *LedgerSuite, desc string) {
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(3)).
+		Return(domain.Ledger{ID: 1, Role: domain.LedgerRoleTypeEditor}, nil).Twice()
+	s.mockLedgerRepo.On("DeleteMember", mockCtx, int64(1), int64(3)).Return(nil).Once()
+
+	err := s.uc.RemoveMember(mockCtx, 1, 3, 3)
+	s.Require().NoError(err, desc)
+}
+
+func removeMember_EditorRemovesOther_ReturnError(s *LedgerSuite, desc string) {
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).
+		Return(domain.Ledger{ID: 1, Role: domain.LedgerRoleTypeEditor}, nil).Once()
+
+	err := s.uc.RemoveMember(mockCtx, 1, 3, 2)
+	s.Require().ErrorIs(err, domain.ErrLedgerPermissionDenied, desc)
+}
+
+func removeMember_OwnerRemovesSelf_ReturnError(s *LedgerSuite, desc string) {
+	s.mockLedgerRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(2)).
+		Return(domain.Ledger{ID: 1, Role: domain.LedgerRoleTypeOwner}, nil).Twice()
+
+	err := s.uc.RemoveMember(mockCtx, 1, 2, 2)
+	s.Require().ErrorIs(err, domain.ErrLedgerOwnerUnchangeable, desc)
+}
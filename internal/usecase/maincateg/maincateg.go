@@ -7,6 +7,7 @@ import (
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
 )
 
 type UC struct {
@@ -58,8 +59,8 @@ func (u *UC) Create(ctx context.Context, categ domain.CreateMainCategInput, user
 	return u.MainCateg.Create(ctx, c, userID)
 }
 
-func (u *UC) GetAll(ctx context.Context, userID int64, transType domain.TransactionType) ([]domain.MainCateg, error) {
-	categs, err := u.MainCateg.GetAll(ctx, userID, transType)
+func (u *UC) GetAll(ctx context.Context, userID int64, transType domain.TransactionType, includeArchived bool) ([]domain.MainCateg, error) {
+	categs, err := u.MainCateg.GetAll(ctx, userID, transType, includeArchived)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +128,57 @@ func (u *UC) Update(ctx context.Context, categ domain.UpdateMainCategInput, user
 	return u.MainCateg.Update(ctx, c)
 }
 
-func (u *UC) Delete(id int64) error {
+func (u *UC) Delete(ctx context.Context, id int64) error {
+	if !ctxutil.GetLedgerRole(ctx).CanManage() {
+		return domain.ErrLedgerPermissionDenied
+	}
+
 	return u.MainCateg.Delete(id)
 }
+
+func (u *UC) Merge(ctx context.Context, id, targetID, userID int64) error {
+	categ, err := u.MainCateg.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	target, err := u.MainCateg.GetByID(targetID, userID)
+	if err != nil {
+		return err
+	}
+
+	if categ.Type != target.Type {
+		return domain.ErrMainCategTypeNotSame
+	}
+
+	return u.MainCateg.Merge(ctx, id, targetID)
+}
+
+func (u *UC) SetArchived(ctx context.Context, id int64, archived bool, userID int64) error {
+	// check if the main category exists
+	if _, err := u.MainCateg.GetByID(id, userID); err != nil {
+		return err
+	}
+
+	return u.MainCateg.SetArchived(ctx, id, archived)
+}
+
+func (u *UC) Reorder(ctx context.Context, ids []int64, userID int64) error {
+	categs, err := u.MainCateg.GetAll(ctx, userID, domain.TransactionTypeUnSpecified, true)
+	if err != nil {
+		return err
+	}
+
+	// check if all the main categories belong to the user
+	userCategIDs := make(map[int64]bool, len(categs))
+	for _, c := range categs {
+		userCategIDs[c.ID] = true
+	}
+	for _, id := range ids {
+		if !userCategIDs[id] {
+			return domain.ErrMainCategNotFound
+		}
+	}
+
+	return u.MainCateg.Reorder(ctx, ids)
+}
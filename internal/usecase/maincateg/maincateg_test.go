@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -160,10 +163,10 @@ func getAll_WithOnlyDefaultIcon_ReturnMainCategories(s *MainCategSuite, desc str
 	}
 
 	// prepare mock service
-	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeExpense).Return(mockMainCategs, nil)
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeExpense, false).Return(mockMainCategs, nil)
 
 	// action, assertion
-	res, err := s.uc.GetAll(mockCtx, mockUserID, domain.TransactionTypeExpense)
+	res, err := s.uc.GetAll(mockCtx, mockUserID, domain.TransactionTypeExpense, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(mockMainCategs, res, desc)
 }
@@ -181,12 +184,12 @@ func getAll_WithOnlyUserIcon_ReturnMainCategories(s *MainCategSuite, desc string
 	mockKey2 := fmt.Sprintf("user_icon-%s", mockMainCategs[1].IconData)
 
 	// prepare mock service
-	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeExpense).Return(mockMainCategs, nil)
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeExpense, false).Return(mockMainCategs, nil)
 	s.mockRedisService.On("GetByFunc", mockCtx, mockKey1, mockTTL, mockGetFun).Return("https://example.com/icon1.png", nil)
 	s.mockRedisService.On("GetByFunc", mockCtx, mockKey2, mockTTL, mockGetFun).Return("https://example.com/icon2.png", nil)
 
 	// action, assertion
-	res, err := s.uc.GetAll(mockCtx, mockUserID, domain.TransactionTypeExpense)
+	res, err := s.uc.GetAll(mockCtx, mockUserID, domain.TransactionTypeExpense, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(mockMainCategs, res, desc)
 }
@@ -204,11 +207,11 @@ func getAll_GetObjectURLFail_ReturnError(s *MainCategSuite, desc string) {
 	mockErr := errors.New("get object url failed")
 
 	// prepare mock service
-	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeExpense).Return(mockMainCategs, nil)
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeExpense, false).Return(mockMainCategs, nil)
 	s.mockRedisService.On("GetByFunc", mockCtx, mockKey, mockTTL, mockGetFun).Return("", mockErr)
 
 	// action, assertion
-	res, err := s.uc.GetAll(mockCtx, mockUserID, domain.TransactionTypeExpense)
+	res, err := s.uc.GetAll(mockCtx, mockUserID, domain.TransactionTypeExpense, false)
 	s.Require().ErrorIs(err, mockErr, desc)
 	s.Require().Empty(res, desc)
 }
@@ -330,7 +333,8 @@ func update_UserIconNotFound_ReturnError(s *MainCategSuite, desc string) {
 
 func (s *MainCategSuite) TestDelete() {
 	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
-		"when no error, delete successfully": delete_NoError_DeleteSuccessfully,
+		"when no error, delete successfully":     delete_NoError_DeleteSuccessfully,
+		"when not owner of ledger, return error": delete_NotOwner_ReturnError,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -348,6 +352,163 @@ func delete_NoError_DeleteSuccessfully(s *MainCategSuite, desc string) {
 	s.mockMainCategRepo.On("Delete", mockID).Return(nil)
 
 	// action, assertion
-	err := s.uc.Delete(mockID)
+	err := s.uc.Delete(mockCtx, mockID)
 	s.Require().NoError(err, desc)
 }
+
+func delete_NotOwner_ReturnError(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockID := int64(1)
+	req := ctxutil.SetLedger(httptest.NewRequest(http.MethodDelete, "/", nil), &domain.Ledger{ID: 1, UserID: 2, Role: domain.LedgerRoleTypeEditor})
+
+	// action, assertion
+	err := s.uc.Delete(req.Context(), mockID)
+	s.Require().ErrorIs(err, domain.ErrLedgerPermissionDenied, desc)
+}
+
+func (s *MainCategSuite) TestMerge() {
+	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
+		"when no error, merge successfully":    merge_NoError_MergeSuccessfully,
+		"when target not exist, return error":  merge_TargetNotExist_ReturnError,
+		"when type not the same, return error": merge_TypeNotSame_ReturnError,
+		"when name conflict, return error":     merge_NameConflict_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func merge_NoError_MergeSuccessfully(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), mockUserID).Return(&domain.MainCateg{ID: 2, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("Merge", mockCtx, int64(1), int64(2)).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.Merge(mockCtx, 1, 2, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func merge_TargetNotExist_ReturnError(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), mockUserID).Return(nil, domain.ErrMainCategNotFound).Once()
+
+	// action, assertion
+	err := s.uc.Merge(mockCtx, 1, 2, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotFound, desc)
+}
+
+func merge_TypeNotSame_ReturnError(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), mockUserID).Return(&domain.MainCateg{ID: 2, Type: domain.TransactionTypeIncome}, nil).Once()
+
+	// action, assertion
+	err := s.uc.Merge(mockCtx, 1, 2, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrMainCategTypeNotSame, desc)
+}
+
+func merge_NameConflict_ReturnError(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), mockUserID).Return(&domain.MainCateg{ID: 2, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("Merge", mockCtx, int64(1), int64(2)).Return(domain.ErrUniqueNameUserMainCateg).Once()
+
+	// action, assertion
+	err := s.uc.Merge(mockCtx, 1, 2, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrUniqueNameUserMainCateg, desc)
+}
+
+func (s *MainCategSuite) TestSetArchived() {
+	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
+		"when no error, set successfully":            setArchived_NoError_SetSuccessfully,
+		"when main category not exist, return error": setArchived_MainCategNotExist_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func setArchived_NoError_SetSuccessfully(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(&domain.MainCateg{ID: 1}, nil).Once()
+	s.mockMainCategRepo.On("SetArchived", mockCtx, int64(1), true).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.SetArchived(mockCtx, 1, true, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func setArchived_MainCategNotExist_ReturnError(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(nil, domain.ErrMainCategNotFound).Once()
+
+	// action, assertion
+	err := s.uc.SetArchived(mockCtx, 1, true, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotFound, desc)
+}
+
+func (s *MainCategSuite) TestReorder() {
+	for scenario, fn := range map[string]func(s *MainCategSuite, desc string){
+		"when all belong to user, reorder successfully": reorder_AllBelongToUser_ReorderSuccessfully,
+		"when any not belong to user, return error":     reorder_AnyNotBelongToUser_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func reorder_AllBelongToUser_ReorderSuccessfully(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockCategs := []domain.MainCateg{{ID: 1}, {ID: 2}, {ID: 3, IsArchived: true}}
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).Return(mockCategs, nil).Once()
+	s.mockMainCategRepo.On("Reorder", mockCtx, []int64{3, 1}).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.Reorder(mockCtx, []int64{3, 1}, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func reorder_AnyNotBelongToUser_ReturnError(s *MainCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockCategs := []domain.MainCateg{{ID: 1}, {ID: 2}}
+
+	// prepare mock service
+	s.mockMainCategRepo.On("GetAll", mockCtx, mockUserID, domain.TransactionTypeUnSpecified, true).Return(mockCategs, nil).Once()
+
+	// action, assertion
+	err := s.uc.Reorder(mockCtx, []int64{2, 9}, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotFound, desc)
+}
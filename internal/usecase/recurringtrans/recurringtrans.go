@@ -0,0 +1,176 @@
+package recurringtrans
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "usecase/recurringtrans"
+)
+
+var (
+	now = func() time.Time {
+		return time.Now()
+	}
+)
+
+type UC struct {
+	RecurringTrans interfaces.RecurringTransRepo
+	MainCateg      interfaces.MainCategRepo
+	SubCateg       interfaces.SubCategRepo
+	Transaction    interfaces.TransactionCreator
+}
+
+func New(rt interfaces.RecurringTransRepo,
+	m interfaces.MainCategRepo,
+	s interfaces.SubCategRepo,
+	t interfaces.TransactionCreator) *UC {
+	return &UC{
+		RecurringTrans: rt,
+		MainCateg:      m,
+		SubCateg:       s,
+		Transaction:    t,
+	}
+}
+
+func (u *UC) Create(ctx context.Context, rt domain.RecurringTrans) error {
+	if err := u.checkCateg(rt.Template); err != nil {
+		return err
+	}
+
+	rt.NextDate = rt.Schedule.StartDate
+	return u.RecurringTrans.Create(ctx, rt)
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.RecurringTrans, error) {
+	return u.RecurringTrans.GetAll(ctx, userID)
+}
+
+func (u *UC) Update(ctx context.Context, input domain.UpdateRecurringTransInput, userID int64) error {
+	input.Template.UserID = userID
+	if err := u.checkCateg(input.Template); err != nil {
+		return err
+	}
+
+	// check permission
+	existing, err := u.RecurringTrans.GetByIDAndUserID(ctx, input.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	// the schedule may be changed, so the next date is recalculated from today
+	// occurrences before today are not materialized again,
+	// and neither is today's one when today's run has claimed it by moving the next date after today
+	today := truncateToDate(now())
+	from := today.AddDate(0, 0, -1)
+	if existing.NextDate.After(today) {
+		from = today
+	}
+	rt := domain.RecurringTrans{
+		ID:       input.ID,
+		Template: input.Template,
+		Schedule: input.Schedule,
+		NextDate: input.Schedule.Next(from),
+	}
+
+	return u.RecurringTrans.Update(ctx, rt)
+}
+
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.RecurringTrans.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return u.RecurringTrans.Delete(ctx, id)
+}
+
+// Materialize creates transactions for all occurrences due on or before the given date.
+// Occurrences missed by previous runs are caught up, and each occurrence is created at most once.
+func (u *UC) Materialize(ctx context.Context, date time.Time) error {
+	date = truncateToDate(date)
+
+	rts, err := u.RecurringTrans.GetDue(ctx, date)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, rt := range rts {
+		if err := u.materializeOne(ctx, rt, date); err != nil {
+			logger.Error("materializeOne failed", "package", packageName, "id", rt.ID, "err", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (u *UC) materializeOne(ctx context.Context, rt domain.RecurringTrans, date time.Time) error {
+	for d := rt.NextDate; !d.After(date) && !rt.Schedule.IsEnded(d); {
+		next := rt.Schedule.Next(d)
+
+		// claim the occurrence before creating the transaction,
+		// so that concurrent or repeated runs never create the same occurrence twice
+		if err := u.RecurringTrans.UpdateNextDate(ctx, rt.ID, d, next); err != nil {
+			if errors.Is(err, domain.ErrRecurringTransNotDue) {
+				return nil
+			}
+			return err
+		}
+
+		trans := rt.Template
+		trans.Date = d
+		trans.IsRecurring = true
+		// the occurrences are expected to look alike, so the suspected duplicates are ignored
+		if _, err := u.Transaction.Create(ctx, trans, false); err != nil {
+			// release the occurrence, so that the next run can retry it
+			if revertErr := u.RecurringTrans.UpdateNextDate(ctx, rt.ID, next, d); revertErr != nil {
+				return errors.Join(err, revertErr)
+			}
+			return err
+		}
+
+		d = next
+	}
+
+	return nil
+}
+
+func (u *UC) checkCateg(trans domain.CreateTransactionInput) error {
+	// check if the main category exists
+	mainCateg, err := u.MainCateg.GetByID(trans.MainCategID, trans.UserID)
+	if err != nil {
+		return err
+	}
+
+	// check if the type in main category matches the transaction type
+	if trans.Type != mainCateg.Type {
+		logger.Error("checkCateg failed", "package", packageName, "err", domain.ErrTypeNotConsistent)
+		return domain.ErrTypeNotConsistent
+	}
+
+	// check if the sub category exists
+	subCateg, err := u.SubCateg.GetByID(trans.SubCategID, trans.UserID)
+	if err != nil {
+		return err
+	}
+
+	// check if the sub category matches the main category
+	if subCateg.MainCategID != trans.MainCategID {
+		logger.Error("checkCateg failed", "package", packageName, "err", domain.ErrMainCategNotConsistent)
+		return domain.ErrMainCategNotConsistent
+	}
+
+	return nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
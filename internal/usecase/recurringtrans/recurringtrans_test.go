@@ -0,0 +1,376 @@
+package recurringtrans
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx     = context.Background()
+	mockTimeNow = time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+)
+
+type RecurringTransSuite struct {
+	suite.Suite
+	uc                     *UC
+	mockRecurringTransRepo *mocks.RecurringTransRepo
+	mockMainCategRepo      *mocks.MainCategRepo
+	mockSubCategRepo       *mocks.SubCategRepo
+	mockTransaction        *mocks.TransactionCreator
+}
+
+func TestRecurringTransSuite(t *testing.T) {
+	suite.Run(t, new(RecurringTransSuite))
+}
+
+func (s *RecurringTransSuite) SetupSuite() {
+	logger.Register()
+}
+
+func setNow(t time.Time) {
+	now = func() time.Time {
+		return t
+	}
+}
+
+func resetNow() {
+	now = func() time.Time {
+		return time.Now()
+	}
+}
+
+func (s *RecurringTransSuite) SetupTest() {
+	s.mockRecurringTransRepo = mocks.NewRecurringTransRepo(s.T())
+	s.mockMainCategRepo = mocks.NewMainCategRepo(s.T())
+	s.mockSubCategRepo = mocks.NewSubCategRepo(s.T())
+	s.mockTransaction = mocks.NewTransactionCreator(s.T())
+	s.uc = New(s.mockRecurringTransRepo, s.mockMainCategRepo, s.mockSubCategRepo, s.mockTransaction)
+}
+
+func (s *RecurringTransSuite) TearDownTest() {
+	s.mockRecurringTransRepo.AssertExpectations(s.T())
+	s.mockMainCategRepo.AssertExpectations(s.T())
+	s.mockSubCategRepo.AssertExpectations(s.T())
+	s.mockTransaction.AssertExpectations(s.T())
+}
+
+func (s *RecurringTransSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when no error, create successfully":                                  create_NoError_CreateSuccessfully,
+		"when type of main category not match transaction type, return error": create_TypeNotMatch_ReturnError,
+		"when main category of sub category not match, return error":          create_MainCategNotMatch_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *RecurringTransSuite, desc string) {
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 2, MainCategID: 1}
+
+	input := genRecurringTrans()
+	expRT := input
+	expRT.NextDate = input.Schedule.StartDate
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&subCateg, nil).Once()
+	s.mockRecurringTransRepo.On("Create", mockCtx, expRT).Return(nil).Once()
+
+	err := s.uc.Create(mockCtx, input)
+	s.Require().NoError(err, desc)
+}
+
+func create_TypeNotMatch_ReturnError(s *RecurringTransSuite, desc string) {
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeIncome}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+
+	err := s.uc.Create(mockCtx, genRecurringTrans())
+	s.Require().ErrorIs(err, domain.ErrTypeNotConsistent, desc)
+}
+
+func create_MainCategNotMatch_ReturnError(s *RecurringTransSuite, desc string) {
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 2, MainCategID: 3}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&subCateg, nil).Once()
+
+	err := s.uc.Create(mockCtx, genRecurringTrans())
+	s.Require().ErrorIs(err, domain.ErrMainCategNotConsistent, desc)
+}
+
+func (s *RecurringTransSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when no error, recalculate next date and update":         update_NoError_RecalculateNextDate,
+		"when today's occurrence is claimed, start from tomorrow": update_TodayClaimed_StartFromTomorrow,
+		"when recurring transaction not found, return error":      update_NotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoError_RecalculateNextDate(s *RecurringTransSuite, desc string) {
+	setNow(mockTimeNow)
+	defer resetNow()
+
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 2, MainCategID: 1}
+
+	rt := genRecurringTrans()
+	input := domain.UpdateRecurringTransInput{
+		ID:       1,
+		Template: rt.Template,
+		Schedule: rt.Schedule,
+	}
+
+	// start date is 2024-01-10 with monthly frequency, so the first occurrence on or after 2024-03-15 is 2024-04-10
+	expRT := domain.RecurringTrans{
+		ID:       1,
+		Template: rt.Template,
+		Schedule: rt.Schedule,
+		NextDate: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&subCateg, nil).Once()
+	s.mockRecurringTransRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(rt, nil).Once()
+	s.mockRecurringTransRepo.On("Update", mockCtx, expRT).Return(nil).Once()
+
+	err := s.uc.Update(mockCtx, input, 1)
+	s.Require().NoError(err, desc)
+}
+
+func update_TodayClaimed_StartFromTomorrow(s *RecurringTransSuite, desc string) {
+	setNow(mockTimeNow)
+	defer resetNow()
+
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 2, MainCategID: 1}
+
+	// today's run has created the occurrence of 2024-03-15, and claimed it by moving the next date to 2024-03-16
+	rt := genRecurringTrans()
+	rt.Schedule.Freq = domain.RecurFreqTypeDaily
+	rt.NextDate = time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	input := domain.UpdateRecurringTransInput{
+		ID:       1,
+		Template: rt.Template,
+		Schedule: rt.Schedule,
+	}
+	input.Template.Price = 200
+
+	expRT := domain.RecurringTrans{
+		ID:       1,
+		Template: input.Template,
+		Schedule: rt.Schedule,
+		NextDate: time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC),
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&subCateg, nil).Once()
+	s.mockRecurringTransRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(rt, nil).Once()
+	s.mockRecurringTransRepo.On("Update", mockCtx, expRT).Return(nil).Once()
+
+	err := s.uc.Update(mockCtx, input, 1)
+	s.Require().NoError(err, desc)
+}
+
+func update_NotFound_ReturnError(s *RecurringTransSuite, desc string) {
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 2, MainCategID: 1}
+
+	rt := genRecurringTrans()
+	input := domain.UpdateRecurringTransInput{
+		ID:       1,
+		Template: rt.Template,
+		Schedule: rt.Schedule,
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&subCateg, nil).Once()
+	s.mockRecurringTransRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.RecurringTrans{}, domain.ErrRecurringTransNotFound).Once()
+
+	err := s.uc.Update(mockCtx, input, 1)
+	s.Require().ErrorIs(err, domain.ErrRecurringTransNotFound, desc)
+}
+
+func (s *RecurringTransSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when no error, delete successfully":                 delete_NoError_DeleteSuccessfully,
+		"when recurring transaction not found, return error": delete_NotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NoError_DeleteSuccessfully(s *RecurringTransSuite, desc string) {
+	s.mockRecurringTransRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(genRecurringTrans(), nil).Once()
+	s.mockRecurringTransRepo.On("Delete", mockCtx, int64(1)).Return(nil).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+}
+
+func delete_NotFound_ReturnError(s *RecurringTransSuite, desc string) {
+	s.mockRecurringTransRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.RecurringTrans{}, domain.ErrRecurringTransNotFound).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrRecurringTransNotFound, desc)
+}
+
+func (s *RecurringTransSuite) TestMaterialize() {
+	for scenario, fn := range map[string]func(s *RecurringTransSuite, desc string){
+		"when one occurrence is due, create one transaction":       materialize_OneDue_CreateOneTransaction,
+		"when runs were missed, catch up all occurrences":          materialize_MissedRuns_CatchUp,
+		"when schedule ends, stop at end date":                     materialize_ScheduleEnds_StopAtEndDate,
+		"when occurrence is claimed by another run, skip it":       materialize_AlreadyClaimed_Skip,
+		"when create transaction fail, release occurrence and err": materialize_CreateFail_ReleaseOccurrence,
+		"when get due fail, return error":                          materialize_GetDueFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func materialize_OneDue_CreateOneTransaction(s *RecurringTransSuite, desc string) {
+	rt := genRecurringTrans()
+	rt.NextDate = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	next := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	expTrans := rt.Template
+	expTrans.Date = rt.NextDate
+	expTrans.IsRecurring = true
+
+	s.mockRecurringTransRepo.On("GetDue", mockCtx, mockTimeNow).Return([]domain.RecurringTrans{rt}, nil).Once()
+	s.mockRecurringTransRepo.On("UpdateNextDate", mockCtx, rt.ID, rt.NextDate, next).Return(nil).Once()
+	s.mockTransaction.On("Create", mockCtx, expTrans, false).Return(nil, nil).Once()
+
+	err := s.uc.Materialize(mockCtx, mockTimeNow.Add(10*time.Hour))
+	s.Require().NoError(err, desc)
+}
+
+func materialize_MissedRuns_CatchUp(s *RecurringTransSuite, desc string) {
+	rt := genRecurringTrans()
+	rt.NextDate = rt.Schedule.StartDate
+	dates := []time.Time{
+		time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	s.mockRecurringTransRepo.On("GetDue", mockCtx, mockTimeNow).Return([]domain.RecurringTrans{rt}, nil).Once()
+	for i := 0; i < len(dates)-1; i++ {
+		expTrans := rt.Template
+		expTrans.Date = dates[i]
+		expTrans.IsRecurring = true
+
+		s.mockRecurringTransRepo.On("UpdateNextDate", mockCtx, rt.ID, dates[i], dates[i+1]).Return(nil).Once()
+		s.mockTransaction.On("Create", mockCtx, expTrans, false).Return(nil, nil).Once()
+	}
+
+	err := s.uc.Materialize(mockCtx, mockTimeNow)
+	s.Require().NoError(err, desc)
+}
+
+func materialize_ScheduleEnds_StopAtEndDate(s *RecurringTransSuite, desc string) {
+	rt := genRecurringTrans()
+	endDate := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+	rt.Schedule.EndDate = &endDate
+	rt.NextDate = time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	next := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	expTrans := rt.Template
+	expTrans.Date = rt.NextDate
+	expTrans.IsRecurring = true
+
+	s.mockRecurringTransRepo.On("GetDue", mockCtx, mockTimeNow).Return([]domain.RecurringTrans{rt}, nil).Once()
+	s.mockRecurringTransRepo.On("UpdateNextDate", mockCtx, rt.ID, rt.NextDate, next).Return(nil).Once()
+	s.mockTransaction.On("Create", mockCtx, expTrans, false).Return(nil, nil).Once()
+
+	err := s.uc.Materialize(mockCtx, mockTimeNow)
+	s.Require().NoError(err, desc)
+}
+
+func materialize_AlreadyClaimed_Skip(s *RecurringTransSuite, desc string) {
+	rt := genRecurringTrans()
+	rt.NextDate = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	next := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	s.mockRecurringTransRepo.On("GetDue", mockCtx, mockTimeNow).Return([]domain.RecurringTrans{rt}, nil).Once()
+	s.mockRecurringTransRepo.On("UpdateNextDate", mockCtx, rt.ID, rt.NextDate, next).Return(domain.ErrRecurringTransNotDue).Once()
+
+	err := s.uc.Materialize(mockCtx, mockTimeNow)
+	s.Require().NoError(err, desc)
+}
+
+func materialize_CreateFail_ReleaseOccurrence(s *RecurringTransSuite, desc string) {
+	rt := genRecurringTrans()
+	rt.NextDate = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	next := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	expTrans := rt.Template
+	expTrans.Date = rt.NextDate
+	expTrans.IsRecurring = true
+	mockErr := errors.New("create fail")
+
+	s.mockRecurringTransRepo.On("GetDue", mockCtx, mockTimeNow).Return([]domain.RecurringTrans{rt}, nil).Once()
+	s.mockRecurringTransRepo.On("UpdateNextDate", mockCtx, rt.ID, rt.NextDate, next).Return(nil).Once()
+	s.mockTransaction.On("Create", mockCtx, expTrans, false).Return(nil, mockErr).Once()
+	s.mockRecurringTransRepo.On("UpdateNextDate", mockCtx, rt.ID, next, rt.NextDate).Return(nil).Once()
+
+	err := s.uc.Materialize(mockCtx, mockTimeNow)
+	s.Require().ErrorIs(err, mockErr, desc)
+}
+
+func materialize_GetDueFail_ReturnError(s *RecurringTransSuite, desc string) {
+	mockErr := errors.New("get due fail")
+
+	s.mockRecurringTransRepo.On("GetDue", mockCtx, mockTimeNow).Return(nil, mockErr).Once()
+
+	err := s.uc.Materialize(mockCtx, mockTimeNow)
+	s.Require().ErrorIs(err, mockErr, desc)
+}
+
+func genRecurringTrans() domain.RecurringTrans {
+	return domain.RecurringTrans{
+		ID: 1,
+		Template: domain.CreateTransactionInput{
+			UserID:      1,
+			Type:        domain.TransactionTypeExpense,
+			MainCategID: 1,
+			SubCategID:  2,
+			Price:       100,
+			Note:        "rent",
+		},
+		Schedule: domain.RecurringSchedule{
+			Freq:      domain.RecurFreqTypeMonthly,
+			Interval:  1,
+			StartDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
@@ -0,0 +1,207 @@
+package rule
+
+import (
+	"context"
+	"slices"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "usecase/rule"
+)
+
+type UC struct {
+	Rule        interfaces.RuleRepo
+	MainCateg   interfaces.MainCategRepo
+	SubCateg    interfaces.SubCategRepo
+	Tag         interfaces.TagRepo
+	Transaction interfaces.TransactionRepo
+	Bulker      interfaces.TransactionBulker
+}
+
+func New(r interfaces.RuleRepo,
+	m interfaces.MainCategRepo,
+	s interfaces.SubCategRepo,
+	tg interfaces.TagRepo,
+	t interfaces.TransactionRepo,
+	b interfaces.TransactionBulker) *UC {
+	return &UC{
+		Rule:        r,
+		MainCateg:   m,
+		SubCateg:    s,
+		Tag:         tg,
+		Transaction: t,
+		Bulker:      b,
+	}
+}
+
+func (u *UC) Create(ctx context.Context, rule domain.Rule, userID int64) error {
+	if err := u.checkRefs(ctx, rule, userID); err != nil {
+		return err
+	}
+
+	return u.Rule.Create(ctx, rule, userID)
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.Rule, error) {
+	return u.Rule.GetAll(ctx, userID)
+}
+
+func (u *UC) Update(ctx context.Context, rule domain.Rule, userID int64) error {
+	// check permission
+	if _, err := u.Rule.GetByIDAndUserID(ctx, rule.ID, userID); err != nil {
+		return err
+	}
+
+	if err := u.checkRefs(ctx, rule, userID); err != nil {
+		return err
+	}
+
+	return u.Rule.Update(ctx, rule)
+}
+
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.Rule.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return u.Rule.Delete(ctx, id)
+}
+
+// Rerun matches the transactions in the date range against the current rules.
+// The matched transaction is changed to the categories of the rule, and the tags of the rule are added to it.
+// Transfer and split transactions are left out, the same as they're never categorized by rules when created.
+// The changes are applied together by bulk update, so they're recorded in the history of each transaction.
+func (u *UC) Rerun(ctx context.Context, opt domain.RuleRerunOpt, user domain.User) (domain.RuleRerunResult, error) {
+	// viewers can only preview the changes
+	if !opt.DryRun && !ctxutil.GetLedgerRole(ctx).CanWrite() {
+		return domain.RuleRerunResult{}, domain.ErrLedgerPermissionDenied
+	}
+
+	result := domain.RuleRerunResult{
+		DryRun:  opt.DryRun,
+		Changes: []domain.RuleChange{},
+	}
+
+	rules, err := u.Rule.GetAll(ctx, user.ID)
+	if err != nil {
+		return domain.RuleRerunResult{}, err
+	}
+	if len(rules) == 0 {
+		return result, nil
+	}
+
+	transOpt := domain.GetTransOpt{
+		Filter: domain.Filter{
+			StartDate: opt.StartDate,
+			EndDate:   opt.EndDate,
+		},
+	}
+	trans, _, err := u.Transaction.GetAll(ctx, transOpt, user.ID)
+	if err != nil {
+		return domain.RuleRerunResult{}, err
+	}
+
+	set := domain.NewRuleSet(rules)
+	var updates []domain.BulkUpdateTransInput
+	for _, t := range trans {
+		if t.Type == domain.TransactionTypeTransfer || len(t.Splits) > 0 {
+			continue
+		}
+
+		rule, ok := set.Match(t.Type, t.Price, t.Note)
+		if !ok {
+			continue
+		}
+
+		var addedTags []domain.Tag
+		for _, tag := range rule.Tags {
+			if !slices.ContainsFunc(t.Tags, func(tg domain.Tag) bool { return tg.ID == tag.ID }) {
+				addedTags = append(addedTags, tag)
+			}
+		}
+
+		isCategChanged := t.MainCateg.ID != rule.MainCateg.ID || t.SubCateg.ID != rule.SubCateg.ID
+		if !isCategChanged && len(addedTags) == 0 {
+			continue
+		}
+
+		result.Changes = append(result.Changes, domain.RuleChange{
+			TransactionID: t.ID,
+			RuleID:        rule.ID,
+			Date:          t.Date,
+			Price:         t.Price,
+			Note:          t.Note,
+			FromMainCateg: t.MainCateg,
+			FromSubCateg:  t.SubCateg,
+			ToMainCateg:   rule.MainCateg,
+			ToSubCateg:    rule.SubCateg,
+			AddedTags:     addedTags,
+		})
+
+		update := domain.BulkUpdateTransInput{ID: t.ID}
+		if isCategChanged {
+			update.MainCategID, update.SubCategID = &rule.MainCateg.ID, &rule.SubCateg.ID
+		}
+		for _, tag := range addedTags {
+			update.AddTagIDs = append(update.AddTagIDs, tag.ID)
+		}
+		updates = append(updates, update)
+	}
+
+	if opt.DryRun || len(updates) == 0 {
+		return result, nil
+	}
+
+	if _, err := u.Bulker.Bulk(ctx, domain.BulkTransInput{Update: updates}, user); err != nil {
+		return domain.RuleRerunResult{}, err
+	}
+
+	return result, nil
+}
+
+// checkRefs checks the categories and tags of the rule belong to the user,
+// and the main category is the same type as the matched transactions
+func (u *UC) checkRefs(ctx context.Context, rule domain.Rule, userID int64) error {
+	mainCateg, err := u.MainCateg.GetByID(rule.MainCateg.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	if mainCateg.Type != rule.Type {
+		logger.Error("checkRefs failed", "package", packageName, "err", domain.ErrTypeNotConsistent)
+		return domain.ErrTypeNotConsistent
+	}
+
+	subCateg, err := u.SubCateg.GetByID(rule.SubCateg.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	if subCateg.MainCategID != rule.MainCateg.ID {
+		logger.Error("checkRefs failed", "package", packageName, "err", domain.ErrMainCategNotConsistent)
+		return domain.ErrMainCategNotConsistent
+	}
+
+	tagIDs := rule.TagIDs()
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	tags, err := u.Tag.GetByIDs(ctx, tagIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(tags) != len(tagIDs) {
+		logger.Error("checkRefs failed", "package", packageName, "err", domain.ErrTagNotFound)
+		return domain.ErrTagNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,379 @@
+package rule
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx  = context.Background()
+	mockDate = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockUser = domain.User{ID: 1}
+)
+
+type RuleSuite struct {
+	suite.Suite
+	uc                  *UC
+	mockRuleRepo        *mocks.RuleRepo
+	mockMainCategRepo   *mocks.MainCategRepo
+	mockSubCategRepo    *mocks.SubCategRepo
+	mockTagRepo         *mocks.TagRepo
+	mockTransactionRepo *mocks.TransactionRepo
+	mockBulker          *mocks.TransactionBulker
+}
+
+func TestRuleSuite(t *testing.T) {
+	suite.Run(t, new(RuleSuite))
+}
+
+func (s *RuleSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *RuleSuite) SetupTest() {
+	s.mockRuleRepo = mocks.NewRuleRepo(s.T())
+	s.mockMainCategRepo = mocks.NewMainCategRepo(s.T())
+	s.mockSubCategRepo = mocks.NewSubCategRepo(s.T())
+	s.mockTagRepo = mocks.NewTagRepo(s.T())
+	s.mockTransactionRepo = mocks.NewTransactionRepo(s.T())
+	s.mockBulker = mocks.NewTransactionBulker(s.T())
+	s.uc = New(s.mockRuleRepo, s.mockMainCategRepo, s.mockSubCategRepo, s.mockTagRepo, s.mockTransactionRepo, s.mockBulker)
+}
+
+func (s *RuleSuite) TearDownTest() {
+	s.mockRuleRepo.AssertExpectations(s.T())
+	s.mockMainCategRepo.AssertExpectations(s.T())
+	s.mockSubCategRepo.AssertExpectations(s.T())
+	s.mockTagRepo.AssertExpectations(s.T())
+	s.mockTransactionRepo.AssertExpectations(s.T())
+	s.mockBulker.AssertExpectations(s.T())
+}
+
+func (s *RuleSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when no error, create successfully":                                 create_NoError_CreateSuccessfully,
+		"when type of main category not match rule type, return error":       create_TypeNotMatch_ReturnError,
+		"when main category of sub category not match, return error":         create_MainCategNotMatch_ReturnError,
+		"when tag not found, return error":                                   create_TagNotFound_ReturnError,
+		"when main category not found, return error without creating a rule": create_MainCategNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *RuleSuite, desc string) {
+	rule := domain.Rule{
+		Name:         "coffee",
+		Type:         domain.TransactionTypeExpense,
+		NoteContains: "starbucks",
+		MainCateg:    domain.MainCateg{ID: 1},
+		SubCateg:     domain.SubCateg{ID: 2},
+		Tags:         []domain.Tag{{ID: 3}},
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&domain.SubCateg{ID: 2, MainCategID: 1}, nil).Once()
+	s.mockTagRepo.On("GetByIDs", mockCtx, []int64{3}, int64(1)).Return([]domain.Tag{{ID: 3}}, nil).Once()
+	s.mockRuleRepo.On("Create", mockCtx, rule, int64(1)).Return(nil).Once()
+
+	err := s.uc.Create(mockCtx, rule, 1)
+	s.Require().NoError(err, desc)
+}
+
+func create_TypeNotMatch_ReturnError(s *RuleSuite, desc string) {
+	rule := domain.Rule{
+		Name:         "salary",
+		Type:         domain.TransactionTypeIncome,
+		NoteContains: "salary",
+		MainCateg:    domain.MainCateg{ID: 1},
+		SubCateg:     domain.SubCateg{ID: 2},
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+
+	err := s.uc.Create(mockCtx, rule, 1)
+	s.Require().ErrorIs(err, domain.ErrTypeNotConsistent, desc)
+}
+
+func create_MainCategNotMatch_ReturnError(s *RuleSuite, desc string) {
+	rule := domain.Rule{
+		Name:         "coffee",
+		Type:         domain.TransactionTypeExpense,
+		NoteContains: "starbucks",
+		MainCateg:    domain.MainCateg{ID: 1},
+		SubCateg:     domain.SubCateg{ID: 2},
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&domain.SubCateg{ID: 2, MainCategID: 9}, nil).Once()
+
+	err := s.uc.Create(mockCtx, rule, 1)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotConsistent, desc)
+}
+
+func create_TagNotFound_ReturnError(s *RuleSuite, desc string) {
+	rule := domain.Rule{
+		Name:         "coffee",
+		Type:         domain.TransactionTypeExpense,
+		NoteContains: "starbucks",
+		MainCateg:    domain.MainCateg{ID: 1},
+		SubCateg:     domain.SubCateg{ID: 2},
+		Tags:         []domain.Tag{{ID: 3}, {ID: 4}},
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&domain.SubCateg{ID: 2, MainCategID: 1}, nil).Once()
+	s.mockTagRepo.On("GetByIDs", mockCtx, []int64{3, 4}, int64(1)).Return([]domain.Tag{{ID: 3}}, nil).Once()
+
+	err := s.uc.Create(mockCtx, rule, 1)
+	s.Require().ErrorIs(err, domain.ErrTagNotFound, desc)
+}
+
+func create_MainCategNotFound_ReturnError(s *RuleSuite, desc string) {
+	rule := domain.Rule{
+		Name:      "coffee",
+		Type:      domain.TransactionTypeExpense,
+		NoteRegex: "^starbucks",
+		MainCateg: domain.MainCateg{ID: 1},
+		SubCateg:  domain.SubCateg{ID: 2},
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(nil, domain.ErrMainCategNotFound).Once()
+
+	err := s.uc.Create(mockCtx, rule, 1)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotFound, desc)
+}
+
+func (s *RuleSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when no error, update successfully": update_NoError_UpdateSuccessfully,
+		"when rule not found, return error":  update_RuleNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoError_UpdateSuccessfully(s *RuleSuite, desc string) {
+	rule := domain.Rule{
+		ID:           1,
+		Name:         "coffee",
+		Type:         domain.TransactionTypeExpense,
+		NoteContains: "starbucks",
+		MainCateg:    domain.MainCateg{ID: 1},
+		SubCateg:     domain.SubCateg{ID: 2},
+	}
+
+	s.mockRuleRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Rule{ID: 1}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&domain.SubCateg{ID: 2, MainCategID: 1}, nil).Once()
+	s.mockRuleRepo.On("Update", mockCtx, rule).Return(nil).Once()
+
+	err := s.uc.Update(mockCtx, rule, 1)
+	s.Require().NoError(err, desc)
+}
+
+func update_RuleNotFound_ReturnError(s *RuleSuite, desc string) {
+	rule := domain.Rule{ID: 1, Name: "coffee"}
+
+	s.mockRuleRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Rule{}, domain.ErrRuleNotFound).Once()
+
+	err := s.uc.Update(mockCtx, rule, 1)
+	s.Require().ErrorIs(err, domain.ErrRuleNotFound, desc)
+}
+
+func (s *RuleSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when no error, delete successfully": delete_NoError_DeleteSuccessfully,
+		"when rule not found, return error":  delete_RuleNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NoError_DeleteSuccessfully(s *RuleSuite, desc string) {
+	s.mockRuleRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Rule{ID: 1}, nil).Once()
+	s.mockRuleRepo.On("Delete", mockCtx, int64(1)).Return(nil).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+}
+
+func delete_RuleNotFound_ReturnError(s *RuleSuite, desc string) {
+	s.mockRuleRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Rule{}, domain.ErrRuleNotFound).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrRuleNotFound, desc)
+}
+
+func (s *RuleSuite) TestRerun() {
+	for scenario, fn := range map[string]func(s *RuleSuite, desc string){
+		"when dry run, return changes without applying":         rerun_DryRun_NotApply,
+		"when not dry run, apply changes by bulk update":        rerun_NotDryRun_ApplyByBulk,
+		"when no rule, return no change":                        rerun_NoRule_ReturnNoChange,
+		"when nothing changes, not call bulk update":            rerun_NothingChanges_NotCallBulk,
+		"when bulk update fail, return error":                   rerun_BulkFail_ReturnError,
+		"when transaction is split or transfer, leave it alone": rerun_SplitOrTransfer_LeaveAlone,
+		"when viewer not dry run, return error":                 rerun_ViewerNotDryRun_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+var (
+	mockRules = []domain.Rule{
+		{
+			ID:           1,
+			Type:         domain.TransactionTypeExpense,
+			NoteContains: "uber",
+			MainCateg:    domain.MainCateg{ID: 1, Name: "transport"},
+			SubCateg:     domain.SubCateg{ID: 2, Name: "taxi"},
+			Tags:         []domain.Tag{{ID: 3, Name: "work"}},
+		},
+	}
+	mockOpt = domain.GetTransOpt{
+		Filter: domain.Filter{
+			StartDate: &mockDate,
+		},
+	}
+)
+
+func rerun_DryRun_NotApply(s *RuleSuite, desc string) {
+	trans := []domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 5, Name: "food"}, SubCateg: domain.SubCateg{ID: 6, Name: "lunch"}, Price: 20, Date: mockDate, Note: "Uber Eats"},
+		{ID: 2, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 5}, SubCateg: domain.SubCateg{ID: 6}, Price: 10, Date: mockDate, Note: "noodle"},
+	}
+
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return(mockRules, nil).Once()
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).Return(trans, domain.DecodedNextKeys{}, nil).Once()
+
+	result, err := s.uc.Rerun(mockCtx, domain.RuleRerunOpt{StartDate: &mockDate, DryRun: true}, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.RuleRerunResult{
+		DryRun: true,
+		Changes: []domain.RuleChange{
+			{
+				TransactionID: 1,
+				RuleID:        1,
+				Date:          mockDate,
+				Price:         20,
+				Note:          "Uber Eats",
+				FromMainCateg: domain.MainCateg{ID: 5, Name: "food"},
+				FromSubCateg:  domain.SubCateg{ID: 6, Name: "lunch"},
+				ToMainCateg:   domain.MainCateg{ID: 1, Name: "transport"},
+				ToSubCateg:    domain.SubCateg{ID: 2, Name: "taxi"},
+				AddedTags:     []domain.Tag{{ID: 3, Name: "work"}},
+			},
+		},
+	}, result, desc)
+}
+
+func rerun_NotDryRun_ApplyByBulk(s *RuleSuite, desc string) {
+	trans := []domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 5}, SubCateg: domain.SubCateg{ID: 6}, Price: 20, Date: mockDate, Note: "uber"},
+		// only the tag is added, since the categories are the same
+		{ID: 2, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 1}, SubCateg: domain.SubCateg{ID: 2}, Price: 10, Date: mockDate, Note: "uber"},
+	}
+	mainCategID, subCategID := int64(1), int64(2)
+	bulkInput := domain.BulkTransInput{
+		Update: []domain.BulkUpdateTransInput{
+			{ID: 1, MainCategID: &mainCategID, SubCategID: &subCategID, AddTagIDs: []int64{3}},
+			{ID: 2, AddTagIDs: []int64{3}},
+		},
+	}
+
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return(mockRules, nil).Once()
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).Return(trans, domain.DecodedNextKeys{}, nil).Once()
+	s.mockBulker.On("Bulk", mockCtx, bulkInput, mockUser).Return(domain.BulkTransResult{Updated: 2}, nil).Once()
+
+	result, err := s.uc.Rerun(mockCtx, domain.RuleRerunOpt{StartDate: &mockDate}, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().False(result.DryRun, desc)
+	s.Require().Len(result.Changes, 2, desc)
+}
+
+func rerun_NoRule_ReturnNoChange(s *RuleSuite, desc string) {
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return([]domain.Rule{}, nil).Once()
+
+	result, err := s.uc.Rerun(mockCtx, domain.RuleRerunOpt{StartDate: &mockDate}, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.RuleRerunResult{Changes: []domain.RuleChange{}}, result, desc)
+}
+
+func rerun_NothingChanges_NotCallBulk(s *RuleSuite, desc string) {
+	trans := []domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 1}, SubCateg: domain.SubCateg{ID: 2}, Price: 20, Date: mockDate, Note: "uber", Tags: []domain.Tag{{ID: 3}}},
+		{ID: 2, Type: domain.TransactionTypeIncome, MainCateg: domain.MainCateg{ID: 7}, SubCateg: domain.SubCateg{ID: 8}, Price: 20, Date: mockDate, Note: "uber"},
+	}
+
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return(mockRules, nil).Once()
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).Return(trans, domain.DecodedNextKeys{}, nil).Once()
+
+	result, err := s.uc.Rerun(mockCtx, domain.RuleRerunOpt{StartDate: &mockDate}, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(result.Changes, desc)
+}
+
+func rerun_BulkFail_ReturnError(s *RuleSuite, desc string) {
+	trans := []domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 5}, SubCateg: domain.SubCateg{ID: 6}, Price: 20, Date: mockDate, Note: "uber"},
+	}
+	mockErr := errors.New("bulk fail")
+
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return(mockRules, nil).Once()
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).Return(trans, domain.DecodedNextKeys{}, nil).Once()
+	s.mockBulker.On("Bulk", mockCtx, domain.BulkTransInput{Update: []domain.BulkUpdateTransInput{{ID: 1, MainCategID: &mockRules[0].MainCateg.ID, SubCategID: &mockRules[0].SubCateg.ID, AddTagIDs: []int64{3}}}}, mockUser).
+		Return(domain.BulkTransResult{}, mockErr).Once()
+
+	result, err := s.uc.Rerun(mockCtx, domain.RuleRerunOpt{StartDate: &mockDate}, mockUser)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(result, desc)
+}
+
+func rerun_SplitOrTransfer_LeaveAlone(s *RuleSuite, desc string) {
+	trans := []domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 5}, SubCateg: domain.SubCateg{ID: 6}, Price: 20, Date: mockDate, Note: "uber", Splits: []domain.TransactionSplit{{Price: 10}, {Price: 10}}},
+		{ID: 2, Type: domain.TransactionTypeTransfer, Price: 20, Date: mockDate, Note: "uber"},
+	}
+
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return(mockRules, nil).Once()
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).Return(trans, domain.DecodedNextKeys{}, nil).Once()
+
+	result, err := s.uc.Rerun(mockCtx, domain.RuleRerunOpt{StartDate: &mockDate}, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(result.Changes, desc)
+}
+
+func rerun_ViewerNotDryRun_ReturnError(s *RuleSuite, desc string) {
+	req := ctxutil.SetLedger(httptest.NewRequest(http.MethodPost, "/", nil), &domain.Ledger{ID: 1, UserID: 1, Role: domain.LedgerRoleTypeViewer})
+
+	result, err := s.uc.Rerun(req.Context(), domain.RuleRerunOpt{StartDate: &mockDate}, mockUser)
+	s.Require().ErrorIs(err, domain.ErrLedgerPermissionDenied, desc)
+	s.Require().Empty(result, desc)
+}
@@ -0,0 +1,116 @@
+package settlement
+
+import (
+	"math"
+	"sort"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+// balanceBook keeps the balances in cents by currency and participant, to avoid floating point errors
+type balanceBook map[string]map[int64]int64
+
+// transfer records that the debtor owes the creditor the amount
+func (b balanceBook) transfer(currency string, debtorID, creditorID int64, amount float64) {
+	if b[currency] == nil {
+		b[currency] = map[int64]int64{}
+	}
+
+	cents := int64(math.Round(amount * 100))
+	b[currency][debtorID] -= cents
+	b[currency][creditorID] += cents
+}
+
+// balances returns the non-zero balances ordered by currency and participant
+func (b balanceBook) balances() []domain.Balance {
+	currencies := make([]string, 0, len(b))
+	for c := range b {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+
+	var balances []domain.Balance
+	for _, c := range currencies {
+		ids := make([]int64, 0, len(b[c]))
+		for id, cents := range b[c] {
+			if cents != 0 {
+				ids = append(ids, id)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		for _, id := range ids {
+			balances = append(balances, domain.Balance{
+				ContactID: id,
+				Currency:  c,
+				Amount:    float64(b[c][id]) / 100,
+			})
+		}
+	}
+
+	return balances
+}
+
+// party is a participant who should pay or receive the cents
+type party struct {
+	id    int64
+	cents int64
+}
+
+// genSettleUpPlan matches the largest debtor with the largest creditor in each currency until all balances are settled.
+// The plan takes at most one payment less than the participants of the currency.
+func genSettleUpPlan(balances []domain.Balance) []domain.SettleUp {
+	currencyToDebtors := map[string][]party{}
+	currencyToCreditors := map[string][]party{}
+	var currencies []string
+	for _, b := range balances {
+		if len(currencyToDebtors[b.Currency]) == 0 && len(currencyToCreditors[b.Currency]) == 0 {
+			currencies = append(currencies, b.Currency)
+		}
+
+		cents := int64(math.Round(b.Amount * 100))
+		if cents < 0 {
+			currencyToDebtors[b.Currency] = append(currencyToDebtors[b.Currency], party{id: b.ContactID, cents: -cents})
+		} else {
+			currencyToCreditors[b.Currency] = append(currencyToCreditors[b.Currency], party{id: b.ContactID, cents: cents})
+		}
+	}
+
+	var plan []domain.SettleUp
+	for _, c := range currencies {
+		debtors, creditors := currencyToDebtors[c], currencyToCreditors[c]
+		sortParties(debtors)
+		sortParties(creditors)
+
+		for i, j := 0, 0; i < len(debtors) && j < len(creditors); {
+			cents := min(debtors[i].cents, creditors[j].cents)
+			plan = append(plan, domain.SettleUp{
+				FromContactID: debtors[i].id,
+				ToContactID:   creditors[j].id,
+				Currency:      c,
+				Amount:        float64(cents) / 100,
+			})
+
+			debtors[i].cents -= cents
+			creditors[j].cents -= cents
+			if debtors[i].cents == 0 {
+				i++
+			}
+			if creditors[j].cents == 0 {
+				j++
+			}
+		}
+	}
+
+	return plan
+}
+
+// sortParties sorts the parties from the largest amount, and by id when the amounts are the same
+func sortParties(parties []party) {
+	sort.Slice(parties, func(i, j int) bool {
+		if parties[i].cents != parties[j].cents {
+			return parties[i].cents > parties[j].cents
+		}
+		return parties[i].id < parties[j].id
+	})
+}
@@ -0,0 +1,93 @@
+package settlement
+
+import (
+	"context"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+)
+
+type UC struct {
+	Settlement interfaces.SettlementRepo
+	Share      interfaces.ShareRepo
+	Contact    interfaces.ContactRepo
+}
+
+func New(st interfaces.SettlementRepo, sh interfaces.ShareRepo, c interfaces.ContactRepo) *UC {
+	return &UC{
+		Settlement: st,
+		Share:      sh,
+		Contact:    c,
+	}
+}
+
+func (u *UC) Create(ctx context.Context, settlement domain.Settlement, userID int64) error {
+	var ids []int64
+	for _, id := range []int64{settlement.FromContactID, settlement.ToContactID} {
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	// check permission
+	contacts, err := u.Contact.GetByIDs(ctx, ids, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(contacts) != len(ids) {
+		return domain.ErrContactNotFound
+	}
+
+	return u.Settlement.Create(ctx, settlement, userID)
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.Settlement, error) {
+	return u.Settlement.GetAll(ctx, userID)
+}
+
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.Settlement.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return u.Settlement.Delete(ctx, id)
+}
+
+// GetBalances returns the net balances of the user and the contacts in each currency, and the plan to settle them.
+// Each participant of a shared expense owes the payer the amount of the share, and a settlement moves money from one to another.
+func (u *UC) GetBalances(ctx context.Context, userID int64) (domain.Balances, error) {
+	sharedTrans, err := u.Share.GetAll(ctx, userID)
+	if err != nil {
+		return domain.Balances{}, err
+	}
+
+	settlements, err := u.Settlement.GetAll(ctx, userID)
+	if err != nil {
+		return domain.Balances{}, err
+	}
+
+	b := balanceBook{}
+	for _, t := range sharedTrans {
+		t.Allocate()
+		for _, s := range t.Shares {
+			if s.ContactID == t.PaidBy {
+				continue
+			}
+
+			b.transfer(t.Currency, s.ContactID, t.PaidBy, s.Amount)
+		}
+	}
+
+	// the payer of the settlement gets back what it owes
+	for _, s := range settlements {
+		b.transfer(s.Currency, s.ToContactID, s.FromContactID, s.Amount)
+	}
+
+	balances := b.balances()
+	return domain.Balances{
+		Balances: balances,
+		Plan:     genSettleUpPlan(balances),
+	}, nil
+}
@@ -0,0 +1,187 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type SettlementSuite struct {
+	suite.Suite
+	uc                 *UC
+	mockSettlementRepo *mocks.SettlementRepo
+	mockShareRepo      *mocks.ShareRepo
+	mockContactRepo    *mocks.ContactRepo
+}
+
+func TestSettlementSuite(t *testing.T) {
+	suite.Run(t, new(SettlementSuite))
+}
+
+func (s *SettlementSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *SettlementSuite) SetupTest() {
+	s.mockSettlementRepo = mocks.NewSettlementRepo(s.T())
+	s.mockShareRepo = mocks.NewShareRepo(s.T())
+	s.mockContactRepo = mocks.NewContactRepo(s.T())
+	s.uc = New(s.mockSettlementRepo, s.mockShareRepo, s.mockContactRepo)
+}
+
+func (s *SettlementSuite) TearDownTest() {
+	s.mockSettlementRepo.AssertExpectations(s.T())
+	s.mockShareRepo.AssertExpectations(s.T())
+	s.mockContactRepo.AssertExpectations(s.T())
+}
+
+func (s *SettlementSuite) TestCreate() {
+	for scenario, fn := range map[string]func(s *SettlementSuite, desc string){
+		"when no error, create successfully":   create_NoError_CreateSuccessfully,
+		"when contact not found, return error": create_ContactNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func create_NoError_CreateSuccessfully(s *SettlementSuite, desc string) {
+	settlement := domain.Settlement{FromContactID: 2, Amount: 10}
+
+	s.mockContactRepo.On("GetByIDs", mockCtx, []int64{2}, int64(1)).Return([]domain.Contact{{ID: 2}}, nil).Once()
+	s.mockSettlementRepo.On("Create", mockCtx, settlement, int64(1)).Return(nil).Once()
+
+	err := s.uc.Create(mockCtx, settlement, 1)
+	s.Require().NoError(err, desc)
+}
+
+func create_ContactNotFound_ReturnError(s *SettlementSuite, desc string) {
+	settlement := domain.Settlement{FromContactID: 2, ToContactID: 3, Amount: 10}
+
+	s.mockContactRepo.On("GetByIDs", mockCtx, []int64{2, 3}, int64(1)).Return([]domain.Contact{{ID: 2}}, nil).Once()
+
+	err := s.uc.Create(mockCtx, settlement, 1)
+	s.Require().ErrorIs(err, domain.ErrContactNotFound, desc)
+}
+
+func (s *SettlementSuite) TestGetBalances() {
+	for scenario, fn := range map[string]func(s *SettlementSuite, desc string){
+		"when no error, return balances and plan":  getBalances_NoError_ReturnBalancesAndPlan,
+		"when settled, return no balance":          getBalances_Settled_ReturnNoBalance,
+		"when get shared trans fail, return error": getBalances_GetSharedTransFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getBalances_NoError_ReturnBalancesAndPlan(s *SettlementSuite, desc string) {
+	// prepare mock data
+	// the user paid 90 for the dinner with contact 2 and 3, and contact 2 paid 60 for the taxi of the user and contact 3
+	mockSharedTrans := []domain.SharedTransaction{
+		{
+			TransactionID: 1,
+			Method:        domain.ShareMethodTypeEqual,
+			Price:         90,
+			Currency:      "USD",
+			Shares:        []domain.Share{{ContactID: 0}, {ContactID: 2}, {ContactID: 3}},
+		},
+		{
+			TransactionID: 2,
+			Method:        domain.ShareMethodTypeExact,
+			PaidBy:        2,
+			Price:         60,
+			Currency:      "USD",
+			Shares:        []domain.Share{{ContactID: 0, Value: 30}, {ContactID: 3, Value: 30}},
+		},
+		{
+			TransactionID: 3,
+			Method:        domain.ShareMethodTypeEqual,
+			Price:         10,
+			Currency:      "EUR",
+			Shares:        []domain.Share{{ContactID: 0}, {ContactID: 2}},
+		},
+	}
+	mockSettlements := []domain.Settlement{
+		{ID: 1, FromContactID: 3, ToContactID: 0, Amount: 10, Currency: "USD"},
+	}
+
+	// prepare mock service
+	s.mockShareRepo.On("GetAll", mockCtx, int64(1)).Return(mockSharedTrans, nil).Once()
+	s.mockSettlementRepo.On("GetAll", mockCtx, int64(1)).Return(mockSettlements, nil).Once()
+
+	// action, assertion
+	// USD: the user is owed 60 - 30 - 10 = 20, contact 2 is owed 60 - 30 = 30, and contact 3 owes 30 + 30 - 10 = 50
+	expRes := domain.Balances{
+		Balances: []domain.Balance{
+			{ContactID: 0, Currency: "EUR", Amount: 5},
+			{ContactID: 2, Currency: "EUR", Amount: -5},
+			{ContactID: 0, Currency: "USD", Amount: 20},
+			{ContactID: 2, Currency: "USD", Amount: 30},
+			{ContactID: 3, Currency: "USD", Amount: -50},
+		},
+		Plan: []domain.SettleUp{
+			{FromContactID: 2, ToContactID: 0, Currency: "EUR", Amount: 5},
+			{FromContactID: 3, ToContactID: 2, Currency: "USD", Amount: 30},
+			{FromContactID: 3, ToContactID: 0, Currency: "USD", Amount: 20},
+		},
+	}
+	res, err := s.uc.GetBalances(mockCtx, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expRes, res, desc)
+}
+
+func getBalances_Settled_ReturnNoBalance(s *SettlementSuite, desc string) {
+	// prepare mock data
+	mockSharedTrans := []domain.SharedTransaction{
+		{
+			TransactionID: 1,
+			Method:        domain.ShareMethodTypeEqual,
+			Price:         20,
+			Currency:      "USD",
+			Shares:        []domain.Share{{ContactID: 0}, {ContactID: 2}},
+		},
+	}
+	mockSettlements := []domain.Settlement{
+		{ID: 1, FromContactID: 2, ToContactID: 0, Amount: 10, Currency: "USD"},
+	}
+
+	// prepare mock service
+	s.mockShareRepo.On("GetAll", mockCtx, int64(1)).Return(mockSharedTrans, nil).Once()
+	s.mockSettlementRepo.On("GetAll", mockCtx, int64(1)).Return(mockSettlements, nil).Once()
+
+	// action, assertion
+	res, err := s.uc.GetBalances(mockCtx, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(res.Balances, desc)
+	s.Require().Empty(res.Plan, desc)
+}
+
+func getBalances_GetSharedTransFail_ReturnError(s *SettlementSuite, desc string) {
+	// prepare mock data
+	mockErr := errors.New("get all fail")
+
+	// prepare mock service
+	s.mockShareRepo.On("GetAll", mockCtx, int64(1)).Return(nil, mockErr).Once()
+
+	// action, assertion
+	res, err := s.uc.GetBalances(mockCtx, 1)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(res, desc)
+}
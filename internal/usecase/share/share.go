@@ -0,0 +1,103 @@
+package share
+
+import (
+	"context"
+	"math"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+)
+
+type UC struct {
+	Share       interfaces.ShareRepo
+	Contact     interfaces.ContactRepo
+	Transaction interfaces.TransactionRepo
+}
+
+func New(s interfaces.ShareRepo, c interfaces.ContactRepo, t interfaces.TransactionRepo) *UC {
+	return &UC{
+		Share:       s,
+		Contact:     c,
+		Transaction: t,
+	}
+}
+
+// Update shares the expense among the participants, and replaces the old shares if the expense is already shared
+func (u *UC) Update(ctx context.Context, sharedTrans domain.SharedTransaction, userID int64) (domain.SharedTransaction, error) {
+	trans, err := u.Transaction.GetByIDAndUserID(ctx, sharedTrans.TransactionID, userID)
+	if err != nil {
+		return domain.SharedTransaction{}, err
+	}
+
+	if trans.Type != domain.TransactionTypeExpense {
+		return domain.SharedTransaction{}, domain.ErrShareNotExpense
+	}
+
+	// the exact amounts are given by the client, so they must sum to the price
+	if sharedTrans.Method == domain.ShareMethodTypeExact && !isSumOfShares(trans.Price, sharedTrans.Shares) {
+		return domain.SharedTransaction{}, domain.ErrShareAmountMismatch
+	}
+
+	if err := u.checkContacts(ctx, sharedTrans.ContactIDs(), userID); err != nil {
+		return domain.SharedTransaction{}, err
+	}
+
+	if err := u.Share.Upsert(ctx, sharedTrans); err != nil {
+		return domain.SharedTransaction{}, err
+	}
+
+	sharedTrans.Price = trans.Price
+	sharedTrans.Currency = trans.Currency
+	sharedTrans.Allocate()
+
+	return sharedTrans, nil
+}
+
+// Get returns the shares of the transaction, the amounts are allocated from the current price of the transaction
+func (u *UC) Get(ctx context.Context, transID, userID int64) (domain.SharedTransaction, error) {
+	// check permission
+	if _, err := u.Transaction.GetByIDAndUserID(ctx, transID, userID); err != nil {
+		return domain.SharedTransaction{}, err
+	}
+
+	sharedTrans, err := u.Share.GetByTransID(ctx, transID)
+	if err != nil {
+		return domain.SharedTransaction{}, err
+	}
+
+	sharedTrans.Allocate()
+	return sharedTrans, nil
+}
+
+func (u *UC) Delete(ctx context.Context, transID, userID int64) error {
+	// check permission
+	if _, err := u.Transaction.GetByIDAndUserID(ctx, transID, userID); err != nil {
+		return err
+	}
+
+	return u.Share.Delete(ctx, transID)
+}
+
+// checkContacts checks if all the contacts belong to the user
+func (u *UC) checkContacts(ctx context.Context, ids []int64, userID int64) error {
+	contacts, err := u.Contact.GetByIDs(ctx, ids, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(contacts) != len(ids) {
+		return domain.ErrContactNotFound
+	}
+
+	return nil
+}
+
+// isSumOfShares compares the sum of the share values with the price in cents to avoid floating point errors
+func isSumOfShares(price float64, shares []domain.Share) bool {
+	var sum float64
+	for _, s := range shares {
+		sum += s.Value
+	}
+
+	return math.Round(sum*100) == math.Round(price*100)
+}
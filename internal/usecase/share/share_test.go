@@ -0,0 +1,220 @@
+package share
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type ShareSuite struct {
+	suite.Suite
+	uc                  *UC
+	mockShareRepo       *mocks.ShareRepo
+	mockContactRepo     *mocks.ContactRepo
+	mockTransactionRepo *mocks.TransactionRepo
+}
+
+func TestShareSuite(t *testing.T) {
+	suite.Run(t, new(ShareSuite))
+}
+
+func (s *ShareSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ShareSuite) SetupTest() {
+	s.mockShareRepo = mocks.NewShareRepo(s.T())
+	s.mockContactRepo = mocks.NewContactRepo(s.T())
+	s.mockTransactionRepo = mocks.NewTransactionRepo(s.T())
+	s.uc = New(s.mockShareRepo, s.mockContactRepo, s.mockTransactionRepo)
+}
+
+func (s *ShareSuite) TearDownTest() {
+	s.mockShareRepo.AssertExpectations(s.T())
+	s.mockContactRepo.AssertExpectations(s.T())
+	s.mockTransactionRepo.AssertExpectations(s.T())
+}
+
+func (s *ShareSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *ShareSuite, desc string){
+		"when share equally, allocate cents left to first shares": update_ShareEqually_AllocateCentsLeftToFirst,
+		"when share by shares, allocate by weights":               update_ShareByShares_AllocateByWeights,
+		"when exact amounts not sum to price, return error":       update_ExactNotSumToPrice_ReturnError,
+		"when transaction is income, return error":                update_Income_ReturnError,
+		"when contact not found, return error":                    update_ContactNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_ShareEqually_AllocateCentsLeftToFirst(s *ShareSuite, desc string) {
+	// prepare mock data
+	input := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeEqual,
+		Shares:        []domain.Share{{ContactID: 0}, {ContactID: 2}, {ContactID: 3}},
+	}
+	mockTrans := domain.Transaction{ID: 1, Type: domain.TransactionTypeExpense, Price: 100, Currency: "USD"}
+
+	// prepare mock service
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(mockTrans, nil).Once()
+	s.mockContactRepo.On("GetByIDs", mockCtx, []int64{2, 3}, int64(1)).
+		Return([]domain.Contact{{ID: 2}, {ID: 3}}, nil).Once()
+	s.mockShareRepo.On("Upsert", mockCtx, input).Return(nil).Once()
+
+	// action, assertion
+	expRes := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeEqual,
+		Price:         100,
+		Currency:      "USD",
+		Shares: []domain.Share{
+			{ContactID: 0, Amount: 33.34},
+			{ContactID: 2, Amount: 33.33},
+			{ContactID: 3, Amount: 33.33},
+		},
+	}
+	res, err := s.uc.Update(mockCtx, input, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expRes, res, desc)
+}
+
+func update_ShareByShares_AllocateByWeights(s *ShareSuite, desc string) {
+	// prepare mock data
+	input := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeShares,
+		PaidBy:        2,
+		Shares:        []domain.Share{{ContactID: 0, Value: 1}, {ContactID: 2, Value: 2}},
+	}
+	mockTrans := domain.Transaction{ID: 1, Type: domain.TransactionTypeExpense, Price: 90, Currency: "EUR"}
+
+	// prepare mock service
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(mockTrans, nil).Once()
+	s.mockContactRepo.On("GetByIDs", mockCtx, []int64{2}, int64(1)).Return([]domain.Contact{{ID: 2}}, nil).Once()
+	s.mockShareRepo.On("Upsert", mockCtx, input).Return(nil).Once()
+
+	// action, assertion
+	res, err := s.uc.Update(mockCtx, input, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.Share{
+		{ContactID: 0, Value: 1, Amount: 30},
+		{ContactID: 2, Value: 2, Amount: 60},
+	}, res.Shares, desc)
+}
+
+func update_ExactNotSumToPrice_ReturnError(s *ShareSuite, desc string) {
+	// prepare mock data
+	input := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeExact,
+		Shares:        []domain.Share{{ContactID: 0, Value: 10}, {ContactID: 2, Value: 20}},
+	}
+	mockTrans := domain.Transaction{ID: 1, Type: domain.TransactionTypeExpense, Price: 40}
+
+	// prepare mock service
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(mockTrans, nil).Once()
+
+	// action, assertion
+	res, err := s.uc.Update(mockCtx, input, 1)
+	s.Require().ErrorIs(err, domain.ErrShareAmountMismatch, desc)
+	s.Require().Empty(res, desc)
+}
+
+func update_Income_ReturnError(s *ShareSuite, desc string) {
+	// prepare mock data
+	input := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeEqual,
+		Shares:        []domain.Share{{ContactID: 0}, {ContactID: 2}},
+	}
+	mockTrans := domain.Transaction{ID: 1, Type: domain.TransactionTypeIncome, Price: 40}
+
+	// prepare mock service
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(mockTrans, nil).Once()
+
+	// action, assertion
+	res, err := s.uc.Update(mockCtx, input, 1)
+	s.Require().ErrorIs(err, domain.ErrShareNotExpense, desc)
+	s.Require().Empty(res, desc)
+}
+
+func update_ContactNotFound_ReturnError(s *ShareSuite, desc string) {
+	// prepare mock data
+	input := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypeEqual,
+		PaidBy:        3,
+		Shares:        []domain.Share{{ContactID: 0}, {ContactID: 2}},
+	}
+	mockTrans := domain.Transaction{ID: 1, Type: domain.TransactionTypeExpense, Price: 40}
+
+	// prepare mock service
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(mockTrans, nil).Once()
+	s.mockContactRepo.On("GetByIDs", mockCtx, []int64{3, 2}, int64(1)).Return([]domain.Contact{{ID: 2}}, nil).Once()
+
+	// action, assertion
+	res, err := s.uc.Update(mockCtx, input, 1)
+	s.Require().ErrorIs(err, domain.ErrContactNotFound, desc)
+	s.Require().Empty(res, desc)
+}
+
+func (s *ShareSuite) TestGet() {
+	for scenario, fn := range map[string]func(s *ShareSuite, desc string){
+		"when no error, allocate by percentages": get_NoError_AllocateByPercentages,
+		"when not shared, return error":          get_NotShared_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func get_NoError_AllocateByPercentages(s *ShareSuite, desc string) {
+	// prepare mock data
+	mockSharedTrans := domain.SharedTransaction{
+		TransactionID: 1,
+		Method:        domain.ShareMethodTypePercent,
+		Price:         10,
+		Currency:      "USD",
+		Shares:        []domain.Share{{ContactID: 0, Value: 33.33}, {ContactID: 2, Value: 66.67}},
+	}
+
+	// prepare mock service
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Transaction{ID: 1}, nil).Once()
+	s.mockShareRepo.On("GetByTransID", mockCtx, int64(1)).Return(mockSharedTrans, nil).Once()
+
+	// action, assertion
+	res, err := s.uc.Get(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.Share{
+		{ContactID: 0, Value: 33.33, Amount: 3.34},
+		{ContactID: 2, Value: 66.67, Amount: 6.66},
+	}, res.Shares, desc)
+}
+
+func get_NotShared_ReturnError(s *ShareSuite, desc string) {
+	// prepare mock service
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Transaction{ID: 1}, nil).Once()
+	s.mockShareRepo.On("GetByTransID", mockCtx, int64(1)).Return(domain.SharedTransaction{}, domain.ErrSharedTransNotFound).Once()
+
+	// action, assertion
+	res, err := s.uc.Get(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrSharedTransNotFound, desc)
+	s.Require().Empty(res, desc)
+}
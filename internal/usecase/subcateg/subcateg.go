@@ -1,6 +1,8 @@
 package subcateg
 
 import (
+	"context"
+
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
 )
@@ -26,8 +28,8 @@ func (u *UC) Create(categ *domain.SubCateg, userID int64) error {
 	return u.SubCateg.Create(categ, userID)
 }
 
-func (u *UC) GetByMainCategID(userID, mainCategID int64) ([]*domain.SubCateg, error) {
-	return u.SubCateg.GetByMainCategID(userID, mainCategID)
+func (u *UC) GetByMainCategID(userID, mainCategID int64, includeArchived bool) ([]*domain.SubCateg, error) {
+	return u.SubCateg.GetByMainCategID(userID, mainCategID, includeArchived)
 }
 
 func (u *UC) Update(categ *domain.SubCateg, userID int64) error {
@@ -52,3 +54,60 @@ func (u *UC) Update(categ *domain.SubCateg, userID int64) error {
 func (u *UC) Delete(id int64) error {
 	return u.SubCateg.Delete(id)
 }
+
+func (u *UC) Move(ctx context.Context, id, mainCategID, userID int64) error {
+	categ, err := u.SubCateg.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	// nothing to move
+	if categ.MainCategID == mainCategID {
+		return nil
+	}
+
+	from, err := u.MainCateg.GetByID(categ.MainCategID, userID)
+	if err != nil {
+		return err
+	}
+
+	to, err := u.MainCateg.GetByID(mainCategID, userID)
+	if err != nil {
+		return err
+	}
+
+	if from.Type != to.Type {
+		return domain.ErrMainCategTypeNotSame
+	}
+
+	return u.SubCateg.Move(ctx, id, mainCategID)
+}
+
+func (u *UC) SetArchived(ctx context.Context, id int64, archived bool, userID int64) error {
+	// check if the sub category exists
+	if _, err := u.SubCateg.GetByID(id, userID); err != nil {
+		return err
+	}
+
+	return u.SubCateg.SetArchived(ctx, id, archived)
+}
+
+func (u *UC) Reorder(ctx context.Context, mainCategID int64, ids []int64, userID int64) error {
+	categs, err := u.SubCateg.GetByMainCategID(userID, mainCategID, true)
+	if err != nil {
+		return err
+	}
+
+	// check if all the sub categories belong to the main category of the user
+	mainCategSubIDs := make(map[int64]bool, len(categs))
+	for _, c := range categs {
+		mainCategSubIDs[c.ID] = true
+	}
+	for _, id := range ids {
+		if !mainCategSubIDs[id] {
+			return domain.ErrSubCategNotFound
+		}
+	}
+
+	return u.SubCateg.Reorder(ctx, ids)
+}
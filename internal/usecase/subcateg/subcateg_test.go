@@ -1,6 +1,7 @@
 package subcateg
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -10,6 +11,10 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+var (
+	mockCtx = context.Background()
+)
+
 type SubCategSuite struct {
 	suite.Suite
 	uc                *UC
@@ -102,10 +107,10 @@ func getByMainCategID_NoError_ReturnData(s *SubCategSuite, desc string) {
 	}
 
 	// prepare mock service
-	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, mockMainCategID).Return(mockSubCategs, nil)
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, mockMainCategID, false).Return(mockSubCategs, nil)
 
 	// action, assertion
-	subCategs, err := s.uc.GetByMainCategID(mockUserID, mockMainCategID)
+	subCategs, err := s.uc.GetByMainCategID(mockUserID, mockMainCategID, false)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(mockSubCategs, subCategs, desc)
 }
@@ -116,10 +121,10 @@ func getByMainCategID_GetByMainCategIDFail_ReturnError(s *SubCategSuite, desc st
 	mockMainCategID := int64(1)
 
 	// prepare mock service
-	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, mockMainCategID).Return(nil, errors.New("getByMainCategID error"))
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, mockMainCategID, false).Return(nil, errors.New("getByMainCategID error"))
 
 	// action, assertion
-	subCategs, err := s.uc.GetByMainCategID(mockUserID, mockMainCategID)
+	subCategs, err := s.uc.GetByMainCategID(mockUserID, mockMainCategID, false)
 	s.Require().EqualError(err, "getByMainCategID error", desc)
 	s.Require().Nil(subCategs, desc)
 }
@@ -260,3 +265,151 @@ func delete_DeleteFail_ReturnError(s *SubCategSuite, desc string) {
 	err := s.uc.Delete(mockID)
 	s.Require().EqualError(err, "delete error", desc)
 }
+
+func (s *SubCategSuite) TestMove() {
+	for scenario, fn := range map[string]func(s *SubCategSuite, desc string){
+		"when no error, move successfully":               move_NoError_MoveSuccessfully,
+		"when already in main category, do nothing":      move_AlreadyInMainCateg_DoNothing,
+		"when main category not exist, return error":     move_MainCategNotExist_ReturnError,
+		"when main category type not same, return error": move_TypeNotSame_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func move_NoError_MoveSuccessfully(s *SubCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockSubCategRepo.On("GetByID", int64(3), mockUserID).Return(&domain.SubCateg{ID: 3, MainCategID: 1}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), mockUserID).Return(&domain.MainCateg{ID: 2, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockSubCategRepo.On("Move", mockCtx, int64(3), int64(2)).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.Move(mockCtx, 3, 2, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func move_AlreadyInMainCateg_DoNothing(s *SubCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockSubCategRepo.On("GetByID", int64(3), mockUserID).Return(&domain.SubCateg{ID: 3, MainCategID: 2}, nil).Once()
+
+	// action, assertion
+	err := s.uc.Move(mockCtx, 3, 2, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func move_MainCategNotExist_ReturnError(s *SubCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockSubCategRepo.On("GetByID", int64(3), mockUserID).Return(&domain.SubCateg{ID: 3, MainCategID: 1}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), mockUserID).Return(nil, domain.ErrMainCategNotFound).Once()
+
+	// action, assertion
+	err := s.uc.Move(mockCtx, 3, 2, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotFound, desc)
+}
+
+func move_TypeNotSame_ReturnError(s *SubCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockSubCategRepo.On("GetByID", int64(3), mockUserID).Return(&domain.SubCateg{ID: 3, MainCategID: 1}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(1), mockUserID).Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), mockUserID).Return(&domain.MainCateg{ID: 2, Type: domain.TransactionTypeIncome}, nil).Once()
+
+	// action, assertion
+	err := s.uc.Move(mockCtx, 3, 2, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrMainCategTypeNotSame, desc)
+}
+
+func (s *SubCategSuite) TestSetArchived() {
+	for scenario, fn := range map[string]func(s *SubCategSuite, desc string){
+		"when no error, set successfully":           setArchived_NoError_SetSuccessfully,
+		"when sub category not exist, return error": setArchived_SubCategNotExist_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func setArchived_NoError_SetSuccessfully(s *SubCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockSubCategRepo.On("GetByID", int64(3), mockUserID).Return(&domain.SubCateg{ID: 3, MainCategID: 1}, nil).Once()
+	s.mockSubCategRepo.On("SetArchived", mockCtx, int64(3), false).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.SetArchived(mockCtx, 3, false, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func setArchived_SubCategNotExist_ReturnError(s *SubCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+
+	// prepare mock service
+	s.mockSubCategRepo.On("GetByID", int64(3), mockUserID).Return(nil, domain.ErrSubCategNotFound).Once()
+
+	// action, assertion
+	err := s.uc.SetArchived(mockCtx, 3, false, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrSubCategNotFound, desc)
+}
+
+func (s *SubCategSuite) TestReorder() {
+	for scenario, fn := range map[string]func(s *SubCategSuite, desc string){
+		"when all in main category, reorder successfully": reorder_AllInMainCateg_ReorderSuccessfully,
+		"when any not in main category, return error":     reorder_AnyNotInMainCateg_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func reorder_AllInMainCateg_ReorderSuccessfully(s *SubCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockCategs := []*domain.SubCateg{{ID: 3, MainCategID: 1}, {ID: 4, MainCategID: 1}}
+
+	// prepare mock service
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, int64(1), true).Return(mockCategs, nil).Once()
+	s.mockSubCategRepo.On("Reorder", mockCtx, []int64{4, 3}).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.Reorder(mockCtx, 1, []int64{4, 3}, mockUserID)
+	s.Require().NoError(err, desc)
+}
+
+func reorder_AnyNotInMainCateg_ReturnError(s *SubCategSuite, desc string) {
+	// prepare mock data
+	mockUserID := int64(1)
+	mockCategs := []*domain.SubCateg{{ID: 3, MainCategID: 1}}
+
+	// prepare mock service
+	s.mockSubCategRepo.On("GetByMainCategID", mockUserID, int64(1), true).Return(mockCategs, nil).Once()
+
+	// action, assertion
+	err := s.uc.Reorder(mockCtx, 1, []int64{3, 5}, mockUserID)
+	s.Require().ErrorIs(err, domain.ErrSubCategNotFound, desc)
+}
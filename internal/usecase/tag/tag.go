@@ -0,0 +1,44 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+)
+
+type UC struct {
+	Tag interfaces.TagRepo
+}
+
+func New(t interfaces.TagRepo) *UC {
+	return &UC{
+		Tag: t,
+	}
+}
+
+func (u *UC) Create(ctx context.Context, tag domain.Tag, userID int64) error {
+	return u.Tag.Create(ctx, tag, userID)
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.Tag, error) {
+	return u.Tag.GetAll(ctx, userID)
+}
+
+func (u *UC) Update(ctx context.Context, tag domain.Tag, userID int64) error {
+	// check permission
+	if _, err := u.Tag.GetByIDAndUserID(ctx, tag.ID, userID); err != nil {
+		return err
+	}
+
+	return u.Tag.Update(ctx, tag)
+}
+
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.Tag.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return u.Tag.Delete(ctx, id)
+}
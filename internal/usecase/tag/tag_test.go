@@ -0,0 +1,99 @@
+package tag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type TagSuite struct {
+	suite.Suite
+	uc          *UC
+	mockTagRepo *mocks.TagRepo
+}
+
+func TestTagSuite(t *testing.T) {
+	suite.Run(t, new(TagSuite))
+}
+
+func (s *TagSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *TagSuite) SetupTest() {
+	s.mockTagRepo = mocks.NewTagRepo(s.T())
+	s.uc = New(s.mockTagRepo)
+}
+
+func (s *TagSuite) TearDownTest() {
+	s.mockTagRepo.AssertExpectations(s.T())
+}
+
+func (s *TagSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *TagSuite, desc string){
+		"when no error, update successfully": update_NoError_UpdateSuccessfully,
+		"when tag not found, return error":   update_TagNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoError_UpdateSuccessfully(s *TagSuite, desc string) {
+	tag := domain.Tag{ID: 1, Name: "trip-japan"}
+
+	s.mockTagRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Tag{ID: 1, Name: "trip"}, nil).Once()
+	s.mockTagRepo.On("Update", mockCtx, tag).Return(nil).Once()
+
+	err := s.uc.Update(mockCtx, tag, 1)
+	s.Require().NoError(err, desc)
+}
+
+func update_TagNotFound_ReturnError(s *TagSuite, desc string) {
+	tag := domain.Tag{ID: 1, Name: "trip-japan"}
+
+	s.mockTagRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Tag{}, domain.ErrTagNotFound).Once()
+
+	err := s.uc.Update(mockCtx, tag, 1)
+	s.Require().ErrorIs(err, domain.ErrTagNotFound, desc)
+}
+
+func (s *TagSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *TagSuite, desc string){
+		"when no error, delete successfully": delete_NoError_DeleteSuccessfully,
+		"when tag not found, return error":   delete_TagNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NoError_DeleteSuccessfully(s *TagSuite, desc string) {
+	s.mockTagRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Tag{ID: 1}, nil).Once()
+	s.mockTagRepo.On("Delete", mockCtx, int64(1)).Return(nil).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+}
+
+func delete_TagNotFound_ReturnError(s *TagSuite, desc string) {
+	s.mockTagRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.Tag{}, domain.ErrTagNotFound).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrTagNotFound, desc)
+}
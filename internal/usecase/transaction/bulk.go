@@ -0,0 +1,300 @@
+package transaction
+
+import (
+	"context"
+	"slices"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+// bulkRefs contains the categories, accounts and tags of the user referenced by bulk operations,
+// so that every operation is checked without querying one by one
+type bulkRefs struct {
+	mainCategTypes map[int64]domain.TransactionType
+	subCategMains  map[int64]int64
+	accountIDs     map[int64]bool
+	tagIDs         map[int64]bool
+}
+
+// Bulk checks all the operations in a handful of queries, and applies them in one database transaction.
+// The history of the changed transactions is recorded in the same database transaction, the same as changing them one by one.
+func (u *UC) Bulk(ctx context.Context, input domain.BulkTransInput, user domain.User) (domain.BulkTransResult, error) {
+	// resolve the filter to the transactions to delete, the cursor and sort are ignored
+	// the resolved transactions are the user's, so their permission isn't checked again
+	resolved := input.Delete.Opt != nil
+	if resolved {
+		opt := domain.GetTransOpt{Filter: input.Delete.Opt.Filter, Search: input.Delete.Opt.Search}
+		trans, _, err := u.Transaction.GetAll(ctx, opt, user.ID)
+		if err != nil {
+			return domain.BulkTransResult{}, err
+		}
+
+		input.Delete.IDs = make([]int64, 0, len(trans))
+		for _, t := range trans {
+			input.Delete.IDs = append(input.Delete.IDs, t.ID)
+		}
+		input.Delete.Opt = nil
+
+		for _, up := range input.Update {
+			if slices.Contains(input.Delete.IDs, up.ID) {
+				logger.Error("Bulk failed", "package", PackageName, "err", domain.ErrBulkUpdateDeletedTrans)
+				return domain.BulkTransResult{}, domain.ErrBulkUpdateDeletedTrans
+			}
+		}
+	}
+
+	// check permission of the updated and deleted transactions
+	targetIDs := make([]int64, 0, len(input.Update)+len(input.Delete.IDs))
+	for _, up := range input.Update {
+		targetIDs = append(targetIDs, up.ID)
+	}
+	if !resolved {
+		targetIDs = append(targetIDs, input.Delete.IDs...)
+	}
+
+	targets, err := u.Transaction.GetByIDsAndUserID(ctx, targetIDs, user.ID)
+	if err != nil {
+		return domain.BulkTransResult{}, err
+	}
+	if len(targets) != len(targetIDs) {
+		logger.Error("Bulk failed", "package", PackageName, "err", domain.ErrTransactionDataNotFound)
+		return domain.BulkTransResult{}, domain.ErrTransactionDataNotFound
+	}
+
+	idToTarget := make(map[int64]domain.Transaction, len(targets))
+	for _, t := range targets {
+		idToTarget[t.ID] = t
+	}
+
+	var uncategorized []*domain.CreateTransactionInput
+	for i := range input.Create {
+		if input.Create[i].IsUncategorized() {
+			uncategorized = append(uncategorized, &input.Create[i])
+		}
+	}
+	if len(uncategorized) > 0 {
+		if err := u.categorizeByRules(ctx, uncategorized, user.ID); err != nil {
+			return domain.BulkTransResult{}, err
+		}
+	}
+
+	refs, err := u.getBulkRefs(ctx, input, user.ID)
+	if err != nil {
+		return domain.BulkTransResult{}, err
+	}
+
+	for i, t := range input.Create {
+		// the split transaction is categorized by its first line
+		if len(t.Splits) > 0 {
+			input.Create[i].MainCategID, input.Create[i].SubCategID = t.Splits[0].MainCategID, t.Splits[0].SubCategID
+		}
+		input.Create[i].UserID = user.ID
+
+		if err := refs.checkCreate(input.Create[i]); err != nil {
+			return domain.BulkTransResult{}, err
+		}
+	}
+
+	for _, up := range input.Update {
+		if err := refs.checkTags(up.AddTagIDs); err != nil {
+			return domain.BulkTransResult{}, err
+		}
+
+		if up.MainCategID == nil {
+			continue
+		}
+
+		target := idToTarget[up.ID]
+		if len(target.Splits) > 0 {
+			logger.Error("Bulk failed", "package", PackageName, "err", domain.ErrBulkUpdateSplitCateg)
+			return domain.BulkTransResult{}, domain.ErrBulkUpdateSplitCateg
+		}
+
+		if err := refs.checkCategs(target.Type, *up.MainCategID, *up.SubCategID); err != nil {
+			return domain.BulkTransResult{}, err
+		}
+	}
+
+	createdIDs, err := u.Transaction.Bulk(ctx, input, user.ID, ctxutil.GetMemberID(ctx, user.ID))
+	if err != nil {
+		return domain.BulkTransResult{}, err
+	}
+
+	return domain.BulkTransResult{
+		CreatedIDs: createdIDs,
+		Updated:    len(input.Update),
+		Deleted:    len(input.Delete.IDs),
+	}, nil
+}
+
+// getBulkRefs gets the categories, accounts and tags referenced by the operations, at most one query for each of them
+func (u *UC) getBulkRefs(ctx context.Context, input domain.BulkTransInput, userID int64) (bulkRefs, error) {
+	var mainCategIDs, subCategIDs, accountIDs, tagIDs []int64
+	for _, t := range input.Create {
+		mainCategIDs = append(mainCategIDs, t.MainCategID)
+		subCategIDs = append(subCategIDs, t.SubCategID)
+		for _, s := range t.Splits {
+			mainCategIDs = append(mainCategIDs, s.MainCategID)
+			subCategIDs = append(subCategIDs, s.SubCategID)
+		}
+		accountIDs = append(accountIDs, t.AccountID, t.ToAccountID)
+		tagIDs = append(tagIDs, t.TagIDs...)
+	}
+	for _, up := range input.Update {
+		if up.MainCategID != nil {
+			mainCategIDs = append(mainCategIDs, *up.MainCategID)
+			subCategIDs = append(subCategIDs, *up.SubCategID)
+		}
+		tagIDs = append(tagIDs, up.AddTagIDs...)
+	}
+
+	// 0 means the transaction doesn't reference it
+	mainCategIDs, subCategIDs, accountIDs, tagIDs = uniqueIDs(mainCategIDs), uniqueIDs(subCategIDs), uniqueIDs(accountIDs), uniqueIDs(tagIDs)
+
+	refs := bulkRefs{
+		mainCategTypes: map[int64]domain.TransactionType{},
+		subCategMains:  map[int64]int64{},
+		accountIDs:     map[int64]bool{},
+		tagIDs:         map[int64]bool{},
+	}
+
+	if len(mainCategIDs) > 0 {
+		// archived main categories can still be used
+		categs, err := u.MainCateg.GetAll(ctx, userID, domain.TransactionTypeUnSpecified, true)
+		if err != nil {
+			return bulkRefs{}, err
+		}
+
+		for _, c := range categs {
+			refs.mainCategTypes[c.ID] = c.Type
+		}
+	}
+
+	if len(subCategIDs) > 0 {
+		categs, err := u.SubCateg.GetByIDs(ctx, subCategIDs, userID)
+		if err != nil {
+			return bulkRefs{}, err
+		}
+
+		for _, c := range categs {
+			refs.subCategMains[c.ID] = c.MainCategID
+		}
+	}
+
+	if len(accountIDs) > 0 {
+		accounts, err := u.Account.GetAll(ctx, userID)
+		if err != nil {
+			return bulkRefs{}, err
+		}
+
+		for _, a := range accounts {
+			refs.accountIDs[a.ID] = true
+		}
+	}
+
+	if len(tagIDs) > 0 {
+		tags, err := u.Tag.GetByIDs(ctx, tagIDs, userID)
+		if err != nil {
+			return bulkRefs{}, err
+		}
+
+		for _, t := range tags {
+			refs.tagIDs[t.ID] = true
+		}
+	}
+
+	return refs, nil
+}
+
+// checkCreate checks the created transaction the same as Create does
+func (r bulkRefs) checkCreate(t domain.CreateTransactionInput) error {
+	if err := r.checkTags(t.TagIDs); err != nil {
+		return err
+	}
+
+	if t.Type == domain.TransactionTypeTransfer {
+		if t.AccountID == t.ToAccountID {
+			logger.Error("Transfer failed", "package", PackageName, "err", domain.ErrTransferSameAccount)
+			return domain.ErrTransferSameAccount
+		}
+
+		if !r.accountIDs[t.AccountID] || !r.accountIDs[t.ToAccountID] {
+			return domain.ErrAccountNotFound
+		}
+
+		return nil
+	}
+
+	if err := r.checkCategs(t.Type, t.MainCategID, t.SubCategID); err != nil {
+		return err
+	}
+
+	// the first line is the category of the transaction, which is checked above
+	for i := 1; i < len(t.Splits); i++ {
+		if err := r.checkCategs(t.Type, t.Splits[i].MainCategID, t.Splits[i].SubCategID); err != nil {
+			return err
+		}
+	}
+
+	// 0 means the transaction doesn't belong to any account
+	if t.AccountID != 0 && !r.accountIDs[t.AccountID] {
+		return domain.ErrAccountNotFound
+	}
+
+	return nil
+}
+
+// checkTags is the same as UC.checkTags, but checks against the fetched tags
+func (r bulkRefs) checkTags(ids []int64) error {
+	for _, id := range ids {
+		if !r.tagIDs[id] {
+			logger.Error("Check tags failed", "package", PackageName, "err", domain.ErrTagNotFound)
+			return domain.ErrTagNotFound
+		}
+	}
+
+	return nil
+}
+
+// checkCategs is the same as UC.checkCategs, but checks against the fetched categories
+func (r bulkRefs) checkCategs(transType domain.TransactionType, mainCategID, subCategID int64) error {
+	mainCategType, ok := r.mainCategTypes[mainCategID]
+	if !ok {
+		return domain.ErrMainCategNotFound
+	}
+
+	if transType != mainCategType {
+		logger.Error("Check categories failed", "package", PackageName, "err", domain.ErrTypeNotConsistent)
+		return domain.ErrTypeNotConsistent
+	}
+
+	subCategMain, ok := r.subCategMains[subCategID]
+	if !ok {
+		return domain.ErrSubCategNotFound
+	}
+
+	if subCategMain != mainCategID {
+		logger.Error("Check categories failed", "package", PackageName, "err", domain.ErrMainCategNotConsistent)
+		return domain.ErrMainCategNotConsistent
+	}
+
+	return nil
+}
+
+// uniqueIDs returns the unique ids which are not 0
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		result = append(result, id)
+	}
+
+	return result
+}
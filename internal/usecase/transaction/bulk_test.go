@@ -0,0 +1,215 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+)
+
+func (s *TransactionSuite) TestBulk() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, apply all operations":                          bulk_NoError_ApplyAll,
+		"when delete by filter, delete the filtered transactions":      bulk_DeleteByFilter_DeleteFiltered,
+		"when update the transaction selected by filter, return error": bulk_UpdateFilteredTrans_ReturnError,
+		"when transaction not found, return error":                     bulk_TransNotFound_ReturnError,
+		"when update category of split transaction, return error":      bulk_UpdateSplitCateg_ReturnError,
+		"when created transaction type not match, return error":        bulk_CreateTypeNotMatch_ReturnError,
+		"when create transfer to unknown account, return error":        bulk_CreateTransferAccountNotFound_ReturnError,
+		"when bulk fail, return error":                                 bulk_BulkFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func bulk_NoError_ApplyAll(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	mainCategID, subCategID, note := int64(2), int64(3), "new note"
+	input := domain.BulkTransInput{
+		Create: []domain.CreateTransactionInput{
+			{Type: domain.TransactionTypeExpense, MainCategID: 2, SubCategID: 3, Price: 10, Date: mockTimeNow, TagIDs: []int64{5}},
+		},
+		Update: []domain.BulkUpdateTransInput{
+			{ID: 7, MainCategID: &mainCategID, SubCategID: &subCategID, Note: &note},
+		},
+		Delete: domain.BulkDeleteTransInput{IDs: []int64{8}},
+	}
+
+	targets := []domain.Transaction{
+		{ID: 7, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 1}, SubCateg: domain.SubCateg{ID: 1}, Price: 20, Date: mockTimeNow},
+		{ID: 8, Type: domain.TransactionTypeIncome, Price: 30, Date: mockTimeNow},
+	}
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{7, 8}, user.ID).
+		Return(targets, nil).Once()
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, user.ID, domain.TransactionTypeUnSpecified, true).
+		Return([]domain.MainCateg{{ID: 2, Type: domain.TransactionTypeExpense}}, nil).Once()
+	s.mockSubCategRepo.On("GetByIDs", mockCtx, []int64{3}, user.ID).
+		Return([]domain.SubCateg{{ID: 3, MainCategID: 2}}, nil).Once()
+	s.mockTagRepo.On("GetByIDs", mockCtx, []int64{5}, user.ID).
+		Return([]domain.Tag{{ID: 5}}, nil).Once()
+
+	expInput := input
+	expInput.Create = []domain.CreateTransactionInput{
+		{UserID: 1, Type: domain.TransactionTypeExpense, MainCategID: 2, SubCategID: 3, Price: 10, Date: mockTimeNow, TagIDs: []int64{5}},
+	}
+	s.mockTransactionRepo.On("Bulk", mockCtx, expInput, user.ID, user.ID).
+		Return([]int64{9}, nil).Once()
+
+	result, err := s.uc.Bulk(mockCtx, input, user)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.BulkTransResult{CreatedIDs: []int64{9}, Updated: 1, Deleted: 1}, result, desc)
+}
+
+func bulk_DeleteByFilter_DeleteFiltered(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	keyword := "coffee"
+	size := 10
+	input := domain.BulkTransInput{
+		Delete: domain.BulkDeleteTransInput{
+			Opt: &domain.GetTransOpt{
+				Search: domain.Search{Keyword: &keyword},
+				Cursor: domain.Cursor{Size: size},
+			},
+		},
+	}
+
+	// the cursor is ignored, so that all filtered transactions are deleted
+	filtered := []domain.Transaction{
+		{ID: 4, Type: domain.TransactionTypeExpense, Price: 1, Note: "coffee", Date: mockTimeNow},
+		{ID: 5, Type: domain.TransactionTypeExpense, Price: 2, Note: "coffee", Date: mockTimeNow},
+	}
+	s.mockTransactionRepo.On("GetAll", mockCtx, domain.GetTransOpt{Search: domain.Search{Keyword: &keyword}}, user.ID).
+		Return(filtered, domain.DecodedNextKeys{}, nil).Once()
+
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{}, user.ID).
+		Return(nil, nil).Once()
+
+	expInput := domain.BulkTransInput{Delete: domain.BulkDeleteTransInput{IDs: []int64{4, 5}}}
+	s.mockTransactionRepo.On("Bulk", mockCtx, expInput, user.ID, user.ID).
+		Return([]int64{}, nil).Once()
+
+	result, err := s.uc.Bulk(mockCtx, input, user)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.BulkTransResult{CreatedIDs: []int64{}, Deleted: 2}, result, desc)
+}
+
+func bulk_UpdateFilteredTrans_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	keyword, note := "coffee", "tea"
+	input := domain.BulkTransInput{
+		Update: []domain.BulkUpdateTransInput{{ID: 4, Note: &note}},
+		Delete: domain.BulkDeleteTransInput{
+			Opt: &domain.GetTransOpt{Search: domain.Search{Keyword: &keyword}},
+		},
+	}
+
+	s.mockTransactionRepo.On("GetAll", mockCtx, domain.GetTransOpt{Search: domain.Search{Keyword: &keyword}}, user.ID).
+		Return([]domain.Transaction{{ID: 4}}, domain.DecodedNextKeys{}, nil).Once()
+
+	result, err := s.uc.Bulk(mockCtx, input, user)
+	s.Require().ErrorIs(err, domain.ErrBulkUpdateDeletedTrans, desc)
+	s.Require().Empty(result, desc)
+}
+
+func bulk_TransNotFound_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	input := domain.BulkTransInput{
+		Delete: domain.BulkDeleteTransInput{IDs: []int64{8, 9}},
+	}
+
+	// the transaction 9 is deleted or belongs to another user
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{8, 9}, user.ID).
+		Return([]domain.Transaction{{ID: 8}}, nil).Once()
+
+	result, err := s.uc.Bulk(mockCtx, input, user)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+	s.Require().Empty(result, desc)
+}
+
+func bulk_UpdateSplitCateg_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	mainCategID, subCategID := int64(2), int64(3)
+	input := domain.BulkTransInput{
+		Update: []domain.BulkUpdateTransInput{{ID: 7, MainCategID: &mainCategID, SubCategID: &subCategID}},
+	}
+
+	target := domain.Transaction{
+		ID:     7,
+		Type:   domain.TransactionTypeExpense,
+		Splits: []domain.TransactionSplit{{Price: 1}, {Price: 2}},
+	}
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{7}, user.ID).
+		Return([]domain.Transaction{target}, nil).Once()
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, user.ID, domain.TransactionTypeUnSpecified, true).
+		Return([]domain.MainCateg{{ID: 2, Type: domain.TransactionTypeExpense}}, nil).Once()
+	s.mockSubCategRepo.On("GetByIDs", mockCtx, []int64{3}, user.ID).
+		Return([]domain.SubCateg{{ID: 3, MainCategID: 2}}, nil).Once()
+
+	result, err := s.uc.Bulk(mockCtx, input, user)
+	s.Require().ErrorIs(err, domain.ErrBulkUpdateSplitCateg, desc)
+	s.Require().Empty(result, desc)
+}
+
+func bulk_CreateTypeNotMatch_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	input := domain.BulkTransInput{
+		Create: []domain.CreateTransactionInput{
+			{Type: domain.TransactionTypeIncome, MainCategID: 2, SubCategID: 3, Price: 10, Date: mockTimeNow},
+		},
+	}
+
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{}, user.ID).
+		Return(nil, nil).Once()
+
+	s.mockMainCategRepo.On("GetAll", mockCtx, user.ID, domain.TransactionTypeUnSpecified, true).
+		Return([]domain.MainCateg{{ID: 2, Type: domain.TransactionTypeExpense}}, nil).Once()
+	s.mockSubCategRepo.On("GetByIDs", mockCtx, []int64{3}, user.ID).
+		Return([]domain.SubCateg{{ID: 3, MainCategID: 2}}, nil).Once()
+
+	result, err := s.uc.Bulk(mockCtx, input, user)
+	s.Require().ErrorIs(err, domain.ErrTypeNotConsistent, desc)
+	s.Require().Empty(result, desc)
+}
+
+func bulk_CreateTransferAccountNotFound_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	input := domain.BulkTransInput{
+		Create: []domain.CreateTransactionInput{
+			{Type: domain.TransactionTypeTransfer, AccountID: 1, ToAccountID: 2, Price: 10, Date: mockTimeNow},
+		},
+	}
+
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{}, user.ID).
+		Return(nil, nil).Once()
+
+	s.mockAccountRepo.On("GetAll", mockCtx, user.ID).
+		Return([]domain.Account{{ID: 1}}, nil).Once()
+
+	result, err := s.uc.Bulk(mockCtx, input, user)
+	s.Require().ErrorIs(err, domain.ErrAccountNotFound, desc)
+	s.Require().Empty(result, desc)
+}
+
+func bulk_BulkFail_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	input := domain.BulkTransInput{
+		Delete: domain.BulkDeleteTransInput{IDs: []int64{8}},
+	}
+
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{8}, user.ID).
+		Return([]domain.Transaction{{ID: 8}}, nil).Once()
+
+	mockErr := errors.New("error")
+	s.mockTransactionRepo.On("Bulk", mockCtx, input, user.ID, user.ID).
+		Return(nil, mockErr).Once()
+
+	result, err := s.uc.Bulk(mockCtx, input, user)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(result, desc)
+}
@@ -0,0 +1,106 @@
+package transaction
+
+import (
+	"context"
+	"slices"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+// getDuplicatesOf returns the existing transactions the created transaction may be a duplicate of
+func (u *UC) getDuplicatesOf(ctx context.Context, trans domain.CreateTransactionInput) ([]domain.Transaction, error) {
+	candidates, err := u.Transaction.GetDuplicateCandidates(ctx, trans, domain.DuplicateWindowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []domain.Transaction
+	for _, c := range candidates {
+		if domain.IsSimilarNote(c.Note, trans.Note) {
+			duplicates = append(duplicates, c)
+		}
+	}
+
+	return duplicates, nil
+}
+
+func (u *UC) GetDuplicates(ctx context.Context, opt domain.GetDuplicatesOpt, user domain.User) ([]domain.DuplicateGroup, error) {
+	pairs, err := u.Transaction.GetDuplicatePairs(ctx, opt, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pairs) == 0 {
+		return []domain.DuplicateGroup{}, nil
+	}
+
+	ids := make([]int64, 0, len(pairs)*2)
+	for _, p := range pairs {
+		ids = append(ids, p.FirstID, p.SecondID)
+	}
+
+	trans, err := u.Transaction.GetByIDsAndUserID(ctx, uniqueIDs(ids), user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.GroupDuplicates(pairs, trans), nil
+}
+
+// MergeDuplicates is applied by Bulk, so the others are moved to trash along with the tags added to the kept one,
+// and the history is recorded the same way
+func (u *UC) MergeDuplicates(ctx context.Context, keepID int64, ids []int64, user domain.User) error {
+	// check permission
+	trans, err := u.Transaction.GetByIDsAndUserID(ctx, append([]int64{keepID}, ids...), user.ID)
+	if err != nil {
+		return err
+	}
+	if len(trans) != len(ids)+1 {
+		logger.Error("MergeDuplicates failed", "package", PackageName, "err", domain.ErrTransactionDataNotFound)
+		return domain.ErrTransactionDataNotFound
+	}
+
+	var keptTagIDs, addTagIDs []int64
+	for _, t := range trans {
+		if t.ID != keepID {
+			continue
+		}
+
+		for _, tag := range t.Tags {
+			keptTagIDs = append(keptTagIDs, tag.ID)
+		}
+	}
+	for _, t := range trans {
+		if t.ID == keepID {
+			continue
+		}
+
+		for _, tag := range t.Tags {
+			if !slices.Contains(keptTagIDs, tag.ID) && !slices.Contains(addTagIDs, tag.ID) {
+				addTagIDs = append(addTagIDs, tag.ID)
+			}
+		}
+	}
+
+	input := domain.BulkTransInput{Delete: domain.BulkDeleteTransInput{IDs: ids}}
+	if len(addTagIDs) > 0 {
+		input.Update = []domain.BulkUpdateTransInput{{ID: keepID, AddTagIDs: addTagIDs}}
+	}
+
+	_, err = u.Bulk(ctx, input, user)
+	return err
+}
+
+func (u *UC) DismissDuplicates(ctx context.Context, ids []int64, user domain.User) error {
+	// check permission
+	trans, err := u.Transaction.GetByIDsAndUserID(ctx, ids, user.ID)
+	if err != nil {
+		return err
+	}
+	if len(trans) != len(ids) {
+		logger.Error("DismissDuplicates failed", "package", PackageName, "err", domain.ErrTransactionDataNotFound)
+		return domain.ErrTransactionDataNotFound
+	}
+
+	return u.Transaction.DismissDuplicates(ctx, ids)
+}
@@ -0,0 +1,215 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+)
+
+var mockDuplicatesOpt = domain.GetDuplicatesOpt{
+	Days:      3,
+	StartDate: mockTimeNow.AddDate(0, 0, -domain.DuplicateRangeDays),
+	EndDate:   mockTimeNow,
+}
+
+func (s *TransactionSuite) TestGetDuplicates() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when pairs have similar notes, return groups":                        getDuplicates_SimilarNotes_ReturnGroups,
+		"when pairs chain regular entries, group only ones paired with first": getDuplicates_ChainedPairs_GroupOnlyPairedWithFirst,
+		"when no pairs, return empty groups":                                  getDuplicates_NoPairs_ReturnEmptyGroups,
+		"when get pairs fail, return error":                                   getDuplicates_GetPairsFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getDuplicates_SimilarNotes_ReturnGroups(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// 1, 2 and 3 are chained by similar notes, and 4 and 5 are not similar
+	pairs := []domain.DuplicatePair{
+		{FirstID: 1, SecondID: 2},
+		{FirstID: 2, SecondID: 3},
+		{FirstID: 4, SecondID: 5},
+	}
+	trans := []domain.Transaction{
+		{ID: 1, Note: "coffee", Date: mockTimeNow},
+		{ID: 2, Note: "Coffee beans", Date: mockTimeNow.AddDate(0, 0, -1)},
+		{ID: 3, Note: "coffee  beans", Date: mockTimeNow},
+		{ID: 4, Note: "rent", Date: mockTimeNow},
+		{ID: 5, Note: "", Date: mockTimeNow},
+	}
+
+	s.mockTransactionRepo.On("GetDuplicatePairs", mockCtx, mockDuplicatesOpt, user.ID).
+		Return(pairs, nil).Once()
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{1, 2, 3, 4, 5}, user.ID).
+		Return(trans, nil).Once()
+
+	groups, err := s.uc.GetDuplicates(mockCtx, mockDuplicatesOpt, user)
+	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.DuplicateGroup{{Transactions: []domain.Transaction{trans[1], trans[0], trans[2]}}}, groups, desc)
+}
+
+func getDuplicates_ChainedPairs_GroupOnlyPairedWithFirst(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	// the daily coffee pairs with the one of the next day, but never chains into one group
+	pairs := []domain.DuplicatePair{
+		{FirstID: 1, SecondID: 2},
+		{FirstID: 2, SecondID: 3},
+		{FirstID: 3, SecondID: 4},
+		{FirstID: 4, SecondID: 5},
+	}
+	trans := []domain.Transaction{
+		{ID: 1, Note: "coffee", Date: mockTimeNow},
+		{ID: 2, Note: "coffee", Date: mockTimeNow.AddDate(0, 0, 1)},
+		{ID: 3, Note: "coffee", Date: mockTimeNow.AddDate(0, 0, 2)},
+		{ID: 4, Note: "coffee", Date: mockTimeNow.AddDate(0, 0, 3)},
+		{ID: 5, Note: "coffee", Date: mockTimeNow.AddDate(0, 0, 4)},
+	}
+
+	s.mockTransactionRepo.On("GetDuplicatePairs", mockCtx, mockDuplicatesOpt, user.ID).
+		Return(pairs, nil).Once()
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{1, 2, 3, 4, 5}, user.ID).
+		Return(trans, nil).Once()
+
+	groups, err := s.uc.GetDuplicates(mockCtx, mockDuplicatesOpt, user)
+	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.DuplicateGroup{
+		{Transactions: []domain.Transaction{trans[0], trans[1]}},
+		{Transactions: []domain.Transaction{trans[2], trans[3]}},
+	}, groups, desc)
+}
+
+func getDuplicates_NoPairs_ReturnEmptyGroups(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetDuplicatePairs", mockCtx, mockDuplicatesOpt, user.ID).
+		Return(nil, nil).Once()
+
+	groups, err := s.uc.GetDuplicates(mockCtx, mockDuplicatesOpt, user)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(groups, desc)
+}
+
+func getDuplicates_GetPairsFail_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	mockErr := errors.New("get pairs fail")
+
+	s.mockTransactionRepo.On("GetDuplicatePairs", mockCtx, mockDuplicatesOpt, user.ID).
+		Return(nil, mockErr).Once()
+
+	groups, err := s.uc.GetDuplicates(mockCtx, mockDuplicatesOpt, user)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Nil(groups, desc)
+}
+
+func (s *TransactionSuite) TestMergeDuplicates() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when others have tags, add them to kept one and delete others": mergeDuplicates_OthersHaveTags_AddTagsAndDelete,
+		"when no new tags, only delete others":                          mergeDuplicates_NoNewTags_OnlyDelete,
+		"when transaction not found, return error":                      mergeDuplicates_TransNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func mergeDuplicates_OthersHaveTags_AddTagsAndDelete(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	trans := []domain.Transaction{
+		{ID: 1, Price: 10, Tags: []domain.Tag{{ID: 5}}},
+		{ID: 2, Price: 10, Tags: []domain.Tag{{ID: 5}, {ID: 6}}},
+		{ID: 3, Price: 10, Tags: []domain.Tag{{ID: 6}}},
+	}
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{1, 2, 3}, user.ID).
+		Return(trans, nil).Once()
+
+	// applied by bulk
+	expInput := domain.BulkTransInput{
+		Update: []domain.BulkUpdateTransInput{{ID: 1, AddTagIDs: []int64{6}}},
+		Delete: domain.BulkDeleteTransInput{IDs: []int64{2, 3}},
+	}
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{1, 2, 3}, user.ID).
+		Return(trans, nil).Once()
+	s.mockTagRepo.On("GetByIDs", mockCtx, []int64{6}, user.ID).
+		Return([]domain.Tag{{ID: 6}}, nil).Once()
+	s.mockTransactionRepo.On("Bulk", mockCtx, expInput, user.ID, user.ID).
+		Return(nil, nil).Once()
+
+	err := s.uc.MergeDuplicates(mockCtx, 1, []int64{2, 3}, user)
+	s.Require().NoError(err, desc)
+}
+
+func mergeDuplicates_NoNewTags_OnlyDelete(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	trans := []domain.Transaction{
+		{ID: 1, Price: 10},
+		{ID: 2, Price: 10},
+	}
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{1, 2}, user.ID).
+		Return(trans, nil).Once()
+
+	// applied by bulk
+	expInput := domain.BulkTransInput{Delete: domain.BulkDeleteTransInput{IDs: []int64{2}}}
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{2}, user.ID).
+		Return(trans[1:], nil).Once()
+	s.mockTransactionRepo.On("Bulk", mockCtx, expInput, user.ID, user.ID).
+		Return(nil, nil).Once()
+
+	err := s.uc.MergeDuplicates(mockCtx, 1, []int64{2}, user)
+	s.Require().NoError(err, desc)
+}
+
+func mergeDuplicates_TransNotFound_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{1, 2}, user.ID).
+		Return([]domain.Transaction{{ID: 1}}, nil).Once()
+
+	err := s.uc.MergeDuplicates(mockCtx, 1, []int64{2}, user)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+}
+
+func (s *TransactionSuite) TestDismissDuplicates() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, dismiss successfully":      dismissDuplicates_NoError_DismissSuccessfully,
+		"when transaction not found, return error": dismissDuplicates_TransNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func dismissDuplicates_NoError_DismissSuccessfully(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{1, 2}, user.ID).
+		Return([]domain.Transaction{{ID: 1}, {ID: 2}}, nil).Once()
+	s.mockTransactionRepo.On("DismissDuplicates", mockCtx, []int64{1, 2}).
+		Return(nil).Once()
+
+	err := s.uc.DismissDuplicates(mockCtx, []int64{1, 2}, user)
+	s.Require().NoError(err, desc)
+}
+
+func dismissDuplicates_TransNotFound_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDsAndUserID", mockCtx, []int64{1, 2}, user.ID).
+		Return([]domain.Transaction{{ID: 1}}, nil).Once()
+
+	err := s.uc.DismissDuplicates(mockCtx, []int64{1, 2}, user)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+}
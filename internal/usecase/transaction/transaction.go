@@ -8,6 +8,7 @@ import (
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
 	"github.com/eyo-chen/expense-tracker-go/pkg/codeutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
 )
 
@@ -28,6 +29,12 @@ type UC struct {
 	MonthlyTrans interfaces.MonthlyTransRepo
 	Redis        interfaces.RedisService
 	S3           interfaces.S3Service
+	Account      interfaces.AccountRepo
+	Tag          interfaces.TagRepo
+	Revision     interfaces.TransRevisionRepo
+	Attachment   interfaces.AttachmentRepo
+	Rule         interfaces.RuleRepo
+	View         interfaces.ViewRepo
 }
 
 func New(t interfaces.TransactionRepo,
@@ -35,7 +42,13 @@ func New(t interfaces.TransactionRepo,
 	s interfaces.SubCategRepo,
 	mt interfaces.MonthlyTransRepo,
 	r interfaces.RedisService,
-	s3 interfaces.S3Service) *UC {
+	s3 interfaces.S3Service,
+	a interfaces.AccountRepo,
+	tg interfaces.TagRepo,
+	rv interfaces.TransRevisionRepo,
+	at interfaces.AttachmentRepo,
+	rl interfaces.RuleRepo,
+	vw interfaces.ViewRepo) *UC {
 	return &UC{
 		Transaction:  t,
 		MainCateg:    m,
@@ -43,38 +56,103 @@ func New(t interfaces.TransactionRepo,
 		MonthlyTrans: mt,
 		Redis:        r,
 		S3:           s3,
+		Account:      a,
+		Tag:          tg,
+		Revision:     rv,
+		Attachment:   at,
+		Rule:         rl,
+		View:         vw,
 	}
 }
 
-func (u *UC) Create(ctx context.Context, trans domain.CreateTransactionInput) error {
-	// check if the main category exists
-	mainCateg, err := u.MainCateg.GetByID(trans.MainCategID, trans.UserID)
+func (u *UC) Create(ctx context.Context, trans domain.CreateTransactionInput, strict bool) ([]domain.Transaction, error) {
+	if trans.IsUncategorized() {
+		if err := u.categorizeByRules(ctx, []*domain.CreateTransactionInput{&trans}, trans.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.checkTags(ctx, trans.TagIDs, trans.UserID); err != nil {
+		return nil, err
+	}
+
+	// transfer doesn't have categories, it only moves money between the user's accounts
+	// so it's not checked for duplicates, which have the same category
+	if trans.Type == domain.TransactionTypeTransfer {
+		if err := u.checkTransferAccounts(ctx, trans.AccountID, trans.ToAccountID, trans.UserID); err != nil {
+			return nil, err
+		}
+
+		return nil, u.create(ctx, trans)
+	}
+
+	// the split transaction is categorized by its first line
+	if len(trans.Splits) > 0 {
+		trans.MainCategID, trans.SubCategID = trans.Splits[0].MainCategID, trans.Splits[0].SubCategID
+	}
+
+	if err := u.checkCategs(trans.Type, trans.MainCategID, trans.SubCategID, trans.UserID); err != nil {
+		return nil, err
+	}
+
+	// the first line is checked above
+	for i := 1; i < len(trans.Splits); i++ {
+		if err := u.checkCategs(trans.Type, trans.Splits[i].MainCategID, trans.Splits[i].SubCategID, trans.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	// check if the account exists
+	if err := u.checkAccount(ctx, trans.AccountID, trans.UserID); err != nil {
+		return nil, err
+	}
+
+	duplicates, err := u.getDuplicatesOf(ctx, trans)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if strict && len(duplicates) > 0 {
+		logger.Error("Create failed", "package", PackageName, "err", domain.ErrDuplicateTransaction)
+		return nil, domain.ErrDuplicateTransaction
 	}
 
-	// check if the type in main category matches the transaction type
-	if trans.Type != mainCateg.Type {
-		logger.Error("Create Transaction failed", "package", PackageName, "err", domain.ErrTypeNotConsistent)
-		return domain.ErrTypeNotConsistent
+	if err := u.create(ctx, trans); err != nil {
+		return nil, err
 	}
 
-	// check if the sub category exists
-	subCateg, err := u.SubCateg.GetByID(trans.SubCategID, trans.UserID)
+	return duplicates, nil
+}
+
+// categorizeByRules sets the categories of the transactions by the rules of the user, and adds the tags of the matched rules.
+// The rules are loaded once for all the transactions.
+func (u *UC) categorizeByRules(ctx context.Context, trans []*domain.CreateTransactionInput, userID int64) error {
+	rules, err := u.Rule.GetAll(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	// check if the sub category matches the main category
-	if subCateg.MainCategID != trans.MainCategID {
-		logger.Error("Create Transaction failed", "package", PackageName, "err", domain.ErrMainCategNotConsistent)
-		return domain.ErrMainCategNotConsistent
+	set := domain.NewRuleSet(rules)
+	for _, t := range trans {
+		if _, ok := set.Categorize(t); !ok {
+			logger.Error("categorizeByRules failed", "package", PackageName, "err", domain.ErrNoRuleMatched)
+			return domain.ErrNoRuleMatched
+		}
 	}
 
-	return u.Transaction.Create(ctx, trans)
+	return nil
+}
+
+func (u *UC) create(ctx context.Context, trans domain.CreateTransactionInput) error {
+	_, err := u.Transaction.Create(ctx, trans, ctxutil.GetMemberID(ctx, trans.UserID))
+	return err
 }
 
 func (u *UC) GetAll(ctx context.Context, opt domain.GetTransOpt, user domain.User) ([]domain.Transaction, domain.Cursor, error) {
+	opt, err := u.applyView(ctx, opt, user.ID)
+	if err != nil {
+		return nil, domain.Cursor{}, err
+	}
+
 	trans, decodedNextKeys, err := u.Transaction.GetAll(ctx, opt, user.ID)
 	if err != nil {
 		return nil, domain.Cursor{}, err
@@ -102,86 +180,271 @@ func (u *UC) GetAll(ctx context.Context, opt domain.GetTransOpt, user domain.Use
 		trans[i].MainCateg.IconData = url
 	}
 
-	var cursor domain.Cursor
-	if opt.Cursor.Size != 0 && len(trans) == opt.Cursor.Size {
-		cursor.Size = opt.Cursor.Size
-
-		// if it's the first page, we need to initialize the nextKey
-		// note that the order of decodedNextKeys does matter
-		// the query will be like:
-		// AND col_1 < or > val_1
-		// OR (col_1 = val_1 AND col_2 < or > val_2)
-		// the base field(ID)(col_2) should be the last field
-		// so we need to make sure the ID is the last field in the decodedNextKeys
-		if opt.Cursor.NextKey == "" {
-			decodedNextKeys = domain.DecodedNextKeys{}
-
-			if opt.Sort != nil && opt.Sort.By.IsValid() {
-				decodedNextKeys = append(decodedNextKeys, domain.DecodedNextKeyInfo{
-					Field: opt.Sort.By.GetField(),
-				})
+	// get and cache presigned URL of attachments
+	// the URL is cached for a day less than its lifetime, so the cached URL is never expired
+	for i, t := range trans {
+		for j, a := range t.Attachments {
+			key := domain.GenAttachmentCacheKey(a.ObjectKey)
+			url, err := u.Redis.GetByFunc(ctx, key, domain.AttachmentURLLifetime-24*time.Hour, func() (string, error) {
+				return u.S3.GetObjectUrl(ctx, a.ObjectKey, int64(domain.AttachmentURLLifetime.Seconds()))
+			})
+			if err != nil {
+				return nil, domain.Cursor{}, err
 			}
 
+			trans[i].Attachments[j].URL = url
+		}
+	}
+
+	if opt.Cursor.Size == 0 || len(trans) == 0 {
+		return trans, domain.Cursor{}, nil
+	}
+
+	// a full page means there may be more transactions in the direction of the cursor,
+	// and there are always transactions on the other side of the given key
+	backward := opt.Cursor.PrevKey != ""
+	hasNext := backward || len(trans) == opt.Cursor.Size
+	hasPrev := opt.Cursor.NextKey != "" || (backward && len(trans) == opt.Cursor.Size)
+	if !hasNext && !hasPrev {
+		return trans, domain.Cursor{}, nil
+	}
+
+	// if it's the first page, we need to initialize the nextKey
+	// note that the order of decodedNextKeys does matter
+	// the query will be like:
+	// AND (col_1 < or > val_1
+	// OR (col_1 = val_1 AND col_2 < or > val_2))
+	// the base field(ID)(col_2) should be the last field
+	// so we need to make sure the ID is the last field in the decodedNextKeys
+	if opt.Cursor.NextKey == "" && opt.Cursor.PrevKey == "" {
+		decodedNextKeys = domain.DecodedNextKeys{}
+
+		if opt.Sort != nil && opt.Sort.By.IsValid() {
 			decodedNextKeys = append(decodedNextKeys, domain.DecodedNextKeyInfo{
-				Field: "ID",
+				Field: opt.Sort.By.GetField(),
 			})
 		}
 
-		// encode the nextKey to string
+		decodedNextKeys = append(decodedNextKeys, domain.DecodedNextKeyInfo{
+			Field: "ID",
+		})
+	}
+
+	cursor := domain.Cursor{Size: opt.Cursor.Size}
+
+	// encode the nextKey with the last transaction, and the prevKey with the first one
+	if hasNext {
 		encodedNextKey, err := codeutil.EncodeNextKeys(decodedNextKeys, trans[len(trans)-1])
 		if err != nil {
 			return nil, domain.Cursor{}, err
 		}
-
 		cursor.NextKey = encodedNextKey
-	} else {
-		cursor.NextKey = ""
-		cursor.Size = 0
+	}
+
+	if hasPrev {
+		encodedPrevKey, err := codeutil.EncodeNextKeys(decodedNextKeys, trans[0])
+		if err != nil {
+			return nil, domain.Cursor{}, err
+		}
+		cursor.PrevKey = encodedPrevKey
 	}
 
 	return trans, cursor, nil
 }
 
-func (u *UC) Update(ctx context.Context, trans domain.UpdateTransactionInput, user domain.User) error {
-	// check if the main category exists
-	mainCateg, err := u.MainCateg.GetByID(trans.MainCategID, user.ID)
+func (u *UC) GetTotal(ctx context.Context, opt domain.GetTransOpt, user domain.User) (domain.TransTotal, error) {
+	opt, err := u.applyView(ctx, opt, user.ID)
+	if err != nil {
+		return domain.TransTotal{}, err
+	}
+
+	return u.Transaction.GetTotal(ctx, opt, user.ID)
+}
+
+func (u *UC) Export(ctx context.Context, opt domain.GetTransOpt, user domain.User, fn func(domain.Transaction) error) error {
+	opt, err := u.applyView(ctx, opt, user.ID)
 	if err != nil {
 		return err
 	}
 
-	// check if the type in main category matches the transaction type
-	if trans.Type != mainCateg.Type {
-		logger.Error("Update Transaction failed", "package", PackageName, "err", domain.ErrTypeNotConsistent)
-		return domain.ErrTypeNotConsistent
+	// export in chronological order by default, so the file reads like a statement
+	var sort domain.Sort
+	if opt.Sort != nil {
+		sort = *opt.Sort
+	}
+	if !sort.By.IsValid() {
+		sort.By = domain.SortByTypeDate
+	}
+	if !sort.Dir.IsValid() {
+		sort.Dir = domain.SortDirTypeAsc
 	}
+	opt.Sort = &sort
 
-	// check if the sub category exists
-	subCateg, err := u.SubCateg.GetByID(trans.SubCategID, user.ID)
+	return u.Transaction.StreamAll(ctx, opt, user.ID, fn)
+}
+
+func (u *UC) Update(ctx context.Context, trans domain.UpdateTransactionInput, user domain.User) error {
+	return u.update(ctx, trans, user, domain.RevisionActionTypeUpdate)
+}
+
+// update updates the transaction, and records the change as the given action
+func (u *UC) update(ctx context.Context, trans domain.UpdateTransactionInput, user domain.User, action domain.RevisionActionType) error {
+	if err := u.checkTags(ctx, trans.TagIDs, user.ID); err != nil {
+		return err
+	}
+
+	if trans.Type == domain.TransactionTypeTransfer {
+		if err := u.checkTransferAccounts(ctx, trans.AccountID, trans.ToAccountID, user.ID); err != nil {
+			return err
+		}
+
+		if err := u.checkChangeable(ctx, trans.ID, user.ID, action); err != nil {
+			return err
+		}
+
+		return u.Transaction.Update(ctx, trans, action, ctxutil.GetMemberID(ctx, user.ID))
+	}
+
+	// the split transaction is categorized by its first line
+	if len(trans.Splits) > 0 {
+		trans.MainCategID, trans.SubCategID = trans.Splits[0].MainCategID, trans.Splits[0].SubCategID
+	}
+
+	if err := u.checkCategs(trans.Type, trans.MainCategID, trans.SubCategID, user.ID); err != nil {
+		return err
+	}
+
+	// the first line is checked above
+	for i := 1; i < len(trans.Splits); i++ {
+		if err := u.checkCategs(trans.Type, trans.Splits[i].MainCategID, trans.Splits[i].SubCategID, user.ID); err != nil {
+			return err
+		}
+	}
+
+	// check if the account exists
+	if err := u.checkAccount(ctx, trans.AccountID, user.ID); err != nil {
+		return err
+	}
+
+	if err := u.checkChangeable(ctx, trans.ID, user.ID, action); err != nil {
+		return err
+	}
+
+	return u.Transaction.Update(ctx, trans, action, ctxutil.GetMemberID(ctx, user.ID))
+}
+
+// checkChangeable checks permission of the transaction to change.
+// The transaction in trash can only be reverted, which brings it back.
+func (u *UC) checkChangeable(ctx context.Context, id, userID int64, action domain.RevisionActionType) error {
+	if action == domain.RevisionActionTypeRevert {
+		_, err := u.Transaction.GetByIDAndUserIDIncludeTrash(ctx, id, userID)
+		return err
+	}
+
+	_, err := u.Transaction.GetByIDAndUserID(ctx, id, userID)
+	return err
+}
+
+func (u *UC) Delete(ctx context.Context, id int64, user domain.User) error {
+	// check permission
+	if _, err := u.Transaction.GetByIDAndUserID(ctx, id, user.ID); err != nil {
+		return err
+	}
+
+	return u.Transaction.Delete(ctx, id, ctxutil.GetMemberID(ctx, user.ID))
+}
+
+// GetHistory returns the history of the transaction, the transaction in trash also has its history
+func (u *UC) GetHistory(ctx context.Context, id int64, user domain.User) ([]domain.TransRevision, error) {
+	// check permission
+	if _, err := u.Transaction.GetByIDAndUserIDIncludeTrash(ctx, id, user.ID); err != nil {
+		return nil, err
+	}
+
+	return u.Revision.GetByTransID(ctx, id)
+}
+
+// Revert updates the transaction back to the snapshot after the given revision.
+// It goes through the same checks as update, so it fails if the categories, account or tags of the revision are gone.
+// The transaction in trash is brought back.
+func (u *UC) Revert(ctx context.Context, id, revisionID int64, user domain.User) error {
+	// check permission
+	if _, err := u.Transaction.GetByIDAndUserIDIncludeTrash(ctx, id, user.ID); err != nil {
+		return err
+	}
+
+	rev, err := u.Revision.GetByIDAndTransID(ctx, revisionID, id)
 	if err != nil {
 		return err
 	}
 
-	// check if the sub category matches the main category
-	if trans.MainCategID != subCateg.MainCategID {
-		logger.Error("Update Transaction failed", "package", PackageName, "err", domain.ErrMainCategNotConsistent)
-		return domain.ErrMainCategNotConsistent
+	if rev.After == nil {
+		return domain.ErrTransRevisionNotRevertible
+	}
+
+	return u.update(ctx, rev.After.ToUpdateInput(id), user, domain.RevisionActionTypeRevert)
+}
+
+// GetAttachmentPutURL returns the presigned URL to upload the file, and the object key to record the attachment with after the upload
+func (u *UC) GetAttachmentPutURL(ctx context.Context, id int64, fileName string, user domain.User) (domain.AttachmentPutURL, error) {
+	// check permission
+	if _, err := u.Transaction.GetByIDAndUserID(ctx, id, user.ID); err != nil {
+		return domain.AttachmentPutURL{}, err
+	}
+
+	objectKey, err := domain.GenAttachmentObjectKey(user.ID, id, fileName)
+	if err != nil {
+		logger.Error("domain.GenAttachmentObjectKey failed", "package", PackageName, "err", err)
+		return domain.AttachmentPutURL{}, err
+	}
+
+	ttl := 60 * time.Second
+	url, err := u.S3.PutObjectUrl(ctx, objectKey, int64(ttl.Seconds()))
+	if err != nil {
+		return domain.AttachmentPutURL{}, err
 	}
 
+	return domain.AttachmentPutURL{URL: url, ObjectKey: objectKey}, nil
+}
+
+// CreateAttachment records the attachment uploaded to the object key, which must be issued for the file of the transaction
+func (u *UC) CreateAttachment(ctx context.Context, id int64, fileName, objectKey string, user domain.User) error {
 	// check permission
-	if _, err := u.Transaction.GetByIDAndUserID(ctx, trans.ID, user.ID); err != nil {
+	if _, err := u.Transaction.GetByIDAndUserID(ctx, id, user.ID); err != nil {
 		return err
 	}
 
-	return u.Transaction.Update(ctx, trans)
+	if !domain.IsAttachmentObjectKeyOf(objectKey, user.ID, id, fileName) {
+		return domain.ErrAttachmentObjectKeyInvalid
+	}
+
+	a := domain.TransAttachment{
+		TransactionID: id,
+		UserID:        user.ID,
+		FileName:      fileName,
+		ObjectKey:     objectKey,
+	}
+
+	return u.Attachment.Create(ctx, a)
 }
 
-func (u *UC) Delete(ctx context.Context, id int64, user domain.User) error {
+// DeleteAttachment deletes the metadata before the file, so that the attachment is never listed without its file
+func (u *UC) DeleteAttachment(ctx context.Context, id, attachmentID int64, user domain.User) error {
 	// check permission
 	if _, err := u.Transaction.GetByIDAndUserID(ctx, id, user.ID); err != nil {
 		return err
 	}
 
-	return u.Transaction.Delete(ctx, id)
+	a, err := u.Attachment.GetByIDAndTransID(ctx, attachmentID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.Attachment.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+
+	return u.S3.DeleteObject(ctx, a.ObjectKey)
 }
 
 func (u *UC) GetAccInfo(ctx context.Context, user domain.User, query domain.GetAccInfoQuery, timeRange domain.TimeRangeType) (domain.AccInfo, error) {
@@ -208,44 +471,98 @@ func (u *UC) GetAccInfo(ctx context.Context, user domain.User, query domain.GetA
 
 }
 
-func (u *UC) GetBarChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, transactionType domain.TransactionType, mainCategIDs []int64, user domain.User) (domain.ChartData, error) {
+func (u *UC) GetBarChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, transactionType domain.TransactionType, mainCategIDs []int64, viewID int64, user domain.User) (domain.ChartData, error) {
+	viewOpt, err := u.getViewOpt(ctx, viewID, user.ID)
+	if err != nil {
+		return domain.ChartData{}, err
+	}
+
 	var dateToData domain.DateToChartData
-	var err error
 	if timeRangeType.IsDailyType() {
-		dateToData, err = u.Transaction.GetDailyBarChartData(ctx, chartDateRange, transactionType, mainCategIDs, user.ID)
+		dateToData, err = u.Transaction.GetDailyBarChartData(ctx, chartDateRange, transactionType, mainCategIDs, viewOpt, user.ID)
 		if err != nil {
 			return domain.ChartData{}, err
 		}
 	} else {
-		dateToData, err = u.Transaction.GetMonthlyBarChartData(ctx, chartDateRange, transactionType, mainCategIDs, user.ID)
+		dateToData, err = u.Transaction.GetMonthlyBarChartData(ctx, chartDateRange, transactionType, mainCategIDs, viewOpt, user.ID)
 		if err != nil {
 			return domain.ChartData{}, err
 		}
 	}
 
-	return genChartData(dateToData, timeRangeType, chartDateRange.Start, chartDateRange.End), nil
+	data := genChartData(dateToData, timeRangeType, chartDateRange.Start, chartDateRange.End)
+	return u.setUnconverted(ctx, data, chartDateRange, transactionType, viewOpt, user.ID)
 }
 
-func (u *UC) GetPieChartData(ctx context.Context, chartDateRange domain.ChartDateRange, transactionType domain.TransactionType, user domain.User) (domain.ChartData, error) {
-	return u.Transaction.GetPieChartData(ctx, chartDateRange, transactionType, user.ID)
+func (u *UC) GetPieChartData(ctx context.Context, chartDateRange domain.ChartDateRange, transactionType domain.TransactionType, viewID int64, user domain.User) (domain.ChartData, error) {
+	viewOpt, err := u.getViewOpt(ctx, viewID, user.ID)
+	if err != nil {
+		return domain.ChartData{}, err
+	}
+
+	data, err := u.Transaction.GetPieChartData(ctx, chartDateRange, transactionType, viewOpt, user.ID)
+	if err != nil {
+		return domain.ChartData{}, err
+	}
+
+	return u.setUnconverted(ctx, data, chartDateRange, transactionType, viewOpt, user.ID)
 }
 
-func (u *UC) GetLineChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, user domain.User) (domain.ChartData, error) {
+func (u *UC) GetTagChartData(ctx context.Context, chartDateRange domain.ChartDateRange, transactionType domain.TransactionType, viewID int64, user domain.User) (domain.ChartData, error) {
+	viewOpt, err := u.getViewOpt(ctx, viewID, user.ID)
+	if err != nil {
+		return domain.ChartData{}, err
+	}
+
+	sums, err := u.Transaction.GetSumByTag(ctx, chartDateRange, transactionType, viewOpt, user.ID)
+	if err != nil {
+		return domain.ChartData{}, err
+	}
+
+	var labels []string
+	var datasets []float64
+	for _, s := range sums {
+		labels = append(labels, s.Tag.Name)
+		datasets = append(datasets, s.Sum)
+	}
+
+	data := domain.ChartData{Labels: labels, Datasets: datasets}
+	return u.setUnconverted(ctx, data, chartDateRange, transactionType, viewOpt, user.ID)
+}
+
+func (u *UC) GetLineChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, viewID int64, user domain.User) (domain.ChartData, error) {
+	viewOpt, err := u.getViewOpt(ctx, viewID, user.ID)
+	if err != nil {
+		return domain.ChartData{}, err
+	}
+
 	var dateToData domain.DateToChartData
-	var err error
 	if timeRangeType.IsDailyType() {
-		dateToData, err = u.Transaction.GetDailyLineChartData(ctx, chartDateRange, user.ID)
+		dateToData, err = u.Transaction.GetDailyLineChartData(ctx, chartDateRange, viewOpt, user.ID)
 		if err != nil {
 			return domain.ChartData{}, err
 		}
 	} else {
-		dateToData, err = u.Transaction.GetMonthlyLineChartData(ctx, chartDateRange, user.ID)
+		dateToData, err = u.Transaction.GetMonthlyLineChartData(ctx, chartDateRange, viewOpt, user.ID)
 		if err != nil {
 			return domain.ChartData{}, err
 		}
 	}
 
-	return genLineChartData(dateToData, timeRangeType, chartDateRange.Start, chartDateRange.End), nil
+	// the line chart is the balance of both income and expense
+	data := genLineChartData(dateToData, timeRangeType, chartDateRange.Start, chartDateRange.End)
+	return u.setUnconverted(ctx, data, chartDateRange, domain.TransactionTypeUnSpecified, viewOpt, user.ID)
+}
+
+// setUnconverted sets the number of transactions left out of the chart, because there's no exchange rate to the base currency
+func (u *UC) setUnconverted(ctx context.Context, data domain.ChartData, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) (domain.ChartData, error) {
+	count, err := u.Transaction.CountUnconverted(ctx, dateRange, transactionType, opt, userID)
+	if err != nil {
+		return domain.ChartData{}, err
+	}
+
+	data.Unconverted = count
+	return data, nil
 }
 
 func (u *UC) GetMonthlyData(ctx context.Context, dateRange domain.GetMonthlyDateRange, user domain.User) ([]domain.TransactionType, error) {
@@ -272,3 +589,108 @@ func (u *UC) GetMonthlyData(ctx context.Context, dateRange domain.GetMonthlyDate
 
 	return data, nil
 }
+
+// checkCategs checks if the categories exist, and match the transaction type and each other
+func (u *UC) checkCategs(transType domain.TransactionType, mainCategID, subCategID, userID int64) error {
+	// check if the main category exists
+	mainCateg, err := u.MainCateg.GetByID(mainCategID, userID)
+	if err != nil {
+		return err
+	}
+
+	// check if the type in main category matches the transaction type
+	if transType != mainCateg.Type {
+		logger.Error("Check categories failed", "package", PackageName, "err", domain.ErrTypeNotConsistent)
+		return domain.ErrTypeNotConsistent
+	}
+
+	// check if the sub category exists
+	subCateg, err := u.SubCateg.GetByID(subCategID, userID)
+	if err != nil {
+		return err
+	}
+
+	// check if the sub category matches the main category
+	if subCateg.MainCategID != mainCategID {
+		logger.Error("Check categories failed", "package", PackageName, "err", domain.ErrMainCategNotConsistent)
+		return domain.ErrMainCategNotConsistent
+	}
+
+	return nil
+}
+
+// checkAccount checks if the account exists, 0 means the transaction doesn't belong to any account
+func (u *UC) checkAccount(ctx context.Context, accountID, userID int64) error {
+	if accountID == 0 {
+		return nil
+	}
+
+	_, err := u.Account.GetByIDAndUserID(ctx, accountID, userID)
+	return err
+}
+
+// checkTags checks if all the tags belong to the user
+func (u *UC) checkTags(ctx context.Context, tagIDs []int64, userID int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	tags, err := u.Tag.GetByIDs(ctx, tagIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	// the ids are validated to be unique, so any missing tag doesn't exist or belongs to other user
+	if len(tags) != len(tagIDs) {
+		logger.Error("Check tags failed", "package", PackageName, "err", domain.ErrTagNotFound)
+		return domain.ErrTagNotFound
+	}
+
+	return nil
+}
+
+// checkTransferAccounts checks if both accounts of the transfer exist, and they are different
+func (u *UC) checkTransferAccounts(ctx context.Context, accountID, toAccountID, userID int64) error {
+	if accountID == toAccountID {
+		logger.Error("Transfer failed", "package", PackageName, "err", domain.ErrTransferSameAccount)
+		return domain.ErrTransferSameAccount
+	}
+
+	if _, err := u.Account.GetByIDAndUserID(ctx, accountID, userID); err != nil {
+		return err
+	}
+
+	if _, err := u.Account.GetByIDAndUserID(ctx, toAccountID, userID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// applyView returns opt with the filter, sort and search of the saved view of opt.ViewID, or opt itself when it's not set
+func (u *UC) applyView(ctx context.Context, opt domain.GetTransOpt, userID int64) (domain.GetTransOpt, error) {
+	if opt.ViewID == 0 {
+		return opt, nil
+	}
+
+	view, err := u.View.GetByIDAndUserID(ctx, opt.ViewID, userID)
+	if err != nil {
+		return domain.GetTransOpt{}, err
+	}
+
+	return view.Apply(opt), nil
+}
+
+// getViewOpt returns the option of the saved view to narrow down chart data, or nil when viewID is 0
+func (u *UC) getViewOpt(ctx context.Context, viewID, userID int64) (*domain.GetTransOpt, error) {
+	if viewID == 0 {
+		return nil, nil
+	}
+
+	opt, err := u.applyView(ctx, domain.GetTransOpt{ViewID: viewID}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &opt, nil
+}
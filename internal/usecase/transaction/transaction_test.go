@@ -3,12 +3,15 @@ package transaction
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
 	"github.com/eyo-chen/expense-tracker-go/mocks"
 	"github.com/eyo-chen/expense-tracker-go/pkg/codeutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
 	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
 	"github.com/stretchr/testify/mock"
@@ -30,6 +33,12 @@ type TransactionSuite struct {
 	mockSubCategRepo     *mocks.SubCategRepo
 	mockRedis            *mocks.RedisService
 	mockS3               *mocks.S3Service
+	mockAccountRepo      *mocks.AccountRepo
+	mockTagRepo          *mocks.TagRepo
+	mockRevisionRepo     *mocks.TransRevisionRepo
+	mockAttachmentRepo   *mocks.AttachmentRepo
+	mockRuleRepo         *mocks.RuleRepo
+	mockViewRepo         *mocks.ViewRepo
 }
 
 func TestTransactionSuite(t *testing.T) {
@@ -59,7 +68,13 @@ func (s *TransactionSuite) SetupTest() {
 	s.mockSubCategRepo = mocks.NewSubCategRepo(s.T())
 	s.mockRedis = mocks.NewRedisService(s.T())
 	s.mockS3 = mocks.NewS3Service(s.T())
-	s.uc = New(s.mockTransactionRepo, s.mockMainCategRepo, s.mockSubCategRepo, s.mockMonthlyTransRepo, s.mockRedis, s.mockS3)
+	s.mockAccountRepo = mocks.NewAccountRepo(s.T())
+	s.mockTagRepo = mocks.NewTagRepo(s.T())
+	s.mockRevisionRepo = mocks.NewTransRevisionRepo(s.T())
+	s.mockAttachmentRepo = mocks.NewAttachmentRepo(s.T())
+	s.mockRuleRepo = mocks.NewRuleRepo(s.T())
+	s.mockViewRepo = mocks.NewViewRepo(s.T())
+	s.uc = New(s.mockTransactionRepo, s.mockMainCategRepo, s.mockSubCategRepo, s.mockMonthlyTransRepo, s.mockRedis, s.mockS3, s.mockAccountRepo, s.mockTagRepo, s.mockRevisionRepo, s.mockAttachmentRepo, s.mockRuleRepo, s.mockViewRepo)
 }
 
 func (s *TransactionSuite) TearDownTest() {
@@ -68,6 +83,11 @@ func (s *TransactionSuite) TearDownTest() {
 	s.mockSubCategRepo.AssertExpectations(s.T())
 	s.mockRedis.AssertExpectations(s.T())
 	s.mockS3.AssertExpectations(s.T())
+	s.mockAccountRepo.AssertExpectations(s.T())
+	s.mockTagRepo.AssertExpectations(s.T())
+	s.mockRevisionRepo.AssertExpectations(s.T())
+	s.mockAttachmentRepo.AssertExpectations(s.T())
+	s.mockRuleRepo.AssertExpectations(s.T())
 }
 
 func (s *TransactionSuite) TestCreate() {
@@ -78,6 +98,18 @@ func (s *TransactionSuite) TestCreate() {
 		"when get sub category fail, return error":                                                create_GetSubCategFail_ReturnError,
 		"when main category of sub category not match main category of transaction, return error": create_MainCategNotMatch_ReturnError,
 		"when create fail, return error":                                                          create_CreateFail_ReturnError,
+		"when account not found, return error":                                                    create_AccountNotFound_ReturnError,
+		"when transfer, skip categories and create successfully":                                  create_Transfer_CreateSuccessfully,
+		"when transfer to the same account, return error":                                         create_TransferSameAccount_ReturnError,
+		"when transfer to account not found, return error":                                        create_TransferToAccountNotFound_ReturnError,
+		"when split, check each line and categorize by the first line":                            create_Split_CategorizeByFirstLine,
+		"when type of split line not match transaction type, return error":                        create_SplitLineTypeNotMatch_ReturnError,
+		"when with tags, check tags and create successfully":                                      create_WithTags_CreateSuccessfully,
+		"when tag not found, return error":                                                        create_TagNotFound_ReturnError,
+		"when without categories, categorize by the first matched rule":                           create_Uncategorized_CategorizeByRule,
+		"when without categories and no rule matches, return error":                               create_NoRuleMatched_ReturnError,
+		"when similar transaction exists, create and return it as duplicate":                      create_SimilarTransExists_ReturnDuplicates,
+		"when similar transaction exists in strict mode, return error":                            create_SimilarTransExistsStrict_ReturnError,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -106,13 +138,177 @@ func create_NoError_CreateSuccessfully(s *TransactionSuite, desc string) {
 	// prepare mock services
 	s.mockMainCategRepo.Mock.On("GetByID", transInput.MainCategID, transInput.UserID).Return(&mainCateg, nil).Once()
 	s.mockSubCategRepo.Mock.On("GetByID", transInput.SubCategID, transInput.UserID).Return(&subCateg, nil).Once()
-	s.mockTransactionRepo.Mock.On("Create", mockCtx, transInput).Return(nil).Once()
+	s.mockTransactionRepo.On("GetDuplicateCandidates", mockCtx, transInput, domain.DuplicateWindowDays).Return(nil, nil).Once()
+	s.mockTransactionRepo.Mock.On("Create", mockCtx, transInput, int64(1)).Return(int64(2), nil).Once()
 
 	// action, assertion
-	err := s.uc.Create(mockCtx, transInput)
+	_, err := s.uc.Create(mockCtx, transInput, false)
 	s.Require().NoError(err, desc)
 }
 
+func create_SimilarTransExists_ReturnDuplicates(s *TransactionSuite, desc string) {
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 1, MainCategID: 1}
+	transInput := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: 1,
+		SubCategID:  1,
+		Price:       100,
+		Date:        mockTimeNow,
+		Note:        "Lunch at cafe",
+	}
+
+	// only the one with similar note is the duplicate
+	candidates := []domain.Transaction{
+		{ID: 5, Price: 100, Date: mockTimeNow.AddDate(0, 0, -1), Note: "lunch  at CAFE"},
+		{ID: 6, Price: 100, Date: mockTimeNow, Note: "gift for mom"},
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(1), int64(1)).Return(&subCateg, nil).Once()
+	s.mockTransactionRepo.On("GetDuplicateCandidates", mockCtx, transInput, domain.DuplicateWindowDays).Return(candidates, nil).Once()
+	s.mockTransactionRepo.On("Create", mockCtx, transInput, int64(1)).Return(int64(7), nil).Once()
+
+	duplicates, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.Transaction{candidates[0]}, duplicates, desc)
+}
+
+func create_SimilarTransExistsStrict_ReturnError(s *TransactionSuite, desc string) {
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 1, MainCategID: 1}
+	transInput := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: 1,
+		SubCategID:  1,
+		Price:       100,
+		Date:        mockTimeNow,
+		Note:        "lunch",
+	}
+
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(1), int64(1)).Return(&subCateg, nil).Once()
+	s.mockTransactionRepo.On("GetDuplicateCandidates", mockCtx, transInput, domain.DuplicateWindowDays).
+		Return([]domain.Transaction{{ID: 5, Note: "lunch"}}, nil).Once()
+
+	duplicates, err := s.uc.Create(mockCtx, transInput, true)
+	s.Require().ErrorIs(err, domain.ErrDuplicateTransaction, desc)
+	s.Require().Nil(duplicates, desc)
+}
+
+func create_WithTags_CreateSuccessfully(s *TransactionSuite, desc string) {
+	// prepare mock data
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 1, MainCategID: 1}
+	tags := []domain.Tag{{ID: 1, Name: "trip-japan"}, {ID: 2, Name: "reimbursable"}}
+
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: 1,
+		SubCategID:  1,
+		Price:       100,
+		Date:        mockTimeNow,
+		TagIDs:      []int64{1, 2},
+	}
+
+	// prepare mock services
+	s.mockTagRepo.On("GetByIDs", mockCtx, []int64{1, 2}, int64(1)).Return(tags, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(1), int64(1)).Return(&subCateg, nil).Once()
+	s.mockTransactionRepo.On("GetDuplicateCandidates", mockCtx, transInput, domain.DuplicateWindowDays).Return(nil, nil).Once()
+	s.mockTransactionRepo.On("Create", mockCtx, transInput, int64(1)).Return(int64(1), nil).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().NoError(err, desc)
+}
+
+func create_Uncategorized_CategorizeByRule(s *TransactionSuite, desc string) {
+	// prepare mock data
+	minPrice := 50.0
+	rules := []domain.Rule{
+		{ID: 1, Type: domain.TransactionTypeExpense, NoteContains: "uber", MainCateg: domain.MainCateg{ID: 3}, SubCateg: domain.SubCateg{ID: 3}},
+		{ID: 2, Type: domain.TransactionTypeExpense, NoteContains: "starbucks", MinPrice: &minPrice, MainCateg: domain.MainCateg{ID: 4}, SubCateg: domain.SubCateg{ID: 4}},
+		{ID: 3, Type: domain.TransactionTypeExpense, NoteRegex: "(?i)^starbucks", MainCateg: domain.MainCateg{ID: 1}, SubCateg: domain.SubCateg{ID: 2}, Tags: []domain.Tag{{ID: 2}, {ID: 5}}},
+	}
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 2, MainCategID: 1}
+
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID: 1,
+		Type:   domain.TransactionTypeExpense,
+		Price:  12,
+		Date:   mockTimeNow,
+		Note:   "STARBUCKS #123",
+		TagIDs: []int64{5},
+	}
+
+	// prepare expected result
+	expTrans := transInput
+	expTrans.MainCategID, expTrans.SubCategID = 1, 2
+	expTrans.TagIDs = []int64{5, 2}
+
+	// prepare mock services
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return(rules, nil).Once()
+	s.mockTagRepo.On("GetByIDs", mockCtx, []int64{5, 2}, int64(1)).Return([]domain.Tag{{ID: 5}, {ID: 2}}, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(2), int64(1)).Return(&subCateg, nil).Once()
+	s.mockTransactionRepo.On("GetDuplicateCandidates", mockCtx, expTrans, domain.DuplicateWindowDays).Return(nil, nil).Once()
+	s.mockTransactionRepo.On("Create", mockCtx, expTrans, int64(1)).Return(int64(1), nil).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().NoError(err, desc)
+}
+
+func create_NoRuleMatched_ReturnError(s *TransactionSuite, desc string) {
+	// prepare mock data
+	rules := []domain.Rule{
+		{ID: 1, Type: domain.TransactionTypeIncome, NoteContains: "salary", MainCateg: domain.MainCateg{ID: 3}, SubCateg: domain.SubCateg{ID: 3}},
+	}
+
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID: 1,
+		Type:   domain.TransactionTypeExpense,
+		Price:  100,
+		Date:   mockTimeNow,
+		Note:   "salary",
+	}
+
+	// prepare mock services
+	s.mockRuleRepo.On("GetAll", mockCtx, int64(1)).Return(rules, nil).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().ErrorIs(err, domain.ErrNoRuleMatched, desc)
+}
+
+func create_TagNotFound_ReturnError(s *TransactionSuite, desc string) {
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: 1,
+		SubCategID:  1,
+		Price:       100,
+		Date:        mockTimeNow,
+		TagIDs:      []int64{1, 2},
+	}
+
+	// prepare mock services, tag 2 belongs to other user
+	s.mockTagRepo.On("GetByIDs", mockCtx, []int64{1, 2}, int64(1)).Return([]domain.Tag{{ID: 1}}, nil).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().ErrorIs(err, domain.ErrTagNotFound, desc)
+}
+
 func create_GetMainCategFail_ReturnError(s *TransactionSuite, desc string) {
 	// prepare input
 	transInput := domain.CreateTransactionInput{
@@ -131,7 +327,7 @@ func create_GetMainCategFail_ReturnError(s *TransactionSuite, desc string) {
 	s.mockMainCategRepo.Mock.On("GetByID", transInput.MainCategID, transInput.UserID).Return(nil, mockErr).Once()
 
 	// action, assertion
-	err := s.uc.Create(mockCtx, transInput)
+	_, err := s.uc.Create(mockCtx, transInput, false)
 	s.Require().ErrorIs(err, mockErr, desc)
 }
 
@@ -154,7 +350,7 @@ func create_TypeNotMatch_ReturnError(s *TransactionSuite, desc string) {
 	s.mockMainCategRepo.Mock.On("GetByID", transInput.MainCategID, transInput.UserID).Return(&mainCateg, nil).Once()
 
 	// action, assertion
-	err := s.uc.Create(mockCtx, transInput)
+	_, err := s.uc.Create(mockCtx, transInput, false)
 	s.Require().ErrorIs(err, domain.ErrTypeNotConsistent, desc)
 }
 
@@ -180,7 +376,7 @@ func create_GetSubCategFail_ReturnError(s *TransactionSuite, desc string) {
 	s.mockSubCategRepo.Mock.On("GetByID", transInput.SubCategID, transInput.UserID).Return(nil, mockErr).Once()
 
 	// action, assertion
-	err := s.uc.Create(mockCtx, transInput)
+	_, err := s.uc.Create(mockCtx, transInput, false)
 	s.Require().ErrorIs(err, mockErr, desc)
 }
 
@@ -205,7 +401,7 @@ func create_MainCategNotMatch_ReturnError(s *TransactionSuite, desc string) {
 	s.mockSubCategRepo.Mock.On("GetByID", transInput.SubCategID, transInput.UserID).Return(&subCateg, nil).Once()
 
 	// action, assertion
-	err := s.uc.Create(mockCtx, transInput)
+	_, err := s.uc.Create(mockCtx, transInput, false)
 	s.Require().EqualError(err, domain.ErrMainCategNotConsistent.Error(), desc)
 }
 
@@ -230,13 +426,161 @@ func create_CreateFail_ReturnError(s *TransactionSuite, desc string) {
 	// prepare mock services
 	s.mockMainCategRepo.Mock.On("GetByID", transInput.MainCategID, transInput.UserID).Return(&mainCateg, nil).Once()
 	s.mockSubCategRepo.Mock.On("GetByID", transInput.SubCategID, transInput.UserID).Return(&subCateg, nil).Once()
-	s.mockTransactionRepo.Mock.On("Create", mockCtx, transInput).Return(mockErr).Once()
+	s.mockTransactionRepo.On("GetDuplicateCandidates", mockCtx, transInput, domain.DuplicateWindowDays).Return(nil, nil).Once()
+	s.mockTransactionRepo.Mock.On("Create", mockCtx, transInput, int64(1)).Return(int64(0), mockErr).Once()
 
 	// action, assertion
-	err := s.uc.Create(mockCtx, transInput)
+	_, err := s.uc.Create(mockCtx, transInput, false)
 	s.Require().ErrorIs(err, mockErr, desc)
 }
 
+func create_AccountNotFound_ReturnError(s *TransactionSuite, desc string) {
+	// prepare mock data
+	mainCateg := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	subCateg := domain.SubCateg{ID: 1, MainCategID: 1}
+
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: 1,
+		SubCategID:  1,
+		Price:       100,
+		Date:        mockTimeNow,
+		AccountID:   2,
+	}
+
+	// prepare mock services
+	s.mockMainCategRepo.Mock.On("GetByID", transInput.MainCategID, transInput.UserID).Return(&mainCateg, nil).Once()
+	s.mockSubCategRepo.Mock.On("GetByID", transInput.SubCategID, transInput.UserID).Return(&subCateg, nil).Once()
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, transInput.AccountID, transInput.UserID).Return(domain.Account{}, domain.ErrAccountNotFound).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().ErrorIs(err, domain.ErrAccountNotFound, desc)
+}
+
+func create_Transfer_CreateSuccessfully(s *TransactionSuite, desc string) {
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeTransfer,
+		Price:       100,
+		Date:        mockTimeNow,
+		AccountID:   2,
+		ToAccountID: 3,
+	}
+
+	// prepare mock services
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(2), int64(1)).Return(domain.Account{ID: 2}, nil).Once()
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(3), int64(1)).Return(domain.Account{ID: 3}, nil).Once()
+	s.mockTransactionRepo.On("Create", mockCtx, transInput, int64(1)).Return(int64(1), nil).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().NoError(err, desc)
+}
+
+func create_TransferSameAccount_ReturnError(s *TransactionSuite, desc string) {
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeTransfer,
+		Price:       100,
+		Date:        mockTimeNow,
+		AccountID:   2,
+		ToAccountID: 2,
+	}
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().ErrorIs(err, domain.ErrTransferSameAccount, desc)
+}
+
+func create_TransferToAccountNotFound_ReturnError(s *TransactionSuite, desc string) {
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID:      1,
+		Type:        domain.TransactionTypeTransfer,
+		Price:       100,
+		Date:        mockTimeNow,
+		AccountID:   2,
+		ToAccountID: 3,
+	}
+
+	// prepare mock services
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(2), int64(1)).Return(domain.Account{ID: 2}, nil).Once()
+	s.mockAccountRepo.On("GetByIDAndUserID", mockCtx, int64(3), int64(1)).Return(domain.Account{}, domain.ErrAccountNotFound).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().ErrorIs(err, domain.ErrAccountNotFound, desc)
+}
+
+func create_Split_CategorizeByFirstLine(s *TransactionSuite, desc string) {
+	// prepare mock data
+	food := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	household := domain.MainCateg{ID: 2, Type: domain.TransactionTypeExpense}
+	snack := domain.SubCateg{ID: 3, MainCategID: 1}
+	cleaning := domain.SubCateg{ID: 4, MainCategID: 2}
+
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID: 1,
+		Type:   domain.TransactionTypeExpense,
+		Price:  100,
+		Date:   mockTimeNow,
+		Splits: []domain.SplitInput{
+			{MainCategID: 1, SubCategID: 3, Price: 60},
+			{MainCategID: 2, SubCategID: 4, Price: 40},
+		},
+	}
+
+	expInput := transInput
+	expInput.MainCategID = 1
+	expInput.SubCategID = 3
+
+	// prepare mock services
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&food, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), int64(1)).Return(&household, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(3), int64(1)).Return(&snack, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(4), int64(1)).Return(&cleaning, nil).Once()
+	s.mockTransactionRepo.On("GetDuplicateCandidates", mockCtx, expInput, domain.DuplicateWindowDays).Return(nil, nil).Once()
+	s.mockTransactionRepo.On("Create", mockCtx, expInput, int64(1)).Return(int64(1), nil).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().NoError(err, desc)
+}
+
+func create_SplitLineTypeNotMatch_ReturnError(s *TransactionSuite, desc string) {
+	// prepare mock data
+	food := domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}
+	salary := domain.MainCateg{ID: 2, Type: domain.TransactionTypeIncome}
+	snack := domain.SubCateg{ID: 3, MainCategID: 1}
+
+	// prepare input
+	transInput := domain.CreateTransactionInput{
+		UserID: 1,
+		Type:   domain.TransactionTypeExpense,
+		Price:  100,
+		Date:   mockTimeNow,
+		Splits: []domain.SplitInput{
+			{MainCategID: 1, SubCategID: 3, Price: 60},
+			{MainCategID: 2, SubCategID: 4, Price: 40},
+		},
+	}
+
+	// prepare mock services
+	s.mockMainCategRepo.On("GetByID", int64(1), int64(1)).Return(&food, nil).Once()
+	s.mockMainCategRepo.On("GetByID", int64(2), int64(1)).Return(&salary, nil).Once()
+	s.mockSubCategRepo.On("GetByID", int64(3), int64(1)).Return(&snack, nil).Once()
+
+	// action, assertion
+	_, err := s.uc.Create(mockCtx, transInput, false)
+	s.Require().ErrorIs(err, domain.ErrTypeNotConsistent, desc)
+}
+
 func (s *TransactionSuite) TestGetAll() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
 		"when no error, return transactions":                                        getAll_NoError_ReturnTransactions,
@@ -245,8 +589,13 @@ func (s *TransactionSuite) TestGetAll() {
 		"when it's the first page with size and sort, return correct cursor":        getAll_InitPageWithSizeAndSort_ReturnCorrectCursor,
 		"when it's not the first page with decoded next key, return correct cursor": getAll_WithDecodedNextKey_ReturnCorrectCursor,
 		"when size is empty value, return no cursor":                                getAll_SizeIsEmptyValue_ReturnNoCursor,
+		"when it's a full page before previous key, return both keys":               getAll_WithPrevKey_ReturnCorrectCursor,
+		"when it's the first page before previous key, return no previous key":      getAll_WithPrevKeyFirstPage_ReturnNoPrevKey,
 		"when with custom icon, return transactions":                                getAll_WithCustomIcon_ReturnTransactions,
 		"when get presigned URL of custom icon fail, return error":                  getAll_GetByFuncFail_ReturnError,
+		"when with attachments, return presigned URL":                               getAll_WithAttachments_ReturnPresignedURL,
+		"when with view, apply the view":                                            getAll_WithView_ApplyView,
+		"when view not found, return error":                                         getAll_ViewNotFound_ReturnError,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -271,6 +620,50 @@ func getAll_NoError_ReturnTransactions(s *TransactionSuite, desc string) {
 	s.Require().Empty(cursor, desc)
 }
 
+func getAll_WithView_ApplyView(s *TransactionSuite, desc string) {
+	minPrice := 20.0
+	mockView := domain.View{
+		ID:     2,
+		Name:   "big spending",
+		Filter: domain.Filter{MinPrice: &minPrice},
+		Sort:   &domain.Sort{By: domain.SortByTypePrice, Dir: domain.SortDirTypeDesc},
+	}
+	mockOpt := domain.GetTransOpt{ViewID: 2, Cursor: domain.Cursor{NextKey: "abc"}}
+	mockUser := domain.User{ID: 1}
+	mockTrans := []domain.Transaction{{ID: 1, UserID: 1}}
+
+	// the filter and sort of the view are used, and the cursor is kept
+	expOpt := domain.GetTransOpt{
+		Filter: domain.Filter{MinPrice: &minPrice},
+		Sort:   &domain.Sort{By: domain.SortByTypePrice, Dir: domain.SortDirTypeDesc},
+		Cursor: domain.Cursor{NextKey: "abc"},
+		ViewID: 2,
+	}
+
+	s.mockViewRepo.On("GetByIDAndUserID", mockCtx, int64(2), int64(1)).
+		Return(mockView, nil).Once()
+
+	s.mockTransactionRepo.On("GetAll", mockCtx, expOpt, int64(1)).
+		Return(mockTrans, domain.DecodedNextKeys{}, nil).Once()
+
+	result, _, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(mockTrans, result, desc)
+}
+
+func getAll_ViewNotFound_ReturnError(s *TransactionSuite, desc string) {
+	mockOpt := domain.GetTransOpt{ViewID: 2}
+	mockUser := domain.User{ID: 1}
+
+	s.mockViewRepo.On("GetByIDAndUserID", mockCtx, int64(2), int64(1)).
+		Return(domain.View{}, domain.ErrViewNotFound).Once()
+
+	result, cursor, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	s.Require().ErrorIs(err, domain.ErrViewNotFound, desc)
+	s.Require().Nil(result, desc)
+	s.Require().Empty(cursor, desc)
+}
+
 func getAll_GetTransFail_ReturnError(s *TransactionSuite, desc string) {
 	mockDecodedNextKeys := domain.DecodedNextKeys{}
 	mockOpt := domain.GetTransOpt{}
@@ -352,6 +745,55 @@ func getAll_WithDecodedNextKey_ReturnCorrectCursor(s *TransactionSuite, desc str
 	encodedNextKey, err := codeutil.DecodeNextKeys(cursor.NextKey, nil)
 	s.Require().NoError(err, desc)
 	s.Require().Equal(domain.DecodedNextKeys{{Field: "ID", Value: "2"}}, encodedNextKey, desc)
+
+	// there are transactions before the next key, so the previous key is the first transaction
+	decodedPrevKey, err := codeutil.DecodeNextKeys(cursor.PrevKey, nil)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.DecodedNextKeys{{Field: "ID", Value: "2"}}, decodedPrevKey, desc)
+}
+
+func getAll_WithPrevKey_ReturnCorrectCursor(s *TransactionSuite, desc string) {
+	mockDecodedNextKeys := domain.DecodedNextKeys{{Field: "Price", Value: "10"}, {Field: "ID", Value: "5"}}
+	mockOpt := domain.GetTransOpt{
+		Sort:   &domain.Sort{By: domain.SortByTypePrice, Dir: domain.SortDirTypeDesc},
+		Cursor: domain.Cursor{Size: 2, PrevKey: "UHJpY2U6MTAsSUQ6NQ=="},
+	}
+	mockUser := domain.User{ID: 1}
+	mockTrans := []domain.Transaction{{ID: 3, UserID: 1, Price: 30}, {ID: 4, UserID: 1, Price: 20}}
+
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).
+		Return(mockTrans, mockDecodedNextKeys, nil).Once()
+
+	result, cursor, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(mockTrans, result, desc)
+	s.Require().Equal(2, cursor.Size, desc)
+
+	decodedNextKey, err := codeutil.DecodeNextKeys(cursor.NextKey, nil)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.DecodedNextKeys{{Field: "Price", Value: "20.000000"}, {Field: "ID", Value: "4"}}, decodedNextKey, desc)
+
+	decodedPrevKey, err := codeutil.DecodeNextKeys(cursor.PrevKey, nil)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.DecodedNextKeys{{Field: "Price", Value: "30.000000"}, {Field: "ID", Value: "3"}}, decodedPrevKey, desc)
+}
+
+func getAll_WithPrevKeyFirstPage_ReturnNoPrevKey(s *TransactionSuite, desc string) {
+	mockDecodedNextKeys := domain.DecodedNextKeys{{Field: "ID", Value: "5"}}
+	mockOpt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 3, PrevKey: "SUQ6NQ=="}}
+	mockUser := domain.User{ID: 1}
+	mockTrans := []domain.Transaction{{ID: 1, UserID: 1}, {ID: 2, UserID: 1}}
+
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).
+		Return(mockTrans, mockDecodedNextKeys, nil).Once()
+
+	_, cursor, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Empty(cursor.PrevKey, desc)
+
+	decodedNextKey, err := codeutil.DecodeNextKeys(cursor.NextKey, nil)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(domain.DecodedNextKeys{{Field: "ID", Value: "2"}}, decodedNextKey, desc)
 }
 
 func getAll_SizeIsEmptyValue_ReturnNoCursor(s *TransactionSuite, desc string) {
@@ -369,48 +811,195 @@ func getAll_SizeIsEmptyValue_ReturnNoCursor(s *TransactionSuite, desc string) {
 	s.Require().Equal(domain.Cursor{}, cursor, desc)
 }
 
-func getAll_WithCustomIcon_ReturnTransactions(s *TransactionSuite, desc string) {
-	mockDecodedNextKeys := domain.DecodedNextKeys{}
-	mockOpt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 3}}
+func getAll_WithCustomIcon_ReturnTransactions(s *TransactionSuite, desc string) {
+	mockDecodedNextKeys := domain.DecodedNextKeys{}
+	mockOpt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 3}}
+	mockUser := domain.User{ID: 1}
+	mockTrans := []domain.Transaction{
+		{ID: 1, UserID: 1, MainCateg: domain.MainCateg{IconType: domain.IconTypeCustom, IconData: "test"}},
+		{ID: 2, UserID: 1, MainCateg: domain.MainCateg{IconType: domain.IconTypeDefault, IconData: "test2"}},
+	}
+	mockKey := "user_icon-test"
+	mockTTL := 7 * 24 * time.Hour
+	mockGetFun := mock.AnythingOfType("func() (string, error)")
+
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).
+		Return(mockTrans, mockDecodedNextKeys, nil).Once()
+	s.mockRedis.On("GetByFunc", mockCtx, mockKey, mockTTL, mockGetFun).
+		Return("https://example.com/icon1.png", nil).Once()
+
+	result, cursor, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(mockTrans, result, desc)
+	s.Require().Empty(cursor, desc)
+}
+
+func getAll_GetByFuncFail_ReturnError(s *TransactionSuite, desc string) {
+	mockDecodedNextKeys := domain.DecodedNextKeys{}
+	mockOpt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 3}}
+	mockUser := domain.User{ID: 1}
+	mockTrans := []domain.Transaction{{ID: 1, UserID: 1, MainCateg: domain.MainCateg{IconType: domain.IconTypeCustom, IconData: "test"}}}
+	mockKey := "user_icon-test"
+	mockTTL := 7 * 24 * time.Hour
+	mockGetFun := mock.AnythingOfType("func() (string, error)")
+	mockErr := errors.New("get by func error")
+
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).
+		Return(mockTrans, mockDecodedNextKeys, nil).Once()
+	s.mockRedis.On("GetByFunc", mockCtx, mockKey, mockTTL, mockGetFun).
+		Return("", mockErr).Once()
+
+	result, cursor, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Nil(result, desc)
+	s.Require().Empty(cursor, desc)
+}
+
+func getAll_WithAttachments_ReturnPresignedURL(s *TransactionSuite, desc string) {
+	mockDecodedNextKeys := domain.DecodedNextKeys{}
+	mockOpt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 3}}
+	mockUser := domain.User{ID: 1}
+	mockTrans := []domain.Transaction{
+		{ID: 1, UserID: 1, Attachments: []domain.TransAttachment{
+			{ID: 1, TransactionID: 1, FileName: "a.png", ObjectKey: "receipts/1/1/a.png"},
+			{ID: 2, TransactionID: 1, FileName: "b.pdf", ObjectKey: "receipts/1/1/b.pdf"},
+		}},
+		{ID: 2, UserID: 1},
+	}
+	mockTTL := 6 * 24 * time.Hour
+	mockGetFun := mock.AnythingOfType("func() (string, error)")
+
+	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).
+		Return(mockTrans, mockDecodedNextKeys, nil).Once()
+	s.mockRedis.On("GetByFunc", mockCtx, "attachment-receipts/1/1/a.png", mockTTL, mockGetFun).
+		Return("https://example.com/a.png", nil).Once()
+	s.mockRedis.On("GetByFunc", mockCtx, "attachment-receipts/1/1/b.pdf", mockTTL, mockGetFun).
+		Return("https://example.com/b.pdf", nil).Once()
+
+	result, _, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	s.Require().NoError(err, desc)
+	s.Require().Equal("https://example.com/a.png", result[0].Attachments[0].URL, desc)
+	s.Require().Equal("https://example.com/b.pdf", result[0].Attachments[1].URL, desc)
+	s.Require().Nil(result[1].Attachments, desc)
+}
+
+func (s *TransactionSuite) TestGetTotal() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, return total":       getTotal_NoError_ReturnTotal,
+		"when with view, apply the view":    getTotal_WithView_ApplyView,
+		"when get total fail, return error": getTotal_GetTotalFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getTotal_NoError_ReturnTotal(s *TransactionSuite, desc string) {
+	mockOpt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 10}}
+	mockTotal := domain.TransTotal{Count: 25, Income: 300, Expense: 120.5}
+
+	s.mockTransactionRepo.On("GetTotal", mockCtx, mockOpt, int64(1)).
+		Return(mockTotal, nil).Once()
+
+	total, err := s.uc.GetTotal(mockCtx, mockOpt, domain.User{ID: 1})
+	s.Require().NoError(err, desc)
+	s.Require().Equal(mockTotal, total, desc)
+}
+
+func getTotal_WithView_ApplyView(s *TransactionSuite, desc string) {
+	keyword := "coffee"
+	mockView := domain.View{ID: 2, Search: domain.Search{Keyword: &keyword}}
+	mockOpt := domain.GetTransOpt{ViewID: 2}
+	expOpt := domain.GetTransOpt{Search: domain.Search{Keyword: &keyword}, ViewID: 2}
+	mockTotal := domain.TransTotal{Count: 3, Expense: 15}
+
+	s.mockViewRepo.On("GetByIDAndUserID", mockCtx, int64(2), int64(1)).
+		Return(mockView, nil).Once()
+
+	s.mockTransactionRepo.On("GetTotal", mockCtx, expOpt, int64(1)).
+		Return(mockTotal, nil).Once()
+
+	total, err := s.uc.GetTotal(mockCtx, mockOpt, domain.User{ID: 1})
+	s.Require().NoError(err, desc)
+	s.Require().Equal(mockTotal, total, desc)
+}
+
+func getTotal_GetTotalFail_ReturnError(s *TransactionSuite, desc string) {
+	mockErr := errors.New("get total fail")
+
+	s.mockTransactionRepo.On("GetTotal", mockCtx, domain.GetTransOpt{}, int64(1)).
+		Return(domain.TransTotal{}, mockErr).Once()
+
+	total, err := s.uc.GetTotal(mockCtx, domain.GetTransOpt{}, domain.User{ID: 1})
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(total, desc)
+}
+
+func (s *TransactionSuite) TestExport() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no sort, sort by date ascending":        export_NoSort_SortByDateAsc,
+		"when sort is specified, keep the sort":       export_WithSort_KeepSort,
+		"when only direction, sort by date":           export_OnlyDir_SortByDate,
+		"when stream transactions fail, return error": export_StreamFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func export_NoSort_SortByDateAsc(s *TransactionSuite, desc string) {
+	mockUser := domain.User{ID: 1}
+	mockOpt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 10}}
+	expOpt := domain.GetTransOpt{
+		Sort:   &domain.Sort{By: domain.SortByTypeDate, Dir: domain.SortDirTypeAsc},
+		Cursor: domain.Cursor{Size: 10},
+	}
+
+	s.mockTransactionRepo.On("StreamAll", mockCtx, expOpt, int64(1), mock.Anything).Return(nil).Once()
+
+	err := s.uc.Export(mockCtx, mockOpt, mockUser, func(domain.Transaction) error { return nil })
+	s.Require().NoError(err, desc)
+	s.Require().Nil(mockOpt.Sort, desc)
+}
+
+func export_WithSort_KeepSort(s *TransactionSuite, desc string) {
+	mockUser := domain.User{ID: 1}
+	mockOpt := domain.GetTransOpt{Sort: &domain.Sort{By: domain.SortByTypePrice, Dir: domain.SortDirTypeDesc}}
+
+	s.mockTransactionRepo.On("StreamAll", mockCtx, mockOpt, int64(1), mock.Anything).Return(nil).Once()
+
+	err := s.uc.Export(mockCtx, mockOpt, mockUser, func(domain.Transaction) error { return nil })
+	s.Require().NoError(err, desc)
+}
+
+func export_OnlyDir_SortByDate(s *TransactionSuite, desc string) {
 	mockUser := domain.User{ID: 1}
-	mockTrans := []domain.Transaction{
-		{ID: 1, UserID: 1, MainCateg: domain.MainCateg{IconType: domain.IconTypeCustom, IconData: "test"}},
-		{ID: 2, UserID: 1, MainCateg: domain.MainCateg{IconType: domain.IconTypeDefault, IconData: "test2"}},
-	}
-	mockKey := "user_icon-test"
-	mockTTL := 7 * 24 * time.Hour
-	mockGetFun := mock.AnythingOfType("func() (string, error)")
+	mockOpt := domain.GetTransOpt{Sort: &domain.Sort{Dir: domain.SortDirTypeDesc}}
+	expOpt := domain.GetTransOpt{Sort: &domain.Sort{By: domain.SortByTypeDate, Dir: domain.SortDirTypeDesc}}
 
-	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).
-		Return(mockTrans, mockDecodedNextKeys, nil).Once()
-	s.mockRedis.On("GetByFunc", mockCtx, mockKey, mockTTL, mockGetFun).
-		Return("https://example.com/icon1.png", nil).Once()
+	s.mockTransactionRepo.On("StreamAll", mockCtx, expOpt, int64(1), mock.Anything).Return(nil).Once()
 
-	result, cursor, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	err := s.uc.Export(mockCtx, mockOpt, mockUser, func(domain.Transaction) error { return nil })
 	s.Require().NoError(err, desc)
-	s.Require().Equal(mockTrans, result, desc)
-	s.Require().Empty(cursor, desc)
+	s.Require().Equal(domain.SortByTypeUnSpecified, mockOpt.Sort.By, desc)
 }
 
-func getAll_GetByFuncFail_ReturnError(s *TransactionSuite, desc string) {
-	mockDecodedNextKeys := domain.DecodedNextKeys{}
-	mockOpt := domain.GetTransOpt{Cursor: domain.Cursor{Size: 3}}
+func export_StreamFail_ReturnError(s *TransactionSuite, desc string) {
 	mockUser := domain.User{ID: 1}
-	mockTrans := []domain.Transaction{{ID: 1, UserID: 1, MainCateg: domain.MainCateg{IconType: domain.IconTypeCustom, IconData: "test"}}}
-	mockKey := "user_icon-test"
-	mockTTL := 7 * 24 * time.Hour
-	mockGetFun := mock.AnythingOfType("func() (string, error)")
-	mockErr := errors.New("get by func error")
+	mockOpt := domain.GetTransOpt{}
+	expOpt := domain.GetTransOpt{Sort: &domain.Sort{By: domain.SortByTypeDate, Dir: domain.SortDirTypeAsc}}
+	mockErr := errors.New("stream fail")
 
-	s.mockTransactionRepo.On("GetAll", mockCtx, mockOpt, int64(1)).
-		Return(mockTrans, mockDecodedNextKeys, nil).Once()
-	s.mockRedis.On("GetByFunc", mockCtx, mockKey, mockTTL, mockGetFun).
-		Return("", mockErr).Once()
+	s.mockTransactionRepo.On("StreamAll", mockCtx, expOpt, int64(1), mock.Anything).Return(mockErr).Once()
 
-	result, cursor, err := s.uc.GetAll(mockCtx, mockOpt, mockUser)
+	err := s.uc.Export(mockCtx, mockOpt, mockUser, func(domain.Transaction) error { return nil })
 	s.Require().ErrorIs(err, mockErr, desc)
-	s.Require().Nil(result, desc)
-	s.Require().Empty(cursor, desc)
 }
 
 func (s *TransactionSuite) TestUpdate() {
@@ -452,9 +1041,9 @@ func update_NoError_UpdateSuccessfully(s *TransactionSuite, desc string) {
 		Return(&subCateg, nil).Once()
 
 	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, trans.ID, user.ID).
-		Return(domain.Transaction{}, nil).Once()
+		Return(domain.Transaction{ID: 1}, nil).Once()
 
-	s.mockTransactionRepo.On("Update", mockCtx, trans).
+	s.mockTransactionRepo.On("Update", mockCtx, trans, domain.RevisionActionTypeUpdate, user.ID).
 		Return(nil).Once()
 
 	err := s.uc.Update(mockCtx, trans, user)
@@ -600,7 +1189,7 @@ func update_UpdateFail_UpdateSuccessfully(s *TransactionSuite, desc string) {
 	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, trans.ID, user.ID).
 		Return(domain.Transaction{}, nil).Once()
 
-	s.mockTransactionRepo.On("Update", mockCtx, trans).
+	s.mockTransactionRepo.On("Update", mockCtx, trans, domain.RevisionActionTypeUpdate, user.ID).
 		Return(mockErr).Once()
 
 	err := s.uc.Update(mockCtx, trans, user)
@@ -609,8 +1198,9 @@ func update_UpdateFail_UpdateSuccessfully(s *TransactionSuite, desc string) {
 
 func (s *TransactionSuite) TestDelete() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
-		"when no error, delete successfully":       delete_NoError_DeleteSuccessfully,
-		"when check permession fail, return error": delete_CheckPermessionFail_ReturnError,
+		"when no error, delete successfully":           delete_NoError_DeleteSuccessfully,
+		"when check permession fail, return error":     delete_CheckPermessionFail_ReturnError,
+		"when deleted by ledger member, record member": delete_ByLedgerMember_RecordMember,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -627,15 +1217,33 @@ func delete_NoError_DeleteSuccessfully(s *TransactionSuite, desc string) {
 
 	s.mockTransactionRepo.
 		On("GetByIDAndUserID", mockCtx, int64(1), user.ID).
-		Return(domain.Transaction{}, nil).Once()
+		Return(domain.Transaction{ID: 1}, nil).Once()
 
-	s.mockTransactionRepo.On("Delete", mockCtx, int64(1)).
+	s.mockTransactionRepo.On("Delete", mockCtx, int64(1), user.ID).
 		Return(nil).Once()
 
 	err := s.uc.Delete(mockCtx, int64(1), user)
 	s.Require().NoError(err, desc)
 }
 
+func delete_ByLedgerMember_RecordMember(s *TransactionSuite, desc string) {
+	// the member 3 deletes the transaction of the ledger owned by the user 1
+	req := ctxutil.SetUser(httptest.NewRequest(http.MethodDelete, "/", nil), &domain.User{ID: 3})
+	req = ctxutil.SetLedger(req, &domain.Ledger{ID: 2, UserID: 1, Role: domain.LedgerRoleTypeEditor})
+	ctx := req.Context()
+	user := ctxutil.GetLedgerUser(req)
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", ctx, int64(1), int64(1)).
+		Return(domain.Transaction{ID: 1}, nil).Once()
+
+	// the revision is recorded with the member, not the owner of the ledger
+	s.mockTransactionRepo.On("Delete", ctx, int64(1), int64(3)).
+		Return(nil).Once()
+
+	err := s.uc.Delete(ctx, int64(1), *user)
+	s.Require().NoError(err, desc)
+}
+
 func delete_CheckPermessionFail_ReturnError(s *TransactionSuite, desc string) {
 	user := domain.User{
 		ID: 1,
@@ -651,6 +1259,372 @@ func delete_CheckPermessionFail_ReturnError(s *TransactionSuite, desc string) {
 	s.Require().ErrorIs(err, mockErr, desc)
 }
 
+func (s *TransactionSuite) TestGetHistory() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, return history":            getHistory_NoError_ReturnHistory,
+		"when check permession fail, return error": getHistory_CheckPermessionFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getHistory_NoError_ReturnHistory(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	history := []domain.TransRevision{
+		{ID: 1, TransactionID: 1, UserID: 1, Action: domain.RevisionActionTypeCreate, After: &domain.TransSnapshot{Price: 90}},
+		{ID: 2, TransactionID: 1, UserID: 1, Action: domain.RevisionActionTypeUpdate, Before: &domain.TransSnapshot{Price: 90}, After: &domain.TransSnapshot{Price: 100}},
+	}
+
+	s.mockTransactionRepo.On("GetByIDAndUserIDIncludeTrash", mockCtx, int64(1), user.ID).
+		Return(domain.Transaction{ID: 1}, nil).Once()
+
+	s.mockRevisionRepo.On("GetByTransID", mockCtx, int64(1)).
+		Return(history, nil).Once()
+
+	result, err := s.uc.GetHistory(mockCtx, int64(1), user)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(history, result, desc)
+}
+
+func getHistory_CheckPermessionFail_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserIDIncludeTrash", mockCtx, int64(1), user.ID).
+		Return(domain.Transaction{}, domain.ErrTransactionDataNotFound).Once()
+
+	result, err := s.uc.GetHistory(mockCtx, int64(1), user)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+	s.Require().Nil(result, desc)
+}
+
+func (s *TransactionSuite) TestRevert() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, revert successfully":         revert_NoError_RevertSuccessfully,
+		"when revision is delete, return error":      revert_DeleteRevision_ReturnError,
+		"when get revision fail, return error":       revert_GetRevisionFail_ReturnError,
+		"when check permession fail, return error":   revert_CheckPermessionFail_ReturnError,
+		"when category of revision gone, return err": revert_CategGone_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func revert_NoError_RevertSuccessfully(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	current := domain.Transaction{ID: 1, Type: domain.TransactionTypeExpense, MainCateg: domain.MainCateg{ID: 1}, SubCateg: domain.SubCateg{ID: 1}, Price: 100, Date: mockTimeNow}
+	rev := domain.TransRevision{
+		ID:            3,
+		TransactionID: 1,
+		Action:        domain.RevisionActionTypeCreate,
+		After: &domain.TransSnapshot{
+			Type:        domain.TransactionTypeExpense,
+			MainCategID: 1,
+			SubCategID:  1,
+			Price:       90,
+			Date:        mockTimeNow,
+			Note:        "note",
+		},
+	}
+	expInput := domain.UpdateTransactionInput{
+		ID:          1,
+		Type:        domain.TransactionTypeExpense,
+		MainCategID: 1,
+		SubCategID:  1,
+		Price:       90,
+		Date:        mockTimeNow,
+		Note:        "note",
+	}
+
+	// check permission of revert, then of update, the transaction in trash is also allowed
+	s.mockTransactionRepo.On("GetByIDAndUserIDIncludeTrash", mockCtx, int64(1), user.ID).
+		Return(current, nil).Twice()
+
+	s.mockRevisionRepo.On("GetByIDAndTransID", mockCtx, int64(3), int64(1)).
+		Return(rev, nil).Once()
+
+	s.mockMainCategRepo.On("GetByID", int64(1), user.ID).
+		Return(&domain.MainCateg{ID: 1, Type: domain.TransactionTypeExpense}, nil).Once()
+
+	s.mockSubCategRepo.On("GetByID", int64(1), user.ID).
+		Return(&domain.SubCateg{ID: 1, MainCategID: 1}, nil).Once()
+
+	s.mockTransactionRepo.On("Update", mockCtx, expInput, domain.RevisionActionTypeRevert, user.ID).
+		Return(nil).Once()
+
+	err := s.uc.Revert(mockCtx, int64(1), int64(3), user)
+	s.Require().NoError(err, desc)
+}
+
+func revert_DeleteRevision_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	rev := domain.TransRevision{
+		ID:            3,
+		TransactionID: 1,
+		Action:        domain.RevisionActionTypeDelete,
+		Before:        &domain.TransSnapshot{Price: 90},
+	}
+
+	s.mockTransactionRepo.On("GetByIDAndUserIDIncludeTrash", mockCtx, int64(1), user.ID).
+		Return(domain.Transaction{ID: 1}, nil).Once()
+
+	s.mockRevisionRepo.On("GetByIDAndTransID", mockCtx, int64(3), int64(1)).
+		Return(rev, nil).Once()
+
+	err := s.uc.Revert(mockCtx, int64(1), int64(3), user)
+	s.Require().ErrorIs(err, domain.ErrTransRevisionNotRevertible, desc)
+}
+
+func revert_GetRevisionFail_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserIDIncludeTrash", mockCtx, int64(1), user.ID).
+		Return(domain.Transaction{ID: 1}, nil).Once()
+
+	s.mockRevisionRepo.On("GetByIDAndTransID", mockCtx, int64(3), int64(1)).
+		Return(domain.TransRevision{}, domain.ErrTransRevisionNotFound).Once()
+
+	err := s.uc.Revert(mockCtx, int64(1), int64(3), user)
+	s.Require().ErrorIs(err, domain.ErrTransRevisionNotFound, desc)
+}
+
+func revert_CheckPermessionFail_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserIDIncludeTrash", mockCtx, int64(1), user.ID).
+		Return(domain.Transaction{}, domain.ErrTransactionDataNotFound).Once()
+
+	err := s.uc.Revert(mockCtx, int64(1), int64(3), user)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+}
+
+func revert_CategGone_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	rev := domain.TransRevision{
+		ID:            3,
+		TransactionID: 1,
+		Action:        domain.RevisionActionTypeUpdate,
+		After: &domain.TransSnapshot{
+			Type:        domain.TransactionTypeExpense,
+			MainCategID: 2,
+			SubCategID:  2,
+			Price:       90,
+			Date:        mockTimeNow,
+		},
+	}
+
+	s.mockTransactionRepo.On("GetByIDAndUserIDIncludeTrash", mockCtx, int64(1), user.ID).
+		Return(domain.Transaction{ID: 1}, nil).Once()
+
+	s.mockRevisionRepo.On("GetByIDAndTransID", mockCtx, int64(3), int64(1)).
+		Return(rev, nil).Once()
+
+	s.mockMainCategRepo.On("GetByID", int64(2), user.ID).
+		Return(nil, domain.ErrMainCategNotFound).Once()
+
+	err := s.uc.Revert(mockCtx, int64(1), int64(3), user)
+	s.Require().ErrorIs(err, domain.ErrMainCategNotFound, desc)
+}
+
+func (s *TransactionSuite) TestGetAttachmentPutURL() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, return url":                getAttachmentPutURL_NoError_ReturnURL,
+		"when same file name, return other key":    getAttachmentPutURL_SameFileName_ReturnOtherKey,
+		"when check permession fail, return error": getAttachmentPutURL_CheckPermessionFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getAttachmentPutURL_NoError_ReturnURL(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{ID: 2}, nil).Once()
+
+	isObjectKey := mock.MatchedBy(func(objectKey string) bool {
+		return domain.IsAttachmentObjectKeyOf(objectKey, user.ID, int64(2), "receipt.png")
+	})
+	s.mockS3.On("PutObjectUrl", mockCtx, isObjectKey, int64(60)).
+		Return("https://example.com/put", nil).Once()
+
+	putURL, err := s.uc.GetAttachmentPutURL(mockCtx, int64(2), "receipt.png", user)
+	s.Require().NoError(err, desc)
+	s.Require().Equal("https://example.com/put", putURL.URL, desc)
+	s.Require().True(domain.IsAttachmentObjectKeyOf(putURL.ObjectKey, user.ID, int64(2), "receipt.png"), desc)
+}
+
+func getAttachmentPutURL_SameFileName_ReturnOtherKey(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{ID: 2}, nil).Twice()
+
+	s.mockS3.On("PutObjectUrl", mockCtx, mock.AnythingOfType("string"), int64(60)).
+		Return("https://example.com/put", nil).Twice()
+
+	// the file uploaded first is never overwritten
+	first, err := s.uc.GetAttachmentPutURL(mockCtx, int64(2), "receipt.png", user)
+	s.Require().NoError(err, desc)
+	second, err := s.uc.GetAttachmentPutURL(mockCtx, int64(2), "receipt.png", user)
+	s.Require().NoError(err, desc)
+	s.Require().NotEqual(first.ObjectKey, second.ObjectKey, desc)
+}
+
+func getAttachmentPutURL_CheckPermessionFail_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{}, domain.ErrTransactionDataNotFound).Once()
+
+	putURL, err := s.uc.GetAttachmentPutURL(mockCtx, int64(2), "receipt.png", user)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+	s.Require().Empty(putURL, desc)
+}
+
+func (s *TransactionSuite) TestCreateAttachment() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, create successfully":       createAttachment_NoError_CreateSuccessfully,
+		"when object key not issued, return error": createAttachment_ObjectKeyNotIssued_ReturnError,
+		"when check permession fail, return error": createAttachment_CheckPermessionFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func createAttachment_NoError_CreateSuccessfully(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	expAttachment := domain.TransAttachment{
+		TransactionID: 2,
+		UserID:        1,
+		FileName:      "receipt.png",
+		ObjectKey:     "receipts/1/2/0a1b2c/receipt.png",
+	}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{ID: 2}, nil).Once()
+
+	s.mockAttachmentRepo.On("Create", mockCtx, expAttachment).
+		Return(nil).Once()
+
+	err := s.uc.CreateAttachment(mockCtx, int64(2), "receipt.png", "receipts/1/2/0a1b2c/receipt.png", user)
+	s.Require().NoError(err, desc)
+}
+
+func createAttachment_ObjectKeyNotIssued_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{ID: 2}, nil).Times(3)
+
+	// the key of other transaction, of other file, or without the random part
+	for _, objectKey := range []string{"receipts/1/3/0a1b2c/receipt.png", "receipts/1/2/0a1b2c/other.png", "receipts/1/2/receipt.png"} {
+		err := s.uc.CreateAttachment(mockCtx, int64(2), "receipt.png", objectKey, user)
+		s.Require().ErrorIs(err, domain.ErrAttachmentObjectKeyInvalid, desc)
+	}
+}
+
+func createAttachment_CheckPermessionFail_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{}, domain.ErrTransactionDataNotFound).Once()
+
+	err := s.uc.CreateAttachment(mockCtx, int64(2), "receipt.png", "receipts/1/2/0a1b2c/receipt.png", user)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+}
+
+func (s *TransactionSuite) TestDeleteAttachment() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, delete with file":          deleteAttachment_NoError_DeleteWithFile,
+		"when attachment not found, return error":  deleteAttachment_NotFound_ReturnError,
+		"when delete fail, keep file":              deleteAttachment_DeleteFail_KeepFile,
+		"when check permession fail, return error": deleteAttachment_CheckPermessionFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func deleteAttachment_NoError_DeleteWithFile(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	attachment := domain.TransAttachment{ID: 3, TransactionID: 2, UserID: 1, FileName: "receipt.png", ObjectKey: "receipts/1/2/receipt.png"}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{ID: 2}, nil).Once()
+
+	s.mockAttachmentRepo.On("GetByIDAndTransID", mockCtx, int64(3), int64(2)).
+		Return(attachment, nil).Once()
+
+	s.mockAttachmentRepo.On("Delete", mockCtx, int64(3)).
+		Return(nil).Once()
+
+	s.mockS3.On("DeleteObject", mockCtx, "receipts/1/2/receipt.png").
+		Return(nil).Once()
+
+	err := s.uc.DeleteAttachment(mockCtx, int64(2), int64(3), user)
+	s.Require().NoError(err, desc)
+}
+
+func deleteAttachment_NotFound_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{ID: 2}, nil).Once()
+
+	s.mockAttachmentRepo.On("GetByIDAndTransID", mockCtx, int64(3), int64(2)).
+		Return(domain.TransAttachment{}, domain.ErrAttachmentNotFound).Once()
+
+	err := s.uc.DeleteAttachment(mockCtx, int64(2), int64(3), user)
+	s.Require().ErrorIs(err, domain.ErrAttachmentNotFound, desc)
+}
+
+func deleteAttachment_DeleteFail_KeepFile(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+	attachment := domain.TransAttachment{ID: 3, TransactionID: 2, UserID: 1, FileName: "receipt.png", ObjectKey: "receipts/1/2/receipt.png"}
+	mockErr := errors.New("error")
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{ID: 2}, nil).Once()
+
+	s.mockAttachmentRepo.On("GetByIDAndTransID", mockCtx, int64(3), int64(2)).
+		Return(attachment, nil).Once()
+
+	s.mockAttachmentRepo.On("Delete", mockCtx, int64(3)).
+		Return(mockErr).Once()
+
+	err := s.uc.DeleteAttachment(mockCtx, int64(2), int64(3), user)
+	s.Require().ErrorIs(err, mockErr, desc)
+}
+
+func deleteAttachment_CheckPermessionFail_ReturnError(s *TransactionSuite, desc string) {
+	user := domain.User{ID: 1}
+
+	s.mockTransactionRepo.On("GetByIDAndUserID", mockCtx, int64(2), user.ID).
+		Return(domain.Transaction{}, domain.ErrTransactionDataNotFound).Once()
+
+	err := s.uc.DeleteAttachment(mockCtx, int64(2), int64(3), user)
+	s.Require().ErrorIs(err, domain.ErrTransactionDataNotFound, desc)
+}
+
 func (s *TransactionSuite) TestGetAccInfo() {
 	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
 		"when no error, return acc info":                    getAccInfo_NoError_ReturnAccInfo,
@@ -826,8 +1800,10 @@ func getBarChartData_WithOneWeekDay_ReturnWeekDayData(s *TransactionSuite, desc
 
 	mainCategIDs := []int64{1}
 
-	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, int64(1)).
+	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -835,7 +1811,7 @@ func getBarChartData_WithOneWeekDay_ReturnWeekDayData(s *TransactionSuite, desc
 		Datasets: []float64{100, 200, 0, 0, 500, 600, 0},
 	}
 
-	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeekDay, domain.TransactionTypeExpense, mainCategIDs, domain.User{ID: 1})
+	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeekDay, domain.TransactionTypeExpense, mainCategIDs, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -860,8 +1836,10 @@ func getBarChartData_WithOneWeek_ReturnDateData(s *TransactionSuite, desc string
 
 	mainCategIDs := []int64{1}
 
-	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, int64(1)).
+	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -869,7 +1847,7 @@ func getBarChartData_WithOneWeek_ReturnDateData(s *TransactionSuite, desc string
 		Datasets: []float64{100, 200, 0, 0, 500, 600, 0},
 	}
 
-	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeek, domain.TransactionTypeExpense, mainCategIDs, domain.User{ID: 1})
+	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeek, domain.TransactionTypeExpense, mainCategIDs, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -897,8 +1875,10 @@ func getBarChartData_WithTwoWeeks_ReturnDateData(s *TransactionSuite, desc strin
 
 	mainCategIDs := []int64{1}
 
-	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, int64(1)).
+	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -906,7 +1886,7 @@ func getBarChartData_WithTwoWeeks_ReturnDateData(s *TransactionSuite, desc strin
 		Datasets: []float64{100, 200, 0, 0, 500, 600, 700, 0, 0, 0, 0, 800, 900, 0},
 	}
 
-	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeTwoWeeks, domain.TransactionTypeExpense, mainCategIDs, domain.User{ID: 1})
+	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeTwoWeeks, domain.TransactionTypeExpense, mainCategIDs, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -934,8 +1914,10 @@ func getBarChartData_WithOneMonth_ReturnDateData(s *TransactionSuite, desc strin
 
 	mainCategIDs := []int64{1}
 
-	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, int64(1)).
+	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -943,7 +1925,7 @@ func getBarChartData_WithOneMonth_ReturnDateData(s *TransactionSuite, desc strin
 		Datasets: []float64{100, 200, 0, 0, 500, 600, 0, 0, 0, 0, 0, 0, 0, 700, 0, 0, 0, 0, 800, 900, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
 	}
 
-	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneMonth, domain.TransactionTypeExpense, mainCategIDs, domain.User{ID: 1})
+	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneMonth, domain.TransactionTypeExpense, mainCategIDs, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -985,8 +1967,10 @@ func getBarChartData_WithThreeMonths_ReturnDateData(s *TransactionSuite, desc st
 
 	mainCategIDs := []int64{1}
 
-	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, int64(1)).
+	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -994,7 +1978,7 @@ func getBarChartData_WithThreeMonths_ReturnDateData(s *TransactionSuite, desc st
 		Datasets: []float64{100, 200, 1100, 0, 0, 700, 800, 900, 0, 0, 0, 2100, 2500, 0, 0, 1400, 0, 3100, 0, 0, 0, 3500, 3900, 0, 0, 2100, 0, 4500, 0, 0, 0},
 	}
 
-	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeThreeMonths, domain.TransactionTypeExpense, mainCategIDs, domain.User{ID: 1})
+	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeThreeMonths, domain.TransactionTypeExpense, mainCategIDs, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1020,8 +2004,10 @@ func getBarChartData_WithSixMonths_ReturnDateData(s *TransactionSuite, desc stri
 
 	mainCategIDs := []int64{1}
 
-	s.mockTransactionRepo.On("GetMonthlyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, int64(1)).
+	s.mockTransactionRepo.On("GetMonthlyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1029,7 +2015,7 @@ func getBarChartData_WithSixMonths_ReturnDateData(s *TransactionSuite, desc stri
 		Datasets: []float64{100, 500, 0, 700, 900, 1100},
 	}
 
-	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeSixMonths, domain.TransactionTypeExpense, mainCategIDs, domain.User{ID: 1})
+	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeSixMonths, domain.TransactionTypeExpense, mainCategIDs, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1061,8 +2047,10 @@ func getBarChartData_WithOneYear_ReturnDateData(s *TransactionSuite, desc string
 
 	mainCategIDs := []int64{1}
 
-	s.mockTransactionRepo.On("GetMonthlyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, int64(1)).
+	s.mockTransactionRepo.On("GetMonthlyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1070,7 +2058,7 @@ func getBarChartData_WithOneYear_ReturnDateData(s *TransactionSuite, desc string
 		Datasets: []float64{100, 500, 0, 700, 900, 1100, 1300, 1500, 1700, 1900, 2100, 2300},
 	}
 
-	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneYear, domain.TransactionTypeExpense, mainCategIDs, domain.User{ID: 1})
+	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneYear, domain.TransactionTypeExpense, mainCategIDs, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1089,13 +2077,13 @@ func getBarChartData_GetChartDataFail_ReturnError(s *TransactionSuite, desc stri
 	mainCategIDs := []int64{1}
 	mockErr := errors.New("error")
 
-	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, int64(1)).
+	s.mockTransactionRepo.On("GetDailyBarChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, mainCategIDs, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(domain.DateToChartData{}, mockErr).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{}
 
-	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeekDay, domain.TransactionTypeExpense, mainCategIDs, domain.User{ID: 1})
+	result, err := s.uc.GetBarChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeekDay, domain.TransactionTypeExpense, mainCategIDs, 0, domain.User{ID: 1})
 	s.Require().ErrorIs(err, mockErr, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1129,10 +2117,12 @@ func getPieChartData_NoError_ReturnChartData(s *TransactionSuite, desc string) {
 		Datasets: []float64{100, 200},
 	}
 
-	s.mockTransactionRepo.On("GetPieChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, int64(1)).
+	s.mockTransactionRepo.On("GetPieChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(chartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
-	result, err := s.uc.GetPieChartData(mockCtx, chartDataRange, domain.TransactionTypeExpense, domain.User{ID: 1})
+	result, err := s.uc.GetPieChartData(mockCtx, chartDataRange, domain.TransactionTypeExpense, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(chartData, result, desc)
 }
@@ -1149,10 +2139,63 @@ func getPieChartData_GetChartDataFail_ReturnError(s *TransactionSuite, desc stri
 	}
 	mockErr := errors.New("error")
 
-	s.mockTransactionRepo.On("GetPieChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, int64(1)).
+	s.mockTransactionRepo.On("GetPieChartData", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(domain.ChartData{}, mockErr).Once()
 
-	result, err := s.uc.GetPieChartData(mockCtx, chartDataRange, domain.TransactionTypeExpense, domain.User{ID: 1})
+	result, err := s.uc.GetPieChartData(mockCtx, chartDataRange, domain.TransactionTypeExpense, 0, domain.User{ID: 1})
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(result, desc)
+}
+
+func (s *TransactionSuite) TestGetTagChartData() {
+	for scenario, fn := range map[string]func(s *TransactionSuite, desc string){
+		"when no error, return chart data":       getTagChartData_NoError_ReturnChartData,
+		"when get sum by tag fail, return error": getTagChartData_GetSumByTagFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func getTagChartData_NoError_ReturnChartData(s *TransactionSuite, desc string) {
+	chartDataRange := domain.ChartDateRange{
+		Start: mockTimeNow,
+		End:   mockTimeNow.AddDate(0, 0, 6),
+	}
+
+	sums := []domain.TagSum{
+		{Tag: domain.Tag{ID: 1, Name: "trip-japan"}, Sum: 100},
+		{Tag: domain.Tag{ID: 2, Name: "reimbursable"}, Sum: 200},
+	}
+	s.mockTransactionRepo.On("GetSumByTag", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(sums, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
+
+	expResult := domain.ChartData{
+		Labels:   []string{"trip-japan", "reimbursable"},
+		Datasets: []float64{100, 200},
+	}
+
+	result, err := s.uc.GetTagChartData(mockCtx, chartDataRange, domain.TransactionTypeExpense, 0, domain.User{ID: 1})
+	s.Require().NoError(err, desc)
+	s.Require().Equal(expResult, result, desc)
+}
+
+func getTagChartData_GetSumByTagFail_ReturnError(s *TransactionSuite, desc string) {
+	chartDataRange := domain.ChartDateRange{
+		Start: mockTimeNow,
+		End:   mockTimeNow.AddDate(0, 0, 6),
+	}
+	mockErr := errors.New("error")
+
+	s.mockTransactionRepo.On("GetSumByTag", mockCtx, chartDataRange, domain.TransactionTypeExpense, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(nil, mockErr).Once()
+
+	result, err := s.uc.GetTagChartData(mockCtx, chartDataRange, domain.TransactionTypeExpense, 0, domain.User{ID: 1})
 	s.Require().ErrorIs(err, mockErr, desc)
 	s.Require().Empty(result, desc)
 }
@@ -1194,8 +2237,10 @@ func getLineChartData_WithOneWeekDay_ReturnWeekDayData(s *TransactionSuite, desc
 		"2024-03-22": 600,
 	}
 
-	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, int64(1)).
+	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeUnSpecified, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1203,7 +2248,7 @@ func getLineChartData_WithOneWeekDay_ReturnWeekDayData(s *TransactionSuite, desc
 		Datasets: []float64{100, 200, 200, 200, -500, 600, 600},
 	}
 
-	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeekDay, domain.User{ID: 1})
+	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeekDay, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1226,8 +2271,10 @@ func getLineChartData_WithOneWeek_ReturnData(s *TransactionSuite, desc string) {
 		"2024-03-22": 600,
 	}
 
-	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, int64(1)).
+	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeUnSpecified, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1235,7 +2282,7 @@ func getLineChartData_WithOneWeek_ReturnData(s *TransactionSuite, desc string) {
 		Datasets: []float64{100, -300, -300, -300, -500, 600, 600},
 	}
 
-	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeek, domain.User{ID: 1})
+	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeek, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1261,8 +2308,10 @@ func getLineChartData_WithTwoWeeks_ReturnData(s *TransactionSuite, desc string)
 		"2024-03-29": 900,
 	}
 
-	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, int64(1)).
+	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeUnSpecified, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1270,7 +2319,7 @@ func getLineChartData_WithTwoWeeks_ReturnData(s *TransactionSuite, desc string)
 		Datasets: []float64{-100, 200, 200, 200, 500, -600, 700, 700, 700, 700, 700, -800, 900, 900},
 	}
 
-	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeek, domain.User{ID: 1})
+	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeek, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1299,8 +2348,10 @@ func getLineChartData_WithOneMonth_ReturnData(s *TransactionSuite, desc string)
 		"2024-04-02": 100,
 	}
 
-	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, int64(1)).
+	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeUnSpecified, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1308,7 +2359,7 @@ func getLineChartData_WithOneMonth_ReturnData(s *TransactionSuite, desc string)
 		Datasets: []float64{500, -600, -600, -600, -600, -100, -100, -100, -100, 700, 700, -500, -500, -500, -800, 900, 900, 900, 900, 900, 1000, 1000, 1000, 1000, 1000, 1000, -1400, -1400, 100, 100},
 	}
 
-	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneMonth, domain.User{ID: 1})
+	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneMonth, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1348,8 +2399,10 @@ func getLineChartData_WithThreeMonths_ReturnData(s *TransactionSuite, desc strin
 		"2024-05-20": -2300,
 	}
 
-	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, int64(1)).
+	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeUnSpecified, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1357,7 +2410,7 @@ func getLineChartData_WithThreeMonths_ReturnData(s *TransactionSuite, desc strin
 		Datasets: []float64{100, 200, -600, -600, -600, -700, 800, 900, 900, 900, 900, -1100, 1300, 1300, 1300, -1400, -1400, 1600, 1600, 1600, 1600, -1800, 2000, 2000, 2000, -2100, -2100, -2300, -2300, -2300, -2300},
 	}
 
-	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeThreeMonths, domain.User{ID: 1})
+	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeThreeMonths, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1381,8 +2434,10 @@ func getLineChartData_WithSixMonths_ReturnMonthData(s *TransactionSuite, desc st
 		"2024-08": -1100,
 	}
 
-	s.mockTransactionRepo.On("GetMonthlyLineChartData", mockCtx, chartDataRange, int64(1)).
+	s.mockTransactionRepo.On("GetMonthlyLineChartData", mockCtx, chartDataRange, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeUnSpecified, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1390,7 +2445,7 @@ func getLineChartData_WithSixMonths_ReturnMonthData(s *TransactionSuite, desc st
 		Datasets: []float64{-100, -500, -500, -700, -900, -1100},
 	}
 
-	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeSixMonths, domain.User{ID: 1})
+	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeSixMonths, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1420,8 +2475,10 @@ func getLineChartData_WithOneYear_ReturnMonthData(s *TransactionSuite, desc stri
 		"2025-02": 2300,
 	}
 
-	s.mockTransactionRepo.On("GetMonthlyLineChartData", mockCtx, chartDataRange, int64(1)).
+	s.mockTransactionRepo.On("GetMonthlyLineChartData", mockCtx, chartDataRange, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(DateToChartData, nil).Once()
+	s.mockTransactionRepo.On("CountUnconverted", mockCtx, chartDataRange, domain.TransactionTypeUnSpecified, (*domain.GetTransOpt)(nil), int64(1)).
+		Return(int64(0), nil).Once()
 
 	// prepare expected result
 	expResult := domain.ChartData{
@@ -1429,7 +2486,7 @@ func getLineChartData_WithOneYear_ReturnMonthData(s *TransactionSuite, desc stri
 		Datasets: []float64{100, 500, 500, -700, 900, -1100, 1300, -1500, -1700, -1900, 2100, 2300},
 	}
 
-	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneYear, domain.User{ID: 1})
+	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneYear, 0, domain.User{ID: 1})
 	s.Require().NoError(err, desc)
 	s.Require().Equal(expResult, result, desc)
 }
@@ -1446,10 +2503,10 @@ func getLineChartData_GetChartDataFail_ReturnError(s *TransactionSuite, desc str
 	}
 	mockErr := errors.New("error")
 
-	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, int64(1)).
+	s.mockTransactionRepo.On("GetDailyLineChartData", mockCtx, chartDataRange, (*domain.GetTransOpt)(nil), int64(1)).
 		Return(domain.DateToChartData{}, mockErr).Once()
 
-	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeekDay, domain.User{ID: 1})
+	result, err := s.uc.GetLineChartData(mockCtx, chartDataRange, domain.TimeRangeTypeOneWeekDay, 0, domain.User{ID: 1})
 	s.Require().ErrorIs(err, mockErr, desc)
 	s.Require().Empty(result, desc)
 }
@@ -0,0 +1,67 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+)
+
+const (
+	packageName = "usecase/trash"
+)
+
+type UC struct {
+	Trash      interfaces.TrashRepo
+	Attachment interfaces.AttachmentRepo
+	S3         interfaces.S3Service
+}
+
+func New(t interfaces.TrashRepo, at interfaces.AttachmentRepo, s3 interfaces.S3Service) *UC {
+	return &UC{
+		Trash:      t,
+		Attachment: at,
+		S3:         s3,
+	}
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.TrashItem, error) {
+	return u.Trash.GetAll(ctx, userID)
+}
+
+func (u *UC) Restore(ctx context.Context, kind domain.TrashKind, id, userID int64) error {
+	if !ctxutil.GetLedgerRole(ctx).CanManage() {
+		return domain.ErrLedgerPermissionDenied
+	}
+
+	// the item is looked up by user id, so other user's item is not found
+	return u.Trash.Restore(ctx, kind, id, userID)
+}
+
+// Purge permanently deletes the items which have been in trash longer than the retention period,
+// and the uploaded files of the attachments of the purged transactions.
+// The files are kept while the transaction is in trash, so that it can be restored with its attachments.
+func (u *UC) Purge(ctx context.Context, now time.Time) error {
+	before := now.Add(-domain.TrashRetention)
+
+	attachments, err := u.Attachment.GetByPurgeableTrans(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	if err := u.Trash.Purge(ctx, before); err != nil {
+		return err
+	}
+
+	// the metadata is already gone, so a file failed to be deleted is only left in the bucket
+	for _, a := range attachments {
+		if err := u.S3.DeleteObject(ctx, a.ObjectKey); err != nil {
+			logger.Error("u.S3.DeleteObject failed", "package", packageName, "err", err, "object_key", a.ObjectKey)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/ctxutil"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type TrashSuite struct {
+	suite.Suite
+	uc                 *UC
+	mockTrashRepo      *mocks.TrashRepo
+	mockAttachmentRepo *mocks.AttachmentRepo
+	mockS3             *mocks.S3Service
+}
+
+func TestTrashSuite(t *testing.T) {
+	suite.Run(t, new(TrashSuite))
+}
+
+func (s *TrashSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *TrashSuite) SetupTest() {
+	s.mockTrashRepo = mocks.NewTrashRepo(s.T())
+	s.mockAttachmentRepo = mocks.NewAttachmentRepo(s.T())
+	s.mockS3 = mocks.NewS3Service(s.T())
+	s.uc = New(s.mockTrashRepo, s.mockAttachmentRepo, s.mockS3)
+}
+
+func (s *TrashSuite) TearDownTest() {
+	s.mockTrashRepo.AssertExpectations(s.T())
+	s.mockAttachmentRepo.AssertExpectations(s.T())
+	s.mockS3.AssertExpectations(s.T())
+}
+
+func (s *TrashSuite) TestPurge() {
+	for scenario, fn := range map[string]func(s *TrashSuite, desc string){
+		"when no error, purge with attachments":                purge_NoError_PurgeWithAttachments,
+		"when purge fail, keep files of attachments":           purge_PurgeFail_KeepFiles,
+		"when delete file fail, purge the rest of attachments": purge_DeleteFileFail_PurgeRest,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func purge_NoError_PurgeWithAttachments(s *TrashSuite, desc string) {
+	now := time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	attachments := []domain.TransAttachment{{ID: 1, ObjectKey: "receipts/1/1/a.png"}, {ID: 2, ObjectKey: "receipts/1/2/b.pdf"}}
+
+	s.mockAttachmentRepo.On("GetByPurgeableTrans", mockCtx, before).Return(attachments, nil).Once()
+	s.mockTrashRepo.On("Purge", mockCtx, before).Return(nil).Once()
+	s.mockS3.On("DeleteObject", mockCtx, "receipts/1/1/a.png").Return(nil).Once()
+	s.mockS3.On("DeleteObject", mockCtx, "receipts/1/2/b.pdf").Return(nil).Once()
+
+	err := s.uc.Purge(mockCtx, now)
+	s.Require().NoError(err, desc)
+}
+
+func purge_PurgeFail_KeepFiles(s *TrashSuite, desc string) {
+	now := time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockErr := errors.New("error")
+
+	s.mockAttachmentRepo.On("GetByPurgeableTrans", mockCtx, before).Return([]domain.TransAttachment{{ID: 1, ObjectKey: "receipts/1/1/a.png"}}, nil).Once()
+	s.mockTrashRepo.On("Purge", mockCtx, before).Return(mockErr).Once()
+
+	err := s.uc.Purge(mockCtx, now)
+	s.Require().ErrorIs(err, mockErr, desc)
+}
+
+func purge_DeleteFileFail_PurgeRest(s *TrashSuite, desc string) {
+	now := time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	attachments := []domain.TransAttachment{{ID: 1, ObjectKey: "receipts/1/1/a.png"}, {ID: 2, ObjectKey: "receipts/1/2/b.pdf"}}
+
+	s.mockAttachmentRepo.On("GetByPurgeableTrans", mockCtx, before).Return(attachments, nil).Once()
+	s.mockTrashRepo.On("Purge", mockCtx, before).Return(nil).Once()
+	s.mockS3.On("DeleteObject", mockCtx, "receipts/1/1/a.png").Return(errors.New("error")).Once()
+	s.mockS3.On("DeleteObject", mockCtx, "receipts/1/2/b.pdf").Return(nil).Once()
+
+	err := s.uc.Purge(mockCtx, now)
+	s.Require().NoError(err, desc)
+}
+
+func (s *TrashSuite) TestRestore() {
+	s.mockTrashRepo.On("Restore", mockCtx, domain.TrashKindSubCateg, int64(2), int64(1)).Return(domain.ErrTrashParentInTrash).Once()
+
+	err := s.uc.Restore(mockCtx, domain.TrashKindSubCateg, 2, 1)
+	s.Require().ErrorIs(err, domain.ErrTrashParentInTrash, "test restore")
+
+	// only the owner of the ledger can restore
+	req := ctxutil.SetLedger(httptest.NewRequest(http.MethodPost, "/", nil), &domain.Ledger{ID: 1, UserID: 1, Role: domain.LedgerRoleTypeEditor})
+	err = s.uc.Restore(req.Context(), domain.TrashKindSubCateg, 2, 1)
+	s.Require().ErrorIs(err, domain.ErrLedgerPermissionDenied, "test restore by editor")
+}
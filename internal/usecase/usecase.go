@@ -1,17 +1,31 @@
 package usecase
 
 import (
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/account"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/apikey"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/budget"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/contact"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/exchangerate"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/hisport"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/icon"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/importtrans"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/initdata"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/ledger"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/maincateg"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/monthlytrans"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/recurringtrans"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/rule"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/settlement"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/share"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/stock"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/subcateg"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/tag"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/transaction"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/trash"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/user"
 	"github.com/eyo-chen/expense-tracker-go/internal/usecase/usericon"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/view"
 )
 
 type Usecase struct {
@@ -20,6 +34,20 @@ type Usecase struct {
 	SubCateg            *subcateg.UC
 	Transaction         *transaction.UC
 	MonthlyTrans        *monthlytrans.UC
+	RecurringTrans      *recurringtrans.UC
+	Budget              *budget.UC
+	ImportTrans         *importtrans.UC
+	ExchangeRate        *exchangerate.UC
+	Account             *account.UC
+	Tag                 *tag.UC
+	Rule                *rule.UC
+	View                *view.UC
+	Ledger              *ledger.UC
+	Contact             *contact.UC
+	Share               *share.UC
+	Settlement          *settlement.UC
+	APIKey              *apikey.UC
+	Trash               *trash.UC
 	Icon                *icon.UC
 	UserIcon            *usericon.UC
 	InitData            *initdata.UC
@@ -38,12 +66,45 @@ func New(u interfaces.UserRepo,
 	s3 interfaces.S3Service,
 	st interfaces.StockService,
 	hs interfaces.HistoricalPortfolioService,
+	rt interfaces.RecurringTransRepo,
+	b interfaces.BudgetRepo,
+	e interfaces.ExchangeRateRepo,
+	a interfaces.AccountRepo,
+	tg interfaces.TagRepo,
+	tr interfaces.TrashRepo,
+	tv interfaces.TransRevisionRepo,
+	at interfaces.AttachmentRepo,
+	rl interfaces.RuleRepo,
+	vw interfaces.ViewRepo,
+	ld interfaces.LedgerRepo,
+	ct interfaces.ContactRepo,
+	sh interfaces.ShareRepo,
+	sl interfaces.SettlementRepo,
+	ml interfaces.Mailer,
+	ss interfaces.SessionRepo,
+	ak interfaces.APIKeyRepo,
 ) *Usecase {
+	transactionUC := transaction.New(t, m, s, mt, r, s3, a, tg, tv, at, rl, vw)
+
 	return &Usecase{
-		User:                user.New(u, r),
+		User:                user.New(u, r, ml, ss),
 		MainCateg:           maincateg.New(m, i, ui, r, s3),
 		SubCateg:            subcateg.New(s, m),
-		Transaction:         transaction.New(t, m, s, mt, r, s3),
+		Transaction:         transactionUC,
+		RecurringTrans:      recurringtrans.New(rt, m, s, transactionUC),
+		Budget:              budget.New(b, m, t),
+		ImportTrans:         importtrans.New(t, m, s, i, rl),
+		ExchangeRate:        exchangerate.New(e),
+		Account:             account.New(a, t),
+		Tag:                 tag.New(tg),
+		Rule:                rule.New(rl, m, s, tg, t, transactionUC),
+		View:                view.New(vw),
+		Ledger:              ledger.New(ld, u),
+		Contact:             contact.New(ct),
+		Share:               share.New(sh, ct, t),
+		Settlement:          settlement.New(sl, sh, ct),
+		APIKey:              apikey.New(ak, u),
+		Trash:               trash.New(tr, at, s3),
 		Icon:                icon.New(i, ui, r, s3),
 		UserIcon:            usericon.New(s3, ui),
 		InitData:            initdata.New(i, m, s, u),
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"fmt"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+func genPasswordResetMail(user domain.User, token string) domain.Mail {
+	return domain.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Use the token below to reset your password. It expires in 1 hour and can be used only once.\n\n"+
+			"%s\n\n"+
+			"If you didn't request a password reset, you can ignore this mail.", user.Name, token),
+	}
+}
+
+func genVerifyEmailMail(user domain.User, token string) domain.Mail {
+	return domain.Mail{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Use the token below to verify your email. It expires in 24 hours and can be used only once.\n\n"+
+			"%s", user.Name, token),
+	}
+}
@@ -1,9 +1,11 @@
 package user
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
@@ -11,6 +13,18 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	// the prefixes keep the one-time tokens apart from the refresh tokens and from each other
+	passwordResetKeyPrefix = "password_reset:"
+	verifyEmailKeyPrefix   = "verify_email:"
+
+	passwordResetTTL = time.Hour
+	verifyEmailTTL   = 24 * time.Hour
+
+	// sessionTouchInterval is how often the last used time of the session is updated
+	sessionTouchInterval = time.Minute
+)
+
 type claims struct {
 	UserID    int64  `json:"user_id"`
 	UserName  string `json:"user_name"`
@@ -23,13 +37,15 @@ func hashToken(token string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-func genJWTToken(user domain.User) (string, error) {
+// genJWTToken generates the access token, the id of the session is the jti, so the token is rejected once the session is revoked
+func genJWTToken(user domain.User, sessionID int64) (string, error) {
 	key := []byte(os.Getenv("JWT_SECRET_KEY"))
 	claims := claims{
 		UserID:    user.ID,
 		UserName:  user.Name,
 		UserEmail: user.Email,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        strconv.FormatInt(sessionID, 10),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
 		},
 	}
@@ -37,13 +53,25 @@ func genJWTToken(user domain.User) (string, error) {
 	return genToken(key, claims)
 }
 
+// genRefreshToken generates a random refresh token, its expiry is kept by the session
 func genRefreshToken() (string, error) {
-	key := []byte(os.Getenv("JWT_SECRET_KEY"))
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(30 * 24 * time.Hour)),
+	return genRandomToken()
+}
+
+// genOneTimeToken generates a random token for password reset and email verification
+func genOneTimeToken() (string, error) {
+	return genRandomToken()
+}
+
+// genRandomToken generates a token from crypto/rand, so that tokens generated at the same time never collide
+func genRandomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		logger.Error("rand.Read failed", "err", err)
+		return "", err
 	}
 
-	return genToken(key, claims)
+	return hex.EncodeToString(b), nil
 }
 
 func genToken(key []byte, claims jwt.Claims) (string, error) {
@@ -16,15 +16,17 @@ const (
 )
 
 type UC struct {
-	user  interfaces.UserRepo
-	redis interfaces.RedisService
+	user    interfaces.UserRepo
+	redis   interfaces.RedisService
+	mailer  interfaces.Mailer
+	session interfaces.SessionRepo
 }
 
-func New(u interfaces.UserRepo, r interfaces.RedisService) *UC {
-	return &UC{user: u, redis: r}
+func New(u interfaces.UserRepo, r interfaces.RedisService, m interfaces.Mailer, ss interfaces.SessionRepo) *UC {
+	return &UC{user: u, redis: r, mailer: m, session: ss}
 }
 
-func (u *UC) Signup(ctx context.Context, user domain.User) (domain.Token, error) {
+func (u *UC) Signup(ctx context.Context, user domain.User, session domain.Session) (domain.Token, error) {
 	_, err := u.user.FindByEmail(user.Email)
 	if err != nil && err != domain.ErrEmailNotFound {
 		return domain.Token{}, err
@@ -48,87 +50,259 @@ func (u *UC) Signup(ctx context.Context, user domain.User) (domain.Token, error)
 		return domain.Token{}, err
 	}
 
-	accessToken, err := genJWTToken(userWithID)
+	return u.startSession(ctx, userWithID, session)
+}
+
+func (u *UC) Login(ctx context.Context, user domain.User, session domain.Session) (domain.Token, error) {
+	userByEmail, err := u.user.FindByEmail(user.Email)
 	if err != nil {
+		if errors.Is(err, domain.ErrEmailNotFound) {
+			return domain.Token{}, domain.ErrAuthentication
+		}
+
 		return domain.Token{}, err
 	}
-	refreshToken, err := genRefreshToken()
+
+	if !auth.CompareHashPassword(user.Password, userByEmail.Password_hash) {
+		return domain.Token{}, domain.ErrAuthentication
+	}
+
+	return u.startSession(ctx, userByEmail, session)
+}
+
+// Token rotates the refresh token of the session, so every refresh token can be used only once
+func (u *UC) Token(ctx context.Context, refreshToken string) (domain.Token, error) {
+	hashedToken := hashToken(refreshToken)
+	session, err := u.session.GetByRefreshTokenHash(ctx, hashedToken)
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return domain.Token{}, domain.ErrAuthToken
+	}
+	if err != nil {
+		return domain.Token{}, err
+	}
+
+	if session.IsExpired(time.Now()) {
+		// it's ok to fail
+		_ = u.session.Delete(ctx, session.ID)
+		return domain.Token{}, domain.ErrAuthToken
+	}
+
+	user, err := u.user.GetInfo(session.UserID)
 	if err != nil {
 		return domain.Token{}, err
 	}
 
-	// it's ok to fail
-	_ = u.redis.Set(ctx, hashToken(refreshToken), userWithID.Email, 7*24*time.Hour)
+	newRefreshToken, err := genRefreshToken()
+	if err != nil {
+		return domain.Token{}, err
+	}
+
+	if err := u.session.RotateRefreshToken(ctx, session.ID, hashedToken, hashToken(newRefreshToken)); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.Token{}, domain.ErrAuthToken
+		}
+
+		return domain.Token{}, err
+	}
+
+	accessToken, err := genJWTToken(user, session.ID)
+	if err != nil {
+		return domain.Token{}, err
+	}
 
 	return domain.Token{
 		Access:  accessToken,
-		Refresh: refreshToken,
+		Refresh: newRefreshToken,
 	}, nil
 }
 
-func (u *UC) Login(ctx context.Context, user domain.User) (domain.Token, error) {
-	userByEmail, err := u.user.FindByEmail(user.Email)
+// ValidateSession returns error if the session of the access token is revoked or expired
+func (u *UC) ValidateSession(ctx context.Context, id, userID int64) error {
+	session, err := u.session.GetByIDAndUserID(ctx, id, userID)
 	if err != nil {
-		if errors.Is(err, domain.ErrEmailNotFound) {
-			return domain.Token{}, domain.ErrAuthentication
+		return err
+	}
+
+	now := time.Now()
+	if session.IsExpired(now) {
+		return domain.ErrSessionNotFound
+	}
+
+	// the last used time is only updated once in a while, so that not every request writes the database
+	if now.Sub(session.LastUsedAt) > sessionTouchInterval {
+		// it's ok to fail
+		_ = u.session.UpdateLastUsedAt(ctx, session.ID)
+	}
+
+	return nil
+}
+
+func (u *UC) ListSessions(ctx context.Context, userID int64) ([]domain.Session, error) {
+	sessions, err := u.session.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	activeSessions := make([]domain.Session, 0, len(sessions))
+	for _, s := range sessions {
+		if !s.IsExpired(now) {
+			activeSessions = append(activeSessions, s)
 		}
+	}
 
-		return domain.Token{}, err
+	return activeSessions, nil
+}
+
+func (u *UC) RevokeSession(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.session.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
 	}
 
-	if !auth.CompareHashPassword(user.Password, userByEmail.Password_hash) {
-		return domain.Token{}, domain.ErrAuthentication
+	return u.session.Delete(ctx, id)
+}
+
+func (u *UC) RevokeAllSessions(ctx context.Context, userID int64) error {
+	return u.session.DeleteByUserID(ctx, userID)
+}
+
+func (u *UC) GetInfo(userID int64) (domain.User, error) {
+	return u.user.GetInfo(userID)
+}
+
+func (u *UC) UpdateBaseCurrency(ctx context.Context, userID int64, currency string) error {
+	return u.user.Update(ctx, userID, domain.UpdateUserOpt{BaseCurrency: &currency})
+}
+
+// RequestPasswordReset mails a password reset token to the user,
+// it's not an error if the email isn't registered, so that the registered emails can't be probed
+func (u *UC) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := u.user.FindByEmail(email)
+	if errors.Is(err, domain.ErrEmailNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
 	}
 
-	token, err := genJWTToken(userByEmail)
+	token, err := u.issueOneTimeToken(ctx, passwordResetKeyPrefix, user.Email, passwordResetTTL)
 	if err != nil {
-		return domain.Token{}, err
+		return err
 	}
-	refreshToken, err := genRefreshToken()
+
+	return u.mailer.Send(ctx, genPasswordResetMail(user, token))
+}
+
+// ResetPassword consumes the password reset token, and updates the password of the user
+func (u *UC) ResetPassword(ctx context.Context, token, password string) error {
+	email, err := u.consumeOneTimeToken(ctx, passwordResetKeyPrefix, token)
 	if err != nil {
-		return domain.Token{}, err
+		return err
 	}
 
-	// it's ok to fail
-	_ = u.redis.Set(ctx, hashToken(refreshToken), userByEmail.Email, 7*24*time.Hour)
+	user, err := u.user.FindByEmail(email)
+	if err != nil {
+		return err
+	}
 
-	return domain.Token{
-		Access:  token,
-		Refresh: refreshToken,
-	}, nil
+	passwordHash, err := auth.GenerateHashPassword(password)
+	if err != nil {
+		logger.Error("auth.GenerateHashPassword failed", "package", packageName, "err", err)
+		return err
+	}
+
+	if err := u.user.Update(ctx, user.ID, domain.UpdateUserOpt{PasswordHash: &passwordHash}); err != nil {
+		return err
+	}
+
+	// the sessions might be started by whoever knew the old password
+	return u.session.DeleteByUserID(ctx, user.ID)
 }
 
-func (u *UC) Token(ctx context.Context, refreshToken string) (domain.Token, error) {
-	hashedToken := hashToken(refreshToken)
-	userEmail, err := u.redis.GetDel(ctx, hashedToken)
+// RequestEmailVerification mails an email verification token to the user
+func (u *UC) RequestEmailVerification(ctx context.Context, userID int64) error {
+	user, err := u.user.GetInfo(userID)
 	if err != nil {
-		return domain.Token{}, err
+		return err
+	}
+
+	if user.IsEmailVerified {
+		return domain.ErrEmailAlreadyVerified
 	}
 
-	user, err := u.user.FindByEmail(userEmail)
+	token, err := u.issueOneTimeToken(ctx, verifyEmailKeyPrefix, user.Email, verifyEmailTTL)
 	if err != nil {
-		return domain.Token{}, err
+		return err
+	}
+
+	return u.mailer.Send(ctx, genVerifyEmailMail(user, token))
+}
+
+// VerifyEmail consumes the email verification token, and marks the email of the user as verified
+func (u *UC) VerifyEmail(ctx context.Context, token string) error {
+	email, err := u.consumeOneTimeToken(ctx, verifyEmailKeyPrefix, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.user.FindByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	isEmailVerified := true
+	return u.user.Update(ctx, user.ID, domain.UpdateUserOpt{IsEmailVerified: &isEmailVerified})
+}
+
+// issueOneTimeToken generates a token, and stores the hashed token with the email
+func (u *UC) issueOneTimeToken(ctx context.Context, keyPrefix, email string, ttl time.Duration) (string, error) {
+	token, err := genOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := u.redis.Set(ctx, keyPrefix+hashToken(token), email, ttl); err != nil {
+		logger.Error("u.redis.Set failed", "package", packageName, "err", err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumeOneTimeToken returns the email of the token, and deletes the token so that it can't be used again
+func (u *UC) consumeOneTimeToken(ctx context.Context, keyPrefix, token string) (string, error) {
+	email, err := u.redis.GetDel(ctx, keyPrefix+hashToken(token))
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return "", domain.ErrOneTimeToken
+	}
+	if err != nil {
+		return "", err
 	}
 
-	accessToken, err := genJWTToken(user)
+	return email, nil
+}
+
+// startSession creates the session, and returns the tokens of it
+func (u *UC) startSession(ctx context.Context, user domain.User, session domain.Session) (domain.Token, error) {
+	refreshToken, err := genRefreshToken()
 	if err != nil {
 		return domain.Token{}, err
 	}
 
-	newRefreshToken, err := genRefreshToken()
+	session.UserID = user.ID
+	id, err := u.session.Create(ctx, session, hashToken(refreshToken))
 	if err != nil {
 		return domain.Token{}, err
 	}
 
-	// it's ok to fail
-	_ = u.redis.Set(ctx, hashToken(newRefreshToken), userEmail, 7*24*time.Hour)
+	accessToken, err := genJWTToken(user, id)
+	if err != nil {
+		return domain.Token{}, err
+	}
 
 	return domain.Token{
 		Access:  accessToken,
-		Refresh: newRefreshToken,
+		Refresh: refreshToken,
 	}, nil
 }
-
-func (u *UC) GetInfo(userID int64) (domain.User, error) {
-	return u.user.GetInfo(userID)
-}
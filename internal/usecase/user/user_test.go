@@ -3,6 +3,8 @@ package user
 import (
 	"context"
 	"errors"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/eyo-chen/expense-tracker-go/pkg/auth"
 	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
 	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -21,9 +24,11 @@ var (
 
 type UserSuite struct {
 	suite.Suite
-	uc           *UC
-	mockUserRepo *mocks.UserRepo
-	mockRedis    *mocks.RedisService
+	uc              *UC
+	mockUserRepo    *mocks.UserRepo
+	mockRedis       *mocks.RedisService
+	mockMailer      *mocks.Mailer
+	mockSessionRepo *mocks.SessionRepo
 }
 
 func TestUserSuite(t *testing.T) {
@@ -37,7 +42,9 @@ func (s *UserSuite) SetupSuite() {
 func (s *UserSuite) SetupTest() {
 	s.mockUserRepo = mocks.NewUserRepo(s.T())
 	s.mockRedis = mocks.NewRedisService(s.T())
-	s.uc = New(s.mockUserRepo, s.mockRedis)
+	s.mockMailer = mocks.NewMailer(s.T())
+	s.mockSessionRepo = mocks.NewSessionRepo(s.T())
+	s.uc = New(s.mockUserRepo, s.mockRedis, s.mockMailer, s.mockSessionRepo)
 }
 
 func (s *UserSuite) TearDownTest() {
@@ -48,7 +55,7 @@ func (s *UserSuite) TestSignup() {
 	for scenario, fn := range map[string]func(s *UserSuite, desc string){
 		"when email not exists, signup successfully": singup_EmailNotExists_SignupSuccessfully,
 		"when email exists, return error":            singup_EmailExists_ReturnError,
-		"when set cache fail, dont return error":     singup_SetCacheFail_DontReturnError,
+		"when create session fail, return error":     singup_CreateSessionFail_ReturnError,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -65,17 +72,19 @@ func singup_EmailNotExists_SignupSuccessfully(s *UserSuite, desc string) {
 		Name:  "username",
 		Email: "email.com",
 	}
+	mockSession := domain.Session{Device: "device", IP: "127.0.0.1"}
 
 	// prepare mock service
 	s.mockUserRepo.On("FindByEmail", "email.com").Return(domain.User{}, domain.ErrEmailNotFound).Once()
 	s.mockUserRepo.On("Create", "username", "email.com", mock.Anything).Return(nil).Once()
 	s.mockUserRepo.On("FindByEmail", "email.com").Return(mockUser, nil).Once()
-	s.mockRedis.On("Set", mockCTX, mock.Anything, mockUser.Email, 7*24*time.Hour).Return(nil).Once()
+	s.mockSessionRepo.On("Create", mockCTX, domain.Session{UserID: 1, Device: "device", IP: "127.0.0.1"}, mock.Anything).Return(int64(2), nil).Once()
 
-	token, err := s.uc.Signup(mockCTX, mockUser)
+	token, err := s.uc.Signup(mockCTX, mockUser, mockSession)
 	s.Require().NoError(err, desc)
 	s.Require().NotEmpty(token.Access, desc)
 	s.Require().NotEmpty(token.Refresh, desc)
+	s.Require().Equal("2", parseJTI(s, token.Access), desc)
 }
 
 func singup_EmailExists_ReturnError(s *UserSuite, desc string) {
@@ -86,37 +95,36 @@ func singup_EmailExists_ReturnError(s *UserSuite, desc string) {
 	}
 	s.mockUserRepo.On("FindByEmail", "email.com").Return(mockUser, nil).Once()
 
-	token, err := s.uc.Signup(mockCTX, mockUser)
+	token, err := s.uc.Signup(mockCTX, mockUser, domain.Session{})
 	s.Require().ErrorIs(err, domain.ErrEmailAlreadyExists, desc)
 	s.Require().Empty(token, desc)
 }
 
-func singup_SetCacheFail_DontReturnError(s *UserSuite, desc string) {
+func singup_CreateSessionFail_ReturnError(s *UserSuite, desc string) {
 	// prepare mock data
 	mockUser := domain.User{
 		ID:    1,
 		Name:  "username",
 		Email: "email.com",
 	}
+	mockErr := errors.New("create session fail")
 
 	// prepare mock service
 	s.mockUserRepo.On("FindByEmail", "email.com").Return(domain.User{}, domain.ErrEmailNotFound).Once()
 	s.mockUserRepo.On("Create", "username", "email.com", mock.Anything).Return(nil).Once()
 	s.mockUserRepo.On("FindByEmail", "email.com").Return(mockUser, nil).Once()
-	s.mockRedis.On("Set", mockCTX, mock.Anything, mockUser.Email, 7*24*time.Hour).Return(errors.New("set fail")).Once()
+	s.mockSessionRepo.On("Create", mockCTX, domain.Session{UserID: 1}, mock.Anything).Return(int64(0), mockErr).Once()
 
-	token, err := s.uc.Signup(mockCTX, mockUser)
-	s.Require().NoError(err, desc)
-	s.Require().NotEmpty(token.Access, desc)
-	s.Require().NotEmpty(token.Refresh, desc)
+	token, err := s.uc.Signup(mockCTX, mockUser, domain.Session{})
+	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().Empty(token, desc)
 }
 
 func (s *UserSuite) TestLogin() {
 	for scenario, fn := range map[string]func(s *UserSuite, desc string){
-		"when no error, return successfully":     login_NoError_ReturnSuccessfully,
-		"when email not exists, return error":    login_EmailNotExists_ReturnError,
-		"when password not match, return error":  login_PasswordNotMatch_ReturnError,
-		"when redis set fail, dont return error": login_RedisSetFail_DontReturnError,
+		"when no error, return successfully":    login_NoError_ReturnSuccessfully,
+		"when email not exists, return error":   login_EmailNotExists_ReturnError,
+		"when password not match, return error": login_PasswordNotMatch_ReturnError,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -137,13 +145,18 @@ func login_NoError_ReturnSuccessfully(s *UserSuite, desc string) {
 		Password:      "password",
 		Password_hash: hashedPassword,
 	}
+
+	var refreshTokenHash string
 	s.mockUserRepo.On("FindByEmail", "email.com").Return(mockUser, nil).Once()
-	s.mockRedis.On("Set", mockCTX, mock.Anything, mockUser.Email, 7*24*time.Hour).Return(nil).Once()
+	s.mockSessionRepo.On("Create", mockCTX, domain.Session{UserID: 1, Device: "device"}, mock.Anything).
+		Run(func(args mock.Arguments) { refreshTokenHash = args.String(2) }).
+		Return(int64(3), nil).Once()
 
-	token, err := s.uc.Login(mockCTX, mockUser)
+	token, err := s.uc.Login(mockCTX, mockUser, domain.Session{Device: "device"})
 	s.Require().NoError(err, desc)
 	s.Require().NotEmpty(token.Access, desc)
-	s.Require().NotEmpty(token.Refresh, desc)
+	s.Require().Equal(hashToken(token.Refresh), refreshTokenHash, desc)
+	s.Require().Equal("3", parseJTI(s, token.Access), desc)
 }
 
 func login_EmailNotExists_ReturnError(s *UserSuite, desc string) {
@@ -153,7 +166,7 @@ func login_EmailNotExists_ReturnError(s *UserSuite, desc string) {
 		Email:    "email.com",
 		Password: "password",
 	}
-	token, err := s.uc.Login(mockCTX, input)
+	token, err := s.uc.Login(mockCTX, input, domain.Session{})
 	s.Require().ErrorIs(err, domain.ErrAuthentication, desc)
 	s.Require().Empty(token, desc)
 }
@@ -175,37 +188,19 @@ func login_PasswordNotMatch_ReturnError(s *UserSuite, desc string) {
 		Email:    "email.com",
 		Password: "password2", // wrong password
 	}
-	token, err := s.uc.Login(mockCTX, input)
+	token, err := s.uc.Login(mockCTX, input, domain.Session{})
 	s.Require().ErrorIs(err, domain.ErrAuthentication, desc)
 	s.Require().Empty(token, desc)
 }
 
-func login_RedisSetFail_DontReturnError(s *UserSuite, desc string) {
-	hashedPassword, err := auth.GenerateHashPassword("password")
-	s.Require().NoError(err)
-
-	mockUser := domain.User{
-		ID:            1,
-		Name:          "username",
-		Email:         "email.com",
-		Password:      "password",
-		Password_hash: hashedPassword,
-	}
-	s.mockUserRepo.On("FindByEmail", "email.com").Return(mockUser, nil).Once()
-	s.mockRedis.On("Set", mockCTX, mock.Anything, mockUser.Email, 7*24*time.Hour).Return(errors.New("set fail")).Once()
-
-	token, err := s.uc.Login(mockCTX, mockUser)
-	s.Require().NoError(err, desc)
-	s.Require().NotEmpty(token.Access, desc)
-	s.Require().NotEmpty(token.Refresh, desc)
-}
-
 func (s *UserSuite) TestToken() {
 	for scenario, fn := range map[string]func(s *UserSuite, desc string){
-		"when no error, return successfully":    token_NoError_ReturnSuccessfully,
-		"when redis get del fail, return error": token_RedisGetDelFail_ReturnError,
-		"when user not found, return error":     token_UserNotFound_ReturnError,
-		"when set fail, dont return error":      token_SetFail_DontReturnError,
+		"when no error, rotate refresh token":                 token_NoError_RotateRefreshToken,
+		"when rotated twice at once, return different tokens": token_RotatedTwiceAtOnce_ReturnDifferentTokens,
+		"when session not found, return error":                token_SessionNotFound_ReturnError,
+		"when session expired, revoke it":                     token_SessionExpired_RevokeIt,
+		"when refresh token used twice, return error":         token_UsedTwice_ReturnError,
+		"when user not found, return error":                   token_UserNotFound_ReturnError,
 	} {
 		s.Run(testutil.GetFunName(fn), func() {
 			s.SetupTest()
@@ -215,84 +210,194 @@ func (s *UserSuite) TestToken() {
 	}
 }
 
-func token_NoError_ReturnSuccessfully(s *UserSuite, desc string) {
+func token_NoError_RotateRefreshToken(s *UserSuite, desc string) {
 	mockRefreshToken := "refresh_token"
-	mockEmail := "email.com"
-	mockUser := domain.User{Name: "username", Email: mockEmail}
-	mockAccessToken, err := genJWTToken(mockUser)
-	s.Require().NoError(err)
-	mockNewRefreshToken, err := genRefreshToken()
-	s.Require().NoError(err)
+	mockSession := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now()}
+	mockUser := domain.User{ID: 1, Name: "username", Email: "email.com"}
 
-	s.mockRedis.On("GetDel", mockCTX, hashToken(mockRefreshToken)).
-		Return(mockEmail, nil).Once()
+	var newHash string
+	s.mockSessionRepo.On("GetByRefreshTokenHash", mockCTX, hashToken(mockRefreshToken)).Return(mockSession, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(1)).Return(mockUser, nil).Once()
+	s.mockSessionRepo.On("RotateRefreshToken", mockCTX, int64(2), hashToken(mockRefreshToken), mock.Anything).
+		Run(func(args mock.Arguments) { newHash = args.String(3) }).
+		Return(nil).Once()
 
-	s.mockUserRepo.On("FindByEmail", mockEmail).Return(mockUser, nil).Once()
+	token, err := s.uc.Token(mockCTX, mockRefreshToken)
+	s.Require().NoError(err, desc)
+	s.Require().Equal(hashToken(token.Refresh), newHash, desc)
+	s.Require().Equal("2", parseJTI(s, token.Access), desc)
+}
 
-	s.mockRedis.On("Set", mockCTX, hashToken(mockNewRefreshToken), mockEmail, 7*24*time.Hour).Return(nil).Once()
+func token_RotatedTwiceAtOnce_ReturnDifferentTokens(s *UserSuite, desc string) {
+	mockSession := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now()}
+	mockUser := domain.User{ID: 1, Name: "username", Email: "email.com"}
 
-	expResp := domain.Token{
-		Access:  mockAccessToken,
-		Refresh: mockNewRefreshToken,
-	}
+	s.mockSessionRepo.On("GetByRefreshTokenHash", mockCTX, mock.Anything).Return(mockSession, nil).Twice()
+	s.mockUserRepo.On("GetInfo", int64(1)).Return(mockUser, nil).Twice()
+	s.mockSessionRepo.On("RotateRefreshToken", mockCTX, int64(2), mock.Anything, mock.Anything).Return(nil).Twice()
 
-	token, err := s.uc.Token(mockCTX, mockRefreshToken)
+	// the refresh tokens are unique in the database, so they must differ even within the same second
+	token1, err := s.uc.Token(mockCTX, "refresh_token1")
+	s.Require().NoError(err, desc)
+	token2, err := s.uc.Token(mockCTX, "refresh_token2")
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResp, token, desc)
+	s.Require().NotEqual(token1.Refresh, token2.Refresh, desc)
 }
 
-func token_RedisGetDelFail_ReturnError(s *UserSuite, desc string) {
+func token_SessionNotFound_ReturnError(s *UserSuite, desc string) {
 	mockRefreshToken := "refresh_token"
-	mockErr := errors.New("redis get del fail")
 
-	s.mockRedis.On("GetDel", mockCTX, hashToken(mockRefreshToken)).
-		Return("", mockErr).Once()
+	s.mockSessionRepo.On("GetByRefreshTokenHash", mockCTX, hashToken(mockRefreshToken)).Return(domain.Session{}, domain.ErrSessionNotFound).Once()
 
 	token, err := s.uc.Token(mockCTX, mockRefreshToken)
-	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().ErrorIs(err, domain.ErrAuthToken, desc)
 	s.Require().Empty(token, desc)
 }
 
-func token_UserNotFound_ReturnError(s *UserSuite, desc string) {
+func token_SessionExpired_RevokeIt(s *UserSuite, desc string) {
 	mockRefreshToken := "refresh_token"
-	mockEmail := "email.com"
-	mockErr := errors.New("find by email fail")
+	mockSession := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now().Add(-domain.SessionTTL - time.Hour)}
 
-	s.mockRedis.On("GetDel", mockCTX, hashToken(mockRefreshToken)).
-		Return(mockEmail, nil).Once()
+	s.mockSessionRepo.On("GetByRefreshTokenHash", mockCTX, hashToken(mockRefreshToken)).Return(mockSession, nil).Once()
+	s.mockSessionRepo.On("Delete", mockCTX, int64(2)).Return(nil).Once()
 
-	s.mockUserRepo.On("FindByEmail", mockEmail).Return(domain.User{}, mockErr).Once()
+	token, err := s.uc.Token(mockCTX, mockRefreshToken)
+	s.Require().ErrorIs(err, domain.ErrAuthToken, desc)
+	s.Require().Empty(token, desc)
+}
+
+func token_UsedTwice_ReturnError(s *UserSuite, desc string) {
+	mockRefreshToken := "refresh_token"
+	mockSession := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now()}
+	mockUser := domain.User{ID: 1, Name: "username", Email: "email.com"}
+
+	s.mockSessionRepo.On("GetByRefreshTokenHash", mockCTX, hashToken(mockRefreshToken)).Return(mockSession, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(1)).Return(mockUser, nil).Once()
+	s.mockSessionRepo.On("RotateRefreshToken", mockCTX, int64(2), hashToken(mockRefreshToken), mock.Anything).Return(domain.ErrSessionNotFound).Once()
 
 	token, err := s.uc.Token(mockCTX, mockRefreshToken)
-	s.Require().ErrorIs(err, mockErr, desc)
+	s.Require().ErrorIs(err, domain.ErrAuthToken, desc)
 	s.Require().Empty(token, desc)
 }
 
-func token_SetFail_DontReturnError(s *UserSuite, desc string) {
+func token_UserNotFound_ReturnError(s *UserSuite, desc string) {
 	mockRefreshToken := "refresh_token"
-	mockEmail := "email.com"
-	mockUser := domain.User{Name: "username", Email: mockEmail}
-	mockErr := errors.New("set fail")
-	mockAccessToken, err := genJWTToken(mockUser)
-	s.Require().NoError(err)
-	mockNewRefreshToken, err := genRefreshToken()
-	s.Require().NoError(err)
+	mockSession := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now()}
 
-	s.mockRedis.On("GetDel", mockCTX, hashToken(mockRefreshToken)).
-		Return(mockEmail, nil).Once()
+	s.mockSessionRepo.On("GetByRefreshTokenHash", mockCTX, hashToken(mockRefreshToken)).Return(mockSession, nil).Once()
+	s.mockUserRepo.On("GetInfo", int64(1)).Return(domain.User{}, domain.ErrUserIDNotFound).Once()
 
-	s.mockUserRepo.On("FindByEmail", mockEmail).Return(mockUser, nil).Once()
+	token, err := s.uc.Token(mockCTX, mockRefreshToken)
+	s.Require().ErrorIs(err, domain.ErrUserIDNotFound, desc)
+	s.Require().Empty(token, desc)
+}
 
-	s.mockRedis.On("Set", mockCTX, hashToken(mockNewRefreshToken), mockEmail, 7*24*time.Hour).Return(mockErr).Once()
+func (s *UserSuite) TestValidateSession() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when recently used, dont update last used": validateSession_RecentlyUsed_DontUpdateLastUsed,
+		"when used long ago, update last used":      validateSession_UsedLongAgo_UpdateLastUsed,
+		"when session expired, return error":        validateSession_Expired_ReturnError,
+		"when session revoked, return error":        validateSession_Revoked_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func validateSession_RecentlyUsed_DontUpdateLastUsed(s *UserSuite, desc string) {
+	mockSession := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now()}
+	s.mockSessionRepo.On("GetByIDAndUserID", mockCTX, int64(2), int64(1)).Return(mockSession, nil).Once()
+
+	err := s.uc.ValidateSession(mockCTX, 2, 1)
+	s.Require().NoError(err, desc)
+}
+
+func validateSession_UsedLongAgo_UpdateLastUsed(s *UserSuite, desc string) {
+	mockSession := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now().Add(-time.Hour)}
+	s.mockSessionRepo.On("GetByIDAndUserID", mockCTX, int64(2), int64(1)).Return(mockSession, nil).Once()
+	s.mockSessionRepo.On("UpdateLastUsedAt", mockCTX, int64(2)).Return(nil).Once()
 
-	expResp := domain.Token{
-		Access:  mockAccessToken,
-		Refresh: mockNewRefreshToken,
+	err := s.uc.ValidateSession(mockCTX, 2, 1)
+	s.Require().NoError(err, desc)
+}
+
+func validateSession_Expired_ReturnError(s *UserSuite, desc string) {
+	mockSession := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now().Add(-domain.SessionTTL - time.Hour)}
+	s.mockSessionRepo.On("GetByIDAndUserID", mockCTX, int64(2), int64(1)).Return(mockSession, nil).Once()
+
+	err := s.uc.ValidateSession(mockCTX, 2, 1)
+	s.Require().ErrorIs(err, domain.ErrSessionNotFound, desc)
+}
+
+func validateSession_Revoked_ReturnError(s *UserSuite, desc string) {
+	s.mockSessionRepo.On("GetByIDAndUserID", mockCTX, int64(2), int64(1)).Return(domain.Session{}, domain.ErrSessionNotFound).Once()
+
+	err := s.uc.ValidateSession(mockCTX, 2, 1)
+	s.Require().ErrorIs(err, domain.ErrSessionNotFound, desc)
+}
+
+func (s *UserSuite) TestListSessions() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when session expired, leave it out": listSessions_Expired_LeaveItOut,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
 	}
+}
 
-	token, err := s.uc.Token(mockCTX, mockRefreshToken)
+func listSessions_Expired_LeaveItOut(s *UserSuite, desc string) {
+	active := domain.Session{ID: 2, UserID: 1, LastUsedAt: time.Now()}
+	expired := domain.Session{ID: 3, UserID: 1, LastUsedAt: time.Now().Add(-domain.SessionTTL - time.Hour)}
+	s.mockSessionRepo.On("GetByUserID", mockCTX, int64(1)).Return([]domain.Session{active, expired}, nil).Once()
+
+	sessions, err := s.uc.ListSessions(mockCTX, 1)
+	s.Require().NoError(err, desc)
+	s.Require().Equal([]domain.Session{active}, sessions, desc)
+}
+
+func (s *UserSuite) TestRevokeSession() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when own session, delete it":              revokeSession_OwnSession_DeleteIt,
+		"when session of other user, return error": revokeSession_OtherUser_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func revokeSession_OwnSession_DeleteIt(s *UserSuite, desc string) {
+	s.mockSessionRepo.On("GetByIDAndUserID", mockCTX, int64(2), int64(1)).Return(domain.Session{ID: 2, UserID: 1}, nil).Once()
+	s.mockSessionRepo.On("Delete", mockCTX, int64(2)).Return(nil).Once()
+
+	err := s.uc.RevokeSession(mockCTX, 2, 1)
 	s.Require().NoError(err, desc)
-	s.Require().Equal(expResp, token, desc)
+}
+
+func revokeSession_OtherUser_ReturnError(s *UserSuite, desc string) {
+	s.mockSessionRepo.On("GetByIDAndUserID", mockCTX, int64(2), int64(1)).Return(domain.Session{}, domain.ErrSessionNotFound).Once()
+
+	err := s.uc.RevokeSession(mockCTX, 2, 1)
+	s.Require().ErrorIs(err, domain.ErrSessionNotFound, desc)
+}
+
+// parseJTI returns the jti of the access token
+func parseJTI(s *UserSuite, accessToken string) string {
+	var c claims
+	_, err := jwt.ParseWithClaims(accessToken, &c, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("JWT_SECRET_KEY")), nil
+	})
+	s.Require().NoError(err)
+
+	return c.ID
 }
 
 func (s *UserSuite) TestGetInfo() {
@@ -328,3 +433,187 @@ func getInfo_GetFail_ReturnError(s *UserSuite, desc string) {
 	s.Require().ErrorIs(err, domain.ErrUserIDNotFound, desc)
 	s.Require().Empty(user, desc)
 }
+
+func (s *UserSuite) TestRequestPasswordReset() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when email exists, mail token":     requestPasswordReset_EmailExists_MailToken,
+		"when email not exists, dont mail":  requestPasswordReset_EmailNotExists_DontMail,
+		"when set cache fail, return error": requestPasswordReset_SetCacheFail_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func requestPasswordReset_EmailExists_MailToken(s *UserSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1, Name: "username", Email: "a@a.com"}
+
+	// prepare mock service
+	var hashedKey string
+	s.mockUserRepo.On("FindByEmail", "a@a.com").Return(mockUser, nil).Once()
+	s.mockRedis.On("Set", mockCTX, mock.Anything, "a@a.com", time.Hour).
+		Run(func(args mock.Arguments) { hashedKey = args.String(1) }).
+		Return(nil).Once()
+
+	var mail domain.Mail
+	s.mockMailer.On("Send", mockCTX, mock.Anything).
+		Run(func(args mock.Arguments) { mail = args.Get(1).(domain.Mail) }).
+		Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.RequestPasswordReset(mockCTX, "a@a.com")
+	s.Require().NoError(err, desc)
+	s.Require().Equal("a@a.com", mail.To, desc)
+
+	// the mail carries the raw token, and only the hash of it is stored
+	token := mail.Body[strings.Index(mail.Body, "once.\n\n")+len("once.\n\n"):]
+	token = token[:strings.Index(token, "\n")]
+	s.Require().Equal(passwordResetKeyPrefix+hashToken(token), hashedKey, desc)
+}
+
+func requestPasswordReset_EmailNotExists_DontMail(s *UserSuite, desc string) {
+	// prepare mock service
+	s.mockUserRepo.On("FindByEmail", "a@a.com").Return(domain.User{}, domain.ErrEmailNotFound).Once()
+
+	// action, assertion
+	err := s.uc.RequestPasswordReset(mockCTX, "a@a.com")
+	s.Require().NoError(err, desc)
+}
+
+func requestPasswordReset_SetCacheFail_ReturnError(s *UserSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1, Name: "username", Email: "a@a.com"}
+	mockErr := errors.New("set cache fail")
+
+	// prepare mock service
+	s.mockUserRepo.On("FindByEmail", "a@a.com").Return(mockUser, nil).Once()
+	s.mockRedis.On("Set", mockCTX, mock.Anything, "a@a.com", time.Hour).Return(mockErr).Once()
+
+	// action, assertion
+	err := s.uc.RequestPasswordReset(mockCTX, "a@a.com")
+	s.Require().ErrorIs(err, mockErr, desc)
+}
+
+func (s *UserSuite) TestResetPassword() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when token is valid, update password": resetPassword_ValidToken_UpdatePassword,
+		"when token is used, return error":     resetPassword_UsedToken_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func resetPassword_ValidToken_UpdatePassword(s *UserSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1, Name: "username", Email: "a@a.com"}
+
+	// prepare mock service
+	s.mockRedis.On("GetDel", mockCTX, passwordResetKeyPrefix+hashToken("token")).Return("a@a.com", nil).Once()
+	s.mockUserRepo.On("FindByEmail", "a@a.com").Return(mockUser, nil).Once()
+
+	var opt domain.UpdateUserOpt
+	s.mockUserRepo.On("Update", mockCTX, int64(1), mock.Anything).
+		Run(func(args mock.Arguments) { opt = args.Get(2).(domain.UpdateUserOpt) }).
+		Return(nil).Once()
+	s.mockSessionRepo.On("DeleteByUserID", mockCTX, int64(1)).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.ResetPassword(mockCTX, "token", "new-password")
+	s.Require().NoError(err, desc)
+	s.Require().NotNil(opt.PasswordHash, desc)
+	s.Require().True(auth.CompareHashPassword("new-password", *opt.PasswordHash), desc)
+}
+
+func resetPassword_UsedToken_ReturnError(s *UserSuite, desc string) {
+	// prepare mock service
+	s.mockRedis.On("GetDel", mockCTX, passwordResetKeyPrefix+hashToken("token")).Return("", domain.ErrCacheMiss).Once()
+
+	// action, assertion
+	err := s.uc.ResetPassword(mockCTX, "token", "new-password")
+	s.Require().ErrorIs(err, domain.ErrOneTimeToken, desc)
+}
+
+func (s *UserSuite) TestRequestEmailVerification() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when email not verified, mail token": requestEmailVerification_NotVerified_MailToken,
+		"when email verified, return error":   requestEmailVerification_Verified_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func requestEmailVerification_NotVerified_MailToken(s *UserSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1, Name: "username", Email: "a@a.com"}
+
+	// prepare mock service
+	s.mockUserRepo.On("GetInfo", int64(1)).Return(mockUser, nil).Once()
+	s.mockRedis.On("Set", mockCTX, mock.Anything, "a@a.com", 24*time.Hour).Return(nil).Once()
+	s.mockMailer.On("Send", mockCTX, mock.MatchedBy(func(m domain.Mail) bool { return m.To == "a@a.com" })).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.RequestEmailVerification(mockCTX, 1)
+	s.Require().NoError(err, desc)
+}
+
+func requestEmailVerification_Verified_ReturnError(s *UserSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1, Name: "username", Email: "a@a.com", IsEmailVerified: true}
+
+	// prepare mock service
+	s.mockUserRepo.On("GetInfo", int64(1)).Return(mockUser, nil).Once()
+
+	// action, assertion
+	err := s.uc.RequestEmailVerification(mockCTX, 1)
+	s.Require().ErrorIs(err, domain.ErrEmailAlreadyVerified, desc)
+}
+
+func (s *UserSuite) TestVerifyEmail() {
+	for scenario, fn := range map[string]func(s *UserSuite, desc string){
+		"when token is valid, mark verified":         verifyEmail_ValidToken_MarkVerified,
+		"when token of password reset, return error": verifyEmail_PasswordResetToken_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func verifyEmail_ValidToken_MarkVerified(s *UserSuite, desc string) {
+	// prepare mock data
+	mockUser := domain.User{ID: 1, Name: "username", Email: "a@a.com"}
+	isEmailVerified := true
+
+	// prepare mock service
+	s.mockRedis.On("GetDel", mockCTX, verifyEmailKeyPrefix+hashToken("token")).Return("a@a.com", nil).Once()
+	s.mockUserRepo.On("FindByEmail", "a@a.com").Return(mockUser, nil).Once()
+	s.mockUserRepo.On("Update", mockCTX, int64(1), domain.UpdateUserOpt{IsEmailVerified: &isEmailVerified}).Return(nil).Once()
+
+	// action, assertion
+	err := s.uc.VerifyEmail(mockCTX, "token")
+	s.Require().NoError(err, desc)
+}
+
+func verifyEmail_PasswordResetToken_ReturnError(s *UserSuite, desc string) {
+	// prepare mock service
+	// the token is only stored under the key of password reset, so it misses the key of email verification
+	s.mockRedis.On("GetDel", mockCTX, verifyEmailKeyPrefix+hashToken("token")).Return("", domain.ErrCacheMiss).Once()
+
+	// action, assertion
+	err := s.uc.VerifyEmail(mockCTX, "token")
+	s.Require().ErrorIs(err, domain.ErrOneTimeToken, desc)
+}
@@ -0,0 +1,44 @@
+package view
+
+import (
+	"context"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/internal/usecase/interfaces"
+)
+
+type UC struct {
+	View interfaces.ViewRepo
+}
+
+func New(v interfaces.ViewRepo) *UC {
+	return &UC{
+		View: v,
+	}
+}
+
+func (u *UC) Create(ctx context.Context, view domain.View, userID int64) error {
+	return u.View.Create(ctx, view, userID)
+}
+
+func (u *UC) GetAll(ctx context.Context, userID int64) ([]domain.View, error) {
+	return u.View.GetAll(ctx, userID)
+}
+
+func (u *UC) Update(ctx context.Context, view domain.View, userID int64) error {
+	// check permission
+	if _, err := u.View.GetByIDAndUserID(ctx, view.ID, userID); err != nil {
+		return err
+	}
+
+	return u.View.Update(ctx, view)
+}
+
+func (u *UC) Delete(ctx context.Context, id, userID int64) error {
+	// check permission
+	if _, err := u.View.GetByIDAndUserID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return u.View.Delete(ctx, id)
+}
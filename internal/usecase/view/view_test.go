@@ -0,0 +1,99 @@
+package view
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+	"github.com/eyo-chen/expense-tracker-go/mocks"
+	"github.com/eyo-chen/expense-tracker-go/pkg/logger"
+	"github.com/eyo-chen/expense-tracker-go/pkg/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mockCtx = context.Background()
+)
+
+type ViewSuite struct {
+	suite.Suite
+	uc           *UC
+	mockViewRepo *mocks.ViewRepo
+}
+
+func TestViewSuite(t *testing.T) {
+	suite.Run(t, new(ViewSuite))
+}
+
+func (s *ViewSuite) SetupSuite() {
+	logger.Register()
+}
+
+func (s *ViewSuite) SetupTest() {
+	s.mockViewRepo = mocks.NewViewRepo(s.T())
+	s.uc = New(s.mockViewRepo)
+}
+
+func (s *ViewSuite) TearDownTest() {
+	s.mockViewRepo.AssertExpectations(s.T())
+}
+
+func (s *ViewSuite) TestUpdate() {
+	for scenario, fn := range map[string]func(s *ViewSuite, desc string){
+		"when no error, update successfully": update_NoError_UpdateSuccessfully,
+		"when view not found, return error":  update_ViewNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func update_NoError_UpdateSuccessfully(s *ViewSuite, desc string) {
+	view := domain.View{ID: 1, Name: "food this year"}
+
+	s.mockViewRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.View{ID: 1, Name: "food"}, nil).Once()
+	s.mockViewRepo.On("Update", mockCtx, view).Return(nil).Once()
+
+	err := s.uc.Update(mockCtx, view, 1)
+	s.Require().NoError(err, desc)
+}
+
+func update_ViewNotFound_ReturnError(s *ViewSuite, desc string) {
+	view := domain.View{ID: 1, Name: "food this year"}
+
+	s.mockViewRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.View{}, domain.ErrViewNotFound).Once()
+
+	err := s.uc.Update(mockCtx, view, 1)
+	s.Require().ErrorIs(err, domain.ErrViewNotFound, desc)
+}
+
+func (s *ViewSuite) TestDelete() {
+	for scenario, fn := range map[string]func(s *ViewSuite, desc string){
+		"when no error, delete successfully": delete_NoError_DeleteSuccessfully,
+		"when view not found, return error":  delete_ViewNotFound_ReturnError,
+	} {
+		s.Run(testutil.GetFunName(fn), func() {
+			s.SetupTest()
+			fn(s, scenario)
+			s.TearDownTest()
+		})
+	}
+}
+
+func delete_NoError_DeleteSuccessfully(s *ViewSuite, desc string) {
+	s.mockViewRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.View{ID: 1}, nil).Once()
+	s.mockViewRepo.On("Delete", mockCtx, int64(1)).Return(nil).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().NoError(err, desc)
+}
+
+func delete_ViewNotFound_ReturnError(s *ViewSuite, desc string) {
+	s.mockViewRepo.On("GetByIDAndUserID", mockCtx, int64(1), int64(1)).Return(domain.View{}, domain.ErrViewNotFound).Once()
+
+	err := s.uc.Delete(mockCtx, 1, 1)
+	s.Require().ErrorIs(err, domain.ErrViewNotFound, desc)
+}
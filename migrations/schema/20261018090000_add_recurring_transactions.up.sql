@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    type ENUM('1', '2') NOT NULL, -- 1 for 'income', 2 for 'expense'
+    main_category_id INT NOT NULL,
+    sub_category_id INT NOT NULL,
+    price DECIMAL(12, 2) NOT NULL,
+    note VARCHAR(255),
+    frequency ENUM('1', '2', '3', '4') NOT NULL, -- 1 for 'daily', 2 for 'weekly', 3 for 'monthly', 4 for 'yearly'
+    interval_count INT NOT NULL DEFAULT 1,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    next_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (main_category_id) REFERENCES main_categories(id) ON DELETE CASCADE,
+    FOREIGN KEY (sub_category_id) REFERENCES sub_categories(id) ON DELETE CASCADE,
+    INDEX idx_user_id (user_id),
+    INDEX idx_next_date (next_date)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD COLUMN base_currency CHAR(3) NOT NULL DEFAULT 'USD' AFTER is_set_init_category;
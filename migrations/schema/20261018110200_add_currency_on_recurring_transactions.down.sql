@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transactions
+DROP COLUMN currency;
@@ -0,0 +1,2 @@
+ALTER TABLE recurring_transactions
+ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD' AFTER price; -- ISO 4217 code
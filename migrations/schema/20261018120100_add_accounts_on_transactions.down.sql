@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+DROP FOREIGN KEY fk_transactions_account_id,
+DROP FOREIGN KEY fk_transactions_to_account_id,
+DROP COLUMN account_id,
+DROP COLUMN to_account_id;
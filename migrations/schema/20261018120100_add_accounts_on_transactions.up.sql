@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+ADD COLUMN account_id INT NULL AFTER sub_category_id,
+ADD COLUMN to_account_id INT NULL AFTER account_id, -- only used by transfer
+ADD CONSTRAINT fk_transactions_account_id FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE SET NULL,
+ADD CONSTRAINT fk_transactions_to_account_id FOREIGN KEY (to_account_id) REFERENCES accounts(id) ON DELETE SET NULL;
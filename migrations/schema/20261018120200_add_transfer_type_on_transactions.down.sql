@@ -0,0 +1,5 @@
+-- transfers have to be removed before rolling back, because they don't have categories
+ALTER TABLE transactions
+MODIFY COLUMN type ENUM('1', '2') NOT NULL,
+MODIFY COLUMN main_category_id INT NOT NULL,
+MODIFY COLUMN sub_category_id INT NOT NULL;
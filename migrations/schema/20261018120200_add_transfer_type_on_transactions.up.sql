@@ -0,0 +1,5 @@
+-- transfer doesn't belong to any category
+ALTER TABLE transactions
+MODIFY COLUMN type ENUM('1', '2', '3') NOT NULL, -- 1 for 'income', 2 for 'expense', 3 for 'transfer'
+MODIFY COLUMN main_category_id INT NULL,
+MODIFY COLUMN sub_category_id INT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transaction_splits;
\ No newline at end of file
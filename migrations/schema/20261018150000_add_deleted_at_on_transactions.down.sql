@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+DROP INDEX idx_deleted_at,
+DROP COLUMN deleted_at;
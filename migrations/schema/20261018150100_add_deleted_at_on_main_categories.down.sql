@@ -0,0 +1,3 @@
+ALTER TABLE main_categories
+DROP INDEX idx_deleted_at,
+DROP COLUMN deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE sub_categories
+ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL, -- set when the row is moved to trash
+ADD INDEX idx_deleted_at (deleted_at);
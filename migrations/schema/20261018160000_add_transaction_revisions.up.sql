@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS transaction_revisions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    transaction_id INT NOT NULL,
+    user_id INT NOT NULL, -- the user who made the change
+    action ENUM('1', '2', '3', '4') NOT NULL, -- 1 for 'create', 2 for 'update', 3 for 'delete', 4 for 'revert'
+    before_snapshot JSON NULL, -- NULL on create
+    after_snapshot JSON NULL, -- NULL on delete
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_transaction_id (transaction_id)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS transaction_attachments (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    transaction_id INT NOT NULL,
+    user_id INT NOT NULL,
+    file_name VARCHAR(100) NOT NULL,
+    object_key VARCHAR(191) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE INDEX unique_object_key (object_key),
+    INDEX idx_transaction_id (transaction_id)
+);
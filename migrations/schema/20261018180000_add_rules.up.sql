@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS rules (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    type ENUM('1', '2') NOT NULL, -- 1 for 'income', 2 for 'expense'
+    note_contains VARCHAR(255) NOT NULL DEFAULT '',
+    note_regex VARCHAR(255) NOT NULL DEFAULT '',
+    min_price DECIMAL(12, 2),
+    max_price DECIMAL(12, 2),
+    main_category_id INT NOT NULL,
+    sub_category_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (main_category_id) REFERENCES main_categories(id) ON DELETE CASCADE,
+    FOREIGN KEY (sub_category_id) REFERENCES sub_categories(id) ON DELETE CASCADE,
+    UNIQUE INDEX unique_name_user (name, user_id),
+    INDEX idx_user_id_priority (user_id, priority)
+);
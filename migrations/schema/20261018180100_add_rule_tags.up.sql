@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS rule_tags (
+    rule_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (rule_id, tag_id),
+    FOREIGN KEY (rule_id) REFERENCES rules(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE,
+    INDEX idx_tag_id (tag_id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS duplicate_dismissals (
+    transaction_id INT NOT NULL,
+    other_transaction_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (transaction_id, other_transaction_id),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (other_transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    INDEX idx_other_transaction_id (other_transaction_id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE main_categories
+DROP COLUMN position,
+DROP COLUMN is_archived;
@@ -0,0 +1,3 @@
+ALTER TABLE main_categories
+ADD COLUMN is_archived BOOLEAN NOT NULL DEFAULT FALSE AFTER icon_data,
+ADD COLUMN position INT NOT NULL DEFAULT 0 AFTER is_archived; -- user-defined order, ties are ordered by id
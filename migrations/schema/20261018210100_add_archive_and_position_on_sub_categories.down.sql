@@ -0,0 +1,3 @@
+ALTER TABLE sub_categories
+DROP COLUMN position,
+DROP COLUMN is_archived;
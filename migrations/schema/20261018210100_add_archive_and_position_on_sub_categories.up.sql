@@ -0,0 +1,3 @@
+ALTER TABLE sub_categories
+ADD COLUMN is_archived BOOLEAN NOT NULL DEFAULT FALSE AFTER main_category_id,
+ADD COLUMN position INT NOT NULL DEFAULT 0 AFTER is_archived; -- user-defined order within the main category, ties are ordered by id
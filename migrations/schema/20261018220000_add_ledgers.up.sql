@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS ledgers (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(191) NOT NULL,
+    user_id INT NOT NULL, -- the user owning the categories and transactions of the ledger, which is the member themself for a personal ledger
+    is_personal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE INDEX unique_user (user_id)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS ledger_members (
+    ledger_id INT NOT NULL,
+    user_id INT NOT NULL,
+    role ENUM('1', '2', '3') NOT NULL, -- 1 for 'owner', 2 for 'editor', 3 for 'viewer'
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (ledger_id, user_id),
+    FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_user_id (user_id)
+);
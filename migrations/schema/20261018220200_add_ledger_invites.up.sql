@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS ledger_invites (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    ledger_id INT NOT NULL,
+    email VARCHAR(191) NOT NULL,
+    role ENUM('2', '3') NOT NULL, -- 2 for 'editor', 3 for 'viewer'
+    invited_by INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE INDEX unique_ledger_email (ledger_id, email),
+    INDEX idx_email (email)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE users
+MODIFY COLUMN email VARCHAR(191) NOT NULL;
@@ -0,0 +1,3 @@
+-- the users backing shared ledgers have no email, so they can't log in
+ALTER TABLE users
+MODIFY COLUMN email VARCHAR(191) NULL;
@@ -0,0 +1 @@
+DELETE FROM ledgers WHERE is_personal = TRUE;
@@ -0,0 +1,2 @@
+INSERT INTO ledgers (name, user_id, is_personal)
+SELECT 'Personal', id, TRUE FROM users WHERE email IS NOT NULL;
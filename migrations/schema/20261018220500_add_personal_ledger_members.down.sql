@@ -0,0 +1,3 @@
+DELETE ledger_members FROM ledger_members
+INNER JOIN ledgers ON ledgers.id = ledger_members.ledger_id
+WHERE ledgers.is_personal = TRUE;
@@ -0,0 +1,2 @@
+INSERT INTO ledger_members (ledger_id, user_id, role)
+SELECT id, user_id, '1' FROM ledgers WHERE is_personal = TRUE;
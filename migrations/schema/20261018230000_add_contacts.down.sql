@@ -0,0 +1 @@
+DROP TABLE IF EXISTS contacts;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shared_transactions;
\ No newline at end of file
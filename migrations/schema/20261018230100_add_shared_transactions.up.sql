@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS shared_transactions (
+    transaction_id INT PRIMARY KEY,
+    method ENUM('1', '2', '3', '4') NOT NULL, -- 1 for 'equal', 2 for 'exact', 3 for 'percent', 4 for 'shares'
+    paid_by INT, -- the contact who paid, NULL means the user
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (paid_by) REFERENCES contacts(id) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transaction_shares;
\ No newline at end of file
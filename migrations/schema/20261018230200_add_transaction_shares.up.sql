@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS transaction_shares (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    transaction_id INT NOT NULL,
+    contact_id INT, -- NULL means the user
+    value DECIMAL(12, 2) NOT NULL, -- the amount, percentage or number of shares, depending on the method
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES shared_transactions(transaction_id) ON DELETE CASCADE,
+    FOREIGN KEY (contact_id) REFERENCES contacts(id) ON DELETE CASCADE,
+    INDEX idx_transaction_id (transaction_id)
+);
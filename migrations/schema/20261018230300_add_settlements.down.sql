@@ -0,0 +1 @@
+DROP TABLE IF EXISTS settlements;
\ No newline at end of file
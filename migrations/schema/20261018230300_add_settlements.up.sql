@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS settlements (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    from_contact_id INT, -- NULL means the user
+    to_contact_id INT, -- NULL means the user
+    amount DECIMAL(12, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    date DATE NOT NULL,
+    note VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_contact_id) REFERENCES contacts(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_contact_id) REFERENCES contacts(id) ON DELETE CASCADE,
+    INDEX idx_user_id (user_id)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    prefix CHAR(12) NOT NULL, -- the beginning of the key, so the user can tell the keys apart
+    key_hash CHAR(64) NOT NULL, -- sha256 of the key, the key itself is only shown once
+    scopes JSON NOT NULL,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    last_used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE INDEX unique_key_hash (key_hash),
+    INDEX idx_user_id (user_id)
+);
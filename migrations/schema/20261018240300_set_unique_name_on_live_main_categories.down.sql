@@ -0,0 +1,4 @@
+ALTER TABLE main_categories
+DROP INDEX unique_name_user_type,
+ADD CONSTRAINT unique_name_user_type UNIQUE (name, user_id, type),
+DROP COLUMN is_live;
@@ -0,0 +1,4 @@
+ALTER TABLE main_categories
+ADD COLUMN is_live BOOLEAN GENERATED ALWAYS AS (IF(deleted_at IS NULL, TRUE, NULL)) VIRTUAL, -- NULL in trash, so the name is only unique among the live categories
+DROP INDEX unique_name_user_type,
+ADD CONSTRAINT unique_name_user_type UNIQUE (name, user_id, type, is_live);
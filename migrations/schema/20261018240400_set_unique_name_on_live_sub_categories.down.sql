@@ -0,0 +1,4 @@
+ALTER TABLE sub_categories
+DROP INDEX unique_name_user_maincategory,
+ADD CONSTRAINT unique_name_user_maincategory UNIQUE (name, user_id, main_category_id),
+DROP COLUMN is_live;
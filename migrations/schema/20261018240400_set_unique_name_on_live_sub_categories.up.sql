@@ -0,0 +1,4 @@
+ALTER TABLE sub_categories
+ADD COLUMN is_live BOOLEAN GENERATED ALWAYS AS (IF(deleted_at IS NULL, TRUE, NULL)) VIRTUAL, -- NULL in trash, so the name is only unique among the live categories
+DROP INDEX unique_name_user_maincategory,
+ADD CONSTRAINT unique_name_user_maincategory UNIQUE (name, user_id, main_category_id, is_live);
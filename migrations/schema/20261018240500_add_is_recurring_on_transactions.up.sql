@@ -0,0 +1,2 @@
+ALTER TABLE transactions
+ADD COLUMN is_recurring BOOLEAN NOT NULL DEFAULT FALSE; -- set when the transaction is an occurrence created by a recurring transaction
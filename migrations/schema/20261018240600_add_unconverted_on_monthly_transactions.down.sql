@@ -0,0 +1,2 @@
+ALTER TABLE monthly_transactions
+DROP COLUMN unconverted;
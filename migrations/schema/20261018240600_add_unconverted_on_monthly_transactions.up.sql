@@ -0,0 +1,2 @@
+ALTER TABLE monthly_transactions
+ADD COLUMN unconverted INT NOT NULL DEFAULT 0; -- number of transactions left out of the totals, because there is no exchange rate
@@ -0,0 +1,180 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyRepo is an autogenerated mock type for the APIKeyRepo type
+type APIKeyRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, key, keyHash
+func (_m *APIKeyRepo) Create(ctx context.Context, key domain.APIKey, keyHash string) (int64, error) {
+	ret := _m.Called(ctx, key, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.APIKey, string) (int64, error)); ok {
+		return rf(ctx, key, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.APIKey, string) int64); ok {
+		r0 = rf(ctx, key, keyHash)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.APIKey, string) error); ok {
+		r1 = rf(ctx, key, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *APIKeyRepo) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *APIKeyRepo) GetAll(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.APIKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.APIKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIDAndUserID provides a mock function with given fields: ctx, id, userID
+func (_m *APIKeyRepo) GetByIDAndUserID(ctx context.Context, id int64, userID int64) (domain.APIKey, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDAndUserID")
+	}
+
+	var r0 domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.APIKey, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.APIKey); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Get(0).(domain.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByKeyHash provides a mock function with given fields: ctx, keyHash
+func (_m *APIKeyRepo) GetByKeyHash(ctx context.Context, keyHash string) (domain.APIKey, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByKeyHash")
+	}
+
+	var r0 domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.APIKey, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.APIKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		r0 = ret.Get(0).(domain.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLastUsedAt provides a mock function with given fields: ctx, id
+func (_m *APIKeyRepo) UpdateLastUsedAt(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastUsedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAPIKeyRepo creates a new instance of APIKeyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepo {
+	mock := &APIKeyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
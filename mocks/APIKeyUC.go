@@ -0,0 +1,148 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyUC is an autogenerated mock type for the APIKeyUC type
+type APIKeyUC struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, rawKey
+func (_m *APIKeyUC) Authenticate(ctx context.Context, rawKey string) (domain.User, domain.APIKey, error) {
+	ret := _m.Called(ctx, rawKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 domain.User
+	var r1 domain.APIKey
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.User, domain.APIKey, error)); ok {
+		return rf(ctx, rawKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = rf(ctx, rawKey)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) domain.APIKey); ok {
+		r1 = rf(ctx, rawKey)
+	} else {
+		r1 = ret.Get(1).(domain.APIKey)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, rawKey)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Create provides a mock function with given fields: ctx, key, userID
+func (_m *APIKeyUC) Create(ctx context.Context, key domain.APIKey, userID int64) (domain.APIKey, string, error) {
+	ret := _m.Called(ctx, key, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.APIKey
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.APIKey, int64) (domain.APIKey, string, error)); ok {
+		return rf(ctx, key, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.APIKey, int64) domain.APIKey); ok {
+		r0 = rf(ctx, key, userID)
+	} else {
+		r0 = ret.Get(0).(domain.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.APIKey, int64) string); ok {
+		r1 = rf(ctx, key, userID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.APIKey, int64) error); ok {
+		r2 = rf(ctx, key, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Delete provides a mock function with given fields: ctx, id, userID
+func (_m *APIKeyUC) Delete(ctx context.Context, id int64, userID int64) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *APIKeyUC) GetAll(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.APIKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.APIKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAPIKeyUC creates a new instance of APIKeyUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyUC {
+	mock := &APIKeyUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
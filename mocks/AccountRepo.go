@@ -0,0 +1,142 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccountRepo is an autogenerated mock type for the AccountRepo type
+type AccountRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, account, userID
+func (_m *AccountRepo) Create(ctx context.Context, account domain.Account, userID int64) error {
+	ret := _m.Called(ctx, account, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Account, int64) error); ok {
+		r0 = rf(ctx, account, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *AccountRepo) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *AccountRepo) GetAll(ctx context.Context, userID int64) ([]domain.Account, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Account, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Account); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIDAndUserID provides a mock function with given fields: ctx, id, userID
+func (_m *AccountRepo) GetByIDAndUserID(ctx context.Context, id int64, userID int64) (domain.Account, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDAndUserID")
+	}
+
+	var r0 domain.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.Account, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.Account); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, account
+func (_m *AccountRepo) Update(ctx context.Context, account domain.Account) error {
+	ret := _m.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Account) error); ok {
+		r0 = rf(ctx, account)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAccountRepo creates a new instance of AccountRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountRepo {
+	mock := &AccountRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
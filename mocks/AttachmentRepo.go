@@ -0,0 +1,126 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AttachmentRepo is an autogenerated mock type for the AttachmentRepo type
+type AttachmentRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, a
+func (_m *AttachmentRepo) Create(ctx context.Context, a domain.TransAttachment) error {
+	ret := _m.Called(ctx, a)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransAttachment) error); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *AttachmentRepo) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByIDAndTransID provides a mock function with given fields: ctx, id, transID
+func (_m *AttachmentRepo) GetByIDAndTransID(ctx context.Context, id int64, transID int64) (domain.TransAttachment, error) {
+	ret := _m.Called(ctx, id, transID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDAndTransID")
+	}
+
+	var r0 domain.TransAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.TransAttachment, error)); ok {
+		return rf(ctx, id, transID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.TransAttachment); ok {
+		r0 = rf(ctx, id, transID)
+	} else {
+		r0 = ret.Get(0).(domain.TransAttachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, transID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByPurgeableTrans provides a mock function with given fields: ctx, before
+func (_m *AttachmentRepo) GetByPurgeableTrans(ctx context.Context, before time.Time) ([]domain.TransAttachment, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByPurgeableTrans")
+	}
+
+	var r0 []domain.TransAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.TransAttachment, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.TransAttachment); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttachmentRepo creates a new instance of AttachmentRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentRepo {
+	mock := &AttachmentRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,128 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BudgetUC is an autogenerated mock type for the BudgetUC type
+type BudgetUC struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, id, userID
+func (_m *BudgetUC) Delete(ctx context.Context, id int64, userID int64) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *BudgetUC) GetAll(ctx context.Context, userID int64) ([]domain.Budget, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Budget, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Budget); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatus provides a mock function with given fields: ctx, month, userID
+func (_m *BudgetUC) GetStatus(ctx context.Context, month time.Time, userID int64) ([]domain.BudgetStatus, error) {
+	ret := _m.Called(ctx, month, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 []domain.BudgetStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int64) ([]domain.BudgetStatus, error)); ok {
+		return rf(ctx, month, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int64) []domain.BudgetStatus); ok {
+		r0 = rf(ctx, month, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BudgetStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int64) error); ok {
+		r1 = rf(ctx, month, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Set provides a mock function with given fields: ctx, budget, userID
+func (_m *BudgetUC) Set(ctx context.Context, budget domain.Budget, userID int64) error {
+	ret := _m.Called(ctx, budget, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Budget, int64) error); ok {
+		r0 = rf(ctx, budget, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBudgetUC creates a new instance of BudgetUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgetUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BudgetUC {
+	mock := &BudgetUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ContactUC is an autogenerated mock type for the ContactUC type
+type ContactUC struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, contact, userID
+func (_m *ContactUC) Create(ctx context.Context, contact domain.Contact, userID int64) error {
+	ret := _m.Called(ctx, contact, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Contact, int64) error); ok {
+		r0 = rf(ctx, contact, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id, userID
+func (_m *ContactUC) Delete(ctx context.Context, id int64, userID int64) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *ContactUC) GetAll(ctx context.Context, userID int64) ([]domain.Contact, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Contact, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Contact); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, contact, userID
+func (_m *ContactUC) Update(ctx context.Context, contact domain.Contact, userID int64) error {
+	ret := _m.Called(ctx, contact, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Contact, int64) error); ok {
+		r0 = rf(ctx, contact, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewContactUC creates a new instance of ContactUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContactUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContactUC {
+	mock := &ContactUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
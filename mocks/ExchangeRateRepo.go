@@ -0,0 +1,78 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExchangeRateRepo is an autogenerated mock type for the ExchangeRateRepo type
+type ExchangeRateRepo struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, from, to
+func (_m *ExchangeRateRepo) GetAll(ctx context.Context, from string, to string) ([]domain.ExchangeRate, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.ExchangeRate, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.ExchangeRate); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, rates
+func (_m *ExchangeRateRepo) Upsert(ctx context.Context, rates []domain.ExchangeRate) error {
+	ret := _m.Called(ctx, rates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ExchangeRate) error); ok {
+		r0 = rf(ctx, rates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExchangeRateRepo creates a new instance of ExchangeRateRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExchangeRateRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExchangeRateRepo {
+	mock := &ExchangeRateRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExchangeRateUC is an autogenerated mock type for the ExchangeRateUC type
+type ExchangeRateUC struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, from, to
+func (_m *ExchangeRateUC) GetAll(ctx context.Context, from string, to string) ([]domain.ExchangeRate, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.ExchangeRate, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.ExchangeRate); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: ctx, rates
+func (_m *ExchangeRateUC) Import(ctx context.Context, rates []domain.ExchangeRate) error {
+	ret := _m.Called(ctx, rates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ExchangeRate) error); ok {
+		r0 = rf(ctx, rates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExchangeRateUC creates a new instance of ExchangeRateUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExchangeRateUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExchangeRateUC {
+	mock := &ExchangeRateUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
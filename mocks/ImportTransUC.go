@@ -0,0 +1,58 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImportTransUC is an autogenerated mock type for the ImportTransUC type
+type ImportTransUC struct {
+	mock.Mock
+}
+
+// Import provides a mock function with given fields: ctx, rows, dryRun, userID
+func (_m *ImportTransUC) Import(ctx context.Context, rows []domain.ImportTransRow, dryRun bool, userID int64) (domain.ImportTransResult, error) {
+	ret := _m.Called(ctx, rows, dryRun, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 domain.ImportTransResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ImportTransRow, bool, int64) (domain.ImportTransResult, error)); ok {
+		return rf(ctx, rows, dryRun, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ImportTransRow, bool, int64) domain.ImportTransResult); ok {
+		r0 = rf(ctx, rows, dryRun, userID)
+	} else {
+		r0 = ret.Get(0).(domain.ImportTransResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.ImportTransRow, bool, int64) error); ok {
+		r1 = rf(ctx, rows, dryRun, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewImportTransUC creates a new instance of ImportTransUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportTransUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportTransUC {
+	mock := &ImportTransUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
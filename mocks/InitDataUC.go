@@ -33,6 +33,52 @@ func (_m *InitDataUC) Create(ctx context.Context, data domain.InitData, userID i
 	return r0
 }
 
+// Export provides a mock function with given fields: ctx, userID
+func (_m *InitDataUC) Export(ctx context.Context, userID int64) (domain.InitData, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 domain.InitData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.InitData, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.InitData); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.InitData)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: ctx, data, mode, userID
+func (_m *InitDataUC) Import(ctx context.Context, data domain.InitData, mode domain.ImportModeType, userID int64) error {
+	ret := _m.Called(ctx, data, mode, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InitData, domain.ImportModeType, int64) error); ok {
+		r0 = rf(ctx, data, mode, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // List provides a mock function with given fields:
 func (_m *InitDataUC) List() (domain.InitData, error) {
 	ret := _m.Called()
@@ -61,6 +107,36 @@ func (_m *InitDataUC) List() (domain.InitData, error) {
 	return r0, r1
 }
 
+// ListTemplates provides a mock function with given fields:
+func (_m *InitDataUC) ListTemplates() ([]domain.CategTemplate, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplates")
+	}
+
+	var r0 []domain.CategTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]domain.CategTemplate, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []domain.CategTemplate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CategTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewInitDataUC creates a new instance of InitDataUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewInitDataUC(t interface {
@@ -0,0 +1,294 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LedgerRepo is an autogenerated mock type for the LedgerRepo type
+type LedgerRepo struct {
+	mock.Mock
+}
+
+// AcceptInvite provides a mock function with given fields: ctx, id, userID
+func (_m *LedgerRepo) AcceptInvite(ctx context.Context, id int64, userID int64) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, name, userID
+func (_m *LedgerRepo) Create(ctx context.Context, name string, userID int64) (int64, error) {
+	ret := _m.Called(ctx, name, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return rf(ctx, name, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = rf(ctx, name, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, name, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInvite provides a mock function with given fields: ctx, invite
+func (_m *LedgerRepo) CreateInvite(ctx context.Context, invite domain.LedgerInvite) error {
+	ret := _m.Called(ctx, invite)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LedgerInvite) error); ok {
+		r0 = rf(ctx, invite)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInvite provides a mock function with given fields: ctx, id
+func (_m *LedgerRepo) DeleteInvite(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMember provides a mock function with given fields: ctx, id, userID
+func (_m *LedgerRepo) DeleteMember(ctx context.Context, id int64, userID int64) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByIDAndUserID provides a mock function with given fields: ctx, id, userID
+func (_m *LedgerRepo) GetByIDAndUserID(ctx context.Context, id int64, userID int64) (domain.Ledger, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDAndUserID")
+	}
+
+	var r0 domain.Ledger
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.Ledger, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.Ledger); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Ledger)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *LedgerRepo) GetByUserID(ctx context.Context, userID int64) ([]domain.Ledger, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []domain.Ledger
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Ledger, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Ledger); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Ledger)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInviteByID provides a mock function with given fields: ctx, id
+func (_m *LedgerRepo) GetInviteByID(ctx context.Context, id int64) (domain.LedgerInvite, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInviteByID")
+	}
+
+	var r0 domain.LedgerInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.LedgerInvite, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.LedgerInvite); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.LedgerInvite)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitesByEmail provides a mock function with given fields: ctx, email
+func (_m *LedgerRepo) GetInvitesByEmail(ctx context.Context, email string) ([]domain.LedgerInvite, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitesByEmail")
+	}
+
+	var r0 []domain.LedgerInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.LedgerInvite, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.LedgerInvite); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LedgerInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: ctx, id
+func (_m *LedgerRepo) GetMembers(ctx context.Context, id int64) ([]domain.LedgerMember, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembers")
+	}
+
+	var r0 []domain.LedgerMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.LedgerMember, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.LedgerMember); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LedgerMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateMemberRole provides a mock function with given fields: ctx, id, userID, role
+func (_m *LedgerRepo) UpdateMemberRole(ctx context.Context, id int64, userID int64, role domain.LedgerRoleType) error {
+	ret := _m.Called(ctx, id, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, domain.LedgerRoleType) error); ok {
+		r0 = rf(ctx, id, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLedgerRepo creates a new instance of LedgerRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLedgerRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LedgerRepo {
+	mock := &LedgerRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
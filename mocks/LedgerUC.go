@@ -0,0 +1,266 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LedgerUC is an autogenerated mock type for the LedgerUC type
+type LedgerUC struct {
+	mock.Mock
+}
+
+// AcceptInvite provides a mock function with given fields: ctx, id, user
+func (_m *LedgerUC) AcceptInvite(ctx context.Context, id int64, user domain.User) error {
+	ret := _m.Called(ctx, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.User) error); ok {
+		r0 = rf(ctx, id, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, name, userID
+func (_m *LedgerUC) Create(ctx context.Context, name string, userID int64) (domain.Ledger, error) {
+	ret := _m.Called(ctx, name, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.Ledger
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (domain.Ledger, error)); ok {
+		return rf(ctx, name, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) domain.Ledger); ok {
+		r0 = rf(ctx, name, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Ledger)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, name, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeclineInvite provides a mock function with given fields: ctx, id, user
+func (_m *LedgerUC) DeclineInvite(ctx context.Context, id int64, user domain.User) error {
+	ret := _m.Called(ctx, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeclineInvite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.User) error); ok {
+		r0 = rf(ctx, id, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Invite provides a mock function with given fields: ctx, invite, userID
+func (_m *LedgerUC) Invite(ctx context.Context, invite domain.LedgerInvite, userID int64) error {
+	ret := _m.Called(ctx, invite, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LedgerInvite, int64) error); ok {
+		r0 = rf(ctx, invite, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *LedgerUC) List(ctx context.Context, userID int64) ([]domain.Ledger, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Ledger
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Ledger, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Ledger); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Ledger)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInvites provides a mock function with given fields: ctx, email
+func (_m *LedgerUC) ListInvites(ctx context.Context, email string) ([]domain.LedgerInvite, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvites")
+	}
+
+	var r0 []domain.LedgerInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.LedgerInvite, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.LedgerInvite); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LedgerInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMembers provides a mock function with given fields: ctx, id, userID
+func (_m *LedgerUC) ListMembers(ctx context.Context, id int64, userID int64) ([]domain.LedgerMember, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMembers")
+	}
+
+	var r0 []domain.LedgerMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]domain.LedgerMember, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []domain.LedgerMember); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LedgerMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, id, memberID, userID
+func (_m *LedgerUC) RemoveMember(ctx context.Context, id int64, memberID int64, userID int64) error {
+	ret := _m.Called(ctx, id, memberID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, id, memberID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Resolve provides a mock function with given fields: ctx, id, userID, write
+func (_m *LedgerUC) Resolve(ctx context.Context, id int64, userID int64, write bool) (domain.Ledger, error) {
+	ret := _m.Called(ctx, id, userID, write)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 domain.Ledger
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bool) (domain.Ledger, error)); ok {
+		return rf(ctx, id, userID, write)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bool) domain.Ledger); ok {
+		r0 = rf(ctx, id, userID, write)
+	} else {
+		r0 = ret.Get(0).(domain.Ledger)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, bool) error); ok {
+		r1 = rf(ctx, id, userID, write)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateMemberRole provides a mock function with given fields: ctx, id, memberID, role, userID
+func (_m *LedgerUC) UpdateMemberRole(ctx context.Context, id int64, memberID int64, role domain.LedgerRoleType, userID int64) error {
+	ret := _m.Called(ctx, id, memberID, role, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, domain.LedgerRoleType, int64) error); ok {
+		r0 = rf(ctx, id, memberID, role, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLedgerUC creates a new instance of LedgerUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLedgerUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LedgerUC {
+	mock := &LedgerUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
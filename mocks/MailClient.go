@@ -0,0 +1,46 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MailClient is an autogenerated mock type for the MailClient type
+type MailClient struct {
+	mock.Mock
+}
+
+// SendMail provides a mock function with given fields: ctx, from, to, msg
+func (_m *MailClient) SendMail(ctx context.Context, from string, to []string, msg []byte) error {
+	ret := _m.Called(ctx, from, to, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, []byte) error); ok {
+		r0 = rf(ctx, from, to, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMailClient creates a new instance of MailClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMailClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MailClient {
+	mock := &MailClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -69,9 +69,9 @@ func (_m *MainCategRepo) Delete(id int64) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: ctx, userID, transType
-func (_m *MainCategRepo) GetAll(ctx context.Context, userID int64, transType domain.TransactionType) ([]domain.MainCateg, error) {
-	ret := _m.Called(ctx, userID, transType)
+// GetAll provides a mock function with given fields: ctx, userID, transType, includeArchived
+func (_m *MainCategRepo) GetAll(ctx context.Context, userID int64, transType domain.TransactionType, includeArchived bool) ([]domain.MainCateg, error) {
+	ret := _m.Called(ctx, userID, transType, includeArchived)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
@@ -79,19 +79,19 @@ func (_m *MainCategRepo) GetAll(ctx context.Context, userID int64, transType dom
 
 	var r0 []domain.MainCateg
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionType) ([]domain.MainCateg, error)); ok {
-		return rf(ctx, userID, transType)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionType, bool) ([]domain.MainCateg, error)); ok {
+		return rf(ctx, userID, transType, includeArchived)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionType) []domain.MainCateg); ok {
-		r0 = rf(ctx, userID, transType)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionType, bool) []domain.MainCateg); ok {
+		r0 = rf(ctx, userID, transType, includeArchived)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.MainCateg)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.TransactionType) error); ok {
-		r1 = rf(ctx, userID, transType)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.TransactionType, bool) error); ok {
+		r1 = rf(ctx, userID, transType, includeArchived)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,6 +129,78 @@ func (_m *MainCategRepo) GetByID(id int64, userID int64) (*domain.MainCateg, err
 	return r0, r1
 }
 
+// Import provides a mock function with given fields: ctx, input, userID
+func (_m *MainCategRepo) Import(ctx context.Context, input domain.ImportCategInput, userID int64) error {
+	ret := _m.Called(ctx, input, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ImportCategInput, int64) error); ok {
+		r0 = rf(ctx, input, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Merge provides a mock function with given fields: ctx, id, targetID
+func (_m *MainCategRepo) Merge(ctx context.Context, id int64, targetID int64) error {
+	ret := _m.Called(ctx, id, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reorder provides a mock function with given fields: ctx, ids
+func (_m *MainCategRepo) Reorder(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reorder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetArchived provides a mock function with given fields: ctx, id, archived
+func (_m *MainCategRepo) SetArchived(ctx context.Context, id int64, archived bool) error {
+	ret := _m.Called(ctx, id, archived)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetArchived")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, archived)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, categ
 func (_m *MainCategRepo) Update(ctx context.Context, categ domain.MainCateg) error {
 	ret := _m.Called(ctx, categ)
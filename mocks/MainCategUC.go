@@ -33,17 +33,17 @@ func (_m *MainCategUC) Create(ctx context.Context, categ domain.CreateMainCategI
 	return r0
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *MainCategUC) Delete(id int64) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MainCategUC) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,9 +51,9 @@ func (_m *MainCategUC) Delete(id int64) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: ctx, userID, transType
-func (_m *MainCategUC) GetAll(ctx context.Context, userID int64, transType domain.TransactionType) ([]domain.MainCateg, error) {
-	ret := _m.Called(ctx, userID, transType)
+// GetAll provides a mock function with given fields: ctx, userID, transType, includeArchived
+func (_m *MainCategUC) GetAll(ctx context.Context, userID int64, transType domain.TransactionType, includeArchived bool) ([]domain.MainCateg, error) {
+	ret := _m.Called(ctx, userID, transType, includeArchived)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
@@ -61,19 +61,19 @@ func (_m *MainCategUC) GetAll(ctx context.Context, userID int64, transType domai
 
 	var r0 []domain.MainCateg
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionType) ([]domain.MainCateg, error)); ok {
-		return rf(ctx, userID, transType)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionType, bool) ([]domain.MainCateg, error)); ok {
+		return rf(ctx, userID, transType, includeArchived)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionType) []domain.MainCateg); ok {
-		r0 = rf(ctx, userID, transType)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.TransactionType, bool) []domain.MainCateg); ok {
+		r0 = rf(ctx, userID, transType, includeArchived)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.MainCateg)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.TransactionType) error); ok {
-		r1 = rf(ctx, userID, transType)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.TransactionType, bool) error); ok {
+		r1 = rf(ctx, userID, transType, includeArchived)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -81,6 +81,60 @@ func (_m *MainCategUC) GetAll(ctx context.Context, userID int64, transType domai
 	return r0, r1
 }
 
+// Merge provides a mock function with given fields: ctx, id, targetID, userID
+func (_m *MainCategUC) Merge(ctx context.Context, id int64, targetID int64, userID int64) error {
+	ret := _m.Called(ctx, id, targetID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, id, targetID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reorder provides a mock function with given fields: ctx, ids, userID
+func (_m *MainCategUC) Reorder(ctx context.Context, ids []int64, userID int64) error {
+	ret := _m.Called(ctx, ids, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reorder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64) error); ok {
+		r0 = rf(ctx, ids, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetArchived provides a mock function with given fields: ctx, id, archived, userID
+func (_m *MainCategUC) SetArchived(ctx context.Context, id int64, archived bool, userID int64) error {
+	ret := _m.Called(ctx, id, archived, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetArchived")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool, int64) error); ok {
+		r0 = rf(ctx, id, archived, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, categ, userID
 func (_m *MainCategUC) Update(ctx context.Context, categ domain.UpdateMainCategInput, userID int64) error {
 	ret := _m.Called(ctx, categ, userID)
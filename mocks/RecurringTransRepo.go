@@ -0,0 +1,192 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RecurringTransRepo is an autogenerated mock type for the RecurringTransRepo type
+type RecurringTransRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, rt
+func (_m *RecurringTransRepo) Create(ctx context.Context, rt domain.RecurringTrans) error {
+	ret := _m.Called(ctx, rt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RecurringTrans) error); ok {
+		r0 = rf(ctx, rt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *RecurringTransRepo) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *RecurringTransRepo) GetAll(ctx context.Context, userID int64) ([]domain.RecurringTrans, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.RecurringTrans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.RecurringTrans, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.RecurringTrans); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RecurringTrans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIDAndUserID provides a mock function with given fields: ctx, id, userID
+func (_m *RecurringTransRepo) GetByIDAndUserID(ctx context.Context, id int64, userID int64) (domain.RecurringTrans, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDAndUserID")
+	}
+
+	var r0 domain.RecurringTrans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.RecurringTrans, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.RecurringTrans); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Get(0).(domain.RecurringTrans)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDue provides a mock function with given fields: ctx, date
+func (_m *RecurringTransRepo) GetDue(ctx context.Context, date time.Time) ([]domain.RecurringTrans, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDue")
+	}
+
+	var r0 []domain.RecurringTrans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.RecurringTrans, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.RecurringTrans); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RecurringTrans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, rt
+func (_m *RecurringTransRepo) Update(ctx context.Context, rt domain.RecurringTrans) error {
+	ret := _m.Called(ctx, rt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RecurringTrans) error); ok {
+		r0 = rf(ctx, rt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateNextDate provides a mock function with given fields: ctx, id, from, to
+func (_m *RecurringTransRepo) UpdateNextDate(ctx context.Context, id int64, from time.Time, to time.Time) error {
+	ret := _m.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNextDate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRecurringTransRepo creates a new instance of RecurringTransRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecurringTransRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecurringTransRepo {
+	mock := &RecurringTransRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,142 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RuleUC is an autogenerated mock type for the RuleUC type
+type RuleUC struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, rule, userID
+func (_m *RuleUC) Create(ctx context.Context, rule domain.Rule, userID int64) error {
+	ret := _m.Called(ctx, rule, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Rule, int64) error); ok {
+		r0 = rf(ctx, rule, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id, userID
+func (_m *RuleUC) Delete(ctx context.Context, id int64, userID int64) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *RuleUC) GetAll(ctx context.Context, userID int64) ([]domain.Rule, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Rule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Rule, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Rule); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Rule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rerun provides a mock function with given fields: ctx, opt, user
+func (_m *RuleUC) Rerun(ctx context.Context, opt domain.RuleRerunOpt, user domain.User) (domain.RuleRerunResult, error) {
+	ret := _m.Called(ctx, opt, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rerun")
+	}
+
+	var r0 domain.RuleRerunResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RuleRerunOpt, domain.User) (domain.RuleRerunResult, error)); ok {
+		return rf(ctx, opt, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RuleRerunOpt, domain.User) domain.RuleRerunResult); ok {
+		r0 = rf(ctx, opt, user)
+	} else {
+		r0 = ret.Get(0).(domain.RuleRerunResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.RuleRerunOpt, domain.User) error); ok {
+		r1 = rf(ctx, opt, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, rule, userID
+func (_m *RuleUC) Update(ctx context.Context, rule domain.Rule, userID int64) error {
+	ret := _m.Called(ctx, rule, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Rule, int64) error); ok {
+		r0 = rf(ctx, rule, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRuleUC creates a new instance of RuleUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRuleUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RuleUC {
+	mock := &RuleUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,216 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionRepo is an autogenerated mock type for the SessionRepo type
+type SessionRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, session, refreshTokenHash
+func (_m *SessionRepo) Create(ctx context.Context, session domain.Session, refreshTokenHash string) (int64, error) {
+	ret := _m.Called(ctx, session, refreshTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Session, string) (int64, error)); ok {
+		return rf(ctx, session, refreshTokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Session, string) int64); ok {
+		r0 = rf(ctx, session, refreshTokenHash)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Session, string) error); ok {
+		r1 = rf(ctx, session, refreshTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *SessionRepo) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *SessionRepo) DeleteByUserID(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByIDAndUserID provides a mock function with given fields: ctx, id, userID
+func (_m *SessionRepo) GetByIDAndUserID(ctx context.Context, id int64, userID int64) (domain.Session, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDAndUserID")
+	}
+
+	var r0 domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.Session, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.Session); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Session)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByRefreshTokenHash provides a mock function with given fields: ctx, refreshTokenHash
+func (_m *SessionRepo) GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (domain.Session, error) {
+	ret := _m.Called(ctx, refreshTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRefreshTokenHash")
+	}
+
+	var r0 domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Session, error)); ok {
+		return rf(ctx, refreshTokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Session); ok {
+		r0 = rf(ctx, refreshTokenHash)
+	} else {
+		r0 = ret.Get(0).(domain.Session)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *SessionRepo) GetByUserID(ctx context.Context, userID int64) ([]domain.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateRefreshToken provides a mock function with given fields: ctx, id, oldHash, newHash
+func (_m *SessionRepo) RotateRefreshToken(ctx context.Context, id int64, oldHash string, newHash string) error {
+	ret := _m.Called(ctx, id, oldHash, newHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, id, oldHash, newHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLastUsedAt provides a mock function with given fields: ctx, id
+func (_m *SessionRepo) UpdateLastUsedAt(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastUsedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSessionRepo creates a new instance of SessionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionRepo {
+	mock := &SessionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
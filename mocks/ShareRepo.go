@@ -0,0 +1,124 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShareRepo is an autogenerated mock type for the ShareRepo type
+type ShareRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, transID
+func (_m *ShareRepo) Delete(ctx context.Context, transID int64) error {
+	ret := _m.Called(ctx, transID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, transID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *ShareRepo) GetAll(ctx context.Context, userID int64) ([]domain.SharedTransaction, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.SharedTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.SharedTransaction, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.SharedTransaction); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SharedTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTransID provides a mock function with given fields: ctx, transID
+func (_m *ShareRepo) GetByTransID(ctx context.Context, transID int64) (domain.SharedTransaction, error) {
+	ret := _m.Called(ctx, transID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTransID")
+	}
+
+	var r0 domain.SharedTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.SharedTransaction, error)); ok {
+		return rf(ctx, transID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.SharedTransaction); ok {
+		r0 = rf(ctx, transID)
+	} else {
+		r0 = ret.Get(0).(domain.SharedTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, transID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, t
+func (_m *ShareRepo) Upsert(ctx context.Context, t domain.SharedTransaction) error {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SharedTransaction) error); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewShareRepo creates a new instance of ShareRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShareRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShareRepo {
+	mock := &ShareRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,104 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShareUC is an autogenerated mock type for the ShareUC type
+type ShareUC struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, transID, userID
+func (_m *ShareUC) Delete(ctx context.Context, transID int64, userID int64) error {
+	ret := _m.Called(ctx, transID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, transID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, transID, userID
+func (_m *ShareUC) Get(ctx context.Context, transID int64, userID int64) (domain.SharedTransaction, error) {
+	ret := _m.Called(ctx, transID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 domain.SharedTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.SharedTransaction, error)); ok {
+		return rf(ctx, transID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.SharedTransaction); ok {
+		r0 = rf(ctx, transID, userID)
+	} else {
+		r0 = ret.Get(0).(domain.SharedTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, transID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, sharedTrans, userID
+func (_m *ShareUC) Update(ctx context.Context, sharedTrans domain.SharedTransaction, userID int64) (domain.SharedTransaction, error) {
+	ret := _m.Called(ctx, sharedTrans, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 domain.SharedTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SharedTransaction, int64) (domain.SharedTransaction, error)); ok {
+		return rf(ctx, sharedTrans, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SharedTransaction, int64) domain.SharedTransaction); ok {
+		r0 = rf(ctx, sharedTrans, userID)
+	} else {
+		r0 = ret.Get(0).(domain.SharedTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SharedTransaction, int64) error); ok {
+		r1 = rf(ctx, sharedTrans, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewShareUC creates a new instance of ShareUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShareUC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShareUC {
+	mock := &ShareUC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -99,9 +99,39 @@ func (_m *SubCategRepo) GetByID(id int64, userID int64) (*domain.SubCateg, error
 	return r0, r1
 }
 
-// GetByMainCategID provides a mock function with given fields: userID, mainCategID
-func (_m *SubCategRepo) GetByMainCategID(userID int64, mainCategID int64) ([]*domain.SubCateg, error) {
-	ret := _m.Called(userID, mainCategID)
+// GetByIDs provides a mock function with given fields: ctx, ids, userID
+func (_m *SubCategRepo) GetByIDs(ctx context.Context, ids []int64, userID int64) ([]domain.SubCateg, error) {
+	ret := _m.Called(ctx, ids, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []domain.SubCateg
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64) ([]domain.SubCateg, error)); ok {
+		return rf(ctx, ids, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64) []domain.SubCateg); ok {
+		r0 = rf(ctx, ids, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SubCateg)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, int64) error); ok {
+		r1 = rf(ctx, ids, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByMainCategID provides a mock function with given fields: userID, mainCategID, includeArchived
+func (_m *SubCategRepo) GetByMainCategID(userID int64, mainCategID int64, includeArchived bool) ([]*domain.SubCateg, error) {
+	ret := _m.Called(userID, mainCategID, includeArchived)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByMainCategID")
@@ -109,19 +139,19 @@ func (_m *SubCategRepo) GetByMainCategID(userID int64, mainCategID int64) ([]*do
 
 	var r0 []*domain.SubCateg
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64, int64) ([]*domain.SubCateg, error)); ok {
-		return rf(userID, mainCategID)
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) ([]*domain.SubCateg, error)); ok {
+		return rf(userID, mainCategID, includeArchived)
 	}
-	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.SubCateg); ok {
-		r0 = rf(userID, mainCategID)
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) []*domain.SubCateg); ok {
+		r0 = rf(userID, mainCategID, includeArchived)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.SubCateg)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(userID, mainCategID)
+	if rf, ok := ret.Get(1).(func(int64, int64, bool) error); ok {
+		r1 = rf(userID, mainCategID, includeArchived)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,6 +159,60 @@ func (_m *SubCategRepo) GetByMainCategID(userID int64, mainCategID int64) ([]*do
 	return r0, r1
 }
 
+// Move provides a mock function with given fields: ctx, id, mainCategID
+func (_m *SubCategRepo) Move(ctx context.Context, id int64, mainCategID int64) error {
+	ret := _m.Called(ctx, id, mainCategID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Move")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, mainCategID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reorder provides a mock function with given fields: ctx, ids
+func (_m *SubCategRepo) Reorder(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reorder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetArchived provides a mock function with given fields: ctx, id, archived
+func (_m *SubCategRepo) SetArchived(ctx context.Context, id int64, archived bool) error {
+	ret := _m.Called(ctx, id, archived)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetArchived")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, archived)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: categ
 func (_m *SubCategRepo) Update(categ *domain.SubCateg) error {
 	ret := _m.Called(categ)
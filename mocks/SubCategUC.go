@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
 
 	mock "github.com/stretchr/testify/mock"
@@ -49,9 +51,9 @@ func (_m *SubCategUC) Delete(id int64) error {
 	return r0
 }
 
-// GetByMainCategID provides a mock function with given fields: userID, mainCategID
-func (_m *SubCategUC) GetByMainCategID(userID int64, mainCategID int64) ([]*domain.SubCateg, error) {
-	ret := _m.Called(userID, mainCategID)
+// GetByMainCategID provides a mock function with given fields: userID, mainCategID, includeArchived
+func (_m *SubCategUC) GetByMainCategID(userID int64, mainCategID int64, includeArchived bool) ([]*domain.SubCateg, error) {
+	ret := _m.Called(userID, mainCategID, includeArchived)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByMainCategID")
@@ -59,19 +61,19 @@ func (_m *SubCategUC) GetByMainCategID(userID int64, mainCategID int64) ([]*doma
 
 	var r0 []*domain.SubCateg
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64, int64) ([]*domain.SubCateg, error)); ok {
-		return rf(userID, mainCategID)
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) ([]*domain.SubCateg, error)); ok {
+		return rf(userID, mainCategID, includeArchived)
 	}
-	if rf, ok := ret.Get(0).(func(int64, int64) []*domain.SubCateg); ok {
-		r0 = rf(userID, mainCategID)
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) []*domain.SubCateg); ok {
+		r0 = rf(userID, mainCategID, includeArchived)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.SubCateg)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(userID, mainCategID)
+	if rf, ok := ret.Get(1).(func(int64, int64, bool) error); ok {
+		r1 = rf(userID, mainCategID, includeArchived)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -79,6 +81,60 @@ func (_m *SubCategUC) GetByMainCategID(userID int64, mainCategID int64) ([]*doma
 	return r0, r1
 }
 
+// Move provides a mock function with given fields: ctx, id, mainCategID, userID
+func (_m *SubCategUC) Move(ctx context.Context, id int64, mainCategID int64, userID int64) error {
+	ret := _m.Called(ctx, id, mainCategID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Move")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, id, mainCategID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reorder provides a mock function with given fields: ctx, mainCategID, ids, userID
+func (_m *SubCategUC) Reorder(ctx context.Context, mainCategID int64, ids []int64, userID int64) error {
+	ret := _m.Called(ctx, mainCategID, ids, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reorder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64, int64) error); ok {
+		r0 = rf(ctx, mainCategID, ids, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetArchived provides a mock function with given fields: ctx, id, archived, userID
+func (_m *SubCategUC) SetArchived(ctx context.Context, id int64, archived bool, userID int64) error {
+	ret := _m.Called(ctx, id, archived, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetArchived")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool, int64) error); ok {
+		r0 = rf(ctx, id, archived, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: categ, userID
 func (_m *SubCategUC) Update(categ *domain.SubCateg, userID int64) error {
 	ret := _m.Called(categ, userID)
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransRevisionRepo is an autogenerated mock type for the TransRevisionRepo type
+type TransRevisionRepo struct {
+	mock.Mock
+}
+
+// GetByIDAndTransID provides a mock function with given fields: ctx, id, transID
+func (_m *TransRevisionRepo) GetByIDAndTransID(ctx context.Context, id int64, transID int64) (domain.TransRevision, error) {
+	ret := _m.Called(ctx, id, transID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDAndTransID")
+	}
+
+	var r0 domain.TransRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.TransRevision, error)); ok {
+		return rf(ctx, id, transID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.TransRevision); ok {
+		r0 = rf(ctx, id, transID)
+	} else {
+		r0 = ret.Get(0).(domain.TransRevision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, transID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTransID provides a mock function with given fields: ctx, transID
+func (_m *TransRevisionRepo) GetByTransID(ctx context.Context, transID int64) ([]domain.TransRevision, error) {
+	ret := _m.Called(ctx, transID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTransID")
+	}
+
+	var r0 []domain.TransRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.TransRevision, error)); ok {
+		return rf(ctx, transID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.TransRevision); ok {
+		r0 = rf(ctx, transID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, transID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTransRevisionRepo creates a new instance of TransRevisionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransRevisionRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TransRevisionRepo {
+	mock := &TransRevisionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionBulker is an autogenerated mock type for the TransactionBulker type
+type TransactionBulker struct {
+	mock.Mock
+}
+
+// Bulk provides a mock function with given fields: ctx, input, user
+func (_m *TransactionBulker) Bulk(ctx context.Context, input domain.BulkTransInput, user domain.User) (domain.BulkTransResult, error) {
+	ret := _m.Called(ctx, input, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bulk")
+	}
+
+	var r0 domain.BulkTransResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkTransInput, domain.User) (domain.BulkTransResult, error)); ok {
+		return rf(ctx, input, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkTransInput, domain.User) domain.BulkTransResult); ok {
+		r0 = rf(ctx, input, user)
+	} else {
+		r0 = ret.Get(0).(domain.BulkTransResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BulkTransInput, domain.User) error); ok {
+		r1 = rf(ctx, input, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTransactionBulker creates a new instance of TransactionBulker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransactionBulker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TransactionBulker {
+	mock := &TransactionBulker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
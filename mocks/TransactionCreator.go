@@ -0,0 +1,60 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionCreator is an autogenerated mock type for the TransactionCreator type
+type TransactionCreator struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, trans, strict
+func (_m *TransactionCreator) Create(ctx context.Context, trans domain.CreateTransactionInput, strict bool) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, trans, strict)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput, bool) ([]domain.Transaction, error)); ok {
+		return rf(ctx, trans, strict)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput, bool) []domain.Transaction); ok {
+		r0 = rf(ctx, trans, strict)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CreateTransactionInput, bool) error); ok {
+		r1 = rf(ctx, trans, strict)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTransactionCreator creates a new instance of TransactionCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransactionCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TransactionCreator {
+	mock := &TransactionCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -17,17 +17,103 @@ type TransactionRepo struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, trans
-func (_m *TransactionRepo) Create(ctx context.Context, trans domain.CreateTransactionInput) error {
-	ret := _m.Called(ctx, trans)
+// Bulk provides a mock function with given fields: ctx, input, userID, memberID
+func (_m *TransactionRepo) Bulk(ctx context.Context, input domain.BulkTransInput, userID int64, memberID int64) ([]int64, error) {
+	ret := _m.Called(ctx, input, userID, memberID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bulk")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkTransInput, int64, int64) ([]int64, error)); ok {
+		return rf(ctx, input, userID, memberID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkTransInput, int64, int64) []int64); ok {
+		r0 = rf(ctx, input, userID, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BulkTransInput, int64, int64) error); ok {
+		r1 = rf(ctx, input, userID, memberID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUnconverted provides a mock function with given fields: ctx, dateRange, transactionType, opt, userID
+func (_m *TransactionRepo) CountUnconverted(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) (int64, error) {
+	ret := _m.Called(ctx, dateRange, transactionType, opt, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnconverted")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) (int64, error)); ok {
+		return rf(ctx, dateRange, transactionType, opt, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) int64); ok {
+		r0 = rf(ctx, dateRange, transactionType, opt, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, dateRange, transactionType, opt, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, trans, memberID
+func (_m *TransactionRepo) Create(ctx context.Context, trans domain.CreateTransactionInput, memberID int64) (int64, error) {
+	ret := _m.Called(ctx, trans, memberID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput, int64) (int64, error)); ok {
+		return rf(ctx, trans, memberID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput, int64) int64); ok {
+		r0 = rf(ctx, trans, memberID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CreateTransactionInput, int64) error); ok {
+		r1 = rf(ctx, trans, memberID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id, memberID
+func (_m *TransactionRepo) Delete(ctx context.Context, id int64, memberID int64) error {
+	ret := _m.Called(ctx, id, memberID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput) error); ok {
-		r0 = rf(ctx, trans)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, memberID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -35,17 +121,17 @@ func (_m *TransactionRepo) Create(ctx context.Context, trans domain.CreateTransa
 	return r0
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *TransactionRepo) Delete(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// DismissDuplicates provides a mock function with given fields: ctx, ids
+func (_m *TransactionRepo) DismissDuplicates(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for DismissDuplicates")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -81,6 +167,36 @@ func (_m *TransactionRepo) GetAccInfo(ctx context.Context, query domain.GetAccIn
 	return r0, r1
 }
 
+// GetAccountEntries provides a mock function with given fields: ctx, accountID, userID
+func (_m *TransactionRepo) GetAccountEntries(ctx context.Context, accountID int64, userID int64) ([]domain.AccountEntry, error) {
+	ret := _m.Called(ctx, accountID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountEntries")
+	}
+
+	var r0 []domain.AccountEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]domain.AccountEntry, error)); ok {
+		return rf(ctx, accountID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []domain.AccountEntry); ok {
+		r0 = rf(ctx, accountID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AccountEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, accountID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: ctx, query, userID
 func (_m *TransactionRepo) GetAll(ctx context.Context, query domain.GetTransOpt, userID int64) ([]domain.Transaction, domain.DecodedNextKeys, error) {
 	ret := _m.Called(ctx, query, userID)
@@ -148,9 +264,67 @@ func (_m *TransactionRepo) GetByIDAndUserID(ctx context.Context, id int64, userI
 	return r0, r1
 }
 
-// GetDailyBarChartData provides a mock function with given fields: ctx, dateRange, transactionType, mainCategIDs, userID
-func (_m *TransactionRepo) GetDailyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, userID int64) (domain.DateToChartData, error) {
-	ret := _m.Called(ctx, dateRange, transactionType, mainCategIDs, userID)
+// GetByIDAndUserIDIncludeTrash provides a mock function with given fields: ctx, id, userID
+func (_m *TransactionRepo) GetByIDAndUserIDIncludeTrash(ctx context.Context, id int64, userID int64) (domain.Transaction, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDAndUserIDIncludeTrash")
+	}
+
+	var r0 domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (domain.Transaction, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) domain.Transaction); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIDsAndUserID provides a mock function with given fields: ctx, ids, userID
+func (_m *TransactionRepo) GetByIDsAndUserID(ctx context.Context, ids []int64, userID int64) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, ids, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDsAndUserID")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64) ([]domain.Transaction, error)); ok {
+		return rf(ctx, ids, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64) []domain.Transaction); ok {
+		r0 = rf(ctx, ids, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, int64) error); ok {
+		r1 = rf(ctx, ids, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDailyBarChartData provides a mock function with given fields: ctx, dateRange, transactionType, mainCategIDs, opt, userID
+func (_m *TransactionRepo) GetDailyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error) {
+	ret := _m.Called(ctx, dateRange, transactionType, mainCategIDs, opt, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDailyBarChartData")
@@ -158,19 +332,19 @@ func (_m *TransactionRepo) GetDailyBarChartData(ctx context.Context, dateRange d
 
 	var r0 domain.DateToChartData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, int64) (domain.DateToChartData, error)); ok {
-		return rf(ctx, dateRange, transactionType, mainCategIDs, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, *domain.GetTransOpt, int64) (domain.DateToChartData, error)); ok {
+		return rf(ctx, dateRange, transactionType, mainCategIDs, opt, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, int64) domain.DateToChartData); ok {
-		r0 = rf(ctx, dateRange, transactionType, mainCategIDs, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, *domain.GetTransOpt, int64) domain.DateToChartData); ok {
+		r0 = rf(ctx, dateRange, transactionType, mainCategIDs, opt, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(domain.DateToChartData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, int64) error); ok {
-		r1 = rf(ctx, dateRange, transactionType, mainCategIDs, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, *domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, dateRange, transactionType, mainCategIDs, opt, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -178,9 +352,9 @@ func (_m *TransactionRepo) GetDailyBarChartData(ctx context.Context, dateRange d
 	return r0, r1
 }
 
-// GetDailyLineChartData provides a mock function with given fields: ctx, dateRange, userID
-func (_m *TransactionRepo) GetDailyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, userID int64) (domain.DateToChartData, error) {
-	ret := _m.Called(ctx, dateRange, userID)
+// GetDailyLineChartData provides a mock function with given fields: ctx, dateRange, opt, userID
+func (_m *TransactionRepo) GetDailyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error) {
+	ret := _m.Called(ctx, dateRange, opt, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDailyLineChartData")
@@ -188,19 +362,79 @@ func (_m *TransactionRepo) GetDailyLineChartData(ctx context.Context, dateRange
 
 	var r0 domain.DateToChartData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, int64) (domain.DateToChartData, error)); ok {
-		return rf(ctx, dateRange, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, *domain.GetTransOpt, int64) (domain.DateToChartData, error)); ok {
+		return rf(ctx, dateRange, opt, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, int64) domain.DateToChartData); ok {
-		r0 = rf(ctx, dateRange, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, *domain.GetTransOpt, int64) domain.DateToChartData); ok {
+		r0 = rf(ctx, dateRange, opt, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(domain.DateToChartData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, int64) error); ok {
-		r1 = rf(ctx, dateRange, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, *domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, dateRange, opt, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicateCandidates provides a mock function with given fields: ctx, trans, days
+func (_m *TransactionRepo) GetDuplicateCandidates(ctx context.Context, trans domain.CreateTransactionInput, days int) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, trans, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicateCandidates")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput, int) ([]domain.Transaction, error)); ok {
+		return rf(ctx, trans, days)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput, int) []domain.Transaction); ok {
+		r0 = rf(ctx, trans, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CreateTransactionInput, int) error); ok {
+		r1 = rf(ctx, trans, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicatePairs provides a mock function with given fields: ctx, opt, userID
+func (_m *TransactionRepo) GetDuplicatePairs(ctx context.Context, opt domain.GetDuplicatesOpt, userID int64) ([]domain.DuplicatePair, error) {
+	ret := _m.Called(ctx, opt, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicatePairs")
+	}
+
+	var r0 []domain.DuplicatePair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetDuplicatesOpt, int64) ([]domain.DuplicatePair, error)); ok {
+		return rf(ctx, opt, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetDuplicatesOpt, int64) []domain.DuplicatePair); ok {
+		r0 = rf(ctx, opt, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DuplicatePair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GetDuplicatesOpt, int64) error); ok {
+		r1 = rf(ctx, opt, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -238,9 +472,9 @@ func (_m *TransactionRepo) GetMonthlyAggregatedData(ctx context.Context, date ti
 	return r0, r1
 }
 
-// GetMonthlyBarChartData provides a mock function with given fields: ctx, dateRange, transactionType, mainCategIDs, userID
-func (_m *TransactionRepo) GetMonthlyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, userID int64) (domain.DateToChartData, error) {
-	ret := _m.Called(ctx, dateRange, transactionType, mainCategIDs, userID)
+// GetMonthlyBarChartData provides a mock function with given fields: ctx, dateRange, transactionType, mainCategIDs, opt, userID
+func (_m *TransactionRepo) GetMonthlyBarChartData(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, mainCategIDs []int64, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error) {
+	ret := _m.Called(ctx, dateRange, transactionType, mainCategIDs, opt, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMonthlyBarChartData")
@@ -248,19 +482,19 @@ func (_m *TransactionRepo) GetMonthlyBarChartData(ctx context.Context, dateRange
 
 	var r0 domain.DateToChartData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, int64) (domain.DateToChartData, error)); ok {
-		return rf(ctx, dateRange, transactionType, mainCategIDs, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, *domain.GetTransOpt, int64) (domain.DateToChartData, error)); ok {
+		return rf(ctx, dateRange, transactionType, mainCategIDs, opt, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, int64) domain.DateToChartData); ok {
-		r0 = rf(ctx, dateRange, transactionType, mainCategIDs, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, *domain.GetTransOpt, int64) domain.DateToChartData); ok {
+		r0 = rf(ctx, dateRange, transactionType, mainCategIDs, opt, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(domain.DateToChartData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, int64) error); ok {
-		r1 = rf(ctx, dateRange, transactionType, mainCategIDs, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, []int64, *domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, dateRange, transactionType, mainCategIDs, opt, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -298,9 +532,9 @@ func (_m *TransactionRepo) GetMonthlyData(ctx context.Context, dateRange domain.
 	return r0, r1
 }
 
-// GetMonthlyLineChartData provides a mock function with given fields: ctx, dateRange, userID
-func (_m *TransactionRepo) GetMonthlyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, userID int64) (domain.DateToChartData, error) {
-	ret := _m.Called(ctx, dateRange, userID)
+// GetMonthlyLineChartData provides a mock function with given fields: ctx, dateRange, opt, userID
+func (_m *TransactionRepo) GetMonthlyLineChartData(ctx context.Context, dateRange domain.ChartDateRange, opt *domain.GetTransOpt, userID int64) (domain.DateToChartData, error) {
+	ret := _m.Called(ctx, dateRange, opt, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMonthlyLineChartData")
@@ -308,19 +542,19 @@ func (_m *TransactionRepo) GetMonthlyLineChartData(ctx context.Context, dateRang
 
 	var r0 domain.DateToChartData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, int64) (domain.DateToChartData, error)); ok {
-		return rf(ctx, dateRange, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, *domain.GetTransOpt, int64) (domain.DateToChartData, error)); ok {
+		return rf(ctx, dateRange, opt, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, int64) domain.DateToChartData); ok {
-		r0 = rf(ctx, dateRange, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, *domain.GetTransOpt, int64) domain.DateToChartData); ok {
+		r0 = rf(ctx, dateRange, opt, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(domain.DateToChartData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, int64) error); ok {
-		r1 = rf(ctx, dateRange, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, *domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, dateRange, opt, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -328,9 +562,9 @@ func (_m *TransactionRepo) GetMonthlyLineChartData(ctx context.Context, dateRang
 	return r0, r1
 }
 
-// GetPieChartData provides a mock function with given fields: ctx, dataRange, transactionType, userID
-func (_m *TransactionRepo) GetPieChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, userID int64) (domain.ChartData, error) {
-	ret := _m.Called(ctx, dataRange, transactionType, userID)
+// GetPieChartData provides a mock function with given fields: ctx, dataRange, transactionType, opt, userID
+func (_m *TransactionRepo) GetPieChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) (domain.ChartData, error) {
+	ret := _m.Called(ctx, dataRange, transactionType, opt, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPieChartData")
@@ -338,17 +572,105 @@ func (_m *TransactionRepo) GetPieChartData(ctx context.Context, dataRange domain
 
 	var r0 domain.ChartData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64) (domain.ChartData, error)); ok {
-		return rf(ctx, dataRange, transactionType, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) (domain.ChartData, error)); ok {
+		return rf(ctx, dataRange, transactionType, opt, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64) domain.ChartData); ok {
-		r0 = rf(ctx, dataRange, transactionType, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) domain.ChartData); ok {
+		r0 = rf(ctx, dataRange, transactionType, opt, userID)
 	} else {
 		r0 = ret.Get(0).(domain.ChartData)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64) error); ok {
-		r1 = rf(ctx, dataRange, transactionType, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, dataRange, transactionType, opt, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSumByMainCateg provides a mock function with given fields: ctx, dateRange, transactionType, opt, userID
+func (_m *TransactionRepo) GetSumByMainCateg(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) ([]domain.MainCategSum, error) {
+	ret := _m.Called(ctx, dateRange, transactionType, opt, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSumByMainCateg")
+	}
+
+	var r0 []domain.MainCategSum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) ([]domain.MainCategSum, error)); ok {
+		return rf(ctx, dateRange, transactionType, opt, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) []domain.MainCategSum); ok {
+		r0 = rf(ctx, dateRange, transactionType, opt, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.MainCategSum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, dateRange, transactionType, opt, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSumByTag provides a mock function with given fields: ctx, dateRange, transactionType, opt, userID
+func (_m *TransactionRepo) GetSumByTag(ctx context.Context, dateRange domain.ChartDateRange, transactionType domain.TransactionType, opt *domain.GetTransOpt, userID int64) ([]domain.TagSum, error) {
+	ret := _m.Called(ctx, dateRange, transactionType, opt, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSumByTag")
+	}
+
+	var r0 []domain.TagSum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) ([]domain.TagSum, error)); ok {
+		return rf(ctx, dateRange, transactionType, opt, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) []domain.TagSum); ok {
+		r0 = rf(ctx, dateRange, transactionType, opt, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TagSum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, *domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, dateRange, transactionType, opt, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotal provides a mock function with given fields: ctx, query, userID
+func (_m *TransactionRepo) GetTotal(ctx context.Context, query domain.GetTransOpt, userID int64) (domain.TransTotal, error) {
+	ret := _m.Called(ctx, query, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotal")
+	}
+
+	var r0 domain.TransTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetTransOpt, int64) (domain.TransTotal, error)); ok {
+		return rf(ctx, query, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetTransOpt, int64) domain.TransTotal); ok {
+		r0 = rf(ctx, query, userID)
+	} else {
+		r0 = ret.Get(0).(domain.TransTotal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GetTransOpt, int64) error); ok {
+		r1 = rf(ctx, query, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -356,17 +678,65 @@ func (_m *TransactionRepo) GetPieChartData(ctx context.Context, dataRange domain
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, trans
-func (_m *TransactionRepo) Update(ctx context.Context, trans domain.UpdateTransactionInput) error {
-	ret := _m.Called(ctx, trans)
+// Import provides a mock function with given fields: ctx, input, userID
+func (_m *TransactionRepo) Import(ctx context.Context, input domain.ImportTransInput, userID int64) (map[int64]int64, error) {
+	ret := _m.Called(ctx, input, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 map[int64]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ImportTransInput, int64) (map[int64]int64, error)); ok {
+		return rf(ctx, input, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ImportTransInput, int64) map[int64]int64); ok {
+		r0 = rf(ctx, input, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ImportTransInput, int64) error); ok {
+		r1 = rf(ctx, input, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamAll provides a mock function with given fields: ctx, query, userID, fn
+func (_m *TransactionRepo) StreamAll(ctx context.Context, query domain.GetTransOpt, userID int64, fn func(domain.Transaction) error) error {
+	ret := _m.Called(ctx, query, userID, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetTransOpt, int64, func(domain.Transaction) error) error); ok {
+		r0 = rf(ctx, query, userID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, trans, action, memberID
+func (_m *TransactionRepo) Update(ctx context.Context, trans domain.UpdateTransactionInput, action domain.RevisionActionType, memberID int64) error {
+	ret := _m.Called(ctx, trans, action, memberID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.UpdateTransactionInput) error); ok {
-		r0 = rf(ctx, trans)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UpdateTransactionInput, domain.RevisionActionType, int64) error); ok {
+		r0 = rf(ctx, trans, action, memberID)
 	} else {
 		r0 = ret.Error(0)
 	}
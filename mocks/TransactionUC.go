@@ -15,17 +15,75 @@ type TransactionUC struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, trans
-func (_m *TransactionUC) Create(ctx context.Context, trans domain.CreateTransactionInput) error {
-	ret := _m.Called(ctx, trans)
+// Bulk provides a mock function with given fields: ctx, input, user
+func (_m *TransactionUC) Bulk(ctx context.Context, input domain.BulkTransInput, user domain.User) (domain.BulkTransResult, error) {
+	ret := _m.Called(ctx, input, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bulk")
+	}
+
+	var r0 domain.BulkTransResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkTransInput, domain.User) (domain.BulkTransResult, error)); ok {
+		return rf(ctx, input, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkTransInput, domain.User) domain.BulkTransResult); ok {
+		r0 = rf(ctx, input, user)
+	} else {
+		r0 = ret.Get(0).(domain.BulkTransResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BulkTransInput, domain.User) error); ok {
+		r1 = rf(ctx, input, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, trans, strict
+func (_m *TransactionUC) Create(ctx context.Context, trans domain.CreateTransactionInput, strict bool) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, trans, strict)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput, bool) ([]domain.Transaction, error)); ok {
+		return rf(ctx, trans, strict)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput, bool) []domain.Transaction); ok {
+		r0 = rf(ctx, trans, strict)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CreateTransactionInput, bool) error); ok {
+		r1 = rf(ctx, trans, strict)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAttachment provides a mock function with given fields: ctx, id, fileName, objectKey, user
+func (_m *TransactionUC) CreateAttachment(ctx context.Context, id int64, fileName string, objectKey string, user domain.User) error {
+	ret := _m.Called(ctx, id, fileName, objectKey, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAttachment")
+	}
+
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateTransactionInput) error); ok {
-		r0 = rf(ctx, trans)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, domain.User) error); ok {
+		r0 = rf(ctx, id, fileName, objectKey, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,6 +109,60 @@ func (_m *TransactionUC) Delete(ctx context.Context, id int64, user domain.User)
 	return r0
 }
 
+// DeleteAttachment provides a mock function with given fields: ctx, id, attachmentID, user
+func (_m *TransactionUC) DeleteAttachment(ctx context.Context, id int64, attachmentID int64, user domain.User) error {
+	ret := _m.Called(ctx, id, attachmentID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, domain.User) error); ok {
+		r0 = rf(ctx, id, attachmentID, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DismissDuplicates provides a mock function with given fields: ctx, ids, user
+func (_m *TransactionUC) DismissDuplicates(ctx context.Context, ids []int64, user domain.User) error {
+	ret := _m.Called(ctx, ids, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DismissDuplicates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, domain.User) error); ok {
+		r0 = rf(ctx, ids, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: ctx, opt, user, fn
+func (_m *TransactionUC) Export(ctx context.Context, opt domain.GetTransOpt, user domain.User, fn func(domain.Transaction) error) error {
+	ret := _m.Called(ctx, opt, user, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetTransOpt, domain.User, func(domain.Transaction) error) error); ok {
+		r0 = rf(ctx, opt, user, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAccInfo provides a mock function with given fields: ctx, user, query, timeRange
 func (_m *TransactionUC) GetAccInfo(ctx context.Context, user domain.User, query domain.GetAccInfoQuery, timeRange domain.TimeRangeType) (domain.AccInfo, error) {
 	ret := _m.Called(ctx, user, query, timeRange)
@@ -116,9 +228,37 @@ func (_m *TransactionUC) GetAll(ctx context.Context, opt domain.GetTransOpt, use
 	return r0, r1, r2
 }
 
-// GetBarChartData provides a mock function with given fields: ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, user
-func (_m *TransactionUC) GetBarChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, transactionType domain.TransactionType, mainCategIDs []int64, user domain.User) (domain.ChartData, error) {
-	ret := _m.Called(ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, user)
+// GetAttachmentPutURL provides a mock function with given fields: ctx, id, fileName, user
+func (_m *TransactionUC) GetAttachmentPutURL(ctx context.Context, id int64, fileName string, user domain.User) (domain.AttachmentPutURL, error) {
+	ret := _m.Called(ctx, id, fileName, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachmentPutURL")
+	}
+
+	var r0 domain.AttachmentPutURL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, domain.User) (domain.AttachmentPutURL, error)); ok {
+		return rf(ctx, id, fileName, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, domain.User) domain.AttachmentPutURL); ok {
+		r0 = rf(ctx, id, fileName, user)
+	} else {
+		r0 = ret.Get(0).(domain.AttachmentPutURL)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, domain.User) error); ok {
+		r1 = rf(ctx, id, fileName, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBarChartData provides a mock function with given fields: ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, viewID, user
+func (_m *TransactionUC) GetBarChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, transactionType domain.TransactionType, mainCategIDs []int64, viewID int64, user domain.User) (domain.ChartData, error) {
+	ret := _m.Called(ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, viewID, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBarChartData")
@@ -126,17 +266,77 @@ func (_m *TransactionUC) GetBarChartData(ctx context.Context, chartDateRange dom
 
 	var r0 domain.ChartData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.TransactionType, []int64, domain.User) (domain.ChartData, error)); ok {
-		return rf(ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.TransactionType, []int64, int64, domain.User) (domain.ChartData, error)); ok {
+		return rf(ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, viewID, user)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.TransactionType, []int64, domain.User) domain.ChartData); ok {
-		r0 = rf(ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.TransactionType, []int64, int64, domain.User) domain.ChartData); ok {
+		r0 = rf(ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, viewID, user)
 	} else {
 		r0 = ret.Get(0).(domain.ChartData)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.TransactionType, []int64, domain.User) error); ok {
-		r1 = rf(ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, user)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.TransactionType, []int64, int64, domain.User) error); ok {
+		r1 = rf(ctx, chartDateRange, timeRangeType, transactionType, mainCategIDs, viewID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicates provides a mock function with given fields: ctx, opt, user
+func (_m *TransactionUC) GetDuplicates(ctx context.Context, opt domain.GetDuplicatesOpt, user domain.User) ([]domain.DuplicateGroup, error) {
+	ret := _m.Called(ctx, opt, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicates")
+	}
+
+	var r0 []domain.DuplicateGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetDuplicatesOpt, domain.User) ([]domain.DuplicateGroup, error)); ok {
+		return rf(ctx, opt, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetDuplicatesOpt, domain.User) []domain.DuplicateGroup); ok {
+		r0 = rf(ctx, opt, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DuplicateGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GetDuplicatesOpt, domain.User) error); ok {
+		r1 = rf(ctx, opt, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: ctx, id, user
+func (_m *TransactionUC) GetHistory(ctx context.Context, id int64, user domain.User) ([]domain.TransRevision, error) {
+	ret := _m.Called(ctx, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []domain.TransRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.User) ([]domain.TransRevision, error)); ok {
+		return rf(ctx, id, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.User) []domain.TransRevision); ok {
+		r0 = rf(ctx, id, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.User) error); ok {
+		r1 = rf(ctx, id, user)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -144,9 +344,9 @@ func (_m *TransactionUC) GetBarChartData(ctx context.Context, chartDateRange dom
 	return r0, r1
 }
 
-// GetLineChartData provides a mock function with given fields: ctx, chartDateRange, timeRangeType, user
-func (_m *TransactionUC) GetLineChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, user domain.User) (domain.ChartData, error) {
-	ret := _m.Called(ctx, chartDateRange, timeRangeType, user)
+// GetLineChartData provides a mock function with given fields: ctx, chartDateRange, timeRangeType, viewID, user
+func (_m *TransactionUC) GetLineChartData(ctx context.Context, chartDateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType, viewID int64, user domain.User) (domain.ChartData, error) {
+	ret := _m.Called(ctx, chartDateRange, timeRangeType, viewID, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLineChartData")
@@ -154,17 +354,17 @@ func (_m *TransactionUC) GetLineChartData(ctx context.Context, chartDateRange do
 
 	var r0 domain.ChartData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.User) (domain.ChartData, error)); ok {
-		return rf(ctx, chartDateRange, timeRangeType, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, int64, domain.User) (domain.ChartData, error)); ok {
+		return rf(ctx, chartDateRange, timeRangeType, viewID, user)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.User) domain.ChartData); ok {
-		r0 = rf(ctx, chartDateRange, timeRangeType, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, int64, domain.User) domain.ChartData); ok {
+		r0 = rf(ctx, chartDateRange, timeRangeType, viewID, user)
 	} else {
 		r0 = ret.Get(0).(domain.ChartData)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, domain.User) error); ok {
-		r1 = rf(ctx, chartDateRange, timeRangeType, user)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TimeRangeType, int64, domain.User) error); ok {
+		r1 = rf(ctx, chartDateRange, timeRangeType, viewID, user)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -202,9 +402,9 @@ func (_m *TransactionUC) GetMonthlyData(ctx context.Context, dateRange domain.Ge
 	return r0, r1
 }
 
-// GetPieChartData provides a mock function with given fields: ctx, dataRange, transactionType, user
-func (_m *TransactionUC) GetPieChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, user domain.User) (domain.ChartData, error) {
-	ret := _m.Called(ctx, dataRange, transactionType, user)
+// GetPieChartData provides a mock function with given fields: ctx, dataRange, transactionType, viewID, user
+func (_m *TransactionUC) GetPieChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, viewID int64, user domain.User) (domain.ChartData, error) {
+	ret := _m.Called(ctx, dataRange, transactionType, viewID, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPieChartData")
@@ -212,17 +412,73 @@ func (_m *TransactionUC) GetPieChartData(ctx context.Context, dataRange domain.C
 
 	var r0 domain.ChartData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, domain.User) (domain.ChartData, error)); ok {
-		return rf(ctx, dataRange, transactionType, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64, domain.User) (domain.ChartData, error)); ok {
+		return rf(ctx, dataRange, transactionType, viewID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64, domain.User) domain.ChartData); ok {
+		r0 = rf(ctx, dataRange, transactionType, viewID, user)
+	} else {
+		r0 = ret.Get(0).(domain.ChartData)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64, domain.User) error); ok {
+		r1 = rf(ctx, dataRange, transactionType, viewID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTagChartData provides a mock function with given fields: ctx, dataRange, transactionType, viewID, user
+func (_m *TransactionUC) GetTagChartData(ctx context.Context, dataRange domain.ChartDateRange, transactionType domain.TransactionType, viewID int64, user domain.User) (domain.ChartData, error) {
+	ret := _m.Called(ctx, dataRange, transactionType, viewID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagChartData")
+	}
+
+	var r0 domain.ChartData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64, domain.User) (domain.ChartData, error)); ok {
+		return rf(ctx, dataRange, transactionType, viewID, user)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, domain.User) domain.ChartData); ok {
-		r0 = rf(ctx, dataRange, transactionType, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64, domain.User) domain.ChartData); ok {
+		r0 = rf(ctx, dataRange, transactionType, viewID, user)
 	} else {
 		r0 = ret.Get(0).(domain.ChartData)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, domain.User) error); ok {
-		r1 = rf(ctx, dataRange, transactionType, user)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ChartDateRange, domain.TransactionType, int64, domain.User) error); ok {
+		r1 = rf(ctx, dataRange, transactionType, viewID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotal provides a mock function with given fields: ctx, opt, user
+func (_m *TransactionUC) GetTotal(ctx context.Context, opt domain.GetTransOpt, user domain.User) (domain.TransTotal, error) {
+	ret := _m.Called(ctx, opt, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotal")
+	}
+
+	var r0 domain.TransTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetTransOpt, domain.User) (domain.TransTotal, error)); ok {
+		return rf(ctx, opt, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GetTransOpt, domain.User) domain.TransTotal); ok {
+		r0 = rf(ctx, opt, user)
+	} else {
+		r0 = ret.Get(0).(domain.TransTotal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GetTransOpt, domain.User) error); ok {
+		r1 = rf(ctx, opt, user)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -230,6 +486,42 @@ func (_m *TransactionUC) GetPieChartData(ctx context.Context, dataRange domain.C
 	return r0, r1
 }
 
+// MergeDuplicates provides a mock function with given fields: ctx, keepID, ids, user
+func (_m *TransactionUC) MergeDuplicates(ctx context.Context, keepID int64, ids []int64, user domain.User) error {
+	ret := _m.Called(ctx, keepID, ids, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeDuplicates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64, domain.User) error); ok {
+		r0 = rf(ctx, keepID, ids, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Revert provides a mock function with given fields: ctx, id, revisionID, user
+func (_m *TransactionUC) Revert(ctx context.Context, id int64, revisionID int64, user domain.User) error {
+	ret := _m.Called(ctx, id, revisionID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, domain.User) error); ok {
+		r0 = rf(ctx, id, revisionID, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, trans, user
 func (_m *TransactionUC) Update(ctx context.Context, trans domain.UpdateTransactionInput, user domain.User) error {
 	ret := _m.Called(ctx, trans, user)
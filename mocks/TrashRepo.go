@@ -0,0 +1,98 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TrashRepo is an autogenerated mock type for the TrashRepo type
+type TrashRepo struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *TrashRepo) GetAll(ctx context.Context, userID int64) ([]domain.TrashItem, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.TrashItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.TrashItem, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.TrashItem); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrashItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, before
+func (_m *TrashRepo) Purge(ctx context.Context, before time.Time) error {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: ctx, kind, id, userID
+func (_m *TrashRepo) Restore(ctx context.Context, kind domain.TrashKind, id int64, userID int64) error {
+	ret := _m.Called(ctx, kind, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TrashKind, int64, int64) error); ok {
+		r0 = rf(ctx, kind, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTrashRepo creates a new instance of TrashRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTrashRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TrashRepo {
+	mock := &TrashRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
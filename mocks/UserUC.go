@@ -43,9 +43,39 @@ func (_m *UserUC) GetInfo(userID int64) (domain.User, error) {
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, user
-func (_m *UserUC) Login(ctx context.Context, user domain.User) (domain.Token, error) {
-	ret := _m.Called(ctx, user)
+// ListSessions provides a mock function with given fields: ctx, userID
+func (_m *UserUC) ListSessions(ctx context.Context, userID int64) ([]domain.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, user, session
+func (_m *UserUC) Login(ctx context.Context, user domain.User, session domain.Session) (domain.Token, error) {
+	ret := _m.Called(ctx, user, session)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -53,17 +83,17 @@ func (_m *UserUC) Login(ctx context.Context, user domain.User) (domain.Token, er
 
 	var r0 domain.Token
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.User) (domain.Token, error)); ok {
-		return rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User, domain.Session) (domain.Token, error)); ok {
+		return rf(ctx, user, session)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.User) domain.Token); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User, domain.Session) domain.Token); ok {
+		r0 = rf(ctx, user, session)
 	} else {
 		r0 = ret.Get(0).(domain.Token)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.User) error); ok {
-		r1 = rf(ctx, user)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.User, domain.Session) error); ok {
+		r1 = rf(ctx, user, session)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -71,9 +101,99 @@ func (_m *UserUC) Login(ctx context.Context, user domain.User) (domain.Token, er
 	return r0, r1
 }
 
-// Signup provides a mock function with given fields: ctx, user
-func (_m *UserUC) Signup(ctx context.Context, user domain.User) (domain.Token, error) {
-	ret := _m.Called(ctx, user)
+// RequestEmailVerification provides a mock function with given fields: ctx, userID
+func (_m *UserUC) RequestEmailVerification(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestEmailVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestPasswordReset provides a mock function with given fields: ctx, email
+func (_m *UserUC) RequestPasswordReset(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, token, password
+func (_m *UserUC) ResetPassword(ctx context.Context, token string, password string) error {
+	ret := _m.Called(ctx, token, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAllSessions provides a mock function with given fields: ctx, userID
+func (_m *UserUC) RevokeAllSessions(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, id, userID
+func (_m *UserUC) RevokeSession(ctx context.Context, id int64, userID int64) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Signup provides a mock function with given fields: ctx, user, session
+func (_m *UserUC) Signup(ctx context.Context, user domain.User, session domain.Session) (domain.Token, error) {
+	ret := _m.Called(ctx, user, session)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Signup")
@@ -81,17 +201,17 @@ func (_m *UserUC) Signup(ctx context.Context, user domain.User) (domain.Token, e
 
 	var r0 domain.Token
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.User) (domain.Token, error)); ok {
-		return rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User, domain.Session) (domain.Token, error)); ok {
+		return rf(ctx, user, session)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.User) domain.Token); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User, domain.Session) domain.Token); ok {
+		r0 = rf(ctx, user, session)
 	} else {
 		r0 = ret.Get(0).(domain.Token)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.User) error); ok {
-		r1 = rf(ctx, user)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.User, domain.Session) error); ok {
+		r1 = rf(ctx, user, session)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -127,6 +247,60 @@ func (_m *UserUC) Token(ctx context.Context, refreshToken string) (domain.Token,
 	return r0, r1
 }
 
+// UpdateBaseCurrency provides a mock function with given fields: ctx, userID, currency
+func (_m *UserUC) UpdateBaseCurrency(ctx context.Context, userID int64, currency string) error {
+	ret := _m.Called(ctx, userID, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBaseCurrency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateSession provides a mock function with given fields: ctx, id, userID
+func (_m *UserUC) ValidateSession(ctx context.Context, id int64, userID int64) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *UserUC) VerifyEmail(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserUC creates a new instance of UserUC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserUC(t interface {
@@ -10,7 +10,14 @@ import (
 
 type contextKey string
 
-const contextKeyUser = contextKey("user")
+const (
+	contextKeyUser    = contextKey("user")
+	contextKeyLedger  = contextKey("ledger")
+	contextKeySession = contextKey("session")
+	contextKeyScope   = contextKey("scope")
+
+	contextKeyDeferRoleCheck = contextKey("deferRoleCheck")
+)
 
 // SetUser stores the user in the request context
 func SetUser(r *http.Request, user *domain.User) *http.Request {
@@ -27,3 +34,83 @@ func GetUser(r *http.Request) *domain.User {
 
 	return user
 }
+
+// SetSessionID stores the id of the session which the request is authenticated by in the request context
+func SetSessionID(r *http.Request, id int64) *http.Request {
+	ctx := context.WithValue(r.Context(), contextKeySession, id)
+	return r.WithContext(ctx)
+}
+
+// GetSessionID retrieves the id of the session from the request context, it's 0 if the session is unknown
+func GetSessionID(r *http.Request) int64 {
+	id, _ := r.Context().Value(contextKeySession).(int64)
+	return id
+}
+
+// SetScope stores the scope which the API key needs to access the route in the request context
+func SetScope(r *http.Request, scope string) *http.Request {
+	ctx := context.WithValue(r.Context(), contextKeyScope, scope)
+	return r.WithContext(ctx)
+}
+
+// GetScope retrieves the scope of the route from the request context, it's empty if the route doesn't accept API keys
+func GetScope(r *http.Request) string {
+	scope, _ := r.Context().Value(contextKeyScope).(string)
+	return scope
+}
+
+// SetLedger stores the ledger selected by the request in the request context
+func SetLedger(r *http.Request, ledger *domain.Ledger) *http.Request {
+	ctx := context.WithValue(r.Context(), contextKeyLedger, ledger)
+	return r.WithContext(ctx)
+}
+
+// GetMemberID retrieves the id of the user making the request, who is a member of the selected ledger.
+// Outside of a request, e.g. in the cron jobs, it's the given id of the user owning the data
+func GetMemberID(ctx context.Context, ownerID int64) int64 {
+	user, ok := ctx.Value(contextKeyUser).(*domain.User)
+	if !ok {
+		return ownerID
+	}
+
+	return user.ID
+}
+
+// GetLedgerRole retrieves the role of the user in the ledger selected by the request.
+// Without selection, it's the owner, because the user owns the data of the personal ledger
+func GetLedgerRole(ctx context.Context) domain.LedgerRoleType {
+	ledger, ok := ctx.Value(contextKeyLedger).(*domain.Ledger)
+	if !ok {
+		return domain.LedgerRoleTypeOwner
+	}
+
+	return ledger.Role
+}
+
+// SetDeferRoleCheck marks in the request context that the usecase of the route checks the role of the ledger member
+func SetDeferRoleCheck(r *http.Request) *http.Request {
+	ctx := context.WithValue(r.Context(), contextKeyDeferRoleCheck, true)
+	return r.WithContext(ctx)
+}
+
+// IsRoleCheckDeferred checks if the usecase of the route checks the role of the ledger member
+func IsRoleCheckDeferred(r *http.Request) bool {
+	deferred, _ := r.Context().Value(contextKeyDeferRoleCheck).(bool)
+	return deferred
+}
+
+// GetLedgerUser retrieves the user owning the data of the selected ledger.
+// Without selection, it's the user themself, who owns the data of the personal ledger
+func GetLedgerUser(r *http.Request) *domain.User {
+	user := GetUser(r)
+
+	ledger, ok := r.Context().Value(contextKeyLedger).(*domain.Ledger)
+	if !ok {
+		return user
+	}
+
+	// the name and email are kept, so that the member is still known
+	ledgerUser := *user
+	ledgerUser.ID = ledger.UserID
+	return &ledgerUser
+}
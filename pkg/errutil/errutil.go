@@ -22,6 +22,16 @@ func AuthenticationErrorResponse(w http.ResponseWriter, r *http.Request, err err
 	errorResponse(w, r, http.StatusUnauthorized, err.Error())
 }
 
+// ForbiddenResponse is a helper function for returning a 403 Forbidden response
+func ForbiddenResponse(w http.ResponseWriter, r *http.Request, err error) {
+	errorResponse(w, r, http.StatusForbidden, err.Error())
+}
+
+// ConflictResponse is a helper function for returning a 409 Conflict response
+func ConflictResponse(w http.ResponseWriter, r *http.Request, err error) {
+	errorResponse(w, r, http.StatusConflict, err.Error())
+}
+
 // VildateErrorResponse is a helper function for returning a 400 Bad Request response,
 // and sending the validation error message in JSON format
 func VildateErrorResponse(w http.ResponseWriter, r *http.Request, err map[string]string) {
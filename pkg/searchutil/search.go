@@ -0,0 +1,332 @@
+package searchutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+const (
+	// searchQueryMaxTerms is the max number of terms in a search query
+	searchQueryMaxTerms = 20
+
+	// searchRangeSep separates the start and end of a range, e.g. date:2024-01..2024-03
+	searchRangeSep = ".."
+)
+
+var (
+	searchTransTypes = map[string]domain.TransactionType{
+		"income":   domain.TransactionTypeIncome,
+		"expense":  domain.TransactionTypeExpense,
+		"transfer": domain.TransactionTypeTransfer,
+	}
+)
+
+// ParseQuery parses the search query language of getting transactions, e.g.
+//
+//	note:"coffee" category:food price>20 date:2024-01..2024-03 -tag:work
+//
+// Terms are separated by spaces, and all of them must match. A term is either a word or "quoted phrase"
+// matched against the note, or a field with an operator and a value.
+// The fields are note, category, tag, type, price and date, and a leading "-" negates
+// the term of note, category, tag and type.
+// Price and date compare with ":", "=", ">", ">=", "<", "<=", and ":" also accepts a range "start..end",
+// where either side can be left out.
+// The date is YYYY, YYYY-MM or YYYY-MM-DD, and covers the whole year, month or day.
+func ParseQuery(raw string) (domain.SearchQuery, error) {
+	tokens, err := tokenizeSearchQuery(raw)
+	if err != nil {
+		return domain.SearchQuery{}, err
+	}
+
+	if len(tokens) == 0 {
+		return domain.SearchQuery{}, errors.New("query can't be empty")
+	}
+
+	if len(tokens) > searchQueryMaxTerms {
+		return domain.SearchQuery{}, fmt.Errorf("query can't have more than %d terms", searchQueryMaxTerms)
+	}
+
+	q := domain.SearchQuery{Raw: strings.TrimSpace(raw)}
+	for _, tok := range tokens {
+		if err := parseSearchTerm(tok, &q); err != nil {
+			return domain.SearchQuery{}, err
+		}
+	}
+
+	return q, nil
+}
+
+// searchToken is a term of search query before parsing its field
+type searchToken struct {
+	raw     string
+	negated bool
+
+	// field and op are empty when the term is a word or phrase
+	field string
+	op    string
+	value string
+}
+
+// tokenizeSearchQuery splits the query into terms by spaces, except the spaces in quotes
+func tokenizeSearchQuery(raw string) ([]searchToken, error) {
+	var (
+		tokens  []searchToken
+		sb      strings.Builder
+		inQuote bool
+	)
+
+	flush := func() {
+		if sb.Len() > 0 {
+			tokens = append(tokens, searchToken{raw: sb.String()})
+			sb.Reset()
+		}
+	}
+
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			sb.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, errors.New("query has an unclosed quote")
+	}
+	flush()
+
+	for i := range tokens {
+		splitSearchToken(&tokens[i])
+	}
+
+	return tokens, nil
+}
+
+// splitSearchToken splits the raw term into negation, field, operator and value
+func splitSearchToken(t *searchToken) {
+	s := t.raw
+	if len(s) > 1 && s[0] == '-' {
+		t.negated = true
+		s = s[1:]
+	}
+
+	// the field is the letters before the first operator, and a quoted term doesn't have a field
+	i := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+	if i <= 0 || !strings.ContainsRune(":=<>", rune(s[i])) {
+		t.value = unquote(s)
+		return
+	}
+
+	t.field = strings.ToLower(s[:i])
+	t.op = s[i : i+1]
+	if (s[i] == '<' || s[i] == '>') && i+1 < len(s) && s[i+1] == '=' {
+		t.op = s[i : i+2]
+	}
+	t.value = unquote(s[i+len(t.op):])
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+
+	return strings.ReplaceAll(s, `"`, "")
+}
+
+func parseSearchTerm(t searchToken, q *domain.SearchQuery) error {
+	if t.value == "" {
+		return fmt.Errorf("%q must have a value", t.raw)
+	}
+
+	if t.field == "" {
+		q.Terms = append(q.Terms, domain.SearchTerm{Value: t.value, Negated: t.negated})
+		return nil
+	}
+
+	switch t.field {
+	case "note", "category", "tag", "type":
+		if t.op != ":" {
+			return fmt.Errorf("%q must use \":\", e.g. %s:value", t.raw, t.field)
+		}
+	case "price", "date":
+		if t.negated {
+			return fmt.Errorf("%q can't be negated, use the opposite operator instead", t.raw)
+		}
+	default:
+		return fmt.Errorf("unknown field %q in %q, use note, category, tag, type, price or date", t.field, t.raw)
+	}
+
+	term := domain.SearchTerm{Value: t.value, Negated: t.negated}
+	switch t.field {
+	case "note":
+		q.Terms = append(q.Terms, term)
+	case "category":
+		q.Categs = append(q.Categs, term)
+	case "tag":
+		q.Tags = append(q.Tags, term)
+	case "type":
+		transType, ok := searchTransTypes[strings.ToLower(t.value)]
+		if !ok {
+			return fmt.Errorf("%q must be type:income, type:expense or type:transfer", t.raw)
+		}
+		q.Types = append(q.Types, domain.SearchTypeCond{Type: transType, Negated: t.negated})
+	case "price":
+		conds, err := parsePriceConds(t)
+		if err != nil {
+			return err
+		}
+		q.Prices = append(q.Prices, conds...)
+	case "date":
+		conds, err := parseDateConds(t)
+		if err != nil {
+			return err
+		}
+		q.Dates = append(q.Dates, conds...)
+	}
+
+	return nil
+}
+
+func parsePriceConds(t searchToken) ([]domain.SearchPriceCond, error) {
+	parse := func(s string) (float64, error) {
+		price, err := strconv.ParseFloat(s, 64)
+		if err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+			return 0, fmt.Errorf("%q must have a price which is a non-negative number", t.raw)
+		}
+		return price, nil
+	}
+
+	op := domain.CvtToCmpOpType(t.op)
+	start, end, isRange := strings.Cut(t.value, searchRangeSep)
+	if !isRange {
+		price, err := parse(t.value)
+		if err != nil {
+			return nil, err
+		}
+		return []domain.SearchPriceCond{{Op: op, Value: price}}, nil
+	}
+
+	if t.op != ":" {
+		return nil, fmt.Errorf("%q must use \":\" with a range", t.raw)
+	}
+	if start == "" && end == "" {
+		return nil, fmt.Errorf("%q must have the start or end of the range", t.raw)
+	}
+
+	var conds []domain.SearchPriceCond
+	if start != "" {
+		price, err := parse(start)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, domain.SearchPriceCond{Op: domain.CmpOpTypeGte, Value: price})
+	}
+	if end != "" {
+		price, err := parse(end)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, domain.SearchPriceCond{Op: domain.CmpOpTypeLte, Value: price})
+	}
+
+	if len(conds) == 2 && conds[0].Value > conds[1].Value {
+		return nil, fmt.Errorf("%q must have the start of the range less than or equal to the end", t.raw)
+	}
+
+	return conds, nil
+}
+
+// parseDateConds converts the date term into conditions on [start, end) of the periods,
+// e.g. date:2024-01..2024-03 is date >= 2024-01-01 and date < 2024-04-01
+func parseDateConds(t searchToken) ([]domain.SearchDateCond, error) {
+	parse := func(s string) (time.Time, time.Time, error) {
+		start, end, ok := parseSearchPeriod(s)
+		if !ok {
+			return time.Time{}, time.Time{}, fmt.Errorf("%q must have a date in YYYY, YYYY-MM or YYYY-MM-DD format", t.raw)
+		}
+		return start, end, nil
+	}
+
+	rawStart, rawEnd, isRange := strings.Cut(t.value, searchRangeSep)
+	if !isRange {
+		start, end, err := parse(t.value)
+		if err != nil {
+			return nil, err
+		}
+
+		switch t.op {
+		case ">":
+			return []domain.SearchDateCond{{Op: domain.CmpOpTypeGte, Value: end}}, nil
+		case ">=":
+			return []domain.SearchDateCond{{Op: domain.CmpOpTypeGte, Value: start}}, nil
+		case "<":
+			return []domain.SearchDateCond{{Op: domain.CmpOpTypeLt, Value: start}}, nil
+		case "<=":
+			return []domain.SearchDateCond{{Op: domain.CmpOpTypeLt, Value: end}}, nil
+		}
+
+		// ":" and "=" are the whole period
+		return []domain.SearchDateCond{{Op: domain.CmpOpTypeGte, Value: start}, {Op: domain.CmpOpTypeLt, Value: end}}, nil
+	}
+
+	if t.op != ":" {
+		return nil, fmt.Errorf("%q must use \":\" with a range", t.raw)
+	}
+	if rawStart == "" && rawEnd == "" {
+		return nil, fmt.Errorf("%q must have the start or end of the range", t.raw)
+	}
+
+	var (
+		conds      []domain.SearchDateCond
+		start, end time.Time
+	)
+	if rawStart != "" {
+		s, _, err := parse(rawStart)
+		if err != nil {
+			return nil, err
+		}
+		start = s
+		conds = append(conds, domain.SearchDateCond{Op: domain.CmpOpTypeGte, Value: start})
+	}
+	if rawEnd != "" {
+		_, e, err := parse(rawEnd)
+		if err != nil {
+			return nil, err
+		}
+		end = e
+		conds = append(conds, domain.SearchDateCond{Op: domain.CmpOpTypeLt, Value: end})
+	}
+
+	if len(conds) == 2 && !start.Before(end) {
+		return nil, fmt.Errorf("%q must have the start of the range before the end", t.raw)
+	}
+
+	return conds, nil
+}
+
+// parseSearchPeriod returns the first day of the year, month or day, and the first day after it
+func parseSearchPeriod(s string) (time.Time, time.Time, bool) {
+	if d, err := time.Parse(time.DateOnly, s); err == nil {
+		return d, d.AddDate(0, 0, 1), true
+	}
+
+	if d, err := time.Parse("2006-01", s); err == nil {
+		return d, d.AddDate(0, 1, 0), true
+	}
+
+	if d, err := time.Parse("2006", s); err == nil {
+		return d, d.AddDate(1, 0, 0), true
+	}
+
+	return time.Time{}, time.Time{}, false
+}
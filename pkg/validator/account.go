@@ -0,0 +1,22 @@
+package validator
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+// CreateAccount validates the input for creating account.
+func (v *Validator) CreateAccount(a domain.Account) bool {
+	v.checkAccount(a)
+	return v.Valid()
+}
+
+// UpdateAccount validates the input for updating account.
+func (v *Validator) UpdateAccount(a domain.Account) bool {
+	v.Check(a.ID > 0, "id", "ID must be greater than 0")
+	v.checkAccount(a)
+	return v.Valid()
+}
+
+func (v *Validator) checkAccount(a domain.Account) {
+	v.Check(len(a.Name) > 0, "name", "Name can't be empty")
+	v.Check(len(a.Name) <= 50, "name", "Name can't be longer than 50 characters")
+	v.Check(a.Type.IsValid(), "type", "Type must be cash, bank or credit_card")
+}
@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+// CreateAPIKey validates the input for creating API key.
+// The expiry is optional, but it must be in the future when it's set.
+func (v *Validator) CreateAPIKey(key domain.APIKey) bool {
+	v.Check(len(key.Name) > 0, "name", "Name can't be empty")
+	v.Check(len(key.Name) <= 50, "name", "Name can't be longer than 50 characters")
+	v.Check(len(key.Scopes) > 0, "scopes", "At least one scope is required")
+
+	seen := make(map[string]bool, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		k := fmt.Sprintf("scopes[%d]", i)
+		v.Check(slices.Contains(domain.APIKeyScopes, scope), k, "Scope must be transactions:read, transactions:write, charts:read or categories:read")
+		v.Check(!seen[scope], k, "Scopes can't be duplicated")
+		seen[scope] = true
+	}
+
+	if key.ExpiresAt != nil {
+		v.Check(key.ExpiresAt.After(time.Now()), "expires_at", "Expires at must be in the future")
+	}
+
+	return v.Valid()
+}
@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var (
+	attachmentExts = []string{".jpg", ".jpeg", ".png", ".heic", ".webp", ".pdf"}
+)
+
+// GetAttachmentPutURL validates the input for getting the URL to upload attachment.
+func (v *Validator) GetAttachmentPutURL(id int64, fileName string) bool {
+	v.Check(id > 0, "id", "ID must be greater than 0")
+	v.checkAttachmentFileName(fileName)
+	return v.Valid()
+}
+
+// CreateAttachment validates the input for creating attachment.
+func (v *Validator) CreateAttachment(id int64, fileName, objectKey string) bool {
+	v.Check(id > 0, "id", "ID must be greater than 0")
+	v.checkAttachmentFileName(fileName)
+	v.Check(len(objectKey) > 0, "object_key", "Object key can't be empty")
+	return v.Valid()
+}
+
+// DeleteAttachment validates the input for deleting attachment.
+func (v *Validator) DeleteAttachment(id, attachmentID int64) bool {
+	v.Check(id > 0, "id", "ID must be greater than 0")
+	v.Check(attachmentID > 0, "attachment_id", "Attachment ID must be greater than 0")
+	return v.Valid()
+}
+
+// checkAttachmentFileName checks the file name is a receipt image or PDF, and it's a part of the object key
+func (v *Validator) checkAttachmentFileName(fileName string) {
+	v.Check(len(fileName) > 0, "file_name", "File name can't be empty")
+	v.Check(len(fileName) <= 100, "file_name", "File name can't be longer than 100 characters")
+	v.Check(!strings.ContainsAny(fileName, `/\`), "file_name", "File name can't contain slash")
+	v.Check(slices.Contains(attachmentExts, strings.ToLower(filepath.Ext(fileName))), "file_name", "File must be jpg, jpeg, png, heic, webp or pdf")
+}
@@ -0,0 +1,10 @@
+package validator
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+// SetBudget validates the input for setting budget.
+func (v *Validator) SetBudget(b domain.Budget) bool {
+	v.Check(b.MainCateg.ID > 0, "main_category_id", "Main category ID must be greater than 0")
+	v.Check(b.Amount > 0, "amount", "Amount must be greater than 0")
+	return v.Valid()
+}
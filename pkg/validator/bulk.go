@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+const (
+	// bulkMaxOperations is the max number of transactions in each operation of a bulk request
+	bulkMaxOperations = 500
+)
+
+// BulkTransaction validates the input for bulk operations on transactions.
+func (v *Validator) BulkTransaction(input domain.BulkTransInput) bool {
+	v.Check(len(input.Create) > 0 || len(input.Update) > 0 || len(input.Delete.IDs) > 0 || input.Delete.Opt != nil, "bulk", "At least one operation is required")
+	v.Check(len(input.Create) <= bulkMaxOperations, "create", fmt.Sprintf("Create can't have more than %d transactions", bulkMaxOperations))
+	v.Check(len(input.Update) <= bulkMaxOperations, "update", fmt.Sprintf("Update can't have more than %d transactions", bulkMaxOperations))
+	v.Check(len(input.Delete.IDs) <= bulkMaxOperations, "delete.ids", fmt.Sprintf("Delete can't have more than %d transactions", bulkMaxOperations))
+
+	for i, t := range input.Create {
+		// each created transaction is validated the same as creating it alone
+		sub := New()
+		if sub.CreateTransaction(t) {
+			continue
+		}
+
+		for key, msg := range sub.Error {
+			v.AddError(fmt.Sprintf("create[%d].%s", i, key), msg)
+		}
+	}
+
+	updateIDs := make(map[int64]bool, len(input.Update))
+	for i, t := range input.Update {
+		key := fmt.Sprintf("update[%d]", i)
+		v.Check(t.ID > 0, key+".id", "ID must be greater than 0")
+		v.Check(!updateIDs[t.ID], key+".id", "ID can't be updated twice")
+		v.Check(t.MainCategID != nil || t.SubCategID != nil || t.Note != nil || t.Date != nil || len(t.AddTagIDs) > 0, key, "At least one field is required")
+		v.Check((t.MainCategID == nil) == (t.SubCategID == nil), key+".main_category_id", "Main category ID and sub category ID must be updated together")
+		if t.MainCategID != nil && t.SubCategID != nil {
+			v.Check(*t.MainCategID > 0, key+".main_category_id", "Main category ID must be greater than 0")
+			v.Check(*t.SubCategID > 0, key+".sub_category_id", "Sub category ID must be greater than 0")
+		}
+		if t.Date != nil {
+			v.Check(!t.Date.IsZero(), key+".date", "Date can't be empty")
+		}
+		seen := make(map[int64]bool, len(t.AddTagIDs))
+		for _, id := range t.AddTagIDs {
+			v.Check(id > 0, key+".add_tag_ids", "Tag ID must be greater than 0")
+			v.Check(!seen[id], key+".add_tag_ids", "Tag IDs can't be duplicated")
+			seen[id] = true
+		}
+
+		updateIDs[t.ID] = true
+	}
+
+	v.Check(len(input.Delete.IDs) == 0 || input.Delete.Opt == nil, "delete", "Delete by either IDs or filter, not both")
+	deleteIDs := make(map[int64]bool, len(input.Delete.IDs))
+	for i, id := range input.Delete.IDs {
+		key := fmt.Sprintf("delete.ids[%d]", i)
+		v.Check(id > 0, key, "ID must be greater than 0")
+		v.Check(!deleteIDs[id], key, "ID can't be deleted twice")
+		v.Check(!updateIDs[id], key, "ID can't be updated and deleted")
+		deleteIDs[id] = true
+	}
+
+	if input.Delete.Opt != nil {
+		v.Check(isFilterSet(*input.Delete.Opt), "delete.filter", "Filter must have at least one condition")
+		v.GetTransaction(*input.Delete.Opt)
+	}
+
+	return v.Valid()
+}
+
+// isFilterSet returns true if the option narrows down the transactions,
+// so that deleting by filter never deletes all transactions by accident
+func isFilterSet(o domain.GetTransOpt) bool {
+	f := o.Filter
+	return (o.Search.Keyword != nil && *o.Search.Keyword != "") || o.Search.Query != nil ||
+		f.StartDate != nil || f.EndDate != nil ||
+		f.MinPrice != nil || f.MaxPrice != nil ||
+		len(f.MainCategIDs) > 0 || len(f.SubCategIDs) > 0 || len(f.TagIDs) > 0
+}
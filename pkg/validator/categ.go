@@ -29,3 +29,50 @@ func (v *Validator) UpdateSubCateg(categ domain.SubCateg) bool {
 	v.Check(categ.MainCategID > 0, "main_category_id", "Main category ID must be greater than 0")
 	return v.Valid()
 }
+
+func (v *Validator) MergeMainCateg(id, targetID int64) bool {
+	v.Check(targetID > 0, "target", "Target main category ID must be greater than 0")
+	v.Check(id != targetID, "target", "Main category can't be merged into itself")
+	return v.Valid()
+}
+
+func (v *Validator) MoveSubCateg(mainCategID int64) bool {
+	v.Check(mainCategID > 0, "main_category_id", "Main category ID must be greater than 0")
+	return v.Valid()
+}
+
+func (v *Validator) SetCategArchived(archived *bool) bool {
+	v.Check(archived != nil, "archived", "Archived is required")
+	return v.Valid()
+}
+
+func (v *Validator) ReorderCategs(ids []int64) bool {
+	v.Check(len(ids) > 0, "ids", "IDs can't be empty")
+	v.checkDuplicateIDs(ids)
+	return v.Valid()
+}
+
+func (v *Validator) ImportCategTemplate(mode domain.ImportModeType, data domain.InitData) bool {
+	v.Check(mode.IsValid(), "mode", "Mode must be merge or replace")
+	v.Check(len(data.Expense)+len(data.Income) > 0, "template", "Template must have at least one main category")
+	v.checkTemplateCategs(data.Expense)
+	v.checkTemplateCategs(data.Income)
+	return v.Valid()
+}
+
+// checkTemplateCategs checks the names of the main categories of the same type, and the names of their sub categories
+func (v *Validator) checkTemplateCategs(categs []domain.InitDataMainCateg) {
+	mainNames := make(map[string]bool, len(categs))
+	for _, c := range categs {
+		v.Check(len(c.Name) > 0, "template", "Name can't be empty")
+		v.Check(!mainNames[c.Name], "template", "Main category names must be unique in the same type")
+		mainNames[c.Name] = true
+
+		subNames := make(map[string]bool, len(c.SubCategs))
+		for _, name := range c.SubCategs {
+			v.Check(len(name) > 0, "template", "Name can't be empty")
+			v.Check(!subNames[name], "template", "Sub category names must be unique in the same main category")
+			subNames[name] = true
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+// ImportExchangeRates validates the exchange rates for importing.
+func (v *Validator) ImportExchangeRates(rates []domain.ExchangeRate) bool {
+	v.Check(len(rates) > 0, "rates", "Rates can't be empty")
+	for i, r := range rates {
+		key := fmt.Sprintf("rates[%d]", i)
+		v.Check(Matches(r.From, CurrencyRX), key+".from", "From must be a 3-letter currency code")
+		v.Check(Matches(r.To, CurrencyRX), key+".to", "To must be a 3-letter currency code")
+		v.Check(r.From != r.To, key+".to", "To must be different from from")
+		v.Check(!r.Date.IsZero(), key+".date", "Date must be in YYYY-MM-DD format")
+		v.Check(r.Rate > 0, key+".rate", "Rate must be greater than 0")
+	}
+	return v.Valid()
+}
+
+// GetExchangeRates validates the currency pair for getting exchange rates.
+func (v *Validator) GetExchangeRates(from, to string) bool {
+	v.Check(Matches(from, CurrencyRX), "from", "From must be a 3-letter currency code")
+	v.Check(Matches(to, CurrencyRX), "to", "To must be a 3-letter currency code")
+	return v.Valid()
+}
+
+// UpdateBaseCurrency validates the base currency of user.
+func (v *Validator) UpdateBaseCurrency(currency string) bool {
+	v.Check(Matches(currency, CurrencyRX), "base_currency", "Base currency must be a 3-letter currency code")
+	return v.Valid()
+}
+
+// checkOptionalCurrency validates the currency when it's given.
+// Empty currency means the user's base currency.
+func (v *Validator) checkOptionalCurrency(currency string) {
+	if currency == "" {
+		return
+	}
+
+	v.Check(Matches(currency, CurrencyRX), "currency", "Currency must be a 3-letter currency code")
+}
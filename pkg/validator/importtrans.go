@@ -0,0 +1,20 @@
+package validator
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+// ImportTransMapping validates the column mapping of CSV import.
+// The main category column is optional, since the rows without category are categorized by rules.
+func (v *Validator) ImportTransMapping(m domain.ImportTransMapping) bool {
+	v.Check(m.Date != "", "date", "Date column is required")
+	v.Check(m.Amount != "", "amount", "Amount column is required")
+	return v.Valid()
+}
+
+// ImportTransRow validates a parsed row of CSV import.
+func (v *Validator) ImportTransRow(row domain.ImportTransRow) bool {
+	v.Check(row.Type.IsValid(), "type", "Type must be income or expense")
+	v.Check(row.Price > 0, "price", "Price must be greater than 0")
+	v.Check(!row.Date.IsZero(), "date", "Date can't be empty")
+	v.checkOptionalCurrency(row.Currency)
+	return v.Valid()
+}
@@ -0,0 +1,28 @@
+package validator
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+// CreateLedger validates the name for creating ledger
+func (v *Validator) CreateLedger(name string) bool {
+	v.checkName(name)
+	v.Check(len(name) <= 50, "name", "Name can't be longer than 50 characters")
+	return v.Valid()
+}
+
+// InviteLedgerMember validates the email and role for inviting a member
+func (v *Validator) InviteLedgerMember(email string, role domain.LedgerRoleType) bool {
+	v.checkEmail(email)
+	v.checkMemberRole(role)
+	return v.Valid()
+}
+
+// UpdateLedgerMemberRole validates the role for updating a member
+func (v *Validator) UpdateLedgerMemberRole(role domain.LedgerRoleType) bool {
+	v.checkMemberRole(role)
+	return v.Valid()
+}
+
+// checkMemberRole checks the role given to a member, a ledger only has one owner
+func (v *Validator) checkMemberRole(role domain.LedgerRoleType) {
+	v.Check(role == domain.LedgerRoleTypeEditor || role == domain.LedgerRoleTypeViewer, "role", "Role must be editor or viewer")
+}
@@ -0,0 +1,35 @@
+package validator
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+// CreateRecurringTrans validates the input for creating recurring transaction.
+func (v *Validator) CreateRecurringTrans(rt domain.RecurringTrans) bool {
+	v.checkRecurringTemplate(rt.Template)
+	v.checkRecurringSchedule(rt.Schedule)
+	return v.Valid()
+}
+
+// UpdateRecurringTrans validates the input for updating recurring transaction.
+func (v *Validator) UpdateRecurringTrans(input domain.UpdateRecurringTransInput) bool {
+	v.Check(input.ID > 0, "id", "ID must be greater than 0")
+	v.checkRecurringTemplate(input.Template)
+	v.checkRecurringSchedule(input.Schedule)
+	return v.Valid()
+}
+
+func (v *Validator) checkRecurringTemplate(t domain.CreateTransactionInput) {
+	v.Check(t.MainCategID > 0, "main_category_id", "Main category ID must be greater than 0")
+	v.Check(t.SubCategID > 0, "sub_category_id", "Sub category ID must be greater than 0")
+	v.Check(t.Price > 0, "price", "Price must be greater than 0")
+	v.Check(t.Type.IsValid(), "type", "Type must be income or expense")
+	v.checkOptionalCurrency(t.Currency)
+}
+
+func (v *Validator) checkRecurringSchedule(s domain.RecurringSchedule) {
+	v.Check(s.Freq.IsValid(), "frequency", "Frequency must be daily, weekly, monthly or yearly")
+	v.Check(s.Interval > 0, "interval", "Interval must be greater than 0")
+	v.Check(!s.StartDate.IsZero(), "start_date", "Start date can't be empty")
+	if s.EndDate != nil {
+		v.Check(!s.EndDate.Before(s.StartDate), "end_date", "End date must be after start date")
+	}
+}
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"regexp"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+// CreateRule validates the input for creating rule.
+func (v *Validator) CreateRule(r domain.Rule) bool {
+	v.checkRule(r)
+	return v.Valid()
+}
+
+// UpdateRule validates the input for updating rule.
+func (v *Validator) UpdateRule(r domain.Rule) bool {
+	v.Check(r.ID > 0, "id", "ID must be greater than 0")
+	v.checkRule(r)
+	return v.Valid()
+}
+
+// RerunRules validates the input for re-running rules.
+func (v *Validator) RerunRules(opt domain.RuleRerunOpt) bool {
+	if opt.StartDate != nil && opt.EndDate != nil {
+		v.Check(checkStartDateBeforeEndDateTime(*opt.StartDate, *opt.EndDate), "start_date", "Start date must be before end date")
+	}
+
+	return v.Valid()
+}
+
+func (v *Validator) checkRule(r domain.Rule) {
+	v.Check(len(r.Name) > 0, "name", "Name can't be empty")
+	v.Check(len(r.Name) <= 50, "name", "Name can't be longer than 50 characters")
+	v.Check(r.Priority >= 0, "priority", "Priority can't be negative")
+	v.Check(r.Type == domain.TransactionTypeIncome || r.Type == domain.TransactionTypeExpense, "type", "Type must be income or expense")
+	v.Check(r.MainCateg.ID > 0, "main_category_id", "Main category ID must be greater than 0")
+	v.Check(r.SubCateg.ID > 0, "sub_category_id", "Sub category ID must be greater than 0")
+
+	// the rule without condition would match every transaction of the type
+	v.Check(r.NoteContains != "" || r.NoteRegex != "" || r.MinPrice != nil || r.MaxPrice != nil, "rule", "At least one condition is required")
+	v.Check(len(r.NoteContains) <= 255, "note_contains", "Note contains can't be longer than 255 characters")
+	v.Check(len(r.NoteRegex) <= 255, "note_regex", "Note regex can't be longer than 255 characters")
+	if r.NoteRegex != "" {
+		_, err := regexp.Compile(r.NoteRegex)
+		v.Check(err == nil, "note_regex", "Note regex is invalid")
+	}
+	if r.MinPrice != nil {
+		v.Check(*r.MinPrice >= 0, "min_price", "Min price can't be negative")
+	}
+	if r.MinPrice != nil && r.MaxPrice != nil {
+		v.Check(*r.MaxPrice >= *r.MinPrice, "max_price", "Max price must be greater than or equal to min price")
+	}
+
+	v.checkTagIDs(r.TagIDs())
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/eyo-chen/expense-tracker-go/internal/domain"
+)
+
+// CreateContact validates the input for creating contact.
+func (v *Validator) CreateContact(c domain.Contact) bool {
+	v.checkContact(c)
+	return v.Valid()
+}
+
+// UpdateContact validates the input for updating contact.
+func (v *Validator) UpdateContact(c domain.Contact) bool {
+	v.Check(c.ID > 0, "id", "ID must be greater than 0")
+	v.checkContact(c)
+	return v.Valid()
+}
+
+// UpdateShares validates the shares of a transaction.
+// The user takes part as the participant with contact id 0, and each participant appears once.
+// The exact amounts are checked against the price by the usecase, because the price isn't given.
+func (v *Validator) UpdateShares(t domain.SharedTransaction) bool {
+	v.Check(t.TransactionID > 0, "id", "ID must be greater than 0")
+	v.Check(t.Method.IsValid(), "method", "Method must be equal, exact, percent or shares")
+	v.Check(t.PaidBy >= 0, "paid_by", "Paid by can't be negative")
+	v.Check(len(t.Shares) >= 2, "shares", "Shares must have at least 2 participants")
+
+	seen := make(map[int64]bool, len(t.Shares))
+	var sum float64
+	for i, s := range t.Shares {
+		key := fmt.Sprintf("shares[%d]", i)
+		v.Check(s.ContactID >= 0, key+".contact_id", "Contact ID can't be negative")
+		v.Check(!seen[s.ContactID], key+".contact_id", "Participants can't be duplicated")
+		seen[s.ContactID] = true
+
+		switch t.Method {
+		case domain.ShareMethodTypeExact, domain.ShareMethodTypePercent:
+			v.Check(s.Value >= 0, key+".value", "Value can't be negative")
+		case domain.ShareMethodTypeShares:
+			v.Check(s.Value > 0, key+".value", "Value must be greater than 0")
+		}
+		sum += s.Value
+	}
+
+	if t.Method == domain.ShareMethodTypePercent {
+		// compare in hundredths to avoid floating point errors
+		v.Check(math.Round(sum*100) == 10000, "shares", "Sum of the percentages must equal 100")
+	}
+	return v.Valid()
+}
+
+// CreateSettlement validates the input for creating settlement.
+func (v *Validator) CreateSettlement(s domain.Settlement) bool {
+	v.Check(s.FromContactID >= 0, "from_contact_id", "From contact ID can't be negative")
+	v.Check(s.ToContactID >= 0, "to_contact_id", "To contact ID can't be negative")
+	v.Check(s.FromContactID != s.ToContactID, "to_contact_id", "To contact ID must be different from from contact ID")
+	v.Check(s.Amount > 0, "amount", "Amount must be greater than 0")
+	v.Check(!s.Date.IsZero(), "date", "Date can't be empty")
+	v.Check(len(s.Note) <= 255, "note", "Note can't be longer than 255 characters")
+	v.checkOptionalCurrency(s.Currency)
+	return v.Valid()
+}
+
+func (v *Validator) checkContact(c domain.Contact) {
+	v.Check(len(c.Name) > 0, "name", "Name can't be empty")
+	v.Check(len(c.Name) <= 50, "name", "Name can't be longer than 50 characters")
+	if c.Email != "" {
+		v.checkEmail(c.Email)
+	}
+}
@@ -0,0 +1,21 @@
+package validator
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+// CreateTag validates the input for creating tag.
+func (v *Validator) CreateTag(t domain.Tag) bool {
+	v.checkTag(t)
+	return v.Valid()
+}
+
+// UpdateTag validates the input for updating tag.
+func (v *Validator) UpdateTag(t domain.Tag) bool {
+	v.Check(t.ID > 0, "id", "ID must be greater than 0")
+	v.checkTag(t)
+	return v.Valid()
+}
+
+func (v *Validator) checkTag(t domain.Tag) {
+	v.Check(len(t.Name) > 0, "name", "Name can't be empty")
+	v.Check(len(t.Name) <= 50, "name", "Name can't be longer than 50 characters")
+}
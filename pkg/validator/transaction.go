@@ -1,6 +1,9 @@
 package validator
 
 import (
+	"fmt"
+	"math"
+	"slices"
 	"time"
 
 	"github.com/eyo-chen/expense-tracker-go/internal/domain"
@@ -8,11 +11,12 @@ import (
 
 // CreateMainCateg validates the input for creating main category.
 func (v *Validator) CreateTransaction(t domain.CreateTransactionInput) bool {
-	v.Check(t.MainCategID > 0, "main_category_id", "Main category ID must be greater than 0")
-	v.Check(t.SubCategID > 0, "sub_category_id", "Sub category ID must be greater than 0")
+	v.checkTransTypeAndRefs(t.Type, t.MainCategID, t.SubCategID, t.AccountID, t.ToAccountID, len(t.Splits) > 0, t.IsUncategorized())
 	v.Check(t.Price > 0, "price", "Price must be greater than 0")
-	v.Check(t.Type.IsValid(), "type", "Type must be income or expense")
+	v.checkSplits(t.Price, t.Splits)
+	v.checkTagIDs(t.TagIDs)
 	v.Check(!t.Date.IsZero(), "date", "Date can't be empty")
+	v.checkOptionalCurrency(t.Currency)
 	return v.Valid()
 }
 
@@ -22,26 +26,81 @@ func (v *Validator) GetTransaction(o domain.GetTransOpt) bool {
 		v.Check(checkStartDateBeforeEndDateTime(*o.Filter.StartDate, *o.Filter.EndDate), "startDate", "Start date must be before end date")
 	}
 
+	v.Check(o.Cursor.NextKey == "" || o.Cursor.PrevKey == "", "cursor", "Next key and previous key can't be used together")
+
+	// the view replaces the filter, sort and search, so they can't be given along with it
+	if o.ViewID != 0 {
+		v.Check(o.ViewID > 0, "view", "View ID must be greater than 0")
+		v.Check(!isFilterSet(o) && o.Sort == nil, "view", "View can't be combined with filter, sort or search")
+	}
+
 	return v.Valid()
 }
 
 // UpdateTransaction validates the input for updating transaction.
 func (v *Validator) UpdateTransaction(t domain.UpdateTransactionInput) bool {
 	v.Check(t.ID > 0, "id", "ID must be greater than 0")
-	v.Check(t.MainCategID > 0, "main_category_id", "Main category ID must be greater than 0")
-	v.Check(t.SubCategID > 0, "sub_category_id", "Sub category ID must be greater than 0")
+	v.checkTransTypeAndRefs(t.Type, t.MainCategID, t.SubCategID, t.AccountID, t.ToAccountID, len(t.Splits) > 0, false)
 	v.Check(t.Price > 0, "price", "Price must be greater than 0")
-	v.Check(t.Type.IsValid(), "type", "Type must be income or expense")
+	v.checkSplits(t.Price, t.Splits)
+	v.checkTagIDs(t.TagIDs)
 	v.Check(!t.Date.IsZero(), "date", "Date can't be empty")
+	v.checkOptionalCurrency(t.Currency)
+	return v.Valid()
+}
+
+// GetDuplicates validates the input for getting duplicate transactions.
+func (v *Validator) GetDuplicates(opt domain.GetDuplicatesOpt) bool {
+	v.Check(opt.Days >= 0 && opt.Days <= 30, "days", "Days must be between 0 and 30")
+	v.Check(checkStartDateBeforeEndDateTime(opt.StartDate, opt.EndDate), "start_date", "start date must be before end date")
+	v.Check(!opt.EndDate.After(opt.StartDate.AddDate(1, 0, 0)), "end_date", "Date range can't be longer than a year")
+	return v.Valid()
+}
+
+// MergeDuplicates validates the input for merging duplicate transactions.
+func (v *Validator) MergeDuplicates(keepID int64, ids []int64) bool {
+	v.Check(keepID > 0, "keep_id", "Keep ID must be greater than 0")
+	v.Check(len(ids) > 0, "ids", "IDs can't be empty")
+	v.checkDuplicateIDs(ids)
+	v.Check(!slices.Contains(ids, keepID), "ids", "IDs can't contain the kept ID")
+	return v.Valid()
+}
+
+// DismissDuplicates validates the input for dismissing duplicate transactions.
+func (v *Validator) DismissDuplicates(ids []int64) bool {
+	v.Check(len(ids) >= 2, "ids", "At least 2 IDs are required")
+	v.checkDuplicateIDs(ids)
 	return v.Valid()
 }
 
+func (v *Validator) checkDuplicateIDs(ids []int64) {
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		v.Check(id > 0, "ids", "ID must be greater than 0")
+		v.Check(!seen[id], "ids", "IDs can't be repeated")
+		seen[id] = true
+	}
+}
+
 // Delete validates the input for deleting transaction.
 func (v *Validator) Delete(id int64) bool {
 	v.Check(id > 0, "id", "ID must be greater than 0")
 	return v.Valid()
 }
 
+// GetTransHistory validates the input for getting the history of transaction.
+func (v *Validator) GetTransHistory(id int64) bool {
+	v.Check(id > 0, "id", "ID must be greater than 0")
+	return v.Valid()
+}
+
+// RevertTransaction validates the input for reverting transaction to a revision.
+func (v *Validator) RevertTransaction(id, revisionID int64) bool {
+	v.Check(id > 0, "id", "ID must be greater than 0")
+	v.Check(revisionID > 0, "revision_id", "Revision ID must be greater than 0")
+	return v.Valid()
+}
+
 // GetAccInfo validates the input for getting account info.
 func (v *Validator) GetAccInfo(q domain.GetAccInfoQuery) bool {
 	v.Check(isValidDateFormat(q.StartDate), "startDate", "Start date must be in YYYY-MM-DD format")
@@ -65,6 +124,13 @@ func (v *Validator) GetPieChartData(dateRange domain.ChartDateRange, transaction
 	return v.Valid()
 }
 
+// GetTagChartData validates the input for getting tag chart data.
+func (v *Validator) GetTagChartData(dateRange domain.ChartDateRange, transactionType domain.TransactionType) bool {
+	v.Check(checkStartDateBeforeEndDateTime(dateRange.Start, dateRange.End), "start_date", "start date must be before end date")
+	v.Check(transactionType.IsValid(), "type", "transaction type must be income or expense")
+	return v.Valid()
+}
+
 // GetLineChartData validates the input for getting line chart data.
 func (v *Validator) GetLineChartData(dateRange domain.ChartDateRange, timeRangeType domain.TimeRangeType) bool {
 	v.Check(checkStartDateBeforeEndDateTime(dateRange.Start, dateRange.End), "start_date", "start date must be before end date")
@@ -78,6 +144,59 @@ func (v *Validator) GetMonthlyData(dateRange domain.GetMonthlyDateRange) bool {
 	return v.Valid()
 }
 
+// checkTransTypeAndRefs checks the categories and accounts referenced by the transaction.
+// Transfer doesn't have categories, but must be between two different accounts.
+// Income and expense must have categories unless they are split or categorized by rules, and the account is optional.
+func (v *Validator) checkTransTypeAndRefs(t domain.TransactionType, mainCategID, subCategID, accountID, toAccountID int64, isSplit, byRules bool) {
+	if t == domain.TransactionTypeTransfer {
+		v.Check(accountID > 0, "account_id", "Account ID must be greater than 0")
+		v.Check(toAccountID > 0, "to_account_id", "To account ID must be greater than 0")
+		v.Check(accountID != toAccountID, "to_account_id", "To account ID must be different from account ID")
+		v.Check(!isSplit, "splits", "Transfer can't be split")
+		return
+	}
+
+	// the split transaction is categorized by its lines
+	if !isSplit && !byRules {
+		v.Check(mainCategID > 0, "main_category_id", "Main category ID must be greater than 0")
+		v.Check(subCategID > 0, "sub_category_id", "Sub category ID must be greater than 0")
+	}
+	v.Check(t.IsValid(), "type", "Type must be income, expense or transfer")
+	v.Check(accountID >= 0, "account_id", "Account ID can't be negative")
+	v.Check(toAccountID == 0, "to_account_id", "To account ID is only for transfer")
+}
+
+// checkSplits checks the lines of a split transaction, which must sum to the price
+func (v *Validator) checkSplits(price float64, splits []domain.SplitInput) {
+	if len(splits) == 0 {
+		return
+	}
+
+	v.Check(len(splits) >= 2, "splits", "Splits must have at least 2 lines")
+
+	var sum float64
+	for i, s := range splits {
+		key := fmt.Sprintf("splits[%d]", i)
+		v.Check(s.MainCategID > 0, key+".main_category_id", "Main category ID must be greater than 0")
+		v.Check(s.SubCategID > 0, key+".sub_category_id", "Sub category ID must be greater than 0")
+		v.Check(s.Price > 0, key+".price", "Price must be greater than 0")
+		sum += s.Price
+	}
+
+	// compare in cents to avoid floating point errors
+	v.Check(math.Round(sum*100) == math.Round(price*100), "splits", "Sum of the split prices must equal the price")
+}
+
+// checkTagIDs checks if the tag ids are valid and unique
+func (v *Validator) checkTagIDs(tagIDs []int64) {
+	seen := make(map[int64]bool, len(tagIDs))
+	for _, id := range tagIDs {
+		v.Check(id > 0, "tag_ids", "Tag ID must be greater than 0")
+		v.Check(!seen[id], "tag_ids", "Tag IDs can't be duplicated")
+		seen[id] = true
+	}
+}
+
 func isValidDateFormat(dateString *string) bool {
 	if dateString == nil {
 		return true
@@ -0,0 +1,10 @@
+package validator
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+// RestoreTrash validates the input for restoring an item from trash.
+func (v *Validator) RestoreTrash(kind domain.TrashKind, id int64) bool {
+	v.Check(kind.IsValid(), "kind", "Kind must be transaction, main-category or sub-category")
+	v.Check(id > 0, "id", "ID must be greater than 0")
+	return v.Valid()
+}
@@ -21,6 +21,29 @@ func (v *Validator) Token(refreshToken string) bool {
 	return v.Valid()
 }
 
+// RequestPasswordReset validates email for password reset request
+func (v *Validator) RequestPasswordReset(email string) bool {
+	v.checkEmail(email)
+	return v.Valid()
+}
+
+// ResetPassword validates token and new password for password reset
+func (v *Validator) ResetPassword(token, password string) bool {
+	v.checkOneTimeToken(token)
+	v.checkPassword(password)
+	return v.Valid()
+}
+
+// VerifyEmail validates token for email verification
+func (v *Validator) VerifyEmail(token string) bool {
+	v.checkOneTimeToken(token)
+	return v.Valid()
+}
+
+func (v *Validator) checkOneTimeToken(token string) {
+	v.Check(len(token) > 0, "token", "Token can't be empty")
+}
+
 func (v *Validator) checkRefreshToken(refreshToken string) {
 	v.Check(len(refreshToken) > 0, "refresh_token", "Refresh token can't be empty")
 }
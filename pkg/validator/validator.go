@@ -4,6 +4,9 @@ import "regexp"
 
 var (
 	EmailRX = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+\/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9-]+` + `(?:\.[a-zA-Z0-9-]+)*$`)
+
+	// CurrencyRX matches ISO 4217 currency codes, e.g. USD
+	CurrencyRX = regexp.MustCompile(`^[A-Z]{3}$`)
 )
 
 // Validator is a custom validator type which can hold a map of validation errors.
@@ -0,0 +1,43 @@
+package validator
+
+import "github.com/eyo-chen/expense-tracker-go/internal/domain"
+
+// CreateView validates the input for creating view.
+func (v *Validator) CreateView(view domain.View) bool {
+	v.checkView(view)
+	return v.Valid()
+}
+
+// UpdateView validates the input for updating view.
+func (v *Validator) UpdateView(view domain.View) bool {
+	v.Check(view.ID > 0, "id", "ID must be greater than 0")
+	v.checkView(view)
+	return v.Valid()
+}
+
+func (v *Validator) checkView(view domain.View) {
+	v.Check(len(view.Name) > 0, "name", "Name can't be empty")
+	v.Check(len(view.Name) <= 50, "name", "Name can't be longer than 50 characters")
+
+	// the view without filter, sort and search would be the same as getting all transactions
+	opt := view.Apply(domain.GetTransOpt{})
+	v.Check(isFilterSet(opt) || opt.Sort != nil, "view", "At least one filter, sort or search is required")
+
+	f := view.Filter
+	if f.StartDate != nil && f.EndDate != nil {
+		v.Check(checkStartDateBeforeEndDateTime(*f.StartDate, *f.EndDate), "start_date", "Start date must be before end date")
+	}
+	if f.MinPrice != nil {
+		v.Check(*f.MinPrice >= 0, "min_price", "Min price can't be negative")
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil {
+		v.Check(*f.MaxPrice >= *f.MinPrice, "max_price", "Max price must be greater than or equal to min price")
+	}
+	for _, id := range f.MainCategIDs {
+		v.Check(id > 0, "main_category_ids", "Main category ID must be greater than 0")
+	}
+	for _, id := range f.SubCategIDs {
+		v.Check(id > 0, "sub_category_ids", "Sub category ID must be greater than 0")
+	}
+	v.checkTagIDs(f.TagIDs)
+}